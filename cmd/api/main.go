@@ -19,7 +19,18 @@ import (
 	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
 	"github.com/xanderflood/plaid-ui/cmd/api/server/views"
 	"github.com/xanderflood/plaid-ui/lib/tools"
+	"github.com/xanderflood/plaid-ui/pkg/alerts"
+	"github.com/xanderflood/plaid-ui/pkg/blob"
 	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/digest"
+	"github.com/xanderflood/plaid-ui/pkg/forecast"
+	"github.com/xanderflood/plaid-ui/pkg/notify"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+	"github.com/xanderflood/plaid-ui/pkg/recurring"
+	"github.com/xanderflood/plaid-ui/pkg/scheduler"
+	"github.com/xanderflood/plaid-ui/pkg/statement"
+	"github.com/xanderflood/plaid-ui/pkg/transfers"
+	"github.com/xanderflood/plaid-ui/pkg/webhooks"
 
 	//postgres driver for db/sql
 	_ "github.com/lib/pq"
@@ -32,8 +43,57 @@ var options struct {
 	PlaidPublicKey           string `long:"plaid-public-key"           env:"PLAID_PUBLIC_KEY"            required:"true"`
 	PlaidEnvironment         string `long:"plaid-environment"          env:"PLAID_ENVIRONMENT"           required:"true"`
 	PostgresConnectionString string `long:"postgres-connection-string" env:"POSTGRES_CONNECTION_STRING"  required:"true"`
-	JWTSigningSecret         string `long:"jwt-signing-secret"         env:"JWT_SIGNING_SECRET"          required:"true"`
-	LoginBaseURL             string `long:"login-base-url"             env:"LOGIN_BASE_URL"              required:"true"`
+
+	//users are sent to the login service at the login base URL, unless
+	//built-in OpenID Connect login is enabled by giving an issuer; it
+	//signs sessions with the HMAC secret, and calls back to
+	//https://SERVICE_DOMAIN/oidc/callback unless a redirect URL is given
+	LoginBaseURL     string        `long:"login-base-url"     env:"LOGIN_BASE_URL"`
+	OIDCIssuer       string        `long:"oidc-issuer"        env:"OIDC_ISSUER"`
+	OIDCClientID     string        `long:"oidc-client-id"     env:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string        `long:"oidc-client-secret" env:"OIDC_CLIENT_SECRET"`
+	OIDCRedirectURL  string        `long:"oidc-redirect-url"  env:"OIDC_REDIRECT_URL"`
+	SessionLifetime  time.Duration `long:"session-lifetime"   env:"SESSION_LIFETIME"   default:"24h"`
+
+	//logins are verified with the HMAC secret, the keys in the JWKS
+	//(an http(s) URL or a file path), or both; at least one is required
+	JWTSigningSecret string        `long:"jwt-signing-secret" env:"JWT_SIGNING_SECRET"`
+	JWKSSource       string        `long:"jwks-source"        env:"JWKS_SOURCE"`
+	JWKSMaxAge       time.Duration `long:"jwks-max-age"       env:"JWKS_MAX_AGE"       default:"1h"`
+	JWTIssuer        string        `long:"jwt-issuer"         env:"JWT_ISSUER"`
+	JWTAudience      string        `long:"jwt-audience"       env:"JWT_AUDIENCE"`
+
+	//3000 is the generic Web Connect bank ID that Quicken accepts
+	//for institutions it doesn't otherwise recognize
+	QFXIntuitBankID string `long:"qfx-intuit-bank-id" env:"QFX_INTUIT_BANK_ID" default:"3000"`
+
+	AttachmentsDirectory string `long:"attachments-directory" env:"ATTACHMENTS_DIRECTORY" default:"./attachments"`
+	TransferWindowDays   int    `long:"transfer-window-days"  env:"TRANSFER_WINDOW_DAYS"  default:"3"`
+
+	//each refresh-now request costs several Plaid calls, each retried
+	//by the transport below, so users may only refresh an item this often
+	ItemRefreshInterval time.Duration `long:"item-refresh-interval" env:"ITEM_REFRESH_INTERVAL" default:"5m"`
+
+	//email alerts are disabled unless an SMTP host is given
+	SMTPHost     string `long:"smtp-host"     env:"SMTP_HOST"`
+	SMTPPort     int    `long:"smtp-port"     env:"SMTP_PORT"     default:"587"`
+	SMTPUsername string `long:"smtp-username" env:"SMTP_USERNAME"`
+	SMTPPassword string `long:"smtp-password" env:"SMTP_PASSWORD"`
+	SMTPFrom     string `long:"smtp-from"     env:"SMTP_FROM"`
+
+	//schedules are cron expressions; digests are only sent when email
+	//is configured
+	BalanceRefreshSchedule string        `long:"balance-refresh-schedule" env:"BALANCE_REFRESH_SCHEDULE" default:"0 */6 * * *"`
+	CatchUpSyncSchedule    string        `long:"catch-up-sync-schedule"   env:"CATCH_UP_SYNC_SCHEDULE"   default:"30 * * * *"`
+	CatchUpSyncAfter       time.Duration `long:"catch-up-sync-after"      env:"CATCH_UP_SYNC_AFTER"      default:"24h"`
+	HousekeepingSchedule   string        `long:"housekeeping-schedule"    env:"HOUSEKEEPING_SCHEDULE"    default:"15 3 * * *"`
+	HistoryRetention       time.Duration `long:"history-retention"        env:"HISTORY_RETENTION"        default:"720h"`
+	DigestSchedule         string        `long:"digest-schedule"          env:"DIGEST_SCHEDULE"          default:"0 * * * *"`
+
+	//history is imported a window at a time by a job that runs every
+	//minute, for at most the given budget each run
+	BackfillYears  int           `long:"backfill-years"  env:"BACKFILL_YEARS"  default:"2"`
+	BackfillBudget time.Duration `long:"backfill-budget" env:"BACKFILL_BUDGET" default:"45s"`
 
 	Port  string `long:"port"          env:"PORT" default:"8000"`
 	Debug bool   `long:"debug"         env:"DEBUG"`
@@ -78,10 +138,39 @@ func main() {
 	if err = db.EnsureTables(context.Background(), dbClient); err != nil {
 		log.Fatalf("couldn't initialize accounts table: %s", err.Error())
 	}
+	for role, scopes := range auth.DefaultRoles {
+		var permissions []string
+		for _, scope := range scopes {
+			permissions = append(permissions, string(scope))
+		}
+		if err = dbClient.SeedRole(context.Background(), role, permissions); err != nil {
+			log.Fatalf("couldn't create role `%s`: %s", role, err.Error())
+		}
+	}
 
-	loginBaseURL, err := url.Parse(options.LoginBaseURL)
-	if err != nil {
-		log.Fatalf("login base URL `%s` was malformed: %s", options.LoginBaseURL, err.Error())
+	oidcRedirectURL := options.OIDCRedirectURL
+	if oidcRedirectURL == "" {
+		oidcRedirectURL = fmt.Sprintf("https://%s/oidc/callback", options.ServiceDomain)
+	}
+
+	var loginBaseURL *url.URL
+	switch {
+	case options.OIDCIssuer != "":
+		if options.OIDCClientID == "" || options.JWTSigningSecret == "" {
+			log.Fatal("--oidc-client-id and --jwt-signing-secret are required for built-in login")
+		}
+		loginBaseURL, err = url.Parse(oidcRedirectURL)
+		if err != nil {
+			log.Fatalf("OIDC redirect URL `%s` was malformed: %s", oidcRedirectURL, err.Error())
+		}
+		loginBaseURL = loginBaseURL.ResolveReference(&url.URL{Path: "/login"})
+	case options.LoginBaseURL != "":
+		loginBaseURL, err = url.Parse(options.LoginBaseURL)
+		if err != nil {
+			log.Fatalf("login base URL `%s` was malformed: %s", options.LoginBaseURL, err.Error())
+		}
+	default:
+		log.Fatal("one of --login-base-url and --oidc-issuer is required")
 	}
 
 	logger := tools.NewStdoutLogger()
@@ -98,25 +187,117 @@ func main() {
 		},
 	)
 
+	var validMethods []string
+	if options.JWTSigningSecret != "" {
+		validMethods = append(validMethods, "HS256")
+	}
+	var keySet auth.KeySet
+	if options.JWKSSource != "" {
+		keySet = auth.NewJWKS(options.JWKSSource, &http.Client{Timeout: 10 * time.Second}, options.JWKSMaxAge)
+		validMethods = append(validMethods, "RS256", "ES256")
+	}
+	if len(validMethods) == 0 {
+		log.Fatal("one of --jwt-signing-secret and --jwks-source is required")
+	}
+
 	authMgr := auth.NewAuthorizationManager(
 		logger,
 		renderer,
 		options.JWTSigningSecret,
-		&jwt.Parser{ValidMethods: []string{"HS256"}},
+		keySet,
+		options.JWTIssuer,
+		options.JWTAudience,
+		&jwt.Parser{ValidMethods: validMethods},
 		dbClient,
 		loginBaseURL,
 	)
 
+	var oidc *auth.OIDCRelyingParty
+	if options.OIDCIssuer != "" {
+		oidc = auth.NewOIDCRelyingParty(
+			logger,
+			renderer,
+			auth.OIDCConfig{
+				Issuer:          options.OIDCIssuer,
+				ClientID:        options.OIDCClientID,
+				ClientSecret:    options.OIDCClientSecret,
+				RedirectURL:     oidcRedirectURL,
+				SessionLifetime: options.SessionLifetime,
+			},
+			&http.Client{Timeout: 10 * time.Second},
+			authMgr,
+			dbClient,
+		)
+	}
+
+	notifiers := map[db.AlertChannel]notify.Notifier{
+		db.AlertChannelWebhook: notify.NewWebhookNotifier(tools.NewPublicHTTPClient(10 * time.Second)),
+	}
+	if options.SMTPHost != "" {
+		notifiers[db.AlertChannelEmail] = notify.NewSMTPNotifier(
+			options.SMTPHost,
+			options.SMTPPort,
+			options.SMTPUsername,
+			options.SMTPPassword,
+			options.SMTPFrom,
+		)
+	}
+
+	recurringDetector := recurring.NewDetector(3)
+
+	webhookDispatcher := webhooks.NewDispatcher(logger, dbClient, tools.NewPublicHTTPClient(10*time.Second))
+	go webhookDispatcher.Run(context.Background())
+
+	jobScheduler := scheduler.NewScheduler(logger, dbClient)
+
 	srv := server.NewServer(
 		logger,
 		options.ServiceDomain,
 		authMgr,
+		oidc,
 		renderer,
 		auth.GetAuthorizationFromContext,
-		plaidClient,
+		plaidapi.NewClient(plaidClient, options.PlaidClientID, options.PlaidSecret),
 		dbClient,
+		statement.NewExporter(options.QFXIntuitBankID),
+		blob.NewLocalStore(options.AttachmentsDirectory),
+		transfers.NewMatcher(options.TransferWindowDays),
+		recurringDetector,
+		forecast.NewForecaster(),
+		alerts.NewEvaluator(recurringDetector),
+		notifiers,
+		webhookDispatcher,
+		jobScheduler,
+		options.ItemRefreshInterval,
+		options.BackfillYears,
 	)
 
+	addJob := func(name string, spec string, run scheduler.JobFunc) {
+		if err := jobScheduler.Add(name, spec, run); err != nil {
+			log.Fatalf("couldn't schedule job `%s`: %s", name, err.Error())
+		}
+	}
+	addJob("refresh_balances", options.BalanceRefreshSchedule, srv.RefreshAllBalances)
+	addJob("catch_up_sync", options.CatchUpSyncSchedule, func(ctx context.Context) error {
+		return srv.CatchUpSync(ctx, options.CatchUpSyncAfter)
+	})
+	addJob("backfill_history", "* * * * *", func(ctx context.Context) error {
+		return srv.BackfillHistory(ctx, options.BackfillBudget)
+	})
+	addJob("housekeeping", options.HousekeepingSchedule, func(ctx context.Context) error {
+		return srv.Housekeeping(ctx, options.HistoryRetention)
+	})
+	if emailNotifier, ok := notifiers[db.AlertChannelEmail]; ok {
+		digestRenderer, err := digest.NewRenderer("templates/digest.tmpl", "templates/digest_text.tmpl")
+		if err != nil {
+			log.Fatalf("couldn't load digest templates: %s", err.Error())
+		}
+
+		digestJob := digest.NewJob(logger, dbClient, digest.NewBuilder(dbClient), digestRenderer, emailNotifier)
+		addJob("send_digests", options.DigestSchedule, digestJob.Run)
+	}
+	go jobScheduler.Run(context.Background())
+
 	//build the gin server
 	r := gin.Default()
 
@@ -125,8 +306,11 @@ func main() {
 		"templates/not_registered.tmpl",
 		"templates/error_code.tmpl",
 	)
-	r.Static("/static", "./static")
 	server.AddRoutes(r, srv)
+	if err := server.CheckOpenAPI(r.Routes()); err != nil {
+		log.Fatal(err)
+	}
+	r.Static("/static", "./static")
 
 	log.Fatal(r.Run(":" + options.Port))
 }
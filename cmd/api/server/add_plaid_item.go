@@ -1,12 +1,15 @@
 package server
 
 import (
+	"math/big"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/plaid/plaid-go/plaid"
 	"github.com/xanderflood/plaid-ui/pkg/db"
 	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+	"github.com/xanderflood/plaid-ui/pkg/webhooks"
 )
 
 //AddPlaidItem adds all the accounts associated with this plaid item
@@ -44,11 +47,26 @@ func (a ServerAgent) AddPlaidItem(c *gin.Context) {
 		return
 	}
 
+	itemUUID, err := a.dbClient.UpsertItem(c, db.Item{
+		UserUUID:        authorization.UserUUID,
+		PlaidItemID:     getItemResponse.Item.ItemID,
+		InstitutionName: getInstitutionResponse.Institution.Name,
+	})
+	if err != nil {
+		a.logger.Errorf("failed saving item `%s`: %s", getItemResponse.Item.ItemID, err.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	a.audit(c, authorization, db.AuditActionItemLinked, "item", itemUUID, nil, gin.H{
+		"plaid_item_id":    getItemResponse.Item.ItemID,
+		"institution_name": getInstitutionResponse.Institution.Name,
+	})
+
 	for _, acct := range getAccountsResponse.Accounts {
 		//TODO enable the webhook for each account
 		//  is this going to be done automatically by the frontend?
 
-		_, err := a.dbClient.CreateAccount(c,
+		accountUUID, err := a.dbClient.CreateAccount(c,
 			authorization.UserUUID,
 			db.Account{
 				PlaidAccessToken:    exchangeTokenResponse.AccessToken,
@@ -64,9 +82,19 @@ func (a ServerAgent) AddPlaidItem(c *gin.Context) {
 			},
 		)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			a.logger.Errorf("failed creating account `%s`: %s", acct.AccountID, err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+			return
+		}
+
+		balance := balanceFromPlaid(authorization.UserUUID, accountUUID, acct.Balances)
+		balance.UUID, err = a.dbClient.RecordBalance(c, balance)
+		if err != nil {
+			a.logger.Errorf("failed recording balance for account `%s`: %s", accountUUID, err.Error())
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
 			return
 		}
+		a.emit(c, authorization.UserUUID, webhooks.EventBalanceUpdated, balance)
 	}
 
 	c.JSON(http.StatusOK, gin.H{
@@ -74,3 +102,17 @@ func (a ServerAgent) AddPlaidItem(c *gin.Context) {
 		"item_id":      getItemResponse.Item.ItemID,
 	})
 }
+
+//balanceFromPlaid builds a snapshot of today's balance from a Plaid account
+func balanceFromPlaid(userUUID string, accountUUID string, balances plaid.AccountBalances) db.Balance {
+	return db.Balance{
+		AccountUUID: accountUUID,
+		UserUUID:    userUUID,
+
+		Date:            time.Now().Format(plaidapi.DateFormat),
+		ISOCurrencyCode: balances.ISOCurrencyCode,
+		Current:         big.NewFloat(balances.Current),
+		Available:       big.NewFloat(balances.Available),
+		Limit:           big.NewFloat(balances.Limit),
+	}
+}
@@ -0,0 +1,299 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/lib/tools"
+	"github.com/xanderflood/plaid-ui/pkg/alerts"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/notify"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//AlertRequest encodes a new alert
+type AlertRequest struct {
+	Kind        db.AlertKind    `json:"kind" binding:"required"`
+	AccountUUID string          `json:"account_uuid"`
+	Category    string          `json:"category"`
+	Threshold   json.Number     `json:"threshold"`
+	Channel     db.AlertChannel `json:"channel" binding:"required"`
+	Target      string          `json:"target" binding:"required"`
+}
+
+//thresholdAlertKinds are the kinds of alert that need a threshold
+var thresholdAlertKinds = map[db.AlertKind]bool{
+	db.AlertKindLargeTransaction: true,
+	db.AlertKindLowBalance:       true,
+	db.AlertKindBudgetExceeded:   true,
+}
+
+//CreateAlert adds an alert for the user
+func (a ServerAgent) CreateAlert(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var req AlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	alert := db.Alert{
+		UserUUID:    auth.UserUUID,
+		Kind:        req.Kind,
+		AccountUUID: req.AccountUUID,
+		Category:    req.Category,
+		Channel:     req.Channel,
+		Target:      req.Target,
+	}
+
+	switch req.Kind {
+	case db.AlertKindLargeTransaction, db.AlertKindLowBalance, db.AlertKindNewRecurring,
+		db.AlertKindItemLoginRequired, db.AlertKindBudgetExceeded:
+	default:
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "unknown alert kind `" + string(req.Kind) + "`"})
+		return
+	}
+
+	if thresholdAlertKinds[req.Kind] {
+		threshold, ok := new(big.Float).SetString(req.Threshold.String())
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "a numeric threshold is required for " + string(req.Kind) + " alerts"})
+			return
+		}
+		alert.Threshold = threshold
+	}
+
+	switch req.Channel {
+	case db.AlertChannelEmail:
+		if _, err := mail.ParseAddress(req.Target); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "target must be an email address"})
+			return
+		}
+	case db.AlertChannelWebhook:
+		if u, err := url.Parse(req.Target); err != nil || u.Scheme != "https" || u.Host == "" {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "target must be an https URL"})
+			return
+		} else if !tools.IsPublicHost(u.Host) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "target must be a public address"})
+			return
+		}
+	default:
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "unknown alert channel `" + string(req.Channel) + "`"})
+		return
+	}
+
+	if req.AccountUUID != "" {
+		_, err := a.dbClient.GetAccount(c, auth.UserUUID, req.AccountUUID)
+		if err == db.ErrNoSuchAccount {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such account"})
+			return
+		}
+		if err != nil {
+			a.logger.Errorf("failed getting account `%s`: %s", req.AccountUUID, err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+			return
+		}
+	}
+
+	uuid, err := a.dbClient.CreateAlert(c, alert)
+	if err != nil {
+		a.logger.Errorf("failed creating alert for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	alert.UUID = uuid
+	a.audit(c, auth, db.AuditActionAlertCreated, "alert", uuid, nil, gin.H{
+		"kind":         alert.Kind,
+		"account_uuid": alert.AccountUUID,
+		"category":     alert.Category,
+		"threshold":    alert.Threshold,
+		"channel":      alert.Channel,
+		"target":       alert.Target,
+	})
+	c.JSON(http.StatusCreated, gin.H{
+		"alert": alert,
+	})
+}
+
+//GetAlerts lists the user's alerts
+func (a ServerAgent) GetAlerts(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	alerts, err := a.dbClient.GetAlerts(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting alerts for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"alerts": alerts,
+	})
+}
+
+//DeleteAlert removes one of the user's alerts
+func (a ServerAgent) DeleteAlert(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such alert")
+	if !ok {
+		return //an error response has already been generated
+	}
+	err := a.dbClient.DeleteAlert(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchAlert {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such alert"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed deleting alert `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "delete failed - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionAlertDeleted, "alert", uuid, nil, nil)
+
+	c.Status(http.StatusNoContent)
+}
+
+//GetAlertDeliveries lists the notifications sent for one of the user's alerts
+func (a ServerAgent) GetAlertDeliveries(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such alert")
+	if !ok {
+		return //an error response has already been generated
+	}
+	deliveries, err := a.dbClient.GetAlertDeliveries(c, auth.UserUUID, uuid)
+	if err != nil {
+		a.logger.Errorf("failed getting deliveries for alert `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+	})
+}
+
+//evaluateAlerts checks the user's alerts after a sync and delivers any
+//that fired. Each event is delivered at most once per alert.
+func (a ServerAgent) evaluateAlerts(ctx context.Context, userUUID string, newTransactions []db.Transaction, loginRequiredItems []string) error {
+	userAlerts, err := a.dbClient.GetAlerts(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+	if len(userAlerts) == 0 {
+		return nil
+	}
+
+	accounts, err := a.dbClient.GetAccounts(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+
+	balances := map[string]db.Balance{}
+	for _, account := range accounts {
+		history, err := a.dbClient.GetBalances(ctx, userUUID, account.UUID)
+		if err != nil {
+			return err
+		}
+		if len(history) > 0 {
+			balances[account.UUID] = history[len(history)-1]
+		}
+	}
+
+	now := time.Now()
+	history, err := a.dbClient.GetUserTransactionsByDateRange(ctx, userUUID,
+		now.AddDate(0, 0, -recurringLookbackDays).Format(plaidapi.DateFormat),
+		now.Format(plaidapi.DateFormat),
+	)
+	if err != nil {
+		return err
+	}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	spending, err := a.dbClient.GetSpendingReport(ctx, userUUID, db.ReportGroupCategory,
+		monthStart.Format(plaidapi.DateFormat),
+		now.Format(plaidapi.DateFormat),
+	)
+	if err != nil {
+		return err
+	}
+
+	triggers := a.alertEvaluator.Evaluate(userAlerts, alerts.Input{
+		Accounts:           accounts,
+		NewTransactions:    newTransactions,
+		History:            history,
+		Balances:           balances,
+		LoginRequiredItems: loginRequiredItems,
+		Spending:           spending,
+		Now:                now,
+	})
+
+	for _, trigger := range triggers {
+		deliveryUUID, err := a.dbClient.ClaimAlertDelivery(ctx, db.AlertDelivery{
+			AlertUUID: trigger.Alert.UUID,
+			UserUUID:  userUUID,
+			DedupKey:  trigger.DedupKey,
+			Subject:   trigger.Subject,
+			Body:      trigger.Body,
+		})
+		if err != nil {
+			return err
+		}
+		if deliveryUUID == "" {
+			continue //already delivered
+		}
+
+		status, errMessage := db.AlertDeliveryStatusSent, ""
+		if err := a.notify(ctx, trigger); err != nil {
+			a.logger.Errorf("failed delivering alert `%s`: %s", trigger.Alert.UUID, err.Error())
+			status, errMessage = db.AlertDeliveryStatusFailed, err.Error()
+		}
+
+		err = a.dbClient.SetAlertDeliveryStatus(ctx, deliveryUUID, status, errMessage)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (a ServerAgent) notify(ctx context.Context, trigger alerts.Trigger) error {
+	notifier, ok := a.notifiers[trigger.Alert.Channel]
+	if !ok {
+		return notify.ErrNotConfigured
+	}
+
+	return notifier.Notify(ctx, notify.Message{
+		To:      trigger.Alert.Target,
+		Event:   "alert." + string(trigger.Alert.Kind),
+		Subject: trigger.Subject,
+		Body:    trigger.Body,
+		Data: gin.H{
+			"alert_uuid": trigger.Alert.UUID,
+			"dedup_key":  trigger.DedupKey,
+		},
+	})
+}
@@ -0,0 +1,107 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"mime"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/xanderflood/plaid-ui/pkg/alerts"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/notify"
+	"github.com/xanderflood/plaid-ui/pkg/notify/notifytest"
+	"github.com/xanderflood/plaid-ui/pkg/recurring/recurringfakes"
+)
+
+func TestBudgetAlertEmail(t *testing.T) {
+	smtpServer, err := notifytest.NewSMTPServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer smtpServer.Close()
+
+	s := newTestServer()
+	s.alertEvaluator = alerts.NewEvaluator(&recurringfakes.FakeDetector{})
+	s.notifiers = map[db.AlertChannel]notify.Notifier{
+		db.AlertChannelEmail: notify.NewSMTPNotifier(smtpServer.Host, smtpServer.Port, "", "", "alerts@plaid-ui.test"),
+	}
+
+	s.db.GetAlertsReturns([]db.Alert{{
+		Model:     db.Model{UUID: "alert-1"},
+		Kind:      db.AlertKindBudgetExceeded,
+		Category:  "Food and Drink",
+		Threshold: big.NewFloat(200),
+		Channel:   db.AlertChannelEmail,
+		Target:    "user@plaid-ui.test",
+	}}, nil)
+	s.db.GetSpendingReportReturns([]db.ReportRow{
+		{Key: "Food and Drink", ISOCurrencyCode: "USD", Expenses: big.NewFloat(212.5)},
+		{Key: "Travel", ISOCurrencyCode: "USD", Expenses: big.NewFloat(900)},
+	}, nil)
+	s.db.ClaimAlertDeliveryReturns("delivery-1", nil)
+
+	if err := s.evaluateAlerts(context.Background(), testUserUUID, nil, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	emails := smtpServer.Emails()
+	if len(emails) != 1 {
+		t.Fatalf("%d emails were delivered, want 1", len(emails))
+	}
+	email := emails[0]
+	if email.From != "alerts@plaid-ui.test" || len(email.To) != 1 || email.To[0] != "user@plaid-ui.test" {
+		t.Errorf("email was sent from `%s` to %v", email.From, email.To)
+	}
+
+	msg, err := email.Message()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Spending in Food and Drink is over budget for "; !strings.HasPrefix(subject, want) {
+		t.Errorf("subject = %q, want it to start with %q", subject, want)
+	}
+	if contentType := msg.Header.Get("Content-Type"); contentType != "text/plain; charset=utf-8" {
+		t.Errorf("content type = %q", contentType)
+	}
+	body, err := ioutil.ReadAll(msg.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Spending in Food and Drink has reached 212.50 USD this month, which is over your budget of 200.00."; strings.TrimSpace(string(body)) != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+
+	if _, delivery := s.db.ClaimAlertDeliveryArgsForCall(0); delivery.AlertUUID != "alert-1" || delivery.Subject != subject {
+		t.Errorf("claimed %v", delivery)
+	}
+	if _, uuid, status, errMessage := s.db.SetAlertDeliveryStatusArgsForCall(0); uuid != "delivery-1" || status != db.AlertDeliveryStatusSent || errMessage != "" {
+		t.Errorf("delivery `%s` was marked %s: %s", uuid, status, errMessage)
+	}
+}
+
+func TestCreateAlertNonPublicTarget(t *testing.T) {
+	for _, target := range []string{
+		"https://localhost/hook",
+		"https://127.0.0.1:8443/hook",
+		"https://10.0.0.5/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/hook",
+	} {
+		s := newTestServer()
+
+		status, resp := serve(t, s.CreateAlert, "POST", "/api/v1/alerts", "/api/v1/alerts",
+			`{"kind": "large_transaction", "threshold": "100", "channel": "webhook", "target": "`+target+`"}`)
+		assertStatus(t, status, http.StatusBadRequest, resp)
+		assertError(t, resp, "target must be a public address")
+		if n := s.db.CreateAlertCallCount(); n != 0 {
+			t.Errorf("an alert for %s was created", target)
+		}
+	}
+}
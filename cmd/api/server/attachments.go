@@ -0,0 +1,181 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+//maxAttachmentSize caps the size of a single uploaded file
+const maxAttachmentSize = 10 << 20
+
+//UploadAttachment stores a file, such as a receipt, against one of the
+//user's transactions. The file is sent as `file` in a multipart form.
+func (a ServerAgent) UploadAttachment(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+	transactionUUID, ok := uuidParam(c, "id", "no such transaction")
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxAttachmentSize+(1<<20))
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "a file must be provided as `file`"})
+		return
+	}
+	if fileHeader.Size > maxAttachmentSize {
+		c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("attachments may be at most %d bytes", maxAttachmentSize)})
+		return
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	attachment := db.Attachment{
+		UserUUID:        auth.UserUUID,
+		TransactionUUID: transactionUUID,
+
+		Filename:    filepath.Base(fileHeader.Filename),
+		ContentType: contentType,
+		Size:        fileHeader.Size,
+	}
+
+	attachment.UUID, err = a.dbClient.CreateAttachment(c, attachment)
+	if err == db.ErrNoSuchTransaction {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such transaction"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed creating attachment for transaction `%s`: %s", attachment.TransactionUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "upload failed - see logs for details"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err == nil {
+		err = a.blobStore.Put(c, attachment.BlobKey(), file)
+		file.Close()
+	}
+	if err != nil {
+		a.logger.Errorf("failed storing attachment `%s`: %s", attachment.UUID, err.Error())
+		if err := a.dbClient.DeleteAttachment(c, auth.UserUUID, attachment.UUID); err != nil {
+			a.logger.Errorf("failed cleaning up attachment `%s`: %s", attachment.UUID, err.Error())
+		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "upload failed - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionAttachmentUploaded, "attachment", attachment.UUID, nil, auditAttachment(attachment))
+
+	c.JSON(http.StatusOK, gin.H{
+		"attachment": attachment,
+	})
+}
+
+//DownloadAttachment serves the content of one of the user's attachments
+func (a ServerAgent) DownloadAttachment(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	attachment, ok := a.getAttachment(c, auth.UserUUID)
+	if !ok {
+		return
+	}
+
+	content, err := a.blobStore.Get(c, attachment.BlobKey())
+	if err != nil {
+		a.logger.Errorf("failed reading attachment `%s`: %s", attachment.UUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "download failed - see logs for details"})
+		return
+	}
+	defer content.Close()
+
+	filename := strings.NewReplacer(`"`, "", "\r", "", "\n", "").Replace(attachment.Filename)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Content-Type", attachment.ContentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, content); err != nil {
+		a.logger.Errorf("failed sending attachment `%s`: %s", attachment.UUID, err.Error())
+	}
+}
+
+//DeleteAttachment removes one of the user's attachments
+func (a ServerAgent) DeleteAttachment(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	attachment, ok := a.getAttachment(c, auth.UserUUID)
+	if !ok {
+		return
+	}
+
+	err := a.dbClient.DeleteAttachment(c, auth.UserUUID, attachment.UUID)
+	if err != nil {
+		a.logger.Errorf("failed deleting attachment `%s`: %s", attachment.UUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "delete failed - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionAttachmentDeleted, "attachment", attachment.UUID, auditAttachment(attachment), nil)
+
+	if err := a.blobStore.Delete(c, attachment.BlobKey()); err != nil {
+		a.logger.Errorf("failed deleting content of attachment `%s`: %s", attachment.UUID, err.Error())
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"attachment_uuid": attachment.UUID,
+	})
+}
+
+//getAttachment looks up the attachment named in the path, making sure
+//that it belongs to both the user and the transaction in the path
+func (a ServerAgent) getAttachment(c *gin.Context, userUUID string) (db.Attachment, bool) {
+	transactionUUID, ok := uuidParam(c, "id", "no such transaction")
+	if !ok {
+		return db.Attachment{}, false
+	}
+	uuid, ok := uuidParam(c, "attachment_id", "no such attachment")
+	if !ok {
+		return db.Attachment{}, false
+	}
+
+	attachment, err := a.dbClient.GetAttachment(c, userUUID, uuid)
+	if err == nil && attachment.TransactionUUID != transactionUUID {
+		err = db.ErrNoSuchAttachment
+	}
+	if err == db.ErrNoSuchAttachment {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such attachment"})
+		return db.Attachment{}, false
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting attachment `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return db.Attachment{}, false
+	}
+	return attachment, true
+}
+
+//auditAttachment is what the audit log records about an attachment
+func auditAttachment(attachment db.Attachment) gin.H {
+	return gin.H{
+		"transaction_uuid": attachment.TransactionUUID,
+		"filename":         attachment.Filename,
+		"content_type":     attachment.ContentType,
+		"size":             attachment.Size,
+	}
+}
@@ -0,0 +1,260 @@
+package server
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+const (
+	defaultAuditEventsLimit = 50
+	maxAuditEventsLimit     = 500
+)
+
+//requestIDHeader carries the ID of a request, to match it up with logs
+//and the audit log
+const requestIDHeader = "X-Request-ID"
+
+//requestIDKey is where RequestID stores the ID in the gin context
+const requestIDKey = "request_id"
+
+//validRequestID matches the IDs that proxies may pass in
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
+//RequestID gives each request an ID, and returns it in a response
+//header. An ID set by a proxy in front of the API is kept.
+func RequestID(c *gin.Context) {
+	id := c.GetHeader(requestIDHeader)
+	if !validRequestID.MatchString(id) {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+			return
+		}
+		id = hex.EncodeToString(b)
+	}
+
+	c.Set(requestIDKey, id)
+	c.Header(requestIDHeader, id)
+}
+
+//audit records an action in the audit log, along with the fields of its
+//subject that it changed. before is nil for creations, and after is nil
+//for deletions. The action has already happened, so a failure is only
+//logged.
+func (a ServerAgent) audit(c *gin.Context, actor auth.Authorization, action db.AuditAction, subjectType string, subjectUUID string, before interface{}, after interface{}) {
+	event := db.AuditEvent{
+		ActorUUID:      actor.UserUUID,
+		ActorTokenUUID: actor.PersonalAccessTokenUUID,
+		Action:         action,
+		SubjectType:    subjectType,
+		SubjectUUID:    subjectUUID,
+		RequestID:      c.GetString(requestIDKey),
+		IP:             c.ClientIP(),
+	}
+
+	var err error
+	event.Before, event.After, err = auditDiff(before, after)
+	if err == nil {
+		_, err = a.dbClient.RecordAuditEvent(c, event)
+	}
+	if err != nil {
+		a.logger.Errorf("failed recording `%s` of %s `%s`: %s", action, subjectType, subjectUUID, err.Error())
+	}
+}
+
+//auditDiff encodes the JSON fields of before and after that differ.
+//Either may be nil, in which case all of the other's fields are kept.
+func auditDiff(before interface{}, after interface{}) (json.RawMessage, json.RawMessage, error) {
+	beforeFields, err := jsonFields(before)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterFields, err := jsonFields(after)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if beforeFields != nil && afterFields != nil {
+		for name, value := range beforeFields {
+			if other, ok := afterFields[name]; ok && bytes.Equal(value, other) {
+				delete(beforeFields, name)
+				delete(afterFields, name)
+			}
+		}
+	}
+
+	beforeDoc, err := marshalFields(beforeFields)
+	if err != nil {
+		return nil, nil, err
+	}
+	afterDoc, err := marshalFields(afterFields)
+	return beforeDoc, afterDoc, err
+}
+
+func jsonFields(v interface{}) (map[string]json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	doc, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode audit state")
+	}
+	var fields map[string]json.RawMessage
+	return fields, errors.Wrap(json.Unmarshal(doc, &fields), "audit state must be a JSON object")
+}
+
+func marshalFields(fields map[string]json.RawMessage) (json.RawMessage, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	doc, err := json.Marshal(fields)
+	return doc, errors.Wrap(err, "failed to encode audit state")
+}
+
+//GetAuditEvents searches the audit log, newest first. `actor_id`,
+//`action`, `subject_type` and `subject_id` narrow the search, as do
+//`since` and `until`, which are RFC 3339 times. The response's
+//next_cursor, passed back as `before`, gets the next page.
+func (a ServerAgent) GetAuditEvents(c *gin.Context) {
+	if _, ok := a.authorize(c); !ok {
+		return //an error response has already been generated
+	}
+
+	filter, ok := auditEventFilter(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+	filter.ActorUUID = c.Query("actor_id")
+	filter.SubjectType = c.Query("subject_type")
+	filter.SubjectUUID = c.Query("subject_id")
+	for _, id := range []string{filter.ActorUUID, filter.SubjectUUID} {
+		if id != "" && !db.ValidUUID(id) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "`" + id + "` isn't a valid ID"})
+			return
+		}
+	}
+
+	a.renderAuditEvents(c, filter)
+}
+
+//GetMyActivity lists the actions the user has taken, and those others
+//have taken on their account, newest first. It takes the same `action`,
+//`since`, `until` and `before` parameters as GetAuditEvents.
+func (a ServerAgent) GetMyActivity(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	filter, ok := auditEventFilter(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+	filter.Involving = auth.UserUUID
+
+	a.renderAuditEvents(c, filter)
+}
+
+//auditEventFilter reads the query parameters shared by the audit log
+//endpoints
+func auditEventFilter(c *gin.Context) (db.AuditEventFilter, bool) {
+	filter := db.AuditEventFilter{
+		Action: db.AuditAction(c.Query("action")),
+		Limit:  defaultAuditEventsLimit,
+	}
+	if s := c.Query("limit"); s != "" {
+		var err error
+		filter.Limit, err = strconv.Atoi(s)
+		if err != nil || filter.Limit < 1 || filter.Limit > maxAuditEventsLimit {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxAuditEventsLimit)})
+			return db.AuditEventFilter{}, false
+		}
+	}
+
+	for name, t := range map[string]**time.Time{"since": &filter.Since, "until": &filter.Until} {
+		s := c.Query(name)
+		if s == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": name + " must be an RFC 3339 time"})
+			return db.AuditEventFilter{}, false
+		}
+		*t = &parsed
+	}
+
+	var err error
+	filter.Before, err = decodeAuditCursor(c.Query("before"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return db.AuditEventFilter{}, false
+	}
+
+	return filter, true
+}
+
+func (a ServerAgent) renderAuditEvents(c *gin.Context, filter db.AuditEventFilter) {
+	//fetch one extra event to tell whether there's another page
+	limit := filter.Limit
+	filter.Limit++
+	events, err := a.dbClient.GetAuditEvents(c, filter)
+	if err != nil {
+		a.logger.Errorf("failed getting audit events: %s", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	var nextCursor string
+	if len(events) > limit {
+		events = events[:limit]
+		nextCursor = encodeAuditCursor(events[limit-1])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events":      events,
+		"next_cursor": nextCursor,
+	})
+}
+
+//encodeAuditCursor makes an opaque cursor for an event's position in the
+//audit log
+func encodeAuditCursor(event db.AuditEvent) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(event.CreatedAt.Format(time.RFC3339Nano) + "/" + event.UUID))
+}
+
+//decodeAuditCursor reads a cursor from encodeAuditCursor
+func decodeAuditCursor(cursor string) (*db.AuditCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	parts := strings.SplitN(string(data), "/", 2)
+	if len(parts) != 2 || !db.ValidUUID(parts[1]) {
+		return nil, errors.New("invalid cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	return &db.AuditCursor{CreatedAt: createdAt, UUID: parts[1]}, nil
+}
@@ -0,0 +1,281 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/blob/blobfakes"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/webhooks/webhooksfakes"
+)
+
+//fileRequest builds a multipart request that uploads content as `file`
+func fileRequest(t *testing.T, method string, path string, filename string, content string) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write([]byte(content)) //nolint:errcheck
+	form.Close()                //nolint:errcheck
+
+	req := httptest.NewRequest(method, path, &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return req
+}
+
+func assertJSON(t *testing.T, name string, got json.RawMessage, want string) {
+	t.Helper()
+
+	var gotValue, wantValue interface{}
+	if len(got) > 0 {
+		if err := json.Unmarshal(got, &gotValue); err != nil {
+			t.Fatalf("%s isn't JSON: %s", name, err)
+		}
+	}
+	if want != "" {
+		if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !reflect.DeepEqual(gotValue, wantValue) {
+		t.Errorf("%s = %s, want %s", name, got, want)
+	}
+}
+
+//TestAuditedChanges checks the audit log entry for each change that
+//isn't covered alongside its handler's other tests
+func TestAuditedChanges(t *testing.T) {
+	const (
+		attachmentUUID = "5d6e7f8a-9b0c-4d4e-9f5a-6b7c8d9e0f1a"
+		transferUUID   = "6e7f8a9b-0c1d-4e5f-8a6b-7c8d9e0f1a2b"
+		shareUUID      = "7f8a9b0c-1d2e-4f6a-9b7c-8d9e0f1a2b3c"
+		alertUUID      = "8a9b0c1d-2e3f-4a7b-8c8d-9e0f1a2b3c4d"
+		endpointUUID   = "9b0c1d2e-3f4a-4b8c-9d9e-0f1a2b3c4d5e"
+	)
+	acceptedAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	attachment := db.Attachment{
+		Model:           db.Model{UUID: attachmentUUID},
+		UserUUID:        testUserUUID,
+		TransactionUUID: testTransactionUUID,
+		Filename:        "receipt.txt",
+		ContentType:     "text/plain",
+		Size:            5,
+	}
+
+	for _, test := range []struct {
+		name    string
+		setup   func(s *testServer)
+		handler func(ServerAgent, *gin.Context)
+		pattern string
+		request func(t *testing.T) *http.Request
+
+		action  db.AuditAction
+		subject string
+		before  string
+		after   string
+	}{
+		{
+			name: "hide account",
+			setup: func(s *testServer) {
+				s.db.GetAccountReturns(db.Account{Access: db.ShareLevelOwner}, nil)
+			},
+			handler: ServerAgent.UpdateAccount,
+			pattern: "/api/v1/accounts/:id",
+			request: jsonRequest("PATCH", "/api/v1/accounts/"+testAccountUUID, `{"hidden": true}`),
+			action:  db.AuditActionAccountUpdated,
+			subject: testAccountUUID,
+			before:  `{"hidden": false}`,
+			after:   `{"hidden": true}`,
+		},
+		{
+			name: "delete account",
+			setup: func(s *testServer) {
+				s.db.GetAccountReturns(db.Account{Manual: true, Access: db.ShareLevelOwner, PlaidAccountName: "Cash"}, nil)
+			},
+			handler: ServerAgent.DeleteAccount,
+			pattern: "/api/v1/accounts/:id",
+			request: jsonRequest("DELETE", "/api/v1/accounts/"+testAccountUUID, ""),
+			action:  db.AuditActionAccountDeleted,
+			subject: testAccountUUID,
+			before:  `{"name": "Cash", "institution_name": ""}`,
+		},
+		{
+			name: "create manual account",
+			setup: func(s *testServer) {
+				s.db.CreateAccountReturns(testAccountUUID, nil)
+			},
+			handler: ServerAgent.CreateManualAccount,
+			pattern: "/api/v1/accounts",
+			request: jsonRequest("POST", "/api/v1/accounts", `{"name": "Cash", "type": "depository", "institution_name": "Wallet"}`),
+			action:  db.AuditActionAccountCreated,
+			subject: testAccountUUID,
+			after:   `{"name": "Cash", "type": "depository", "subtype": "", "institution_name": "Wallet"}`,
+		},
+		{
+			name: "import statement",
+			setup: func(s *testServer) {
+				s.webhookDispatcher = &webhooksfakes.FakeDispatcher{}
+				s.db.GetAccountReturns(db.Account{Model: db.Model{UUID: testAccountUUID}, Manual: true, Access: db.ShareLevelOwner}, nil)
+				s.db.UpsertTransactionReturns(testTransactionUUID, true, nil)
+			},
+			handler: ServerAgent.ImportStatement,
+			pattern: "/api/v1/accounts/:id/import",
+			request: func(t *testing.T) *http.Request {
+				return fileRequest(t, "POST", "/api/v1/accounts/"+testAccountUUID+"/import", "statement.ofx", testOFX)
+			},
+			action:  db.AuditActionAccountImported,
+			subject: testAccountUUID,
+			after:   `{"format": "ofx", "filename": "statement.ofx", "inserted": 4, "skipped": 1}`,
+		},
+		{
+			name: "upload attachment",
+			setup: func(s *testServer) {
+				s.blobStore = &blobfakes.FakeStore{}
+				s.db.CreateAttachmentReturns(attachmentUUID, nil)
+			},
+			handler: ServerAgent.UploadAttachment,
+			pattern: "/api/v1/transactions/:id/attachments",
+			request: func(t *testing.T) *http.Request {
+				return fileRequest(t, "POST", "/api/v1/transactions/"+testTransactionUUID+"/attachments", "receipt.txt", "hello")
+			},
+			action:  db.AuditActionAttachmentUploaded,
+			subject: attachmentUUID,
+			after:   `{"transaction_uuid": "` + testTransactionUUID + `", "filename": "receipt.txt", "content_type": "application/octet-stream", "size": 5}`,
+		},
+		{
+			name: "delete attachment",
+			setup: func(s *testServer) {
+				s.blobStore = &blobfakes.FakeStore{}
+				s.db.GetAttachmentReturns(attachment, nil)
+			},
+			handler: ServerAgent.DeleteAttachment,
+			pattern: "/api/v1/transactions/:id/attachments/:attachment_id",
+			request: jsonRequest("DELETE", "/api/v1/transactions/"+testTransactionUUID+"/attachments/"+attachmentUUID, ""),
+			action:  db.AuditActionAttachmentDeleted,
+			subject: attachmentUUID,
+			before:  `{"transaction_uuid": "` + testTransactionUUID + `", "filename": "receipt.txt", "content_type": "text/plain", "size": 5}`,
+		},
+		{
+			name:    "confirm transfer",
+			handler: ServerAgent.ConfirmTransfer,
+			pattern: "/api/v1/transfers/:id/confirm",
+			request: jsonRequest("PUT", "/api/v1/transfers/"+transferUUID+"/confirm", ""),
+			action:  db.AuditActionTransferConfirmed,
+			subject: transferUUID,
+			after:   `{"status": "confirmed"}`,
+		},
+		{
+			name:    "reject transfer",
+			handler: ServerAgent.RejectTransfer,
+			pattern: "/api/v1/transfers/:id",
+			request: jsonRequest("DELETE", "/api/v1/transfers/"+transferUUID, ""),
+			action:  db.AuditActionTransferRejected,
+			subject: transferUUID,
+			after:   `{"status": "rejected"}`,
+		},
+		{
+			name: "accept share",
+			setup: func(s *testServer) {
+				s.db.GetShareReturns(db.Share{
+					Model:       db.Model{UUID: shareUUID},
+					OwnerEmail:  "owner@plaid-ui.test",
+					AccountUUID: testAccountUUID,
+					Level:       db.ShareLevelRead,
+					AcceptedAt:  &acceptedAt,
+				}, nil)
+			},
+			handler: ServerAgent.AcceptShare,
+			pattern: "/api/v1/shares/:id/accept",
+			request: jsonRequest("POST", "/api/v1/shares/"+shareUUID+"/accept", ""),
+			action:  db.AuditActionShareAccepted,
+			subject: shareUUID,
+			before:  `{"accepted_at": null}`,
+			after:   `{"owner_email": "owner@plaid-ui.test", "account_uuid": "` + testAccountUUID + `", "item_uuid": "", "level": "read", "accepted_at": "2020-01-02T03:04:05Z"}`,
+		},
+		{
+			name: "create alert",
+			setup: func(s *testServer) {
+				s.db.CreateAlertReturns(alertUUID, nil)
+			},
+			handler: ServerAgent.CreateAlert,
+			pattern: "/api/v1/alerts",
+			request: jsonRequest("POST", "/api/v1/alerts", `{"kind": "large_transaction", "threshold": 100, "channel": "email", "target": "user@plaid-ui.test"}`),
+			action:  db.AuditActionAlertCreated,
+			subject: alertUUID,
+			after:   `{"kind": "large_transaction", "account_uuid": "", "category": "", "threshold": "100", "channel": "email", "target": "user@plaid-ui.test"}`,
+		},
+		{
+			name:    "delete alert",
+			handler: ServerAgent.DeleteAlert,
+			pattern: "/api/v1/alerts/:id",
+			request: jsonRequest("DELETE", "/api/v1/alerts/"+alertUUID, ""),
+			action:  db.AuditActionAlertDeleted,
+			subject: alertUUID,
+		},
+		{
+			name: "create webhook endpoint",
+			setup: func(s *testServer) {
+				s.db.CreateWebhookEndpointReturns(endpointUUID, nil)
+			},
+			handler: ServerAgent.CreateWebhookEndpoint,
+			pattern: "/api/v1/webhooks",
+			request: jsonRequest("POST", "/api/v1/webhooks", `{"url": "https://hooks.example.com/plaid-ui", "events": ["transaction.created"]}`),
+			action:  db.AuditActionWebhookEndpointCreated,
+			subject: endpointUUID,
+			after:   `{"url": "https://hooks.example.com/plaid-ui", "events": ["transaction.created"]}`,
+		},
+		{
+			name:    "delete webhook endpoint",
+			handler: ServerAgent.DeleteWebhookEndpoint,
+			pattern: "/api/v1/webhooks/:id",
+			request: jsonRequest("DELETE", "/api/v1/webhooks/"+endpointUUID, ""),
+			action:  db.AuditActionWebhookEndpointDeleted,
+			subject: endpointUUID,
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer()
+			if test.setup != nil {
+				test.setup(&s)
+			}
+
+			code, resp := serveRequest(t, func(c *gin.Context) { test.handler(s.ServerAgent, c) }, test.pattern, test.request(t))
+			if code >= 300 {
+				t.Fatalf("status = %d (response: %v)", code, resp)
+			}
+
+			if n := s.db.RecordAuditEventCallCount(); n != 1 {
+				t.Fatalf("recorded %d events, want 1", n)
+			}
+			_, event := s.db.RecordAuditEventArgsForCall(0)
+			if event.Action != test.action || event.SubjectUUID != test.subject || event.ActorUUID != testUserUUID {
+				t.Errorf("recorded `%s` of `%s` by `%s`", event.Action, event.SubjectUUID, event.ActorUUID)
+			}
+			assertJSON(t, "before", event.Before, test.before)
+			assertJSON(t, "after", event.After, test.after)
+		})
+	}
+}
+
+func jsonRequest(method string, path string, body string) func(t *testing.T) *http.Request {
+	return func(t *testing.T) *http.Request {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		return req
+	}
+}
@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package authfakes
+
+import (
+	"sync"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
+)
+
+type FakeAuthorizer struct {
+	ParseWithClaimsStub        func(string, jwt.Claims, jwt.Keyfunc) (*jwt.Token, error)
+	parseWithClaimsMutex       sync.RWMutex
+	parseWithClaimsArgsForCall []struct {
+		arg1 string
+		arg2 jwt.Claims
+		arg3 jwt.Keyfunc
+	}
+	parseWithClaimsReturns struct {
+		result1 *jwt.Token
+		result2 error
+	}
+	parseWithClaimsReturnsOnCall map[int]struct {
+		result1 *jwt.Token
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuthorizer) ParseWithClaims(arg1 string, arg2 jwt.Claims, arg3 jwt.Keyfunc) (*jwt.Token, error) {
+	fake.parseWithClaimsMutex.Lock()
+	ret, specificReturn := fake.parseWithClaimsReturnsOnCall[len(fake.parseWithClaimsArgsForCall)]
+	fake.parseWithClaimsArgsForCall = append(fake.parseWithClaimsArgsForCall, struct {
+		arg1 string
+		arg2 jwt.Claims
+		arg3 jwt.Keyfunc
+	}{arg1, arg2, arg3})
+	stub := fake.ParseWithClaimsStub
+	fakeReturns := fake.parseWithClaimsReturns
+	fake.recordInvocation("ParseWithClaims", []interface{}{arg1, arg2, arg3})
+	fake.parseWithClaimsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuthorizer) ParseWithClaimsCallCount() int {
+	fake.parseWithClaimsMutex.RLock()
+	defer fake.parseWithClaimsMutex.RUnlock()
+	return len(fake.parseWithClaimsArgsForCall)
+}
+
+func (fake *FakeAuthorizer) ParseWithClaimsCalls(stub func(string, jwt.Claims, jwt.Keyfunc) (*jwt.Token, error)) {
+	fake.parseWithClaimsMutex.Lock()
+	defer fake.parseWithClaimsMutex.Unlock()
+	fake.ParseWithClaimsStub = stub
+}
+
+func (fake *FakeAuthorizer) ParseWithClaimsArgsForCall(i int) (string, jwt.Claims, jwt.Keyfunc) {
+	fake.parseWithClaimsMutex.RLock()
+	defer fake.parseWithClaimsMutex.RUnlock()
+	argsForCall := fake.parseWithClaimsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAuthorizer) ParseWithClaimsReturns(result1 *jwt.Token, result2 error) {
+	fake.parseWithClaimsMutex.Lock()
+	defer fake.parseWithClaimsMutex.Unlock()
+	fake.ParseWithClaimsStub = nil
+	fake.parseWithClaimsReturns = struct {
+		result1 *jwt.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuthorizer) ParseWithClaimsReturnsOnCall(i int, result1 *jwt.Token, result2 error) {
+	fake.parseWithClaimsMutex.Lock()
+	defer fake.parseWithClaimsMutex.Unlock()
+	fake.ParseWithClaimsStub = nil
+	if fake.parseWithClaimsReturnsOnCall == nil {
+		fake.parseWithClaimsReturnsOnCall = make(map[int]struct {
+			result1 *jwt.Token
+			result2 error
+		})
+	}
+	fake.parseWithClaimsReturnsOnCall[i] = struct {
+		result1 *jwt.Token
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuthorizer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.parseWithClaimsMutex.RLock()
+	defer fake.parseWithClaimsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAuthorizer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auth.Authorizer = new(FakeAuthorizer)
@@ -0,0 +1,116 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package authfakes
+
+import (
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
+)
+
+type FakeGetter struct {
+	Stub        func(*gin.Context) (auth.Authorization, bool)
+	mutex       sync.RWMutex
+	argsForCall []struct {
+		arg1 *gin.Context
+	}
+	returns struct {
+		result1 auth.Authorization
+		result2 bool
+	}
+	returnsOnCall map[int]struct {
+		result1 auth.Authorization
+		result2 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeGetter) Spy(arg1 *gin.Context) (auth.Authorization, bool) {
+	fake.mutex.Lock()
+	ret, specificReturn := fake.returnsOnCall[len(fake.argsForCall)]
+	fake.argsForCall = append(fake.argsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.Stub
+	returns := fake.returns
+	fake.recordInvocation("Getter", []interface{}{arg1})
+	fake.mutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return returns.result1, returns.result2
+}
+
+func (fake *FakeGetter) CallCount() int {
+	fake.mutex.RLock()
+	defer fake.mutex.RUnlock()
+	return len(fake.argsForCall)
+}
+
+func (fake *FakeGetter) Calls(stub func(*gin.Context) (auth.Authorization, bool)) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.Stub = stub
+}
+
+func (fake *FakeGetter) ArgsForCall(i int) *gin.Context {
+	fake.mutex.RLock()
+	defer fake.mutex.RUnlock()
+	return fake.argsForCall[i].arg1
+}
+
+func (fake *FakeGetter) Returns(result1 auth.Authorization, result2 bool) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.Stub = nil
+	fake.returns = struct {
+		result1 auth.Authorization
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeGetter) ReturnsOnCall(i int, result1 auth.Authorization, result2 bool) {
+	fake.mutex.Lock()
+	defer fake.mutex.Unlock()
+	fake.Stub = nil
+	if fake.returnsOnCall == nil {
+		fake.returnsOnCall = make(map[int]struct {
+			result1 auth.Authorization
+			result2 bool
+		})
+	}
+	fake.returnsOnCall[i] = struct {
+		result1 auth.Authorization
+		result2 bool
+	}{result1, result2}
+}
+
+func (fake *FakeGetter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.mutex.RLock()
+	defer fake.mutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeGetter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ auth.Getter = new(FakeGetter).Spy
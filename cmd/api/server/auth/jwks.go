@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//KeySet looks up the public keys that verify asymmetrically signed JWTs
+//go:generate counterfeiter . KeySet
+type KeySet interface {
+	//Key finds the key with the given ID that verifies tokens signed
+	//with alg. kid may be empty if the set has a single key.
+	Key(ctx context.Context, kid string, alg string) (interface{}, error)
+}
+
+//jwksMinRefreshInterval limits how often the set is fetched, so that
+//neither tokens with made-up key IDs nor an unreachable key server cause
+//a fetch on every request
+const jwksMinRefreshInterval = 30 * time.Second
+
+//JWKS is a KeySet read from a JSON Web Key Set, at either an http(s) URL
+//or a file path. Keys are cached, and fetched again once they're older
+//than maxAge or when a token names a key that isn't in the cache.
+type JWKS struct {
+	source     string
+	httpClient *http.Client
+	maxAge     time.Duration
+
+	mu          sync.Mutex
+	keys        map[string]jwksKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+type jwksKey struct {
+	alg string
+	key interface{}
+}
+
+//NewJWKS creates a new JWKS
+func NewJWKS(source string, httpClient *http.Client, maxAge time.Duration) *JWKS {
+	return &JWKS{
+		source:     source,
+		httpClient: httpClient,
+		maxAge:     maxAge,
+	}
+}
+
+//Key finds a key, fetching the set again if needed. If fetching fails
+//but the key is still cached, the cached key is used until a fetch
+//succeeds.
+func (j *JWKS) Key(ctx context.Context, kid string, alg string) (interface{}, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	now := time.Now()
+	key, found := j.find(kid)
+
+	var err error
+	stale := j.keys == nil || now.Sub(j.fetchedAt) > j.maxAge
+	if (stale || !found) && now.Sub(j.attemptedAt) > jwksMinRefreshInterval {
+		err = j.refresh(ctx, now)
+		if err == nil {
+			key, found = j.find(kid)
+		}
+	}
+
+	if !found {
+		if err != nil {
+			return nil, err
+		}
+		if kid == "" {
+			return nil, errors.New("the token doesn't name a key, and there's more than one")
+		}
+		return nil, errors.Errorf("no key with ID `%s` was found", kid)
+	}
+	if key.alg != "" && key.alg != alg {
+		return nil, errors.Errorf("key `%s` is for %s, not %s", kid, key.alg, alg)
+	}
+	return key.key, nil
+}
+
+//find looks a key up in the cache. Tokens without a key ID can only be
+//verified when the set has a single key.
+func (j *JWKS) find(kid string) (jwksKey, bool) {
+	if kid == "" {
+		if len(j.keys) != 1 {
+			return jwksKey{}, false
+		}
+		for _, key := range j.keys {
+			return key, true
+		}
+	}
+
+	key, ok := j.keys[kid]
+	return key, ok
+}
+
+func (j *JWKS) refresh(ctx context.Context, now time.Time) error {
+	j.attemptedAt = now
+
+	body, err := j.read(ctx)
+	if err != nil {
+		return errors.Wrapf(err, "failed reading key set from `%s`", j.source)
+	}
+
+	keys, err := parseJWKS(body)
+	if err != nil {
+		return errors.Wrapf(err, "failed parsing key set from `%s`", j.source)
+	}
+
+	j.keys = keys
+	j.fetchedAt = now
+	return nil
+}
+
+func (j *JWKS) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(j.source, "https://") && !strings.HasPrefix(j.source, "http://") {
+		return ioutil.ReadFile(j.source)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, j.source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := j.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	//key sets are small, so anything much bigger is a misconfiguration
+	return ioutil.ReadAll(io.LimitReader(resp.Body, 1<<20))
+}
+
+//jwk is a single JSON Web Key, as described by RFC 7517. Only the fields
+//of RSA and EC public keys are read.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+
+	//RSA
+	N string `json:"n"`
+	E string `json:"e"`
+
+	//EC
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+//parseJWKS reads the signing keys from a key set, skipping keys of other
+//types or uses
+func parseJWKS(body []byte) (map[string]jwksKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(body, &set); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]jwksKey{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		var key interface{}
+		var err error
+		switch k.Kty {
+		case "RSA":
+			key, err = k.rsaPublicKey()
+		case "EC":
+			key, err = k.ecdsaPublicKey()
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "key `%s` is malformed", k.Kid)
+		}
+
+		keys[k.Kid] = jwksKey{alg: k.Alg, key: key}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("the key set has no RSA or EC signing keys")
+	}
+	return keys, nil
+}
+
+func (k jwk) rsaPublicKey() (*rsa.PublicKey, error) {
+	n, err := decodeJWKInt(k.N)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid modulus")
+	}
+
+	e, err := decodeJWKInt(k.E)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid exponent")
+	}
+	if !e.IsInt64() || e.Int64() > 1<<31-1 {
+		return nil, errors.New("exponent is too large")
+	}
+
+	return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+func (k jwk) ecdsaPublicKey() (*ecdsa.PublicKey, error) {
+	var curve elliptic.Curve
+	switch k.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	case "P-521":
+		curve = elliptic.P521()
+	default:
+		return nil, errors.Errorf("unsupported curve `%s`", k.Crv)
+	}
+
+	x, err := decodeJWKInt(k.X)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid x coordinate")
+	}
+
+	y, err := decodeJWKInt(k.Y)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid y coordinate")
+	}
+
+	if !curve.IsOnCurve(x, y) {
+		return nil, errors.New("the point isn't on the curve")
+	}
+
+	return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+}
+
+//decodeJWKInt decodes an unsigned big-endian integer in unpadded
+//base64url, as JWKs encode them
+func decodeJWKInt(s string) (*big.Int, error) {
+	if s == "" {
+		return nil, errors.New("missing value")
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
@@ -0,0 +1,275 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	testKeysOnce sync.Once
+	testRSAKey   *rsa.PrivateKey
+	testECKey    *ecdsa.PrivateKey
+)
+
+//testKeys generates the keys once, since RSA keys are slow to make
+func testKeys(t *testing.T) (*rsa.PrivateKey, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	var err error
+	testKeysOnce.Do(func() {
+		if testRSAKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			return
+		}
+		testECKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testRSAKey, testECKey
+}
+
+func encodeJWKInt(i *big.Int) string {
+	return base64.RawURLEncoding.EncodeToString(i.Bytes())
+}
+
+func rsaJWK(kid string, alg string, key *rsa.PublicKey) jwk {
+	return jwk{Kty: "RSA", Kid: kid, Use: "sig", Alg: alg, N: encodeJWKInt(key.N), E: encodeJWKInt(big.NewInt(int64(key.E)))}
+}
+
+func ecJWK(kid string, alg string, key *ecdsa.PublicKey) jwk {
+	return jwk{Kty: "EC", Kid: kid, Alg: alg, Crv: "P-256", X: encodeJWKInt(key.X), Y: encodeJWKInt(key.Y)}
+}
+
+func keySet(t *testing.T, keys ...jwk) []byte {
+	t.Helper()
+
+	body, err := json.Marshal(map[string]interface{}{"keys": keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey, ecKey := testKeys(t)
+
+	keys, err := parseJWKS(keySet(t,
+		rsaJWK("rsa-1", "RS256", &rsaKey.PublicKey),
+		ecJWK("ec-1", "", &ecKey.PublicKey),
+		//not for signing, or not asymmetric
+		jwk{Kty: "RSA", Kid: "rsa-enc", Use: "enc", N: "AQAB", E: "AQAB"},
+		jwk{Kty: "oct", Kid: "secret"},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("parsed %d keys, want 2", len(keys))
+	}
+
+	if key, ok := keys["rsa-1"].key.(*rsa.PublicKey); !ok || key.N.Cmp(rsaKey.N) != 0 || key.E != rsaKey.E || keys["rsa-1"].alg != "RS256" {
+		t.Errorf("RSA key = %+v", keys["rsa-1"])
+	}
+	if key, ok := keys["ec-1"].key.(*ecdsa.PublicKey); !ok || key.X.Cmp(ecKey.X) != 0 || key.Y.Cmp(ecKey.Y) != 0 || key.Curve != elliptic.P256() {
+		t.Errorf("EC key = %+v", keys["ec-1"])
+	}
+}
+
+func TestParseJWKSErrors(t *testing.T) {
+	rsaKey, ecKey := testKeys(t)
+
+	offCurve := ecJWK("ec-1", "", &ecKey.PublicKey)
+	offCurve.Y = encodeJWKInt(new(big.Int).Add(ecKey.Y, big.NewInt(1)))
+	otherCurve := ecJWK("ec-1", "", &ecKey.PublicKey)
+	otherCurve.Crv = "secp256k1"
+	noModulus := rsaJWK("rsa-1", "", &rsaKey.PublicKey)
+	noModulus.N = ""
+	badExponent := rsaJWK("rsa-1", "", &rsaKey.PublicKey)
+	badExponent.E = "not base64!"
+	hugeExponent := rsaJWK("rsa-1", "", &rsaKey.PublicKey)
+	hugeExponent.E = encodeJWKInt(big.NewInt(1 << 32))
+
+	for name, body := range map[string][]byte{
+		"not JSON":         []byte("<html>"),
+		"no keys":          keySet(t),
+		"no signing keys":  keySet(t, jwk{Kty: "oct", Kid: "secret"}),
+		"off the curve":    keySet(t, offCurve),
+		"unknown curve":    keySet(t, otherCurve),
+		"no modulus":       keySet(t, noModulus),
+		"bad exponent":     keySet(t, badExponent),
+		"exponent too big": keySet(t, hugeExponent),
+	} {
+		if keys, err := parseJWKS(body); err == nil {
+			t.Errorf("%s: parsed %v", name, keys)
+		}
+	}
+}
+
+//jwksServer serves whichever key set is current, counting the fetches
+type jwksServer struct {
+	*httptest.Server
+
+	mu      sync.Mutex
+	body    []byte
+	status  int
+	fetches int
+}
+
+func newJWKSServer(body []byte) *jwksServer {
+	s := &jwksServer{body: body, status: http.StatusOK}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.fetches++
+		w.WriteHeader(s.status)
+		w.Write(s.body) //nolint:errcheck
+	}))
+	return s
+}
+
+func (s *jwksServer) serve(status int, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status, s.body = status, body
+}
+
+func (s *jwksServer) fetchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.fetches
+}
+
+//rewind makes the set look like it was fetched, and last attempted, age
+//ago
+func (j *JWKS) rewind(age time.Duration) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.fetchedAt = j.fetchedAt.Add(-age)
+	j.attemptedAt = j.attemptedAt.Add(-age)
+}
+
+func TestJWKSKey(t *testing.T) {
+	rsaKey, ecKey := testKeys(t)
+	server := newJWKSServer(keySet(t,
+		rsaJWK("rsa-1", "RS256", &rsaKey.PublicKey),
+		ecJWK("ec-1", "", &ecKey.PublicKey),
+	))
+	defer server.Close()
+
+	ctx := context.Background()
+	j := NewJWKS(server.URL, server.Client(), time.Hour)
+
+	if key, err := j.Key(ctx, "rsa-1", "RS256"); err != nil {
+		t.Error(err)
+	} else if key.(*rsa.PublicKey).N.Cmp(rsaKey.N) != 0 {
+		t.Error("got the wrong RSA key")
+	}
+	//keys without an alg can be used with any
+	if _, err := j.Key(ctx, "ec-1", "ES256"); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := j.Key(ctx, "rsa-1", "RS512"); err == nil || !strings.Contains(err.Error(), "is for RS256") {
+		t.Errorf("using an RS256 key for RS512: %v", err)
+	}
+	if _, err := j.Key(ctx, "rsa-2", "RS256"); err == nil || !strings.Contains(err.Error(), "no key with ID `rsa-2`") {
+		t.Errorf("finding an unknown key: %v", err)
+	}
+	if _, err := j.Key(ctx, "", "RS256"); err == nil || !strings.Contains(err.Error(), "more than one") {
+		t.Errorf("finding an unnamed key: %v", err)
+	}
+
+	if n := server.fetchCount(); n != 1 {
+		t.Errorf("the set was fetched %d times, want 1", n)
+	}
+}
+
+func TestJWKSRefresh(t *testing.T) {
+	rsaKey, ecKey := testKeys(t)
+	server := newJWKSServer(keySet(t, rsaJWK("rsa-1", "RS256", &rsaKey.PublicKey)))
+	defer server.Close()
+
+	ctx := context.Background()
+	j := NewJWKS(server.URL, server.Client(), time.Hour)
+
+	//with a single key, tokens needn't name it
+	if _, err := j.Key(ctx, "", "RS256"); err != nil {
+		t.Fatal(err)
+	}
+
+	//a new key isn't looked for again until the interval has passed
+	server.serve(http.StatusOK, keySet(t,
+		rsaJWK("rsa-1", "RS256", &rsaKey.PublicKey),
+		ecJWK("ec-1", "ES256", &ecKey.PublicKey),
+	))
+	for i := 0; i < 3; i++ {
+		if _, err := j.Key(ctx, "ec-1", "ES256"); err == nil {
+			t.Fatal("found a key without fetching the set")
+		}
+	}
+	if n := server.fetchCount(); n != 1 {
+		t.Fatalf("the set was fetched %d times, want 1", n)
+	}
+
+	j.rewind(jwksMinRefreshInterval + time.Second)
+	if _, err := j.Key(ctx, "ec-1", "ES256"); err != nil {
+		t.Fatal(err)
+	}
+	if n := server.fetchCount(); n != 2 {
+		t.Fatalf("the set was fetched %d times, want 2", n)
+	}
+
+	//once the set is stale, failed fetches fall back to the cached keys,
+	//and aren't retried on every request either
+	server.serve(http.StatusInternalServerError, nil)
+	j.rewind(2 * time.Hour)
+	for i := 0; i < 3; i++ {
+		if _, err := j.Key(ctx, "rsa-1", "RS256"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := server.fetchCount(); n != 3 {
+		t.Fatalf("the set was fetched %d times, want 3", n)
+	}
+
+	//but a key that isn't cached can't be found
+	j.rewind(jwksMinRefreshInterval + time.Second)
+	if _, err := j.Key(ctx, "rsa-2", "RS256"); err == nil || !strings.Contains(err.Error(), "unexpected status 500") {
+		t.Errorf("finding a key while the server is down: %v", err)
+	}
+}
+
+func TestJWKSFile(t *testing.T) {
+	rsaKey, _ := testKeys(t)
+
+	dir, err := ioutil.TempDir("", "jwks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "jwks.json")
+	if err := ioutil.WriteFile(path, keySet(t, rsaJWK("rsa-1", "RS256", &rsaKey.PublicKey)), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	j := NewJWKS(path, nil, time.Hour)
+	if _, err := j.Key(context.Background(), "rsa-1", "RS256"); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,10 +1,14 @@
 package auth
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/gin-gonic/gin"
@@ -25,6 +29,20 @@ type Authorization struct {
 	Email    string `json:"eml,omitempty"`
 	Admin    bool   `json:"login.adm,omitempty"`
 	User     bool   `json:"login.user,omitempty"`
+
+	//Audience replaces the claim in StandardClaims, which can't hold
+	//the list of audiences that many issuers send
+	Audience Audience `json:"aud,omitempty"`
+
+	//PersonalAccessTokenUUID is set when the request was made with a
+	//personal access token, which is limited to its Scopes
+	PersonalAccessTokenUUID string  `json:"-"`
+	Scopes                  []Scope `json:"-"`
+
+	//Role is the user's role, which grants its Permissions; both are
+	//loaded from the database rather than the JWT
+	Role        string  `json:"-"`
+	Permissions []Scope `json:"-"`
 }
 
 //Valid applies standard JWT validations as well as generic
@@ -41,6 +59,49 @@ func (a *Authorization) Valid() error {
 	return nil
 }
 
+//ValidFor checks that the token was issued by issuer for audience,
+//either of which is skipped if empty
+func (a *Authorization) ValidFor(issuer, audience string) error {
+	if issuer != "" && a.Issuer != issuer {
+		return fmt.Errorf("token was not issued by `%s`", issuer)
+	}
+
+	if audience != "" && !a.Audience.Contains(audience) {
+		return fmt.Errorf("token is not intended for `%s`", audience)
+	}
+
+	return nil
+}
+
+//Audience is the `aud` claim, which may be either a string or a list
+type Audience []string
+
+//UnmarshalJSON accepts either form of the claim
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return errors.New("aud must be a string or a list of strings")
+	}
+	*a = Audience(list)
+	return nil
+}
+
+//Contains reports whether audience is one of the token's audiences
+func (a Audience) Contains(audience string) bool {
+	for _, aud := range a {
+		if aud == audience {
+			return true
+		}
+	}
+	return false
+}
+
 //Getter is a helper for grabbing the Authorization
 //that the middleware stores in the context.
 //go:generate counterfeiter . Getter
@@ -68,21 +129,31 @@ type AuthorizationManager interface {
 	FrontendMiddleware() gin.HandlerFunc
 }
 
-//JWTAuthorizationManager provides a JWT-based implementation of AuthorizationManager
+//JWTAuthorizationManager provides a JWT-based implementation of AuthorizationManager.
+//Logins may be signed with a shared HMAC secret, with a key from a KeySet,
+//or either, depending on which are configured.
 type JWTAuthorizationManager struct {
 	logger          tools.Logger
 	renderer        views.Renderer
 	signingSecret   string
+	keySet          KeySet
+	issuer          string
+	audience        string
 	authorizer      Authorizer
 	db              db.DB
 	loginBaseURLRef *url.URL
 }
 
-//NewAuthorizationManager creates a new JWTAuthorizationManager
+//NewAuthorizationManager creates a new JWTAuthorizationManager. Either
+//signingSecret or keySet may be left empty to reject the tokens it would
+//verify, and issuer and audience are only checked if given.
 func NewAuthorizationManager(
 	logger tools.Logger,
 	renderer views.Renderer,
 	signingSecret string,
+	keySet KeySet,
+	issuer string,
+	audience string,
 	authorizer Authorizer,
 	db db.DB,
 	loginBaseURLRef *url.URL,
@@ -91,39 +162,141 @@ func NewAuthorizationManager(
 		logger:          logger,
 		renderer:        renderer,
 		signingSecret:   signingSecret,
+		keySet:          keySet,
+		issuer:          issuer,
+		audience:        audience,
 		authorizer:      authorizer,
 		db:              db,
 		loginBaseURLRef: loginBaseURLRef,
 	}
 }
 
-func (a JWTAuthorizationManager) getAuthorizationFromString(tokenString string) (Authorization, error) {
+func (a JWTAuthorizationManager) getAuthorizationFromString(ctx context.Context, tokenString string) (Authorization, error) {
 	var auth Authorization
 	_, err := a.authorizer.ParseWithClaims(tokenString, &auth, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("must use HMAC signing")
-		}
+		switch token.Method.(type) {
+		case *jwt.SigningMethodHMAC:
+			if a.signingSecret == "" {
+				return nil, errors.New("HMAC signing is not accepted")
+			}
+			return []byte(a.signingSecret), nil
+
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodECDSA:
+			if a.keySet == nil {
+				return nil, errors.New("only HMAC signing is accepted")
+			}
+			kid, _ := token.Header["kid"].(string)
+			return a.keySet.Key(ctx, kid, token.Method.Alg())
 
-		return []byte(a.signingSecret), nil
+		default:
+			return nil, errors.New("must use HMAC, RSA or ECDSA signing")
+		}
 	})
 	if err != nil {
 		return Authorization{}, err
 	}
 
+	if err := auth.ValidFor(a.issuer, a.audience); err != nil {
+		return Authorization{}, err
+	}
+
 	return auth, nil
 }
 
-func (a JWTAuthorizationManager) requireServiceAccess(c *gin.Context, auth Authorization) (bool, error) {
-	if auth.User || auth.Admin {
+//SessionCookieName is the cookie that holds the login JWT for the
+//frontend, which the single-page app also sends as a bearer token
+const SessionCookieName = "_identify_jwt_string"
+
+//NewSessionToken signs a login JWT for a user with the HMAC secret, with
+//the issuer and audience that the manager expects
+func (a JWTAuthorizationManager) NewSessionToken(userUUID string, email string, lifetime time.Duration) (string, error) {
+	if a.signingSecret == "" {
+		return "", errors.New("sessions can't be issued without an HMAC signing secret")
+	}
+
+	now := time.Now()
+	auth := Authorization{
+		StandardClaims: jwt.StandardClaims{
+			Issuer:    a.issuer,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(lifetime).Unix(),
+		},
+		UserUUID: userUUID,
+		Email:    email,
+	}
+	if a.audience != "" {
+		auth.Audience = Audience{a.audience}
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, &auth).SignedString([]byte(a.signingSecret))
+}
+
+//errInvalidPersonalAccessToken is returned for tokens that don't exist,
+//have been revoked or have expired
+var errInvalidPersonalAccessToken = errors.New("invalid personal access token")
+
+func (a JWTAuthorizationManager) getAuthorizationFromPersonalAccessToken(c *gin.Context, tokenString string) (Authorization, error) {
+	token, err := a.db.UsePersonalAccessToken(c, HashPersonalAccessToken(tokenString))
+	if err == db.ErrNoSuchPersonalAccessToken {
+		return Authorization{}, errInvalidPersonalAccessToken
+	}
+	if err != nil {
+		return Authorization{}, err
+	}
+
+	auth := Authorization{
+		UserUUID:                token.UserUUID,
+		PersonalAccessTokenUUID: token.UUID,
+	}
+	for _, scope := range token.Scopes {
+		auth.Scopes = append(auth.Scopes, Scope(scope))
+	}
+	return auth, nil
+}
+
+//requireServiceAccess loads the user's role, reporting whether they may
+//use the service at all. Deactivated users may not, whatever their
+//claims. Otherwise, JWTs with the admin claim get the admin role, and
+//those with the user claim may be used by unregistered users, who are
+//members. Admin JWTs may have no identity, and are let in without one.
+func (a JWTAuthorizationManager) requireServiceAccess(c *gin.Context, auth *Authorization) (bool, error) {
+	if auth.Admin && auth.UserUUID == "" {
+		auth.Role, auth.Permissions = RoleAdmin, DefaultRoles[RoleAdmin]
+		return true, nil
+	}
+
+	role, permissions, err := a.db.GetUserPermissions(c, auth.UserUUID)
+	if err == db.ErrUserDeactivated {
+		return false, nil
+	}
+	if err != nil && err != db.ErrNoSuchUser {
+		return false, err
+	}
+
+	if auth.Admin {
+		auth.Role, auth.Permissions = RoleAdmin, DefaultRoles[RoleAdmin]
 		return true, nil
 	}
 
-	return a.db.CheckUser(c, auth.UserUUID)
+	if err == db.ErrNoSuchUser {
+		if !auth.User {
+			return false, nil
+		}
+		auth.Role, auth.Permissions = RoleMember, DefaultRoles[RoleMember]
+		return true, nil
+	}
+
+	auth.Role = role
+	auth.Permissions = nil
+	for _, permission := range permissions {
+		auth.Permissions = append(auth.Permissions, Scope(permission))
+	}
+	return true, nil
 }
 
-//BackendMiddleware checks for a JWT in a bearer token on the request
-//and converts it into an Authorzation struct, which is stored in
-//the context.
+//BackendMiddleware checks for a JWT or personal access token in a
+//bearer token on the request and converts it into an Authorzation
+//struct, which is stored in the context.
 func (a JWTAuthorizationManager) BackendMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -133,13 +306,28 @@ func (a JWTAuthorizationManager) BackendMiddleware() gin.HandlerFunc {
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		auth, err := a.getAuthorizationFromString(tokenString)
+
+		var auth Authorization
+		var err error
+		if strings.HasPrefix(tokenString, PersonalAccessTokenPrefix) {
+			auth, err = a.getAuthorizationFromPersonalAccessToken(c, tokenString)
+			if err != nil && err != errInvalidPersonalAccessToken {
+				a.logger.Errorf(err.Error())
+				c.AbortWithStatusJSON(
+					http.StatusInternalServerError,
+					gin.H{"error": "An internal error occurred"},
+				)
+				return
+			}
+		} else {
+			auth, err = a.getAuthorizationFromString(c, tokenString)
+		}
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
 			return
 		}
 
-		ok, err := a.requireServiceAccess(c, auth)
+		ok, err := a.requireServiceAccess(c, &auth)
 		if err != nil {
 			a.logger.Errorf(err.Error())
 			c.AbortWithStatusJSON(
@@ -187,19 +375,19 @@ func (a JWTAuthorizationManager) FrontendMiddleware() gin.HandlerFunc {
 	}
 
 	return func(c *gin.Context) {
-		jwtCookie, err := c.Request.Cookie("_identify_jwt_string")
+		jwtCookie, err := c.Request.Cookie(SessionCookieName)
 		if err != nil {
 			redirectToLogin(c)
 			return
 		}
 
-		auth, err := a.getAuthorizationFromString(jwtCookie.Value)
+		auth, err := a.getAuthorizationFromString(c, jwtCookie.Value)
 		if err != nil {
 			redirectToLogin(c)
 			return
 		}
 
-		ok, err := a.requireServiceAccess(c, auth)
+		ok, err := a.requireServiceAccess(c, &auth)
 		if err != nil {
 			a.logger.Errorf(err.Error())
 			a.renderer.RenderStatusCode(
@@ -0,0 +1,319 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth/authfakes"
+	"github.com/xanderflood/plaid-ui/lib/tools/toolsfakes"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/db/dbfakes"
+)
+
+const testSigningSecret = "test-signing-secret"
+
+func signHMAC(t *testing.T, claims auth.Authorization) string {
+	t.Helper()
+
+	claims.ExpiresAt = time.Now().Add(time.Hour).Unix()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims).SignedString([]byte(testSigningSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+//serveBackend makes a request with the token through BackendMiddleware,
+//returning the status and the Authorization that handlers would see
+func serveBackend(t *testing.T, dbClient db.DB, token string) (int, auth.Authorization) {
+	t.Helper()
+
+	manager := auth.NewAuthorizationManager(&toolsfakes.FakeLogger{}, nil, testSigningSecret, nil, "", "", &jwt.Parser{}, dbClient, nil)
+	return serveManager(t, manager, token)
+}
+
+//serveManager makes a request with the token through the manager's
+//BackendMiddleware
+func serveManager(t *testing.T, manager auth.JWTAuthorizationManager, token string) (int, auth.Authorization) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	var seen auth.Authorization
+	e := gin.New()
+	e.GET("/", manager.BackendMiddleware(), func(c *gin.Context) {
+		seen, _ = auth.GetAuthorizationFromContext(c)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec.Code, seen
+}
+
+func TestBackendMiddlewareServiceAccess(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		claims auth.Authorization
+		role   string
+		err    error
+
+		status   int
+		wantRole string
+		lookedUp bool
+	}{
+		{
+			name:     "admin without an identity",
+			claims:   auth.Authorization{Admin: true},
+			status:   http.StatusOK,
+			wantRole: auth.RoleAdmin,
+		},
+		{
+			name:     "registered admin",
+			claims:   auth.Authorization{UserUUID: "user-1", Admin: true},
+			role:     auth.RoleMember,
+			status:   http.StatusOK,
+			wantRole: auth.RoleAdmin,
+			lookedUp: true,
+		},
+		{
+			name:     "deactivated admin",
+			claims:   auth.Authorization{UserUUID: "user-1", Admin: true},
+			err:      db.ErrUserDeactivated,
+			status:   http.StatusForbidden,
+			lookedUp: true,
+		},
+		{
+			name:     "registered user",
+			claims:   auth.Authorization{UserUUID: "user-1"},
+			role:     "auditor",
+			status:   http.StatusOK,
+			wantRole: "auditor",
+			lookedUp: true,
+		},
+		{
+			name:     "deactivated user",
+			claims:   auth.Authorization{UserUUID: "user-1", User: true},
+			err:      db.ErrUserDeactivated,
+			status:   http.StatusForbidden,
+			lookedUp: true,
+		},
+		{
+			name:     "unregistered user with the user claim",
+			claims:   auth.Authorization{UserUUID: "user-1", User: true},
+			err:      db.ErrNoSuchUser,
+			status:   http.StatusOK,
+			wantRole: auth.RoleMember,
+			lookedUp: true,
+		},
+		{
+			name:     "unregistered user",
+			claims:   auth.Authorization{UserUUID: "user-1"},
+			err:      db.ErrNoSuchUser,
+			status:   http.StatusForbidden,
+			lookedUp: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dbClient := &dbfakes.FakeDB{}
+			dbClient.GetUserPermissionsReturns(tc.role, nil, tc.err)
+
+			status, seen := serveBackend(t, dbClient, signHMAC(t, tc.claims))
+			if status != tc.status {
+				t.Fatalf("status = %d, want %d", status, tc.status)
+			}
+			if seen.Role != tc.wantRole {
+				t.Errorf("role = `%s`, want `%s`", seen.Role, tc.wantRole)
+			}
+			if lookedUp := dbClient.GetUserPermissionsCallCount() > 0; lookedUp != tc.lookedUp {
+				t.Errorf("looked up permissions: %v, want %v", lookedUp, tc.lookedUp)
+			}
+		})
+	}
+}
+
+//TestTokenKeySelection checks which key verifies a token, depending on
+//how it's signed and which kinds of signing are configured
+func TestTokenKeySelection(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherRSAKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sign := func(method jwt.SigningMethod, kid string, key interface{}, claims auth.Authorization) string {
+		claims.ExpiresAt = time.Now().Add(time.Hour).Unix()
+		token := jwt.NewWithClaims(method, &claims)
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	admin := auth.Authorization{Admin: true}
+	issued := auth.Authorization{Admin: true, StandardClaims: jwt.StandardClaims{Issuer: "https://login.plaid-ui.test"}, Audience: auth.Audience{"plaid-ui"}}
+
+	for _, tc := range []struct {
+		name     string
+		secret   string
+		noKeys   bool
+		issuer   string
+		audience string
+		token    string
+
+		status int
+		kid    string
+		alg    string
+	}{
+		{
+			name:   "HMAC",
+			secret: testSigningSecret,
+			token:  sign(jwt.SigningMethodHS256, "", []byte(testSigningSecret), admin),
+			status: http.StatusOK,
+		},
+		{
+			name:   "HMAC with another secret",
+			secret: testSigningSecret,
+			token:  sign(jwt.SigningMethodHS256, "", []byte("another-secret"), admin),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "HMAC without a secret",
+			token:  sign(jwt.SigningMethodHS256, "", []byte(""), admin),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "RSA",
+			secret: testSigningSecret,
+			token:  sign(jwt.SigningMethodRS256, "rsa-1", rsaKey, admin),
+			status: http.StatusOK,
+			kid:    "rsa-1",
+			alg:    "RS256",
+		},
+		{
+			name:   "RSA without a key ID",
+			token:  sign(jwt.SigningMethodRS384, "", rsaKey, admin),
+			status: http.StatusOK,
+			alg:    "RS384",
+		},
+		{
+			name:   "RSA with another key",
+			token:  sign(jwt.SigningMethodRS256, "rsa-1", otherRSAKey, admin),
+			status: http.StatusUnauthorized,
+			kid:    "rsa-1",
+			alg:    "RS256",
+		},
+		{
+			name:   "ECDSA",
+			token:  sign(jwt.SigningMethodES256, "ec-1", ecKey, admin),
+			status: http.StatusOK,
+			kid:    "ec-1",
+			alg:    "ES256",
+		},
+		{
+			name:   "unknown key ID",
+			token:  sign(jwt.SigningMethodRS256, "rsa-2", rsaKey, admin),
+			status: http.StatusUnauthorized,
+			kid:    "rsa-2",
+			alg:    "RS256",
+		},
+		{
+			name:   "RSA without a key set",
+			secret: testSigningSecret,
+			noKeys: true,
+			token:  sign(jwt.SigningMethodRS256, "rsa-1", rsaKey, admin),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "unsigned",
+			secret: testSigningSecret,
+			token:  sign(jwt.SigningMethodNone, "", jwt.UnsafeAllowNoneSignatureType, admin),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:     "expected issuer and audience",
+			issuer:   "https://login.plaid-ui.test",
+			audience: "plaid-ui",
+			token:    sign(jwt.SigningMethodRS256, "rsa-1", rsaKey, issued),
+			status:   http.StatusOK,
+			kid:      "rsa-1",
+			alg:      "RS256",
+		},
+		{
+			name:   "another issuer",
+			issuer: "https://login.example.com",
+			token:  sign(jwt.SigningMethodRS256, "rsa-1", rsaKey, issued),
+			status: http.StatusUnauthorized,
+			kid:    "rsa-1",
+			alg:    "RS256",
+		},
+		{
+			name:     "another audience",
+			audience: "another-app",
+			token:    sign(jwt.SigningMethodRS256, "rsa-1", rsaKey, issued),
+			status:   http.StatusUnauthorized,
+			kid:      "rsa-1",
+			alg:      "RS256",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			keySet := &authfakes.FakeKeySet{}
+			keySet.KeyStub = func(_ context.Context, kid string, _ string) (interface{}, error) {
+				switch kid {
+				case "rsa-1", "":
+					return &rsaKey.PublicKey, nil
+				case "ec-1":
+					return &ecKey.PublicKey, nil
+				}
+				return nil, errors.New("no such key")
+			}
+
+			var keys auth.KeySet = keySet
+			if tc.noKeys {
+				keys = nil
+			}
+			manager := auth.NewAuthorizationManager(&toolsfakes.FakeLogger{}, nil, tc.secret, keys, tc.issuer, tc.audience, &jwt.Parser{}, &dbfakes.FakeDB{}, nil)
+
+			status, _ := serveManager(t, manager, tc.token)
+			if status != tc.status {
+				t.Fatalf("status = %d, want %d", status, tc.status)
+			}
+
+			if tc.alg == "" {
+				if n := keySet.KeyCallCount(); n != 0 {
+					t.Errorf("the key set was consulted %d times", n)
+				}
+				return
+			}
+			if n := keySet.KeyCallCount(); n != 1 {
+				t.Fatalf("the key set was consulted %d times, want 1", n)
+			}
+			if _, kid, alg := keySet.KeyArgsForCall(0); kid != tc.kid || alg != tc.alg {
+				t.Errorf("looked up key `%s` for %s, want `%s` for %s", kid, alg, tc.kid, tc.alg)
+			}
+		})
+	}
+}
@@ -0,0 +1,414 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server/views"
+	"github.com/xanderflood/plaid-ui/lib/tools"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+//oidcLoginCookieName holds the state of a login between the redirect to
+//the provider and the callback
+const oidcLoginCookieName = "_oidc_login"
+
+//oidcLoginLifetime is how long a user has to log in with the provider
+const oidcLoginLifetime = 10 * time.Minute
+
+//OIDCConfig configures the built-in OpenID Connect login
+type OIDCConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+
+	//RedirectURL is the absolute URL of the callback route
+	RedirectURL string
+
+	//SessionLifetime is how long the session cookie lasts
+	SessionLifetime time.Duration
+}
+
+//OIDCRelyingParty logs users in with an OpenID Connect provider, using
+//the authorization code flow with PKCE, and issues the session cookie
+//that FrontendMiddleware expects. Provider subjects are linked to
+//registered users by their verified email the first time they log in.
+type OIDCRelyingParty struct {
+	logger     tools.Logger
+	renderer   views.Renderer
+	config     OIDCConfig
+	httpClient *http.Client
+	manager    JWTAuthorizationManager
+	db         db.DB
+
+	//the provider's configuration is discovered on first use
+	mu       sync.Mutex
+	provider *oidcProvider
+}
+
+//oidcProvider is the part of the provider's discovery document that's used
+type oidcProvider struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+
+	keySet *JWKS
+}
+
+//oidcLoginState is kept in a cookie during a login
+type oidcLoginState struct {
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	ReturnTo string `json:"return_to"`
+}
+
+//idTokenClaims are the claims read from an ID token
+type idTokenClaims struct {
+	jwt.StandardClaims
+	Audience        Audience    `json:"aud"`
+	AuthorizedParty string      `json:"azp"`
+	Nonce           string      `json:"nonce"`
+	Email           string      `json:"email"`
+	EmailVerified   interface{} `json:"email_verified"`
+}
+
+//emailVerified accepts the claim as a boolean or, as some providers
+//send it, a string
+func (c idTokenClaims) emailVerified() bool {
+	return c.EmailVerified == true || c.EmailVerified == "true"
+}
+
+//NewOIDCRelyingParty creates a new OIDCRelyingParty. The manager must
+//have an HMAC signing secret, which is used to sign sessions.
+func NewOIDCRelyingParty(
+	logger tools.Logger,
+	renderer views.Renderer,
+	config OIDCConfig,
+	httpClient *http.Client,
+	manager JWTAuthorizationManager,
+	db db.DB,
+) *OIDCRelyingParty {
+	return &OIDCRelyingParty{
+		logger:     logger,
+		renderer:   renderer,
+		config:     config,
+		httpClient: httpClient,
+		manager:    manager,
+		db:         db,
+	}
+}
+
+//Login starts a login by redirecting to the provider. The `referrer_url`
+//query parameter, as sent by FrontendMiddleware, is where the user is
+//sent back to afterwards.
+func (p *OIDCRelyingParty) Login(c *gin.Context) {
+	provider, err := p.discover(c)
+	if err != nil {
+		p.logger.Errorf(err.Error())
+		p.renderer.RenderStatusCode(http.StatusInternalServerError, "An internal error occurred", c)
+		return
+	}
+
+	state := oidcLoginState{ReturnTo: returnPath(c.Query("referrer_url"), c.Request.Host)}
+	for _, s := range []*string{&state.State, &state.Nonce, &state.Verifier} {
+		if *s, err = randomString(); err != nil {
+			p.logger.Errorf(err.Error())
+			p.renderer.RenderStatusCode(http.StatusInternalServerError, "An internal error occurred", c)
+			return
+		}
+	}
+
+	cookie, err := json.Marshal(state)
+	if err != nil {
+		p.logger.Errorf("failed encoding login state: %s", err.Error())
+		p.renderer.RenderStatusCode(http.StatusInternalServerError, "An internal error occurred", c)
+		return
+	}
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oidcLoginCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(cookie),
+		Path:     "/",
+		MaxAge:   int(oidcLoginLifetime.Seconds()),
+		Secure:   true,
+		HttpOnly: true,
+
+		//the callback is a top-level navigation from the provider, which
+		//lax cookies are sent with
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	challenge := sha256.Sum256([]byte(state.Verifier))
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.config.ClientID},
+		"redirect_uri":          {p.config.RedirectURL},
+		"scope":                 {"openid email"},
+		"state":                 {state.State},
+		"nonce":                 {state.Nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+
+	authURL, err := url.Parse(provider.AuthorizationEndpoint)
+	if err != nil {
+		p.logger.Errorf("provider's authorization endpoint is malformed: %s", err.Error())
+		p.renderer.RenderStatusCode(http.StatusInternalServerError, "An internal error occurred", c)
+		return
+	}
+	if authURL.RawQuery != "" {
+		authURL.RawQuery += "&"
+	}
+	authURL.RawQuery += query.Encode()
+
+	c.Redirect(http.StatusFound, authURL.String())
+	c.Abort()
+}
+
+//Callback finishes a login, exchanging the code for an ID token and
+//issuing a session for the user it identifies
+func (p *OIDCRelyingParty) Callback(c *gin.Context) {
+	state, ok := p.takeLoginState(c)
+	if !ok {
+		p.renderer.RenderStatusCode(http.StatusBadRequest, "The login expired or was started in another browser. Please try again.", c)
+		return
+	}
+
+	if code := c.Query("error"); code != "" {
+		p.logger.Infof("login was refused by the provider: %s %s", code, c.Query("error_description"))
+		p.renderer.RenderStatusCode(http.StatusUnauthorized, "The login was refused by the provider.", c)
+		return
+	}
+
+	if subtle.ConstantTimeCompare([]byte(c.Query("state")), []byte(state.State)) != 1 {
+		p.renderer.RenderStatusCode(http.StatusBadRequest, "The login could not be verified. Please try again.", c)
+		return
+	}
+
+	provider, err := p.discover(c)
+	if err != nil {
+		p.logger.Errorf(err.Error())
+		p.renderer.RenderStatusCode(http.StatusInternalServerError, "An internal error occurred", c)
+		return
+	}
+
+	claims, err := p.exchange(c, provider, c.Query("code"), state)
+	if err != nil {
+		p.logger.Errorf("failed completing login: %s", err.Error())
+		p.renderer.RenderStatusCode(http.StatusUnauthorized, "The login could not be completed.", c)
+		return
+	}
+
+	userUUID, err := p.db.GetUserForIdentity(c, provider.Issuer, claims.Subject)
+	if err == db.ErrNoSuchUser && claims.Email != "" && claims.emailVerified() {
+		userUUID, err = p.db.LinkUserIdentity(c, provider.Issuer, claims.Subject, claims.Email)
+	}
+	if err == db.ErrNoSuchUser {
+		p.renderer.RenderNotRegistered(claims.Email, c)
+		return
+	}
+	if err != nil {
+		p.logger.Errorf(err.Error())
+		p.renderer.RenderStatusCode(http.StatusInternalServerError, "An internal error occurred", c)
+		return
+	}
+
+	token, err := p.manager.NewSessionToken(userUUID, claims.Email, p.config.SessionLifetime)
+	if err != nil {
+		p.logger.Errorf("failed issuing session for user `%s`: %s", userUUID, err.Error())
+		p.renderer.RenderStatusCode(http.StatusInternalServerError, "An internal error occurred", c)
+		return
+	}
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:   SessionCookieName,
+		Value:  token,
+		Path:   "/",
+		MaxAge: int(p.config.SessionLifetime.Seconds()),
+		Secure: true,
+
+		//the single-page app reads the session to send it as a bearer
+		//token, so it can't be HttpOnly
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	c.Redirect(http.StatusFound, state.ReturnTo)
+	c.Abort()
+}
+
+//takeLoginState reads and clears the login state cookie
+func (p *OIDCRelyingParty) takeLoginState(c *gin.Context) (oidcLoginState, bool) {
+	cookie, err := c.Request.Cookie(oidcLoginCookieName)
+	if err != nil {
+		return oidcLoginState{}, false
+	}
+
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     oidcLoginCookieName,
+		Path:     "/",
+		MaxAge:   -1,
+		Secure:   true,
+		HttpOnly: true,
+	})
+
+	raw, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return oidcLoginState{}, false
+	}
+
+	var state oidcLoginState
+	if err := json.Unmarshal(raw, &state); err != nil || state.State == "" {
+		return oidcLoginState{}, false
+	}
+	return state, true
+}
+
+//discover fetches the provider's configuration, which is cached once it
+//has been fetched successfully
+func (p *OIDCRelyingParty) discover(ctx context.Context) (*oidcProvider, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.provider != nil {
+		return p.provider, nil
+	}
+
+	discoveryURL := strings.TrimSuffix(p.config.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequest(http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed building discovery request for `%s`", p.config.Issuer)
+	}
+
+	resp, err := p.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed discovering provider `%s`", p.config.Issuer)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("failed discovering provider `%s`: unexpected status %d", p.config.Issuer, resp.StatusCode)
+	}
+
+	var provider oidcProvider
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&provider); err != nil {
+		return nil, errors.Wrapf(err, "failed parsing discovery document for `%s`", p.config.Issuer)
+	}
+	if provider.Issuer != p.config.Issuer {
+		return nil, errors.Errorf("provider `%s` claims to be `%s`", p.config.Issuer, provider.Issuer)
+	}
+	if provider.AuthorizationEndpoint == "" || provider.TokenEndpoint == "" || provider.JWKSURI == "" {
+		return nil, errors.Errorf("provider `%s` is missing an endpoint or key set", p.config.Issuer)
+	}
+
+	provider.keySet = NewJWKS(provider.JWKSURI, p.httpClient, time.Hour)
+	p.provider = &provider
+	return p.provider, nil
+}
+
+//exchange redeems an authorization code and verifies the ID token
+func (p *OIDCRelyingParty) exchange(ctx context.Context, provider *oidcProvider, code string, state oidcLoginState) (idTokenClaims, error) {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.config.RedirectURL},
+		"client_id":     {p.config.ClientID},
+		"code_verifier": {state.Verifier},
+	}
+	req, err := http.NewRequest(http.MethodPost, provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return idTokenClaims{}, errors.Wrap(err, "failed building token request")
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.config.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.config.ClientID), url.QueryEscape(p.config.ClientSecret))
+	}
+
+	resp, err := p.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return idTokenClaims{}, errors.Wrap(err, "token request failed")
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return idTokenClaims{}, errors.Wrapf(err, "failed parsing token response with status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return idTokenClaims{}, errors.Errorf("token endpoint responded %d: %s %s", resp.StatusCode, body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return idTokenClaims{}, errors.New("token response has no ID token")
+	}
+
+	var claims idTokenClaims
+	parser := &jwt.Parser{ValidMethods: []string{"RS256", "ES256"}}
+	_, err = parser.ParseWithClaims(body.IDToken, &claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return provider.keySet.Key(ctx, kid, token.Method.Alg())
+	})
+	if err != nil {
+		return idTokenClaims{}, errors.Wrap(err, "invalid ID token")
+	}
+
+	switch {
+	case claims.Issuer != provider.Issuer:
+		return idTokenClaims{}, errors.Errorf("ID token was issued by `%s`", claims.Issuer)
+	case !claims.Audience.Contains(p.config.ClientID):
+		return idTokenClaims{}, errors.New("ID token is for another client")
+	case len(claims.Audience) > 1 && claims.AuthorizedParty != p.config.ClientID:
+		return idTokenClaims{}, errors.New("ID token was issued to another client")
+	case claims.ExpiresAt == 0:
+		return idTokenClaims{}, errors.New("ID token has no expiry")
+	case claims.Subject == "":
+		return idTokenClaims{}, errors.New("ID token has no subject")
+	case subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(state.Nonce)) != 1:
+		return idTokenClaims{}, errors.New("ID token nonce doesn't match the login")
+	}
+	return claims, nil
+}
+
+//returnPath reduces a referrer URL to a path on this host, so that
+//logins can't be used to redirect users elsewhere
+func returnPath(referrer string, host string) string {
+	u, err := url.Parse(referrer)
+	if err != nil || (u.Host != "" && u.Host != host) {
+		return "/"
+	}
+
+	path := u.EscapedPath()
+	if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") || strings.Contains(path, "\\") {
+		return "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return path
+}
+
+//randomString returns 32 random bytes, encoded for use in URLs
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed generating login state")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+//the roles that are created by default. Roles and their permissions are
+//stored in the database, so others can be added there.
+const (
+	RoleAdmin   = "admin"
+	RoleMember  = "member"
+	RoleViewer  = "viewer"
+	RoleSupport = "support"
+)
+
+//staff permissions, which concern every user rather than the user's own
+//data, and so can't be granted to personal access tokens
+const (
+	ScopeUsersWrite  Scope = "users:write"
+	ScopeJobsRead    Scope = "jobs:read"
+	ScopeSupportRead Scope = "support:read"
+	ScopeAuditRead   Scope = "audit:read"
+)
+
+//StaffScopes lists the staff permissions
+var StaffScopes = []Scope{
+	ScopeUsersWrite,
+	ScopeJobsRead,
+	ScopeSupportRead,
+	ScopeAuditRead,
+}
+
+//DefaultRoles are the permissions each role is created with. Support
+//staff can see the status of any user's items and webhook deliveries,
+//but not their accounts or transactions.
+var DefaultRoles = map[string][]Scope{
+	RoleAdmin:   append(append([]Scope{}, Scopes...), StaffScopes...),
+	RoleMember:  Scopes,
+	RoleViewer:  readScopes(),
+	RoleSupport: {ScopeSupportRead, ScopeJobsRead},
+}
+
+func readScopes() []Scope {
+	var scopes []Scope
+	for _, scope := range Scopes {
+		if strings.HasSuffix(string(scope), ":read") {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+//Can reports whether the authorization allows an action. The user's
+//role must grant the permission and, for personal access tokens, so must
+//the token.
+func (a Authorization) Can(permission Scope) bool {
+	return a.roleGrants(permission) && a.tokenGrants(permission)
+}
+
+func (a Authorization) roleGrants(permission Scope) bool {
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+//tokenGrants checks a personal access token's scopes. Logins aren't
+//limited by scopes.
+func (a Authorization) tokenGrants(permission Scope) bool {
+	if a.PersonalAccessTokenUUID == "" {
+		return true
+	}
+	for _, s := range a.Scopes {
+		if s == permission {
+			return true
+		}
+	}
+	return false
+}
+
+//Require rejects requests whose authorization doesn't allow all of the
+//permissions. It has to run after BackendMiddleware.
+func Require(permissions ...Scope) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auth, ok := GetAuthorizationFromContext(c)
+		if !ok {
+			return //an error response has already been generated
+		}
+
+		for _, permission := range permissions {
+			if !auth.roleGrants(permission) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "the `" + auth.Role + "` role doesn't allow `" + string(permission) + "`"})
+				return
+			}
+			if !auth.tokenGrants(permission) {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "this token lacks the `" + string(permission) + "` scope"})
+				return
+			}
+		}
+	}
+}
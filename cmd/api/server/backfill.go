@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/plaid/plaid-go/plaid"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//backfillWindowDays is how many days of history are pulled per page
+const backfillWindowDays = 90
+
+//startBackfill queues an import of an item's full history, which
+//BackfillHistory then works through
+func (a ServerAgent) startBackfill(ctx context.Context, itemID string) error {
+	now := time.Now()
+	return a.dbClient.StartItemBackfill(ctx, itemID,
+		now.AddDate(-a.backfillYears, 0, 0).Format(plaidapi.DateFormat),
+		now.Format(plaidapi.DateFormat),
+	)
+}
+
+//BackfillHistory imports history for items with a backfill underway, one
+//window at a time, until they're done or budget runs out. Progress is
+//saved after every window, so the next run resumes where this one
+//stopped.
+func (a ServerAgent) BackfillHistory(ctx context.Context, budget time.Duration) error {
+	deadline := time.Now().Add(budget)
+	for {
+		items, err := a.dbClient.GetBackfillingItems(ctx)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+
+		failed := 0
+		for _, item := range items {
+			if time.Now().After(deadline) {
+				return nil
+			}
+
+			if err := a.backfillWindow(ctx, item); err != nil {
+				a.logger.Errorf("failed backfilling plaid item `%s`: %s", item.PlaidItemID, err.Error())
+				failed++
+			}
+		}
+
+		//items that failed are still queued, so don't spin on them
+		if failed > 0 {
+			return errors.Errorf("failed backfilling %d of %d items", failed, len(items))
+		}
+	}
+}
+
+//backfillWindow imports the next window of an item's history
+func (a ServerAgent) backfillWindow(ctx context.Context, item db.Item) error {
+	backfill := item.Backfill
+
+	end, err := time.Parse(plaidapi.DateFormat, backfill.EndDate)
+	if err != nil {
+		return errors.Wrapf(err, "invalid backfill end date")
+	}
+	if backfill.OldestDate != "" {
+		oldest, err := time.Parse(plaidapi.DateFormat, backfill.OldestDate)
+		if err != nil {
+			return errors.Wrapf(err, "invalid backfill progress date")
+		}
+		end = oldest.AddDate(0, 0, -1)
+	}
+
+	start := end.AddDate(0, 0, 1-backfillWindowDays)
+	if s := start.Format(plaidapi.DateFormat); s < backfill.StartDate {
+		start, _ = time.Parse(plaidapi.DateFormat, backfill.StartDate)
+	}
+
+	imported, err := a.importRange(ctx, item, start, end)
+	if err != nil {
+		backfill.Error = err.Error()
+
+		//an item error won't go away by retrying, so give up until the
+		//user fixes the item and Plaid sends history again
+		if plaidErr, ok := errors.Cause(err).(plaid.Error); ok && plaidErr.ErrorType == "ITEM_ERROR" {
+			backfill.Status = db.BackfillStatusFailed
+			a.recordItemError(ctx, item.PlaidItemID, err)
+		}
+		if err := a.dbClient.SetItemBackfill(ctx, item.UUID, backfill); err != nil {
+			a.logger.Errorf("failed recording backfill error for item `%s`: %s", item.UUID, err.Error())
+		}
+		return err
+	}
+
+	backfill.OldestDate = start.Format(plaidapi.DateFormat)
+	backfill.Imported += imported
+	backfill.Error = ""
+	if backfill.OldestDate <= backfill.StartDate {
+		backfill.Status = db.BackfillStatusComplete
+	}
+
+	if err := a.dbClient.SetItemBackfill(ctx, item.UUID, backfill); err != nil {
+		return err
+	}
+
+	if backfill.Status == db.BackfillStatusComplete {
+		a.logger.Infof("finished backfilling plaid item `%s` with %d transactions", item.PlaidItemID, backfill.Imported)
+		if _, err := a.detectTransfers(ctx, item.UserUUID); err != nil {
+			a.logger.Errorf("failed detecting transfers for user `%s`: %s", item.UserUUID, err.Error())
+		}
+	}
+	return nil
+}
+
+//importRange pulls every transaction between two dates for an item and
+//returns how many were new
+func (a ServerAgent) importRange(ctx context.Context, item db.Item, start time.Time, end time.Time) (int, error) {
+	accts, err := a.dbClient.GetAccountsByPlaidItemID(ctx, item.PlaidItemID)
+	if err != nil {
+		return 0, err
+	}
+	if len(accts) == 0 {
+		return 0, errors.Errorf("plaid item `%s` has no accounts", item.PlaidItemID)
+	}
+
+	var accountMapping = map[string]db.Account{}
+	for _, account := range accts {
+		accountMapping[account.PlaidAccountID] = account
+	}
+
+	added, err := a.pullTransactions(ctx, item.UserUUID, accts[0].PlaidAccessToken, accountMapping, start, end)
+	return len(added), err
+}
@@ -0,0 +1,222 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/plaid/plaid-go/plaid"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/transfers/transfersfakes"
+	"github.com/xanderflood/plaid-ui/pkg/webhooks/webhooksfakes"
+)
+
+func newBackfillTestServer() testServer {
+	s := newTestServer()
+	s.transferMatcher = &transfersfakes.FakeMatcher{}
+	s.webhookDispatcher = &webhooksfakes.FakeDispatcher{}
+
+	s.db.GetAccountsByPlaidItemIDReturns([]db.Account{{
+		Model:            db.Model{UUID: testAccountUUID},
+		UserUUID:         testUserUUID,
+		PlaidAccessToken: "access-token",
+		PlaidAccountID:   "plaid-acct-1",
+		PlaidItemID:      "item-1",
+	}}, nil)
+	s.plaid.GetTransactionsWithOptionsReturns(plaid.GetTransactionsResponse{
+		Transactions: []plaid.Transaction{
+			{ID: "plaid-txn-1", AccountID: "plaid-acct-1", Date: "2019-12-01"},
+			{ID: "plaid-txn-2", AccountID: "plaid-acct-1", Date: "2019-12-02"},
+		},
+		TotalTransactions: 2,
+	}, nil)
+	s.db.UpsertTransactionReturnsOnCall(0, testTransactionUUID, true, nil)
+	//already saved and unchanged
+	s.db.UpsertTransactionReturnsOnCall(1, "", false, nil)
+	return s
+}
+
+func backfillingItem(backfill db.Backfill) db.Item {
+	return db.Item{
+		Model:       db.Model{UUID: testItemUUID},
+		UserUUID:    testUserUUID,
+		PlaidItemID: "item-1",
+		Backfill:    backfill,
+	}
+}
+
+func TestBackfillWindow(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		backfill db.Backfill
+
+		start string
+		end   string
+		saved db.Backfill
+	}{
+		{
+			name:     "first window",
+			backfill: db.Backfill{Status: db.BackfillStatusInProgress, StartDate: "2018-01-31", EndDate: "2020-01-31"},
+			start:    "2019-11-03",
+			end:      "2020-01-31",
+			saved:    db.Backfill{Status: db.BackfillStatusInProgress, StartDate: "2018-01-31", EndDate: "2020-01-31", OldestDate: "2019-11-03", Imported: 1},
+		},
+		{
+			name:     "resumed",
+			backfill: db.Backfill{Status: db.BackfillStatusInProgress, StartDate: "2018-01-31", EndDate: "2020-01-31", OldestDate: "2019-11-03", Imported: 5, Error: "timed out"},
+			start:    "2019-08-05",
+			end:      "2019-11-02",
+			saved:    db.Backfill{Status: db.BackfillStatusInProgress, StartDate: "2018-01-31", EndDate: "2020-01-31", OldestDate: "2019-08-05", Imported: 6},
+		},
+		{
+			name:     "last window",
+			backfill: db.Backfill{Status: db.BackfillStatusInProgress, StartDate: "2019-10-01", EndDate: "2020-01-31", OldestDate: "2019-11-03", Imported: 5},
+			start:    "2019-10-01",
+			end:      "2019-11-02",
+			saved:    db.Backfill{Status: db.BackfillStatusComplete, StartDate: "2019-10-01", EndDate: "2020-01-31", OldestDate: "2019-10-01", Imported: 6},
+		},
+		{
+			name:     "shorter than a window",
+			backfill: db.Backfill{Status: db.BackfillStatusInProgress, StartDate: "2020-01-01", EndDate: "2020-01-31"},
+			start:    "2020-01-01",
+			end:      "2020-01-31",
+			saved:    db.Backfill{Status: db.BackfillStatusComplete, StartDate: "2020-01-01", EndDate: "2020-01-31", OldestDate: "2020-01-01", Imported: 1},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := newBackfillTestServer()
+
+			if err := s.backfillWindow(context.Background(), backfillingItem(test.backfill)); err != nil {
+				t.Fatal(err)
+			}
+
+			if n := s.plaid.GetTransactionsWithOptionsCallCount(); n != 1 {
+				t.Fatalf("Plaid was asked for transactions %d times, want 1", n)
+			}
+			accessToken, options := s.plaid.GetTransactionsWithOptionsArgsForCall(0)
+			if accessToken != "access-token" || options.StartDate != test.start || options.EndDate != test.end {
+				t.Errorf("pulled `%s` to `%s` with `%s`, want `%s` to `%s`", options.StartDate, options.EndDate, accessToken, test.start, test.end)
+			}
+
+			if n := s.db.SetItemBackfillCallCount(); n != 1 {
+				t.Fatalf("progress was saved %d times, want 1", n)
+			}
+			_, uuid, saved := s.db.SetItemBackfillArgsForCall(0)
+			if uuid != testItemUUID || !reflect.DeepEqual(saved, test.saved) {
+				t.Errorf("saved %+v for `%s`, want %+v", saved, uuid, test.saved)
+			}
+
+			//transfers are only looked for once the whole history is in
+			detected := s.db.GetTransfersCallCount() == 1
+			if complete := test.saved.Status == db.BackfillStatusComplete; detected != complete {
+				t.Errorf("detected transfers: %v, want %v", detected, complete)
+			}
+		})
+	}
+}
+
+func TestBackfillWindowErrors(t *testing.T) {
+	backfill := db.Backfill{Status: db.BackfillStatusInProgress, StartDate: "2018-01-31", EndDate: "2020-01-31", OldestDate: "2019-11-03", Imported: 5}
+
+	t.Run("plaid error", func(t *testing.T) {
+		s := newBackfillTestServer()
+		s.plaid.GetTransactionsWithOptionsReturns(plaid.GetTransactionsResponse{}, errors.New("timed out"))
+
+		if err := s.backfillWindow(context.Background(), backfillingItem(backfill)); err == nil {
+			t.Fatal("expected an error")
+		}
+
+		//progress is kept, so the window is retried next time
+		want := backfill
+		want.Error = "timed out"
+		if _, _, saved := s.db.SetItemBackfillArgsForCall(0); !reflect.DeepEqual(saved, want) {
+			t.Errorf("saved %+v, want %+v", saved, want)
+		}
+		if n := s.db.SetItemStatusCallCount(); n != 0 {
+			t.Errorf("the item's status was set %d times", n)
+		}
+	})
+
+	t.Run("item error", func(t *testing.T) {
+		s := newBackfillTestServer()
+		s.plaid.GetTransactionsWithOptionsReturns(plaid.GetTransactionsResponse{}, plaid.Error{
+			ErrorType:    "ITEM_ERROR",
+			ErrorCode:    "ITEM_LOGIN_REQUIRED",
+			ErrorMessage: "the login details of this item have changed",
+		})
+
+		if err := s.backfillWindow(context.Background(), backfillingItem(backfill)); err == nil {
+			t.Fatal("expected an error")
+		}
+
+		_, _, saved := s.db.SetItemBackfillArgsForCall(0)
+		if saved.Status != db.BackfillStatusFailed || saved.OldestDate != backfill.OldestDate || saved.Error == "" {
+			t.Errorf("saved %+v", saved)
+		}
+		if _, itemID, status, code := s.db.SetItemStatusArgsForCall(0); itemID != "item-1" || status != db.ItemStatusLoginRequired || code != "ITEM_LOGIN_REQUIRED" {
+			t.Errorf("set `%s` to `%s` (%s)", itemID, status, code)
+		}
+	})
+
+	t.Run("invalid dates", func(t *testing.T) {
+		for _, invalid := range []db.Backfill{
+			{Status: db.BackfillStatusInProgress, StartDate: "2018-01-31", EndDate: "yesterday"},
+			{Status: db.BackfillStatusInProgress, StartDate: "2018-01-31", EndDate: "2020-01-31", OldestDate: "01/02/2019"},
+		} {
+			s := newBackfillTestServer()
+			if err := s.backfillWindow(context.Background(), backfillingItem(invalid)); err == nil {
+				t.Errorf("%+v: expected an error", invalid)
+			}
+			if n := s.plaid.GetTransactionsWithOptionsCallCount(); n != 0 {
+				t.Errorf("%+v: Plaid was asked for transactions %d times", invalid, n)
+			}
+		}
+	})
+}
+
+func TestBackfillHistory(t *testing.T) {
+	s := newBackfillTestServer()
+	s.db.GetBackfillingItemsReturnsOnCall(0, []db.Item{backfillingItem(db.Backfill{Status: db.BackfillStatusInProgress, StartDate: "2019-10-01", EndDate: "2020-01-31"})}, nil)
+	s.db.GetBackfillingItemsReturnsOnCall(1, []db.Item{backfillingItem(db.Backfill{Status: db.BackfillStatusInProgress, StartDate: "2019-10-01", EndDate: "2020-01-31", OldestDate: "2019-11-03"})}, nil)
+	s.db.GetBackfillingItemsReturnsOnCall(2, nil, nil)
+
+	if err := s.BackfillHistory(context.Background(), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.plaid.GetTransactionsWithOptionsCallCount(); n != 2 {
+		t.Errorf("Plaid was asked for transactions %d times, want 2", n)
+	}
+	if n := s.db.GetBackfillingItemsCallCount(); n != 3 {
+		t.Errorf("backfilling items were listed %d times, want 3", n)
+	}
+}
+
+func TestBackfillHistoryFailure(t *testing.T) {
+	s := newBackfillTestServer()
+	s.plaid.GetTransactionsWithOptionsReturns(plaid.GetTransactionsResponse{}, errors.New("timed out"))
+	//the failed item is still queued
+	s.db.GetBackfillingItemsReturns([]db.Item{backfillingItem(db.Backfill{Status: db.BackfillStatusInProgress, StartDate: "2019-10-01", EndDate: "2020-01-31"})}, nil)
+
+	if err := s.BackfillHistory(context.Background(), time.Minute); err == nil {
+		t.Fatal("expected an error")
+	}
+	if n := s.plaid.GetTransactionsWithOptionsCallCount(); n != 1 {
+		t.Errorf("Plaid was asked for transactions %d times, want 1", n)
+	}
+}
+
+func TestBackfillHistoryBudget(t *testing.T) {
+	s := newBackfillTestServer()
+	s.db.GetBackfillingItemsReturns([]db.Item{backfillingItem(db.Backfill{Status: db.BackfillStatusInProgress, StartDate: "2010-01-01", EndDate: "2020-01-31"})}, nil)
+
+	//with no time left, nothing is pulled
+	if err := s.BackfillHistory(context.Background(), -time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.plaid.GetTransactionsWithOptionsCallCount(); n != 0 {
+		t.Errorf("Plaid was asked for transactions %d times, want 0", n)
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//ManualAccountRequest describes an account that isn't linked through Plaid
+type ManualAccountRequest struct {
+	Name            string                  `json:"name" binding:"required"`
+	Type            plaidapi.AccountType    `json:"type" binding:"required"`
+	Subtype         plaidapi.AccountSubtype `json:"subtype"`
+	InstitutionName string                  `json:"institution_name"`
+	InstitutionURL  string                  `json:"institution_url"`
+}
+
+//CreateManualAccount adds an account whose transactions will be
+//imported from statement files rather than synced from Plaid
+func (a ServerAgent) CreateManualAccount(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var req ManualAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account := db.Account{
+		UserUUID: auth.UserUUID,
+		Manual:   true,
+
+		PlaidAccountName:    req.Name,
+		PlaidAccountType:    req.Type,
+		PlaidAccountSubtype: req.Subtype,
+
+		PlaidInstitutionName: req.InstitutionName,
+		PlaidInstitutionURL:  req.InstitutionURL,
+	}
+
+	uuid, err := a.dbClient.CreateAccount(c, auth.UserUUID, account)
+	if err != nil {
+		a.logger.Errorf("failed creating manual account for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "account creation failed - see logs for details"})
+		return
+	}
+	account.UUID = uuid
+	a.audit(c, auth, db.AuditActionAccountCreated, "account", uuid, nil, gin.H{
+		"name":             req.Name,
+		"type":             req.Type,
+		"subtype":          req.Subtype,
+		"institution_name": req.InstitutionName,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"account": account,
+	})
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+//DigestPreferencesRequest opts the user in or out of email digests
+type DigestPreferencesRequest struct {
+	Enabled *bool            `json:"enabled" binding:"required"`
+	Cadence db.DigestCadence `json:"cadence"`
+}
+
+//GetDigestPreferences gets the user's email digest preferences
+func (a ServerAgent) GetDigestPreferences(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	prefs, err := a.dbClient.GetDigestPreferences(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting digest preferences for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preferences": prefs,
+	})
+}
+
+//UpdateDigestPreferences sets whether the user gets email digests, and
+//how often
+func (a ServerAgent) UpdateDigestPreferences(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var req DigestPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Cadence == "" {
+		req.Cadence = db.DigestCadenceWeekly
+	}
+	if req.Cadence != db.DigestCadenceWeekly && req.Cadence != db.DigestCadenceMonthly {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "cadence must be `weekly` or `monthly`"})
+		return
+	}
+
+	err := a.dbClient.SetDigestPreferences(c, db.DigestPreferences{
+		UserUUID: auth.UserUUID,
+		Enabled:  *req.Enabled,
+		Cadence:  req.Cadence,
+	})
+	if err != nil {
+		a.logger.Errorf("failed setting digest preferences for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "update failed - see logs for details"})
+		return
+	}
+
+	prefs, err := a.dbClient.GetDigestPreferences(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting digest preferences for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"preferences": prefs,
+	})
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/ledger"
+)
+
+//ExportLedger renders all of the user's accounts, transactions and
+//balance snapshots as a Beancount or ledger-cli file. The request body
+//may optionally contain a ledger.Mapping to override account names.
+func (a ServerAgent) ExportLedger(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	format := ledger.Format(c.Param("format"))
+
+	mapping := ledger.DefaultMapping()
+	if c.Request.ContentLength > 0 {
+		var overrides ledger.Mapping
+		if err := c.ShouldBindJSON(&overrides); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		mapping = mapping.Merge(overrides)
+	}
+
+	accounts, err := a.dbClient.GetAccounts(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting accounts for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "export failed - see logs for details"})
+		return
+	}
+
+	journal := ledger.Journal{Accounts: accounts}
+	for _, acct := range accounts {
+		transactions, err := a.dbClient.GetTransactions(c, auth.UserUUID, acct.UUID)
+		if err != nil {
+			a.logger.Errorf("failed getting transactions for account `%s`: %s", acct.UUID, err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "export failed - see logs for details"})
+			return
+		}
+		journal.Transactions = append(journal.Transactions, transactions...)
+
+		balances, err := a.dbClient.GetBalances(c, auth.UserUUID, acct.UUID)
+		if err != nil {
+			a.logger.Errorf("failed getting balances for account `%s`: %s", acct.UUID, err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "export failed - see logs for details"})
+			return
+		}
+		journal.Balances = append(journal.Balances, balances...)
+	}
+
+	var buf bytes.Buffer
+	err = ledger.NewExporter(mapping).Export(&buf, format, journal)
+	if err == ledger.ErrUnknownFormat {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown export format `%s`", format)})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed rendering %s export for user `%s`: %s", format, auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "export failed - see logs for details"})
+		return
+	}
+
+	a.audit(c, auth, db.AuditActionUserExported, "user", auth.UserUUID, nil, gin.H{"format": format})
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="plaid-ui.%s"`, format))
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", buf.Bytes())
+}
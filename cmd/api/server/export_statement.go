@@ -0,0 +1,109 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+	"github.com/xanderflood/plaid-ui/pkg/statement"
+)
+
+//defaultStatementDays is how far back a statement goes when the
+//request doesn't specify a start date
+const defaultStatementDays = 90
+
+//ExportStatement generates an OFX, QFX or QIF statement file for one
+//of the user's accounts. The format is taken from the extension on the
+//request path, and the optional `start_date` and `end_date` query
+//parameters bound the statement.
+func (a ServerAgent) ExportStatement(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	format := statement.Format(strings.TrimPrefix(path.Ext(c.Request.URL.Path), "."))
+
+	endDate := c.DefaultQuery("end_date", time.Now().Format(plaidapi.DateFormat))
+	end, err := time.Parse(plaidapi.DateFormat, endDate)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("end_date must be formatted as %s", plaidapi.DateFormat)})
+		return
+	}
+	startDate := c.DefaultQuery("start_date", end.AddDate(0, 0, -defaultStatementDays).Format(plaidapi.DateFormat))
+	if _, err := time.Parse(plaidapi.DateFormat, startDate); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("start_date must be formatted as %s", plaidapi.DateFormat)})
+		return
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such account")
+	if !ok {
+		return //an error response has already been generated
+	}
+	account, err := a.dbClient.GetAccount(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchAccount {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such account"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting account `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "export failed - see logs for details"})
+		return
+	}
+
+	transactions, err := a.dbClient.GetTransactionsByDateRange(c, auth.UserUUID, account.UUID, startDate, endDate)
+	if err != nil {
+		a.logger.Errorf("failed getting transactions for account `%s`: %s", account.UUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "export failed - see logs for details"})
+		return
+	}
+
+	balances, err := a.dbClient.GetBalances(c, auth.UserUUID, account.UUID)
+	if err != nil {
+		a.logger.Errorf("failed getting balances for account `%s`: %s", account.UUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "export failed - see logs for details"})
+		return
+	}
+
+	//balances are ordered by date, so keep the last one in range
+	var balance *db.Balance
+	for i := range balances {
+		if balances[i].Date <= endDate {
+			balance = &balances[i]
+		}
+	}
+
+	var buf bytes.Buffer
+	err = a.statementExporter.Export(&buf, format, statement.Statement{
+		Account:      account,
+		StartDate:    startDate,
+		EndDate:      endDate,
+		Transactions: transactions,
+		Balance:      balance,
+		GeneratedAt:  time.Now(),
+	})
+	if err == statement.ErrUnknownFormat {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown statement format `%s`", format)})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed rendering %s statement for account `%s`: %s", format, account.UUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "export failed - see logs for details"})
+		return
+	}
+
+	a.audit(c, auth, db.AuditActionAccountExported, "account", account.UUID, nil, gin.H{
+		"format":     format,
+		"start_date": startDate,
+		"end_date":   endDate,
+	})
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%s-%s.%s"`, account.UUID, startDate, endDate, format))
+	c.Data(http.StatusOK, a.statementExporter.ContentType(format), buf.Bytes())
+}
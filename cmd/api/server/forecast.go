@@ -0,0 +1,95 @@
+package server
+
+import (
+	"math/big"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/forecast"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+const (
+	//defaultForecastDays is how far ahead a forecast projects
+	defaultForecastDays = 90
+
+	//maxForecastDays bounds the `days` query parameter
+	maxForecastDays = 366
+
+	//recurringLookbackDays is how much history recurring series are
+	//detected from
+	recurringLookbackDays = 180
+)
+
+//GetForecast projects the daily balance of the user's depository
+//accounts from their latest balances and recurring income and bills.
+//The optional `days` query parameter sets how far ahead to project, and
+//`threshold` flags days whose balance dips below it.
+func (a ServerAgent) GetForecast(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	days, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(defaultForecastDays)))
+	if err != nil || days < 1 || days > maxForecastDays {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "days must be a whole number from 1 to " + strconv.Itoa(maxForecastDays)})
+		return
+	}
+
+	threshold, ok := new(big.Float).SetString(c.DefaultQuery("threshold", "0"))
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "threshold must be a number"})
+		return
+	}
+
+	accounts, err := a.dbClient.GetAccounts(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting accounts for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "forecast failed - see logs for details"})
+		return
+	}
+
+	balances := map[string]db.Balance{}
+	for _, account := range accounts {
+		if account.PlaidAccountType != plaidapi.AccountTypeDepository {
+			continue
+		}
+
+		history, err := a.dbClient.GetBalances(c, auth.UserUUID, account.UUID)
+		if err != nil {
+			a.logger.Errorf("failed getting balances for account `%s`: %s", account.UUID, err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "forecast failed - see logs for details"})
+			return
+		}
+		if len(history) > 0 {
+			balances[account.UUID] = history[len(history)-1]
+		}
+	}
+
+	now := time.Now()
+	transactions, err := a.dbClient.GetUserTransactionsByDateRange(c, auth.UserUUID,
+		now.AddDate(0, 0, -recurringLookbackDays).Format(plaidapi.DateFormat),
+		now.Format(plaidapi.DateFormat),
+	)
+	if err != nil {
+		a.logger.Errorf("failed getting transactions for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "forecast failed - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, a.forecaster.Forecast(forecast.Input{
+		Accounts:     accounts,
+		Balances:     balances,
+		Series:       a.recurringDetector.Detect(transactions, now),
+		Transactions: transactions,
+
+		Start:     now,
+		Days:      days,
+		Threshold: threshold,
+	}))
+}
@@ -3,15 +3,15 @@ package server
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"math/big"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/plaid/plaid-go/plaid"
 	"github.com/xanderflood/plaid-ui/pkg/db"
-	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+	"github.com/xanderflood/plaid-ui/pkg/webhooks"
 )
 
 type WebhookType string
@@ -23,6 +23,10 @@ const (
 
 type WebhookCode string
 
+//webhookSyncDays is how far back a transactions webhook syncs. Plaid's
+//INITIAL_UPDATE covers 30 days, and DEFAULT_UPDATE only recent ones.
+const webhookSyncDays = 30
+
 const (
 	InitialUpdate       WebhookCode = "INITIAL_UPDATE"
 	HistoricalUpdate    WebhookCode = "HISTORICAL_UPDATE"
@@ -31,6 +35,7 @@ const (
 
 	ItemWebhookUpdateAcknowledged WebhookCode = "WEBHOOK_UPDATE_ACKNOWLEDGED"
 	ItemError                     WebhookCode = "ERROR"
+	ItemPendingExpiration         WebhookCode = "PENDING_EXPIRATION"
 )
 
 func (t WebhookCode) IsRemoval() bool {
@@ -92,12 +97,31 @@ func (a ServerAgent) GenericPlaidWebhook(c *gin.Context) {
 			}
 			return
 
-		case ItemError:
-			a.logger.Errorf("received an error webhook from Plaid: %s: %s", wr.Error, err.Error())
+		case ItemError, ItemPendingExpiration:
+			a.logger.Errorf("received a `%s` webhook from Plaid for item `%s`: %s", wr.Code, wr.ItemID, wr.Error)
+			status := db.ItemStatusError
+			if loginRequired(wr) {
+				status = db.ItemStatusLoginRequired
+			}
+			if err := a.dbClient.SetItemStatus(c, wr.ItemID, status, plaidErrorCode(wr)); err != nil {
+				a.logger.Errorf("failed updating status of plaid item `%s`: %s", wr.ItemID, err.Error())
+			}
+			if len(accounts) > 0 {
+				a.emit(c, accounts[0].UserUUID, webhooks.EventItemError, gin.H{
+					"item_id": wr.ItemID,
+					"code":    wr.Code,
+					"error":   wr.Error,
+				})
+			}
+			if loginRequired(wr) && len(accounts) > 0 {
+				if err := a.evaluateAlerts(c, accounts[0].UserUUID, nil, []string{wr.ItemID}); err != nil {
+					a.logger.Errorf("failed evaluating alerts for user `%s`: %s", accounts[0].UserUUID, err.Error())
+				}
+			}
 			return
 
 		default:
-			a.logger.Errorf("invalid transaction webhook code `%s`: %s", wr.Code, err.Error())
+			a.logger.Errorf("invalid item webhook code `%s`", wr.Code)
 			c.AbortWithStatus(http.StatusBadRequest)
 			return
 		}
@@ -105,7 +129,7 @@ func (a ServerAgent) GenericPlaidWebhook(c *gin.Context) {
 		a.logger.Infof("processing transaction webhook code `%s` for item `%s`", wr.Code, wr.ItemID)
 		switch wr.Code {
 		case InitialUpdate:
-			_, err := a.transactionWebhookAddHelper(c, wr.Newtransactions, wr.ItemID)
+			err := a.syncRecentTransactions(c, wr.ItemID)
 			if err != nil {
 				a.logger.Errorf("failed processing transaction webhook for plaid item `%s` with `%v` items: %s", wr.ItemID, wr.Newtransactions, err.Error())
 				c.AbortWithStatus(http.StatusInternalServerError)
@@ -114,16 +138,18 @@ func (a ServerAgent) GenericPlaidWebhook(c *gin.Context) {
 			return
 
 		case HistoricalUpdate:
-			_, err := a.transactionWebhookAddHelper(c, wr.Newtransactions, wr.ItemID)
+			//history can run to thousands of transactions, so it's
+			//imported in the background by the backfill job
+			err := a.startBackfill(c, wr.ItemID)
 			if err != nil {
-				a.logger.Errorf("failed processing transaction webhook for plaid item `%s` with `%v` items: %s", wr.ItemID, wr.Newtransactions, err.Error())
+				a.logger.Errorf("failed starting backfill for plaid item `%s`: %s", wr.ItemID, err.Error())
 				c.AbortWithStatus(http.StatusInternalServerError)
 				return
 			}
 			return
 
 		case DefaultUpdate:
-			_, err := a.transactionWebhookAddHelper(c, wr.Newtransactions, wr.ItemID)
+			err := a.syncRecentTransactions(c, wr.ItemID)
 			if err != nil {
 				a.logger.Errorf("failed processing transaction webhook for plaid item `%s` with `%v` items: %s", wr.ItemID, wr.Newtransactions, err.Error())
 				c.AbortWithStatus(http.StatusInternalServerError)
@@ -133,17 +159,21 @@ func (a ServerAgent) GenericPlaidWebhook(c *gin.Context) {
 
 		case TransactionsRemoved:
 			for _, tid := range wr.RemovedTransactions {
-				err := a.dbClient.DeleteTransactionByPlaidID(c, tid)
+				transaction, err := a.dbClient.DeleteTransactionByPlaidID(c, tid)
+				if err == db.ErrNoSuchTransaction {
+					continue //already removed
+				}
 				if err != nil {
 					a.logger.Errorf("failed processing transaction removal webhook: %s", err.Error())
 					c.AbortWithStatus(http.StatusInternalServerError)
 					return
 				}
+				a.emit(c, transaction.UserUUID, webhooks.EventTransactionRemoved, transaction)
 			}
 			return
 
 		default:
-			a.logger.Errorf("invalid transaction webhook code `%s`: %s", wr.Code, err.Error())
+			a.logger.Errorf("invalid transaction webhook code `%s`", wr.Code)
 			c.AbortWithStatus(http.StatusBadRequest)
 			return
 		}
@@ -153,86 +183,123 @@ func (a ServerAgent) GenericPlaidWebhook(c *gin.Context) {
 	}
 }
 
-func (a ServerAgent) addLimitedTransactionsForDate(ctx context.Context, date time.Time, remaining *int, userUUID string, accessToken string, accounts map[string]db.Account) error {
-	//TODO I've been getting 429s from this - does it have built-in retry logic?
-	getTransactionsResp, err := a.plaidClient.GetTransactions(accessToken, date.Format(plaidapi.DateFormat), date.Format(plaidapi.DateFormat))
-	if err != nil {
-		return err
+//upsertPlaidTransaction saves a transaction pulled from Plaid, emitting
+//an event if it's new or has changed
+func (a ServerAgent) upsertPlaidTransaction(ctx context.Context, userUUID string, accounts map[string]db.Account, plaidTransaction plaid.Transaction) (db.Transaction, bool, error) {
+	transaction := db.Transaction{
+		AccountUUID: accounts[plaidTransaction.AccountID].UUID,
+		UserUUID:    userUUID,
+
+		ISOCurrencyCode: plaidTransaction.ISOCurrencyCode,
+		Amount:          big.NewFloat(plaidTransaction.Amount),
+		Date:            plaidTransaction.Date,
+
+		PlaidAccountID:            plaidTransaction.AccountID,
+		PlaidName:                 plaidTransaction.Name,
+		PlaidCategoryID:           plaidTransaction.CategoryID,
+		PlaidPending:              plaidTransaction.Pending,
+		PlaidPendingTransactionID: plaidTransaction.PendingTransactionID,
+		PlaidAccountOwner:         plaidTransaction.AccountOwner,
+		PlaidID:                   plaidTransaction.ID,
+		PlaidType:                 plaidTransaction.Type,
 	}
 
-	for _, plaidTransaction := range getTransactionsResp.Transactions {
-		transaction := db.Transaction{
-			AccountUUID: accounts[plaidTransaction.AccountID].UUID,
-			UserUUID:    userUUID,
+	uuid, isNew, err := a.dbClient.UpsertTransaction(ctx, transaction)
+	if err != nil {
+		return db.Transaction{}, false, err
+	}
+	transaction.UUID = uuid
+	if uuid != "" && !isNew {
+		a.emit(ctx, userUUID, webhooks.EventTransactionUpdated, transaction)
+	}
+	if isNew {
+		a.emit(ctx, userUUID, webhooks.EventTransactionCreated, transaction)
+	}
+	return transaction, isNew, nil
+}
 
-			ISOCurrencyCode: plaidTransaction.ISOCurrencyCode,
-			Amount:          big.NewFloat(plaidTransaction.Amount),
-			Date:            plaidTransaction.Date,
+//syncRecentTransactions pulls an item's transactions from the last
+//webhookSyncDays when Plaid reports new ones. The window is fixed rather
+//than walked back until new_transactions have been seen, since a
+//redelivered webhook, or one for transactions that a catch-up sync
+//already pulled, would never see them.
+func (a ServerAgent) syncRecentTransactions(ctx context.Context, itemID string) error {
+	//end tomorrow to avoid timezone issues
+	end := time.Now().AddDate(0, 0, 1)
+	return a.syncItem(ctx, itemID, end.AddDate(0, 0, -webhookSyncDays), end)
+}
 
-			PlaidAccountID:            plaidTransaction.AccountID,
-			PlaidName:                 plaidTransaction.Name,
-			PlaidCategoryID:           plaidTransaction.CategoryID,
-			PlaidPending:              plaidTransaction.Pending,
-			PlaidPendingTransactionID: plaidTransaction.PendingTransactionID,
-			PlaidAccountOwner:         plaidTransaction.AccountOwner,
-			PlaidID:                   plaidTransaction.ID,
-			PlaidType:                 plaidTransaction.Type,
-		}
+//afterSync brings everything derived from an item's transactions up to
+//date. Failures here are only logged, since the transactions themselves
+//were saved and each step can be rerun.
+func (a ServerAgent) afterSync(ctx context.Context, itemID string, userUUID string, accessToken string, accounts map[string]db.Account, added []db.Transaction) {
+	if err := a.dbClient.MarkItemSynced(ctx, itemID); err != nil {
+		a.logger.Errorf("failed marking plaid item `%s` as synced: %s", itemID, err.Error())
+	}
 
-		_, isNew, err := a.dbClient.UpsertTransaction(ctx, transaction)
-		if err != nil {
-			return err
-		}
-		if isNew {
-			*remaining--
+	if _, err := a.detectTransfers(ctx, userUUID); err != nil {
+		a.logger.Errorf("failed detecting transfers for user `%s`: %s", userUUID, err.Error())
+	}
 
-			if *remaining <= 0 {
-				return nil
-			}
-		}
+	if err := a.refreshBalances(ctx, userUUID, accessToken, accounts); err != nil {
+		a.logger.Errorf("failed refreshing balances for plaid item `%s`: %s", itemID, err.Error())
 	}
 
-	return nil
+	if err := a.evaluateAlerts(ctx, userUUID, added, nil); err != nil {
+		a.logger.Errorf("failed evaluating alerts for user `%s`: %s", userUUID, err.Error())
+	}
 }
 
-func (a ServerAgent) transactionWebhookAddHelper(ctx context.Context, max int, itemID string) (int, error) {
-	//start with tomorrow to avoid timezone issues
-	date := time.Now().Truncate(time.Hour * 24).Add(time.Hour * 24)
-
-	accts, err := a.dbClient.GetAccountsByPlaidItemID(ctx, itemID)
+//refreshBalances records a fresh balance snapshot for each of an item's accounts
+func (a ServerAgent) refreshBalances(ctx context.Context, userUUID string, accessToken string, accounts map[string]db.Account) error {
+	resp, err := a.plaidClient.GetAccounts(accessToken)
 	if err != nil {
-		return 0, err
+		return err
 	}
 
-	if len(accts) == 0 {
-		return 0, fmt.Errorf("itemID `%s` has no plaid accounts", itemID)
-	}
+	return a.recordBalances(ctx, userUUID, accounts, resp.Accounts)
+}
 
-	// TODO: if the webhook is hit before the add_plaid_item call completes,
-	//  the account record won't exist. To address that, we could have the
-	//  webhook simply queue a re-tryable job instead of doing the upserts
-	//  right away.
-	// However this might not be a real issue if Plaid makes a habit of delaying a few seconds :shrug:
+//recordBalances saves a balance snapshot for each Plaid account that
+//matches one of the user's accounts
+func (a ServerAgent) recordBalances(ctx context.Context, userUUID string, accounts map[string]db.Account, plaidAccounts []plaid.Account) error {
+	var err error
+	for _, acct := range plaidAccounts {
+		account, ok := accounts[acct.AccountID]
+		if !ok {
+			continue
+		}
 
-	var accountMapping = map[string]db.Account{}
-	var accessToken = accts[0].PlaidAccessToken
-	var userUUID string
-	for _, account := range accts {
-		userUUID = account.UserUUID // these will all be the same value
-		accountMapping[account.PlaidAccountID] = account
+		balance := balanceFromPlaid(userUUID, account.UUID, acct.Balances)
+		balance.UUID, err = a.dbClient.RecordBalance(ctx, balance)
+		if err != nil {
+			return err
+		}
+		a.emit(ctx, userUUID, webhooks.EventBalanceUpdated, balance)
 	}
 
-	var remaining = max
-	for remaining > 0 {
-		// TODO Put some reasonable limits so that it doesn't go crazy if a transaction gets sent twice
+	return nil
+}
 
-		err := a.addLimitedTransactionsForDate(ctx, date, &remaining, userUUID, accessToken, accountMapping)
-		if err != nil {
-			return remaining, err
-		}
+//plaidError is the error object in an item webhook
+type plaidError struct {
+	ErrorCode string `json:"error_code"`
+}
 
-		date = date.Add(-time.Hour * 24)
+//loginRequired reports whether an item webhook means the user has to
+//log in to their institution again
+func loginRequired(wr WebhookRequest) bool {
+	if wr.Code == ItemPendingExpiration {
+		return true
 	}
+	return plaidErrorCode(wr) == "ITEM_LOGIN_REQUIRED"
+}
 
-	return remaining, nil
+//plaidErrorCode is the error code of an item error webhook, if any
+func plaidErrorCode(wr WebhookRequest) string {
+	var pe plaidError
+	if wr.Code != ItemError || json.Unmarshal(wr.Error, &pe) != nil {
+		return ""
+	}
+	return pe.ErrorCode
 }
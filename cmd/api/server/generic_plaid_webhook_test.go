@@ -0,0 +1,52 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/plaid/plaid-go/plaid"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/transfers/transfersfakes"
+	"github.com/xanderflood/plaid-ui/pkg/webhooks/webhooksfakes"
+)
+
+//TestRedeliveredTransactionsWebhook checks that a webhook for
+//transactions that are already saved syncs a bounded window once,
+//rather than walking back a day at a time looking for new ones
+func TestRedeliveredTransactionsWebhook(t *testing.T) {
+	s := newTestServer()
+	s.transferMatcher = &transfersfakes.FakeMatcher{}
+	s.webhookDispatcher = &webhooksfakes.FakeDispatcher{}
+
+	s.db.GetAccountsByPlaidItemIDReturns([]db.Account{{
+		Model:            db.Model{UUID: "acct-1"},
+		UserUUID:         testUserUUID,
+		PlaidAccessToken: "access-token",
+		PlaidAccountID:   "plaid-acct-1",
+		PlaidItemID:      "item-1",
+	}}, nil)
+	s.plaid.GetTransactionsWithOptionsReturns(plaid.GetTransactionsResponse{
+		Transactions:      []plaid.Transaction{{ID: "plaid-txn-1", AccountID: "plaid-acct-1", Date: "2020-01-03"}},
+		TotalTransactions: 1,
+	}, nil)
+	//already saved and unchanged
+	s.db.UpsertTransactionReturns("", false, nil)
+
+	for _, code := range []string{"INITIAL_UPDATE", "DEFAULT_UPDATE"} {
+		status, resp := serve(t, s.GenericPlaidWebhook, "POST", "/webhook/v1/plaid", "/webhook/v1/plaid",
+			`{"webhook_type": "TRANSACTIONS", "webhook_code": "`+code+`", "item_id": "item-1", "new_transactions": 5}`)
+		assertStatus(t, status, http.StatusOK, resp)
+	}
+
+	if n := s.plaid.GetTransactionsWithOptionsCallCount(); n != 2 {
+		t.Fatalf("Plaid was asked for transactions %d times, want 2", n)
+	}
+	_, options := s.plaid.GetTransactionsWithOptionsArgsForCall(1)
+	if options.StartDate >= options.EndDate {
+		t.Errorf("synced `%s` to `%s`", options.StartDate, options.EndDate)
+	}
+	if n := s.db.MarkItemSyncedCallCount(); n != 2 {
+		t.Errorf("the item was marked synced %d times, want 2", n)
+	}
+}
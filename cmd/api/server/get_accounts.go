@@ -4,9 +4,12 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
 )
 
-//GetAccounts gets all the accounts
+//GetAccounts gets all the accounts, along with the items they belong to
+//so that history imports can be tracked
 func (a ServerAgent) GetAccounts(c *gin.Context) {
 	auth, ok := a.authorize(c)
 	if !ok {
@@ -15,11 +18,103 @@ func (a ServerAgent) GetAccounts(c *gin.Context) {
 
 	accounts, err := a.dbClient.GetAccounts(c, auth.UserUUID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		a.logger.Errorf("failed getting accounts for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
 		return
 	}
+	if accounts == nil {
+		accounts = []db.Account{}
+	}
+
+	items, err := a.dbClient.GetItems(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting items for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	if items == nil {
+		items = []db.Item{}
+	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"accounts": accounts,
+		"items":    items,
+	})
+}
+
+//GetAccount gets one of the user's accounts
+func (a ServerAgent) GetAccount(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such account")
+	if !ok {
+		return //an error response has already been generated
+	}
+	account, err := a.dbClient.GetAccount(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchAccount {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such account"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting account `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"account": account,
 	})
 }
+
+//DeleteAccount removes one of the user's manual accounts. Accounts linked
+//through Plaid are removed along with their item instead.
+func (a ServerAgent) DeleteAccount(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such account")
+	if !ok {
+		return //an error response has already been generated
+	}
+	account, err := a.dbClient.GetAccount(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchAccount {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such account"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting account `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	if account.Access != db.ShareLevelOwner {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "only the account's owner can delete it"})
+		return
+	}
+	if !account.Manual {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "this account is linked through Plaid - delete its item instead"})
+		return
+	}
+
+	err = a.dbClient.DeleteAccount(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchAccount {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such account"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed deleting account `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "delete failed - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionAccountDeleted, "account", uuid, gin.H{
+		"name":             account.PlaidAccountName,
+		"institution_name": account.PlaidInstitutionName,
+	}, nil)
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,257 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
+	"github.com/xanderflood/plaid-ui/lib/tools"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/graphql"
+)
+
+//GraphQLRequest is the body of a GraphQL query
+type GraphQLRequest struct {
+	Query         string                 `json:"query" binding:"required"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+//GraphQL runs a GraphQL query over the user's items, accounts and
+//transactions. Like any GraphQL server, it responds 200 even when some
+//fields fail, listing the failures under `errors`.
+func (a ServerAgent) GraphQL(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var req GraphQLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := context.WithValue(c, graphQLLoadersKey{}, a.newGraphQLLoaders(&auth))
+	c.JSON(http.StatusOK, a.graphQLSchema.Execute(ctx, graphql.Request{
+		Query:         req.Query,
+		OperationName: req.OperationName,
+		Variables:     req.Variables,
+	}))
+}
+
+//GetGraphQLSchema describes the GraphQL schema in the schema definition
+//language, for client code generators
+func (a ServerAgent) GetGraphQLSchema(c *gin.Context) {
+	c.String(http.StatusOK, a.graphQLSchema.SDL())
+}
+
+//errGraphQLInternal is reported for fields that failed for reasons that
+//are logged rather than shown to the user
+var errGraphQLInternal = errors.New("an internal error occurred - see logs for details")
+
+type graphQLLoadersKey struct{}
+
+//graphQLLoaders batch the lookups made while running one query, so that
+//listing the transactions of every account takes one query rather than
+//one per account. They're scoped to the user who made the request.
+type graphQLLoaders struct {
+	auth     *auth.Authorization
+	dbClient db.DB
+	logger   tools.Logger
+
+	//items and accounts are loaded in full, under the user's UUID, since
+	//users have few of them
+	items    *graphql.Loader
+	accounts *graphql.Loader
+
+	transactionPages *graphql.Loader
+	tags             *graphql.Loader
+	splits           *graphql.Loader
+}
+
+func graphQLLoadersFrom(ctx context.Context) *graphQLLoaders {
+	return ctx.Value(graphQLLoadersKey{}).(*graphQLLoaders)
+}
+
+func (a ServerAgent) newGraphQLLoaders(authorization *auth.Authorization) *graphQLLoaders {
+	userUUID := authorization.UserUUID
+
+	return &graphQLLoaders{
+		auth:     authorization,
+		dbClient: a.dbClient,
+		logger:   a.logger,
+
+		items: graphql.NewLoader(func(ctx context.Context, keys []string) ([]interface{}, error) {
+			items, err := a.dbClient.GetItems(ctx, userUUID)
+			if err != nil {
+				a.logger.Errorf("failed getting items for user `%s`: %s", userUUID, err.Error())
+				return nil, errGraphQLInternal
+			}
+			if items == nil {
+				items = []db.Item{}
+			}
+			return []interface{}{items}, nil
+		}),
+
+		accounts: graphql.NewLoader(func(ctx context.Context, keys []string) ([]interface{}, error) {
+			accounts, err := a.dbClient.GetAccounts(ctx, userUUID)
+			if err != nil {
+				a.logger.Errorf("failed getting accounts for user `%s`: %s", userUUID, err.Error())
+				return nil, errGraphQLInternal
+			}
+			if accounts == nil {
+				accounts = []db.Account{}
+			}
+			return []interface{}{accounts}, nil
+		}),
+
+		transactionPages: graphql.NewLoader(func(ctx context.Context, keys []string) ([]interface{}, error) {
+			//the same connection may be selected with different arguments
+			//in one query, so fetch each distinct page separately
+			byPage := map[transactionPageKey][]string{}
+			for _, key := range keys {
+				pageKey := parseTransactionPageKey(key)
+				byPage[pageKey.page()] = append(byPage[pageKey.page()], pageKey.AccountUUID)
+			}
+
+			connections := map[transactionPageKey]*transactionConnection{}
+			for pageKey, accountUUIDs := range byPage {
+				page := pageKey.dbPage()
+				page.Limit++ //to see whether there's another page
+
+				transactions, err := a.dbClient.GetAccountsTransactionsPage(ctx, userUUID, accountUUIDs, page)
+				if err != nil {
+					a.logger.Errorf("failed getting transactions for user `%s`: %s", userUUID, err.Error())
+					return nil, errGraphQLInternal
+				}
+
+				for _, accountUUID := range accountUUIDs {
+					connection := &transactionConnection{transactions: []db.Transaction{}}
+					connection.transactions = append(connection.transactions, transactions[accountUUID]...)
+					if len(connection.transactions) > pageKey.First {
+						connection.transactions = connection.transactions[:pageKey.First]
+						connection.hasNextPage = true
+					}
+
+					key := pageKey
+					key.AccountUUID = accountUUID
+					connections[key] = connection
+				}
+			}
+
+			values := make([]interface{}, len(keys))
+			for i, key := range keys {
+				values[i] = connections[parseTransactionPageKey(key)]
+			}
+			return values, nil
+		}),
+
+		tags: graphql.NewLoader(func(ctx context.Context, keys []string) ([]interface{}, error) {
+			tags, err := a.dbClient.GetTagsForTransactions(ctx, userUUID, keys)
+			if err != nil {
+				a.logger.Errorf("failed getting tags for user `%s`: %s", userUUID, err.Error())
+				return nil, errGraphQLInternal
+			}
+
+			values := make([]interface{}, len(keys))
+			for i, key := range keys {
+				if tags[key] == nil {
+					values[i] = []string{}
+				} else {
+					values[i] = tags[key]
+				}
+			}
+			return values, nil
+		}),
+
+		splits: graphql.NewLoader(func(ctx context.Context, keys []string) ([]interface{}, error) {
+			splits, err := a.dbClient.GetSplitsForTransactions(ctx, userUUID, keys)
+			if err != nil {
+				a.logger.Errorf("failed getting splits for user `%s`: %s", userUUID, err.Error())
+				return nil, errGraphQLInternal
+			}
+
+			values := make([]interface{}, len(keys))
+			for i, key := range keys {
+				if splits[key] == nil {
+					values[i] = []db.Split{}
+				} else {
+					values[i] = splits[key]
+				}
+			}
+			return values, nil
+		}),
+	}
+}
+
+//getItems loads the user's items
+func (l *graphQLLoaders) getItems(ctx context.Context) graphql.Thunk {
+	return l.items.Load(ctx, l.auth.UserUUID)
+}
+
+//getAccounts loads the user's accounts, optionally keeping only those
+//that match
+func (l *graphQLLoaders) getAccounts(ctx context.Context, match func(db.Account) bool) graphql.Thunk {
+	load := l.accounts.Load(ctx, l.auth.UserUUID)
+	return func() (interface{}, error) {
+		v, err := load()
+		if err != nil || match == nil {
+			return v, err
+		}
+
+		accounts := []db.Account{}
+		for _, account := range v.([]db.Account) {
+			if match(account) {
+				accounts = append(accounts, account)
+			}
+		}
+		return accounts, nil
+	}
+}
+
+//getAccount loads one of the user's accounts, or nil
+func (l *graphQLLoaders) getAccount(ctx context.Context, uuid string) graphql.Thunk {
+	load := l.getAccounts(ctx, func(account db.Account) bool { return account.UUID == uuid })
+	return func() (interface{}, error) {
+		v, err := load()
+		if err != nil || len(v.([]db.Account)) == 0 {
+			return nil, err
+		}
+		return v.([]db.Account)[0], nil
+	}
+}
+
+//getItem loads the user's item that matches, or nil
+func (l *graphQLLoaders) getItem(ctx context.Context, match func(db.Item) bool) graphql.Thunk {
+	load := l.getItems(ctx)
+	return func() (interface{}, error) {
+		v, err := load()
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range v.([]db.Item) {
+			if match(item) {
+				return item, nil
+			}
+		}
+		return nil, nil
+	}
+}
+
+//getTransaction gets one of the user's transactions, or nil. Only the
+//root field looks transactions up by ID, so this isn't batched.
+func (l *graphQLLoaders) getTransaction(ctx context.Context, uuid string) (interface{}, error) {
+	transaction, err := l.dbClient.GetTransaction(ctx, l.auth.UserUUID, uuid)
+	if err == db.ErrNoSuchTransaction {
+		return nil, nil
+	}
+	if err != nil {
+		l.logger.Errorf("failed getting transaction `%s`: %s", uuid, err.Error())
+		return nil, errGraphQLInternal
+	}
+	return transaction, nil
+}
@@ -0,0 +1,422 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/graphql"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+const (
+	//defaultTransactionsPageSize and maxTransactionsPageSize bound the
+	//`first` argument of transaction connections
+	defaultTransactionsPageSize = 20
+	maxTransactionsPageSize     = 100
+
+	//maxGraphQLDepth keeps a single query from fanning out without limit
+	maxGraphQLDepth = 10
+)
+
+//transactionConnection is a page of an account's transactions
+type transactionConnection struct {
+	transactions []db.Transaction
+	hasNextPage  bool
+}
+
+//transactionPageKey identifies a page of one account's transactions, for
+//batching
+type transactionPageKey struct {
+	AccountUUID string
+	StartDate   string
+	EndDate     string
+	After       string
+	First       int
+}
+
+func (k transactionPageKey) String() string {
+	return strings.Join([]string{k.AccountUUID, k.StartDate, k.EndDate, k.After, strconv.Itoa(k.First)}, "\n")
+}
+
+func parseTransactionPageKey(s string) transactionPageKey {
+	parts := strings.Split(s, "\n")
+	first, _ := strconv.Atoi(parts[4])
+	return transactionPageKey{
+		AccountUUID: parts[0],
+		StartDate:   parts[1],
+		EndDate:     parts[2],
+		After:       parts[3],
+		First:       first,
+	}
+}
+
+//page is the key without its account, which is shared by every account
+//fetched in the same query
+func (k transactionPageKey) page() transactionPageKey {
+	k.AccountUUID = ""
+	return k
+}
+
+func (k transactionPageKey) dbPage() db.TransactionPage {
+	//the cursor was checked when the key was built
+	after, _ := decodeTransactionCursor(k.After)
+	return db.TransactionPage{
+		StartDate: k.StartDate,
+		EndDate:   k.EndDate,
+		After:     after,
+		Limit:     k.First,
+	}
+}
+
+//encodeTransactionCursor makes an opaque cursor for a transaction's
+//position in a connection
+func encodeTransactionCursor(transaction db.Transaction) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(transaction.Date + "/" + transaction.UUID))
+}
+
+func decodeTransactionCursor(cursor string) (*db.TransactionCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	parts := strings.SplitN(string(data), "/", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid cursor")
+	}
+	return &db.TransactionCursor{Date: parts[0], UUID: parts[1]}, nil
+}
+
+//decimalScalar serializes amounts as strings, so no precision is lost
+var decimalScalar = &graphql.Scalar{
+	Name:        "Decimal",
+	Description: "A decimal amount, as a string",
+	Serialize: func(v interface{}) (interface{}, error) {
+		if f, ok := v.(*big.Float); ok {
+			return f.Text('f', -1), nil
+		}
+		return nil, errors.Errorf("Decimal cannot represent %v", v)
+	},
+	Parse: func(v interface{}) (interface{}, error) {
+		if s, ok := v.(string); ok {
+			if f, ok := new(big.Float).SetString(s); ok {
+				return f, nil
+			}
+		}
+		return nil, errors.Errorf("Decimal cannot represent %v", v)
+	},
+}
+
+//dateTimeScalar serializes times in RFC 3339 format
+var dateTimeScalar = &graphql.Scalar{
+	Name:        "DateTime",
+	Description: "A time, in RFC 3339 format",
+	Serialize: func(v interface{}) (interface{}, error) {
+		if t, ok := v.(time.Time); ok {
+			return t.Format(time.RFC3339), nil
+		}
+		return nil, errors.Errorf("DateTime cannot represent %v", v)
+	},
+	Parse: func(v interface{}) (interface{}, error) {
+		if s, ok := v.(string); ok {
+			if t, err := time.Parse(time.RFC3339, s); err == nil {
+				return t, nil
+			}
+		}
+		return nil, errors.Errorf("DateTime cannot represent %v", v)
+	},
+}
+
+//field is shorthand for a field that reads its value straight off the
+//source
+func field(name string, t graphql.Type, get func(source interface{}) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Name: name,
+		Type: t,
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return get(source), nil
+		},
+	}
+}
+
+var (
+	nonNullID      = graphql.NonNullOf(graphql.ID)
+	nonNullString  = graphql.NonNullOf(graphql.String)
+	nonNullBoolean = graphql.NonNullOf(graphql.Boolean)
+)
+
+//newGraphQLSchema describes the user's data. Resolvers get the user,
+//and the loaders that fetch their data, from the query's context.
+func newGraphQLSchema() *graphql.Schema {
+	var (
+		user        = graphql.NewObject("User", "The user making the request")
+		item        = graphql.NewObject("Item", "A login at a financial institution, linked through Plaid")
+		account     = graphql.NewObject("Account", "A bank account")
+		transaction = graphql.NewObject("Transaction", "A single transaction")
+		split       = graphql.NewObject("Split", "Part of a transaction's amount, allocated to a category")
+		connection  = graphql.NewObject("TransactionConnection", "A page of transactions, newest first")
+		edge        = graphql.NewObject("TransactionEdge", "A transaction and its position in a connection")
+		pageInfo    = graphql.NewObject("PageInfo", "How to fetch the next page of a connection")
+		query       = graphql.NewObject("Query", "")
+	)
+
+	query.AddField(&graphql.Field{
+		Name: "viewer",
+		Type: graphql.NonNullOf(user),
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return graphQLLoadersFrom(ctx).auth, nil
+		},
+	}).AddField(&graphql.Field{
+		Name: "item",
+		Type: item,
+		Args: []*graphql.Argument{{Name: "id", Type: nonNullID}},
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return graphQLLoadersFrom(ctx).getItem(ctx, func(item db.Item) bool { return item.UUID == args["id"] }), nil
+		},
+	}).AddField(&graphql.Field{
+		Name: "account",
+		Type: account,
+		Args: []*graphql.Argument{{Name: "id", Type: nonNullID}},
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return graphQLLoadersFrom(ctx).getAccount(ctx, args["id"].(string)), nil
+		},
+	}).AddField(&graphql.Field{
+		Name: "transaction",
+		Type: transaction,
+		Args: []*graphql.Argument{{Name: "id", Type: nonNullID}},
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return graphQLLoadersFrom(ctx).getTransaction(ctx, args["id"].(string))
+		},
+	})
+
+	user.AddField(field("id", nonNullID, func(source interface{}) interface{} {
+		return source.(*auth.Authorization).UserUUID
+	})).AddField(field("email", nonNullString, func(source interface{}) interface{} {
+		return source.(*auth.Authorization).Email
+	})).AddField(&graphql.Field{
+		Name: "items",
+		Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(item))),
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return graphQLLoadersFrom(ctx).getItems(ctx), nil
+		},
+	}).AddField(&graphql.Field{
+		Name: "accounts",
+		Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(account))),
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return graphQLLoadersFrom(ctx).getAccounts(ctx, nil), nil
+		},
+	})
+
+	item.AddField(field("id", nonNullID, func(source interface{}) interface{} {
+		return source.(db.Item).UUID
+	})).AddField(field("institutionName", nonNullString, func(source interface{}) interface{} {
+		return source.(db.Item).InstitutionName
+	})).AddField(field("status", nonNullString, func(source interface{}) interface{} {
+		return string(source.(db.Item).Status)
+	})).AddField(field("errorCode", graphql.String, func(source interface{}) interface{} {
+		if code := source.(db.Item).ErrorCode; code != "" {
+			return code
+		}
+		return nil
+	})).AddField(field("lastSyncedAt", dateTimeScalar, func(source interface{}) interface{} {
+		if t := source.(db.Item).LastSyncedAt; t != nil {
+			return *t
+		}
+		return nil
+	})).AddField(field("access", nonNullString, func(source interface{}) interface{} {
+		return string(source.(db.Item).Access)
+	})).AddField(&graphql.Field{
+		Name: "accounts",
+		Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(account))),
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			plaidItemID := source.(db.Item).PlaidItemID
+			return graphQLLoadersFrom(ctx).getAccounts(ctx, func(account db.Account) bool {
+				return account.PlaidItemID == plaidItemID
+			}), nil
+		},
+	})
+
+	account.AddField(field("id", nonNullID, func(source interface{}) interface{} {
+		return source.(db.Account).UUID
+	})).AddField(field("name", nonNullString, func(source interface{}) interface{} {
+		return source.(db.Account).PlaidAccountName
+	})).AddField(field("type", nonNullString, func(source interface{}) interface{} {
+		return string(source.(db.Account).PlaidAccountType)
+	})).AddField(field("subtype", nonNullString, func(source interface{}) interface{} {
+		return string(source.(db.Account).PlaidAccountSubtype)
+	})).AddField(field("institutionName", nonNullString, func(source interface{}) interface{} {
+		return source.(db.Account).PlaidInstitutionName
+	})).AddField(field("manual", nonNullBoolean, func(source interface{}) interface{} {
+		return source.(db.Account).Manual
+	})).AddField(field("hidden", nonNullBoolean, func(source interface{}) interface{} {
+		return source.(db.Account).Hidden
+	})).AddField(field("access", nonNullString, func(source interface{}) interface{} {
+		return string(source.(db.Account).Access)
+	})).AddField(&graphql.Field{
+		Name:        "item",
+		Description: "The item the account was linked through; manual accounts have none",
+		Type:        item,
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			acct := source.(db.Account)
+			if acct.Manual {
+				return nil, nil
+			}
+			return graphQLLoadersFrom(ctx).getItem(ctx, func(item db.Item) bool {
+				return item.PlaidItemID == acct.PlaidItemID
+			}), nil
+		},
+	}).AddField(&graphql.Field{
+		Name: "transactions",
+		Type: graphql.NonNullOf(connection),
+		Args: []*graphql.Argument{
+			{Name: "first", Type: graphql.Int, Default: defaultTransactionsPageSize, Description: fmt.Sprintf("at most %d", maxTransactionsPageSize)},
+			{Name: "after", Type: graphql.String, Description: "the endCursor of the previous page"},
+			{Name: "startDate", Type: graphql.String, Description: "the first day to include, as YYYY-MM-DD"},
+			{Name: "endDate", Type: graphql.String, Description: "the last day to include, as YYYY-MM-DD"},
+		},
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			key := transactionPageKey{AccountUUID: source.(db.Account).UUID}
+
+			first, _ := args["first"].(int)
+			if first < 1 || first > maxTransactionsPageSize {
+				return nil, errors.Errorf("first must be between 1 and %d", maxTransactionsPageSize)
+			}
+			key.First = first
+
+			key.After, _ = args["after"].(string)
+			if _, err := decodeTransactionCursor(key.After); err != nil {
+				return nil, err
+			}
+
+			for name, date := range map[string]*string{"startDate": &key.StartDate, "endDate": &key.EndDate} {
+				*date, _ = args[name].(string)
+				if _, err := time.Parse(plaidapi.DateFormat, *date); *date != "" && err != nil {
+					return nil, errors.Errorf("%s must be formatted as %s", name, plaidapi.DateFormat)
+				}
+			}
+
+			return graphQLLoadersFrom(ctx).transactionPages.Load(ctx, key.String()), nil
+		},
+	})
+
+	connection.AddField(field("edges", graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(edge))), func(source interface{}) interface{} {
+		return source.(*transactionConnection).transactions
+	})).AddField(field("pageInfo", graphql.NonNullOf(pageInfo), func(source interface{}) interface{} {
+		return source
+	}))
+
+	edge.AddField(field("cursor", nonNullString, func(source interface{}) interface{} {
+		return encodeTransactionCursor(source.(db.Transaction))
+	})).AddField(field("node", graphql.NonNullOf(transaction), func(source interface{}) interface{} {
+		return source
+	}))
+
+	pageInfo.AddField(field("hasNextPage", nonNullBoolean, func(source interface{}) interface{} {
+		return source.(*transactionConnection).hasNextPage
+	})).AddField(field("endCursor", graphql.String, func(source interface{}) interface{} {
+		transactions := source.(*transactionConnection).transactions
+		if len(transactions) == 0 {
+			return nil
+		}
+		return encodeTransactionCursor(transactions[len(transactions)-1])
+	}))
+
+	transaction.AddField(field("id", nonNullID, func(source interface{}) interface{} {
+		return source.(db.Transaction).UUID
+	})).AddField(field("date", nonNullString, func(source interface{}) interface{} {
+		return source.(db.Transaction).Date
+	})).AddField(field("name", nonNullString, func(source interface{}) interface{} {
+		return source.(db.Transaction).PlaidName
+	})).AddField(field("amount", decimalScalar, func(source interface{}) interface{} {
+		return source.(db.Transaction).Amount
+	})).AddField(field("isoCurrencyCode", nonNullString, func(source interface{}) interface{} {
+		return source.(db.Transaction).ISOCurrencyCode
+	})).AddField(field("pending", nonNullBoolean, func(source interface{}) interface{} {
+		return source.(db.Transaction).PlaidPending
+	})).AddField(field("note", nonNullString, func(source interface{}) interface{} {
+		return source.(db.Transaction).Note
+	})).AddField(&graphql.Field{
+		Name: "account",
+		Type: account,
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return graphQLLoadersFrom(ctx).getAccount(ctx, source.(db.Transaction).AccountUUID), nil
+		},
+	}).AddField(&graphql.Field{
+		Name: "tags",
+		Type: graphql.NonNullOf(graphql.ListOf(nonNullString)),
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return graphQLLoadersFrom(ctx).tags.Load(ctx, source.(db.Transaction).UUID), nil
+		},
+	}).AddField(&graphql.Field{
+		Name: "splits",
+		Type: graphql.NonNullOf(graphql.ListOf(graphql.NonNullOf(split))),
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return graphQLLoadersFrom(ctx).splits.Load(ctx, source.(db.Transaction).UUID), nil
+		},
+	}).AddField(&graphql.Field{
+		Name:        "categories",
+		Description: "The categories of the transaction's splits, or Plaid's category if it isn't split",
+		Type:        graphql.NonNullOf(graphql.ListOf(nonNullString)),
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			t := source.(db.Transaction)
+			load := graphQLLoadersFrom(ctx).splits.Load(ctx, t.UUID)
+			return graphql.Thunk(func() (interface{}, error) {
+				v, err := load()
+				if err != nil {
+					return nil, err
+				}
+				return transactionCategories(t, v.([]db.Split)), nil
+			}), nil
+		},
+	})
+
+	split.AddField(field("id", nonNullID, func(source interface{}) interface{} {
+		return source.(db.Split).UUID
+	})).AddField(field("amount", decimalScalar, func(source interface{}) interface{} {
+		return source.(db.Split).Amount
+	})).AddField(field("category", nonNullString, func(source interface{}) interface{} {
+		return source.(db.Split).Category
+	})).AddField(field("note", nonNullString, func(source interface{}) interface{} {
+		return source.(db.Split).Note
+	}))
+
+	schema, err := graphql.NewSchema(query)
+	if err != nil {
+		panic(err) //the schema is static, so this is a programming error
+	}
+	schema.MaxDepth = maxGraphQLDepth
+	return schema
+}
+
+//transactionCategories lists the distinct categories a transaction is
+//allocated to, matching the transaction_allocations view
+func transactionCategories(t db.Transaction, splits []db.Split) []string {
+	categories := []string{}
+	if len(splits) == 0 {
+		if t.PlaidCategoryID != "" {
+			categories = append(categories, t.PlaidCategoryID)
+		}
+		return categories
+	}
+
+	seen := map[string]bool{}
+	for _, split := range splits {
+		if !seen[split.Category] {
+			seen[split.Category] = true
+			categories = append(categories, split.Category)
+		}
+	}
+	return categories
+}
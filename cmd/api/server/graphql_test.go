@@ -0,0 +1,328 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+func newGraphQLTestServer() testServer {
+	s := newTestServer()
+	s.graphQLSchema = newGraphQLSchema()
+	return s
+}
+
+//graphQL runs a query, returning its data and the messages of any errors
+func graphQL(t *testing.T, s testServer, query string, variables map[string]interface{}) (map[string]interface{}, []string) {
+	t.Helper()
+
+	body, err := json.Marshal(GraphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		t.Fatal(err)
+	}
+	status, resp := serve(t, s.GraphQL, "POST", "/api/v1/graphql", "/api/v1/graphql", string(body))
+	assertStatus(t, status, http.StatusOK, resp)
+
+	var messages []string
+	errs, _ := resp["errors"].([]interface{})
+	for _, err := range errs {
+		messages = append(messages, err.(map[string]interface{})["message"].(string))
+	}
+	data, _ := resp["data"].(map[string]interface{})
+	return data, messages
+}
+
+//jsonPath follows keys and list indexes through decoded JSON
+func jsonPath(v interface{}, elems ...interface{}) interface{} {
+	for _, elem := range elems {
+		switch elem := elem.(type) {
+		case string:
+			v = v.(map[string]interface{})[elem]
+		case int:
+			v = v.([]interface{})[elem]
+		}
+	}
+	return v
+}
+
+var testGraphQLAccounts = []db.Account{
+	{Model: db.Model{UUID: "acct-1"}, UserUUID: testUserUUID, PlaidAccountName: "Checking", Access: db.ShareLevelOwner},
+	{Model: db.Model{UUID: "acct-2"}, UserUUID: testUserUUID, PlaidAccountName: "Savings", Access: db.ShareLevelOwner},
+	{Model: db.Model{UUID: "acct-3"}, UserUUID: "someone-else", PlaidAccountName: "Shared", Access: db.ShareLevelRead},
+}
+
+//testGraphQLTransactions are each account's transactions, newest first
+var testGraphQLTransactions = map[string][]db.Transaction{
+	"acct-1": {
+		{Model: db.Model{UUID: "txn-1c"}, AccountUUID: "acct-1", Date: "2020-01-03", Amount: big.NewFloat(3)},
+		{Model: db.Model{UUID: "txn-1b"}, AccountUUID: "acct-1", Date: "2020-01-02", Amount: big.NewFloat(2)},
+		{Model: db.Model{UUID: "txn-1a"}, AccountUUID: "acct-1", Date: "2020-01-01", Amount: big.NewFloat(1)},
+	},
+	"acct-2": {
+		{Model: db.Model{UUID: "txn-2a"}, AccountUUID: "acct-2", Date: "2020-01-02", Amount: big.NewFloat(20)},
+	},
+}
+
+//fakeTransactionsPage pages through testGraphQLTransactions like the
+//database would
+func fakeTransactionsPage(accountUUIDs []string, page db.TransactionPage) map[string][]db.Transaction {
+	pages := map[string][]db.Transaction{}
+	for _, accountUUID := range accountUUIDs {
+		for _, transaction := range testGraphQLTransactions[accountUUID] {
+			if page.After != nil && (transaction.Date > page.After.Date ||
+				(transaction.Date == page.After.Date && transaction.UUID >= page.After.UUID)) {
+				continue
+			}
+			if len(pages[accountUUID]) < page.Limit {
+				pages[accountUUID] = append(pages[accountUUID], transaction)
+			}
+		}
+	}
+	return pages
+}
+
+func TestGraphQLBatchesLookups(t *testing.T) {
+	s := newGraphQLTestServer()
+	s.db.GetAccountsReturns(testGraphQLAccounts, nil)
+	s.db.GetAccountsTransactionsPageStub = func(_ context.Context, _ string, accountUUIDs []string, page db.TransactionPage) (map[string][]db.Transaction, error) {
+		return fakeTransactionsPage(accountUUIDs, page), nil
+	}
+	s.db.GetTagsForTransactionsReturns(map[string][]string{"txn-1c": {"coffee"}}, nil)
+
+	data, errs := graphQL(t, s, `{
+		viewer {
+			accounts {
+				id
+				transactions(first: 2) {
+					edges { node { id tags account { name } } }
+				}
+			}
+		}
+	}`, nil)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	if tags := jsonPath(data, "viewer", "accounts", 0, "transactions", "edges", 0, "node", "tags"); !reflect.DeepEqual(tags, []interface{}{"coffee"}) {
+		t.Errorf("tags = %v", tags)
+	}
+	if name := jsonPath(data, "viewer", "accounts", 1, "transactions", "edges", 0, "node", "account", "name"); name != "Savings" {
+		t.Errorf("account name = %v", name)
+	}
+
+	//one query for each kind of thing, however many accounts there are
+	if n := s.db.GetAccountsCallCount(); n != 1 {
+		t.Errorf("accounts were fetched %d times, want 1", n)
+	}
+	if n := s.db.GetAccountsTransactionsPageCallCount(); n != 1 {
+		t.Fatalf("transactions were fetched %d times, want 1", n)
+	}
+	_, _, accountUUIDs, page := s.db.GetAccountsTransactionsPageArgsForCall(0)
+	if want := []string{"acct-1", "acct-2", "acct-3"}; !reflect.DeepEqual(accountUUIDs, want) {
+		t.Errorf("fetched transactions for %v, want %v", accountUUIDs, want)
+	}
+	if page.Limit != 3 {
+		t.Errorf("fetched %d transactions per account, want one more than the page size", page.Limit)
+	}
+	if n := s.db.GetTagsForTransactionsCallCount(); n != 1 {
+		t.Fatalf("tags were fetched %d times, want 1", n)
+	}
+	_, _, transactionUUIDs := s.db.GetTagsForTransactionsArgsForCall(0)
+	sort.Strings(transactionUUIDs)
+	if want := []string{"txn-1b", "txn-1c", "txn-2a"}; !reflect.DeepEqual(transactionUUIDs, want) {
+		t.Errorf("fetched tags for %v, want %v", transactionUUIDs, want)
+	}
+}
+
+func TestGraphQLTransactionPaging(t *testing.T) {
+	s := newGraphQLTestServer()
+	s.db.GetAccountsReturns(testGraphQLAccounts, nil)
+	s.db.GetAccountsTransactionsPageStub = func(_ context.Context, _ string, accountUUIDs []string, page db.TransactionPage) (map[string][]db.Transaction, error) {
+		return fakeTransactionsPage(accountUUIDs, page), nil
+	}
+
+	query := `query($after: String) {
+		viewer {
+			accounts {
+				id
+				transactions(first: 2, after: $after) {
+					edges { cursor node { id } }
+					pageInfo { hasNextPage endCursor }
+				}
+			}
+		}
+	}`
+	ids := func(data map[string]interface{}, account int) []string {
+		var ids []string
+		for _, edge := range jsonPath(data, "viewer", "accounts", account, "transactions", "edges").([]interface{}) {
+			ids = append(ids, jsonPath(edge, "node", "id").(string))
+		}
+		return ids
+	}
+
+	data, errs := graphQL(t, s, query, nil)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	if got := ids(data, 0); !reflect.DeepEqual(got, []string{"txn-1c", "txn-1b"}) {
+		t.Errorf("first page of acct-1 = %v", got)
+	}
+	if got := ids(data, 1); !reflect.DeepEqual(got, []string{"txn-2a"}) {
+		t.Errorf("first page of acct-2 = %v", got)
+	}
+	if jsonPath(data, "viewer", "accounts", 0, "transactions", "pageInfo", "hasNextPage") != true {
+		t.Error("acct-1 has another page")
+	}
+	if jsonPath(data, "viewer", "accounts", 1, "transactions", "pageInfo", "hasNextPage") != false {
+		t.Error("acct-2 has no other page")
+	}
+	if jsonPath(data, "viewer", "accounts", 2, "transactions", "pageInfo", "endCursor") != nil {
+		t.Error("acct-3 has no transactions, so no cursor")
+	}
+
+	endCursor := jsonPath(data, "viewer", "accounts", 0, "transactions", "pageInfo", "endCursor")
+	if lastEdge := jsonPath(data, "viewer", "accounts", 0, "transactions", "edges", 1, "cursor"); endCursor != lastEdge {
+		t.Errorf("endCursor %v isn't the last edge's cursor %v", endCursor, lastEdge)
+	}
+
+	data, errs = graphQL(t, s, query, map[string]interface{}{"after": endCursor})
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+	_, _, _, page := s.db.GetAccountsTransactionsPageArgsForCall(1)
+	if page.After == nil || page.After.Date != "2020-01-02" || page.After.UUID != "txn-1b" {
+		t.Errorf("fetched the page after %v", page.After)
+	}
+	if got := ids(data, 0); !reflect.DeepEqual(got, []string{"txn-1a"}) {
+		t.Errorf("second page of acct-1 = %v", got)
+	}
+	if jsonPath(data, "viewer", "accounts", 0, "transactions", "pageInfo", "hasNextPage") != false {
+		t.Error("acct-1 has no third page")
+	}
+}
+
+func TestGraphQLTransactionArguments(t *testing.T) {
+	s := newGraphQLTestServer()
+	s.db.GetAccountsReturns(testGraphQLAccounts[:1], nil)
+
+	for query, want := range map[string]string{
+		`{ viewer { accounts { transactions(first: 0) { edges { cursor } } } } }`:            "first must be between 1 and 100",
+		`{ viewer { accounts { transactions(first: 101) { edges { cursor } } } } }`:          "first must be between 1 and 100",
+		`{ viewer { accounts { transactions(after: "!!!") { edges { cursor } } } } }`:        "invalid cursor",
+		`{ viewer { accounts { transactions(startDate: "1/2/20") { edges { cursor } } } } }`: "startDate must be formatted as 2006-01-02",
+	} {
+		_, errs := graphQL(t, s, query, nil)
+		if len(errs) != 1 || errs[0] != want {
+			t.Errorf("%s: got errors %v, want %q", query, errs, want)
+		}
+	}
+	if n := s.db.GetAccountsTransactionsPageCallCount(); n != 0 {
+		t.Errorf("transactions were fetched %d times", n)
+	}
+}
+
+func TestGraphQLScopedToUser(t *testing.T) {
+	s := newGraphQLTestServer()
+	s.db.GetItemsReturns([]db.Item{}, nil)
+	s.db.GetAccountsReturns(testGraphQLAccounts[:1], nil)
+	s.db.GetTransactionReturns(db.Transaction{}, db.ErrNoSuchTransaction)
+
+	data, errs := graphQL(t, s, `{
+		viewer { id }
+		mine: account(id: "acct-1") { id }
+		theirs: account(id: "acct-elsewhere") { id }
+		item(id: "item-elsewhere") { id }
+		transaction(id: "txn-elsewhere") { id }
+	}`, nil)
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	if id := jsonPath(data, "viewer", "id"); id != testUserUUID {
+		t.Errorf("viewer = %v", id)
+	}
+	if id := jsonPath(data, "mine", "id"); id != "acct-1" {
+		t.Errorf("mine = %v", id)
+	}
+	for _, key := range []string{"theirs", "item", "transaction"} {
+		if data[key] != nil {
+			t.Errorf("%s = %v, want null", key, data[key])
+		}
+	}
+
+	if _, userUUID := s.db.GetAccountsArgsForCall(0); userUUID != testUserUUID {
+		t.Errorf("fetched the accounts of `%s`", userUUID)
+	}
+	if _, userUUID := s.db.GetItemsArgsForCall(0); userUUID != testUserUUID {
+		t.Errorf("fetched the items of `%s`", userUUID)
+	}
+	if _, userUUID, uuid := s.db.GetTransactionArgsForCall(0); userUUID != testUserUUID || uuid != "txn-elsewhere" {
+		t.Errorf("fetched transaction `%s` of `%s`", uuid, userUUID)
+	}
+}
+
+func TestGraphQLInternalError(t *testing.T) {
+	s := newGraphQLTestServer()
+	s.db.GetAccountsReturns(nil, db.ErrNoSuchAccount)
+
+	data, errs := graphQL(t, s, `{ viewer { id accounts { id } } }`, nil)
+	if len(errs) != 1 || errs[0] != internalError {
+		t.Errorf("got errors %v", errs)
+	}
+	//accounts is non-null, so the whole viewer is null
+	if data["viewer"] != nil {
+		t.Errorf("viewer = %v", data["viewer"])
+	}
+	if n := s.logger.ErrorfCallCount(); n != 1 {
+		t.Errorf("logged %d errors, want 1", n)
+	}
+}
+
+func TestGraphQLMaxDepth(t *testing.T) {
+	s := newGraphQLTestServer()
+
+	//each round adds two levels under viewer
+	selection := "id"
+	for i := 0; i < maxGraphQLDepth/2; i++ {
+		selection = "accounts { item { " + selection + " } }"
+	}
+	_, errs := graphQL(t, s, "{ viewer { "+selection+" } }", nil)
+	if want := "the query is nested more than 10 levels deep"; len(errs) != 1 || errs[0] != want {
+		t.Errorf("got errors %v, want %q", errs, want)
+	}
+	if n := s.db.GetAccountsCallCount(); n != 0 {
+		t.Errorf("accounts were fetched %d times", n)
+	}
+}
+
+func TestGetGraphQLSchema(t *testing.T) {
+	s := newGraphQLTestServer()
+
+	e := gin.New()
+	e.GET("/graphql/schema", s.GetGraphQLSchema)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/graphql/schema", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	for _, want := range []string{
+		"type Query {",
+		"scalar Decimal",
+		"transactions(first: Int = 20, after: String, startDate: String, endDate: String): TransactionConnection!",
+	} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Errorf("the schema doesn't contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/statement"
+	"github.com/xanderflood/plaid-ui/pkg/webhooks"
+)
+
+//ImportStatement loads transactions into a manual account from an
+//uploaded CSV or OFX file. The multipart form carries the file under
+//`file`, and CSV imports also need a statement.CSVProfile as JSON under
+//`profile`. Rows that carry a FITID already imported into the account
+//are skipped, as are rows without one that match an existing transaction
+//on date, amount and description.
+func (a ServerAgent) ImportStatement(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such account")
+	if !ok {
+		return //an error response has already been generated
+	}
+	account, err := a.dbClient.GetAccount(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchAccount {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such account"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting account `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "import failed - see logs for details"})
+		return
+	}
+	if !account.Access.CanEdit() {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "this account is shared with you read-only"})
+		return
+	}
+	if !account.Manual {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "statements can only be imported into manual accounts"})
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "a statement file must be provided as `file`"})
+		return
+	}
+
+	format := c.PostForm("format")
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(fileHeader.Filename)), ".")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	var records []statement.Record
+	switch statement.Format(format) {
+	case statement.FormatCSV:
+		var profile statement.CSVProfile
+		if err := json.Unmarshal([]byte(c.PostForm("profile")), &profile); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "CSV imports require a JSON column-mapping `profile`"})
+			return
+		}
+		records, err = statement.ParseCSV(file, profile)
+	case statement.FormatOFX, statement.FormatQFX:
+		records, err = statement.ParseOFX(file)
+	default:
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unsupported import format `%s`", format)})
+		return
+	}
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	inserted, skipped, err := a.importRecords(c, account, records)
+	if inserted > 0 {
+		//a failed import can still have added some transactions
+		a.audit(c, auth, db.AuditActionAccountImported, "account", account.UUID, nil, gin.H{
+			"format":   format,
+			"filename": fileHeader.Filename,
+			"inserted": inserted,
+			"skipped":  skipped,
+		})
+	}
+	if err != nil {
+		a.logger.Errorf("failed importing %s statement into account `%s`: %s", format, account.UUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error":    "import failed - see logs for details",
+			"inserted": inserted,
+			"skipped":  skipped,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"inserted": inserted,
+		"skipped":  skipped,
+	})
+}
+
+//importRecords inserts every record that doesn't duplicate an existing
+//transaction. A record with a FITID is the same transaction as one with
+//that FITID, and can only otherwise match a transaction that has none,
+//such as one imported from a CSV file. Matches on date, amount and
+//description are counted, so that a file containing two identical
+//purchases on the same day can still add the second one.
+func (a ServerAgent) importRecords(c *gin.Context, account db.Account, records []statement.Record) (int, int, error) {
+	if len(records) == 0 {
+		return 0, 0, nil
+	}
+
+	startDate, endDate := records[0].Date, records[0].Date
+	for _, record := range records {
+		if record.Date < startDate {
+			startDate = record.Date
+		}
+		if record.Date > endDate {
+			endDate = record.Date
+		}
+	}
+
+	existing, err := a.dbClient.GetTransactionsByDateRange(c, account.UserUUID, account.UUID, startDate, endDate)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fitIDs := map[string]bool{}
+	seen := map[string]int{}
+	seenWithFITID := map[string]int{}
+	for _, t := range existing {
+		key := statement.DedupKey(t.Date, t.Amount, t.PlaidName)
+		if t.FITID != "" {
+			fitIDs[t.FITID] = true
+			seenWithFITID[key]++
+		} else {
+			seen[key]++
+		}
+	}
+
+	var inserted, skipped int
+	for _, record := range records {
+		key := statement.DedupKey(record.Date, record.Amount, record.Description)
+		switch {
+		case record.FITID != "" && fitIDs[record.FITID]:
+			skipped++
+			continue
+		case seen[key] > 0:
+			seen[key]--
+			skipped++
+			continue
+		case record.FITID == "" && seenWithFITID[key] > 0:
+			seenWithFITID[key]--
+			skipped++
+			continue
+		}
+
+		transaction := db.Transaction{
+			AccountUUID: account.UUID,
+			UserUUID:    account.UserUUID,
+
+			ISOCurrencyCode: record.ISOCurrencyCode,
+			Amount:          record.Amount,
+			Date:            record.Date,
+
+			PlaidName: record.Description,
+
+			FITID: record.FITID,
+		}
+		uuid, _, err := a.dbClient.UpsertTransaction(c, transaction)
+		if err != nil {
+			return inserted, skipped, err
+		}
+		if record.FITID != "" {
+			fitIDs[record.FITID] = true
+		}
+		transaction.UUID = uuid
+		a.emit(c, account.UserUUID, webhooks.EventTransactionCreated, transaction)
+		inserted++
+	}
+
+	return inserted, skipped, nil
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"bytes"
+	"math/big"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/webhooks/webhooksfakes"
+)
+
+const testOFX = `OFXHEADER:100
+DATA:OFXSGML
+
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS>
+<CURDEF>USD
+<BANKTRANLIST>
+<STMTTRN><DTPOSTED>20200103<TRNAMT>-12.50<FITID>F1<NAME>COFFEE SHOP 123</STMTTRN>
+<STMTTRN><DTPOSTED>20200104<TRNAMT>-30.00<FITID>F2<NAME>Books</STMTTRN>
+<STMTTRN><DTPOSTED>20200105<TRNAMT>-8.00<FITID>F3<NAME>Lunch</STMTTRN>
+<STMTTRN><DTPOSTED>20200105<TRNAMT>-8.00<FITID>F3<NAME>Lunch</STMTTRN>
+<STMTTRN><DTPOSTED>20200106<TRNAMT>-8.00<FITID>F4<NAME>Lunch</STMTTRN>
+</BANKTRANLIST>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>
+`
+
+func TestImportStatementFITID(t *testing.T) {
+	s := newTestServer()
+	dispatcher := &webhooksfakes.FakeDispatcher{}
+	s.webhookDispatcher = dispatcher
+	s.db.GetAccountReturns(db.Account{Model: db.Model{UUID: testAccountUUID}, UserUUID: testUserUUID, Manual: true, Access: db.ShareLevelOwner}, nil)
+	s.db.GetTransactionsByDateRangeReturns([]db.Transaction{
+		//imported before under a description the bank has since changed
+		{Date: "2020-01-03", Amount: big.NewFloat(12.5), PlaidName: "Coffee", FITID: "F1"},
+		//imported from a CSV file, which carries no FITID
+		{Date: "2020-01-04", Amount: big.NewFloat(30), PlaidName: "Books"},
+		//the same date, amount and description as F4, but a different FITID
+		{Date: "2020-01-06", Amount: big.NewFloat(8), PlaidName: "Lunch", FITID: "F0"},
+	}, nil)
+	s.db.UpsertTransactionReturns("txn-new", true, nil)
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", "statement.ofx")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write([]byte(testOFX)) //nolint:errcheck
+	form.Close()                //nolint:errcheck
+
+	req := httptest.NewRequest("POST", "/api/v1/accounts/"+testAccountUUID+"/import", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	status, resp := serveRequest(t, s.ImportStatement, "/api/v1/accounts/:id/import", req)
+	assertStatus(t, status, http.StatusOK, resp)
+	if resp["inserted"] != 2.0 || resp["skipped"] != 3.0 {
+		t.Errorf("inserted %v and skipped %v, want 2 and 3", resp["inserted"], resp["skipped"])
+	}
+
+	if n := s.db.UpsertTransactionCallCount(); n != 2 {
+		t.Fatalf("%d transactions were inserted, want 2", n)
+	}
+	for i, want := range []string{"F3", "F4"} {
+		if _, transaction := s.db.UpsertTransactionArgsForCall(i); transaction.FITID != want {
+			t.Errorf("inserted FITID `%s`, want `%s`", transaction.FITID, want)
+		}
+	}
+	if n := dispatcher.EmitCallCount(); n != 2 {
+		t.Errorf("%d events were emitted, want 2", n)
+	}
+}
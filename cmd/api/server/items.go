@@ -0,0 +1,149 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+	"github.com/plaid/plaid-go/plaid"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+//GetItems lists the user's items
+func (a ServerAgent) GetItems(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	items, err := a.dbClient.GetItems(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting items for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	if items == nil {
+		items = []db.Item{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+	})
+}
+
+//GetItem gets one of the user's items along with those of its accounts
+//the user can see
+func (a ServerAgent) GetItem(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such item")
+	if !ok {
+		return //an error response has already been generated
+	}
+	item, err := a.dbClient.GetItem(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchItem {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such item"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting item `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	//users the item is shared with may only see some of its accounts
+	userAccounts, err := a.dbClient.GetAccounts(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting accounts for item `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	accounts := []db.Account{}
+	for _, account := range userAccounts {
+		if account.PlaidItemID == item.PlaidItemID {
+			accounts = append(accounts, account)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"item":     item,
+		"accounts": accounts,
+	})
+}
+
+//DeleteItem unlinks one of the user's items from Plaid and removes it
+//along with its accounts. Their transactions are kept.
+func (a ServerAgent) DeleteItem(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such item")
+	if !ok {
+		return //an error response has already been generated
+	}
+	item, err := a.dbClient.GetItem(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchItem {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such item"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting item `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	if item.Access != db.ShareLevelOwner {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "only the item's owner can delete it"})
+		return
+	}
+
+	err = a.removePlaidItem(c, item.PlaidItemID)
+	if err != nil {
+		a.logger.Errorf("failed removing plaid item `%s`: %s", item.PlaidItemID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "delete failed - see logs for details"})
+		return
+	}
+
+	err = a.dbClient.DeleteItem(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchItem {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such item"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed deleting item `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "delete failed - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionItemRemoved, "item", uuid, gin.H{
+		"plaid_item_id":    item.PlaidItemID,
+		"institution_name": item.InstitutionName,
+	}, nil)
+
+	c.Status(http.StatusNoContent)
+}
+
+//removePlaidItem unlinks an item from Plaid, using the access token of
+//any of its accounts
+func (a ServerAgent) removePlaidItem(ctx context.Context, plaidItemID string) error {
+	accounts, err := a.dbClient.GetAccountsByPlaidItemID(ctx, plaidItemID)
+	if err != nil {
+		return err
+	}
+	if len(accounts) == 0 {
+		return nil
+	}
+
+	_, err = a.plaidClient.RemoveItem(accounts[0].PlaidAccessToken)
+
+	//an item Plaid no longer knows about is as good as removed
+	if plaidErr, ok := errors.Cause(err).(plaid.Error); ok && plaidErr.ErrorCode == "ITEM_NOT_FOUND" {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/plaid/plaid-go/plaid"
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+const (
+	//catchUpOverlapDays is how far before an item's last sync a catch-up
+	//sync starts, so that pending transactions which have since posted
+	//are picked up
+	catchUpOverlapDays = 7
+
+	//catchUpInitialDays is how far back to sync an item that never synced
+	catchUpInitialDays = 30
+
+	plaidTransactionsPageSize = 500
+
+	defaultJobRunsLimit = 50
+	maxJobRunsLimit     = 500
+)
+
+//RefreshAllBalances records fresh balances for every healthy item
+func (a ServerAgent) RefreshAllBalances(ctx context.Context) error {
+	items, err := a.dbClient.GetItemsSyncedBefore(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, item := range items {
+		accounts, err := a.dbClient.GetAccountsByPlaidItemID(ctx, item.PlaidItemID)
+		if err != nil {
+			return err
+		}
+		if len(accounts) == 0 {
+			continue
+		}
+
+		accountMapping := map[string]db.Account{}
+		for _, account := range accounts {
+			accountMapping[account.PlaidAccountID] = account
+		}
+
+		err = a.refreshBalances(ctx, item.UserUUID, accounts[0].PlaidAccessToken, accountMapping)
+		if err != nil {
+			a.logger.Errorf("failed refreshing balances for plaid item `%s`: %s", item.PlaidItemID, err.Error())
+			a.recordItemError(ctx, item.PlaidItemID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return errors.Errorf("failed refreshing balances for %d of %d items", failed, len(items))
+	}
+	return nil
+}
+
+//CatchUpSync pulls recent transactions for every healthy item that
+//hasn't synced within staleAfter, in case Plaid's webhooks were missed
+func (a ServerAgent) CatchUpSync(ctx context.Context, staleAfter time.Duration) error {
+	now := time.Now()
+	items, err := a.dbClient.GetItemsSyncedBefore(ctx, now.Add(-staleAfter))
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, item := range items {
+		start := now.AddDate(0, 0, -catchUpInitialDays)
+		if item.LastSyncedAt != nil {
+			start = item.LastSyncedAt.AddDate(0, 0, -catchUpOverlapDays)
+		}
+
+		err := a.syncItem(ctx, item.PlaidItemID, start, now)
+		if err != nil {
+			a.logger.Errorf("failed catching up plaid item `%s`: %s", item.PlaidItemID, err.Error())
+			a.recordItemError(ctx, item.PlaidItemID, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return errors.Errorf("failed catching up %d of %d items", failed, len(items))
+	}
+	return nil
+}
+
+//Housekeeping deletes job and webhook delivery history older than
+//retention
+func (a ServerAgent) Housekeeping(ctx context.Context, retention time.Duration) error {
+	before := time.Now().Add(-retention)
+
+	runs, err := a.dbClient.PruneJobRuns(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	deliveries, err := a.dbClient.PruneWebhookDeliveries(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	a.logger.Infof("housekeeping pruned %d job runs and %d webhook deliveries", runs, deliveries)
+	return nil
+}
+
+//syncItem pulls every transaction in a date range for an item
+func (a ServerAgent) syncItem(ctx context.Context, itemID string, start time.Time, end time.Time) error {
+	accts, err := a.dbClient.GetAccountsByPlaidItemID(ctx, itemID)
+	if err != nil {
+		return err
+	}
+	if len(accts) == 0 {
+		return nil
+	}
+
+	var accountMapping = map[string]db.Account{}
+	var accessToken = accts[0].PlaidAccessToken
+	var userUUID = accts[0].UserUUID
+	for _, account := range accts {
+		accountMapping[account.PlaidAccountID] = account
+	}
+
+	added, err := a.pullTransactions(ctx, userUUID, accessToken, accountMapping, start, end)
+	if err != nil {
+		return err
+	}
+
+	a.afterSync(ctx, itemID, userUUID, accessToken, accountMapping, added)
+	return nil
+}
+
+//pullTransactions saves every transaction Plaid has for an item between
+//two dates, a page at a time, and returns the ones that were new
+func (a ServerAgent) pullTransactions(ctx context.Context, userUUID string, accessToken string, accounts map[string]db.Account, start time.Time, end time.Time) ([]db.Transaction, error) {
+	var added []db.Transaction
+	for offset := 0; ; {
+		resp, err := a.plaidClient.GetTransactionsWithOptions(accessToken, plaid.GetTransactionsOptions{
+			StartDate:  start.Format(plaidapi.DateFormat),
+			EndDate:    end.Format(plaidapi.DateFormat),
+			AccountIDs: []string{},
+			Count:      plaidTransactionsPageSize,
+			Offset:     offset,
+		})
+		if err != nil {
+			return added, err
+		}
+
+		for _, plaidTransaction := range resp.Transactions {
+			transaction, isNew, err := a.upsertPlaidTransaction(ctx, userUUID, accounts, plaidTransaction)
+			if err != nil {
+				return added, err
+			}
+			if isNew {
+				added = append(added, transaction)
+			}
+		}
+
+		offset += len(resp.Transactions)
+		if len(resp.Transactions) == 0 || offset >= resp.TotalTransactions {
+			return added, nil
+		}
+	}
+}
+
+//recordItemError marks an item as needing attention when Plaid reports
+//a problem with it, so that scheduled jobs stop retrying it
+func (a ServerAgent) recordItemError(ctx context.Context, itemID string, err error) {
+	plaidErr, ok := errors.Cause(err).(plaid.Error)
+	if !ok || plaidErr.ErrorType != "ITEM_ERROR" {
+		return
+	}
+
+	status := db.ItemStatusError
+	if plaidErr.ErrorCode == "ITEM_LOGIN_REQUIRED" {
+		status = db.ItemStatusLoginRequired
+	}
+	if err := a.dbClient.SetItemStatus(ctx, itemID, status, plaidErr.ErrorCode); err != nil {
+		a.logger.Errorf("failed updating status of plaid item `%s`: %s", itemID, err.Error())
+	}
+}
+
+//GetJobs lists the scheduled jobs
+func (a ServerAgent) GetJobs(c *gin.Context) {
+	if _, ok := a.authorize(c); !ok {
+		return //an error response has already been generated
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs": a.scheduler.Jobs(),
+	})
+}
+
+//GetJobRuns lists recent runs of scheduled jobs, most recent first,
+//optionally for a single job
+func (a ServerAgent) GetJobRuns(c *gin.Context) {
+	if _, ok := a.authorize(c); !ok {
+		return //an error response has already been generated
+	}
+
+	limit := defaultJobRunsLimit
+	if s := c.Query("limit"); s != "" {
+		var err error
+		limit, err = strconv.Atoi(s)
+		if err != nil || limit < 1 || limit > maxJobRunsLimit {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxJobRunsLimit)})
+			return
+		}
+	}
+
+	runs, err := a.dbClient.GetJobRuns(c, c.Query("job"), limit)
+	if err != nil {
+		a.logger.Errorf("failed getting job runs: %s", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"runs": runs,
+	})
+}
@@ -0,0 +1,25 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//Login starts a login with the OpenID Connect provider
+func (a ServerAgent) Login(c *gin.Context) {
+	if a.oidc == nil {
+		a.renderer.RenderStatusCode(http.StatusNotFound, "Built-in login is not enabled.", c)
+		return
+	}
+	a.oidc.Login(c)
+}
+
+//OIDCCallback finishes a login with the OpenID Connect provider
+func (a ServerAgent) OIDCCallback(c *gin.Context) {
+	if a.oidc == nil {
+		a.renderer.RenderStatusCode(http.StatusNotFound, "Built-in login is not enabled.", c)
+		return
+	}
+	a.oidc.Callback(c)
+}
@@ -0,0 +1,278 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
+	"github.com/xanderflood/plaid-ui/pkg/blob"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+//ExportMe builds a ZIP archive of everything stored about the user: their
+//profile and settings, the items and accounts they own, those accounts'
+//transactions, notes and balances, and the attachments they've uploaded.
+//Plaid access tokens and webhook secrets are left out.
+func (a ServerAgent) ExportMe(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var buf bytes.Buffer
+	if err := a.writeUserExport(c, auth, &buf); err != nil {
+		a.logger.Errorf("failed exporting data for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "export failed - see logs for details"})
+		return
+	}
+
+	a.audit(c, auth, db.AuditActionUserExported, "user", auth.UserUUID, nil, gin.H{"format": "zip"})
+	c.Header("Content-Disposition", `attachment; filename="plaid-ui-export.zip"`)
+	c.Data(http.StatusOK, "application/zip", buf.Bytes())
+}
+
+//DeleteMe permanently deletes the user and all of their data, after
+//unlinking their items from Plaid. A record of the deletion is kept in
+//the audit log.
+func (a ServerAgent) DeleteMe(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	//users who have never registered have nothing stored to delete
+	err := a.deleteUserData(c, auth.UserUUID)
+	if err != nil && err != db.ErrNoSuchUser {
+		a.logger.Errorf("failed deleting user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "delete failed - see logs for details"})
+		return
+	}
+
+	a.audit(c, auth, db.AuditActionUserDeleted, "user", auth.UserUUID, nil, nil)
+	c.Status(http.StatusNoContent)
+}
+
+//writeUserExport writes the archive for ExportMe
+func (a ServerAgent) writeUserExport(ctx context.Context, authorization auth.Authorization, w io.Writer) error {
+	uuid := authorization.UserUUID
+	archive := exportArchive{zip.NewWriter(w)}
+
+	//users who have never registered only exist in their login
+	user, err := a.dbClient.GetUser(ctx, uuid)
+	if err == db.ErrNoSuchUser {
+		user = db.User{Model: db.Model{UUID: uuid}, Email: authorization.Email, Role: authorization.Role}
+	} else if err != nil {
+		return err
+	}
+	prefs, err := a.dbClient.GetDigestPreferences(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	err = archive.writeJSON("profile.json", gin.H{
+		"user":               user,
+		"digest_preferences": prefs,
+	})
+	if err != nil {
+		return err
+	}
+
+	allItems, err := a.dbClient.GetItems(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	items := []db.Item{}
+	for _, item := range allItems {
+		if item.Access == db.ShareLevelOwner {
+			items = append(items, item)
+		}
+	}
+	if err := archive.writeJSON("items.json", items); err != nil {
+		return err
+	}
+
+	allAccounts, err := a.dbClient.GetAccounts(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	accounts := []db.Account{}
+	transactions := []db.Transaction{}
+	balances := []db.Balance{}
+	for _, account := range allAccounts {
+		if account.Access != db.ShareLevelOwner {
+			continue
+		}
+		accounts = append(accounts, account)
+
+		accountTransactions, err := a.dbClient.GetTransactions(ctx, uuid, account.UUID)
+		if err != nil {
+			return err
+		}
+		transactions = append(transactions, accountTransactions...)
+
+		accountBalances, err := a.dbClient.GetBalances(ctx, uuid, account.UUID)
+		if err != nil {
+			return err
+		}
+		balances = append(balances, accountBalances...)
+	}
+	if err := archive.writeJSON("accounts.json", accounts); err != nil {
+		return err
+	}
+
+	transactionUUIDs := make([]string, len(transactions))
+	for i, transaction := range transactions {
+		transactionUUIDs[i] = transaction.UUID
+	}
+	tags, err := a.dbClient.GetTagsForTransactions(ctx, uuid, transactionUUIDs)
+	if err != nil {
+		return err
+	}
+	splits, err := a.dbClient.GetSplitsForTransactions(ctx, uuid, transactionUUIDs)
+	if err != nil {
+		return err
+	}
+
+	rows := [][]string{{"uuid", "account_uuid", "date", "amount", "iso_currency_code", "name", "category_id", "pending", "note", "tags"}}
+	for _, t := range transactions {
+		rows = append(rows, []string{
+			t.UUID, t.AccountUUID, t.Date, formatExportAmount(t.Amount), t.ISOCurrencyCode,
+			t.PlaidName, t.PlaidCategoryID, strconv.FormatBool(t.PlaidPending), t.Note,
+			strings.Join(tags[t.UUID], ";"),
+		})
+	}
+	if err := archive.writeCSV("transactions.csv", rows); err != nil {
+		return err
+	}
+
+	allSplits := []db.Split{}
+	for _, transactionUUID := range transactionUUIDs {
+		allSplits = append(allSplits, splits[transactionUUID]...)
+	}
+	if err := archive.writeJSON("splits.json", allSplits); err != nil {
+		return err
+	}
+
+	rows = [][]string{{"account_uuid", "date", "iso_currency_code", "current", "available", "limit"}}
+	for _, b := range balances {
+		rows = append(rows, []string{
+			b.AccountUUID, b.Date, b.ISOCurrencyCode,
+			formatExportAmount(b.Current), formatExportAmount(b.Available), formatExportAmount(b.Limit),
+		})
+	}
+	if err := archive.writeCSV("balances.csv", rows); err != nil {
+		return err
+	}
+
+	transfers, err := a.dbClient.GetTransfers(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err := archive.writeJSON("transfers.json", transfers); err != nil {
+		return err
+	}
+
+	//alerts hold the user's rules and budgets
+	alerts, err := a.dbClient.GetAlerts(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err := archive.writeJSON("alerts.json", alerts); err != nil {
+		return err
+	}
+
+	endpoints, err := a.dbClient.GetWebhookEndpoints(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err := archive.writeJSON("webhook_endpoints.json", endpoints); err != nil {
+		return err
+	}
+
+	tokens, err := a.dbClient.GetPersonalAccessTokens(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err := archive.writeJSON("tokens.json", tokens); err != nil {
+		return err
+	}
+
+	shares, err := a.dbClient.GetShares(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err := archive.writeJSON("shares.json", shares); err != nil {
+		return err
+	}
+
+	attachments, err := a.dbClient.GetUserAttachments(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	if err := archive.writeJSON("attachments.json", attachments); err != nil {
+		return err
+	}
+	for _, attachment := range attachments {
+		if err := archive.copyBlob(ctx, a.blobStore, "attachments/"+attachment.UUID+"-"+path.Base(attachment.Filename), attachment.BlobKey()); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+//exportArchive adds the files of a user's export to a ZIP archive
+type exportArchive struct {
+	*zip.Writer
+}
+
+func (z exportArchive) writeJSON(name string, v interface{}) error {
+	w, err := z.Create(name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add `%s` to export", name)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return errors.Wrapf(enc.Encode(v), "failed to write `%s` to export", name)
+}
+
+func (z exportArchive) writeCSV(name string, rows [][]string) error {
+	w, err := z.Create(name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add `%s` to export", name)
+	}
+	return errors.Wrapf(csv.NewWriter(w).WriteAll(rows), "failed to write `%s` to export", name)
+}
+
+func (z exportArchive) copyBlob(ctx context.Context, store blob.Store, name string, key string) error {
+	contents, err := store.Get(ctx, key)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get contents of `%s`", key)
+	}
+	defer contents.Close()
+
+	w, err := z.Create(name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add `%s` to export", name)
+	}
+	_, err = io.Copy(w, contents)
+	return errors.Wrapf(err, "failed to write `%s` to export", name)
+}
+
+func formatExportAmount(f *big.Float) string {
+	if f == nil {
+		return ""
+	}
+	return f.Text('f', -1)
+}
@@ -0,0 +1,730 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/forecast"
+	"github.com/xanderflood/plaid-ui/pkg/graphql"
+	"github.com/xanderflood/plaid-ui/pkg/ledger"
+	"github.com/xanderflood/plaid-ui/pkg/openapi"
+	"github.com/xanderflood/plaid-ui/pkg/scheduler"
+	"github.com/xanderflood/plaid-ui/pkg/statement"
+)
+
+//APIVersion is the version of the API described by APIDocument
+const APIVersion = "1.0.0"
+
+//ServeOpenAPI serves the OpenAPI document describing this API
+func (a ServerAgent) ServeOpenAPI(c *gin.Context) {
+	c.JSON(http.StatusOK, a.openAPIDocument)
+}
+
+//CheckOpenAPI makes sure that APIDocument and the registered routes
+//match in both directions, and that operation IDs are unique, so that
+//the document can't silently fall behind AddRoutes
+func CheckOpenAPI(routes gin.RoutesInfo) error {
+	doc := APIDocument()
+
+	var problems []string
+	routed := map[string]bool{}
+	for _, route := range routes {
+		routed[route.Method+" "+openapi.GinPathToOpenAPI(route.Path)] = true
+		if _, ok := doc.Lookup(route.Method, route.Path); !ok {
+			problems = append(problems, "route "+route.Method+" "+route.Path+" isn't documented")
+		}
+	}
+
+	operationIDs := map[string]string{}
+	for _, op := range doc.Operations() {
+		name := op.Method + " " + op.Path
+		if !routed[name] {
+			problems = append(problems, "operation "+name+" has no route")
+		}
+		if other, ok := operationIDs[op.Operation.OperationID]; ok {
+			problems = append(problems, other+" and "+name+" share the operation ID `"+op.Operation.OperationID+"`")
+		}
+		operationIDs[op.Operation.OperationID] = name
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return errors.Errorf("the OpenAPI document doesn't match the routes: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+//APIDocument describes every route that AddRoutes registers. Schemas
+//are generated from the same types that the handlers bind and render,
+//and each response envelope lists the keys of the handler's `gin.H`.
+func APIDocument() *openapi.Document {
+	d := openapi.NewDocument("plaid-ui", APIVersion)
+	s := &d.Components
+
+	s.SecuritySchemes = map[string]*openapi.SecurityScheme{
+		"bearer": {
+			Type:         "http",
+			Scheme:       "bearer",
+			BearerFormat: "JWT",
+			Description:  "A login JWT, or a personal access token limited to its scopes: " + strings.Join(scopeNames(), ", ") + ". Either way, requests are also limited to the permissions of the user's role; staff roles may also have " + strings.Join(staffScopeNames(), ", ") + ".",
+		},
+		"cookie": {Type: "apiKey", In: "cookie", Name: "_identify_jwt_string"},
+	}
+	d.Security = []openapi.SecurityRequirement{{"bearer": {}}}
+	s.Schemas["Error"] = s.Object(map[string]interface{}{"error": ""})
+
+	var (
+		startDate   = query("start_date", fmt.Sprintf("first day to include, as YYYY-MM-DD; defaults to %d days before end_date", defaultStatementDays))
+		endDate     = query("end_date", "last day to include, as YYYY-MM-DD; defaults to today")
+		transaction = s.Object(map[string]interface{}{
+			"transaction":           db.Transaction{},
+			"tags":                  []string{},
+			"attachments":           []db.Attachment{},
+			"splits":                []db.Split{},
+			"splits_out_of_balance": false,
+		})
+	)
+
+	//frontend and Plaid
+	d.Add("GET", "/", &openapi.Operation{
+		OperationID: "serveSPA",
+		Summary:     "Serve the single-page app, redirecting to login without a session",
+		Tags:        []string{"frontend"},
+		Security:    &[]openapi.SecurityRequirement{{"cookie": {}}},
+		Responses: map[string]*openapi.Response{
+			"200": {Description: "the app", Content: content("text/html", &openapi.Schema{Type: "string"})},
+			"307": {Description: "a redirect to the login flow"},
+		},
+	})
+	d.Add("GET", "/login", &openapi.Operation{
+		OperationID: "login",
+		Summary:     "Start a login with the OpenID Connect provider, if built-in login is enabled",
+		Tags:        []string{"frontend"},
+		Security:    openapi.Public(),
+		Parameters:  []openapi.Parameter{query("referrer_url", "where to send the user after logging in; only its path is used")},
+		Responses: map[string]*openapi.Response{
+			"302": {Description: "a redirect to the provider"},
+			"404": {Description: "built-in login is not enabled"},
+		},
+	})
+	d.Add("GET", "/oidc/callback", &openapi.Operation{
+		OperationID: "oidcCallback",
+		Summary:     "Finish a login with the OpenID Connect provider and set the session cookie",
+		Tags:        []string{"frontend"},
+		Security:    openapi.Public(),
+		Parameters: []openapi.Parameter{
+			query("code", "the authorization code"),
+			query("state", "the state sent with the login"),
+			query("error", "set instead of code if the provider refused the login"),
+		},
+		Responses: map[string]*openapi.Response{
+			"302": {Description: "a redirect back to the page that required login"},
+			"400": {Description: "the login state is missing or doesn't match"},
+			"401": {Description: "the provider refused the login, or its ID token was invalid"},
+			"403": {Description: "the user isn't registered"},
+			"404": {Description: "built-in login is not enabled"},
+		},
+	})
+	d.Add("POST", "/webhook/v1/plaid", &openapi.Operation{
+		OperationID: "genericPlaidWebhook",
+		Summary:     "Receive a webhook from Plaid",
+		Tags:        []string{"plaid"},
+		Security:    openapi.Public(),
+		RequestBody: jsonBody(s.RequestSchemaOf(WebhookRequest{})),
+		Responses:   responses(http.StatusOK, nil),
+	})
+	d.Add("GET", "/api/v1/openapi.json", &openapi.Operation{
+		OperationID: "getOpenAPI",
+		Summary:     "Get this document",
+		Tags:        []string{"meta"},
+		Security:    openapi.Public(),
+		Responses:   responses(http.StatusOK, &openapi.Schema{Type: "object"}),
+	})
+
+	//items and accounts
+	d.Add("POST", "/api/v1/add_plaid_item", &openapi.Operation{
+		OperationID: "addPlaidItem",
+		Summary:     "Link a Plaid item and its accounts from a Link public token",
+		Tags:        []string{"items"},
+		RequestBody: formBody("application/x-www-form-urlencoded", map[string]*openapi.Schema{
+			"public_token": {Type: "string"},
+		}, "public_token"),
+		Responses: responses(http.StatusOK, s.Object(map[string]interface{}{"access_token": "", "item_id": ""})),
+	})
+	d.Add("GET", "/api/v1/get_accounts", &openapi.Operation{
+		OperationID: "getAccountsLegacy",
+		Summary:     "List accounts and items; use GET /accounts instead",
+		Deprecated:  true,
+		Tags:        []string{"accounts"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"accounts": []db.Account{}, "items": []db.Item{}})),
+	})
+	d.Add("GET", "/api/v1/items", &openapi.Operation{
+		OperationID: "getItems",
+		Summary:     "List linked items",
+		Tags:        []string{"items"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"items": []db.Item{}})),
+	})
+	d.Add("GET", "/api/v1/items/:id", &openapi.Operation{
+		OperationID: "getItem",
+		Summary:     "Get an item and its accounts",
+		Tags:        []string{"items"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"item": db.Item{}, "accounts": []db.Account{}})),
+	})
+	d.Add("DELETE", "/api/v1/items/:id", &openapi.Operation{
+		OperationID: "deleteItem",
+		Summary:     "Unlink an item from Plaid and remove its accounts",
+		Tags:        []string{"items"},
+		Responses:   responses(http.StatusNoContent, nil),
+	})
+	d.Add("POST", "/api/v1/items/:id/refresh", &openapi.Operation{
+		OperationID: "refreshItem",
+		Summary:     "Sync an item now; poll the returned job for the outcome",
+		Tags:        []string{"items"},
+		Responses:   responses(http.StatusAccepted, s.Object(map[string]interface{}{"job": db.JobRun{}})),
+	})
+	d.Add("GET", "/api/v1/accounts", &openapi.Operation{
+		OperationID: "getAccounts",
+		Summary:     "List accounts and items",
+		Tags:        []string{"accounts"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"accounts": []db.Account{}, "items": []db.Item{}})),
+	})
+	d.Add("POST", "/api/v1/accounts", &openapi.Operation{
+		OperationID: "createManualAccount",
+		Summary:     "Add an account that's populated by importing statements",
+		Tags:        []string{"accounts"},
+		RequestBody: jsonBody(s.RequestSchemaOf(ManualAccountRequest{})),
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"account": db.Account{}})),
+	})
+	d.Add("GET", "/api/v1/accounts/:id", &openapi.Operation{
+		OperationID: "getAccount",
+		Summary:     "Get an account",
+		Tags:        []string{"accounts"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"account": db.Account{}})),
+	})
+	d.Add("PATCH", "/api/v1/accounts/:id", &openapi.Operation{
+		OperationID: "updateAccount",
+		Summary:     "Change an account's settings",
+		Tags:        []string{"accounts"},
+		RequestBody: jsonBody(s.RequestSchemaOf(AccountUpdateRequest{})),
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"account": db.Account{}})),
+	})
+	d.Add("DELETE", "/api/v1/accounts/:id", &openapi.Operation{
+		OperationID: "deleteAccount",
+		Summary:     "Remove a manual account",
+		Tags:        []string{"accounts"},
+		Responses:   responses(http.StatusNoContent, nil),
+	})
+	d.Add("POST", "/api/v1/accounts/:id/import", &openapi.Operation{
+		OperationID: "importStatement",
+		Summary:     "Import a statement file into a manual account",
+		Tags:        []string{"accounts"},
+		RequestBody: formBody("multipart/form-data", map[string]*openapi.Schema{
+			"file":    {Type: "string", Format: "binary"},
+			"format":  {Type: "string", Description: "one of csv, ofx, qfx or qif; defaults to the file's extension"},
+			"profile": {Type: "string", Description: "a JSON CSVProfile, required for CSV files"},
+		}, "file"),
+		Responses: responses(http.StatusOK, s.Object(map[string]interface{}{"inserted": 0, "skipped": 0})),
+	})
+	for _, format := range []statement.Format{statement.FormatOFX, statement.FormatQFX, statement.FormatQIF} {
+		d.Add("GET", "/api/v1/accounts/:id/export."+string(format), &openapi.Operation{
+			OperationID: "exportStatement" + strings.ToUpper(string(format)),
+			Summary:     "Export an account's transactions as a " + strings.ToUpper(string(format)) + " statement",
+			Tags:        []string{"accounts"},
+			Parameters:  []openapi.Parameter{startDate, endDate},
+			Responses:   fileResponses(),
+		})
+	}
+	d.Add("POST", "/api/v1/export/:format", &openapi.Operation{
+		OperationID: "exportLedger",
+		Summary:     "Export all transactions as a Beancount or ledger-cli file",
+		Tags:        []string{"accounts"},
+		Parameters: []openapi.Parameter{{
+			Name:     "format",
+			In:       "path",
+			Required: true,
+			Schema:   &openapi.Schema{Type: "string", Enum: []string{string(ledger.FormatBeancount), string(ledger.FormatLedger)}},
+		}},
+		RequestBody: &openapi.RequestBody{Content: content("application/json", s.RequestSchemaOf(ledger.Mapping{}))},
+		Responses:   fileResponses(),
+	})
+
+	//transactions
+	d.Add("GET", "/api/v1/transactions", &openapi.Operation{
+		OperationID: "getTransactions",
+		Summary:     "List transactions in a date range",
+		Tags:        []string{"transactions"},
+		Parameters: []openapi.Parameter{
+			query("start_date", fmt.Sprintf("first day to include, as YYYY-MM-DD; defaults to %d days before end_date", defaultTransactionsDays)),
+			endDate,
+			query("account_id", "only list this account's transactions"),
+		},
+		Responses: responses(http.StatusOK, s.Object(map[string]interface{}{
+			"transactions": []db.Transaction{},
+			"start_date":   "",
+			"end_date":     "",
+		})),
+	})
+	d.Add("GET", "/api/v1/transactions/:id", &openapi.Operation{
+		OperationID: "getTransaction",
+		Summary:     "Get a transaction with its tags, attachments and splits",
+		Tags:        []string{"transactions"},
+		Responses:   responses(http.StatusOK, transaction),
+	})
+	d.Add("PATCH", "/api/v1/transactions/:id", &openapi.Operation{
+		OperationID: "updateTransaction",
+		Summary:     "Set a transaction's note and tags",
+		Tags:        []string{"transactions"},
+		RequestBody: jsonBody(s.RequestSchemaOf(TransactionUpdateRequest{})),
+		Responses:   responses(http.StatusOK, transaction),
+	})
+	d.Add("PUT", "/api/v1/transactions/:id/splits", &openapi.Operation{
+		OperationID: "splitTransaction",
+		Summary:     "Replace a transaction's splits",
+		Tags:        []string{"transactions"},
+		RequestBody: jsonBody(s.RequestSchemaOf(SplitTransactionRequest{})),
+		Responses:   responses(http.StatusOK, transaction),
+	})
+	d.Add("POST", "/api/v1/transactions/:id/attachments", &openapi.Operation{
+		OperationID: "uploadAttachment",
+		Summary:     "Attach a file to a transaction",
+		Tags:        []string{"transactions"},
+		RequestBody: formBody("multipart/form-data", map[string]*openapi.Schema{
+			"file": {Type: "string", Format: "binary"},
+		}, "file"),
+		Responses: responses(http.StatusOK, s.Object(map[string]interface{}{"attachment": db.Attachment{}})),
+	})
+	d.Add("GET", "/api/v1/transactions/:id/attachments/:attachment_id", &openapi.Operation{
+		OperationID: "downloadAttachment",
+		Summary:     "Download an attachment",
+		Tags:        []string{"transactions"},
+		Responses:   fileResponses(),
+	})
+	d.Add("DELETE", "/api/v1/transactions/:id/attachments/:attachment_id", &openapi.Operation{
+		OperationID: "deleteAttachment",
+		Summary:     "Remove an attachment",
+		Tags:        []string{"transactions"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"attachment_uuid": ""})),
+	})
+
+	//transfers
+	d.Add("GET", "/api/v1/transfers", &openapi.Operation{
+		OperationID: "getTransfers",
+		Summary:     "List detected transfers",
+		Tags:        []string{"transfers"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"transfers": []db.Transfer{}})),
+	})
+	d.Add("POST", "/api/v1/transfers/detect", &openapi.Operation{
+		OperationID: "detectTransfers",
+		Summary:     "Pair up recent transactions that look like transfers",
+		Tags:        []string{"transfers"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"transfers": []db.Transfer{}})),
+	})
+	d.Add("PUT", "/api/v1/transfers/:id/confirm", &openapi.Operation{
+		OperationID: "confirmTransfer",
+		Summary:     "Mark a detected transfer as correct",
+		Tags:        []string{"transfers"},
+		Responses:   responses(http.StatusNoContent, nil),
+	})
+	d.Add("DELETE", "/api/v1/transfers/:id", &openapi.Operation{
+		OperationID: "rejectTransfer",
+		Summary:     "Break a detected transfer apart",
+		Tags:        []string{"transfers"},
+		Responses:   responses(http.StatusNoContent, nil),
+	})
+
+	//reports
+	report := s.Object(map[string]interface{}{
+		"group":      db.ReportGroup(""),
+		"start_date": "",
+		"end_date":   "",
+		"rows":       []db.ReportRow{},
+	})
+	reportParameters := func(defaultGroup db.ReportGroup) []openapi.Parameter {
+		return []openapi.Parameter{
+			query("group", "one of month, category, merchant or account; defaults to "+string(defaultGroup)),
+			query("start_date", fmt.Sprintf("first day to include, as YYYY-MM-DD; defaults to %d months before end_date", defaultReportMonths)),
+			endDate,
+			{Name: "If-None-Match", In: "header", Schema: &openapi.Schema{Type: "string"}},
+		}
+	}
+	d.Add("GET", "/api/v1/reports/cashflow", &openapi.Operation{
+		OperationID: "getCashflowReport",
+		Summary:     "Report income and expenses",
+		Tags:        []string{"reports"},
+		Parameters:  reportParameters(db.ReportGroupMonth),
+		Responses:   cacheableResponses(report),
+	})
+	d.Add("GET", "/api/v1/reports/spending", &openapi.Operation{
+		OperationID: "getSpendingReport",
+		Summary:     "Report spending",
+		Tags:        []string{"reports"},
+		Parameters:  reportParameters(db.ReportGroupCategory),
+		Responses:   cacheableResponses(report),
+	})
+	d.Add("GET", "/api/v1/forecast", &openapi.Operation{
+		OperationID: "getForecast",
+		Summary:     "Project daily balances from recurring income and bills",
+		Tags:        []string{"reports"},
+		Parameters: []openapi.Parameter{
+			{Name: "days", In: "query", Description: fmt.Sprintf("how many days to project, at most %d; defaults to %d", maxForecastDays, defaultForecastDays), Schema: &openapi.Schema{Type: "integer"}},
+			{Name: "threshold", In: "query", Description: "the balance to warn below", Schema: &openapi.Schema{Type: "string", Format: openapi.FormatDecimal}},
+		},
+		Responses: responses(http.StatusOK, s.SchemaOf(forecast.Forecast{})),
+	})
+
+	//alerts
+	d.Add("GET", "/api/v1/alerts", &openapi.Operation{
+		OperationID: "getAlerts",
+		Summary:     "List alerts",
+		Tags:        []string{"alerts"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"alerts": []db.Alert{}})),
+	})
+	d.Add("POST", "/api/v1/alerts", &openapi.Operation{
+		OperationID: "createAlert",
+		Summary:     "Create an alert",
+		Tags:        []string{"alerts"},
+		RequestBody: jsonBody(s.RequestSchemaOf(AlertRequest{})),
+		Responses:   responses(http.StatusCreated, s.Object(map[string]interface{}{"alert": db.Alert{}})),
+	})
+	d.Add("DELETE", "/api/v1/alerts/:id", &openapi.Operation{
+		OperationID: "deleteAlert",
+		Summary:     "Remove an alert",
+		Tags:        []string{"alerts"},
+		Responses:   responses(http.StatusNoContent, nil),
+	})
+	d.Add("GET", "/api/v1/alerts/:id/deliveries", &openapi.Operation{
+		OperationID: "getAlertDeliveries",
+		Summary:     "List an alert's deliveries",
+		Tags:        []string{"alerts"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"deliveries": []db.AlertDelivery{}})),
+	})
+
+	//webhooks
+	d.Add("GET", "/api/v1/webhooks", &openapi.Operation{
+		OperationID: "getWebhookEndpoints",
+		Summary:     "List webhook endpoints",
+		Tags:        []string{"webhooks"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"endpoints": []db.WebhookEndpoint{}})),
+	})
+	d.Add("POST", "/api/v1/webhooks", &openapi.Operation{
+		OperationID: "createWebhookEndpoint",
+		Summary:     "Register a webhook endpoint; the signing secret is only returned here",
+		Tags:        []string{"webhooks"},
+		RequestBody: jsonBody(s.RequestSchemaOf(WebhookEndpointRequest{})),
+		Responses:   responses(http.StatusCreated, s.Object(map[string]interface{}{"endpoint": db.WebhookEndpoint{}, "secret": ""})),
+	})
+	d.Add("DELETE", "/api/v1/webhooks/:id", &openapi.Operation{
+		OperationID: "deleteWebhookEndpoint",
+		Summary:     "Remove a webhook endpoint",
+		Tags:        []string{"webhooks"},
+		Responses:   responses(http.StatusNoContent, nil),
+	})
+	d.Add("GET", "/api/v1/webhooks/:id/deliveries", &openapi.Operation{
+		OperationID: "getWebhookDeliveries",
+		Summary:     "List an endpoint's recent deliveries",
+		Tags:        []string{"webhooks"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"deliveries": []db.WebhookDelivery{}})),
+	})
+	d.Add("POST", "/api/v1/webhooks/:id/deliveries/:delivery_id/redeliver", &openapi.Operation{
+		OperationID: "redeliverWebhook",
+		Summary:     "Send a past delivery again",
+		Tags:        []string{"webhooks"},
+		Responses:   responses(http.StatusAccepted, nil),
+	})
+
+	//preferences and jobs
+	d.Add("GET", "/api/v1/preferences/digest", &openapi.Operation{
+		OperationID: "getDigestPreferences",
+		Summary:     "Get email digest preferences",
+		Tags:        []string{"preferences"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"preferences": db.DigestPreferences{}})),
+	})
+	d.Add("PUT", "/api/v1/preferences/digest", &openapi.Operation{
+		OperationID: "updateDigestPreferences",
+		Summary:     "Set email digest preferences",
+		Tags:        []string{"preferences"},
+		RequestBody: jsonBody(s.RequestSchemaOf(DigestPreferencesRequest{})),
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"preferences": db.DigestPreferences{}})),
+	})
+	d.Add("GET", "/api/v1/jobs/:id", &openapi.Operation{
+		OperationID: "getJob",
+		Summary:     "Get the status of an on-demand job",
+		Tags:        []string{"jobs"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"job": db.JobRun{}})),
+	})
+
+	//GraphQL
+	d.Add("POST", "/api/v1/graphql", &openapi.Operation{
+		OperationID: "graphQL",
+		Summary:     "Run a GraphQL query over items, accounts and transactions",
+		Tags:        []string{"graphql"},
+		RequestBody: jsonBody(s.RequestSchemaOf(GraphQLRequest{})),
+		//data is missing or null when the query couldn't run, and errors
+		//is missing when it ran cleanly
+		Responses: responses(http.StatusOK, &openapi.Schema{
+			Type: "object",
+			Properties: map[string]*openapi.Schema{
+				"data":   {Type: "object", Nullable: true},
+				"errors": s.SchemaOf([]graphql.Error{}),
+			},
+		}),
+	})
+	d.Add("GET", "/api/v1/graphql/schema", &openapi.Operation{
+		OperationID: "getGraphQLSchema",
+		Summary:     "Describe the GraphQL schema in the schema definition language",
+		Tags:        []string{"graphql"},
+		Responses: map[string]*openapi.Response{
+			"200":     {Description: "the schema", Content: content("text/plain", &openapi.Schema{Type: "string"})},
+			"default": {Description: "an error", Content: content("application/json", errorSchema)},
+		},
+	})
+
+	//personal access tokens
+	d.Add("GET", "/api/v1/tokens", &openapi.Operation{
+		OperationID: "getPersonalAccessTokens",
+		Summary:     "List personal access tokens; needs a login",
+		Tags:        []string{"tokens"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"tokens": []db.PersonalAccessToken{}})),
+	})
+	d.Add("POST", "/api/v1/tokens", &openapi.Operation{
+		OperationID: "createPersonalAccessToken",
+		Summary:     "Create a personal access token; the token is only returned here",
+		Tags:        []string{"tokens"},
+		RequestBody: jsonBody(s.RequestSchemaOf(PersonalAccessTokenRequest{})),
+		Responses:   responses(http.StatusCreated, s.Object(map[string]interface{}{"token": db.PersonalAccessToken{}, "secret": ""})),
+	})
+	d.Add("DELETE", "/api/v1/tokens/:id", &openapi.Operation{
+		OperationID: "revokePersonalAccessToken",
+		Summary:     "Revoke a personal access token",
+		Tags:        []string{"tokens"},
+		Responses:   responses(http.StatusNoContent, nil),
+	})
+
+	//shares
+	d.Add("GET", "/api/v1/shares", &openapi.Operation{
+		OperationID: "getShares",
+		Summary:     "List the shares made by and offered to the user; needs a login",
+		Tags:        []string{"shares"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"shared_by_me": []db.Share{}, "shared_with_me": []db.Share{}})),
+	})
+	d.Add("POST", "/api/v1/shares", &openapi.Operation{
+		OperationID: "createShare",
+		Summary:     "Invite someone by email to an account or item, read-only or to edit",
+		Tags:        []string{"shares"},
+		RequestBody: jsonBody(s.RequestSchemaOf(ShareRequest{})),
+		Responses:   responses(http.StatusCreated, s.Object(map[string]interface{}{"share": db.Share{}})),
+	})
+	d.Add("POST", "/api/v1/shares/:id/accept", &openapi.Operation{
+		OperationID: "acceptShare",
+		Summary:     "Accept a share offered to the user",
+		Tags:        []string{"shares"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"share": db.Share{}})),
+	})
+	d.Add("DELETE", "/api/v1/shares/:id", &openapi.Operation{
+		OperationID: "revokeShare",
+		Summary:     "Revoke a share, or decline or leave one offered to the user",
+		Tags:        []string{"shares"},
+		Responses:   responses(http.StatusNoContent, nil),
+	})
+
+	//the user's own data, and the audit log
+	auditEventParameters := []openapi.Parameter{
+		query("action", "only list this action, such as `item.linked`"),
+		query("since", "only list actions taken at or after this RFC 3339 time"),
+		query("until", "only list actions taken before this RFC 3339 time"),
+		query("before", "the next_cursor of the previous page"),
+		{Name: "limit", In: "query", Description: fmt.Sprintf("at most %d; defaults to %d", maxAuditEventsLimit, defaultAuditEventsLimit), Schema: &openapi.Schema{Type: "integer"}},
+	}
+	d.Add("POST", "/api/v1/me/export", &openapi.Operation{
+		OperationID: "exportMe",
+		Summary:     "Download a ZIP archive of everything stored about the user; needs a login",
+		Tags:        []string{"me"},
+		Responses:   fileResponses(),
+	})
+	d.Add("DELETE", "/api/v1/me", &openapi.Operation{
+		OperationID: "deleteMe",
+		Summary:     "Unlink the user's items from Plaid and permanently delete the user and their data; needs a login",
+		Tags:        []string{"me"},
+		Responses:   responses(http.StatusNoContent, nil),
+	})
+	d.Add("GET", "/api/v1/me/activity", &openapi.Operation{
+		OperationID: "getMyActivity",
+		Summary:     "List the actions the user has taken, and those taken on their account, newest first; needs a login",
+		Tags:        []string{"me"},
+		Parameters:  auditEventParameters,
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"events": []db.AuditEvent{}, "next_cursor": ""})),
+	})
+
+	//admin
+	d.Add("POST", "/api/v1/admin/register-user", &openapi.Operation{
+		OperationID: "registerUser",
+		Summary:     "Grant a user access to the service",
+		Tags:        []string{"admin"},
+		RequestBody: jsonBody(s.RequestSchemaOf(RegistrationRequest{})),
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"user_uuid": "", "email": "", "role": ""})),
+	})
+	d.Add("PUT", "/api/v1/admin/users/:id/role", &openapi.Operation{
+		OperationID: "setUserRole",
+		Summary:     "Change a user's role",
+		Tags:        []string{"admin"},
+		RequestBody: jsonBody(s.RequestSchemaOf(RoleRequest{})),
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"user_uuid": "", "role": ""})),
+	})
+	d.Add("GET", "/api/v1/admin/users", &openapi.Operation{
+		OperationID: "getUsers",
+		Summary:     "List registered users in order of email, a page at a time",
+		Tags:        []string{"support"},
+		Parameters: []openapi.Parameter{
+			query("query", "only list users whose email contains this, ignoring case"),
+			query("include_deactivated", "`true` to include deactivated users"),
+			query("after", "the next_cursor of the previous page"),
+			{Name: "limit", In: "query", Description: fmt.Sprintf("at most %d; defaults to %d", maxUsersLimit, defaultUsersLimit), Schema: &openapi.Schema{Type: "integer"}},
+		},
+		Responses: responses(http.StatusOK, s.Object(map[string]interface{}{"users": []db.User{}, "next_cursor": ""})),
+	})
+	d.Add("GET", "/api/v1/admin/users/:id", &openapi.Operation{
+		OperationID: "getUser",
+		Summary:     "Get a registered user and the health of their items",
+		Tags:        []string{"support"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"user": db.User{}, "items": []db.Item{}})),
+	})
+	d.Add("PATCH", "/api/v1/admin/users/:id", &openapi.Operation{
+		OperationID: "updateUser",
+		Summary:     "Change a user's email",
+		Tags:        []string{"admin"},
+		RequestBody: jsonBody(s.RequestSchemaOf(UserUpdateRequest{})),
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"user": db.User{}})),
+	})
+	d.Add("DELETE", "/api/v1/admin/users/:id", &openapi.Operation{
+		OperationID: "deleteUser",
+		Summary:     "Permanently delete a user and all of their data, unlinking their items from Plaid",
+		Tags:        []string{"admin"},
+		Responses:   responses(http.StatusNoContent, nil),
+	})
+	d.Add("POST", "/api/v1/admin/users/:id/deactivate", &openapi.Operation{
+		OperationID: "deactivateUser",
+		Summary:     "Lock a user out of the service, keeping their data",
+		Tags:        []string{"admin"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"user": db.User{}})),
+	})
+	d.Add("POST", "/api/v1/admin/users/:id/reactivate", &openapi.Operation{
+		OperationID: "reactivateUser",
+		Summary:     "Let a deactivated user back in",
+		Tags:        []string{"admin"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"user": db.User{}})),
+	})
+	d.Add("GET", "/api/v1/admin/users/:id/items", &openapi.Operation{
+		OperationID: "getUserItems",
+		Summary:     "List any user's items and their status",
+		Tags:        []string{"support"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"items": []db.Item{}})),
+	})
+	d.Add("GET", "/api/v1/admin/users/:id/webhook-deliveries", &openapi.Operation{
+		OperationID: "getUserWebhookDeliveries",
+		Summary:     "List any user's recent webhook deliveries, without their payloads",
+		Tags:        []string{"support"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"deliveries": []db.WebhookDelivery{}})),
+	})
+	d.Add("GET", "/api/v1/admin/jobs", &openapi.Operation{
+		OperationID: "getJobs",
+		Summary:     "List scheduled jobs",
+		Tags:        []string{"admin"},
+		Responses:   responses(http.StatusOK, s.Object(map[string]interface{}{"jobs": []scheduler.JobInfo{}})),
+	})
+	d.Add("GET", "/api/v1/admin/job-runs", &openapi.Operation{
+		OperationID: "getJobRuns",
+		Summary:     "List recent runs of scheduled jobs",
+		Tags:        []string{"admin"},
+		Parameters: []openapi.Parameter{
+			query("job", "only list runs of this job"),
+			{Name: "limit", In: "query", Description: fmt.Sprintf("at most %d; defaults to %d", maxJobRunsLimit, defaultJobRunsLimit), Schema: &openapi.Schema{Type: "integer"}},
+		},
+		Responses: responses(http.StatusOK, s.Object(map[string]interface{}{"runs": []db.JobRun{}})),
+	})
+	d.Add("GET", "/api/v1/admin/audit-events", &openapi.Operation{
+		OperationID: "getAuditEvents",
+		Summary:     "Search the audit log, newest first",
+		Tags:        []string{"admin"},
+		Parameters: append([]openapi.Parameter{
+			query("actor_id", "only list actions taken by this user"),
+			query("subject_type", "only list actions taken on this type of subject, such as `user` or `item`"),
+			query("subject_id", "only list actions taken on this subject"),
+		}, auditEventParameters...),
+		Responses: responses(http.StatusOK, s.Object(map[string]interface{}{"events": []db.AuditEvent{}, "next_cursor": ""})),
+	})
+
+	return d
+}
+
+var errorSchema = &openapi.Schema{Ref: "#/components/schemas/Error"}
+
+func scopeNames() []string {
+	names := make([]string, len(auth.Scopes))
+	for i, scope := range auth.Scopes {
+		names[i] = string(scope)
+	}
+	return names
+}
+
+func staffScopeNames() []string {
+	names := make([]string, len(auth.StaffScopes))
+	for i, scope := range auth.StaffScopes {
+		names[i] = string(scope)
+	}
+	return names
+}
+
+func query(name string, description string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: &openapi.Schema{Type: "string"}}
+}
+
+func content(contentType string, schema *openapi.Schema) map[string]*openapi.MediaType {
+	return map[string]*openapi.MediaType{contentType: {Schema: schema}}
+}
+
+func jsonBody(schema *openapi.Schema) *openapi.RequestBody {
+	return &openapi.RequestBody{Required: true, Content: content("application/json", schema)}
+}
+
+func formBody(contentType string, fields map[string]*openapi.Schema, required ...string) *openapi.RequestBody {
+	return &openapi.RequestBody{
+		Required: true,
+		Content:  content(contentType, &openapi.Schema{Type: "object", Properties: fields, Required: required}),
+	}
+}
+
+//responses describes a success response, with a JSON body unless body
+//is nil, along with the error envelope every handler uses for failures
+func responses(status int, body *openapi.Schema) map[string]*openapi.Response {
+	success := &openapi.Response{Description: http.StatusText(status)}
+	if body != nil {
+		success.Content = content("application/json", body)
+	}
+
+	return map[string]*openapi.Response{
+		strconv.Itoa(status): success,
+		"default":            {Description: "an error", Content: content("application/json", errorSchema)},
+	}
+}
+
+//cacheableResponses describes the responses of respondCacheable
+func cacheableResponses(body *openapi.Schema) map[string]*openapi.Response {
+	r := responses(http.StatusOK, body)
+	r[strconv.Itoa(http.StatusNotModified)] = &openapi.Response{Description: "the copy named by If-None-Match is current"}
+	return r
+}
+
+//fileResponses describes downloads
+func fileResponses() map[string]*openapi.Response {
+	r := responses(http.StatusOK, nil)
+	r[strconv.Itoa(http.StatusOK)].Content = content("application/octet-stream", &openapi.Schema{Type: "string", Format: "binary"})
+	return r
+}
@@ -0,0 +1,77 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server"
+	"github.com/xanderflood/plaid-ui/cmd/api/server/serverfakes"
+	"github.com/xanderflood/plaid-ui/pkg/openapi"
+)
+
+//TestOpenAPIMatchesRoutes loads the OpenAPI document that the API
+//serves, and checks it against the routes AddRoutes registers
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	fake := &serverfakes.FakeServer{}
+	fake.ServeOpenAPIStub = func(c *gin.Context) {
+		c.JSON(http.StatusOK, server.APIDocument())
+	}
+	e := gin.New()
+	server.AddRoutes(e, fake)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest("GET", "/api/v1/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("serving the OpenAPI document: status %d", rec.Code)
+	}
+	var doc openapi.Document
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("the OpenAPI document isn't valid JSON: %s", err)
+	}
+
+	if len(doc.Paths) == 0 {
+		t.Error("the served OpenAPI document has no paths")
+	}
+
+	if err := server.CheckOpenAPI(e.Routes()); err != nil {
+		t.Error(err)
+	}
+}
+
+//TestCheckOpenAPI checks that CheckOpenAPI notices routes that aren't
+//documented and operations that aren't routed
+func TestCheckOpenAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	e := gin.New()
+	server.AddRoutes(e, &serverfakes.FakeServer{})
+	routes := e.Routes()
+
+	var withoutItems gin.RoutesInfo
+	for _, route := range routes {
+		if route.Path != "/api/v1/items" {
+			withoutItems = append(withoutItems, route)
+		}
+	}
+	withExtra := append(routes, gin.RouteInfo{Method: "GET", Path: "/api/v1/undocumented/:id"})
+
+	for _, tc := range []struct {
+		routes gin.RoutesInfo
+		want   string
+	}{
+		{routes: withoutItems, want: "operation GET /api/v1/items has no route"},
+		{routes: withExtra, want: "route GET /api/v1/undocumented/:id isn't documented"},
+	} {
+		err := server.CheckOpenAPI(tc.routes)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("got %v, want an error containing %q", err, tc.want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+//refreshItemJobName names on-demand item refreshes in the job history
+const refreshItemJobName = "refresh_item"
+
+//RefreshItem asks Plaid to check an item the user can edit for new
+//transactions and fetches its real-time balances. The work happens in
+//the background, and the response holds a job ID to poll.
+func (a ServerAgent) RefreshItem(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such item")
+	if !ok {
+		return //an error response has already been generated
+	}
+	item, err := a.dbClient.GetItem(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchItem {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such item"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting item `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	if !item.Access.CanEdit() {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "this item is shared with you read-only"})
+		return
+	}
+
+	claimed, err := a.dbClient.ClaimItemRefresh(c, auth.UserUUID, uuid, a.itemRefreshInterval)
+	if err != nil {
+		a.logger.Errorf("failed claiming refresh of item `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	if !claimed {
+		retryAfter := a.itemRefreshInterval
+		if item.RefreshRequestedAt != nil {
+			retryAfter = time.Until(item.RefreshRequestedAt.Add(a.itemRefreshInterval))
+		}
+		c.Header("Retry-After", strconv.Itoa(int(math.Max(1, math.Ceil(retryAfter.Seconds())))))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "this item was refreshed recently - try again later"})
+		return
+	}
+
+	runUUID, err := a.dbClient.StartJobRun(c, db.JobRun{
+		UserUUID: auth.UserUUID,
+		ItemUUID: uuid,
+		Name:     refreshItemJobName,
+	})
+	if err != nil {
+		a.logger.Errorf("failed starting refresh of item `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	run, err := a.dbClient.GetJobRun(c, auth.UserUUID, runUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting job run `%s`: %s", runUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	go a.refreshItem(context.Background(), item, run.UUID)
+
+	c.Header("Location", "/api/v1/jobs/"+run.UUID)
+	c.JSON(http.StatusAccepted, gin.H{
+		"job": run,
+	})
+}
+
+//refreshItem does the work of a refresh and records the outcome
+func (a ServerAgent) refreshItem(ctx context.Context, item db.Item, runUUID string) {
+	err := func() error {
+		accounts, err := a.dbClient.GetAccountsByPlaidItemID(ctx, item.PlaidItemID)
+		if err != nil {
+			return err
+		}
+		if len(accounts) == 0 {
+			return errors.Errorf("plaid item `%s` has no accounts", item.PlaidItemID)
+		}
+
+		accountMapping := map[string]db.Account{}
+		for _, account := range accounts {
+			accountMapping[account.PlaidAccountID] = account
+		}
+		accessToken := accounts[0].PlaidAccessToken
+
+		if _, err := a.plaidClient.RefreshTransactions(accessToken); err != nil {
+			return err
+		}
+
+		resp, err := a.plaidClient.GetBalances(accessToken)
+		if err != nil {
+			return err
+		}
+		return a.recordBalances(ctx, item.UserUUID, accountMapping, resp.Accounts)
+	}()
+
+	status, errMessage := db.JobRunStatusSucceeded, ""
+	if err != nil {
+		a.logger.Errorf("failed refreshing item `%s`: %s", item.UUID, err.Error())
+		a.recordItemError(ctx, item.PlaidItemID, err)
+		status, errMessage = db.JobRunStatusFailed, err.Error()
+	}
+
+	if err := a.dbClient.FinishJobRun(ctx, runUUID, status, errMessage); err != nil {
+		a.logger.Errorf("failed recording outcome of job run `%s`: %s", runUUID, err.Error())
+	}
+}
+
+//GetJob reports the status of a job started on the user's behalf
+func (a ServerAgent) GetJob(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such job")
+	if !ok {
+		return //an error response has already been generated
+	}
+	run, err := a.dbClient.GetJobRun(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchJobRun {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such job"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting job run `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job": run,
+	})
+}
@@ -4,12 +4,18 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
+	"github.com/xanderflood/plaid-ui/pkg/db"
 )
 
 //RegistrationRequest encodes a single request for user registration
 type RegistrationRequest struct {
 	UserUUID string `json:"user_uuid" binding:"required"`
 	Email    string `json:"email" binding:"required"`
+
+	//Role defaults to member
+	Role string `json:"role"`
 }
 
 //RegisterUser adds all the accounts associated with this plaid item
@@ -19,11 +25,6 @@ func (a ServerAgent) RegisterUser(c *gin.Context) {
 		return
 	}
 
-	if !authorization.Admin {
-		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "this endpoint is only accessible to users with administrative priveleges"})
-		return
-	}
-
 	var req RegistrationRequest
 	err := c.ShouldBindJSON(&req)
 	if err != nil {
@@ -32,15 +33,29 @@ func (a ServerAgent) RegisterUser(c *gin.Context) {
 		return
 	}
 
-	err = a.dbClient.RegisterUser(c, req.UserUUID, req.Email)
+	if req.Role == "" {
+		req.Role = auth.RoleMember
+	}
+
+	err = a.dbClient.RegisterUser(c, req.UserUUID, req.Email, req.Role)
+	if err == db.ErrNoSuchRole {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "no such role `" + req.Role + "`"})
+		return
+	}
+	if err == db.ErrUserExists {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		a.logger.Errorf("register user `%s` failed: %s", req.UserUUID, err.Error())
 		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "user registration failed - see logs for details"})
 		return
 	}
+	a.audit(c, authorization, db.AuditActionUserRegistered, "user", req.UserUUID, nil, gin.H{"email": req.Email, "role": req.Role})
 
 	c.JSON(http.StatusOK, gin.H{
 		"user_uuid": req.UserUUID,
 		"email":     req.Email,
+		"role":      req.Role,
 	})
 }
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//defaultReportMonths is how far back a report goes when the request
+//doesn't specify a start date
+const defaultReportMonths = 12
+
+//reportMaxAge is how long clients may reuse a report without checking
+//back, since new transactions only arrive every few hours
+const reportMaxAge = 5 * time.Minute
+
+type reportFunc func(ctx context.Context, userUUID string, group db.ReportGroup, startDate string, endDate string) ([]db.ReportRow, error)
+
+//GetCashflowReport reports the user's income and expenses. The optional
+//`group` query parameter is one of month (the default), category,
+//merchant or account, and `start_date` and `end_date` bound the report.
+func (a ServerAgent) GetCashflowReport(c *gin.Context) {
+	a.report(c, "cashflow", db.ReportGroupMonth, a.dbClient.GetCashflowReport)
+}
+
+//GetSpendingReport reports where the user's money went. It accepts the
+//same query parameters as GetCashflowReport, but groups by category
+//by default.
+func (a ServerAgent) GetSpendingReport(c *gin.Context) {
+	a.report(c, "spending", db.ReportGroupCategory, a.dbClient.GetSpendingReport)
+}
+
+func (a ServerAgent) report(c *gin.Context, name string, defaultGroup db.ReportGroup, get reportFunc) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	group := db.ReportGroup(c.DefaultQuery("group", string(defaultGroup)))
+
+	endDate := c.DefaultQuery("end_date", time.Now().Format(plaidapi.DateFormat))
+	end, err := time.Parse(plaidapi.DateFormat, endDate)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("end_date must be formatted as %s", plaidapi.DateFormat)})
+		return
+	}
+	startDate := c.DefaultQuery("start_date", end.AddDate(0, -defaultReportMonths, 1).Format(plaidapi.DateFormat))
+	if _, err := time.Parse(plaidapi.DateFormat, startDate); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("start_date must be formatted as %s", plaidapi.DateFormat)})
+		return
+	}
+
+	rows, err := get(c, auth.UserUUID, group, startDate, endDate)
+	if err == db.ErrUnknownReportGroup {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown report group `%s`", group)})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed generating %s report for user `%s`: %s", name, auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "report failed - see logs for details"})
+		return
+	}
+
+	a.respondCacheable(c, gin.H{
+		"group":      group,
+		"start_date": startDate,
+		"end_date":   endDate,
+		"rows":       rows,
+	})
+}
+
+//respondCacheable renders a JSON body with an ETag, so that clients can
+//revalidate a cached copy cheaply, and responds 304 when theirs is current
+func (a ServerAgent) respondCacheable(c *gin.Context, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		a.logger.Errorf("failed encoding response: %s", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", int(reportMaxAge.Seconds())))
+	if c.GetHeader("If-None-Match") == etag {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", data)
+}
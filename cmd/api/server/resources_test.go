@@ -0,0 +1,425 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/plaid/plaid-go/plaid"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
+	"github.com/xanderflood/plaid-ui/lib/tools/toolsfakes"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/db/dbfakes"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi/plaidapifakes"
+)
+
+const (
+	testUserUUID        = "11111111-1111-1111-1111-111111111111"
+	testItemUUID        = "2a3b4c5d-6e7f-4a1b-8c2d-3e4f5a6b7c8d"
+	testAccountUUID     = "3b4c5d6e-7f8a-4b2c-9d3e-4f5a6b7c8d9e"
+	testTransactionUUID = "4c5d6e7f-8a9b-4c3d-8e4f-5a6b7c8d9e0f"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+//testServer is a ServerAgent backed by fakes, logged in as testUserUUID
+type testServer struct {
+	ServerAgent
+
+	db     *dbfakes.FakeDB
+	plaid  *plaidapifakes.FakeClient
+	logger *toolsfakes.FakeLogger
+}
+
+func newTestServer() testServer {
+	s := testServer{
+		db:     &dbfakes.FakeDB{},
+		plaid:  &plaidapifakes.FakeClient{},
+		logger: &toolsfakes.FakeLogger{},
+	}
+	s.ServerAgent = ServerAgent{
+		logger:      s.logger,
+		dbClient:    s.db,
+		plaidClient: s.plaid,
+		authorize: func(c *gin.Context) (auth.Authorization, bool) {
+			return auth.Authorization{UserUUID: testUserUUID, User: true}, true
+		},
+	}
+	return s
+}
+
+//testAPIDocument is what handler responses are checked against
+var testAPIDocument = APIDocument()
+
+//serve sends a request to a single handler mounted at pattern, which
+//must be the route AddRoutes registers it under, and decodes its JSON
+//response
+func serve(t *testing.T, handler gin.HandlerFunc, method string, pattern string, path string, body string) (int, map[string]interface{}) {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return serveRequest(t, handler, pattern, req)
+}
+
+//serveRequest is serve for requests that aren't JSON. The response must
+//match the one the OpenAPI document describes.
+func serveRequest(t *testing.T, handler gin.HandlerFunc, pattern string, req *http.Request) (int, map[string]interface{}) {
+	t.Helper()
+
+	e := gin.New()
+	e.Handle(req.Method, pattern, handler)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if err := testAPIDocument.ValidateResponse(req.Method, pattern, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes()); err != nil {
+		t.Error(err)
+	}
+
+	var resp map[string]interface{}
+	if rec.Body.Len() > 0 {
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("response isn't JSON: %s\n%s", err, rec.Body.String())
+		}
+	}
+	return rec.Code, resp
+}
+
+func assertStatus(t *testing.T, got int, want int, resp map[string]interface{}) {
+	t.Helper()
+	if got != want {
+		t.Fatalf("status = %d, want %d (response: %v)", got, want, resp)
+	}
+}
+
+func assertError(t *testing.T, resp map[string]interface{}, want string) {
+	t.Helper()
+	if resp["error"] != want {
+		t.Errorf("error = %q, want %q", resp["error"], want)
+	}
+}
+
+const internalError = "an internal error occurred - see logs for details"
+
+func TestUnauthorized(t *testing.T) {
+	s := newTestServer()
+	s.authorize = func(c *gin.Context) (auth.Authorization, bool) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid authorization object was found"})
+		return auth.Authorization{}, false
+	}
+
+	code, resp := serve(t, s.GetItems, "GET", "/api/v1/items", "/api/v1/items", "")
+	assertStatus(t, code, http.StatusUnauthorized, resp)
+	if s.db.GetItemsCallCount() != 0 {
+		t.Error("items were looked up without an authorization")
+	}
+}
+
+func TestGetItems(t *testing.T) {
+	s := newTestServer()
+	s.db.GetItemsReturns([]db.Item{{Model: db.Model{UUID: testItemUUID}, Access: db.ShareLevelOwner}}, nil)
+
+	code, resp := serve(t, s.GetItems, "GET", "/api/v1/items", "/api/v1/items", "")
+	assertStatus(t, code, http.StatusOK, resp)
+	if items := resp["items"].([]interface{}); len(items) != 1 {
+		t.Errorf("got %d items, want 1", len(items))
+	}
+	if _, userUUID := s.db.GetItemsArgsForCall(0); userUUID != testUserUUID {
+		t.Errorf("items were listed for `%s`", userUUID)
+	}
+}
+
+func TestGetItemsInternalError(t *testing.T) {
+	s := newTestServer()
+	s.db.GetItemsReturns(nil, errors.New("connection refused"))
+
+	code, resp := serve(t, s.GetItems, "GET", "/api/v1/items", "/api/v1/items", "")
+	assertStatus(t, code, http.StatusInternalServerError, resp)
+	assertError(t, resp, internalError)
+	if s.logger.ErrorfCallCount() != 1 {
+		t.Error("the error wasn't logged")
+	}
+}
+
+func TestGetItemNotFound(t *testing.T) {
+	s := newTestServer()
+	s.db.GetItemReturns(db.Item{}, db.ErrNoSuchItem)
+
+	code, resp := serve(t, s.GetItem, "GET", "/api/v1/items/:id", "/api/v1/items/"+testItemUUID, "")
+	assertStatus(t, code, http.StatusNotFound, resp)
+	assertError(t, resp, "no such item")
+	if _, _, uuid := s.db.GetItemArgsForCall(0); uuid != testItemUUID {
+		t.Errorf("looked up item `%s`", uuid)
+	}
+}
+
+func TestGetItemSharedAccounts(t *testing.T) {
+	s := newTestServer()
+	s.db.GetItemReturns(db.Item{Model: db.Model{UUID: testItemUUID}, PlaidItemID: "plaid-item-1", Access: db.ShareLevelRead}, nil)
+	s.db.GetAccountsReturns([]db.Account{
+		{Model: db.Model{UUID: testAccountUUID}, PlaidItemID: "plaid-item-1", PlaidAccessToken: "access-sandbox-secret"},
+		{Model: db.Model{UUID: "account-2"}, PlaidItemID: "plaid-item-2", PlaidAccessToken: "access-sandbox-other"},
+	}, nil)
+
+	code, resp := serve(t, s.GetItem, "GET", "/api/v1/items/:id", "/api/v1/items/"+testItemUUID, "")
+	assertStatus(t, code, http.StatusOK, resp)
+
+	accounts := resp["accounts"].([]interface{})
+	if len(accounts) != 1 {
+		t.Fatalf("got %d accounts, want only the item's", len(accounts))
+	}
+	account := accounts[0].(map[string]interface{})
+	if account["uuid"] != testAccountUUID {
+		t.Errorf("got account %v", account["uuid"])
+	}
+	for name, value := range account {
+		if s, ok := value.(string); ok && strings.HasPrefix(s, "access-sandbox") {
+			t.Errorf("access token was sent to the client as `%s`", name)
+		}
+	}
+}
+
+func TestDeleteItem(t *testing.T) {
+	s := newTestServer()
+	s.db.GetItemReturns(db.Item{Model: db.Model{UUID: testItemUUID}, PlaidItemID: "plaid-item-1", Access: db.ShareLevelOwner}, nil)
+	s.db.GetAccountsByPlaidItemIDReturns([]db.Account{{PlaidAccessToken: "access-sandbox-secret"}}, nil)
+
+	code, resp := serve(t, s.DeleteItem, "DELETE", "/api/v1/items/:id", "/api/v1/items/"+testItemUUID, "")
+	assertStatus(t, code, http.StatusNoContent, resp)
+	if s.plaid.RemoveItemCallCount() != 1 || s.plaid.RemoveItemArgsForCall(0) != "access-sandbox-secret" {
+		t.Error("the item wasn't removed from Plaid")
+	}
+	if s.db.DeleteItemCallCount() != 1 {
+		t.Error("the item wasn't deleted")
+	}
+	if _, event := s.db.RecordAuditEventArgsForCall(0); event.Action != db.AuditActionItemRemoved || event.SubjectUUID != testItemUUID {
+		t.Errorf("recorded %v", event)
+	}
+}
+
+func TestDeleteItemForbidden(t *testing.T) {
+	for _, level := range []db.ShareLevel{db.ShareLevelRead, db.ShareLevelEdit} {
+		s := newTestServer()
+		s.db.GetItemReturns(db.Item{PlaidItemID: "plaid-item-1", Access: level}, nil)
+
+		code, resp := serve(t, s.DeleteItem, "DELETE", "/api/v1/items/:id", "/api/v1/items/"+testItemUUID, "")
+		assertStatus(t, code, http.StatusForbidden, resp)
+		if s.plaid.RemoveItemCallCount() != 0 {
+			t.Errorf("a user with %s access unlinked the item from Plaid", level)
+		}
+		if s.db.DeleteItemCallCount() != 0 {
+			t.Errorf("a user with %s access deleted the item", level)
+		}
+	}
+}
+
+func TestDeleteItemAlreadyRemovedFromPlaid(t *testing.T) {
+	s := newTestServer()
+	s.db.GetItemReturns(db.Item{PlaidItemID: "plaid-item-1", Access: db.ShareLevelOwner}, nil)
+	s.db.GetAccountsByPlaidItemIDReturns([]db.Account{{PlaidAccessToken: "access-sandbox-secret"}}, nil)
+	s.plaid.RemoveItemReturns(plaid.RemoveItemResponse{}, plaid.Error{ErrorCode: "ITEM_NOT_FOUND"})
+
+	code, resp := serve(t, s.DeleteItem, "DELETE", "/api/v1/items/:id", "/api/v1/items/"+testItemUUID, "")
+	assertStatus(t, code, http.StatusNoContent, resp)
+	if s.db.DeleteItemCallCount() != 1 {
+		t.Error("the item wasn't deleted")
+	}
+}
+
+func TestDeleteItemPlaidError(t *testing.T) {
+	s := newTestServer()
+	s.db.GetItemReturns(db.Item{PlaidItemID: "plaid-item-1", Access: db.ShareLevelOwner}, nil)
+	s.db.GetAccountsByPlaidItemIDReturns([]db.Account{{PlaidAccessToken: "access-sandbox-secret"}}, nil)
+	s.plaid.RemoveItemReturns(plaid.RemoveItemResponse{}, errors.New("timeout"))
+
+	code, resp := serve(t, s.DeleteItem, "DELETE", "/api/v1/items/:id", "/api/v1/items/"+testItemUUID, "")
+	assertStatus(t, code, http.StatusInternalServerError, resp)
+	assertError(t, resp, "delete failed - see logs for details")
+	if s.db.DeleteItemCallCount() != 0 {
+		t.Error("the item was deleted while still linked at Plaid")
+	}
+}
+
+func TestGetAccounts(t *testing.T) {
+	s := newTestServer()
+
+	code, resp := serve(t, s.GetAccounts, "GET", "/api/v1/accounts", "/api/v1/accounts", "")
+	assertStatus(t, code, http.StatusOK, resp)
+	if accounts, ok := resp["accounts"].([]interface{}); !ok || len(accounts) != 0 {
+		t.Errorf("accounts = %v, want an empty list", resp["accounts"])
+	}
+}
+
+func TestGetAccountNotFound(t *testing.T) {
+	s := newTestServer()
+	s.db.GetAccountReturns(db.Account{}, db.ErrNoSuchAccount)
+
+	code, resp := serve(t, s.GetAccount, "GET", "/api/v1/accounts/:id", "/api/v1/accounts/"+testAccountUUID, "")
+	assertStatus(t, code, http.StatusNotFound, resp)
+	assertError(t, resp, "no such account")
+}
+
+func TestDeleteAccount(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		account db.Account
+		status  int
+	}{
+		{"manual", db.Account{Manual: true, Access: db.ShareLevelOwner}, http.StatusNoContent},
+		{"linked", db.Account{Access: db.ShareLevelOwner}, http.StatusConflict},
+		{"shared", db.Account{Manual: true, Access: db.ShareLevelEdit}, http.StatusForbidden},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer()
+			s.db.GetAccountReturns(test.account, nil)
+
+			code, resp := serve(t, s.DeleteAccount, "DELETE", "/api/v1/accounts/:id", "/api/v1/accounts/"+testAccountUUID, "")
+			assertStatus(t, code, test.status, resp)
+			if deleted := s.db.DeleteAccountCallCount() == 1; deleted != (test.status == http.StatusNoContent) {
+				t.Errorf("deleted = %t", deleted)
+			}
+		})
+	}
+}
+
+func TestUpdateAccount(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		access db.ShareLevel
+		body   string
+		status int
+	}{
+		{"owner hides", db.ShareLevelOwner, `{"hidden":true}`, http.StatusOK},
+		{"editor hides", db.ShareLevelEdit, `{"hidden":true}`, http.StatusOK},
+		{"reader hides", db.ShareLevelRead, `{"hidden":true}`, http.StatusOK},
+		{"owner configures", db.ShareLevelOwner, `{"webhook_configured":true}`, http.StatusOK},
+		{"editor configures", db.ShareLevelEdit, `{"webhook_configured":true}`, http.StatusForbidden},
+		{"malformed", db.ShareLevelOwner, `{"hidden":"yes"}`, http.StatusBadRequest},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			s := newTestServer()
+			s.db.GetAccountReturns(db.Account{Access: test.access}, nil)
+
+			code, resp := serve(t, s.UpdateAccount, "PATCH", "/api/v1/accounts/:id", "/api/v1/accounts/"+testAccountUUID, test.body)
+			assertStatus(t, code, test.status, resp)
+
+			updated := s.db.SetAccountHiddenCallCount() + s.db.ConfigureAccountCallCount()
+			if (updated == 1) != (test.status == http.StatusOK) {
+				t.Errorf("made %d updates", updated)
+			}
+		})
+	}
+}
+
+func TestGetTransactions(t *testing.T) {
+	s := newTestServer()
+
+	code, resp := serve(t, s.GetTransactions, "GET", "/api/v1/transactions", "/api/v1/transactions?end_date=2020-03-31", "")
+	assertStatus(t, code, http.StatusOK, resp)
+	if resp["start_date"] != "2020-03-01" {
+		t.Errorf("start_date = %v, want 30 days before end_date", resp["start_date"])
+	}
+	if _, userUUID, start, end := s.db.GetUserTransactionsByDateRangeArgsForCall(0); userUUID != testUserUUID || start != "2020-03-01" || end != "2020-03-31" {
+		t.Errorf("listed transactions for `%s` from %s to %s", userUUID, start, end)
+	}
+}
+
+func TestGetTransactionsForAccount(t *testing.T) {
+	s := newTestServer()
+	s.db.GetAccountReturns(db.Account{}, db.ErrNoSuchAccount)
+
+	code, resp := serve(t, s.GetTransactions, "GET", "/api/v1/transactions", "/api/v1/transactions?account_id=account-1", "")
+	assertStatus(t, code, http.StatusNotFound, resp)
+	if s.db.GetTransactionsByDateRangeCallCount() != 0 {
+		t.Error("listed transactions for an account the user can't see")
+	}
+}
+
+func TestGetTransactionsBadDate(t *testing.T) {
+	s := newTestServer()
+
+	code, resp := serve(t, s.GetTransactions, "GET", "/api/v1/transactions", "/api/v1/transactions?start_date=03/01/2020", "")
+	assertStatus(t, code, http.StatusBadRequest, resp)
+}
+
+func TestGetTransactionNotFound(t *testing.T) {
+	s := newTestServer()
+	s.db.GetTransactionReturns(db.Transaction{}, db.ErrNoSuchTransaction)
+
+	code, resp := serve(t, s.GetTransaction, "GET", "/api/v1/transactions/:id", "/api/v1/transactions/"+testTransactionUUID, "")
+	assertStatus(t, code, http.StatusNotFound, resp)
+	assertError(t, resp, "no such transaction")
+}
+
+//TestMalformedIDs checks that IDs in paths that can't be UUIDs are
+//reported as missing without reaching the database, which would reject
+//them as input
+func TestMalformedIDs(t *testing.T) {
+	const otherUUID = "5d6e7f8a-9b0c-4d4e-9f5a-6b7c8d9e0f1a"
+
+	for _, test := range []struct {
+		handler func(ServerAgent, *gin.Context)
+		method  string
+		pattern string
+		path    string
+		body    string
+		error   string
+	}{
+		{ServerAgent.GetItem, "GET", "/api/v1/items/:id", "/api/v1/items/item-1", "", "no such item"},
+		{ServerAgent.DeleteItem, "DELETE", "/api/v1/items/:id", "/api/v1/items/item-1", "", "no such item"},
+		{ServerAgent.RefreshItem, "POST", "/api/v1/items/:id/refresh", "/api/v1/items/item-1/refresh", "", "no such item"},
+		{ServerAgent.GetJob, "GET", "/api/v1/jobs/:id", "/api/v1/jobs/job-1", "", "no such job"},
+		{ServerAgent.GetAccount, "GET", "/api/v1/accounts/:id", "/api/v1/accounts/1", "", "no such account"},
+		{ServerAgent.UpdateAccount, "PATCH", "/api/v1/accounts/:id", "/api/v1/accounts/1", `{"hidden": true}`, "no such account"},
+		{ServerAgent.DeleteAccount, "DELETE", "/api/v1/accounts/:id", "/api/v1/accounts/1", "", "no such account"},
+		{ServerAgent.ExportStatement, "GET", "/api/v1/accounts/:id/export.ofx", "/api/v1/accounts/1/export.ofx", "", "no such account"},
+		{ServerAgent.ImportStatement, "POST", "/api/v1/accounts/:id/import", "/api/v1/accounts/1/import", "", "no such account"},
+		{ServerAgent.GetTransaction, "GET", "/api/v1/transactions/:id", "/api/v1/transactions/x'", "", "no such transaction"},
+		{ServerAgent.UpdateTransaction, "PATCH", "/api/v1/transactions/:id", "/api/v1/transactions/x'", `{"note": "hi"}`, "no such transaction"},
+		{ServerAgent.SplitTransaction, "PUT", "/api/v1/transactions/:id/splits", "/api/v1/transactions/x'/splits", `{"splits": []}`, "no such transaction"},
+		{ServerAgent.UploadAttachment, "POST", "/api/v1/transactions/:id/attachments", "/api/v1/transactions/x'/attachments", "", "no such transaction"},
+		{ServerAgent.DownloadAttachment, "GET", "/api/v1/transactions/:id/attachments/:attachment_id", "/api/v1/transactions/x'/attachments/" + otherUUID, "", "no such transaction"},
+		{ServerAgent.DownloadAttachment, "GET", "/api/v1/transactions/:id/attachments/:attachment_id", "/api/v1/transactions/" + testTransactionUUID + "/attachments/1", "", "no such attachment"},
+		{ServerAgent.DeleteAttachment, "DELETE", "/api/v1/transactions/:id/attachments/:attachment_id", "/api/v1/transactions/x'/attachments/" + otherUUID, "", "no such transaction"},
+		{ServerAgent.DeleteAttachment, "DELETE", "/api/v1/transactions/:id/attachments/:attachment_id", "/api/v1/transactions/" + testTransactionUUID + "/attachments/1", "", "no such attachment"},
+		{ServerAgent.ConfirmTransfer, "PUT", "/api/v1/transfers/:id/confirm", "/api/v1/transfers/1/confirm", "", "no such transfer"},
+		{ServerAgent.RejectTransfer, "DELETE", "/api/v1/transfers/:id", "/api/v1/transfers/1", "", "no such transfer"},
+		{ServerAgent.DeleteAlert, "DELETE", "/api/v1/alerts/:id", "/api/v1/alerts/1", "", "no such alert"},
+		{ServerAgent.GetAlertDeliveries, "GET", "/api/v1/alerts/:id/deliveries", "/api/v1/alerts/1/deliveries", "", "no such alert"},
+		{ServerAgent.DeleteWebhookEndpoint, "DELETE", "/api/v1/webhooks/:id", "/api/v1/webhooks/1", "", "no such webhook endpoint"},
+		{ServerAgent.GetWebhookDeliveries, "GET", "/api/v1/webhooks/:id/deliveries", "/api/v1/webhooks/1/deliveries", "", "no such webhook endpoint"},
+		{ServerAgent.RedeliverWebhook, "POST", "/api/v1/webhooks/:id/deliveries/:delivery_id/redeliver", "/api/v1/webhooks/1/deliveries/" + otherUUID + "/redeliver", "", "no such webhook endpoint"},
+		{ServerAgent.RedeliverWebhook, "POST", "/api/v1/webhooks/:id/deliveries/:delivery_id/redeliver", "/api/v1/webhooks/" + otherUUID + "/deliveries/1/redeliver", "", "no such webhook delivery"},
+		{ServerAgent.RevokePersonalAccessToken, "DELETE", "/api/v1/tokens/:id", "/api/v1/tokens/1", "", "no such token"},
+		{ServerAgent.AcceptShare, "POST", "/api/v1/shares/:id/accept", "/api/v1/shares/1/accept", "", "no such share"},
+		{ServerAgent.RevokeShare, "DELETE", "/api/v1/shares/:id", "/api/v1/shares/1", "", "no such share"},
+		{ServerAgent.GetUser, "GET", "/api/v1/admin/users/:id", "/api/v1/admin/users/1", "", "no such user"},
+		{ServerAgent.UpdateUser, "PATCH", "/api/v1/admin/users/:id", "/api/v1/admin/users/1", `{"email": "a@example.com"}`, "no such user"},
+		{ServerAgent.DeleteUser, "DELETE", "/api/v1/admin/users/:id", "/api/v1/admin/users/1", "", "no such user"},
+		{ServerAgent.DeactivateUser, "POST", "/api/v1/admin/users/:id/deactivate", "/api/v1/admin/users/1/deactivate", "", "no such user"},
+		{ServerAgent.ReactivateUser, "POST", "/api/v1/admin/users/:id/reactivate", "/api/v1/admin/users/1/reactivate", "", "no such user"},
+		{ServerAgent.SetUserRole, "PUT", "/api/v1/admin/users/:id/role", "/api/v1/admin/users/1/role", `{"role": "member"}`, "no such user"},
+		{ServerAgent.GetUserItems, "GET", "/api/v1/admin/users/:id/items", "/api/v1/admin/users/1/items", "", "no such user"},
+		{ServerAgent.GetUserWebhookDeliveries, "GET", "/api/v1/admin/users/:id/webhook-deliveries", "/api/v1/admin/users/1/webhook-deliveries", "", "no such user"},
+	} {
+		t.Run(test.method+" "+test.path, func(t *testing.T) {
+			s := newTestServer()
+
+			code, resp := serve(t, func(c *gin.Context) { test.handler(s.ServerAgent, c) }, test.method, test.pattern, test.path, test.body)
+			assertStatus(t, code, http.StatusNotFound, resp)
+			assertError(t, resp, test.error)
+			if calls := s.db.Invocations(); len(calls) > 0 {
+				t.Errorf("the database was called: %v", calls)
+			}
+		})
+	}
+}
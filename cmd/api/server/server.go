@@ -1,15 +1,28 @@
 package server
 
 import (
+	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
 	"github.com/xanderflood/plaid-ui/cmd/api/server/views"
 	"github.com/xanderflood/plaid-ui/lib/tools"
+	"github.com/xanderflood/plaid-ui/pkg/alerts"
+	"github.com/xanderflood/plaid-ui/pkg/blob"
 	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/forecast"
+	"github.com/xanderflood/plaid-ui/pkg/graphql"
+	"github.com/xanderflood/plaid-ui/pkg/notify"
+	"github.com/xanderflood/plaid-ui/pkg/openapi"
 	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+	"github.com/xanderflood/plaid-ui/pkg/recurring"
+	"github.com/xanderflood/plaid-ui/pkg/scheduler"
+	"github.com/xanderflood/plaid-ui/pkg/statement"
+	"github.com/xanderflood/plaid-ui/pkg/transfers"
+	"github.com/xanderflood/plaid-ui/pkg/webhooks"
 )
 
 //Server is the gin server interface for the public API
@@ -17,13 +30,77 @@ import (
 type Server interface {
 	// frontend
 	ServeSPA(c *gin.Context)
+	ServeOpenAPI(c *gin.Context)
+	Login(c *gin.Context)
+	OIDCCallback(c *gin.Context)
 
 	// user api
 	AddPlaidItem(c *gin.Context)
 	GetAccounts(c *gin.Context)
+	ExportLedger(c *gin.Context)
+	ExportStatement(c *gin.Context)
+	CreateManualAccount(c *gin.Context)
+	UpdateAccount(c *gin.Context)
+	ImportStatement(c *gin.Context)
+	UpdateTransaction(c *gin.Context)
+	SplitTransaction(c *gin.Context)
+	UploadAttachment(c *gin.Context)
+	DownloadAttachment(c *gin.Context)
+	DeleteAttachment(c *gin.Context)
+	GetTransfers(c *gin.Context)
+	DetectTransfers(c *gin.Context)
+	ConfirmTransfer(c *gin.Context)
+	RejectTransfer(c *gin.Context)
+	GetCashflowReport(c *gin.Context)
+	GetSpendingReport(c *gin.Context)
+	GetForecast(c *gin.Context)
+	CreateAlert(c *gin.Context)
+	GetAlerts(c *gin.Context)
+	DeleteAlert(c *gin.Context)
+	GetAlertDeliveries(c *gin.Context)
+	CreateWebhookEndpoint(c *gin.Context)
+	GetWebhookEndpoints(c *gin.Context)
+	DeleteWebhookEndpoint(c *gin.Context)
+	GetWebhookDeliveries(c *gin.Context)
+	RedeliverWebhook(c *gin.Context)
+	GetDigestPreferences(c *gin.Context)
+	UpdateDigestPreferences(c *gin.Context)
+	GetItems(c *gin.Context)
+	GetItem(c *gin.Context)
+	DeleteItem(c *gin.Context)
+	RefreshItem(c *gin.Context)
+	GetAccount(c *gin.Context)
+	DeleteAccount(c *gin.Context)
+	GetTransactions(c *gin.Context)
+	GetTransaction(c *gin.Context)
+	GetJob(c *gin.Context)
+	GraphQL(c *gin.Context)
+	GetGraphQLSchema(c *gin.Context)
+	CreatePersonalAccessToken(c *gin.Context)
+	GetPersonalAccessTokens(c *gin.Context)
+	RevokePersonalAccessToken(c *gin.Context)
+	CreateShare(c *gin.Context)
+	GetShares(c *gin.Context)
+	AcceptShare(c *gin.Context)
+	RevokeShare(c *gin.Context)
+	ExportMe(c *gin.Context)
+	DeleteMe(c *gin.Context)
+	GetMyActivity(c *gin.Context)
 
 	// admin api
 	RegisterUser(c *gin.Context)
+	SetUserRole(c *gin.Context)
+	GetUsers(c *gin.Context)
+	GetUser(c *gin.Context)
+	UpdateUser(c *gin.Context)
+	DeactivateUser(c *gin.Context)
+	ReactivateUser(c *gin.Context)
+	DeleteUser(c *gin.Context)
+	GetUserItems(c *gin.Context)
+	GetUserWebhookDeliveries(c *gin.Context)
+	GetJobs(c *gin.Context)
+	GetJobRuns(c *gin.Context)
+	GetAuditEvents(c *gin.Context)
 
 	// plaid webhooks
 	GenericPlaidWebhook(c *gin.Context)
@@ -42,9 +119,29 @@ type ServerAgent struct {
 
 	authorize   auth.Getter
 	renderer    views.Renderer
+	oidc        *auth.OIDCRelyingParty
 	plaidClient plaidapi.Client
 	dbClient    db.DB
 
+	statementExporter statement.Exporter
+	blobStore         blob.Store
+	transferMatcher   transfers.Matcher
+	recurringDetector recurring.Detector
+	forecaster        forecast.Forecaster
+	alertEvaluator    alerts.Evaluator
+	notifiers         map[db.AlertChannel]notify.Notifier
+	webhookDispatcher webhooks.Dispatcher
+	scheduler         scheduler.Scheduler
+
+	//itemRefreshInterval is how often a user may refresh each item
+	itemRefreshInterval time.Duration
+
+	//backfillYears is how much history to import for a new item
+	backfillYears int
+
+	openAPIDocument *openapi.Document
+	graphQLSchema   *graphql.Schema
+
 	backendJWTMiddleware  gin.HandlerFunc
 	frontendJWTMiddleware gin.HandlerFunc
 }
@@ -52,21 +149,134 @@ type ServerAgent struct {
 //AddRoutes accepts a *gin.Engine and adds all the
 //necessary routes to it for this API.
 func AddRoutes(e *gin.Engine, a Server) {
+	e.Use(RequestID)
+
 	frontend := e.Group("/", a.FrontendAuthorizationMiddleware)
 	frontend.GET("/", a.ServeSPA)
 
+	//built-in login, when it's enabled
+	e.GET("/login", a.Login)
+	e.GET("/oidc/callback", a.OIDCCallback)
+
 	//webhook
 	webhook := e.Group("/webhook")
 	webhook.POST("/v1/plaid", a.GenericPlaidWebhook)
 
-	//JWT endpoints
+	//API description, which is public
+	e.GET("/api/v1/openapi.json", a.ServeOpenAPI)
+
+	//JWT and personal access token endpoints, each of which requires the
+	//permissions that the user's role, and any personal access token,
+	//must grant to call it
+	var (
+		itemsRead         = auth.Require(auth.ScopeItemsRead)
+		itemsWrite        = auth.Require(auth.ScopeItemsWrite)
+		accountsRead      = auth.Require(auth.ScopeAccountsRead)
+		accountsWrite     = auth.Require(auth.ScopeAccountsWrite)
+		transactionsRead  = auth.Require(auth.ScopeTransactionsRead)
+		transactionsWrite = auth.Require(auth.ScopeTransactionsWrite)
+		transfersRead     = auth.Require(auth.ScopeTransfersRead)
+		transfersWrite    = auth.Require(auth.ScopeTransfersWrite)
+		reportsRead       = auth.Require(auth.ScopeReportsRead)
+		alertsRead        = auth.Require(auth.ScopeAlertsRead)
+		alertsWrite       = auth.Require(auth.ScopeAlertsWrite)
+		webhooksRead      = auth.Require(auth.ScopeWebhooksRead)
+		webhooksWrite     = auth.Require(auth.ScopeWebhooksWrite)
+		preferencesRead   = auth.Require(auth.ScopePreferencesRead)
+		preferencesWrite  = auth.Require(auth.ScopePreferencesWrite)
+		graphQLRead       = auth.Require(auth.ScopeItemsRead, auth.ScopeAccountsRead, auth.ScopeTransactionsRead)
+	)
+
 	backend := e.Group("/api/v1", a.BackendAuthorizationMiddleware)
-	backend.POST("/add_plaid_item", a.AddPlaidItem)
-	backend.GET("/get_accounts", a.GetAccounts)
+	backend.POST("/add_plaid_item", itemsWrite, a.AddPlaidItem)
+	backend.GET("/get_accounts", accountsRead, a.GetAccounts)
+	backend.POST("/export/:format", transactionsRead, a.ExportLedger)
+	backend.GET("/accounts/:id/export.ofx", transactionsRead, a.ExportStatement)
+	backend.GET("/accounts/:id/export.qfx", transactionsRead, a.ExportStatement)
+	backend.GET("/accounts/:id/export.qif", transactionsRead, a.ExportStatement)
+	backend.GET("/items", itemsRead, a.GetItems)
+	backend.GET("/items/:id", itemsRead, a.GetItem)
+	backend.DELETE("/items/:id", itemsWrite, a.DeleteItem)
+	backend.POST("/items/:id/refresh", itemsWrite, a.RefreshItem)
+	backend.GET("/accounts", accountsRead, a.GetAccounts)
+	backend.POST("/accounts", accountsWrite, a.CreateManualAccount)
+	backend.GET("/accounts/:id", accountsRead, a.GetAccount)
+	backend.PATCH("/accounts/:id", accountsWrite, a.UpdateAccount)
+	backend.DELETE("/accounts/:id", accountsWrite, a.DeleteAccount)
+	backend.POST("/accounts/:id/import", transactionsWrite, a.ImportStatement)
+	backend.GET("/transactions", transactionsRead, a.GetTransactions)
+	backend.GET("/transactions/:id", transactionsRead, a.GetTransaction)
+	backend.PATCH("/transactions/:id", transactionsWrite, a.UpdateTransaction)
+	backend.PUT("/transactions/:id/splits", transactionsWrite, a.SplitTransaction)
+	backend.POST("/transactions/:id/attachments", transactionsWrite, a.UploadAttachment)
+	backend.GET("/transactions/:id/attachments/:attachment_id", transactionsRead, a.DownloadAttachment)
+	backend.DELETE("/transactions/:id/attachments/:attachment_id", transactionsWrite, a.DeleteAttachment)
+	backend.GET("/transfers", transfersRead, a.GetTransfers)
+	backend.POST("/transfers/detect", transfersWrite, a.DetectTransfers)
+	//gin can't route POST /transfers/detect alongside POST /transfers/:id/...
+	backend.PUT("/transfers/:id/confirm", transfersWrite, a.ConfirmTransfer)
+	backend.DELETE("/transfers/:id", transfersWrite, a.RejectTransfer)
+	backend.GET("/reports/cashflow", reportsRead, a.GetCashflowReport)
+	backend.GET("/reports/spending", reportsRead, a.GetSpendingReport)
+	backend.GET("/forecast", reportsRead, a.GetForecast)
+	backend.GET("/alerts", alertsRead, a.GetAlerts)
+	backend.POST("/alerts", alertsWrite, a.CreateAlert)
+	backend.DELETE("/alerts/:id", alertsWrite, a.DeleteAlert)
+	backend.GET("/alerts/:id/deliveries", alertsRead, a.GetAlertDeliveries)
+	backend.GET("/webhooks", webhooksRead, a.GetWebhookEndpoints)
+	backend.POST("/webhooks", webhooksWrite, a.CreateWebhookEndpoint)
+	backend.DELETE("/webhooks/:id", webhooksWrite, a.DeleteWebhookEndpoint)
+	backend.GET("/webhooks/:id/deliveries", webhooksRead, a.GetWebhookDeliveries)
+	backend.POST("/webhooks/:id/deliveries/:delivery_id/redeliver", webhooksWrite, a.RedeliverWebhook)
+	backend.GET("/preferences/digest", preferencesRead, a.GetDigestPreferences)
+	backend.PUT("/preferences/digest", preferencesWrite, a.UpdateDigestPreferences)
+	backend.GET("/jobs/:id", itemsRead, a.GetJob)
+	backend.POST("/graphql", graphQLRead, a.GraphQL)
+	backend.GET("/graphql/schema", graphQLRead, a.GetGraphQLSchema)
+
+	//token management needs a login, so that a leaked token can't be
+	//used to mint more
+	tokens := backend.Group("/tokens", auth.RequireLogin)
+	tokens.GET("", a.GetPersonalAccessTokens)
+	tokens.POST("", a.CreatePersonalAccessToken)
+	tokens.DELETE("/:id", a.RevokePersonalAccessToken)
 
-	//admin endpoints
-	adminGroup := backend.Group("/admin")
-	adminGroup.POST("/register-user", a.RegisterUser)
+	//sharing gives other users access to the user's data, so it needs a
+	//login too
+	shares := backend.Group("/shares", auth.RequireLogin)
+	shares.GET("", accountsRead, a.GetShares)
+	shares.POST("", accountsWrite, a.CreateShare)
+	shares.POST("/:id/accept", accountsWrite, a.AcceptShare)
+	shares.DELETE("/:id", accountsWrite, a.RevokeShare)
+
+	//exporting or deleting everything about the user needs a login, and
+	//is open to every role
+	me := backend.Group("/me", auth.RequireLogin)
+	me.POST("/export", a.ExportMe)
+	me.DELETE("", a.DeleteMe)
+	me.GET("/activity", a.GetMyActivity)
+
+	//admin and support endpoints, which concern every user
+	var (
+		usersWrite  = auth.Require(auth.ScopeUsersWrite)
+		jobsRead    = auth.Require(auth.ScopeJobsRead)
+		supportRead = auth.Require(auth.ScopeSupportRead)
+		auditRead   = auth.Require(auth.ScopeAuditRead)
+	)
+	adminGroup := backend.Group("/admin", auth.RequireLogin)
+	adminGroup.POST("/register-user", usersWrite, a.RegisterUser)
+	adminGroup.GET("/users", supportRead, a.GetUsers)
+	adminGroup.GET("/users/:id", supportRead, a.GetUser)
+	adminGroup.PATCH("/users/:id", usersWrite, a.UpdateUser)
+	adminGroup.DELETE("/users/:id", usersWrite, a.DeleteUser)
+	adminGroup.POST("/users/:id/deactivate", usersWrite, a.DeactivateUser)
+	adminGroup.POST("/users/:id/reactivate", usersWrite, a.ReactivateUser)
+	adminGroup.PUT("/users/:id/role", usersWrite, a.SetUserRole)
+	adminGroup.GET("/users/:id/items", supportRead, a.GetUserItems)
+	adminGroup.GET("/users/:id/webhook-deliveries", supportRead, a.GetUserWebhookDeliveries)
+	adminGroup.GET("/jobs", jobsRead, a.GetJobs)
+	adminGroup.GET("/job-runs", jobsRead, a.GetJobRuns)
+	adminGroup.GET("/audit-events", auditRead, a.GetAuditEvents)
 }
 
 //NewServer creates a new Server.
@@ -76,10 +286,22 @@ func NewServer(
 	serviceDomain string,
 
 	authMgr auth.AuthorizationManager,
+	oidc *auth.OIDCRelyingParty,
 	renderer views.Renderer,
 	authorize auth.Getter,
 	plaidClient plaidapi.Client,
 	dbClient db.DB,
+	statementExporter statement.Exporter,
+	blobStore blob.Store,
+	transferMatcher transfers.Matcher,
+	recurringDetector recurring.Detector,
+	forecaster forecast.Forecaster,
+	alertEvaluator alerts.Evaluator,
+	notifiers map[db.AlertChannel]notify.Notifier,
+	webhookDispatcher webhooks.Dispatcher,
+	scheduler scheduler.Scheduler,
+	itemRefreshInterval time.Duration,
+	backfillYears int,
 ) ServerAgent {
 	plaidWebhookURL := (&url.URL{
 		Scheme: "https",
@@ -94,9 +316,26 @@ func NewServer(
 
 		authorize:   authorize,
 		renderer:    renderer,
+		oidc:        oidc,
 		plaidClient: plaidClient,
 		dbClient:    dbClient,
 
+		statementExporter: statementExporter,
+		blobStore:         blobStore,
+		transferMatcher:   transferMatcher,
+		recurringDetector: recurringDetector,
+		forecaster:        forecaster,
+		alertEvaluator:    alertEvaluator,
+		notifiers:         notifiers,
+		webhookDispatcher: webhookDispatcher,
+		scheduler:         scheduler,
+
+		itemRefreshInterval: itemRefreshInterval,
+		backfillYears:       backfillYears,
+
+		openAPIDocument: APIDocument(),
+		graphQLSchema:   newGraphQLSchema(),
+
 		backendJWTMiddleware:  authMgr.BackendMiddleware(),
 		frontendJWTMiddleware: authMgr.FrontendMiddleware(),
 	}
@@ -111,3 +350,15 @@ func (a ServerAgent) BackendAuthorizationMiddleware(c *gin.Context) {
 func (a ServerAgent) FrontendAuthorizationMiddleware(c *gin.Context) {
 	a.frontendJWTMiddleware(c)
 }
+
+//uuidParam gets a path parameter that identifies a resource by UUID.
+//Nothing can match a malformed one, and Postgres would reject it as
+//input, so it responds 404 with notFound instead.
+func uuidParam(c *gin.Context, name string, notFound string) (string, bool) {
+	uuid := c.Param(name)
+	if !db.ValidUUID(uuid) {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": notFound})
+		return "", false
+	}
+	return uuid, true
+}
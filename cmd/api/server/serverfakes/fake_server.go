@@ -0,0 +1,2807 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package serverfakes
+
+import (
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/xanderflood/plaid-ui/cmd/api/server"
+)
+
+type FakeServer struct {
+	AcceptShareStub        func(*gin.Context)
+	acceptShareMutex       sync.RWMutex
+	acceptShareArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	AddPlaidItemStub        func(*gin.Context)
+	addPlaidItemMutex       sync.RWMutex
+	addPlaidItemArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	BackendAuthorizationMiddlewareStub        func(*gin.Context)
+	backendAuthorizationMiddlewareMutex       sync.RWMutex
+	backendAuthorizationMiddlewareArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	ConfirmTransferStub        func(*gin.Context)
+	confirmTransferMutex       sync.RWMutex
+	confirmTransferArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	CreateAlertStub        func(*gin.Context)
+	createAlertMutex       sync.RWMutex
+	createAlertArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	CreateManualAccountStub        func(*gin.Context)
+	createManualAccountMutex       sync.RWMutex
+	createManualAccountArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	CreatePersonalAccessTokenStub        func(*gin.Context)
+	createPersonalAccessTokenMutex       sync.RWMutex
+	createPersonalAccessTokenArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	CreateShareStub        func(*gin.Context)
+	createShareMutex       sync.RWMutex
+	createShareArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	CreateWebhookEndpointStub        func(*gin.Context)
+	createWebhookEndpointMutex       sync.RWMutex
+	createWebhookEndpointArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	DeactivateUserStub        func(*gin.Context)
+	deactivateUserMutex       sync.RWMutex
+	deactivateUserArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	DeleteAccountStub        func(*gin.Context)
+	deleteAccountMutex       sync.RWMutex
+	deleteAccountArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	DeleteAlertStub        func(*gin.Context)
+	deleteAlertMutex       sync.RWMutex
+	deleteAlertArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	DeleteAttachmentStub        func(*gin.Context)
+	deleteAttachmentMutex       sync.RWMutex
+	deleteAttachmentArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	DeleteItemStub        func(*gin.Context)
+	deleteItemMutex       sync.RWMutex
+	deleteItemArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	DeleteMeStub        func(*gin.Context)
+	deleteMeMutex       sync.RWMutex
+	deleteMeArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	DeleteUserStub        func(*gin.Context)
+	deleteUserMutex       sync.RWMutex
+	deleteUserArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	DeleteWebhookEndpointStub        func(*gin.Context)
+	deleteWebhookEndpointMutex       sync.RWMutex
+	deleteWebhookEndpointArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	DetectTransfersStub        func(*gin.Context)
+	detectTransfersMutex       sync.RWMutex
+	detectTransfersArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	DownloadAttachmentStub        func(*gin.Context)
+	downloadAttachmentMutex       sync.RWMutex
+	downloadAttachmentArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	ExportLedgerStub        func(*gin.Context)
+	exportLedgerMutex       sync.RWMutex
+	exportLedgerArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	ExportMeStub        func(*gin.Context)
+	exportMeMutex       sync.RWMutex
+	exportMeArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	ExportStatementStub        func(*gin.Context)
+	exportStatementMutex       sync.RWMutex
+	exportStatementArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	FrontendAuthorizationMiddlewareStub        func(*gin.Context)
+	frontendAuthorizationMiddlewareMutex       sync.RWMutex
+	frontendAuthorizationMiddlewareArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GenericPlaidWebhookStub        func(*gin.Context)
+	genericPlaidWebhookMutex       sync.RWMutex
+	genericPlaidWebhookArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetAccountStub        func(*gin.Context)
+	getAccountMutex       sync.RWMutex
+	getAccountArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetAccountsStub        func(*gin.Context)
+	getAccountsMutex       sync.RWMutex
+	getAccountsArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetAlertDeliveriesStub        func(*gin.Context)
+	getAlertDeliveriesMutex       sync.RWMutex
+	getAlertDeliveriesArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetAlertsStub        func(*gin.Context)
+	getAlertsMutex       sync.RWMutex
+	getAlertsArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetAuditEventsStub        func(*gin.Context)
+	getAuditEventsMutex       sync.RWMutex
+	getAuditEventsArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetCashflowReportStub        func(*gin.Context)
+	getCashflowReportMutex       sync.RWMutex
+	getCashflowReportArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetDigestPreferencesStub        func(*gin.Context)
+	getDigestPreferencesMutex       sync.RWMutex
+	getDigestPreferencesArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetForecastStub        func(*gin.Context)
+	getForecastMutex       sync.RWMutex
+	getForecastArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetGraphQLSchemaStub        func(*gin.Context)
+	getGraphQLSchemaMutex       sync.RWMutex
+	getGraphQLSchemaArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetItemStub        func(*gin.Context)
+	getItemMutex       sync.RWMutex
+	getItemArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetItemsStub        func(*gin.Context)
+	getItemsMutex       sync.RWMutex
+	getItemsArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetJobStub        func(*gin.Context)
+	getJobMutex       sync.RWMutex
+	getJobArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetJobRunsStub        func(*gin.Context)
+	getJobRunsMutex       sync.RWMutex
+	getJobRunsArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetJobsStub        func(*gin.Context)
+	getJobsMutex       sync.RWMutex
+	getJobsArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetMyActivityStub        func(*gin.Context)
+	getMyActivityMutex       sync.RWMutex
+	getMyActivityArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetPersonalAccessTokensStub        func(*gin.Context)
+	getPersonalAccessTokensMutex       sync.RWMutex
+	getPersonalAccessTokensArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetSharesStub        func(*gin.Context)
+	getSharesMutex       sync.RWMutex
+	getSharesArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetSpendingReportStub        func(*gin.Context)
+	getSpendingReportMutex       sync.RWMutex
+	getSpendingReportArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetTransactionStub        func(*gin.Context)
+	getTransactionMutex       sync.RWMutex
+	getTransactionArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetTransactionsStub        func(*gin.Context)
+	getTransactionsMutex       sync.RWMutex
+	getTransactionsArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetTransfersStub        func(*gin.Context)
+	getTransfersMutex       sync.RWMutex
+	getTransfersArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetUserStub        func(*gin.Context)
+	getUserMutex       sync.RWMutex
+	getUserArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetUserItemsStub        func(*gin.Context)
+	getUserItemsMutex       sync.RWMutex
+	getUserItemsArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetUserWebhookDeliveriesStub        func(*gin.Context)
+	getUserWebhookDeliveriesMutex       sync.RWMutex
+	getUserWebhookDeliveriesArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetUsersStub        func(*gin.Context)
+	getUsersMutex       sync.RWMutex
+	getUsersArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetWebhookDeliveriesStub        func(*gin.Context)
+	getWebhookDeliveriesMutex       sync.RWMutex
+	getWebhookDeliveriesArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GetWebhookEndpointsStub        func(*gin.Context)
+	getWebhookEndpointsMutex       sync.RWMutex
+	getWebhookEndpointsArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	GraphQLStub        func(*gin.Context)
+	graphQLMutex       sync.RWMutex
+	graphQLArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	ImportStatementStub        func(*gin.Context)
+	importStatementMutex       sync.RWMutex
+	importStatementArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	LoginStub        func(*gin.Context)
+	loginMutex       sync.RWMutex
+	loginArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	OIDCCallbackStub        func(*gin.Context)
+	oIDCCallbackMutex       sync.RWMutex
+	oIDCCallbackArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	ReactivateUserStub        func(*gin.Context)
+	reactivateUserMutex       sync.RWMutex
+	reactivateUserArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	RedeliverWebhookStub        func(*gin.Context)
+	redeliverWebhookMutex       sync.RWMutex
+	redeliverWebhookArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	RefreshItemStub        func(*gin.Context)
+	refreshItemMutex       sync.RWMutex
+	refreshItemArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	RegisterUserStub        func(*gin.Context)
+	registerUserMutex       sync.RWMutex
+	registerUserArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	RejectTransferStub        func(*gin.Context)
+	rejectTransferMutex       sync.RWMutex
+	rejectTransferArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	RevokePersonalAccessTokenStub        func(*gin.Context)
+	revokePersonalAccessTokenMutex       sync.RWMutex
+	revokePersonalAccessTokenArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	RevokeShareStub        func(*gin.Context)
+	revokeShareMutex       sync.RWMutex
+	revokeShareArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	ServeOpenAPIStub        func(*gin.Context)
+	serveOpenAPIMutex       sync.RWMutex
+	serveOpenAPIArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	ServeSPAStub        func(*gin.Context)
+	serveSPAMutex       sync.RWMutex
+	serveSPAArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	SetUserRoleStub        func(*gin.Context)
+	setUserRoleMutex       sync.RWMutex
+	setUserRoleArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	SplitTransactionStub        func(*gin.Context)
+	splitTransactionMutex       sync.RWMutex
+	splitTransactionArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	UpdateAccountStub        func(*gin.Context)
+	updateAccountMutex       sync.RWMutex
+	updateAccountArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	UpdateDigestPreferencesStub        func(*gin.Context)
+	updateDigestPreferencesMutex       sync.RWMutex
+	updateDigestPreferencesArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	UpdateTransactionStub        func(*gin.Context)
+	updateTransactionMutex       sync.RWMutex
+	updateTransactionArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	UpdateUserStub        func(*gin.Context)
+	updateUserMutex       sync.RWMutex
+	updateUserArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	UploadAttachmentStub        func(*gin.Context)
+	uploadAttachmentMutex       sync.RWMutex
+	uploadAttachmentArgsForCall []struct {
+		arg1 *gin.Context
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeServer) AcceptShare(arg1 *gin.Context) {
+	fake.acceptShareMutex.Lock()
+	fake.acceptShareArgsForCall = append(fake.acceptShareArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.AcceptShareStub
+	fake.recordInvocation("AcceptShare", []interface{}{arg1})
+	fake.acceptShareMutex.Unlock()
+	if stub != nil {
+		fake.AcceptShareStub(arg1)
+	}
+}
+
+func (fake *FakeServer) AcceptShareCallCount() int {
+	fake.acceptShareMutex.RLock()
+	defer fake.acceptShareMutex.RUnlock()
+	return len(fake.acceptShareArgsForCall)
+}
+
+func (fake *FakeServer) AcceptShareCalls(stub func(*gin.Context)) {
+	fake.acceptShareMutex.Lock()
+	defer fake.acceptShareMutex.Unlock()
+	fake.AcceptShareStub = stub
+}
+
+func (fake *FakeServer) AcceptShareArgsForCall(i int) *gin.Context {
+	fake.acceptShareMutex.RLock()
+	defer fake.acceptShareMutex.RUnlock()
+	argsForCall := fake.acceptShareArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) AddPlaidItem(arg1 *gin.Context) {
+	fake.addPlaidItemMutex.Lock()
+	fake.addPlaidItemArgsForCall = append(fake.addPlaidItemArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.AddPlaidItemStub
+	fake.recordInvocation("AddPlaidItem", []interface{}{arg1})
+	fake.addPlaidItemMutex.Unlock()
+	if stub != nil {
+		fake.AddPlaidItemStub(arg1)
+	}
+}
+
+func (fake *FakeServer) AddPlaidItemCallCount() int {
+	fake.addPlaidItemMutex.RLock()
+	defer fake.addPlaidItemMutex.RUnlock()
+	return len(fake.addPlaidItemArgsForCall)
+}
+
+func (fake *FakeServer) AddPlaidItemCalls(stub func(*gin.Context)) {
+	fake.addPlaidItemMutex.Lock()
+	defer fake.addPlaidItemMutex.Unlock()
+	fake.AddPlaidItemStub = stub
+}
+
+func (fake *FakeServer) AddPlaidItemArgsForCall(i int) *gin.Context {
+	fake.addPlaidItemMutex.RLock()
+	defer fake.addPlaidItemMutex.RUnlock()
+	argsForCall := fake.addPlaidItemArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) BackendAuthorizationMiddleware(arg1 *gin.Context) {
+	fake.backendAuthorizationMiddlewareMutex.Lock()
+	fake.backendAuthorizationMiddlewareArgsForCall = append(fake.backendAuthorizationMiddlewareArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.BackendAuthorizationMiddlewareStub
+	fake.recordInvocation("BackendAuthorizationMiddleware", []interface{}{arg1})
+	fake.backendAuthorizationMiddlewareMutex.Unlock()
+	if stub != nil {
+		fake.BackendAuthorizationMiddlewareStub(arg1)
+	}
+}
+
+func (fake *FakeServer) BackendAuthorizationMiddlewareCallCount() int {
+	fake.backendAuthorizationMiddlewareMutex.RLock()
+	defer fake.backendAuthorizationMiddlewareMutex.RUnlock()
+	return len(fake.backendAuthorizationMiddlewareArgsForCall)
+}
+
+func (fake *FakeServer) BackendAuthorizationMiddlewareCalls(stub func(*gin.Context)) {
+	fake.backendAuthorizationMiddlewareMutex.Lock()
+	defer fake.backendAuthorizationMiddlewareMutex.Unlock()
+	fake.BackendAuthorizationMiddlewareStub = stub
+}
+
+func (fake *FakeServer) BackendAuthorizationMiddlewareArgsForCall(i int) *gin.Context {
+	fake.backendAuthorizationMiddlewareMutex.RLock()
+	defer fake.backendAuthorizationMiddlewareMutex.RUnlock()
+	argsForCall := fake.backendAuthorizationMiddlewareArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) ConfirmTransfer(arg1 *gin.Context) {
+	fake.confirmTransferMutex.Lock()
+	fake.confirmTransferArgsForCall = append(fake.confirmTransferArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.ConfirmTransferStub
+	fake.recordInvocation("ConfirmTransfer", []interface{}{arg1})
+	fake.confirmTransferMutex.Unlock()
+	if stub != nil {
+		fake.ConfirmTransferStub(arg1)
+	}
+}
+
+func (fake *FakeServer) ConfirmTransferCallCount() int {
+	fake.confirmTransferMutex.RLock()
+	defer fake.confirmTransferMutex.RUnlock()
+	return len(fake.confirmTransferArgsForCall)
+}
+
+func (fake *FakeServer) ConfirmTransferCalls(stub func(*gin.Context)) {
+	fake.confirmTransferMutex.Lock()
+	defer fake.confirmTransferMutex.Unlock()
+	fake.ConfirmTransferStub = stub
+}
+
+func (fake *FakeServer) ConfirmTransferArgsForCall(i int) *gin.Context {
+	fake.confirmTransferMutex.RLock()
+	defer fake.confirmTransferMutex.RUnlock()
+	argsForCall := fake.confirmTransferArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) CreateAlert(arg1 *gin.Context) {
+	fake.createAlertMutex.Lock()
+	fake.createAlertArgsForCall = append(fake.createAlertArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.CreateAlertStub
+	fake.recordInvocation("CreateAlert", []interface{}{arg1})
+	fake.createAlertMutex.Unlock()
+	if stub != nil {
+		fake.CreateAlertStub(arg1)
+	}
+}
+
+func (fake *FakeServer) CreateAlertCallCount() int {
+	fake.createAlertMutex.RLock()
+	defer fake.createAlertMutex.RUnlock()
+	return len(fake.createAlertArgsForCall)
+}
+
+func (fake *FakeServer) CreateAlertCalls(stub func(*gin.Context)) {
+	fake.createAlertMutex.Lock()
+	defer fake.createAlertMutex.Unlock()
+	fake.CreateAlertStub = stub
+}
+
+func (fake *FakeServer) CreateAlertArgsForCall(i int) *gin.Context {
+	fake.createAlertMutex.RLock()
+	defer fake.createAlertMutex.RUnlock()
+	argsForCall := fake.createAlertArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) CreateManualAccount(arg1 *gin.Context) {
+	fake.createManualAccountMutex.Lock()
+	fake.createManualAccountArgsForCall = append(fake.createManualAccountArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.CreateManualAccountStub
+	fake.recordInvocation("CreateManualAccount", []interface{}{arg1})
+	fake.createManualAccountMutex.Unlock()
+	if stub != nil {
+		fake.CreateManualAccountStub(arg1)
+	}
+}
+
+func (fake *FakeServer) CreateManualAccountCallCount() int {
+	fake.createManualAccountMutex.RLock()
+	defer fake.createManualAccountMutex.RUnlock()
+	return len(fake.createManualAccountArgsForCall)
+}
+
+func (fake *FakeServer) CreateManualAccountCalls(stub func(*gin.Context)) {
+	fake.createManualAccountMutex.Lock()
+	defer fake.createManualAccountMutex.Unlock()
+	fake.CreateManualAccountStub = stub
+}
+
+func (fake *FakeServer) CreateManualAccountArgsForCall(i int) *gin.Context {
+	fake.createManualAccountMutex.RLock()
+	defer fake.createManualAccountMutex.RUnlock()
+	argsForCall := fake.createManualAccountArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) CreatePersonalAccessToken(arg1 *gin.Context) {
+	fake.createPersonalAccessTokenMutex.Lock()
+	fake.createPersonalAccessTokenArgsForCall = append(fake.createPersonalAccessTokenArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.CreatePersonalAccessTokenStub
+	fake.recordInvocation("CreatePersonalAccessToken", []interface{}{arg1})
+	fake.createPersonalAccessTokenMutex.Unlock()
+	if stub != nil {
+		fake.CreatePersonalAccessTokenStub(arg1)
+	}
+}
+
+func (fake *FakeServer) CreatePersonalAccessTokenCallCount() int {
+	fake.createPersonalAccessTokenMutex.RLock()
+	defer fake.createPersonalAccessTokenMutex.RUnlock()
+	return len(fake.createPersonalAccessTokenArgsForCall)
+}
+
+func (fake *FakeServer) CreatePersonalAccessTokenCalls(stub func(*gin.Context)) {
+	fake.createPersonalAccessTokenMutex.Lock()
+	defer fake.createPersonalAccessTokenMutex.Unlock()
+	fake.CreatePersonalAccessTokenStub = stub
+}
+
+func (fake *FakeServer) CreatePersonalAccessTokenArgsForCall(i int) *gin.Context {
+	fake.createPersonalAccessTokenMutex.RLock()
+	defer fake.createPersonalAccessTokenMutex.RUnlock()
+	argsForCall := fake.createPersonalAccessTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) CreateShare(arg1 *gin.Context) {
+	fake.createShareMutex.Lock()
+	fake.createShareArgsForCall = append(fake.createShareArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.CreateShareStub
+	fake.recordInvocation("CreateShare", []interface{}{arg1})
+	fake.createShareMutex.Unlock()
+	if stub != nil {
+		fake.CreateShareStub(arg1)
+	}
+}
+
+func (fake *FakeServer) CreateShareCallCount() int {
+	fake.createShareMutex.RLock()
+	defer fake.createShareMutex.RUnlock()
+	return len(fake.createShareArgsForCall)
+}
+
+func (fake *FakeServer) CreateShareCalls(stub func(*gin.Context)) {
+	fake.createShareMutex.Lock()
+	defer fake.createShareMutex.Unlock()
+	fake.CreateShareStub = stub
+}
+
+func (fake *FakeServer) CreateShareArgsForCall(i int) *gin.Context {
+	fake.createShareMutex.RLock()
+	defer fake.createShareMutex.RUnlock()
+	argsForCall := fake.createShareArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) CreateWebhookEndpoint(arg1 *gin.Context) {
+	fake.createWebhookEndpointMutex.Lock()
+	fake.createWebhookEndpointArgsForCall = append(fake.createWebhookEndpointArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.CreateWebhookEndpointStub
+	fake.recordInvocation("CreateWebhookEndpoint", []interface{}{arg1})
+	fake.createWebhookEndpointMutex.Unlock()
+	if stub != nil {
+		fake.CreateWebhookEndpointStub(arg1)
+	}
+}
+
+func (fake *FakeServer) CreateWebhookEndpointCallCount() int {
+	fake.createWebhookEndpointMutex.RLock()
+	defer fake.createWebhookEndpointMutex.RUnlock()
+	return len(fake.createWebhookEndpointArgsForCall)
+}
+
+func (fake *FakeServer) CreateWebhookEndpointCalls(stub func(*gin.Context)) {
+	fake.createWebhookEndpointMutex.Lock()
+	defer fake.createWebhookEndpointMutex.Unlock()
+	fake.CreateWebhookEndpointStub = stub
+}
+
+func (fake *FakeServer) CreateWebhookEndpointArgsForCall(i int) *gin.Context {
+	fake.createWebhookEndpointMutex.RLock()
+	defer fake.createWebhookEndpointMutex.RUnlock()
+	argsForCall := fake.createWebhookEndpointArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) DeactivateUser(arg1 *gin.Context) {
+	fake.deactivateUserMutex.Lock()
+	fake.deactivateUserArgsForCall = append(fake.deactivateUserArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.DeactivateUserStub
+	fake.recordInvocation("DeactivateUser", []interface{}{arg1})
+	fake.deactivateUserMutex.Unlock()
+	if stub != nil {
+		fake.DeactivateUserStub(arg1)
+	}
+}
+
+func (fake *FakeServer) DeactivateUserCallCount() int {
+	fake.deactivateUserMutex.RLock()
+	defer fake.deactivateUserMutex.RUnlock()
+	return len(fake.deactivateUserArgsForCall)
+}
+
+func (fake *FakeServer) DeactivateUserCalls(stub func(*gin.Context)) {
+	fake.deactivateUserMutex.Lock()
+	defer fake.deactivateUserMutex.Unlock()
+	fake.DeactivateUserStub = stub
+}
+
+func (fake *FakeServer) DeactivateUserArgsForCall(i int) *gin.Context {
+	fake.deactivateUserMutex.RLock()
+	defer fake.deactivateUserMutex.RUnlock()
+	argsForCall := fake.deactivateUserArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) DeleteAccount(arg1 *gin.Context) {
+	fake.deleteAccountMutex.Lock()
+	fake.deleteAccountArgsForCall = append(fake.deleteAccountArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.DeleteAccountStub
+	fake.recordInvocation("DeleteAccount", []interface{}{arg1})
+	fake.deleteAccountMutex.Unlock()
+	if stub != nil {
+		fake.DeleteAccountStub(arg1)
+	}
+}
+
+func (fake *FakeServer) DeleteAccountCallCount() int {
+	fake.deleteAccountMutex.RLock()
+	defer fake.deleteAccountMutex.RUnlock()
+	return len(fake.deleteAccountArgsForCall)
+}
+
+func (fake *FakeServer) DeleteAccountCalls(stub func(*gin.Context)) {
+	fake.deleteAccountMutex.Lock()
+	defer fake.deleteAccountMutex.Unlock()
+	fake.DeleteAccountStub = stub
+}
+
+func (fake *FakeServer) DeleteAccountArgsForCall(i int) *gin.Context {
+	fake.deleteAccountMutex.RLock()
+	defer fake.deleteAccountMutex.RUnlock()
+	argsForCall := fake.deleteAccountArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) DeleteAlert(arg1 *gin.Context) {
+	fake.deleteAlertMutex.Lock()
+	fake.deleteAlertArgsForCall = append(fake.deleteAlertArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.DeleteAlertStub
+	fake.recordInvocation("DeleteAlert", []interface{}{arg1})
+	fake.deleteAlertMutex.Unlock()
+	if stub != nil {
+		fake.DeleteAlertStub(arg1)
+	}
+}
+
+func (fake *FakeServer) DeleteAlertCallCount() int {
+	fake.deleteAlertMutex.RLock()
+	defer fake.deleteAlertMutex.RUnlock()
+	return len(fake.deleteAlertArgsForCall)
+}
+
+func (fake *FakeServer) DeleteAlertCalls(stub func(*gin.Context)) {
+	fake.deleteAlertMutex.Lock()
+	defer fake.deleteAlertMutex.Unlock()
+	fake.DeleteAlertStub = stub
+}
+
+func (fake *FakeServer) DeleteAlertArgsForCall(i int) *gin.Context {
+	fake.deleteAlertMutex.RLock()
+	defer fake.deleteAlertMutex.RUnlock()
+	argsForCall := fake.deleteAlertArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) DeleteAttachment(arg1 *gin.Context) {
+	fake.deleteAttachmentMutex.Lock()
+	fake.deleteAttachmentArgsForCall = append(fake.deleteAttachmentArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.DeleteAttachmentStub
+	fake.recordInvocation("DeleteAttachment", []interface{}{arg1})
+	fake.deleteAttachmentMutex.Unlock()
+	if stub != nil {
+		fake.DeleteAttachmentStub(arg1)
+	}
+}
+
+func (fake *FakeServer) DeleteAttachmentCallCount() int {
+	fake.deleteAttachmentMutex.RLock()
+	defer fake.deleteAttachmentMutex.RUnlock()
+	return len(fake.deleteAttachmentArgsForCall)
+}
+
+func (fake *FakeServer) DeleteAttachmentCalls(stub func(*gin.Context)) {
+	fake.deleteAttachmentMutex.Lock()
+	defer fake.deleteAttachmentMutex.Unlock()
+	fake.DeleteAttachmentStub = stub
+}
+
+func (fake *FakeServer) DeleteAttachmentArgsForCall(i int) *gin.Context {
+	fake.deleteAttachmentMutex.RLock()
+	defer fake.deleteAttachmentMutex.RUnlock()
+	argsForCall := fake.deleteAttachmentArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) DeleteItem(arg1 *gin.Context) {
+	fake.deleteItemMutex.Lock()
+	fake.deleteItemArgsForCall = append(fake.deleteItemArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.DeleteItemStub
+	fake.recordInvocation("DeleteItem", []interface{}{arg1})
+	fake.deleteItemMutex.Unlock()
+	if stub != nil {
+		fake.DeleteItemStub(arg1)
+	}
+}
+
+func (fake *FakeServer) DeleteItemCallCount() int {
+	fake.deleteItemMutex.RLock()
+	defer fake.deleteItemMutex.RUnlock()
+	return len(fake.deleteItemArgsForCall)
+}
+
+func (fake *FakeServer) DeleteItemCalls(stub func(*gin.Context)) {
+	fake.deleteItemMutex.Lock()
+	defer fake.deleteItemMutex.Unlock()
+	fake.DeleteItemStub = stub
+}
+
+func (fake *FakeServer) DeleteItemArgsForCall(i int) *gin.Context {
+	fake.deleteItemMutex.RLock()
+	defer fake.deleteItemMutex.RUnlock()
+	argsForCall := fake.deleteItemArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) DeleteMe(arg1 *gin.Context) {
+	fake.deleteMeMutex.Lock()
+	fake.deleteMeArgsForCall = append(fake.deleteMeArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.DeleteMeStub
+	fake.recordInvocation("DeleteMe", []interface{}{arg1})
+	fake.deleteMeMutex.Unlock()
+	if stub != nil {
+		fake.DeleteMeStub(arg1)
+	}
+}
+
+func (fake *FakeServer) DeleteMeCallCount() int {
+	fake.deleteMeMutex.RLock()
+	defer fake.deleteMeMutex.RUnlock()
+	return len(fake.deleteMeArgsForCall)
+}
+
+func (fake *FakeServer) DeleteMeCalls(stub func(*gin.Context)) {
+	fake.deleteMeMutex.Lock()
+	defer fake.deleteMeMutex.Unlock()
+	fake.DeleteMeStub = stub
+}
+
+func (fake *FakeServer) DeleteMeArgsForCall(i int) *gin.Context {
+	fake.deleteMeMutex.RLock()
+	defer fake.deleteMeMutex.RUnlock()
+	argsForCall := fake.deleteMeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) DeleteUser(arg1 *gin.Context) {
+	fake.deleteUserMutex.Lock()
+	fake.deleteUserArgsForCall = append(fake.deleteUserArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.DeleteUserStub
+	fake.recordInvocation("DeleteUser", []interface{}{arg1})
+	fake.deleteUserMutex.Unlock()
+	if stub != nil {
+		fake.DeleteUserStub(arg1)
+	}
+}
+
+func (fake *FakeServer) DeleteUserCallCount() int {
+	fake.deleteUserMutex.RLock()
+	defer fake.deleteUserMutex.RUnlock()
+	return len(fake.deleteUserArgsForCall)
+}
+
+func (fake *FakeServer) DeleteUserCalls(stub func(*gin.Context)) {
+	fake.deleteUserMutex.Lock()
+	defer fake.deleteUserMutex.Unlock()
+	fake.DeleteUserStub = stub
+}
+
+func (fake *FakeServer) DeleteUserArgsForCall(i int) *gin.Context {
+	fake.deleteUserMutex.RLock()
+	defer fake.deleteUserMutex.RUnlock()
+	argsForCall := fake.deleteUserArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) DeleteWebhookEndpoint(arg1 *gin.Context) {
+	fake.deleteWebhookEndpointMutex.Lock()
+	fake.deleteWebhookEndpointArgsForCall = append(fake.deleteWebhookEndpointArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.DeleteWebhookEndpointStub
+	fake.recordInvocation("DeleteWebhookEndpoint", []interface{}{arg1})
+	fake.deleteWebhookEndpointMutex.Unlock()
+	if stub != nil {
+		fake.DeleteWebhookEndpointStub(arg1)
+	}
+}
+
+func (fake *FakeServer) DeleteWebhookEndpointCallCount() int {
+	fake.deleteWebhookEndpointMutex.RLock()
+	defer fake.deleteWebhookEndpointMutex.RUnlock()
+	return len(fake.deleteWebhookEndpointArgsForCall)
+}
+
+func (fake *FakeServer) DeleteWebhookEndpointCalls(stub func(*gin.Context)) {
+	fake.deleteWebhookEndpointMutex.Lock()
+	defer fake.deleteWebhookEndpointMutex.Unlock()
+	fake.DeleteWebhookEndpointStub = stub
+}
+
+func (fake *FakeServer) DeleteWebhookEndpointArgsForCall(i int) *gin.Context {
+	fake.deleteWebhookEndpointMutex.RLock()
+	defer fake.deleteWebhookEndpointMutex.RUnlock()
+	argsForCall := fake.deleteWebhookEndpointArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) DetectTransfers(arg1 *gin.Context) {
+	fake.detectTransfersMutex.Lock()
+	fake.detectTransfersArgsForCall = append(fake.detectTransfersArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.DetectTransfersStub
+	fake.recordInvocation("DetectTransfers", []interface{}{arg1})
+	fake.detectTransfersMutex.Unlock()
+	if stub != nil {
+		fake.DetectTransfersStub(arg1)
+	}
+}
+
+func (fake *FakeServer) DetectTransfersCallCount() int {
+	fake.detectTransfersMutex.RLock()
+	defer fake.detectTransfersMutex.RUnlock()
+	return len(fake.detectTransfersArgsForCall)
+}
+
+func (fake *FakeServer) DetectTransfersCalls(stub func(*gin.Context)) {
+	fake.detectTransfersMutex.Lock()
+	defer fake.detectTransfersMutex.Unlock()
+	fake.DetectTransfersStub = stub
+}
+
+func (fake *FakeServer) DetectTransfersArgsForCall(i int) *gin.Context {
+	fake.detectTransfersMutex.RLock()
+	defer fake.detectTransfersMutex.RUnlock()
+	argsForCall := fake.detectTransfersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) DownloadAttachment(arg1 *gin.Context) {
+	fake.downloadAttachmentMutex.Lock()
+	fake.downloadAttachmentArgsForCall = append(fake.downloadAttachmentArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.DownloadAttachmentStub
+	fake.recordInvocation("DownloadAttachment", []interface{}{arg1})
+	fake.downloadAttachmentMutex.Unlock()
+	if stub != nil {
+		fake.DownloadAttachmentStub(arg1)
+	}
+}
+
+func (fake *FakeServer) DownloadAttachmentCallCount() int {
+	fake.downloadAttachmentMutex.RLock()
+	defer fake.downloadAttachmentMutex.RUnlock()
+	return len(fake.downloadAttachmentArgsForCall)
+}
+
+func (fake *FakeServer) DownloadAttachmentCalls(stub func(*gin.Context)) {
+	fake.downloadAttachmentMutex.Lock()
+	defer fake.downloadAttachmentMutex.Unlock()
+	fake.DownloadAttachmentStub = stub
+}
+
+func (fake *FakeServer) DownloadAttachmentArgsForCall(i int) *gin.Context {
+	fake.downloadAttachmentMutex.RLock()
+	defer fake.downloadAttachmentMutex.RUnlock()
+	argsForCall := fake.downloadAttachmentArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) ExportLedger(arg1 *gin.Context) {
+	fake.exportLedgerMutex.Lock()
+	fake.exportLedgerArgsForCall = append(fake.exportLedgerArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.ExportLedgerStub
+	fake.recordInvocation("ExportLedger", []interface{}{arg1})
+	fake.exportLedgerMutex.Unlock()
+	if stub != nil {
+		fake.ExportLedgerStub(arg1)
+	}
+}
+
+func (fake *FakeServer) ExportLedgerCallCount() int {
+	fake.exportLedgerMutex.RLock()
+	defer fake.exportLedgerMutex.RUnlock()
+	return len(fake.exportLedgerArgsForCall)
+}
+
+func (fake *FakeServer) ExportLedgerCalls(stub func(*gin.Context)) {
+	fake.exportLedgerMutex.Lock()
+	defer fake.exportLedgerMutex.Unlock()
+	fake.ExportLedgerStub = stub
+}
+
+func (fake *FakeServer) ExportLedgerArgsForCall(i int) *gin.Context {
+	fake.exportLedgerMutex.RLock()
+	defer fake.exportLedgerMutex.RUnlock()
+	argsForCall := fake.exportLedgerArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) ExportMe(arg1 *gin.Context) {
+	fake.exportMeMutex.Lock()
+	fake.exportMeArgsForCall = append(fake.exportMeArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.ExportMeStub
+	fake.recordInvocation("ExportMe", []interface{}{arg1})
+	fake.exportMeMutex.Unlock()
+	if stub != nil {
+		fake.ExportMeStub(arg1)
+	}
+}
+
+func (fake *FakeServer) ExportMeCallCount() int {
+	fake.exportMeMutex.RLock()
+	defer fake.exportMeMutex.RUnlock()
+	return len(fake.exportMeArgsForCall)
+}
+
+func (fake *FakeServer) ExportMeCalls(stub func(*gin.Context)) {
+	fake.exportMeMutex.Lock()
+	defer fake.exportMeMutex.Unlock()
+	fake.ExportMeStub = stub
+}
+
+func (fake *FakeServer) ExportMeArgsForCall(i int) *gin.Context {
+	fake.exportMeMutex.RLock()
+	defer fake.exportMeMutex.RUnlock()
+	argsForCall := fake.exportMeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) ExportStatement(arg1 *gin.Context) {
+	fake.exportStatementMutex.Lock()
+	fake.exportStatementArgsForCall = append(fake.exportStatementArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.ExportStatementStub
+	fake.recordInvocation("ExportStatement", []interface{}{arg1})
+	fake.exportStatementMutex.Unlock()
+	if stub != nil {
+		fake.ExportStatementStub(arg1)
+	}
+}
+
+func (fake *FakeServer) ExportStatementCallCount() int {
+	fake.exportStatementMutex.RLock()
+	defer fake.exportStatementMutex.RUnlock()
+	return len(fake.exportStatementArgsForCall)
+}
+
+func (fake *FakeServer) ExportStatementCalls(stub func(*gin.Context)) {
+	fake.exportStatementMutex.Lock()
+	defer fake.exportStatementMutex.Unlock()
+	fake.ExportStatementStub = stub
+}
+
+func (fake *FakeServer) ExportStatementArgsForCall(i int) *gin.Context {
+	fake.exportStatementMutex.RLock()
+	defer fake.exportStatementMutex.RUnlock()
+	argsForCall := fake.exportStatementArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) FrontendAuthorizationMiddleware(arg1 *gin.Context) {
+	fake.frontendAuthorizationMiddlewareMutex.Lock()
+	fake.frontendAuthorizationMiddlewareArgsForCall = append(fake.frontendAuthorizationMiddlewareArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.FrontendAuthorizationMiddlewareStub
+	fake.recordInvocation("FrontendAuthorizationMiddleware", []interface{}{arg1})
+	fake.frontendAuthorizationMiddlewareMutex.Unlock()
+	if stub != nil {
+		fake.FrontendAuthorizationMiddlewareStub(arg1)
+	}
+}
+
+func (fake *FakeServer) FrontendAuthorizationMiddlewareCallCount() int {
+	fake.frontendAuthorizationMiddlewareMutex.RLock()
+	defer fake.frontendAuthorizationMiddlewareMutex.RUnlock()
+	return len(fake.frontendAuthorizationMiddlewareArgsForCall)
+}
+
+func (fake *FakeServer) FrontendAuthorizationMiddlewareCalls(stub func(*gin.Context)) {
+	fake.frontendAuthorizationMiddlewareMutex.Lock()
+	defer fake.frontendAuthorizationMiddlewareMutex.Unlock()
+	fake.FrontendAuthorizationMiddlewareStub = stub
+}
+
+func (fake *FakeServer) FrontendAuthorizationMiddlewareArgsForCall(i int) *gin.Context {
+	fake.frontendAuthorizationMiddlewareMutex.RLock()
+	defer fake.frontendAuthorizationMiddlewareMutex.RUnlock()
+	argsForCall := fake.frontendAuthorizationMiddlewareArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GenericPlaidWebhook(arg1 *gin.Context) {
+	fake.genericPlaidWebhookMutex.Lock()
+	fake.genericPlaidWebhookArgsForCall = append(fake.genericPlaidWebhookArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GenericPlaidWebhookStub
+	fake.recordInvocation("GenericPlaidWebhook", []interface{}{arg1})
+	fake.genericPlaidWebhookMutex.Unlock()
+	if stub != nil {
+		fake.GenericPlaidWebhookStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GenericPlaidWebhookCallCount() int {
+	fake.genericPlaidWebhookMutex.RLock()
+	defer fake.genericPlaidWebhookMutex.RUnlock()
+	return len(fake.genericPlaidWebhookArgsForCall)
+}
+
+func (fake *FakeServer) GenericPlaidWebhookCalls(stub func(*gin.Context)) {
+	fake.genericPlaidWebhookMutex.Lock()
+	defer fake.genericPlaidWebhookMutex.Unlock()
+	fake.GenericPlaidWebhookStub = stub
+}
+
+func (fake *FakeServer) GenericPlaidWebhookArgsForCall(i int) *gin.Context {
+	fake.genericPlaidWebhookMutex.RLock()
+	defer fake.genericPlaidWebhookMutex.RUnlock()
+	argsForCall := fake.genericPlaidWebhookArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetAccount(arg1 *gin.Context) {
+	fake.getAccountMutex.Lock()
+	fake.getAccountArgsForCall = append(fake.getAccountArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetAccountStub
+	fake.recordInvocation("GetAccount", []interface{}{arg1})
+	fake.getAccountMutex.Unlock()
+	if stub != nil {
+		fake.GetAccountStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetAccountCallCount() int {
+	fake.getAccountMutex.RLock()
+	defer fake.getAccountMutex.RUnlock()
+	return len(fake.getAccountArgsForCall)
+}
+
+func (fake *FakeServer) GetAccountCalls(stub func(*gin.Context)) {
+	fake.getAccountMutex.Lock()
+	defer fake.getAccountMutex.Unlock()
+	fake.GetAccountStub = stub
+}
+
+func (fake *FakeServer) GetAccountArgsForCall(i int) *gin.Context {
+	fake.getAccountMutex.RLock()
+	defer fake.getAccountMutex.RUnlock()
+	argsForCall := fake.getAccountArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetAccounts(arg1 *gin.Context) {
+	fake.getAccountsMutex.Lock()
+	fake.getAccountsArgsForCall = append(fake.getAccountsArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetAccountsStub
+	fake.recordInvocation("GetAccounts", []interface{}{arg1})
+	fake.getAccountsMutex.Unlock()
+	if stub != nil {
+		fake.GetAccountsStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetAccountsCallCount() int {
+	fake.getAccountsMutex.RLock()
+	defer fake.getAccountsMutex.RUnlock()
+	return len(fake.getAccountsArgsForCall)
+}
+
+func (fake *FakeServer) GetAccountsCalls(stub func(*gin.Context)) {
+	fake.getAccountsMutex.Lock()
+	defer fake.getAccountsMutex.Unlock()
+	fake.GetAccountsStub = stub
+}
+
+func (fake *FakeServer) GetAccountsArgsForCall(i int) *gin.Context {
+	fake.getAccountsMutex.RLock()
+	defer fake.getAccountsMutex.RUnlock()
+	argsForCall := fake.getAccountsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetAlertDeliveries(arg1 *gin.Context) {
+	fake.getAlertDeliveriesMutex.Lock()
+	fake.getAlertDeliveriesArgsForCall = append(fake.getAlertDeliveriesArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetAlertDeliveriesStub
+	fake.recordInvocation("GetAlertDeliveries", []interface{}{arg1})
+	fake.getAlertDeliveriesMutex.Unlock()
+	if stub != nil {
+		fake.GetAlertDeliveriesStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetAlertDeliveriesCallCount() int {
+	fake.getAlertDeliveriesMutex.RLock()
+	defer fake.getAlertDeliveriesMutex.RUnlock()
+	return len(fake.getAlertDeliveriesArgsForCall)
+}
+
+func (fake *FakeServer) GetAlertDeliveriesCalls(stub func(*gin.Context)) {
+	fake.getAlertDeliveriesMutex.Lock()
+	defer fake.getAlertDeliveriesMutex.Unlock()
+	fake.GetAlertDeliveriesStub = stub
+}
+
+func (fake *FakeServer) GetAlertDeliveriesArgsForCall(i int) *gin.Context {
+	fake.getAlertDeliveriesMutex.RLock()
+	defer fake.getAlertDeliveriesMutex.RUnlock()
+	argsForCall := fake.getAlertDeliveriesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetAlerts(arg1 *gin.Context) {
+	fake.getAlertsMutex.Lock()
+	fake.getAlertsArgsForCall = append(fake.getAlertsArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetAlertsStub
+	fake.recordInvocation("GetAlerts", []interface{}{arg1})
+	fake.getAlertsMutex.Unlock()
+	if stub != nil {
+		fake.GetAlertsStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetAlertsCallCount() int {
+	fake.getAlertsMutex.RLock()
+	defer fake.getAlertsMutex.RUnlock()
+	return len(fake.getAlertsArgsForCall)
+}
+
+func (fake *FakeServer) GetAlertsCalls(stub func(*gin.Context)) {
+	fake.getAlertsMutex.Lock()
+	defer fake.getAlertsMutex.Unlock()
+	fake.GetAlertsStub = stub
+}
+
+func (fake *FakeServer) GetAlertsArgsForCall(i int) *gin.Context {
+	fake.getAlertsMutex.RLock()
+	defer fake.getAlertsMutex.RUnlock()
+	argsForCall := fake.getAlertsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetAuditEvents(arg1 *gin.Context) {
+	fake.getAuditEventsMutex.Lock()
+	fake.getAuditEventsArgsForCall = append(fake.getAuditEventsArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetAuditEventsStub
+	fake.recordInvocation("GetAuditEvents", []interface{}{arg1})
+	fake.getAuditEventsMutex.Unlock()
+	if stub != nil {
+		fake.GetAuditEventsStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetAuditEventsCallCount() int {
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	return len(fake.getAuditEventsArgsForCall)
+}
+
+func (fake *FakeServer) GetAuditEventsCalls(stub func(*gin.Context)) {
+	fake.getAuditEventsMutex.Lock()
+	defer fake.getAuditEventsMutex.Unlock()
+	fake.GetAuditEventsStub = stub
+}
+
+func (fake *FakeServer) GetAuditEventsArgsForCall(i int) *gin.Context {
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	argsForCall := fake.getAuditEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetCashflowReport(arg1 *gin.Context) {
+	fake.getCashflowReportMutex.Lock()
+	fake.getCashflowReportArgsForCall = append(fake.getCashflowReportArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetCashflowReportStub
+	fake.recordInvocation("GetCashflowReport", []interface{}{arg1})
+	fake.getCashflowReportMutex.Unlock()
+	if stub != nil {
+		fake.GetCashflowReportStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetCashflowReportCallCount() int {
+	fake.getCashflowReportMutex.RLock()
+	defer fake.getCashflowReportMutex.RUnlock()
+	return len(fake.getCashflowReportArgsForCall)
+}
+
+func (fake *FakeServer) GetCashflowReportCalls(stub func(*gin.Context)) {
+	fake.getCashflowReportMutex.Lock()
+	defer fake.getCashflowReportMutex.Unlock()
+	fake.GetCashflowReportStub = stub
+}
+
+func (fake *FakeServer) GetCashflowReportArgsForCall(i int) *gin.Context {
+	fake.getCashflowReportMutex.RLock()
+	defer fake.getCashflowReportMutex.RUnlock()
+	argsForCall := fake.getCashflowReportArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetDigestPreferences(arg1 *gin.Context) {
+	fake.getDigestPreferencesMutex.Lock()
+	fake.getDigestPreferencesArgsForCall = append(fake.getDigestPreferencesArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetDigestPreferencesStub
+	fake.recordInvocation("GetDigestPreferences", []interface{}{arg1})
+	fake.getDigestPreferencesMutex.Unlock()
+	if stub != nil {
+		fake.GetDigestPreferencesStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetDigestPreferencesCallCount() int {
+	fake.getDigestPreferencesMutex.RLock()
+	defer fake.getDigestPreferencesMutex.RUnlock()
+	return len(fake.getDigestPreferencesArgsForCall)
+}
+
+func (fake *FakeServer) GetDigestPreferencesCalls(stub func(*gin.Context)) {
+	fake.getDigestPreferencesMutex.Lock()
+	defer fake.getDigestPreferencesMutex.Unlock()
+	fake.GetDigestPreferencesStub = stub
+}
+
+func (fake *FakeServer) GetDigestPreferencesArgsForCall(i int) *gin.Context {
+	fake.getDigestPreferencesMutex.RLock()
+	defer fake.getDigestPreferencesMutex.RUnlock()
+	argsForCall := fake.getDigestPreferencesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetForecast(arg1 *gin.Context) {
+	fake.getForecastMutex.Lock()
+	fake.getForecastArgsForCall = append(fake.getForecastArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetForecastStub
+	fake.recordInvocation("GetForecast", []interface{}{arg1})
+	fake.getForecastMutex.Unlock()
+	if stub != nil {
+		fake.GetForecastStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetForecastCallCount() int {
+	fake.getForecastMutex.RLock()
+	defer fake.getForecastMutex.RUnlock()
+	return len(fake.getForecastArgsForCall)
+}
+
+func (fake *FakeServer) GetForecastCalls(stub func(*gin.Context)) {
+	fake.getForecastMutex.Lock()
+	defer fake.getForecastMutex.Unlock()
+	fake.GetForecastStub = stub
+}
+
+func (fake *FakeServer) GetForecastArgsForCall(i int) *gin.Context {
+	fake.getForecastMutex.RLock()
+	defer fake.getForecastMutex.RUnlock()
+	argsForCall := fake.getForecastArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetGraphQLSchema(arg1 *gin.Context) {
+	fake.getGraphQLSchemaMutex.Lock()
+	fake.getGraphQLSchemaArgsForCall = append(fake.getGraphQLSchemaArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetGraphQLSchemaStub
+	fake.recordInvocation("GetGraphQLSchema", []interface{}{arg1})
+	fake.getGraphQLSchemaMutex.Unlock()
+	if stub != nil {
+		fake.GetGraphQLSchemaStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetGraphQLSchemaCallCount() int {
+	fake.getGraphQLSchemaMutex.RLock()
+	defer fake.getGraphQLSchemaMutex.RUnlock()
+	return len(fake.getGraphQLSchemaArgsForCall)
+}
+
+func (fake *FakeServer) GetGraphQLSchemaCalls(stub func(*gin.Context)) {
+	fake.getGraphQLSchemaMutex.Lock()
+	defer fake.getGraphQLSchemaMutex.Unlock()
+	fake.GetGraphQLSchemaStub = stub
+}
+
+func (fake *FakeServer) GetGraphQLSchemaArgsForCall(i int) *gin.Context {
+	fake.getGraphQLSchemaMutex.RLock()
+	defer fake.getGraphQLSchemaMutex.RUnlock()
+	argsForCall := fake.getGraphQLSchemaArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetItem(arg1 *gin.Context) {
+	fake.getItemMutex.Lock()
+	fake.getItemArgsForCall = append(fake.getItemArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetItemStub
+	fake.recordInvocation("GetItem", []interface{}{arg1})
+	fake.getItemMutex.Unlock()
+	if stub != nil {
+		fake.GetItemStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetItemCallCount() int {
+	fake.getItemMutex.RLock()
+	defer fake.getItemMutex.RUnlock()
+	return len(fake.getItemArgsForCall)
+}
+
+func (fake *FakeServer) GetItemCalls(stub func(*gin.Context)) {
+	fake.getItemMutex.Lock()
+	defer fake.getItemMutex.Unlock()
+	fake.GetItemStub = stub
+}
+
+func (fake *FakeServer) GetItemArgsForCall(i int) *gin.Context {
+	fake.getItemMutex.RLock()
+	defer fake.getItemMutex.RUnlock()
+	argsForCall := fake.getItemArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetItems(arg1 *gin.Context) {
+	fake.getItemsMutex.Lock()
+	fake.getItemsArgsForCall = append(fake.getItemsArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetItemsStub
+	fake.recordInvocation("GetItems", []interface{}{arg1})
+	fake.getItemsMutex.Unlock()
+	if stub != nil {
+		fake.GetItemsStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetItemsCallCount() int {
+	fake.getItemsMutex.RLock()
+	defer fake.getItemsMutex.RUnlock()
+	return len(fake.getItemsArgsForCall)
+}
+
+func (fake *FakeServer) GetItemsCalls(stub func(*gin.Context)) {
+	fake.getItemsMutex.Lock()
+	defer fake.getItemsMutex.Unlock()
+	fake.GetItemsStub = stub
+}
+
+func (fake *FakeServer) GetItemsArgsForCall(i int) *gin.Context {
+	fake.getItemsMutex.RLock()
+	defer fake.getItemsMutex.RUnlock()
+	argsForCall := fake.getItemsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetJob(arg1 *gin.Context) {
+	fake.getJobMutex.Lock()
+	fake.getJobArgsForCall = append(fake.getJobArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetJobStub
+	fake.recordInvocation("GetJob", []interface{}{arg1})
+	fake.getJobMutex.Unlock()
+	if stub != nil {
+		fake.GetJobStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetJobCallCount() int {
+	fake.getJobMutex.RLock()
+	defer fake.getJobMutex.RUnlock()
+	return len(fake.getJobArgsForCall)
+}
+
+func (fake *FakeServer) GetJobCalls(stub func(*gin.Context)) {
+	fake.getJobMutex.Lock()
+	defer fake.getJobMutex.Unlock()
+	fake.GetJobStub = stub
+}
+
+func (fake *FakeServer) GetJobArgsForCall(i int) *gin.Context {
+	fake.getJobMutex.RLock()
+	defer fake.getJobMutex.RUnlock()
+	argsForCall := fake.getJobArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetJobRuns(arg1 *gin.Context) {
+	fake.getJobRunsMutex.Lock()
+	fake.getJobRunsArgsForCall = append(fake.getJobRunsArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetJobRunsStub
+	fake.recordInvocation("GetJobRuns", []interface{}{arg1})
+	fake.getJobRunsMutex.Unlock()
+	if stub != nil {
+		fake.GetJobRunsStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetJobRunsCallCount() int {
+	fake.getJobRunsMutex.RLock()
+	defer fake.getJobRunsMutex.RUnlock()
+	return len(fake.getJobRunsArgsForCall)
+}
+
+func (fake *FakeServer) GetJobRunsCalls(stub func(*gin.Context)) {
+	fake.getJobRunsMutex.Lock()
+	defer fake.getJobRunsMutex.Unlock()
+	fake.GetJobRunsStub = stub
+}
+
+func (fake *FakeServer) GetJobRunsArgsForCall(i int) *gin.Context {
+	fake.getJobRunsMutex.RLock()
+	defer fake.getJobRunsMutex.RUnlock()
+	argsForCall := fake.getJobRunsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetJobs(arg1 *gin.Context) {
+	fake.getJobsMutex.Lock()
+	fake.getJobsArgsForCall = append(fake.getJobsArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetJobsStub
+	fake.recordInvocation("GetJobs", []interface{}{arg1})
+	fake.getJobsMutex.Unlock()
+	if stub != nil {
+		fake.GetJobsStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetJobsCallCount() int {
+	fake.getJobsMutex.RLock()
+	defer fake.getJobsMutex.RUnlock()
+	return len(fake.getJobsArgsForCall)
+}
+
+func (fake *FakeServer) GetJobsCalls(stub func(*gin.Context)) {
+	fake.getJobsMutex.Lock()
+	defer fake.getJobsMutex.Unlock()
+	fake.GetJobsStub = stub
+}
+
+func (fake *FakeServer) GetJobsArgsForCall(i int) *gin.Context {
+	fake.getJobsMutex.RLock()
+	defer fake.getJobsMutex.RUnlock()
+	argsForCall := fake.getJobsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetMyActivity(arg1 *gin.Context) {
+	fake.getMyActivityMutex.Lock()
+	fake.getMyActivityArgsForCall = append(fake.getMyActivityArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetMyActivityStub
+	fake.recordInvocation("GetMyActivity", []interface{}{arg1})
+	fake.getMyActivityMutex.Unlock()
+	if stub != nil {
+		fake.GetMyActivityStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetMyActivityCallCount() int {
+	fake.getMyActivityMutex.RLock()
+	defer fake.getMyActivityMutex.RUnlock()
+	return len(fake.getMyActivityArgsForCall)
+}
+
+func (fake *FakeServer) GetMyActivityCalls(stub func(*gin.Context)) {
+	fake.getMyActivityMutex.Lock()
+	defer fake.getMyActivityMutex.Unlock()
+	fake.GetMyActivityStub = stub
+}
+
+func (fake *FakeServer) GetMyActivityArgsForCall(i int) *gin.Context {
+	fake.getMyActivityMutex.RLock()
+	defer fake.getMyActivityMutex.RUnlock()
+	argsForCall := fake.getMyActivityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetPersonalAccessTokens(arg1 *gin.Context) {
+	fake.getPersonalAccessTokensMutex.Lock()
+	fake.getPersonalAccessTokensArgsForCall = append(fake.getPersonalAccessTokensArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetPersonalAccessTokensStub
+	fake.recordInvocation("GetPersonalAccessTokens", []interface{}{arg1})
+	fake.getPersonalAccessTokensMutex.Unlock()
+	if stub != nil {
+		fake.GetPersonalAccessTokensStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetPersonalAccessTokensCallCount() int {
+	fake.getPersonalAccessTokensMutex.RLock()
+	defer fake.getPersonalAccessTokensMutex.RUnlock()
+	return len(fake.getPersonalAccessTokensArgsForCall)
+}
+
+func (fake *FakeServer) GetPersonalAccessTokensCalls(stub func(*gin.Context)) {
+	fake.getPersonalAccessTokensMutex.Lock()
+	defer fake.getPersonalAccessTokensMutex.Unlock()
+	fake.GetPersonalAccessTokensStub = stub
+}
+
+func (fake *FakeServer) GetPersonalAccessTokensArgsForCall(i int) *gin.Context {
+	fake.getPersonalAccessTokensMutex.RLock()
+	defer fake.getPersonalAccessTokensMutex.RUnlock()
+	argsForCall := fake.getPersonalAccessTokensArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetShares(arg1 *gin.Context) {
+	fake.getSharesMutex.Lock()
+	fake.getSharesArgsForCall = append(fake.getSharesArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetSharesStub
+	fake.recordInvocation("GetShares", []interface{}{arg1})
+	fake.getSharesMutex.Unlock()
+	if stub != nil {
+		fake.GetSharesStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetSharesCallCount() int {
+	fake.getSharesMutex.RLock()
+	defer fake.getSharesMutex.RUnlock()
+	return len(fake.getSharesArgsForCall)
+}
+
+func (fake *FakeServer) GetSharesCalls(stub func(*gin.Context)) {
+	fake.getSharesMutex.Lock()
+	defer fake.getSharesMutex.Unlock()
+	fake.GetSharesStub = stub
+}
+
+func (fake *FakeServer) GetSharesArgsForCall(i int) *gin.Context {
+	fake.getSharesMutex.RLock()
+	defer fake.getSharesMutex.RUnlock()
+	argsForCall := fake.getSharesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetSpendingReport(arg1 *gin.Context) {
+	fake.getSpendingReportMutex.Lock()
+	fake.getSpendingReportArgsForCall = append(fake.getSpendingReportArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetSpendingReportStub
+	fake.recordInvocation("GetSpendingReport", []interface{}{arg1})
+	fake.getSpendingReportMutex.Unlock()
+	if stub != nil {
+		fake.GetSpendingReportStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetSpendingReportCallCount() int {
+	fake.getSpendingReportMutex.RLock()
+	defer fake.getSpendingReportMutex.RUnlock()
+	return len(fake.getSpendingReportArgsForCall)
+}
+
+func (fake *FakeServer) GetSpendingReportCalls(stub func(*gin.Context)) {
+	fake.getSpendingReportMutex.Lock()
+	defer fake.getSpendingReportMutex.Unlock()
+	fake.GetSpendingReportStub = stub
+}
+
+func (fake *FakeServer) GetSpendingReportArgsForCall(i int) *gin.Context {
+	fake.getSpendingReportMutex.RLock()
+	defer fake.getSpendingReportMutex.RUnlock()
+	argsForCall := fake.getSpendingReportArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetTransaction(arg1 *gin.Context) {
+	fake.getTransactionMutex.Lock()
+	fake.getTransactionArgsForCall = append(fake.getTransactionArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetTransactionStub
+	fake.recordInvocation("GetTransaction", []interface{}{arg1})
+	fake.getTransactionMutex.Unlock()
+	if stub != nil {
+		fake.GetTransactionStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetTransactionCallCount() int {
+	fake.getTransactionMutex.RLock()
+	defer fake.getTransactionMutex.RUnlock()
+	return len(fake.getTransactionArgsForCall)
+}
+
+func (fake *FakeServer) GetTransactionCalls(stub func(*gin.Context)) {
+	fake.getTransactionMutex.Lock()
+	defer fake.getTransactionMutex.Unlock()
+	fake.GetTransactionStub = stub
+}
+
+func (fake *FakeServer) GetTransactionArgsForCall(i int) *gin.Context {
+	fake.getTransactionMutex.RLock()
+	defer fake.getTransactionMutex.RUnlock()
+	argsForCall := fake.getTransactionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetTransactions(arg1 *gin.Context) {
+	fake.getTransactionsMutex.Lock()
+	fake.getTransactionsArgsForCall = append(fake.getTransactionsArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetTransactionsStub
+	fake.recordInvocation("GetTransactions", []interface{}{arg1})
+	fake.getTransactionsMutex.Unlock()
+	if stub != nil {
+		fake.GetTransactionsStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetTransactionsCallCount() int {
+	fake.getTransactionsMutex.RLock()
+	defer fake.getTransactionsMutex.RUnlock()
+	return len(fake.getTransactionsArgsForCall)
+}
+
+func (fake *FakeServer) GetTransactionsCalls(stub func(*gin.Context)) {
+	fake.getTransactionsMutex.Lock()
+	defer fake.getTransactionsMutex.Unlock()
+	fake.GetTransactionsStub = stub
+}
+
+func (fake *FakeServer) GetTransactionsArgsForCall(i int) *gin.Context {
+	fake.getTransactionsMutex.RLock()
+	defer fake.getTransactionsMutex.RUnlock()
+	argsForCall := fake.getTransactionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetTransfers(arg1 *gin.Context) {
+	fake.getTransfersMutex.Lock()
+	fake.getTransfersArgsForCall = append(fake.getTransfersArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetTransfersStub
+	fake.recordInvocation("GetTransfers", []interface{}{arg1})
+	fake.getTransfersMutex.Unlock()
+	if stub != nil {
+		fake.GetTransfersStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetTransfersCallCount() int {
+	fake.getTransfersMutex.RLock()
+	defer fake.getTransfersMutex.RUnlock()
+	return len(fake.getTransfersArgsForCall)
+}
+
+func (fake *FakeServer) GetTransfersCalls(stub func(*gin.Context)) {
+	fake.getTransfersMutex.Lock()
+	defer fake.getTransfersMutex.Unlock()
+	fake.GetTransfersStub = stub
+}
+
+func (fake *FakeServer) GetTransfersArgsForCall(i int) *gin.Context {
+	fake.getTransfersMutex.RLock()
+	defer fake.getTransfersMutex.RUnlock()
+	argsForCall := fake.getTransfersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetUser(arg1 *gin.Context) {
+	fake.getUserMutex.Lock()
+	fake.getUserArgsForCall = append(fake.getUserArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetUserStub
+	fake.recordInvocation("GetUser", []interface{}{arg1})
+	fake.getUserMutex.Unlock()
+	if stub != nil {
+		fake.GetUserStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetUserCallCount() int {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return len(fake.getUserArgsForCall)
+}
+
+func (fake *FakeServer) GetUserCalls(stub func(*gin.Context)) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = stub
+}
+
+func (fake *FakeServer) GetUserArgsForCall(i int) *gin.Context {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	argsForCall := fake.getUserArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetUserItems(arg1 *gin.Context) {
+	fake.getUserItemsMutex.Lock()
+	fake.getUserItemsArgsForCall = append(fake.getUserItemsArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetUserItemsStub
+	fake.recordInvocation("GetUserItems", []interface{}{arg1})
+	fake.getUserItemsMutex.Unlock()
+	if stub != nil {
+		fake.GetUserItemsStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetUserItemsCallCount() int {
+	fake.getUserItemsMutex.RLock()
+	defer fake.getUserItemsMutex.RUnlock()
+	return len(fake.getUserItemsArgsForCall)
+}
+
+func (fake *FakeServer) GetUserItemsCalls(stub func(*gin.Context)) {
+	fake.getUserItemsMutex.Lock()
+	defer fake.getUserItemsMutex.Unlock()
+	fake.GetUserItemsStub = stub
+}
+
+func (fake *FakeServer) GetUserItemsArgsForCall(i int) *gin.Context {
+	fake.getUserItemsMutex.RLock()
+	defer fake.getUserItemsMutex.RUnlock()
+	argsForCall := fake.getUserItemsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetUserWebhookDeliveries(arg1 *gin.Context) {
+	fake.getUserWebhookDeliveriesMutex.Lock()
+	fake.getUserWebhookDeliveriesArgsForCall = append(fake.getUserWebhookDeliveriesArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetUserWebhookDeliveriesStub
+	fake.recordInvocation("GetUserWebhookDeliveries", []interface{}{arg1})
+	fake.getUserWebhookDeliveriesMutex.Unlock()
+	if stub != nil {
+		fake.GetUserWebhookDeliveriesStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetUserWebhookDeliveriesCallCount() int {
+	fake.getUserWebhookDeliveriesMutex.RLock()
+	defer fake.getUserWebhookDeliveriesMutex.RUnlock()
+	return len(fake.getUserWebhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeServer) GetUserWebhookDeliveriesCalls(stub func(*gin.Context)) {
+	fake.getUserWebhookDeliveriesMutex.Lock()
+	defer fake.getUserWebhookDeliveriesMutex.Unlock()
+	fake.GetUserWebhookDeliveriesStub = stub
+}
+
+func (fake *FakeServer) GetUserWebhookDeliveriesArgsForCall(i int) *gin.Context {
+	fake.getUserWebhookDeliveriesMutex.RLock()
+	defer fake.getUserWebhookDeliveriesMutex.RUnlock()
+	argsForCall := fake.getUserWebhookDeliveriesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetUsers(arg1 *gin.Context) {
+	fake.getUsersMutex.Lock()
+	fake.getUsersArgsForCall = append(fake.getUsersArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetUsersStub
+	fake.recordInvocation("GetUsers", []interface{}{arg1})
+	fake.getUsersMutex.Unlock()
+	if stub != nil {
+		fake.GetUsersStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetUsersCallCount() int {
+	fake.getUsersMutex.RLock()
+	defer fake.getUsersMutex.RUnlock()
+	return len(fake.getUsersArgsForCall)
+}
+
+func (fake *FakeServer) GetUsersCalls(stub func(*gin.Context)) {
+	fake.getUsersMutex.Lock()
+	defer fake.getUsersMutex.Unlock()
+	fake.GetUsersStub = stub
+}
+
+func (fake *FakeServer) GetUsersArgsForCall(i int) *gin.Context {
+	fake.getUsersMutex.RLock()
+	defer fake.getUsersMutex.RUnlock()
+	argsForCall := fake.getUsersArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetWebhookDeliveries(arg1 *gin.Context) {
+	fake.getWebhookDeliveriesMutex.Lock()
+	fake.getWebhookDeliveriesArgsForCall = append(fake.getWebhookDeliveriesArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetWebhookDeliveriesStub
+	fake.recordInvocation("GetWebhookDeliveries", []interface{}{arg1})
+	fake.getWebhookDeliveriesMutex.Unlock()
+	if stub != nil {
+		fake.GetWebhookDeliveriesStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetWebhookDeliveriesCallCount() int {
+	fake.getWebhookDeliveriesMutex.RLock()
+	defer fake.getWebhookDeliveriesMutex.RUnlock()
+	return len(fake.getWebhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeServer) GetWebhookDeliveriesCalls(stub func(*gin.Context)) {
+	fake.getWebhookDeliveriesMutex.Lock()
+	defer fake.getWebhookDeliveriesMutex.Unlock()
+	fake.GetWebhookDeliveriesStub = stub
+}
+
+func (fake *FakeServer) GetWebhookDeliveriesArgsForCall(i int) *gin.Context {
+	fake.getWebhookDeliveriesMutex.RLock()
+	defer fake.getWebhookDeliveriesMutex.RUnlock()
+	argsForCall := fake.getWebhookDeliveriesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GetWebhookEndpoints(arg1 *gin.Context) {
+	fake.getWebhookEndpointsMutex.Lock()
+	fake.getWebhookEndpointsArgsForCall = append(fake.getWebhookEndpointsArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GetWebhookEndpointsStub
+	fake.recordInvocation("GetWebhookEndpoints", []interface{}{arg1})
+	fake.getWebhookEndpointsMutex.Unlock()
+	if stub != nil {
+		fake.GetWebhookEndpointsStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GetWebhookEndpointsCallCount() int {
+	fake.getWebhookEndpointsMutex.RLock()
+	defer fake.getWebhookEndpointsMutex.RUnlock()
+	return len(fake.getWebhookEndpointsArgsForCall)
+}
+
+func (fake *FakeServer) GetWebhookEndpointsCalls(stub func(*gin.Context)) {
+	fake.getWebhookEndpointsMutex.Lock()
+	defer fake.getWebhookEndpointsMutex.Unlock()
+	fake.GetWebhookEndpointsStub = stub
+}
+
+func (fake *FakeServer) GetWebhookEndpointsArgsForCall(i int) *gin.Context {
+	fake.getWebhookEndpointsMutex.RLock()
+	defer fake.getWebhookEndpointsMutex.RUnlock()
+	argsForCall := fake.getWebhookEndpointsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) GraphQL(arg1 *gin.Context) {
+	fake.graphQLMutex.Lock()
+	fake.graphQLArgsForCall = append(fake.graphQLArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.GraphQLStub
+	fake.recordInvocation("GraphQL", []interface{}{arg1})
+	fake.graphQLMutex.Unlock()
+	if stub != nil {
+		fake.GraphQLStub(arg1)
+	}
+}
+
+func (fake *FakeServer) GraphQLCallCount() int {
+	fake.graphQLMutex.RLock()
+	defer fake.graphQLMutex.RUnlock()
+	return len(fake.graphQLArgsForCall)
+}
+
+func (fake *FakeServer) GraphQLCalls(stub func(*gin.Context)) {
+	fake.graphQLMutex.Lock()
+	defer fake.graphQLMutex.Unlock()
+	fake.GraphQLStub = stub
+}
+
+func (fake *FakeServer) GraphQLArgsForCall(i int) *gin.Context {
+	fake.graphQLMutex.RLock()
+	defer fake.graphQLMutex.RUnlock()
+	argsForCall := fake.graphQLArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) ImportStatement(arg1 *gin.Context) {
+	fake.importStatementMutex.Lock()
+	fake.importStatementArgsForCall = append(fake.importStatementArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.ImportStatementStub
+	fake.recordInvocation("ImportStatement", []interface{}{arg1})
+	fake.importStatementMutex.Unlock()
+	if stub != nil {
+		fake.ImportStatementStub(arg1)
+	}
+}
+
+func (fake *FakeServer) ImportStatementCallCount() int {
+	fake.importStatementMutex.RLock()
+	defer fake.importStatementMutex.RUnlock()
+	return len(fake.importStatementArgsForCall)
+}
+
+func (fake *FakeServer) ImportStatementCalls(stub func(*gin.Context)) {
+	fake.importStatementMutex.Lock()
+	defer fake.importStatementMutex.Unlock()
+	fake.ImportStatementStub = stub
+}
+
+func (fake *FakeServer) ImportStatementArgsForCall(i int) *gin.Context {
+	fake.importStatementMutex.RLock()
+	defer fake.importStatementMutex.RUnlock()
+	argsForCall := fake.importStatementArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) Login(arg1 *gin.Context) {
+	fake.loginMutex.Lock()
+	fake.loginArgsForCall = append(fake.loginArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.LoginStub
+	fake.recordInvocation("Login", []interface{}{arg1})
+	fake.loginMutex.Unlock()
+	if stub != nil {
+		fake.LoginStub(arg1)
+	}
+}
+
+func (fake *FakeServer) LoginCallCount() int {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	return len(fake.loginArgsForCall)
+}
+
+func (fake *FakeServer) LoginCalls(stub func(*gin.Context)) {
+	fake.loginMutex.Lock()
+	defer fake.loginMutex.Unlock()
+	fake.LoginStub = stub
+}
+
+func (fake *FakeServer) LoginArgsForCall(i int) *gin.Context {
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	argsForCall := fake.loginArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) OIDCCallback(arg1 *gin.Context) {
+	fake.oIDCCallbackMutex.Lock()
+	fake.oIDCCallbackArgsForCall = append(fake.oIDCCallbackArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.OIDCCallbackStub
+	fake.recordInvocation("OIDCCallback", []interface{}{arg1})
+	fake.oIDCCallbackMutex.Unlock()
+	if stub != nil {
+		fake.OIDCCallbackStub(arg1)
+	}
+}
+
+func (fake *FakeServer) OIDCCallbackCallCount() int {
+	fake.oIDCCallbackMutex.RLock()
+	defer fake.oIDCCallbackMutex.RUnlock()
+	return len(fake.oIDCCallbackArgsForCall)
+}
+
+func (fake *FakeServer) OIDCCallbackCalls(stub func(*gin.Context)) {
+	fake.oIDCCallbackMutex.Lock()
+	defer fake.oIDCCallbackMutex.Unlock()
+	fake.OIDCCallbackStub = stub
+}
+
+func (fake *FakeServer) OIDCCallbackArgsForCall(i int) *gin.Context {
+	fake.oIDCCallbackMutex.RLock()
+	defer fake.oIDCCallbackMutex.RUnlock()
+	argsForCall := fake.oIDCCallbackArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) ReactivateUser(arg1 *gin.Context) {
+	fake.reactivateUserMutex.Lock()
+	fake.reactivateUserArgsForCall = append(fake.reactivateUserArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.ReactivateUserStub
+	fake.recordInvocation("ReactivateUser", []interface{}{arg1})
+	fake.reactivateUserMutex.Unlock()
+	if stub != nil {
+		fake.ReactivateUserStub(arg1)
+	}
+}
+
+func (fake *FakeServer) ReactivateUserCallCount() int {
+	fake.reactivateUserMutex.RLock()
+	defer fake.reactivateUserMutex.RUnlock()
+	return len(fake.reactivateUserArgsForCall)
+}
+
+func (fake *FakeServer) ReactivateUserCalls(stub func(*gin.Context)) {
+	fake.reactivateUserMutex.Lock()
+	defer fake.reactivateUserMutex.Unlock()
+	fake.ReactivateUserStub = stub
+}
+
+func (fake *FakeServer) ReactivateUserArgsForCall(i int) *gin.Context {
+	fake.reactivateUserMutex.RLock()
+	defer fake.reactivateUserMutex.RUnlock()
+	argsForCall := fake.reactivateUserArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) RedeliverWebhook(arg1 *gin.Context) {
+	fake.redeliverWebhookMutex.Lock()
+	fake.redeliverWebhookArgsForCall = append(fake.redeliverWebhookArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.RedeliverWebhookStub
+	fake.recordInvocation("RedeliverWebhook", []interface{}{arg1})
+	fake.redeliverWebhookMutex.Unlock()
+	if stub != nil {
+		fake.RedeliverWebhookStub(arg1)
+	}
+}
+
+func (fake *FakeServer) RedeliverWebhookCallCount() int {
+	fake.redeliverWebhookMutex.RLock()
+	defer fake.redeliverWebhookMutex.RUnlock()
+	return len(fake.redeliverWebhookArgsForCall)
+}
+
+func (fake *FakeServer) RedeliverWebhookCalls(stub func(*gin.Context)) {
+	fake.redeliverWebhookMutex.Lock()
+	defer fake.redeliverWebhookMutex.Unlock()
+	fake.RedeliverWebhookStub = stub
+}
+
+func (fake *FakeServer) RedeliverWebhookArgsForCall(i int) *gin.Context {
+	fake.redeliverWebhookMutex.RLock()
+	defer fake.redeliverWebhookMutex.RUnlock()
+	argsForCall := fake.redeliverWebhookArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) RefreshItem(arg1 *gin.Context) {
+	fake.refreshItemMutex.Lock()
+	fake.refreshItemArgsForCall = append(fake.refreshItemArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.RefreshItemStub
+	fake.recordInvocation("RefreshItem", []interface{}{arg1})
+	fake.refreshItemMutex.Unlock()
+	if stub != nil {
+		fake.RefreshItemStub(arg1)
+	}
+}
+
+func (fake *FakeServer) RefreshItemCallCount() int {
+	fake.refreshItemMutex.RLock()
+	defer fake.refreshItemMutex.RUnlock()
+	return len(fake.refreshItemArgsForCall)
+}
+
+func (fake *FakeServer) RefreshItemCalls(stub func(*gin.Context)) {
+	fake.refreshItemMutex.Lock()
+	defer fake.refreshItemMutex.Unlock()
+	fake.RefreshItemStub = stub
+}
+
+func (fake *FakeServer) RefreshItemArgsForCall(i int) *gin.Context {
+	fake.refreshItemMutex.RLock()
+	defer fake.refreshItemMutex.RUnlock()
+	argsForCall := fake.refreshItemArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) RegisterUser(arg1 *gin.Context) {
+	fake.registerUserMutex.Lock()
+	fake.registerUserArgsForCall = append(fake.registerUserArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.RegisterUserStub
+	fake.recordInvocation("RegisterUser", []interface{}{arg1})
+	fake.registerUserMutex.Unlock()
+	if stub != nil {
+		fake.RegisterUserStub(arg1)
+	}
+}
+
+func (fake *FakeServer) RegisterUserCallCount() int {
+	fake.registerUserMutex.RLock()
+	defer fake.registerUserMutex.RUnlock()
+	return len(fake.registerUserArgsForCall)
+}
+
+func (fake *FakeServer) RegisterUserCalls(stub func(*gin.Context)) {
+	fake.registerUserMutex.Lock()
+	defer fake.registerUserMutex.Unlock()
+	fake.RegisterUserStub = stub
+}
+
+func (fake *FakeServer) RegisterUserArgsForCall(i int) *gin.Context {
+	fake.registerUserMutex.RLock()
+	defer fake.registerUserMutex.RUnlock()
+	argsForCall := fake.registerUserArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) RejectTransfer(arg1 *gin.Context) {
+	fake.rejectTransferMutex.Lock()
+	fake.rejectTransferArgsForCall = append(fake.rejectTransferArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.RejectTransferStub
+	fake.recordInvocation("RejectTransfer", []interface{}{arg1})
+	fake.rejectTransferMutex.Unlock()
+	if stub != nil {
+		fake.RejectTransferStub(arg1)
+	}
+}
+
+func (fake *FakeServer) RejectTransferCallCount() int {
+	fake.rejectTransferMutex.RLock()
+	defer fake.rejectTransferMutex.RUnlock()
+	return len(fake.rejectTransferArgsForCall)
+}
+
+func (fake *FakeServer) RejectTransferCalls(stub func(*gin.Context)) {
+	fake.rejectTransferMutex.Lock()
+	defer fake.rejectTransferMutex.Unlock()
+	fake.RejectTransferStub = stub
+}
+
+func (fake *FakeServer) RejectTransferArgsForCall(i int) *gin.Context {
+	fake.rejectTransferMutex.RLock()
+	defer fake.rejectTransferMutex.RUnlock()
+	argsForCall := fake.rejectTransferArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) RevokePersonalAccessToken(arg1 *gin.Context) {
+	fake.revokePersonalAccessTokenMutex.Lock()
+	fake.revokePersonalAccessTokenArgsForCall = append(fake.revokePersonalAccessTokenArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.RevokePersonalAccessTokenStub
+	fake.recordInvocation("RevokePersonalAccessToken", []interface{}{arg1})
+	fake.revokePersonalAccessTokenMutex.Unlock()
+	if stub != nil {
+		fake.RevokePersonalAccessTokenStub(arg1)
+	}
+}
+
+func (fake *FakeServer) RevokePersonalAccessTokenCallCount() int {
+	fake.revokePersonalAccessTokenMutex.RLock()
+	defer fake.revokePersonalAccessTokenMutex.RUnlock()
+	return len(fake.revokePersonalAccessTokenArgsForCall)
+}
+
+func (fake *FakeServer) RevokePersonalAccessTokenCalls(stub func(*gin.Context)) {
+	fake.revokePersonalAccessTokenMutex.Lock()
+	defer fake.revokePersonalAccessTokenMutex.Unlock()
+	fake.RevokePersonalAccessTokenStub = stub
+}
+
+func (fake *FakeServer) RevokePersonalAccessTokenArgsForCall(i int) *gin.Context {
+	fake.revokePersonalAccessTokenMutex.RLock()
+	defer fake.revokePersonalAccessTokenMutex.RUnlock()
+	argsForCall := fake.revokePersonalAccessTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) RevokeShare(arg1 *gin.Context) {
+	fake.revokeShareMutex.Lock()
+	fake.revokeShareArgsForCall = append(fake.revokeShareArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.RevokeShareStub
+	fake.recordInvocation("RevokeShare", []interface{}{arg1})
+	fake.revokeShareMutex.Unlock()
+	if stub != nil {
+		fake.RevokeShareStub(arg1)
+	}
+}
+
+func (fake *FakeServer) RevokeShareCallCount() int {
+	fake.revokeShareMutex.RLock()
+	defer fake.revokeShareMutex.RUnlock()
+	return len(fake.revokeShareArgsForCall)
+}
+
+func (fake *FakeServer) RevokeShareCalls(stub func(*gin.Context)) {
+	fake.revokeShareMutex.Lock()
+	defer fake.revokeShareMutex.Unlock()
+	fake.RevokeShareStub = stub
+}
+
+func (fake *FakeServer) RevokeShareArgsForCall(i int) *gin.Context {
+	fake.revokeShareMutex.RLock()
+	defer fake.revokeShareMutex.RUnlock()
+	argsForCall := fake.revokeShareArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) ServeOpenAPI(arg1 *gin.Context) {
+	fake.serveOpenAPIMutex.Lock()
+	fake.serveOpenAPIArgsForCall = append(fake.serveOpenAPIArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.ServeOpenAPIStub
+	fake.recordInvocation("ServeOpenAPI", []interface{}{arg1})
+	fake.serveOpenAPIMutex.Unlock()
+	if stub != nil {
+		fake.ServeOpenAPIStub(arg1)
+	}
+}
+
+func (fake *FakeServer) ServeOpenAPICallCount() int {
+	fake.serveOpenAPIMutex.RLock()
+	defer fake.serveOpenAPIMutex.RUnlock()
+	return len(fake.serveOpenAPIArgsForCall)
+}
+
+func (fake *FakeServer) ServeOpenAPICalls(stub func(*gin.Context)) {
+	fake.serveOpenAPIMutex.Lock()
+	defer fake.serveOpenAPIMutex.Unlock()
+	fake.ServeOpenAPIStub = stub
+}
+
+func (fake *FakeServer) ServeOpenAPIArgsForCall(i int) *gin.Context {
+	fake.serveOpenAPIMutex.RLock()
+	defer fake.serveOpenAPIMutex.RUnlock()
+	argsForCall := fake.serveOpenAPIArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) ServeSPA(arg1 *gin.Context) {
+	fake.serveSPAMutex.Lock()
+	fake.serveSPAArgsForCall = append(fake.serveSPAArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.ServeSPAStub
+	fake.recordInvocation("ServeSPA", []interface{}{arg1})
+	fake.serveSPAMutex.Unlock()
+	if stub != nil {
+		fake.ServeSPAStub(arg1)
+	}
+}
+
+func (fake *FakeServer) ServeSPACallCount() int {
+	fake.serveSPAMutex.RLock()
+	defer fake.serveSPAMutex.RUnlock()
+	return len(fake.serveSPAArgsForCall)
+}
+
+func (fake *FakeServer) ServeSPACalls(stub func(*gin.Context)) {
+	fake.serveSPAMutex.Lock()
+	defer fake.serveSPAMutex.Unlock()
+	fake.ServeSPAStub = stub
+}
+
+func (fake *FakeServer) ServeSPAArgsForCall(i int) *gin.Context {
+	fake.serveSPAMutex.RLock()
+	defer fake.serveSPAMutex.RUnlock()
+	argsForCall := fake.serveSPAArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) SetUserRole(arg1 *gin.Context) {
+	fake.setUserRoleMutex.Lock()
+	fake.setUserRoleArgsForCall = append(fake.setUserRoleArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.SetUserRoleStub
+	fake.recordInvocation("SetUserRole", []interface{}{arg1})
+	fake.setUserRoleMutex.Unlock()
+	if stub != nil {
+		fake.SetUserRoleStub(arg1)
+	}
+}
+
+func (fake *FakeServer) SetUserRoleCallCount() int {
+	fake.setUserRoleMutex.RLock()
+	defer fake.setUserRoleMutex.RUnlock()
+	return len(fake.setUserRoleArgsForCall)
+}
+
+func (fake *FakeServer) SetUserRoleCalls(stub func(*gin.Context)) {
+	fake.setUserRoleMutex.Lock()
+	defer fake.setUserRoleMutex.Unlock()
+	fake.SetUserRoleStub = stub
+}
+
+func (fake *FakeServer) SetUserRoleArgsForCall(i int) *gin.Context {
+	fake.setUserRoleMutex.RLock()
+	defer fake.setUserRoleMutex.RUnlock()
+	argsForCall := fake.setUserRoleArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) SplitTransaction(arg1 *gin.Context) {
+	fake.splitTransactionMutex.Lock()
+	fake.splitTransactionArgsForCall = append(fake.splitTransactionArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.SplitTransactionStub
+	fake.recordInvocation("SplitTransaction", []interface{}{arg1})
+	fake.splitTransactionMutex.Unlock()
+	if stub != nil {
+		fake.SplitTransactionStub(arg1)
+	}
+}
+
+func (fake *FakeServer) SplitTransactionCallCount() int {
+	fake.splitTransactionMutex.RLock()
+	defer fake.splitTransactionMutex.RUnlock()
+	return len(fake.splitTransactionArgsForCall)
+}
+
+func (fake *FakeServer) SplitTransactionCalls(stub func(*gin.Context)) {
+	fake.splitTransactionMutex.Lock()
+	defer fake.splitTransactionMutex.Unlock()
+	fake.SplitTransactionStub = stub
+}
+
+func (fake *FakeServer) SplitTransactionArgsForCall(i int) *gin.Context {
+	fake.splitTransactionMutex.RLock()
+	defer fake.splitTransactionMutex.RUnlock()
+	argsForCall := fake.splitTransactionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) UpdateAccount(arg1 *gin.Context) {
+	fake.updateAccountMutex.Lock()
+	fake.updateAccountArgsForCall = append(fake.updateAccountArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.UpdateAccountStub
+	fake.recordInvocation("UpdateAccount", []interface{}{arg1})
+	fake.updateAccountMutex.Unlock()
+	if stub != nil {
+		fake.UpdateAccountStub(arg1)
+	}
+}
+
+func (fake *FakeServer) UpdateAccountCallCount() int {
+	fake.updateAccountMutex.RLock()
+	defer fake.updateAccountMutex.RUnlock()
+	return len(fake.updateAccountArgsForCall)
+}
+
+func (fake *FakeServer) UpdateAccountCalls(stub func(*gin.Context)) {
+	fake.updateAccountMutex.Lock()
+	defer fake.updateAccountMutex.Unlock()
+	fake.UpdateAccountStub = stub
+}
+
+func (fake *FakeServer) UpdateAccountArgsForCall(i int) *gin.Context {
+	fake.updateAccountMutex.RLock()
+	defer fake.updateAccountMutex.RUnlock()
+	argsForCall := fake.updateAccountArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) UpdateDigestPreferences(arg1 *gin.Context) {
+	fake.updateDigestPreferencesMutex.Lock()
+	fake.updateDigestPreferencesArgsForCall = append(fake.updateDigestPreferencesArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.UpdateDigestPreferencesStub
+	fake.recordInvocation("UpdateDigestPreferences", []interface{}{arg1})
+	fake.updateDigestPreferencesMutex.Unlock()
+	if stub != nil {
+		fake.UpdateDigestPreferencesStub(arg1)
+	}
+}
+
+func (fake *FakeServer) UpdateDigestPreferencesCallCount() int {
+	fake.updateDigestPreferencesMutex.RLock()
+	defer fake.updateDigestPreferencesMutex.RUnlock()
+	return len(fake.updateDigestPreferencesArgsForCall)
+}
+
+func (fake *FakeServer) UpdateDigestPreferencesCalls(stub func(*gin.Context)) {
+	fake.updateDigestPreferencesMutex.Lock()
+	defer fake.updateDigestPreferencesMutex.Unlock()
+	fake.UpdateDigestPreferencesStub = stub
+}
+
+func (fake *FakeServer) UpdateDigestPreferencesArgsForCall(i int) *gin.Context {
+	fake.updateDigestPreferencesMutex.RLock()
+	defer fake.updateDigestPreferencesMutex.RUnlock()
+	argsForCall := fake.updateDigestPreferencesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) UpdateTransaction(arg1 *gin.Context) {
+	fake.updateTransactionMutex.Lock()
+	fake.updateTransactionArgsForCall = append(fake.updateTransactionArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.UpdateTransactionStub
+	fake.recordInvocation("UpdateTransaction", []interface{}{arg1})
+	fake.updateTransactionMutex.Unlock()
+	if stub != nil {
+		fake.UpdateTransactionStub(arg1)
+	}
+}
+
+func (fake *FakeServer) UpdateTransactionCallCount() int {
+	fake.updateTransactionMutex.RLock()
+	defer fake.updateTransactionMutex.RUnlock()
+	return len(fake.updateTransactionArgsForCall)
+}
+
+func (fake *FakeServer) UpdateTransactionCalls(stub func(*gin.Context)) {
+	fake.updateTransactionMutex.Lock()
+	defer fake.updateTransactionMutex.Unlock()
+	fake.UpdateTransactionStub = stub
+}
+
+func (fake *FakeServer) UpdateTransactionArgsForCall(i int) *gin.Context {
+	fake.updateTransactionMutex.RLock()
+	defer fake.updateTransactionMutex.RUnlock()
+	argsForCall := fake.updateTransactionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) UpdateUser(arg1 *gin.Context) {
+	fake.updateUserMutex.Lock()
+	fake.updateUserArgsForCall = append(fake.updateUserArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.UpdateUserStub
+	fake.recordInvocation("UpdateUser", []interface{}{arg1})
+	fake.updateUserMutex.Unlock()
+	if stub != nil {
+		fake.UpdateUserStub(arg1)
+	}
+}
+
+func (fake *FakeServer) UpdateUserCallCount() int {
+	fake.updateUserMutex.RLock()
+	defer fake.updateUserMutex.RUnlock()
+	return len(fake.updateUserArgsForCall)
+}
+
+func (fake *FakeServer) UpdateUserCalls(stub func(*gin.Context)) {
+	fake.updateUserMutex.Lock()
+	defer fake.updateUserMutex.Unlock()
+	fake.UpdateUserStub = stub
+}
+
+func (fake *FakeServer) UpdateUserArgsForCall(i int) *gin.Context {
+	fake.updateUserMutex.RLock()
+	defer fake.updateUserMutex.RUnlock()
+	argsForCall := fake.updateUserArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) UploadAttachment(arg1 *gin.Context) {
+	fake.uploadAttachmentMutex.Lock()
+	fake.uploadAttachmentArgsForCall = append(fake.uploadAttachmentArgsForCall, struct {
+		arg1 *gin.Context
+	}{arg1})
+	stub := fake.UploadAttachmentStub
+	fake.recordInvocation("UploadAttachment", []interface{}{arg1})
+	fake.uploadAttachmentMutex.Unlock()
+	if stub != nil {
+		fake.UploadAttachmentStub(arg1)
+	}
+}
+
+func (fake *FakeServer) UploadAttachmentCallCount() int {
+	fake.uploadAttachmentMutex.RLock()
+	defer fake.uploadAttachmentMutex.RUnlock()
+	return len(fake.uploadAttachmentArgsForCall)
+}
+
+func (fake *FakeServer) UploadAttachmentCalls(stub func(*gin.Context)) {
+	fake.uploadAttachmentMutex.Lock()
+	defer fake.uploadAttachmentMutex.Unlock()
+	fake.UploadAttachmentStub = stub
+}
+
+func (fake *FakeServer) UploadAttachmentArgsForCall(i int) *gin.Context {
+	fake.uploadAttachmentMutex.RLock()
+	defer fake.uploadAttachmentMutex.RUnlock()
+	argsForCall := fake.uploadAttachmentArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeServer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.acceptShareMutex.RLock()
+	defer fake.acceptShareMutex.RUnlock()
+	fake.addPlaidItemMutex.RLock()
+	defer fake.addPlaidItemMutex.RUnlock()
+	fake.backendAuthorizationMiddlewareMutex.RLock()
+	defer fake.backendAuthorizationMiddlewareMutex.RUnlock()
+	fake.confirmTransferMutex.RLock()
+	defer fake.confirmTransferMutex.RUnlock()
+	fake.createAlertMutex.RLock()
+	defer fake.createAlertMutex.RUnlock()
+	fake.createManualAccountMutex.RLock()
+	defer fake.createManualAccountMutex.RUnlock()
+	fake.createPersonalAccessTokenMutex.RLock()
+	defer fake.createPersonalAccessTokenMutex.RUnlock()
+	fake.createShareMutex.RLock()
+	defer fake.createShareMutex.RUnlock()
+	fake.createWebhookEndpointMutex.RLock()
+	defer fake.createWebhookEndpointMutex.RUnlock()
+	fake.deactivateUserMutex.RLock()
+	defer fake.deactivateUserMutex.RUnlock()
+	fake.deleteAccountMutex.RLock()
+	defer fake.deleteAccountMutex.RUnlock()
+	fake.deleteAlertMutex.RLock()
+	defer fake.deleteAlertMutex.RUnlock()
+	fake.deleteAttachmentMutex.RLock()
+	defer fake.deleteAttachmentMutex.RUnlock()
+	fake.deleteItemMutex.RLock()
+	defer fake.deleteItemMutex.RUnlock()
+	fake.deleteMeMutex.RLock()
+	defer fake.deleteMeMutex.RUnlock()
+	fake.deleteUserMutex.RLock()
+	defer fake.deleteUserMutex.RUnlock()
+	fake.deleteWebhookEndpointMutex.RLock()
+	defer fake.deleteWebhookEndpointMutex.RUnlock()
+	fake.detectTransfersMutex.RLock()
+	defer fake.detectTransfersMutex.RUnlock()
+	fake.downloadAttachmentMutex.RLock()
+	defer fake.downloadAttachmentMutex.RUnlock()
+	fake.exportLedgerMutex.RLock()
+	defer fake.exportLedgerMutex.RUnlock()
+	fake.exportMeMutex.RLock()
+	defer fake.exportMeMutex.RUnlock()
+	fake.exportStatementMutex.RLock()
+	defer fake.exportStatementMutex.RUnlock()
+	fake.frontendAuthorizationMiddlewareMutex.RLock()
+	defer fake.frontendAuthorizationMiddlewareMutex.RUnlock()
+	fake.genericPlaidWebhookMutex.RLock()
+	defer fake.genericPlaidWebhookMutex.RUnlock()
+	fake.getAccountMutex.RLock()
+	defer fake.getAccountMutex.RUnlock()
+	fake.getAccountsMutex.RLock()
+	defer fake.getAccountsMutex.RUnlock()
+	fake.getAlertDeliveriesMutex.RLock()
+	defer fake.getAlertDeliveriesMutex.RUnlock()
+	fake.getAlertsMutex.RLock()
+	defer fake.getAlertsMutex.RUnlock()
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	fake.getCashflowReportMutex.RLock()
+	defer fake.getCashflowReportMutex.RUnlock()
+	fake.getDigestPreferencesMutex.RLock()
+	defer fake.getDigestPreferencesMutex.RUnlock()
+	fake.getForecastMutex.RLock()
+	defer fake.getForecastMutex.RUnlock()
+	fake.getGraphQLSchemaMutex.RLock()
+	defer fake.getGraphQLSchemaMutex.RUnlock()
+	fake.getItemMutex.RLock()
+	defer fake.getItemMutex.RUnlock()
+	fake.getItemsMutex.RLock()
+	defer fake.getItemsMutex.RUnlock()
+	fake.getJobMutex.RLock()
+	defer fake.getJobMutex.RUnlock()
+	fake.getJobRunsMutex.RLock()
+	defer fake.getJobRunsMutex.RUnlock()
+	fake.getJobsMutex.RLock()
+	defer fake.getJobsMutex.RUnlock()
+	fake.getMyActivityMutex.RLock()
+	defer fake.getMyActivityMutex.RUnlock()
+	fake.getPersonalAccessTokensMutex.RLock()
+	defer fake.getPersonalAccessTokensMutex.RUnlock()
+	fake.getSharesMutex.RLock()
+	defer fake.getSharesMutex.RUnlock()
+	fake.getSpendingReportMutex.RLock()
+	defer fake.getSpendingReportMutex.RUnlock()
+	fake.getTransactionMutex.RLock()
+	defer fake.getTransactionMutex.RUnlock()
+	fake.getTransactionsMutex.RLock()
+	defer fake.getTransactionsMutex.RUnlock()
+	fake.getTransfersMutex.RLock()
+	defer fake.getTransfersMutex.RUnlock()
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	fake.getUserItemsMutex.RLock()
+	defer fake.getUserItemsMutex.RUnlock()
+	fake.getUserWebhookDeliveriesMutex.RLock()
+	defer fake.getUserWebhookDeliveriesMutex.RUnlock()
+	fake.getUsersMutex.RLock()
+	defer fake.getUsersMutex.RUnlock()
+	fake.getWebhookDeliveriesMutex.RLock()
+	defer fake.getWebhookDeliveriesMutex.RUnlock()
+	fake.getWebhookEndpointsMutex.RLock()
+	defer fake.getWebhookEndpointsMutex.RUnlock()
+	fake.graphQLMutex.RLock()
+	defer fake.graphQLMutex.RUnlock()
+	fake.importStatementMutex.RLock()
+	defer fake.importStatementMutex.RUnlock()
+	fake.loginMutex.RLock()
+	defer fake.loginMutex.RUnlock()
+	fake.oIDCCallbackMutex.RLock()
+	defer fake.oIDCCallbackMutex.RUnlock()
+	fake.reactivateUserMutex.RLock()
+	defer fake.reactivateUserMutex.RUnlock()
+	fake.redeliverWebhookMutex.RLock()
+	defer fake.redeliverWebhookMutex.RUnlock()
+	fake.refreshItemMutex.RLock()
+	defer fake.refreshItemMutex.RUnlock()
+	fake.registerUserMutex.RLock()
+	defer fake.registerUserMutex.RUnlock()
+	fake.rejectTransferMutex.RLock()
+	defer fake.rejectTransferMutex.RUnlock()
+	fake.revokePersonalAccessTokenMutex.RLock()
+	defer fake.revokePersonalAccessTokenMutex.RUnlock()
+	fake.revokeShareMutex.RLock()
+	defer fake.revokeShareMutex.RUnlock()
+	fake.serveOpenAPIMutex.RLock()
+	defer fake.serveOpenAPIMutex.RUnlock()
+	fake.serveSPAMutex.RLock()
+	defer fake.serveSPAMutex.RUnlock()
+	fake.setUserRoleMutex.RLock()
+	defer fake.setUserRoleMutex.RUnlock()
+	fake.splitTransactionMutex.RLock()
+	defer fake.splitTransactionMutex.RUnlock()
+	fake.updateAccountMutex.RLock()
+	defer fake.updateAccountMutex.RUnlock()
+	fake.updateDigestPreferencesMutex.RLock()
+	defer fake.updateDigestPreferencesMutex.RUnlock()
+	fake.updateTransactionMutex.RLock()
+	defer fake.updateTransactionMutex.RUnlock()
+	fake.updateUserMutex.RLock()
+	defer fake.updateUserMutex.RUnlock()
+	fake.uploadAttachmentMutex.RLock()
+	defer fake.uploadAttachmentMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeServer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ server.Server = new(FakeServer)
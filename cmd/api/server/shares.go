@@ -0,0 +1,191 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+//ShareRequest invites someone by email to one of the user's accounts, or
+//to every account of one of their items
+type ShareRequest struct {
+	Email       string        `json:"email" binding:"required"`
+	AccountUUID string        `json:"account_id"`
+	ItemUUID    string        `json:"item_id"`
+	Level       db.ShareLevel `json:"level" binding:"required"`
+}
+
+//CreateShare invites another user to see, or also annotate, one of the
+//user's accounts or items. The share has no effect until they accept it.
+//The response is the same whether or not anyone has registered with the
+//email, so that it can't be used to find out who has.
+func (a ServerAgent) CreateShare(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var req ShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if (req.AccountUUID == "") == (req.ItemUUID == "") {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "exactly one of account_id and item_id is required"})
+		return
+	}
+	if req.Level != db.ShareLevelRead && req.Level != db.ShareLevelEdit {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "level must be `read` or `edit`"})
+		return
+	}
+	for _, id := range []string{req.AccountUUID, req.ItemUUID} {
+		if id != "" && !db.ValidUUID(id) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "`" + id + "` isn't a valid ID"})
+			return
+		}
+	}
+
+	uuid, err := a.dbClient.CreateShare(c, db.Share{
+		OwnerUUID:    auth.UserUUID,
+		GranteeEmail: req.Email,
+		AccountUUID:  req.AccountUUID,
+		ItemUUID:     req.ItemUUID,
+		Level:        req.Level,
+	})
+	switch err {
+	case nil:
+	case db.ErrNoSuchAccount:
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such account"})
+		return
+	case db.ErrNoSuchItem:
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such item"})
+		return
+	case db.ErrNoSuchUser:
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "you can't share with yourself"})
+		return
+	case db.ErrShareExists:
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	default:
+		a.logger.Errorf("failed creating share for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	share, err := a.dbClient.GetShare(c, auth.UserUUID, uuid)
+	if err != nil {
+		a.logger.Errorf("failed getting share `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionShareCreated, "share", uuid, nil, gin.H{
+		"grantee_email": share.GranteeEmail,
+		"account_uuid":  share.AccountUUID,
+		"item_uuid":     share.ItemUUID,
+		"level":         share.Level,
+	})
+
+	c.JSON(http.StatusCreated, gin.H{
+		"share": share,
+	})
+}
+
+//GetShares lists the shares the user has made, and the ones offered to
+//them, including invitations they haven't accepted yet
+func (a ServerAgent) GetShares(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	shares, err := a.dbClient.GetShares(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting shares for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	sharedByMe, sharedWithMe := []db.Share{}, []db.Share{}
+	for _, share := range shares {
+		if share.OwnerUUID == auth.UserUUID {
+			sharedByMe = append(sharedByMe, share)
+		} else {
+			sharedWithMe = append(sharedWithMe, share)
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"shared_by_me":   sharedByMe,
+		"shared_with_me": sharedWithMe,
+	})
+}
+
+//AcceptShare accepts an invitation to another user's account or item
+func (a ServerAgent) AcceptShare(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such share")
+	if !ok {
+		return //an error response has already been generated
+	}
+	err := a.dbClient.AcceptShare(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchShare {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such share"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed accepting share `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "accept failed - see logs for details"})
+		return
+	}
+
+	share, err := a.dbClient.GetShare(c, auth.UserUUID, uuid)
+	if err != nil {
+		a.logger.Errorf("failed getting share `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionShareAccepted, "share", uuid, gin.H{"accepted_at": nil}, gin.H{
+		"owner_email":  share.OwnerEmail,
+		"account_uuid": share.AccountUUID,
+		"item_uuid":    share.ItemUUID,
+		"level":        share.Level,
+		"accepted_at":  share.AcceptedAt,
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"share": share,
+	})
+}
+
+//RevokeShare ends a share. Owners use it to revoke access, and grantees
+//to decline an invitation or give up access.
+func (a ServerAgent) RevokeShare(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such share")
+	if !ok {
+		return //an error response has already been generated
+	}
+	err := a.dbClient.RevokeShare(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchShare {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such share"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed revoking share `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "revoke failed - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionShareRevoked, "share", uuid, nil, nil)
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+func TestCreateShareMalformedID(t *testing.T) {
+	for _, body := range []string{
+		`{"email":"friend@plaid-ui.test","account_id":"not-a-uuid","level":"read"}`,
+		`{"email":"friend@plaid-ui.test","item_id":"1234","level":"edit"}`,
+	} {
+		s := newTestServer()
+
+		code, resp := serve(t, s.CreateShare, "POST", "/api/v1/shares", "/api/v1/shares", body)
+		assertStatus(t, code, http.StatusBadRequest, resp)
+		if s.db.CreateShareCallCount() != 0 {
+			t.Errorf("a share was created for %s", body)
+		}
+	}
+}
+
+//TestCreateShareUnregisteredEmail checks that inviting an email nobody
+//has registered with looks the same as inviting one somebody has
+func TestCreateShareUnregisteredEmail(t *testing.T) {
+	s := newTestServer()
+	s.db.CreateShareReturns("share-1", nil)
+	s.db.GetShareReturns(db.Share{
+		Model:        db.Model{UUID: "share-1"},
+		OwnerUUID:    testUserUUID,
+		GranteeEmail: "nobody@plaid-ui.test",
+		AccountUUID:  "5e6f7a8b-1c2d-4e3f-8a9b-0c1d2e3f4a5b",
+		Level:        db.ShareLevelRead,
+	}, nil)
+
+	code, resp := serve(t, s.CreateShare, "POST", "/api/v1/shares", "/api/v1/shares",
+		`{"email":"nobody@plaid-ui.test","account_id":"5e6f7a8b-1c2d-4e3f-8a9b-0c1d2e3f4a5b","level":"read"}`)
+	assertStatus(t, code, http.StatusCreated, resp)
+
+	share, _ := resp["share"].(map[string]interface{})
+	if share["grantee_email"] != "nobody@plaid-ui.test" || share["grantee_uuid"] != "" {
+		t.Errorf("share = %v", share)
+	}
+	if _, created := s.db.CreateShareArgsForCall(0); created.GranteeEmail != "nobody@plaid-ui.test" {
+		t.Errorf("invited `%s`", created.GranteeEmail)
+	}
+}
+
+func TestCreateShareWithSelf(t *testing.T) {
+	s := newTestServer()
+	s.db.CreateShareReturns("", db.ErrNoSuchUser)
+
+	code, resp := serve(t, s.CreateShare, "POST", "/api/v1/shares", "/api/v1/shares",
+		`{"email":"me@plaid-ui.test","account_id":"5e6f7a8b-1c2d-4e3f-8a9b-0c1d2e3f4a5b","level":"read"}`)
+	assertStatus(t, code, http.StatusBadRequest, resp)
+	assertError(t, resp, "you can't share with yourself")
+}
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+//SplitRequest encodes a single allocation of part of a transaction
+type SplitRequest struct {
+	Amount   json.Number `json:"amount" binding:"required"`
+	Category string      `json:"category" binding:"required"`
+	Note     string      `json:"note"`
+}
+
+//SplitTransactionRequest replaces all of a transaction's splits
+type SplitTransactionRequest struct {
+	Splits []SplitRequest `json:"splits"`
+}
+
+//SplitTransaction divides one of the user's transactions across several
+//categories. The split amounts must add up to the transaction amount,
+//and sending an empty list removes the splits.
+func (a ServerAgent) SplitTransaction(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var req SplitTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	splits := make([]db.Split, 0, len(req.Splits))
+	for i, split := range req.Splits {
+		amount, ok := new(big.Float).SetString(split.Amount.String())
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("split %d has an invalid amount", i)})
+			return
+		}
+
+		splits = append(splits, db.Split{
+			Amount:   amount,
+			Category: split.Category,
+			Note:     split.Note,
+		})
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such transaction")
+	if !ok {
+		return //an error response has already been generated
+	}
+	previous, err := a.dbClient.GetTransactionSplits(c, auth.UserUUID, uuid)
+	if err != nil {
+		a.logger.Errorf("failed getting splits for transaction `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	err = a.dbClient.SetTransactionSplits(c, auth.UserUUID, uuid, splits)
+	if err == db.ErrNoSuchTransaction {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such transaction"})
+		return
+	}
+	if err == db.ErrUnbalancedSplits {
+		c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed splitting transaction `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "split failed - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionTransactionEdited, "transaction", uuid, gin.H{"splits": auditSplits(previous)}, gin.H{"splits": auditSplits(splits)})
+
+	a.renderTransaction(c, auth.UserUUID, uuid)
+}
+
+//auditSplits describes splits for the audit log, leaving out the fields
+//that change every time they're replaced
+func auditSplits(splits []db.Split) []gin.H {
+	described := []gin.H{}
+	for _, split := range splits {
+		described = append(described, gin.H{
+			"amount":   split.Amount,
+			"category": split.Category,
+			"note":     split.Note,
+		})
+	}
+	return described
+}
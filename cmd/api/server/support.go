@@ -0,0 +1,58 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+//GetUserItems lists any user's items, for support staff checking on the
+//health of their connections
+func (a ServerAgent) GetUserItems(c *gin.Context) {
+	if _, ok := a.authorize(c); !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such user")
+	if !ok {
+		return //an error response has already been generated
+	}
+	items, err := a.dbClient.GetItems(c, uuid)
+	if err != nil {
+		a.logger.Errorf("failed getting items for user `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": items,
+	})
+}
+
+//GetUserWebhookDeliveries lists any user's recent webhook deliveries,
+//for support staff. Payloads are left out, since they can describe the
+//user's transactions.
+func (a ServerAgent) GetUserWebhookDeliveries(c *gin.Context) {
+	if _, ok := a.authorize(c); !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such user")
+	if !ok {
+		return //an error response has already been generated
+	}
+	deliveries, err := a.dbClient.GetUserWebhookDeliveries(c, uuid, webhookDeliveriesLimit)
+	if err != nil {
+		a.logger.Errorf("failed getting webhook deliveries for user `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	for i := range deliveries {
+		deliveries[i].Payload = ""
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+	})
+}
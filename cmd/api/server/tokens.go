@@ -0,0 +1,140 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+const (
+	//defaultTokenLifetimeDays and maxTokenLifetimeDays bound how long a
+	//personal access token lasts
+	defaultTokenLifetimeDays = 30
+	maxTokenLifetimeDays     = 365
+)
+
+//PersonalAccessTokenRequest encodes a new personal access token
+type PersonalAccessTokenRequest struct {
+	Name          string   `json:"name" binding:"required"`
+	Scopes        []string `json:"scopes" binding:"required"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
+
+//CreatePersonalAccessToken creates a token for the user's scripts. The
+//response includes the token itself, which isn't shown again.
+func (a ServerAgent) CreatePersonalAccessToken(c *gin.Context) {
+	authorization, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var req PersonalAccessTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(req.Scopes) == 0 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "at least one scope is required"})
+		return
+	}
+	for _, scope := range req.Scopes {
+		if !auth.ValidScope(scope) {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "unknown scope `" + scope + "`"})
+			return
+		}
+		if !authorization.Can(auth.Scope(scope)) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "the `" + authorization.Role + "` role doesn't allow `" + scope + "`"})
+			return
+		}
+	}
+
+	if req.ExpiresInDays == 0 {
+		req.ExpiresInDays = defaultTokenLifetimeDays
+	}
+	if req.ExpiresInDays < 0 || req.ExpiresInDays > maxTokenLifetimeDays {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("expires_in_days must be between 1 and %d", maxTokenLifetimeDays)})
+		return
+	}
+
+	secret, hash, err := auth.NewPersonalAccessToken()
+	if err != nil {
+		a.logger.Errorf("failed generating personal access token: %s", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	token := db.PersonalAccessToken{
+		UserUUID:  authorization.UserUUID,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: time.Now().AddDate(0, 0, req.ExpiresInDays),
+		TokenHash: hash,
+	}
+	token.UUID, err = a.dbClient.CreatePersonalAccessToken(c, token)
+	if err != nil {
+		a.logger.Errorf("failed creating personal access token for user `%s`: %s", authorization.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	a.audit(c, authorization, db.AuditActionTokenCreated, "token", token.UUID, nil, gin.H{
+		"name":       token.Name,
+		"scopes":     token.Scopes,
+		"expires_at": token.ExpiresAt,
+	})
+
+	c.JSON(http.StatusCreated, gin.H{
+		"token":  token,
+		"secret": secret,
+	})
+}
+
+//GetPersonalAccessTokens lists the user's personal access tokens
+func (a ServerAgent) GetPersonalAccessTokens(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	tokens, err := a.dbClient.GetPersonalAccessTokens(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting personal access tokens for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tokens": tokens,
+	})
+}
+
+//RevokePersonalAccessToken revokes one of the user's personal access tokens
+func (a ServerAgent) RevokePersonalAccessToken(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such token")
+	if !ok {
+		return //an error response has already been generated
+	}
+	err := a.dbClient.RevokePersonalAccessToken(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchPersonalAccessToken {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such token"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed revoking personal access token `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "revoke failed - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionTokenRevoked, "token", uuid, nil, nil)
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,83 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//defaultTransactionsDays is how far back transactions are listed when
+//no start_date is given
+const defaultTransactionsDays = 30
+
+//GetTransactions lists the user's transactions between `start_date` and
+//`end_date`, optionally for a single account given by `account_id`
+func (a ServerAgent) GetTransactions(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	endDate := c.DefaultQuery("end_date", time.Now().Format(plaidapi.DateFormat))
+	end, err := time.Parse(plaidapi.DateFormat, endDate)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("end_date must be formatted as %s", plaidapi.DateFormat)})
+		return
+	}
+	startDate := c.DefaultQuery("start_date", end.AddDate(0, 0, -defaultTransactionsDays).Format(plaidapi.DateFormat))
+	if _, err := time.Parse(plaidapi.DateFormat, startDate); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("start_date must be formatted as %s", plaidapi.DateFormat)})
+		return
+	}
+
+	var transactions []db.Transaction
+	if accountUUID := c.Query("account_id"); accountUUID != "" {
+		_, err = a.dbClient.GetAccount(c, auth.UserUUID, accountUUID)
+		if err == db.ErrNoSuchAccount {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such account"})
+			return
+		}
+		if err != nil {
+			a.logger.Errorf("failed getting account `%s`: %s", accountUUID, err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+			return
+		}
+
+		transactions, err = a.dbClient.GetTransactionsByDateRange(c, auth.UserUUID, accountUUID, startDate, endDate)
+	} else {
+		transactions, err = a.dbClient.GetUserTransactionsByDateRange(c, auth.UserUUID, startDate, endDate)
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting transactions for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	if transactions == nil {
+		transactions = []db.Transaction{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transactions": transactions,
+		"start_date":   startDate,
+		"end_date":     endDate,
+	})
+}
+
+//GetTransaction gets one of the user's transactions and its annotations
+func (a ServerAgent) GetTransaction(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such transaction")
+	if !ok {
+		return //an error response has already been generated
+	}
+	a.renderTransaction(c, auth.UserUUID, uuid)
+}
@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//transferLookbackDays is how far back detection looks for unpaired transactions
+const transferLookbackDays = 90
+
+//GetTransfers lists the user's detected and confirmed transfers
+func (a ServerAgent) GetTransfers(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	transfers, err := a.dbClient.GetTransfers(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting transfers for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transfers": transfers,
+	})
+}
+
+//DetectTransfers pairs up any of the user's recent transactions that look
+//like transfers between their own accounts
+func (a ServerAgent) DetectTransfers(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	created, err := a.detectTransfers(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed detecting transfers for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "detection failed - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transfers": created,
+	})
+}
+
+//ConfirmTransfer marks a detected transfer as correct
+func (a ServerAgent) ConfirmTransfer(c *gin.Context) {
+	a.setTransferStatus(c, db.TransferStatusConfirmed, db.AuditActionTransferConfirmed)
+}
+
+//RejectTransfer breaks a pairing apart, so that both transactions count
+//towards spending and income again. Rejected pairings are remembered and
+//won't be suggested again.
+func (a ServerAgent) RejectTransfer(c *gin.Context) {
+	a.setTransferStatus(c, db.TransferStatusRejected, db.AuditActionTransferRejected)
+}
+
+func (a ServerAgent) setTransferStatus(c *gin.Context, status db.TransferStatus, action db.AuditAction) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such transfer")
+	if !ok {
+		return //an error response has already been generated
+	}
+	err := a.dbClient.SetTransferStatus(c, auth.UserUUID, uuid, status)
+	if err == db.ErrNoSuchTransfer {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such transfer"})
+		return
+	}
+	if err == db.ErrTransferConflict {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed updating status of transfer `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "update failed - see logs for details"})
+		return
+	}
+	a.audit(c, auth, action, "transfer", uuid, nil, gin.H{"status": status})
+
+	c.Status(http.StatusNoContent)
+}
+
+//detectTransfers runs the matcher over the user's recent transactions and
+//records any new pairings as suggestions. Only the user's own accounts
+//are matched, since a pairing hides both transactions from reports.
+func (a ServerAgent) detectTransfers(ctx context.Context, userUUID string) ([]db.Transfer, error) {
+	shared, err := a.dbClient.GetAccounts(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []db.Account
+	owned := map[string]bool{}
+	for _, account := range shared {
+		if account.Access == db.ShareLevelOwner {
+			accounts = append(accounts, account)
+			owned[account.UUID] = true
+		}
+	}
+
+	now := time.Now()
+	userTransactions, err := a.dbClient.GetUserTransactionsByDateRange(ctx, userUUID,
+		now.AddDate(0, 0, -transferLookbackDays).Format(plaidapi.DateFormat),
+		now.Format(plaidapi.DateFormat),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var transactions []db.Transaction
+	for _, t := range userTransactions {
+		if owned[t.AccountUUID] {
+			transactions = append(transactions, t)
+		}
+	}
+
+	existing, err := a.dbClient.GetTransfers(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	created := []db.Transfer{}
+	for _, pair := range a.transferMatcher.Match(accounts, transactions, existing) {
+		transfer := db.Transfer{
+			UserUUID:               userUUID,
+			OutflowTransactionUUID: pair.Outflow.UUID,
+			InflowTransactionUUID:  pair.Inflow.UUID,
+			Status:                 db.TransferStatusSuggested,
+		}
+
+		uuid, err := a.dbClient.CreateTransfer(ctx, transfer)
+		if err != nil {
+			return nil, err
+		}
+		if uuid == "" {
+			continue //paired concurrently
+		}
+
+		transfer.UUID = uuid
+		created = append(created, transfer)
+	}
+
+	return created, nil
+}
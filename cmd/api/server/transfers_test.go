@@ -0,0 +1,37 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/transfers/transfersfakes"
+)
+
+func TestDetectTransfersOwnAccountsOnly(t *testing.T) {
+	s := newTestServer()
+	matcher := &transfersfakes.FakeMatcher{}
+	s.transferMatcher = matcher
+
+	s.db.GetAccountsReturns([]db.Account{
+		{Model: db.Model{UUID: "acct-own"}, Access: db.ShareLevelOwner},
+		{Model: db.Model{UUID: "acct-shared"}, Access: db.ShareLevelRead},
+		{Model: db.Model{UUID: "acct-editable"}, Access: db.ShareLevelEdit},
+	}, nil)
+	s.db.GetUserTransactionsByDateRangeReturns([]db.Transaction{
+		{Model: db.Model{UUID: "txn-own"}, AccountUUID: "acct-own"},
+		{Model: db.Model{UUID: "txn-shared"}, AccountUUID: "acct-shared"},
+		{Model: db.Model{UUID: "txn-editable"}, AccountUUID: "acct-editable"},
+	}, nil)
+
+	status, resp := serve(t, s.DetectTransfers, "POST", "/api/v1/transfers/detect", "/api/v1/transfers/detect", "")
+	assertStatus(t, status, http.StatusOK, resp)
+
+	accounts, transactions, _ := matcher.MatchArgsForCall(0)
+	if len(accounts) != 1 || accounts[0].UUID != "acct-own" {
+		t.Errorf("matched accounts %v", accounts)
+	}
+	if len(transactions) != 1 || transactions[0].UUID != "txn-own" {
+		t.Errorf("matched transactions %v", transactions)
+	}
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+//AccountUpdateRequest encodes a partial update to an account.
+//Fields that are omitted are left unchanged.
+type AccountUpdateRequest struct {
+	Hidden            *bool `json:"hidden"`
+	WebhookConfigured *bool `json:"webhook_configured"`
+}
+
+//UpdateAccount changes the settings for an account. Anyone who can see
+//the account can hide it from their own reports, but only its owner can
+//configure webhooks.
+func (a ServerAgent) UpdateAccount(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var req AccountUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such account")
+	if !ok {
+		return //an error response has already been generated
+	}
+	account, err := a.dbClient.GetAccount(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchAccount {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such account"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting account `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	if req.WebhookConfigured != nil && account.Access != db.ShareLevelOwner {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "only the account's owner can configure webhooks"})
+		return
+	}
+	before := gin.H{"hidden": account.Hidden, "webhook_configured": account.WebhookConfigured}
+	after := gin.H{"hidden": account.Hidden, "webhook_configured": account.WebhookConfigured}
+
+	if req.Hidden != nil {
+		err := a.dbClient.SetAccountHidden(c, auth.UserUUID, uuid, *req.Hidden)
+		if err == db.ErrNoSuchAccount {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such account"})
+			return
+		}
+		if err != nil {
+			a.logger.Errorf("failed updating account `%s`: %s", uuid, err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "update failed - see logs for details"})
+			return
+		}
+		after["hidden"] = *req.Hidden
+	}
+
+	if req.WebhookConfigured != nil {
+		setConfigured := a.dbClient.DeconfigureAccount
+		if *req.WebhookConfigured {
+			setConfigured = a.dbClient.ConfigureAccount
+		}
+
+		err := setConfigured(c, auth.UserUUID, uuid)
+		if err == db.ErrNoSuchAccount {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such account"})
+			return
+		}
+		if err != nil {
+			a.logger.Errorf("failed updating account `%s`: %s", uuid, err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "update failed - see logs for details"})
+			return
+		}
+		after["webhook_configured"] = *req.WebhookConfigured
+	}
+	a.audit(c, auth, db.AuditActionAccountUpdated, "account", uuid, before, after)
+
+	account, err = a.dbClient.GetAccount(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchAccount {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such account"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting account `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"account": account,
+	})
+}
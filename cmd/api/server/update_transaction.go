@@ -0,0 +1,148 @@
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+//TransactionUpdateRequest encodes a partial update to a transaction.
+//Fields that are omitted are left unchanged.
+type TransactionUpdateRequest struct {
+	Note *string   `json:"note"`
+	Tags *[]string `json:"tags"`
+}
+
+//UpdateTransaction annotates one of the user's transactions with a
+//note and tags
+func (a ServerAgent) UpdateTransaction(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var req TransactionUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such transaction")
+	if !ok {
+		return //an error response has already been generated
+	}
+	transaction, err := a.dbClient.GetTransaction(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchTransaction {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such transaction"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting transaction `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	tags, err := a.dbClient.GetTransactionTags(c, auth.UserUUID, uuid)
+	if err != nil {
+		a.logger.Errorf("failed getting tags for transaction `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	before := gin.H{"note": transaction.Note, "tags": tags}
+	after := gin.H{"note": transaction.Note, "tags": tags}
+
+	if req.Note != nil {
+		err := a.dbClient.UpdateTransactionNote(c, auth.UserUUID, uuid, *req.Note)
+		if err == db.ErrNoSuchTransaction {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such transaction"})
+			return
+		}
+		if err != nil {
+			a.logger.Errorf("failed updating note for transaction `%s`: %s", uuid, err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "update failed - see logs for details"})
+			return
+		}
+		after["note"] = *req.Note
+	}
+
+	if req.Tags != nil {
+		tags := normalizeTags(*req.Tags)
+		err := a.dbClient.SetTransactionTags(c, auth.UserUUID, uuid, tags)
+		if err == db.ErrNoSuchTransaction {
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such transaction"})
+			return
+		}
+		if err != nil {
+			a.logger.Errorf("failed updating tags for transaction `%s`: %s", uuid, err.Error())
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "update failed - see logs for details"})
+			return
+		}
+		after["tags"] = tags
+	}
+	a.audit(c, auth, db.AuditActionTransactionEdited, "transaction", uuid, before, after)
+
+	a.renderTransaction(c, auth.UserUUID, uuid)
+}
+
+//renderTransaction responds with a transaction and its annotations
+func (a ServerAgent) renderTransaction(c *gin.Context, userUUID string, uuid string) {
+	transaction, err := a.dbClient.GetTransaction(c, userUUID, uuid)
+	if err == db.ErrNoSuchTransaction {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such transaction"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting transaction `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	tags, err := a.dbClient.GetTransactionTags(c, userUUID, uuid)
+	if err != nil {
+		a.logger.Errorf("failed getting tags for transaction `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	attachments, err := a.dbClient.GetAttachments(c, userUUID, uuid)
+	if err != nil {
+		a.logger.Errorf("failed getting attachments for transaction `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	splits, err := a.dbClient.GetTransactionSplits(c, userUUID, uuid)
+	if err != nil {
+		a.logger.Errorf("failed getting splits for transaction `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transaction":           transaction,
+		"tags":                  tags,
+		"attachments":           attachments,
+		"splits":                splits,
+		"splits_out_of_balance": db.SplitsOutOfBalance(transaction.Amount, splits),
+	})
+}
+
+//normalizeTags trims, deduplicates and sorts tag names
+func normalizeTags(tags []string) []string {
+	set := map[string]bool{}
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			set[tag] = true
+		}
+	}
+
+	normalized := []string{}
+	for tag := range set {
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+//RoleRequest encodes a change to a user's role
+type RoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+//SetUserRole changes a registered user's role
+func (a ServerAgent) SetUserRole(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var req RoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such user")
+	if !ok {
+		return //an error response has already been generated
+	}
+	user, err := a.dbClient.GetUser(c, uuid)
+	if err == db.ErrNoSuchUser {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such user"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting user `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	err = a.dbClient.SetUserRole(c, uuid, req.Role)
+	if err == db.ErrNoSuchRole {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "no such role `" + req.Role + "`"})
+		return
+	}
+	if err == db.ErrNoSuchUser {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such user"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed setting role for user `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionUserRoleChanged, "user", uuid, gin.H{"role": user.Role}, gin.H{"role": req.Role})
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_uuid": uuid,
+		"role":      req.Role,
+	})
+}
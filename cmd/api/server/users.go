@@ -0,0 +1,309 @@
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+const (
+	defaultUsersLimit = 50
+	maxUsersLimit     = 500
+)
+
+//UserUpdateRequest encodes a change to a registered user
+type UserUpdateRequest struct {
+	Email string `json:"email" binding:"required"`
+}
+
+//GetUsers lists registered users in order of email, a page at a time.
+//`query` matches any part of an email, and deactivated users are only
+//included with `include_deactivated=true`. The response's next_cursor,
+//passed back as `after`, gets the next page.
+func (a ServerAgent) GetUsers(c *gin.Context) {
+	if _, ok := a.authorize(c); !ok {
+		return //an error response has already been generated
+	}
+
+	page := db.UserPage{
+		Query:              c.Query("query"),
+		IncludeDeactivated: c.Query("include_deactivated") == "true",
+		Limit:              defaultUsersLimit,
+	}
+	if s := c.Query("limit"); s != "" {
+		var err error
+		page.Limit, err = strconv.Atoi(s)
+		if err != nil || page.Limit < 1 || page.Limit > maxUsersLimit {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and " + strconv.Itoa(maxUsersLimit)})
+			return
+		}
+	}
+
+	var err error
+	page.After, err = decodeUserCursor(c.Query("after"))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	//fetch one extra user to tell whether there's another page
+	limit := page.Limit
+	page.Limit++
+	users, err := a.dbClient.GetUsers(c, page)
+	if err != nil {
+		a.logger.Errorf("failed getting users: %s", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	var nextCursor string
+	if len(users) > limit {
+		users = users[:limit]
+		nextCursor = encodeUserCursor(users[limit-1])
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users":       users,
+		"next_cursor": nextCursor,
+	})
+}
+
+//GetUser gets a registered user along with their items, whose status,
+//error code and last sync show the health of their connections
+func (a ServerAgent) GetUser(c *gin.Context) {
+	if _, ok := a.authorize(c); !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such user")
+	if !ok {
+		return //an error response has already been generated
+	}
+	user, err := a.dbClient.GetUser(c, uuid)
+	if err == db.ErrNoSuchUser {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such user"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting user `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	items, err := a.dbClient.GetItems(c, uuid)
+	if err != nil {
+		a.logger.Errorf("failed getting items for user `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user":  user,
+		"items": items,
+	})
+}
+
+//UpdateUser changes a registered user's email
+func (a ServerAgent) UpdateUser(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var req UserUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such user")
+	if !ok {
+		return //an error response has already been generated
+	}
+	user, err := a.dbClient.GetUser(c, uuid)
+	if err == db.ErrNoSuchUser {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such user"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting user `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	err = a.dbClient.SetUserEmail(c, uuid, req.Email)
+	if err == db.ErrNoSuchUser {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such user"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed updating user `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "update failed - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionUserUpdated, "user", uuid, gin.H{"email": user.Email}, gin.H{"email": req.Email})
+
+	a.renderUser(c, uuid)
+}
+
+//DeactivateUser locks a registered user out of the service, keeping
+//their data
+func (a ServerAgent) DeactivateUser(c *gin.Context) {
+	a.setUserActive(c, false)
+}
+
+//ReactivateUser lets a deactivated user back in
+func (a ServerAgent) ReactivateUser(c *gin.Context) {
+	a.setUserActive(c, true)
+}
+
+func (a ServerAgent) setUserActive(c *gin.Context, active bool) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such user")
+	if !ok {
+		return //an error response has already been generated
+	}
+	if uuid == auth.UserUUID {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "you can't change whether you're active yourself"})
+		return
+	}
+
+	err := a.dbClient.SetUserActive(c, uuid, active)
+	if err == db.ErrNoSuchUser {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such user"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed setting active for user `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "update failed - see logs for details"})
+		return
+	}
+
+	action := db.AuditActionUserDeactivated
+	if active {
+		action = db.AuditActionUserReactivated
+	}
+	a.audit(c, auth, action, "user", uuid, nil, nil)
+
+	a.renderUser(c, uuid)
+}
+
+//DeleteUser permanently deletes a user and all of their data, after
+//unlinking their items from Plaid
+func (a ServerAgent) DeleteUser(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such user")
+	if !ok {
+		return //an error response has already been generated
+	}
+	if uuid == auth.UserUUID {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "you can't delete yourself"})
+		return
+	}
+
+	if _, err := a.dbClient.GetUser(c, uuid); err == db.ErrNoSuchUser {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such user"})
+		return
+	} else if err != nil {
+		a.logger.Errorf("failed getting user `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	if err := a.deleteUserData(c, uuid); err == db.ErrNoSuchUser {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such user"})
+		return
+	} else if err != nil {
+		a.logger.Errorf("failed deleting user `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "delete failed - see logs for details"})
+		return
+	}
+
+	a.audit(c, auth, db.AuditActionUserDeleted, "user", uuid, nil, nil)
+	c.Status(http.StatusNoContent)
+}
+
+//deleteUserData unlinks the items the user owns from Plaid, deletes the
+//user and their data, and then removes their attachments' contents
+func (a ServerAgent) deleteUserData(ctx context.Context, uuid string) error {
+	items, err := a.dbClient.GetItems(ctx, uuid)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if item.Access != db.ShareLevelOwner {
+			continue
+		}
+		if err := a.removePlaidItem(ctx, item.PlaidItemID); err != nil {
+			return err
+		}
+	}
+
+	attachments, err := a.dbClient.DeleteUser(ctx, uuid)
+	if err != nil {
+		return err
+	}
+
+	//the user is gone either way, so failures here are only logged
+	for _, attachment := range attachments {
+		if err := a.blobStore.Delete(ctx, attachment.BlobKey()); err != nil {
+			a.logger.Errorf("failed deleting contents of attachment `%s`: %s", attachment.UUID, err.Error())
+		}
+	}
+	return nil
+}
+
+func (a ServerAgent) renderUser(c *gin.Context, uuid string) {
+	user, err := a.dbClient.GetUser(c, uuid)
+	if err == db.ErrNoSuchUser {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such user"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed getting user `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user": user,
+	})
+}
+
+//encodeUserCursor makes an opaque cursor for a user's position in a page
+func encodeUserCursor(user db.User) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(user.Email + "/" + user.UUID))
+}
+
+//decodeUserCursor reads a cursor from encodeUserCursor. Emails may
+//contain slashes, but UUIDs don't.
+func decodeUserCursor(cursor string) (*db.UserCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	i := strings.LastIndex(string(data), "/")
+	if i < 0 {
+		return nil, errors.New("invalid cursor")
+	}
+	return &db.UserCursor{Email: string(data[:i]), UUID: string(data[i+1:])}, nil
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/lib/tools"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/webhooks"
+)
+
+//webhookDeliveriesLimit is how many recent deliveries are listed per endpoint
+const webhookDeliveriesLimit = 100
+
+//WebhookEndpointRequest registers a URL to receive events
+type WebhookEndpointRequest struct {
+	URL    string   `json:"url" binding:"required"`
+	Events []string `json:"events"`
+}
+
+//CreateWebhookEndpoint registers an HTTPS endpoint for the user. The
+//response includes the endpoint's signing secret, which isn't shown again.
+func (a ServerAgent) CreateWebhookEndpoint(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	var req WebhookEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if u, err := url.Parse(req.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "url must be an https URL"})
+		return
+	} else if !tools.IsPublicHost(u.Host) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "url must be a public address"})
+		return
+	}
+
+	known := map[string]bool{}
+	for _, eventType := range webhooks.EventTypes {
+		known[eventType] = true
+	}
+	for _, eventType := range req.Events {
+		if !known[eventType] {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "unknown event type `" + eventType + "`"})
+			return
+		}
+	}
+
+	secret, err := webhooks.NewSecret()
+	if err != nil {
+		a.logger.Errorf("failed generating webhook secret: %s", err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	endpoint := db.WebhookEndpoint{
+		UserUUID: auth.UserUUID,
+		URL:      req.URL,
+		Events:   req.Events,
+		Secret:   secret,
+	}
+	if endpoint.Events == nil {
+		//every type, as it's stored
+		endpoint.Events = []string{}
+	}
+	endpoint.UUID, err = a.dbClient.CreateWebhookEndpoint(c, endpoint)
+	if err != nil {
+		a.logger.Errorf("failed creating webhook endpoint for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionWebhookEndpointCreated, "webhook_endpoint", endpoint.UUID, nil, gin.H{
+		"url":    endpoint.URL,
+		"events": endpoint.Events,
+	})
+
+	c.JSON(http.StatusCreated, gin.H{
+		"endpoint": endpoint,
+		"secret":   secret,
+	})
+}
+
+//GetWebhookEndpoints lists the user's webhook endpoints
+func (a ServerAgent) GetWebhookEndpoints(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	endpoints, err := a.dbClient.GetWebhookEndpoints(c, auth.UserUUID)
+	if err != nil {
+		a.logger.Errorf("failed getting webhook endpoints for user `%s`: %s", auth.UserUUID, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"endpoints": endpoints,
+	})
+}
+
+//DeleteWebhookEndpoint removes one of the user's webhook endpoints and
+//cancels any deliveries still queued for it
+func (a ServerAgent) DeleteWebhookEndpoint(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such webhook endpoint")
+	if !ok {
+		return //an error response has already been generated
+	}
+	err := a.dbClient.DeleteWebhookEndpoint(c, auth.UserUUID, uuid)
+	if err == db.ErrNoSuchWebhookEndpoint {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such webhook endpoint"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed deleting webhook endpoint `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "delete failed - see logs for details"})
+		return
+	}
+	a.audit(c, auth, db.AuditActionWebhookEndpointDeleted, "webhook_endpoint", uuid, nil, nil)
+
+	c.Status(http.StatusNoContent)
+}
+
+//GetWebhookDeliveries lists recent deliveries to one of the user's
+//webhook endpoints, most recent first
+func (a ServerAgent) GetWebhookDeliveries(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	uuid, ok := uuidParam(c, "id", "no such webhook endpoint")
+	if !ok {
+		return //an error response has already been generated
+	}
+	deliveries, err := a.dbClient.GetWebhookDeliveries(c, auth.UserUUID, uuid, webhookDeliveriesLimit)
+	if err != nil {
+		a.logger.Errorf("failed getting deliveries for webhook endpoint `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "an internal error occurred - see logs for details"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+	})
+}
+
+//RedeliverWebhook sends one of the user's past deliveries again
+func (a ServerAgent) RedeliverWebhook(c *gin.Context) {
+	auth, ok := a.authorize(c)
+	if !ok {
+		return //an error response has already been generated
+	}
+
+	endpointUUID, ok := uuidParam(c, "id", "no such webhook endpoint")
+	if !ok {
+		return //an error response has already been generated
+	}
+	uuid, ok := uuidParam(c, "delivery_id", "no such webhook delivery")
+	if !ok {
+		return //an error response has already been generated
+	}
+	err := a.dbClient.RedeliverWebhookDelivery(c, auth.UserUUID, endpointUUID, uuid)
+	if err == db.ErrNoSuchWebhookDelivery {
+		c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "no such webhook delivery"})
+		return
+	}
+	if err != nil {
+		a.logger.Errorf("failed requeueing webhook delivery `%s`: %s", uuid, err.Error())
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "redelivery failed - see logs for details"})
+		return
+	}
+
+	a.webhookDispatcher.Wake()
+	c.Status(http.StatusAccepted)
+}
+
+//emit queues an event for the user's webhook endpoints. Failures are
+//logged rather than returned, so that they never fail the caller.
+func (a ServerAgent) emit(ctx context.Context, userUUID string, eventType string, data interface{}) {
+	if err := a.webhookDispatcher.Emit(ctx, userUUID, eventType, data); err != nil {
+		a.logger.Errorf("failed emitting %s event for user `%s`: %s", eventType, userUUID, err.Error())
+	}
+}
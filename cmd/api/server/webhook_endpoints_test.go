@@ -0,0 +1,40 @@
+package server
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestCreateWebhookEndpointNonPublicURL(t *testing.T) {
+	for _, url := range []string{
+		"https://localhost/hook",
+		"https://127.0.0.1:8443/hook",
+		"https://192.168.1.20/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[fd00::1]/hook",
+	} {
+		s := newTestServer()
+
+		status, resp := serve(t, s.CreateWebhookEndpoint, "POST", "/api/v1/webhooks", "/api/v1/webhooks",
+			`{"url": "`+url+`", "events": []}`)
+		assertStatus(t, status, http.StatusBadRequest, resp)
+		assertError(t, resp, "url must be a public address")
+		if n := s.db.CreateWebhookEndpointCallCount(); n != 0 {
+			t.Errorf("an endpoint for %s was created", url)
+		}
+	}
+}
+
+func TestCreateWebhookEndpointAllEvents(t *testing.T) {
+	s := newTestServer()
+	s.db.CreateWebhookEndpointReturns("9b0c1d2e-3f4a-4b8c-9d9e-0f1a2b3c4d5e", nil)
+
+	status, resp := serve(t, s.CreateWebhookEndpoint, "POST", "/api/v1/webhooks", "/api/v1/webhooks",
+		`{"url": "https://hooks.example.com/plaid-ui"}`)
+	assertStatus(t, status, http.StatusCreated, resp)
+
+	endpoint := resp["endpoint"].(map[string]interface{})
+	if events, ok := endpoint["events"].([]interface{}); !ok || len(events) != 0 {
+		t.Errorf("events = %v, want []", endpoint["events"])
+	}
+}
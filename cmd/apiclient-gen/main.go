@@ -0,0 +1,422 @@
+//apiclient-gen writes the typed Go client in pkg/apiclient from the
+//OpenAPI document that the API server serves
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"sort"
+	"strings"
+
+	flag "github.com/jessevdk/go-flags"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server"
+	"github.com/xanderflood/plaid-ui/pkg/openapi"
+)
+
+var options struct {
+	Output  string `long:"output"  short:"o" default:"client.gen.go"`
+	Package string `long:"package"           default:"apiclient"`
+
+	//only operations under this prefix are part of the client; the SPA
+	//and the Plaid webhook aren't called by scripts
+	PathPrefix string `long:"path-prefix" default:"/api/v1/"`
+}
+
+//initialisms are written in capitals in Go names
+var initialisms = map[string]bool{
+	"api": true, "csv": true, "fitid": true, "http": true, "id": true, "iso": true, "json": true,
+	"ofx": true, "qfx": true, "qif": true, "spa": true, "url": true, "uuid": true,
+}
+
+func main() {
+	if _, err := flag.Parse(&options); err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := newGenerator(server.APIDocument()).generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := ioutil.WriteFile(options.Output, src, 0644); err != nil {
+		log.Fatalf("couldn't write `%s`: %s", options.Output, err.Error())
+	}
+}
+
+func newGenerator(doc *openapi.Document) *generator {
+	return &generator{
+		doc:     doc,
+		types:   map[string]bool{},
+		imports: map[string]bool{"context": true, "net/url": true},
+	}
+}
+
+type generator struct {
+	doc     *openapi.Document
+	types   map[string]bool
+	imports map[string]bool
+
+	decls   bytes.Buffer
+	methods bytes.Buffer
+}
+
+func (g *generator) generate() ([]byte, error) {
+	for _, ref := range g.doc.Operations() {
+		if !strings.HasPrefix(ref.Path, options.PathPrefix) {
+			continue
+		}
+		if err := g.operation(ref); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by apiclient-gen from the API's OpenAPI document. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\n", options.Package)
+	var imports []string
+	for pkg := range g.imports {
+		imports = append(imports, pkg)
+	}
+	sort.Strings(imports)
+	fmt.Fprintf(&out, "import (\n")
+	for _, pkg := range imports {
+		fmt.Fprintf(&out, "\t%q\n", pkg)
+	}
+	fmt.Fprintf(&out, ")\n\n")
+	fmt.Fprintf(&out, "//APIVersion is the version of the API this client was generated from\nconst APIVersion = %q\n\n", g.doc.Info.Version)
+	out.Write(g.decls.Bytes())
+	out.Write(g.methods.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code is invalid: %s", err.Error())
+	}
+	return src, nil
+}
+
+//declareStruct writes a struct type for an object schema. Optional
+//fields are left out when they aren't set, so that requests keep the
+//server's defaults.
+func (g *generator) declareStruct(name string, schema *openapi.Schema) {
+	if g.types[name] {
+		return
+	}
+	g.types[name] = true
+
+	required := map[string]bool{}
+	for _, prop := range schema.Required {
+		required[prop] = true
+	}
+
+	var props []string
+	for prop := range schema.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	var body bytes.Buffer
+	for _, prop := range props {
+		field := goName(prop)
+		typ := g.goType(schema.Properties[prop], name+field)
+
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+		}
+		if desc := schema.Properties[prop].Description; desc != "" {
+			fmt.Fprintf(&body, "\t//%s\n", desc)
+		}
+		fmt.Fprintf(&body, "\t%s %s `json:%q`\n", field, typ, tag)
+	}
+
+	fmt.Fprintf(&g.decls, "//%s is generated from the `%s` schema\ntype %s struct {\n%s}\n\n", name, name, name, body.String())
+}
+
+//goType finds the Go type for a schema, declaring a struct named hint
+//for inline objects
+func (g *generator) goType(schema *openapi.Schema, hint string) string {
+	if schema.Ref != "" {
+		//components are declared as they're used, which leaves out the
+		//ones only the server's own routes need
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		g.declareStruct(name, g.doc.Components.Schemas[name])
+		return name
+	}
+
+	var typ string
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			g.imports["time"] = true
+			typ = "time.Time"
+		case openapi.FormatDecimal:
+			g.imports["math/big"] = true
+			return "*big.Float"
+		case "byte":
+			return "[]byte"
+		default:
+			typ = "string"
+		}
+	case "integer":
+		typ = "int"
+		if schema.Format == "int64" {
+			typ = "int64"
+		}
+	case "number":
+		g.imports["encoding/json"] = true
+		typ = "json.Number"
+	case "boolean":
+		typ = "bool"
+	case "array":
+		typ = "[]" + g.goType(schema.Items, hint+"Item")
+	case "object":
+		switch {
+		case schema.AdditionalProperties != nil:
+			typ = "map[string]" + g.goType(schema.AdditionalProperties, hint+"Value")
+		case len(schema.Properties) > 0:
+			g.declareStruct(hint, schema)
+			typ = hint
+		default:
+			typ = "map[string]interface{}"
+		}
+	default:
+		return "interface{}"
+	}
+
+	if schema.Nullable {
+		return "*" + typ
+	}
+	return typ
+}
+
+type param struct {
+	name   string
+	field  string
+	in     string
+	goType string
+}
+
+//operation writes a method for one route
+func (g *generator) operation(ref openapi.OperationRef) error {
+	op := ref.Operation
+	method := goName(op.OperationID)
+
+	var pathParams, otherParams []param
+	for _, p := range op.Parameters {
+		gp := param{name: p.Name, field: goName(p.Name), in: p.In, goType: "string"}
+		switch p.In {
+		case "path":
+			gp.field = lowerFirst(gp.field)
+			pathParams = append(pathParams, gp)
+		case "query", "header":
+			if p.Schema.Type == "integer" {
+				gp.goType = "int"
+			}
+			otherParams = append(otherParams, gp)
+		default:
+			return fmt.Errorf("%s has a parameter in unsupported location `%s`", op.OperationID, p.In)
+		}
+	}
+
+	args := []string{"ctx context.Context"}
+	for _, p := range pathParams {
+		args = append(args, p.field+" string")
+	}
+
+	var build bytes.Buffer
+	fmt.Fprintf(&build, "\treq := request{\n\t\tmethod: %q,\n\t\tpath: %s,\n\t\tquery: url.Values{},\n\t\theader: map[string]string{},\n\t}\n", ref.Method, pathExpr(ref.Path, pathParams))
+
+	if op.RequestBody != nil {
+		contentType, media, err := single(op.RequestBody.Content)
+		if err != nil {
+			return fmt.Errorf("%s: %s", op.OperationID, err.Error())
+		}
+
+		switch contentType {
+		case "application/json":
+			typ := g.goType(media.Schema, method+"Request")
+			args = append(args, "body *"+typ)
+			fmt.Fprintf(&build, "\tif body != nil {\n\t\treq.json = body\n\t}\n")
+		case "application/x-www-form-urlencoded":
+			args = append(args, "form url.Values")
+			fmt.Fprintf(&build, "\treq.form = form\n")
+		case "multipart/form-data":
+			fileField := ""
+			for name, prop := range media.Schema.Properties {
+				if prop.Format == "binary" {
+					fileField = name
+				}
+			}
+			args = append(args, "form MultipartForm")
+			fmt.Fprintf(&build, "\treq.multipart = &form\n\treq.fileField = %q\n", fileField)
+		default:
+			return fmt.Errorf("%s has a request body of unsupported type `%s`", op.OperationID, contentType)
+		}
+	}
+
+	if len(otherParams) > 0 {
+		paramsType := method + "Params"
+		args = append(args, "params *"+paramsType)
+
+		fmt.Fprintf(&g.decls, "//%s holds the optional parameters of %s\ntype %s struct {\n", paramsType, method, paramsType)
+		for _, p := range otherParams {
+			fmt.Fprintf(&g.decls, "\t%s %s\n", p.field, p.goType)
+		}
+		fmt.Fprintf(&g.decls, "}\n\n")
+
+		fmt.Fprintf(&build, "\tif params != nil {\n")
+		for _, p := range otherParams {
+			value, zero := "params."+p.field, `""`
+			if p.goType == "int" {
+				g.imports["strconv"] = true
+				value, zero = "strconv.Itoa(params."+p.field+")", "0"
+			}
+			set := fmt.Sprintf("req.query.Set(%q, %s)", p.name, value)
+			if p.in == "header" {
+				set = fmt.Sprintf("req.header[%q] = %s", p.name, value)
+			}
+			fmt.Fprintf(&build, "\t\tif params.%s != %s {\n\t\t\t%s\n\t\t}\n", p.field, zero, set)
+		}
+		fmt.Fprintf(&build, "\t}\n")
+	}
+
+	status, resp, err := success(op.Responses)
+	if err != nil {
+		return fmt.Errorf("%s: %s", op.OperationID, err.Error())
+	}
+
+	var result, call string
+	switch {
+	case len(resp.Content) == 0:
+		result = "error"
+		call = "\treturn c.do(ctx, req, nil)\n"
+	case resp.Content["application/json"] != nil:
+		typ := g.goType(resp.Content["application/json"].Schema, method+"Response")
+		if strings.HasPrefix(typ, "map[") || strings.HasPrefix(typ, "[]") {
+			result = "(" + typ + ", error)"
+			call = fmt.Sprintf("\tvar out %s\n\tif err := c.do(ctx, req, &out); err != nil {\n\t\treturn nil, err\n\t}\n\treturn out, nil\n", typ)
+		} else {
+			result = "(*" + typ + ", error)"
+			call = fmt.Sprintf("\tvar out %s\n\tif err := c.do(ctx, req, &out); err != nil {\n\t\treturn nil, err\n\t}\n\treturn &out, nil\n", typ)
+		}
+	default:
+		g.imports["io"] = true
+		result = "(io.ReadCloser, error)"
+		call = "\treturn c.download(ctx, req)\n"
+	}
+
+	fmt.Fprintf(&g.methods, "//%s calls %s %s, which responds %s: %s\n", method, ref.Method, ref.Path, status, op.Summary)
+	if op.Deprecated {
+		fmt.Fprintf(&g.methods, "//\n//Deprecated: the route is kept for old clients.\n")
+	}
+	fmt.Fprintf(&g.methods, "func (c *Client) %s(%s) %s {\n%s%s}\n\n", method, strings.Join(args, ", "), result, build.String(), call)
+	return nil
+}
+
+//success finds the operation's only non-error response
+func success(responses map[string]*openapi.Response) (string, *openapi.Response, error) {
+	var found []string
+	for status := range responses {
+		if strings.HasPrefix(status, "2") {
+			found = append(found, status)
+		}
+	}
+	if len(found) != 1 {
+		return "", nil, fmt.Errorf("expected one success response, found %d", len(found))
+	}
+	return found[0], responses[found[0]], nil
+}
+
+func single(content map[string]*openapi.MediaType) (string, *openapi.MediaType, error) {
+	if len(content) != 1 {
+		return "", nil, fmt.Errorf("expected one content type, found %d", len(content))
+	}
+	for contentType, media := range content {
+		return contentType, media, nil
+	}
+	return "", nil, nil
+}
+
+//pathExpr builds a Go expression for a path, escaping each parameter
+func pathExpr(path string, params []param) string {
+	var parts []string
+	literal := ""
+	for _, segment := range strings.SplitAfter(path, "/") {
+		if strings.HasPrefix(segment, "{") {
+			name := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "/"), "}")
+			rest := strings.TrimPrefix(segment, "{"+name+"}")
+			for _, p := range params {
+				if p.name == name {
+					if literal != "" {
+						parts = append(parts, fmt.Sprintf("%q", literal))
+					}
+					parts = append(parts, "url.PathEscape("+p.field+")")
+					literal = rest
+				}
+			}
+			continue
+		}
+		literal += segment
+	}
+	if literal != "" {
+		parts = append(parts, fmt.Sprintf("%q", literal))
+	}
+	return strings.Join(parts, " + ")
+}
+
+//goName converts a snake_case, kebab-case or camelCase name to an
+//exported Go name
+func goName(name string) string {
+	var out strings.Builder
+	for _, word := range words(name) {
+		if initialisms[strings.ToLower(word)] {
+			out.WriteString(strings.ToUpper(word))
+		} else {
+			out.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return out.String()
+}
+
+func words(name string) []string {
+	var words []string
+	var current strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_' || r == '-' || r == '.':
+			if current.Len() > 0 {
+				words = append(words, current.String())
+				current.Reset()
+			}
+			continue
+		case r >= 'A' && r <= 'Z' && i > 0 && current.Len() > 0:
+			last := current.String()[current.Len()-1]
+			if last < 'A' || last > 'Z' {
+				words = append(words, current.String())
+				current.Reset()
+			}
+		}
+		current.WriteRune(r)
+	}
+	if current.Len() > 0 {
+		words = append(words, current.String())
+	}
+	return words
+}
+
+func lowerFirst(name string) string {
+	for i, r := range name {
+		if r < 'A' || r > 'Z' {
+			if i > 1 {
+				i--
+			}
+			return strings.ToLower(name[:i]) + name[i:]
+		}
+	}
+	return strings.ToLower(name)
+}
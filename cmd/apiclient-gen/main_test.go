@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	flag "github.com/jessevdk/go-flags"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server"
+)
+
+//TestClientIsCurrent checks that pkg/apiclient/client.gen.go was
+//regenerated after the last change to the OpenAPI document
+func TestClientIsCurrent(t *testing.T) {
+	if _, err := flag.ParseArgs(&options, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := newGenerator(server.APIDocument()).generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := ioutil.ReadFile("../../pkg/apiclient/client.gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, current) {
+		t.Error("pkg/apiclient/client.gen.go is out of date; run `go generate ./pkg/apiclient`")
+	}
+}
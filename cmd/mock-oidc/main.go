@@ -0,0 +1,258 @@
+//mock-oidc is an OpenID Connect provider for trying out the API's
+//built-in login locally. It logs everyone in, without asking, as the
+//user given on the command line, or as the email in a `login_hint`.
+//
+//	go run ./cmd/mock-oidc --email you@example.com
+//
+//and run the API with OIDC_ISSUER=http://localhost:9000,
+//OIDC_CLIENT_ID=plaid-ui and an OIDC_REDIRECT_URL on localhost. With
+//--unverified-email, the email is marked unverified, so logins can't
+//link to a registered user by it.
+package main
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	flag "github.com/jessevdk/go-flags"
+	"github.com/pkg/errors"
+)
+
+var options struct {
+	Port     string `long:"port"      default:"9000"`
+	Issuer   string `long:"issuer"    default:"http://localhost:9000"`
+	ClientID string `long:"client-id" default:"plaid-ui"`
+	Subject  string `long:"subject"   default:"mock-user"`
+	Email    string `long:"email"     default:"user@example.com"`
+
+	UnverifiedEmail bool `long:"unverified-email"`
+}
+
+//keyID names the provider's only signing key
+const keyID = "mock"
+
+//grant is an authorization code waiting to be redeemed
+type grant struct {
+	redirectURI string
+	challenge   string
+	nonce       string
+	subject     string
+	email       string
+	expiresAt   time.Time
+}
+
+type provider struct {
+	issuer        string
+	clientID      string
+	subject       string
+	email         string
+	emailVerified bool
+
+	key *rsa.PrivateKey
+
+	mu     sync.Mutex
+	grants map[string]grant
+}
+
+func main() {
+	if _, err := flag.Parse(&options); err != nil {
+		log.Fatal(err)
+	}
+
+	p, err := newProvider(options.Issuer, options.ClientID, options.Subject, options.Email, !options.UnverifiedEmail)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("mock OpenID Connect provider `%s` listening on :%s", options.Issuer, options.Port)
+	log.Fatal(http.ListenAndServe(":"+options.Port, p.handler()))
+}
+
+//newProvider creates a provider with a new signing key
+func newProvider(issuer string, clientID string, subject string, email string, emailVerified bool) (*provider, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't generate a signing key")
+	}
+
+	return &provider{
+		issuer:        issuer,
+		clientID:      clientID,
+		subject:       subject,
+		email:         email,
+		emailVerified: emailVerified,
+
+		key:    key,
+		grants: map[string]grant{},
+	}, nil
+}
+
+func (p *provider) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("/authorize", p.authorize)
+	mux.HandleFunc("/token", p.token)
+	mux.HandleFunc("/jwks", p.jwks)
+	return mux
+}
+
+func (p *provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                p.issuer,
+		"authorization_endpoint":                p.issuer + "/authorize",
+		"token_endpoint":                        p.issuer + "/token",
+		"jwks_uri":                              p.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (p *provider) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := p.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+//authorize approves every request that's well-formed
+func (p *provider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	switch {
+	case q.Get("response_type") != "code":
+		http.Error(w, "response_type must be code", http.StatusBadRequest)
+		return
+	case q.Get("client_id") != p.clientID:
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	case q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "":
+		http.Error(w, "an S256 code_challenge is required", http.StatusBadRequest)
+		return
+	}
+
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || !redirect.IsAbs() {
+		http.Error(w, "redirect_uri must be an absolute URL", http.StatusBadRequest)
+		return
+	}
+
+	subject, email := p.subject, p.email
+	if hint := q.Get("login_hint"); hint != "" {
+		subject, email = "mock-"+hint, hint
+	}
+
+	code := randomString()
+	p.mu.Lock()
+	p.grants[code] = grant{
+		redirectURI: q.Get("redirect_uri"),
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+		subject:     subject,
+		email:       email,
+		expiresAt:   time.Now().Add(time.Minute),
+	}
+	p.mu.Unlock()
+
+	query := redirect.Query()
+	query.Set("code", code)
+	query.Set("state", q.Get("state"))
+	redirect.RawQuery = query.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+//token redeems a code once, checking it against the PKCE verifier
+func (p *provider) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "token requests must be POSTs", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, "invalid_request", err.Error())
+		return
+	}
+
+	p.mu.Lock()
+	g, ok := p.grants[r.PostForm.Get("code")]
+	delete(p.grants, r.PostForm.Get("code"))
+	p.mu.Unlock()
+
+	verifier := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	switch {
+	case r.PostForm.Get("grant_type") != "authorization_code":
+		tokenError(w, "unsupported_grant_type", "only authorization_code is supported")
+		return
+	case !ok || time.Now().After(g.expiresAt):
+		tokenError(w, "invalid_grant", "the code is unknown, used or expired")
+		return
+	case r.PostForm.Get("redirect_uri") != g.redirectURI:
+		tokenError(w, "invalid_grant", "redirect_uri doesn't match the authorization request")
+		return
+	case base64.RawURLEncoding.EncodeToString(verifier[:]) != g.challenge:
+		tokenError(w, "invalid_grant", "code_verifier doesn't match the code_challenge")
+		return
+	}
+
+	now := time.Now()
+	idToken := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":            p.issuer,
+		"aud":            p.clientID,
+		"sub":            g.subject,
+		"email":          g.email,
+		"email_verified": p.emailVerified,
+		"nonce":          g.nonce,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+	})
+	idToken.Header["kid"] = keyID
+
+	signed, err := idToken.SignedString(p.key)
+	if err != nil {
+		tokenError(w, "server_error", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     signed,
+	})
+}
+
+func tokenError(w http.ResponseWriter, code string, description string) {
+	writeJSON(w, http.StatusBadRequest, map[string]string{"error": code, "error_description": description})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("couldn't write response: %s", err.Error())
+	}
+}
+
+func randomString() string {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatalf("couldn't generate a random string: %s", err.Error())
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,329 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+
+	"github.com/xanderflood/plaid-ui/cmd/api/server/auth"
+	"github.com/xanderflood/plaid-ui/lib/tools/toolsfakes"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/db/dbfakes"
+)
+
+const (
+	testClientID      = "plaid-ui"
+	testSigningSecret = "test-signing-secret"
+	testAPIHost       = "plaid-ui.test"
+)
+
+//testRenderer renders each view as its name, so tests can tell which
+//one was shown
+type testRenderer struct{}
+
+func (testRenderer) RenderSPA(c *gin.Context) {
+	c.String(http.StatusOK, "spa")
+	c.Abort()
+}
+
+func (testRenderer) RenderNotRegistered(email string, c *gin.Context) {
+	c.String(http.StatusOK, "not registered: "+email)
+	c.Abort()
+}
+
+func (testRenderer) RenderStatusCode(status int, message string, c *gin.Context) {
+	c.String(status, message)
+	c.Abort()
+}
+
+//loginTest is the API's built-in login, configured against a mock
+//provider served over httptest
+type loginTest struct {
+	t *testing.T
+
+	provider *httptest.Server
+	api      *gin.Engine
+	db       *dbfakes.FakeDB
+}
+
+func newLoginTest(t *testing.T, emailVerified bool) loginTest {
+	gin.SetMode(gin.TestMode)
+
+	p, err := newProvider("", testClientID, "mock-user", "user@example.com", emailVerified)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(p.handler())
+	p.issuer = server.URL
+
+	logger := &toolsfakes.FakeLogger{}
+	dbClient := &dbfakes.FakeDB{}
+	manager := auth.NewAuthorizationManager(logger, testRenderer{}, testSigningSecret, nil, "plaid-ui", "plaid-ui", &jwt.Parser{}, dbClient, nil)
+	rp := auth.NewOIDCRelyingParty(logger, testRenderer{}, auth.OIDCConfig{
+		Issuer:          server.URL,
+		ClientID:        testClientID,
+		RedirectURL:     "https://" + testAPIHost + "/oidc/callback",
+		SessionLifetime: time.Hour,
+	}, server.Client(), manager, dbClient)
+
+	api := gin.New()
+	api.GET("/login", rp.Login)
+	api.GET("/oidc/callback", rp.Callback)
+
+	return loginTest{t: t, provider: server, api: api, db: dbClient}
+}
+
+func (l loginTest) Close() {
+	l.provider.Close()
+}
+
+func (l loginTest) serveAPI(target string, cookies ...*http.Cookie) *http.Response {
+	l.t.Helper()
+
+	req := httptest.NewRequest("GET", target, nil)
+	req.Host = testAPIHost
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	l.api.ServeHTTP(rec, req)
+	return rec.Result()
+}
+
+//startLogin visits /login, returning the provider's authorization URL
+//and the login state cookie
+func (l loginTest) startLogin() (*url.URL, *http.Cookie) {
+	l.t.Helper()
+
+	resp := l.serveAPI("/login?referrer_url=" + url.QueryEscape("https://"+testAPIHost+"/accounts?view=all"))
+	if resp.StatusCode != http.StatusFound {
+		l.t.Fatalf("/login responded %d", resp.StatusCode)
+	}
+	authorizeURL, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == "_oidc_login" {
+			return authorizeURL, cookie
+		}
+	}
+	l.t.Fatal("/login didn't set a login state cookie")
+	return nil, nil
+}
+
+//authorize visits the provider's authorization URL, returning the
+//callback URL it redirects to
+func (l loginTest) authorize(authorizeURL *url.URL) *url.URL {
+	l.t.Helper()
+
+	client := *l.provider.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	resp, err := client.Get(authorizeURL.String())
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		l.t.Fatalf("the provider refused the authorization request with %d", resp.StatusCode)
+	}
+
+	callbackURL, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		l.t.Fatal(err)
+	}
+	if callbackURL.Host != testAPIHost || callbackURL.Path != "/oidc/callback" {
+		l.t.Fatalf("the provider redirected to `%s`", callbackURL)
+	}
+	return callbackURL
+}
+
+//session gets the session token that a response set, if any
+func session(resp *http.Response) string {
+	for _, cookie := range resp.Cookies() {
+		if cookie.Name == auth.SessionCookieName {
+			return cookie.Value
+		}
+	}
+	return ""
+}
+
+func loginState(t *testing.T, cookie *http.Cookie) map[string]string {
+	t.Helper()
+
+	raw, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var state map[string]string
+	if err := json.Unmarshal(raw, &state); err != nil {
+		t.Fatal(err)
+	}
+	return state
+}
+
+func TestLogin(t *testing.T) {
+	l := newLoginTest(t, true)
+	defer l.Close()
+	l.db.GetUserForIdentityReturns("user-1", nil)
+
+	authorizeURL, cookie := l.startLogin()
+
+	//the provider is only sent the challenge, never the verifier
+	query := authorizeURL.Query()
+	state := loginState(t, cookie)
+	challenge := sha256.Sum256([]byte(state["verifier"]))
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") != base64.RawURLEncoding.EncodeToString(challenge[:]) {
+		t.Errorf("code challenge `%s` doesn't match the verifier", query.Get("code_challenge"))
+	}
+	if query.Get("state") != state["state"] || query.Get("nonce") != state["nonce"] {
+		t.Error("state and nonce weren't sent to the provider")
+	}
+
+	resp := l.serveAPI(l.authorize(authorizeURL).RequestURI(), cookie)
+	if resp.StatusCode != http.StatusFound || resp.Header.Get("Location") != "/accounts?view=all" {
+		t.Fatalf("callback responded %d to `%s`", resp.StatusCode, resp.Header.Get("Location"))
+	}
+
+	var claims auth.Authorization
+	_, err := jwt.ParseWithClaims(session(resp), &claims, func(*jwt.Token) (interface{}, error) {
+		return []byte(testSigningSecret), nil
+	})
+	if err != nil {
+		t.Fatalf("invalid session: %s", err)
+	}
+	if claims.UserUUID != "user-1" || claims.Email != "user@example.com" {
+		t.Errorf("session is for `%s` <%s>", claims.UserUUID, claims.Email)
+	}
+
+	if _, issuer, subject := l.db.GetUserForIdentityArgsForCall(0); issuer != l.provider.URL || subject != "mock-user" {
+		t.Errorf("looked up identity `%s` at `%s`", subject, issuer)
+	}
+	if l.db.LinkUserIdentityCallCount() != 0 {
+		t.Error("a known identity was linked again")
+	}
+
+	//the login state is cleared, so it can't be used twice
+	cleared := false
+	for _, c := range resp.Cookies() {
+		cleared = cleared || (c.Name == cookie.Name && c.MaxAge < 0)
+	}
+	if !cleared {
+		t.Error("the login state cookie wasn't cleared")
+	}
+}
+
+func TestLoginLinksVerifiedEmail(t *testing.T) {
+	l := newLoginTest(t, true)
+	defer l.Close()
+	l.db.GetUserForIdentityReturns("", db.ErrNoSuchUser)
+	l.db.LinkUserIdentityReturns("user-2", nil)
+
+	authorizeURL, cookie := l.startLogin()
+	resp := l.serveAPI(l.authorize(authorizeURL).RequestURI(), cookie)
+	if resp.StatusCode != http.StatusFound || session(resp) == "" {
+		t.Fatalf("callback responded %d without a session", resp.StatusCode)
+	}
+
+	if l.db.LinkUserIdentityCallCount() != 1 {
+		t.Fatal("the identity wasn't linked")
+	}
+	if _, issuer, subject, email := l.db.LinkUserIdentityArgsForCall(0); issuer != l.provider.URL || subject != "mock-user" || email != "user@example.com" {
+		t.Errorf("linked `%s` at `%s` by <%s>", subject, issuer, email)
+	}
+}
+
+func TestLoginUnverifiedEmail(t *testing.T) {
+	l := newLoginTest(t, false)
+	defer l.Close()
+	l.db.GetUserForIdentityReturns("", db.ErrNoSuchUser)
+
+	authorizeURL, cookie := l.startLogin()
+	resp := l.serveAPI(l.authorize(authorizeURL).RequestURI(), cookie)
+	if session(resp) != "" {
+		t.Error("a session was issued for an unverified email")
+	}
+	if l.db.LinkUserIdentityCallCount() != 0 {
+		t.Error("an identity was linked by an unverified email")
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != "not registered: user@example.com" {
+		t.Errorf("rendered %q", body)
+	}
+}
+
+func TestLoginTamperedState(t *testing.T) {
+	l := newLoginTest(t, true)
+	defer l.Close()
+	l.db.GetUserForIdentityReturns("user-1", nil)
+
+	authorizeURL, cookie := l.startLogin()
+	callbackURL := l.authorize(authorizeURL)
+	query := callbackURL.Query()
+	query.Set("state", "attacker-state")
+	callbackURL.RawQuery = query.Encode()
+
+	resp := l.serveAPI(callbackURL.RequestURI(), cookie)
+	if resp.StatusCode != http.StatusBadRequest || session(resp) != "" {
+		t.Errorf("callback with a tampered state responded %d", resp.StatusCode)
+	}
+	if l.db.GetUserForIdentityCallCount() != 0 {
+		t.Error("a user was looked up for a tampered login")
+	}
+}
+
+func TestLoginTamperedNonce(t *testing.T) {
+	l := newLoginTest(t, true)
+	defer l.Close()
+	l.db.GetUserForIdentityReturns("user-1", nil)
+
+	//the provider issues an ID token for a nonce other than the login's
+	authorizeURL, cookie := l.startLogin()
+	query := authorizeURL.Query()
+	query.Set("nonce", "attacker-nonce")
+	authorizeURL.RawQuery = query.Encode()
+
+	resp := l.serveAPI(l.authorize(authorizeURL).RequestURI(), cookie)
+	if resp.StatusCode != http.StatusUnauthorized || session(resp) != "" {
+		t.Errorf("callback with a tampered nonce responded %d", resp.StatusCode)
+	}
+	if l.db.GetUserForIdentityCallCount() != 0 {
+		t.Error("a user was looked up for a tampered login")
+	}
+}
+
+func TestLoginWrongVerifier(t *testing.T) {
+	l := newLoginTest(t, true)
+	defer l.Close()
+	l.db.GetUserForIdentityReturns("user-1", nil)
+
+	//a stolen code can't be redeemed without the login's verifier
+	authorizeURL, cookie := l.startLogin()
+	state := loginState(t, cookie)
+	state["verifier"] = "attacker-verifier"
+	raw, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cookie.Value = base64.RawURLEncoding.EncodeToString(raw)
+
+	resp := l.serveAPI(l.authorize(authorizeURL).RequestURI(), cookie)
+	if resp.StatusCode != http.StatusUnauthorized || session(resp) != "" {
+		t.Errorf("callback with the wrong verifier responded %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,70 @@
+package tools_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/lib/tools"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	for addr, want := range map[string]bool{
+		"93.184.216.34":   true,
+		"2606:4700::1111": true,
+		"127.0.0.1":       false,
+		"::1":             false,
+		"0.0.0.0":         false,
+		"10.1.2.3":        false,
+		"172.20.0.1":      false,
+		"192.168.1.1":     false,
+		"100.64.0.1":      false,
+		"169.254.169.254": false,
+		"fe80::1":         false,
+		"fd00::1":         false,
+		"::ffff:10.0.0.1": false,
+		"224.0.0.1":       false,
+	} {
+		if got := tools.IsPublicIP(net.ParseIP(addr)); got != want {
+			t.Errorf("IsPublicIP(%s) = %v, want %v", addr, got, want)
+		}
+	}
+}
+
+func TestIsPublicHost(t *testing.T) {
+	for host, want := range map[string]bool{
+		"example.com":          true,
+		"example.com:8443":     true,
+		"localhost":            false,
+		"LOCALHOST.":           false,
+		"api.localhost:443":    false,
+		"127.0.0.1:8080":       false,
+		"[::1]:443":            false,
+		"[::1]":                false,
+		"169.254.169.254":      false,
+		"[2606:4700::1111]:80": true,
+	} {
+		if got := tools.IsPublicHost(host); got != want {
+			t.Errorf("IsPublicHost(%s) = %v, want %v", host, got, want)
+		}
+	}
+}
+
+func TestPublicHTTPClientRefusesLoopback(t *testing.T) {
+	var called bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	_, err := tools.NewPublicHTTPClient(time.Second).Get(srv.URL)
+	if err == nil || called {
+		t.Fatalf("request to %s was allowed", srv.URL)
+	}
+	if !strings.Contains(err.Error(), tools.ErrNonPublicAddress.Error()) {
+		t.Errorf("unexpected error %v", err)
+	}
+}
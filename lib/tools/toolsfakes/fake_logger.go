@@ -0,0 +1,242 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package toolsfakes
+
+import (
+	"sync"
+
+	"github.com/xanderflood/plaid-ui/lib/tools"
+)
+
+type FakeLogger struct {
+	DebugfStub        func(string, ...interface{})
+	debugfMutex       sync.RWMutex
+	debugfArgsForCall []struct {
+		arg1 string
+		arg2 []interface{}
+	}
+	ErrorfStub        func(string, ...interface{})
+	errorfMutex       sync.RWMutex
+	errorfArgsForCall []struct {
+		arg1 string
+		arg2 []interface{}
+	}
+	InfofStub        func(string, ...interface{})
+	infofMutex       sync.RWMutex
+	infofArgsForCall []struct {
+		arg1 string
+		arg2 []interface{}
+	}
+	TracefStub        func(string, ...interface{})
+	tracefMutex       sync.RWMutex
+	tracefArgsForCall []struct {
+		arg1 string
+		arg2 []interface{}
+	}
+	WarningfStub        func(string, ...interface{})
+	warningfMutex       sync.RWMutex
+	warningfArgsForCall []struct {
+		arg1 string
+		arg2 []interface{}
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeLogger) Debugf(arg1 string, arg2 ...interface{}) {
+	fake.debugfMutex.Lock()
+	fake.debugfArgsForCall = append(fake.debugfArgsForCall, struct {
+		arg1 string
+		arg2 []interface{}
+	}{arg1, arg2})
+	stub := fake.DebugfStub
+	fake.recordInvocation("Debugf", []interface{}{arg1, arg2})
+	fake.debugfMutex.Unlock()
+	if stub != nil {
+		fake.DebugfStub(arg1, arg2...)
+	}
+}
+
+func (fake *FakeLogger) DebugfCallCount() int {
+	fake.debugfMutex.RLock()
+	defer fake.debugfMutex.RUnlock()
+	return len(fake.debugfArgsForCall)
+}
+
+func (fake *FakeLogger) DebugfCalls(stub func(string, ...interface{})) {
+	fake.debugfMutex.Lock()
+	defer fake.debugfMutex.Unlock()
+	fake.DebugfStub = stub
+}
+
+func (fake *FakeLogger) DebugfArgsForCall(i int) (string, []interface{}) {
+	fake.debugfMutex.RLock()
+	defer fake.debugfMutex.RUnlock()
+	argsForCall := fake.debugfArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLogger) Errorf(arg1 string, arg2 ...interface{}) {
+	fake.errorfMutex.Lock()
+	fake.errorfArgsForCall = append(fake.errorfArgsForCall, struct {
+		arg1 string
+		arg2 []interface{}
+	}{arg1, arg2})
+	stub := fake.ErrorfStub
+	fake.recordInvocation("Errorf", []interface{}{arg1, arg2})
+	fake.errorfMutex.Unlock()
+	if stub != nil {
+		fake.ErrorfStub(arg1, arg2...)
+	}
+}
+
+func (fake *FakeLogger) ErrorfCallCount() int {
+	fake.errorfMutex.RLock()
+	defer fake.errorfMutex.RUnlock()
+	return len(fake.errorfArgsForCall)
+}
+
+func (fake *FakeLogger) ErrorfCalls(stub func(string, ...interface{})) {
+	fake.errorfMutex.Lock()
+	defer fake.errorfMutex.Unlock()
+	fake.ErrorfStub = stub
+}
+
+func (fake *FakeLogger) ErrorfArgsForCall(i int) (string, []interface{}) {
+	fake.errorfMutex.RLock()
+	defer fake.errorfMutex.RUnlock()
+	argsForCall := fake.errorfArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLogger) Infof(arg1 string, arg2 ...interface{}) {
+	fake.infofMutex.Lock()
+	fake.infofArgsForCall = append(fake.infofArgsForCall, struct {
+		arg1 string
+		arg2 []interface{}
+	}{arg1, arg2})
+	stub := fake.InfofStub
+	fake.recordInvocation("Infof", []interface{}{arg1, arg2})
+	fake.infofMutex.Unlock()
+	if stub != nil {
+		fake.InfofStub(arg1, arg2...)
+	}
+}
+
+func (fake *FakeLogger) InfofCallCount() int {
+	fake.infofMutex.RLock()
+	defer fake.infofMutex.RUnlock()
+	return len(fake.infofArgsForCall)
+}
+
+func (fake *FakeLogger) InfofCalls(stub func(string, ...interface{})) {
+	fake.infofMutex.Lock()
+	defer fake.infofMutex.Unlock()
+	fake.InfofStub = stub
+}
+
+func (fake *FakeLogger) InfofArgsForCall(i int) (string, []interface{}) {
+	fake.infofMutex.RLock()
+	defer fake.infofMutex.RUnlock()
+	argsForCall := fake.infofArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLogger) Tracef(arg1 string, arg2 ...interface{}) {
+	fake.tracefMutex.Lock()
+	fake.tracefArgsForCall = append(fake.tracefArgsForCall, struct {
+		arg1 string
+		arg2 []interface{}
+	}{arg1, arg2})
+	stub := fake.TracefStub
+	fake.recordInvocation("Tracef", []interface{}{arg1, arg2})
+	fake.tracefMutex.Unlock()
+	if stub != nil {
+		fake.TracefStub(arg1, arg2...)
+	}
+}
+
+func (fake *FakeLogger) TracefCallCount() int {
+	fake.tracefMutex.RLock()
+	defer fake.tracefMutex.RUnlock()
+	return len(fake.tracefArgsForCall)
+}
+
+func (fake *FakeLogger) TracefCalls(stub func(string, ...interface{})) {
+	fake.tracefMutex.Lock()
+	defer fake.tracefMutex.Unlock()
+	fake.TracefStub = stub
+}
+
+func (fake *FakeLogger) TracefArgsForCall(i int) (string, []interface{}) {
+	fake.tracefMutex.RLock()
+	defer fake.tracefMutex.RUnlock()
+	argsForCall := fake.tracefArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLogger) Warningf(arg1 string, arg2 ...interface{}) {
+	fake.warningfMutex.Lock()
+	fake.warningfArgsForCall = append(fake.warningfArgsForCall, struct {
+		arg1 string
+		arg2 []interface{}
+	}{arg1, arg2})
+	stub := fake.WarningfStub
+	fake.recordInvocation("Warningf", []interface{}{arg1, arg2})
+	fake.warningfMutex.Unlock()
+	if stub != nil {
+		fake.WarningfStub(arg1, arg2...)
+	}
+}
+
+func (fake *FakeLogger) WarningfCallCount() int {
+	fake.warningfMutex.RLock()
+	defer fake.warningfMutex.RUnlock()
+	return len(fake.warningfArgsForCall)
+}
+
+func (fake *FakeLogger) WarningfCalls(stub func(string, ...interface{})) {
+	fake.warningfMutex.Lock()
+	defer fake.warningfMutex.Unlock()
+	fake.WarningfStub = stub
+}
+
+func (fake *FakeLogger) WarningfArgsForCall(i int) (string, []interface{}) {
+	fake.warningfMutex.RLock()
+	defer fake.warningfMutex.RUnlock()
+	argsForCall := fake.warningfArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeLogger) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.debugfMutex.RLock()
+	defer fake.debugfMutex.RUnlock()
+	fake.errorfMutex.RLock()
+	defer fake.errorfMutex.RUnlock()
+	fake.infofMutex.RLock()
+	defer fake.infofMutex.RUnlock()
+	fake.tracefMutex.RLock()
+	defer fake.tracefMutex.RUnlock()
+	fake.warningfMutex.RLock()
+	defer fake.warningfMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeLogger) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ tools.Logger = new(FakeLogger)
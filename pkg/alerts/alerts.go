@@ -0,0 +1,286 @@
+package alerts
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+	"github.com/xanderflood/plaid-ui/pkg/recurring"
+)
+
+//Input is the state of a user's data after a sync
+type Input struct {
+	Accounts []db.Account
+
+	//NewTransactions were inserted by the sync, and History holds all
+	//recent transactions, including the new ones
+	NewTransactions []db.Transaction
+	History         []db.Transaction
+
+	//Balances maps account UUIDs to their latest balance snapshot
+	Balances map[string]db.Balance
+
+	//LoginRequiredItems lists Plaid item IDs that need re-authentication
+	LoginRequiredItems []string
+
+	//Spending is this month's spending report, grouped by category
+	Spending []db.ReportRow
+
+	Now time.Time
+}
+
+//Trigger is an alert that fired, along with its notification
+type Trigger struct {
+	Alert db.Alert
+
+	//DedupKey identifies the triggering event within the alert
+	DedupKey string
+	Subject  string
+	Body     string
+}
+
+//Evaluator decides which alerts should fire
+//go:generate counterfeiter . Evaluator
+type Evaluator interface {
+	Evaluate(alerts []db.Alert, input Input) []Trigger
+}
+
+//EvaluatorAgent implements Evaluator
+type EvaluatorAgent struct {
+	detector recurring.Detector
+}
+
+//NewEvaluator creates a new EvaluatorAgent
+func NewEvaluator(detector recurring.Detector) EvaluatorAgent {
+	return EvaluatorAgent{detector: detector}
+}
+
+//Evaluate checks every alert against the input. The same event always
+//produces the same DedupKey, so callers can skip triggers that were
+//already delivered.
+func (e EvaluatorAgent) Evaluate(alerts []db.Alert, input Input) []Trigger {
+	names := map[string]string{}
+	for _, account := range input.Accounts {
+		names[account.UUID] = account.PlaidAccountName
+	}
+
+	var newSeries []recurring.Series
+	for _, alert := range alerts {
+		if alert.Kind == db.AlertKindNewRecurring {
+			newSeries = e.newSeries(input)
+			break
+		}
+	}
+
+	triggers := []Trigger{}
+	for _, alert := range alerts {
+		switch alert.Kind {
+		case db.AlertKindLargeTransaction:
+			triggers = append(triggers, largeTransactions(alert, input, names)...)
+		case db.AlertKindLowBalance:
+			triggers = append(triggers, lowBalances(alert, input, names)...)
+		case db.AlertKindNewRecurring:
+			triggers = append(triggers, newRecurring(alert, newSeries, names)...)
+		case db.AlertKindItemLoginRequired:
+			triggers = append(triggers, loginRequired(alert, input)...)
+		case db.AlertKindBudgetExceeded:
+			triggers = append(triggers, budgetExceeded(alert, input)...)
+		}
+	}
+	return triggers
+}
+
+//newSeries finds the recurring series that only exist because of the
+//new transactions
+func (e EvaluatorAgent) newSeries(input Input) []recurring.Series {
+	if len(input.NewTransactions) == 0 {
+		return nil
+	}
+
+	isNew := map[string]bool{}
+	for _, t := range input.NewTransactions {
+		isNew[t.UUID] = true
+	}
+
+	var before []db.Transaction
+	for _, t := range input.History {
+		if !isNew[t.UUID] {
+			before = append(before, t)
+		}
+	}
+
+	known := map[string]bool{}
+	for _, s := range e.detector.Detect(before, input.Now) {
+		known[seriesKey(s)] = true
+	}
+
+	var series []recurring.Series
+	for _, s := range e.detector.Detect(input.History, input.Now) {
+		if !known[seriesKey(s)] && s.Amount.Sign() > 0 {
+			series = append(series, s)
+		}
+	}
+	return series
+}
+
+func largeTransactions(alert db.Alert, input Input, names map[string]string) []Trigger {
+	if alert.Threshold == nil {
+		return nil
+	}
+
+	var triggers []Trigger
+	for _, t := range input.NewTransactions {
+		if !appliesTo(alert, t.AccountUUID) || t.Amount == nil || t.Amount.Cmp(alert.Threshold) <= 0 {
+			continue
+		}
+
+		triggers = append(triggers, Trigger{
+			Alert:    alert,
+			DedupKey: "transaction:" + t.UUID,
+			Subject:  fmt.Sprintf("Large transaction: %s %s at %s", formatAmount(t.Amount), t.ISOCurrencyCode, t.PlaidName),
+			Body: fmt.Sprintf("A transaction of %s %s at %s posted to %s on %s, which is over your limit of %s.",
+				formatAmount(t.Amount), t.ISOCurrencyCode, t.PlaidName, names[t.AccountUUID], t.Date, formatAmount(alert.Threshold)),
+		})
+	}
+	return triggers
+}
+
+func lowBalances(alert db.Alert, input Input, names map[string]string) []Trigger {
+	if alert.Threshold == nil {
+		return nil
+	}
+
+	//the current balance of a credit or loan account is the amount
+	//owed, so only depository accounts can run low
+	depository := map[string]bool{}
+	for _, account := range input.Accounts {
+		depository[account.UUID] = account.PlaidAccountType == plaidapi.AccountTypeDepository
+	}
+
+	var accountUUIDs []string
+	for uuid := range input.Balances {
+		if depository[uuid] {
+			accountUUIDs = append(accountUUIDs, uuid)
+		}
+	}
+	sort.Strings(accountUUIDs)
+
+	var triggers []Trigger
+	for _, uuid := range accountUUIDs {
+		balance := input.Balances[uuid]
+		if !appliesTo(alert, uuid) || balance.Current == nil || balance.Current.Cmp(alert.Threshold) >= 0 {
+			continue
+		}
+
+		//notify at most once a day while the balance stays low
+		triggers = append(triggers, Trigger{
+			Alert:    alert,
+			DedupKey: "balance:" + uuid + ":" + input.Now.Format(plaidapi.DateFormat),
+			Subject:  fmt.Sprintf("Low balance in %s", names[uuid]),
+			Body: fmt.Sprintf("The balance of %s is %s %s, which is under your limit of %s.",
+				names[uuid], formatAmount(balance.Current), balance.ISOCurrencyCode, formatAmount(alert.Threshold)),
+		})
+	}
+	return triggers
+}
+
+func newRecurring(alert db.Alert, series []recurring.Series, names map[string]string) []Trigger {
+	var triggers []Trigger
+	for _, s := range series {
+		if !appliesTo(alert, s.AccountUUID) {
+			continue
+		}
+
+		triggers = append(triggers, Trigger{
+			Alert:    alert,
+			DedupKey: "recurring:" + seriesKey(s),
+			Subject:  fmt.Sprintf("New recurring charge: %s", s.Name),
+			Body: fmt.Sprintf("%s looks like a new %s charge of about %s %s on %s. The next one is expected on %s.",
+				s.Name, s.Cadence, formatAmount(s.Amount), s.ISOCurrencyCode, names[s.AccountUUID], s.NextDate),
+		})
+	}
+	return triggers
+}
+
+func loginRequired(alert db.Alert, input Input) []Trigger {
+	institutions := map[string]string{}
+	for _, account := range input.Accounts {
+		if appliesTo(alert, account.UUID) {
+			institutions[account.PlaidItemID] = account.PlaidInstitutionName
+		}
+	}
+
+	var triggers []Trigger
+	for _, itemID := range input.LoginRequiredItems {
+		institution, ok := institutions[itemID]
+		if !ok {
+			continue
+		}
+
+		triggers = append(triggers, Trigger{
+			Alert:    alert,
+			DedupKey: "item:" + itemID + ":" + input.Now.Format(plaidapi.DateFormat),
+			Subject:  fmt.Sprintf("Please reconnect %s", institution),
+			Body:     fmt.Sprintf("Your connection to %s has expired, so its accounts won't update until you log in again.", institution),
+		})
+	}
+	return triggers
+}
+
+func budgetExceeded(alert db.Alert, input Input) []Trigger {
+	if alert.Threshold == nil {
+		return nil
+	}
+
+	//rows are kept apart by currency, so each is checked on its own
+	spent := map[string]*big.Float{}
+	var currencies []string
+	for _, row := range input.Spending {
+		if alert.Category != "" && row.Key != alert.Category {
+			continue
+		}
+		if _, ok := spent[row.ISOCurrencyCode]; !ok {
+			spent[row.ISOCurrencyCode] = new(big.Float)
+			currencies = append(currencies, row.ISOCurrencyCode)
+		}
+		spent[row.ISOCurrencyCode].Add(spent[row.ISOCurrencyCode], row.Expenses)
+	}
+	sort.Strings(currencies)
+
+	what := "Spending"
+	if alert.Category != "" {
+		what = fmt.Sprintf("Spending in %s", alert.Category)
+	}
+
+	month := input.Now.Format("2006-01")
+	var triggers []Trigger
+	for _, currency := range currencies {
+		if spent[currency].Cmp(alert.Threshold) <= 0 {
+			continue
+		}
+
+		triggers = append(triggers, Trigger{
+			Alert:    alert,
+			DedupKey: "budget:" + month + ":" + currency,
+			Subject:  fmt.Sprintf("%s is over budget for %s", what, month),
+			Body: fmt.Sprintf("%s has reached %s %s this month, which is over your budget of %s.",
+				what, formatAmount(spent[currency]), currency, formatAmount(alert.Threshold)),
+		})
+	}
+	return triggers
+}
+
+func appliesTo(alert db.Alert, accountUUID string) bool {
+	return alert.AccountUUID == "" || alert.AccountUUID == accountUUID
+}
+
+func seriesKey(s recurring.Series) string {
+	return s.AccountUUID + ":" + recurring.NormalizeName(s.Name)
+}
+
+func formatAmount(f *big.Float) string {
+	return f.Text('f', 2)
+}
@@ -0,0 +1,54 @@
+package alerts_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/pkg/alerts"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+	"github.com/xanderflood/plaid-ui/pkg/recurring/recurringfakes"
+)
+
+func TestLowBalanceDepositoryOnly(t *testing.T) {
+	evaluator := alerts.NewEvaluator(&recurringfakes.FakeDetector{})
+	input := alerts.Input{
+		Accounts: []db.Account{
+			{Model: db.Model{UUID: "checking"}, PlaidAccountName: "Checking", PlaidAccountType: plaidapi.AccountTypeDepository},
+			{Model: db.Model{UUID: "card"}, PlaidAccountName: "Card", PlaidAccountType: plaidapi.AccountTypeCredit},
+			{Model: db.Model{UUID: "mortgage"}, PlaidAccountName: "Mortgage", PlaidAccountType: plaidapi.AccountTypeLoan},
+		},
+		//a paid-down card and loan owe little, which isn't a low balance
+		Balances: map[string]db.Balance{
+			"checking": {AccountUUID: "checking", Current: big.NewFloat(40), ISOCurrencyCode: "USD"},
+			"card":     {AccountUUID: "card", Current: big.NewFloat(0), ISOCurrencyCode: "USD"},
+			"mortgage": {AccountUUID: "mortgage", Current: big.NewFloat(12), ISOCurrencyCode: "USD"},
+		},
+		Now: time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, tc := range []struct {
+		name    string
+		account string
+		want    int
+	}{
+		{"every account", "", 1},
+		{"checking", "checking", 1},
+		{"card", "card", 0},
+	} {
+		triggers := evaluator.Evaluate([]db.Alert{{
+			Model:       db.Model{UUID: "alert-1"},
+			Kind:        db.AlertKindLowBalance,
+			AccountUUID: tc.account,
+			Threshold:   big.NewFloat(100),
+		}}, input)
+		if len(triggers) != tc.want {
+			t.Errorf("%s: %d alerts fired, want %d: %v", tc.name, len(triggers), tc.want, triggers)
+			continue
+		}
+		if tc.want > 0 && triggers[0].Subject != "Low balance in Checking" {
+			t.Errorf("%s: subject = %q", tc.name, triggers[0].Subject)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package alertsfakes
+
+import (
+	"sync"
+
+	"github.com/xanderflood/plaid-ui/pkg/alerts"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+type FakeEvaluator struct {
+	EvaluateStub        func([]db.Alert, alerts.Input) []alerts.Trigger
+	evaluateMutex       sync.RWMutex
+	evaluateArgsForCall []struct {
+		arg1 []db.Alert
+		arg2 alerts.Input
+	}
+	evaluateReturns struct {
+		result1 []alerts.Trigger
+	}
+	evaluateReturnsOnCall map[int]struct {
+		result1 []alerts.Trigger
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeEvaluator) Evaluate(arg1 []db.Alert, arg2 alerts.Input) []alerts.Trigger {
+	var arg1Copy []db.Alert
+	if arg1 != nil {
+		arg1Copy = make([]db.Alert, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.evaluateMutex.Lock()
+	ret, specificReturn := fake.evaluateReturnsOnCall[len(fake.evaluateArgsForCall)]
+	fake.evaluateArgsForCall = append(fake.evaluateArgsForCall, struct {
+		arg1 []db.Alert
+		arg2 alerts.Input
+	}{arg1Copy, arg2})
+	stub := fake.EvaluateStub
+	fakeReturns := fake.evaluateReturns
+	fake.recordInvocation("Evaluate", []interface{}{arg1Copy, arg2})
+	fake.evaluateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeEvaluator) EvaluateCallCount() int {
+	fake.evaluateMutex.RLock()
+	defer fake.evaluateMutex.RUnlock()
+	return len(fake.evaluateArgsForCall)
+}
+
+func (fake *FakeEvaluator) EvaluateCalls(stub func([]db.Alert, alerts.Input) []alerts.Trigger) {
+	fake.evaluateMutex.Lock()
+	defer fake.evaluateMutex.Unlock()
+	fake.EvaluateStub = stub
+}
+
+func (fake *FakeEvaluator) EvaluateArgsForCall(i int) ([]db.Alert, alerts.Input) {
+	fake.evaluateMutex.RLock()
+	defer fake.evaluateMutex.RUnlock()
+	argsForCall := fake.evaluateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeEvaluator) EvaluateReturns(result1 []alerts.Trigger) {
+	fake.evaluateMutex.Lock()
+	defer fake.evaluateMutex.Unlock()
+	fake.EvaluateStub = nil
+	fake.evaluateReturns = struct {
+		result1 []alerts.Trigger
+	}{result1}
+}
+
+func (fake *FakeEvaluator) EvaluateReturnsOnCall(i int, result1 []alerts.Trigger) {
+	fake.evaluateMutex.Lock()
+	defer fake.evaluateMutex.Unlock()
+	fake.EvaluateStub = nil
+	if fake.evaluateReturnsOnCall == nil {
+		fake.evaluateReturnsOnCall = make(map[int]struct {
+			result1 []alerts.Trigger
+		})
+	}
+	fake.evaluateReturnsOnCall[i] = struct {
+		result1 []alerts.Trigger
+	}{result1}
+}
+
+func (fake *FakeEvaluator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.evaluateMutex.RLock()
+	defer fake.evaluateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeEvaluator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ alerts.Evaluator = new(FakeEvaluator)
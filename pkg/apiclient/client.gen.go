@@ -0,0 +1,1920 @@
+// Code generated by apiclient-gen from the API's OpenAPI document. DO NOT EDIT.
+
+package apiclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// APIVersion is the version of the API this client was generated from
+const APIVersion = "1.0.0"
+
+// Account is generated from the `Account` schema
+type Account struct {
+	Access               string     `json:"access,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+	DeletedAt            *time.Time `json:"deleted_at"`
+	Hidden               bool       `json:"hidden"`
+	Manual               bool       `json:"manual"`
+	ModifiedAt           time.Time  `json:"modified_at"`
+	PlaidAccountID       string     `json:"plaid_account_id"`
+	PlaidAccountName     string     `json:"plaid_account_name"`
+	PlaidAccountSubtype  string     `json:"plaid_account_subtype"`
+	PlaidAccountType     string     `json:"plaid_account_type"`
+	PlaidInstitutionLogo string     `json:"plaid_institution_logo"`
+	PlaidInstitutionName string     `json:"plaid_institution_name"`
+	PlaidInstitutionURL  string     `json:"plaid_institution_url"`
+	PlaidItemID          string     `json:"plaid_item_id"`
+	UserUUID             string     `json:"user_uuid"`
+	UUID                 string     `json:"uuid"`
+	WebhookConfigured    bool       `json:"webhook_configured"`
+}
+
+// Backfill is generated from the `Backfill` schema
+type Backfill struct {
+	EndDate    string `json:"end_date"`
+	Error      string `json:"error"`
+	Imported   int    `json:"imported"`
+	OldestDate string `json:"oldest_date"`
+	Percent    int    `json:"percent"`
+	StartDate  string `json:"start_date"`
+	Status     string `json:"status"`
+}
+
+// Item is generated from the `Item` schema
+type Item struct {
+	Access             string     `json:"access,omitempty"`
+	Backfill           Backfill   `json:"backfill"`
+	CreatedAt          time.Time  `json:"created_at"`
+	DeletedAt          *time.Time `json:"deleted_at"`
+	ErrorCode          string     `json:"error_code"`
+	InstitutionName    string     `json:"institution_name"`
+	LastSyncedAt       *time.Time `json:"last_synced_at"`
+	ModifiedAt         time.Time  `json:"modified_at"`
+	PlaidItemID        string     `json:"plaid_item_id"`
+	RefreshRequestedAt *time.Time `json:"refresh_requested_at"`
+	Status             string     `json:"status"`
+	UserUUID           string     `json:"user_uuid"`
+	UUID               string     `json:"uuid"`
+}
+
+// GetAccountsResponse is generated from the `GetAccountsResponse` schema
+type GetAccountsResponse struct {
+	Accounts []Account `json:"accounts"`
+	Items    []Item    `json:"items"`
+}
+
+// ManualAccountRequest is generated from the `ManualAccountRequest` schema
+type ManualAccountRequest struct {
+	InstitutionName string `json:"institution_name,omitempty"`
+	InstitutionURL  string `json:"institution_url,omitempty"`
+	Name            string `json:"name"`
+	Subtype         string `json:"subtype,omitempty"`
+	Type            string `json:"type"`
+}
+
+// CreateManualAccountResponse is generated from the `CreateManualAccountResponse` schema
+type CreateManualAccountResponse struct {
+	Account Account `json:"account"`
+}
+
+// GetAccountResponse is generated from the `GetAccountResponse` schema
+type GetAccountResponse struct {
+	Account Account `json:"account"`
+}
+
+// AccountUpdateRequest is generated from the `AccountUpdateRequest` schema
+type AccountUpdateRequest struct {
+	Hidden            *bool `json:"hidden,omitempty"`
+	WebhookConfigured *bool `json:"webhook_configured,omitempty"`
+}
+
+// UpdateAccountResponse is generated from the `UpdateAccountResponse` schema
+type UpdateAccountResponse struct {
+	Account Account `json:"account"`
+}
+
+// ExportStatementOFXParams holds the optional parameters of ExportStatementOFX
+type ExportStatementOFXParams struct {
+	StartDate string
+	EndDate   string
+}
+
+// ExportStatementQFXParams holds the optional parameters of ExportStatementQFX
+type ExportStatementQFXParams struct {
+	StartDate string
+	EndDate   string
+}
+
+// ExportStatementQIFParams holds the optional parameters of ExportStatementQIF
+type ExportStatementQIFParams struct {
+	StartDate string
+	EndDate   string
+}
+
+// ImportStatementResponse is generated from the `ImportStatementResponse` schema
+type ImportStatementResponse struct {
+	Inserted int `json:"inserted"`
+	Skipped  int `json:"skipped"`
+}
+
+// AddPlaidItemResponse is generated from the `AddPlaidItemResponse` schema
+type AddPlaidItemResponse struct {
+	AccessToken string `json:"access_token"`
+	ItemID      string `json:"item_id"`
+}
+
+// GetAuditEventsParams holds the optional parameters of GetAuditEvents
+type GetAuditEventsParams struct {
+	ActorID     string
+	SubjectType string
+	SubjectID   string
+	Action      string
+	Since       string
+	Until       string
+	Before      string
+	Limit       int
+}
+
+// AuditEvent is generated from the `AuditEvent` schema
+type AuditEvent struct {
+	Action         string      `json:"action"`
+	ActorTokenUUID string      `json:"actor_token_uuid,omitempty"`
+	ActorUUID      string      `json:"actor_uuid"`
+	After          interface{} `json:"after,omitempty"`
+	Before         interface{} `json:"before,omitempty"`
+	CreatedAt      time.Time   `json:"created_at"`
+	Ip             string      `json:"ip"`
+	RequestID      string      `json:"request_id"`
+	SubjectType    string      `json:"subject_type"`
+	SubjectUUID    string      `json:"subject_uuid"`
+	UUID           string      `json:"uuid"`
+}
+
+// GetAuditEventsResponse is generated from the `GetAuditEventsResponse` schema
+type GetAuditEventsResponse struct {
+	Events     []AuditEvent `json:"events"`
+	NextCursor string       `json:"next_cursor"`
+}
+
+// GetJobRunsParams holds the optional parameters of GetJobRuns
+type GetJobRunsParams struct {
+	Job   string
+	Limit int
+}
+
+// JobRun is generated from the `JobRun` schema
+type JobRun struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+	Error      string     `json:"error"`
+	FinishedAt *time.Time `json:"finished_at"`
+	ItemUUID   string     `json:"item_uuid,omitempty"`
+	ModifiedAt time.Time  `json:"modified_at"`
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	UserUUID   string     `json:"user_uuid,omitempty"`
+	UUID       string     `json:"uuid"`
+}
+
+// GetJobRunsResponse is generated from the `GetJobRunsResponse` schema
+type GetJobRunsResponse struct {
+	Runs []JobRun `json:"runs"`
+}
+
+// JobInfo is generated from the `JobInfo` schema
+type JobInfo struct {
+	Name      string    `json:"name"`
+	NextRunAt time.Time `json:"next_run_at"`
+	Schedule  string    `json:"schedule"`
+}
+
+// GetJobsResponse is generated from the `GetJobsResponse` schema
+type GetJobsResponse struct {
+	Jobs []JobInfo `json:"jobs"`
+}
+
+// RegistrationRequest is generated from the `RegistrationRequest` schema
+type RegistrationRequest struct {
+	Email    string `json:"email"`
+	Role     string `json:"role,omitempty"`
+	UserUUID string `json:"user_uuid"`
+}
+
+// RegisterUserResponse is generated from the `RegisterUserResponse` schema
+type RegisterUserResponse struct {
+	Email    string `json:"email"`
+	Role     string `json:"role"`
+	UserUUID string `json:"user_uuid"`
+}
+
+// GetUsersParams holds the optional parameters of GetUsers
+type GetUsersParams struct {
+	Query              string
+	IncludeDeactivated string
+	After              string
+	Limit              int
+}
+
+// User is generated from the `User` schema
+type User struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+	Email      string     `json:"email"`
+	ModifiedAt time.Time  `json:"modified_at"`
+	Role       string     `json:"role"`
+	UUID       string     `json:"uuid"`
+}
+
+// GetUsersResponse is generated from the `GetUsersResponse` schema
+type GetUsersResponse struct {
+	NextCursor string `json:"next_cursor"`
+	Users      []User `json:"users"`
+}
+
+// GetUserResponse is generated from the `GetUserResponse` schema
+type GetUserResponse struct {
+	Items []Item `json:"items"`
+	User  User   `json:"user"`
+}
+
+// UserUpdateRequest is generated from the `UserUpdateRequest` schema
+type UserUpdateRequest struct {
+	Email string `json:"email"`
+}
+
+// UpdateUserResponse is generated from the `UpdateUserResponse` schema
+type UpdateUserResponse struct {
+	User User `json:"user"`
+}
+
+// DeactivateUserResponse is generated from the `DeactivateUserResponse` schema
+type DeactivateUserResponse struct {
+	User User `json:"user"`
+}
+
+// GetUserItemsResponse is generated from the `GetUserItemsResponse` schema
+type GetUserItemsResponse struct {
+	Items []Item `json:"items"`
+}
+
+// ReactivateUserResponse is generated from the `ReactivateUserResponse` schema
+type ReactivateUserResponse struct {
+	User User `json:"user"`
+}
+
+// RoleRequest is generated from the `RoleRequest` schema
+type RoleRequest struct {
+	Role string `json:"role"`
+}
+
+// SetUserRoleResponse is generated from the `SetUserRoleResponse` schema
+type SetUserRoleResponse struct {
+	Role     string `json:"role"`
+	UserUUID string `json:"user_uuid"`
+}
+
+// WebhookDelivery is generated from the `WebhookDelivery` schema
+type WebhookDelivery struct {
+	Attempts       int        `json:"attempts"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeletedAt      *time.Time `json:"deleted_at"`
+	EndpointUUID   string     `json:"endpoint_uuid"`
+	EventType      string     `json:"event_type"`
+	LastError      string     `json:"last_error"`
+	LastStatusCode int        `json:"last_status_code"`
+	ModifiedAt     time.Time  `json:"modified_at"`
+	NextAttemptAt  *time.Time `json:"next_attempt_at"`
+	Payload        string     `json:"payload"`
+	Status         string     `json:"status"`
+	UserUUID       string     `json:"user_uuid"`
+	UUID           string     `json:"uuid"`
+}
+
+// GetUserWebhookDeliveriesResponse is generated from the `GetUserWebhookDeliveriesResponse` schema
+type GetUserWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+// Alert is generated from the `Alert` schema
+type Alert struct {
+	AccountUUID string     `json:"account_uuid"`
+	Category    string     `json:"category"`
+	Channel     string     `json:"channel"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeletedAt   *time.Time `json:"deleted_at"`
+	Kind        string     `json:"kind"`
+	ModifiedAt  time.Time  `json:"modified_at"`
+	Target      string     `json:"target"`
+	Threshold   *big.Float `json:"threshold"`
+	UserUUID    string     `json:"user_uuid"`
+	UUID        string     `json:"uuid"`
+}
+
+// GetAlertsResponse is generated from the `GetAlertsResponse` schema
+type GetAlertsResponse struct {
+	Alerts []Alert `json:"alerts"`
+}
+
+// AlertRequest is generated from the `AlertRequest` schema
+type AlertRequest struct {
+	AccountUUID string      `json:"account_uuid,omitempty"`
+	Category    string      `json:"category,omitempty"`
+	Channel     string      `json:"channel"`
+	Kind        string      `json:"kind"`
+	Target      string      `json:"target"`
+	Threshold   json.Number `json:"threshold,omitempty"`
+}
+
+// CreateAlertResponse is generated from the `CreateAlertResponse` schema
+type CreateAlertResponse struct {
+	Alert Alert `json:"alert"`
+}
+
+// AlertDelivery is generated from the `AlertDelivery` schema
+type AlertDelivery struct {
+	AlertUUID  string     `json:"alert_uuid"`
+	Body       string     `json:"body"`
+	CreatedAt  time.Time  `json:"created_at"`
+	DedupKey   string     `json:"dedup_key"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+	Error      string     `json:"error"`
+	ModifiedAt time.Time  `json:"modified_at"`
+	Status     string     `json:"status"`
+	Subject    string     `json:"subject"`
+	UserUUID   string     `json:"user_uuid"`
+	UUID       string     `json:"uuid"`
+}
+
+// GetAlertDeliveriesResponse is generated from the `GetAlertDeliveriesResponse` schema
+type GetAlertDeliveriesResponse struct {
+	Deliveries []AlertDelivery `json:"deliveries"`
+}
+
+// Mapping is generated from the `Mapping` schema
+type Mapping struct {
+	Accounts        map[string]string `json:"accounts,omitempty"`
+	Categories      map[string]string `json:"categories,omitempty"`
+	DefaultCurrency string            `json:"default_currency,omitempty"`
+	DefaultExpense  string            `json:"default_expense,omitempty"`
+	DefaultIncome   string            `json:"default_income,omitempty"`
+	Roots           map[string]string `json:"roots,omitempty"`
+}
+
+// GetForecastParams holds the optional parameters of GetForecast
+type GetForecastParams struct {
+	Days      int
+	Threshold string
+}
+
+// Day is generated from the `Day` schema
+type Day struct {
+	Balance *big.Float `json:"balance"`
+	Date    string     `json:"date"`
+}
+
+// AccountForecast is generated from the `AccountForecast` schema
+type AccountForecast struct {
+	AccountName     string     `json:"account_name"`
+	AccountUUID     string     `json:"account_uuid"`
+	BalanceDate     string     `json:"balance_date"`
+	Days            []Day      `json:"days"`
+	ISOCurrencyCode string     `json:"iso_currency_code"`
+	LowDays         []string   `json:"low_days"`
+	StartingBalance *big.Float `json:"starting_balance"`
+}
+
+// ExpectedTransaction is generated from the `ExpectedTransaction` schema
+type ExpectedTransaction struct {
+	AccountUUID string     `json:"account_uuid"`
+	Amount      *big.Float `json:"amount"`
+	Cadence     string     `json:"cadence"`
+	Date        string     `json:"date"`
+	Name        string     `json:"name"`
+}
+
+// Forecast is generated from the `Forecast` schema
+type Forecast struct {
+	Accounts             []AccountForecast     `json:"accounts"`
+	EndDate              string                `json:"end_date"`
+	ExpectedTransactions []ExpectedTransaction `json:"expected_transactions"`
+	StartDate            string                `json:"start_date"`
+	Threshold            *big.Float            `json:"threshold"`
+}
+
+// GetAccountsLegacyResponse is generated from the `GetAccountsLegacyResponse` schema
+type GetAccountsLegacyResponse struct {
+	Accounts []Account `json:"accounts"`
+	Items    []Item    `json:"items"`
+}
+
+// GraphQLRequest is generated from the `GraphQLRequest` schema
+type GraphQLRequest struct {
+	OperationName string                 `json:"operationName,omitempty"`
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Location is generated from the `Location` schema
+type Location struct {
+	Column int `json:"column"`
+	Line   int `json:"line"`
+}
+
+// GraphqlError is generated from the `GraphqlError` schema
+type GraphqlError struct {
+	Locations []Location    `json:"locations,omitempty"`
+	Message   string        `json:"message"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+// GraphQLResponse is generated from the `GraphQLResponse` schema
+type GraphQLResponse struct {
+	Data   *map[string]interface{} `json:"data,omitempty"`
+	Errors []GraphqlError          `json:"errors,omitempty"`
+}
+
+// GetItemsResponse is generated from the `GetItemsResponse` schema
+type GetItemsResponse struct {
+	Items []Item `json:"items"`
+}
+
+// GetItemResponse is generated from the `GetItemResponse` schema
+type GetItemResponse struct {
+	Accounts []Account `json:"accounts"`
+	Item     Item      `json:"item"`
+}
+
+// RefreshItemResponse is generated from the `RefreshItemResponse` schema
+type RefreshItemResponse struct {
+	Job JobRun `json:"job"`
+}
+
+// GetJobResponse is generated from the `GetJobResponse` schema
+type GetJobResponse struct {
+	Job JobRun `json:"job"`
+}
+
+// GetMyActivityParams holds the optional parameters of GetMyActivity
+type GetMyActivityParams struct {
+	Action string
+	Since  string
+	Until  string
+	Before string
+	Limit  int
+}
+
+// GetMyActivityResponse is generated from the `GetMyActivityResponse` schema
+type GetMyActivityResponse struct {
+	Events     []AuditEvent `json:"events"`
+	NextCursor string       `json:"next_cursor"`
+}
+
+// DigestPreferences is generated from the `DigestPreferences` schema
+type DigestPreferences struct {
+	Cadence    string     `json:"cadence"`
+	Enabled    bool       `json:"enabled"`
+	LastSentAt *time.Time `json:"last_sent_at"`
+}
+
+// GetDigestPreferencesResponse is generated from the `GetDigestPreferencesResponse` schema
+type GetDigestPreferencesResponse struct {
+	Preferences DigestPreferences `json:"preferences"`
+}
+
+// DigestPreferencesRequest is generated from the `DigestPreferencesRequest` schema
+type DigestPreferencesRequest struct {
+	Cadence string `json:"cadence,omitempty"`
+	Enabled *bool  `json:"enabled"`
+}
+
+// UpdateDigestPreferencesResponse is generated from the `UpdateDigestPreferencesResponse` schema
+type UpdateDigestPreferencesResponse struct {
+	Preferences DigestPreferences `json:"preferences"`
+}
+
+// GetCashflowReportParams holds the optional parameters of GetCashflowReport
+type GetCashflowReportParams struct {
+	Group       string
+	StartDate   string
+	EndDate     string
+	IfNoneMatch string
+}
+
+// ReportRow is generated from the `ReportRow` schema
+type ReportRow struct {
+	Count           int        `json:"count"`
+	Expenses        *big.Float `json:"expenses"`
+	Income          *big.Float `json:"income,omitempty"`
+	ISOCurrencyCode string     `json:"iso_currency_code"`
+	Key             string     `json:"key"`
+	Label           string     `json:"label"`
+	Net             *big.Float `json:"net,omitempty"`
+}
+
+// GetCashflowReportResponse is generated from the `GetCashflowReportResponse` schema
+type GetCashflowReportResponse struct {
+	EndDate   string      `json:"end_date"`
+	Group     string      `json:"group"`
+	Rows      []ReportRow `json:"rows"`
+	StartDate string      `json:"start_date"`
+}
+
+// GetSpendingReportParams holds the optional parameters of GetSpendingReport
+type GetSpendingReportParams struct {
+	Group       string
+	StartDate   string
+	EndDate     string
+	IfNoneMatch string
+}
+
+// GetSpendingReportResponse is generated from the `GetSpendingReportResponse` schema
+type GetSpendingReportResponse struct {
+	EndDate   string      `json:"end_date"`
+	Group     string      `json:"group"`
+	Rows      []ReportRow `json:"rows"`
+	StartDate string      `json:"start_date"`
+}
+
+// Share is generated from the `Share` schema
+type Share struct {
+	AcceptedAt   *time.Time `json:"accepted_at"`
+	AccountUUID  string     `json:"account_uuid,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	DeletedAt    *time.Time `json:"deleted_at"`
+	GranteeEmail string     `json:"grantee_email"`
+	GranteeUUID  string     `json:"grantee_uuid"`
+	ItemUUID     string     `json:"item_uuid,omitempty"`
+	Level        string     `json:"level"`
+	ModifiedAt   time.Time  `json:"modified_at"`
+	OwnerEmail   string     `json:"owner_email"`
+	OwnerUUID    string     `json:"owner_uuid"`
+	UUID         string     `json:"uuid"`
+}
+
+// GetSharesResponse is generated from the `GetSharesResponse` schema
+type GetSharesResponse struct {
+	SharedByMe   []Share `json:"shared_by_me"`
+	SharedWithMe []Share `json:"shared_with_me"`
+}
+
+// ShareRequest is generated from the `ShareRequest` schema
+type ShareRequest struct {
+	AccountID string `json:"account_id,omitempty"`
+	Email     string `json:"email"`
+	ItemID    string `json:"item_id,omitempty"`
+	Level     string `json:"level"`
+}
+
+// CreateShareResponse is generated from the `CreateShareResponse` schema
+type CreateShareResponse struct {
+	Share Share `json:"share"`
+}
+
+// AcceptShareResponse is generated from the `AcceptShareResponse` schema
+type AcceptShareResponse struct {
+	Share Share `json:"share"`
+}
+
+// PersonalAccessToken is generated from the `PersonalAccessToken` schema
+type PersonalAccessToken struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	ModifiedAt time.Time  `json:"modified_at"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	UserUUID   string     `json:"user_uuid"`
+	UUID       string     `json:"uuid"`
+}
+
+// GetPersonalAccessTokensResponse is generated from the `GetPersonalAccessTokensResponse` schema
+type GetPersonalAccessTokensResponse struct {
+	Tokens []PersonalAccessToken `json:"tokens"`
+}
+
+// PersonalAccessTokenRequest is generated from the `PersonalAccessTokenRequest` schema
+type PersonalAccessTokenRequest struct {
+	ExpiresInDays int      `json:"expires_in_days,omitempty"`
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+}
+
+// CreatePersonalAccessTokenResponse is generated from the `CreatePersonalAccessTokenResponse` schema
+type CreatePersonalAccessTokenResponse struct {
+	Secret string              `json:"secret"`
+	Token  PersonalAccessToken `json:"token"`
+}
+
+// GetTransactionsParams holds the optional parameters of GetTransactions
+type GetTransactionsParams struct {
+	StartDate string
+	EndDate   string
+	AccountID string
+}
+
+// Transaction is generated from the `Transaction` schema
+type Transaction struct {
+	AccountUUID               string     `json:"account_uuid"`
+	Amount                    *big.Float `json:"amount"`
+	CreatedAt                 time.Time  `json:"created_at"`
+	Date                      string     `json:"date"`
+	DeletedAt                 *time.Time `json:"deleted_at"`
+	FITID                     string     `json:"fitid"`
+	ISOCurrencyCode           string     `json:"iso_currency_code"`
+	ModifiedAt                time.Time  `json:"modified_at"`
+	Note                      string     `json:"note"`
+	PlaidAccountID            string     `json:"plaid_account_id"`
+	PlaidAccountOwner         string     `json:"plaid_account_owner"`
+	PlaidCategoryID           string     `json:"plaid_category_id"`
+	PlaidName                 string     `json:"plaid_name"`
+	PlaidPending              bool       `json:"plaid_pending"`
+	PlaidPendingTransactionID string     `json:"plaid_pending_transaction_id"`
+	PlaidTransactionID        string     `json:"plaid_transaction_id"`
+	PlaidTransactionType      string     `json:"plaid_transaction_type"`
+	UserUUID                  string     `json:"user_uuid"`
+	UUID                      string     `json:"uuid"`
+}
+
+// GetTransactionsResponse is generated from the `GetTransactionsResponse` schema
+type GetTransactionsResponse struct {
+	EndDate      string        `json:"end_date"`
+	StartDate    string        `json:"start_date"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+// Attachment is generated from the `Attachment` schema
+type Attachment struct {
+	ContentType     string     `json:"content_type"`
+	CreatedAt       time.Time  `json:"created_at"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+	Filename        string     `json:"filename"`
+	ModifiedAt      time.Time  `json:"modified_at"`
+	Size            int64      `json:"size"`
+	TransactionUUID string     `json:"transaction_uuid"`
+	UserUUID        string     `json:"user_uuid"`
+	UUID            string     `json:"uuid"`
+}
+
+// Split is generated from the `Split` schema
+type Split struct {
+	Amount          *big.Float `json:"amount"`
+	Category        string     `json:"category"`
+	CreatedAt       time.Time  `json:"created_at"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+	ModifiedAt      time.Time  `json:"modified_at"`
+	Note            string     `json:"note"`
+	TransactionUUID string     `json:"transaction_uuid"`
+	UserUUID        string     `json:"user_uuid"`
+	UUID            string     `json:"uuid"`
+}
+
+// GetTransactionResponse is generated from the `GetTransactionResponse` schema
+type GetTransactionResponse struct {
+	Attachments        []Attachment `json:"attachments"`
+	Splits             []Split      `json:"splits"`
+	SplitsOutOfBalance bool         `json:"splits_out_of_balance"`
+	Tags               []string     `json:"tags"`
+	Transaction        Transaction  `json:"transaction"`
+}
+
+// TransactionUpdateRequest is generated from the `TransactionUpdateRequest` schema
+type TransactionUpdateRequest struct {
+	Note *string   `json:"note,omitempty"`
+	Tags *[]string `json:"tags,omitempty"`
+}
+
+// UpdateTransactionResponse is generated from the `UpdateTransactionResponse` schema
+type UpdateTransactionResponse struct {
+	Attachments        []Attachment `json:"attachments"`
+	Splits             []Split      `json:"splits"`
+	SplitsOutOfBalance bool         `json:"splits_out_of_balance"`
+	Tags               []string     `json:"tags"`
+	Transaction        Transaction  `json:"transaction"`
+}
+
+// UploadAttachmentResponse is generated from the `UploadAttachmentResponse` schema
+type UploadAttachmentResponse struct {
+	Attachment Attachment `json:"attachment"`
+}
+
+// DeleteAttachmentResponse is generated from the `DeleteAttachmentResponse` schema
+type DeleteAttachmentResponse struct {
+	AttachmentUUID string `json:"attachment_uuid"`
+}
+
+// SplitRequest is generated from the `SplitRequest` schema
+type SplitRequest struct {
+	Amount   json.Number `json:"amount"`
+	Category string      `json:"category"`
+	Note     string      `json:"note,omitempty"`
+}
+
+// SplitTransactionRequest is generated from the `SplitTransactionRequest` schema
+type SplitTransactionRequest struct {
+	Splits []SplitRequest `json:"splits,omitempty"`
+}
+
+// SplitTransactionResponse is generated from the `SplitTransactionResponse` schema
+type SplitTransactionResponse struct {
+	Attachments        []Attachment `json:"attachments"`
+	Splits             []Split      `json:"splits"`
+	SplitsOutOfBalance bool         `json:"splits_out_of_balance"`
+	Tags               []string     `json:"tags"`
+	Transaction        Transaction  `json:"transaction"`
+}
+
+// Transfer is generated from the `Transfer` schema
+type Transfer struct {
+	CreatedAt              time.Time  `json:"created_at"`
+	DeletedAt              *time.Time `json:"deleted_at"`
+	InflowTransactionUUID  string     `json:"inflow_transaction_uuid"`
+	ModifiedAt             time.Time  `json:"modified_at"`
+	OutflowTransactionUUID string     `json:"outflow_transaction_uuid"`
+	Status                 string     `json:"status"`
+	UserUUID               string     `json:"user_uuid"`
+	UUID                   string     `json:"uuid"`
+}
+
+// GetTransfersResponse is generated from the `GetTransfersResponse` schema
+type GetTransfersResponse struct {
+	Transfers []Transfer `json:"transfers"`
+}
+
+// DetectTransfersResponse is generated from the `DetectTransfersResponse` schema
+type DetectTransfersResponse struct {
+	Transfers []Transfer `json:"transfers"`
+}
+
+// WebhookEndpoint is generated from the `WebhookEndpoint` schema
+type WebhookEndpoint struct {
+	CreatedAt  time.Time  `json:"created_at"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+	Events     []string   `json:"events"`
+	ModifiedAt time.Time  `json:"modified_at"`
+	URL        string     `json:"url"`
+	UserUUID   string     `json:"user_uuid"`
+	UUID       string     `json:"uuid"`
+}
+
+// GetWebhookEndpointsResponse is generated from the `GetWebhookEndpointsResponse` schema
+type GetWebhookEndpointsResponse struct {
+	Endpoints []WebhookEndpoint `json:"endpoints"`
+}
+
+// WebhookEndpointRequest is generated from the `WebhookEndpointRequest` schema
+type WebhookEndpointRequest struct {
+	Events []string `json:"events,omitempty"`
+	URL    string   `json:"url"`
+}
+
+// CreateWebhookEndpointResponse is generated from the `CreateWebhookEndpointResponse` schema
+type CreateWebhookEndpointResponse struct {
+	Endpoint WebhookEndpoint `json:"endpoint"`
+	Secret   string          `json:"secret"`
+}
+
+// GetWebhookDeliveriesResponse is generated from the `GetWebhookDeliveriesResponse` schema
+type GetWebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+// GetAccounts calls GET /api/v1/accounts, which responds 200: List accounts and items
+func (c *Client) GetAccounts(ctx context.Context) (*GetAccountsResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/accounts",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetAccountsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateManualAccount calls POST /api/v1/accounts, which responds 200: Add an account that's populated by importing statements
+func (c *Client) CreateManualAccount(ctx context.Context, body *ManualAccountRequest) (*CreateManualAccountResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/accounts",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out CreateManualAccountResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteAccount calls DELETE /api/v1/accounts/{id}, which responds 204: Remove a manual account
+func (c *Client) DeleteAccount(ctx context.Context, id string) error {
+	req := request{
+		method: "DELETE",
+		path:   "/api/v1/accounts/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.do(ctx, req, nil)
+}
+
+// GetAccount calls GET /api/v1/accounts/{id}, which responds 200: Get an account
+func (c *Client) GetAccount(ctx context.Context, id string) (*GetAccountResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/accounts/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetAccountResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateAccount calls PATCH /api/v1/accounts/{id}, which responds 200: Change an account's settings
+func (c *Client) UpdateAccount(ctx context.Context, id string, body *AccountUpdateRequest) (*UpdateAccountResponse, error) {
+	req := request{
+		method: "PATCH",
+		path:   "/api/v1/accounts/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out UpdateAccountResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportStatementOFX calls GET /api/v1/accounts/{id}/export.ofx, which responds 200: Export an account's transactions as a OFX statement
+func (c *Client) ExportStatementOFX(ctx context.Context, id string, params *ExportStatementOFXParams) (io.ReadCloser, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/accounts/" + url.PathEscape(id) + "/export.ofx",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if params != nil {
+		if params.StartDate != "" {
+			req.query.Set("start_date", params.StartDate)
+		}
+		if params.EndDate != "" {
+			req.query.Set("end_date", params.EndDate)
+		}
+	}
+	return c.download(ctx, req)
+}
+
+// ExportStatementQFX calls GET /api/v1/accounts/{id}/export.qfx, which responds 200: Export an account's transactions as a QFX statement
+func (c *Client) ExportStatementQFX(ctx context.Context, id string, params *ExportStatementQFXParams) (io.ReadCloser, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/accounts/" + url.PathEscape(id) + "/export.qfx",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if params != nil {
+		if params.StartDate != "" {
+			req.query.Set("start_date", params.StartDate)
+		}
+		if params.EndDate != "" {
+			req.query.Set("end_date", params.EndDate)
+		}
+	}
+	return c.download(ctx, req)
+}
+
+// ExportStatementQIF calls GET /api/v1/accounts/{id}/export.qif, which responds 200: Export an account's transactions as a QIF statement
+func (c *Client) ExportStatementQIF(ctx context.Context, id string, params *ExportStatementQIFParams) (io.ReadCloser, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/accounts/" + url.PathEscape(id) + "/export.qif",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if params != nil {
+		if params.StartDate != "" {
+			req.query.Set("start_date", params.StartDate)
+		}
+		if params.EndDate != "" {
+			req.query.Set("end_date", params.EndDate)
+		}
+	}
+	return c.download(ctx, req)
+}
+
+// ImportStatement calls POST /api/v1/accounts/{id}/import, which responds 200: Import a statement file into a manual account
+func (c *Client) ImportStatement(ctx context.Context, id string, form MultipartForm) (*ImportStatementResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/accounts/" + url.PathEscape(id) + "/import",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	req.multipart = &form
+	req.fileField = "file"
+	var out ImportStatementResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AddPlaidItem calls POST /api/v1/add_plaid_item, which responds 200: Link a Plaid item and its accounts from a Link public token
+func (c *Client) AddPlaidItem(ctx context.Context, form url.Values) (*AddPlaidItemResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/add_plaid_item",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	req.form = form
+	var out AddPlaidItemResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAuditEvents calls GET /api/v1/admin/audit-events, which responds 200: Search the audit log, newest first
+func (c *Client) GetAuditEvents(ctx context.Context, params *GetAuditEventsParams) (*GetAuditEventsResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/admin/audit-events",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if params != nil {
+		if params.ActorID != "" {
+			req.query.Set("actor_id", params.ActorID)
+		}
+		if params.SubjectType != "" {
+			req.query.Set("subject_type", params.SubjectType)
+		}
+		if params.SubjectID != "" {
+			req.query.Set("subject_id", params.SubjectID)
+		}
+		if params.Action != "" {
+			req.query.Set("action", params.Action)
+		}
+		if params.Since != "" {
+			req.query.Set("since", params.Since)
+		}
+		if params.Until != "" {
+			req.query.Set("until", params.Until)
+		}
+		if params.Before != "" {
+			req.query.Set("before", params.Before)
+		}
+		if params.Limit != 0 {
+			req.query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	var out GetAuditEventsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetJobRuns calls GET /api/v1/admin/job-runs, which responds 200: List recent runs of scheduled jobs
+func (c *Client) GetJobRuns(ctx context.Context, params *GetJobRunsParams) (*GetJobRunsResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/admin/job-runs",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if params != nil {
+		if params.Job != "" {
+			req.query.Set("job", params.Job)
+		}
+		if params.Limit != 0 {
+			req.query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	var out GetJobRunsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetJobs calls GET /api/v1/admin/jobs, which responds 200: List scheduled jobs
+func (c *Client) GetJobs(ctx context.Context) (*GetJobsResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/admin/jobs",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetJobsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RegisterUser calls POST /api/v1/admin/register-user, which responds 200: Grant a user access to the service
+func (c *Client) RegisterUser(ctx context.Context, body *RegistrationRequest) (*RegisterUserResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/admin/register-user",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out RegisterUserResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUsers calls GET /api/v1/admin/users, which responds 200: List registered users in order of email, a page at a time
+func (c *Client) GetUsers(ctx context.Context, params *GetUsersParams) (*GetUsersResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/admin/users",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if params != nil {
+		if params.Query != "" {
+			req.query.Set("query", params.Query)
+		}
+		if params.IncludeDeactivated != "" {
+			req.query.Set("include_deactivated", params.IncludeDeactivated)
+		}
+		if params.After != "" {
+			req.query.Set("after", params.After)
+		}
+		if params.Limit != 0 {
+			req.query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	var out GetUsersResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteUser calls DELETE /api/v1/admin/users/{id}, which responds 204: Permanently delete a user and all of their data, unlinking their items from Plaid
+func (c *Client) DeleteUser(ctx context.Context, id string) error {
+	req := request{
+		method: "DELETE",
+		path:   "/api/v1/admin/users/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.do(ctx, req, nil)
+}
+
+// GetUser calls GET /api/v1/admin/users/{id}, which responds 200: Get a registered user and the health of their items
+func (c *Client) GetUser(ctx context.Context, id string) (*GetUserResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/admin/users/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetUserResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateUser calls PATCH /api/v1/admin/users/{id}, which responds 200: Change a user's email
+func (c *Client) UpdateUser(ctx context.Context, id string, body *UserUpdateRequest) (*UpdateUserResponse, error) {
+	req := request{
+		method: "PATCH",
+		path:   "/api/v1/admin/users/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out UpdateUserResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeactivateUser calls POST /api/v1/admin/users/{id}/deactivate, which responds 200: Lock a user out of the service, keeping their data
+func (c *Client) DeactivateUser(ctx context.Context, id string) (*DeactivateUserResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/admin/users/" + url.PathEscape(id) + "/deactivate",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out DeactivateUserResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUserItems calls GET /api/v1/admin/users/{id}/items, which responds 200: List any user's items and their status
+func (c *Client) GetUserItems(ctx context.Context, id string) (*GetUserItemsResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/admin/users/" + url.PathEscape(id) + "/items",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetUserItemsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReactivateUser calls POST /api/v1/admin/users/{id}/reactivate, which responds 200: Let a deactivated user back in
+func (c *Client) ReactivateUser(ctx context.Context, id string) (*ReactivateUserResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/admin/users/" + url.PathEscape(id) + "/reactivate",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out ReactivateUserResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetUserRole calls PUT /api/v1/admin/users/{id}/role, which responds 200: Change a user's role
+func (c *Client) SetUserRole(ctx context.Context, id string, body *RoleRequest) (*SetUserRoleResponse, error) {
+	req := request{
+		method: "PUT",
+		path:   "/api/v1/admin/users/" + url.PathEscape(id) + "/role",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out SetUserRoleResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUserWebhookDeliveries calls GET /api/v1/admin/users/{id}/webhook-deliveries, which responds 200: List any user's recent webhook deliveries, without their payloads
+func (c *Client) GetUserWebhookDeliveries(ctx context.Context, id string) (*GetUserWebhookDeliveriesResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/admin/users/" + url.PathEscape(id) + "/webhook-deliveries",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetUserWebhookDeliveriesResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAlerts calls GET /api/v1/alerts, which responds 200: List alerts
+func (c *Client) GetAlerts(ctx context.Context) (*GetAlertsResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/alerts",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetAlertsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateAlert calls POST /api/v1/alerts, which responds 201: Create an alert
+func (c *Client) CreateAlert(ctx context.Context, body *AlertRequest) (*CreateAlertResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/alerts",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out CreateAlertResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteAlert calls DELETE /api/v1/alerts/{id}, which responds 204: Remove an alert
+func (c *Client) DeleteAlert(ctx context.Context, id string) error {
+	req := request{
+		method: "DELETE",
+		path:   "/api/v1/alerts/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.do(ctx, req, nil)
+}
+
+// GetAlertDeliveries calls GET /api/v1/alerts/{id}/deliveries, which responds 200: List an alert's deliveries
+func (c *Client) GetAlertDeliveries(ctx context.Context, id string) (*GetAlertDeliveriesResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/alerts/" + url.PathEscape(id) + "/deliveries",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetAlertDeliveriesResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportLedger calls POST /api/v1/export/{format}, which responds 200: Export all transactions as a Beancount or ledger-cli file
+func (c *Client) ExportLedger(ctx context.Context, format string, body *Mapping) (io.ReadCloser, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/export/" + url.PathEscape(format),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	return c.download(ctx, req)
+}
+
+// GetForecast calls GET /api/v1/forecast, which responds 200: Project daily balances from recurring income and bills
+func (c *Client) GetForecast(ctx context.Context, params *GetForecastParams) (*Forecast, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/forecast",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if params != nil {
+		if params.Days != 0 {
+			req.query.Set("days", strconv.Itoa(params.Days))
+		}
+		if params.Threshold != "" {
+			req.query.Set("threshold", params.Threshold)
+		}
+	}
+	var out Forecast
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAccountsLegacy calls GET /api/v1/get_accounts, which responds 200: List accounts and items; use GET /accounts instead
+//
+// Deprecated: the route is kept for old clients.
+func (c *Client) GetAccountsLegacy(ctx context.Context) (*GetAccountsLegacyResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/get_accounts",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetAccountsLegacyResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GraphQL calls POST /api/v1/graphql, which responds 200: Run a GraphQL query over items, accounts and transactions
+func (c *Client) GraphQL(ctx context.Context, body *GraphQLRequest) (*GraphQLResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/graphql",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out GraphQLResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetGraphQLSchema calls GET /api/v1/graphql/schema, which responds 200: Describe the GraphQL schema in the schema definition language
+func (c *Client) GetGraphQLSchema(ctx context.Context) (io.ReadCloser, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/graphql/schema",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.download(ctx, req)
+}
+
+// GetItems calls GET /api/v1/items, which responds 200: List linked items
+func (c *Client) GetItems(ctx context.Context) (*GetItemsResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/items",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetItemsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteItem calls DELETE /api/v1/items/{id}, which responds 204: Unlink an item from Plaid and remove its accounts
+func (c *Client) DeleteItem(ctx context.Context, id string) error {
+	req := request{
+		method: "DELETE",
+		path:   "/api/v1/items/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.do(ctx, req, nil)
+}
+
+// GetItem calls GET /api/v1/items/{id}, which responds 200: Get an item and its accounts
+func (c *Client) GetItem(ctx context.Context, id string) (*GetItemResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/items/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetItemResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RefreshItem calls POST /api/v1/items/{id}/refresh, which responds 202: Sync an item now; poll the returned job for the outcome
+func (c *Client) RefreshItem(ctx context.Context, id string) (*RefreshItemResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/items/" + url.PathEscape(id) + "/refresh",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out RefreshItemResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetJob calls GET /api/v1/jobs/{id}, which responds 200: Get the status of an on-demand job
+func (c *Client) GetJob(ctx context.Context, id string) (*GetJobResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/jobs/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetJobResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteMe calls DELETE /api/v1/me, which responds 204: Unlink the user's items from Plaid and permanently delete the user and their data; needs a login
+func (c *Client) DeleteMe(ctx context.Context) error {
+	req := request{
+		method: "DELETE",
+		path:   "/api/v1/me",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.do(ctx, req, nil)
+}
+
+// GetMyActivity calls GET /api/v1/me/activity, which responds 200: List the actions the user has taken, and those taken on their account, newest first; needs a login
+func (c *Client) GetMyActivity(ctx context.Context, params *GetMyActivityParams) (*GetMyActivityResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/me/activity",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if params != nil {
+		if params.Action != "" {
+			req.query.Set("action", params.Action)
+		}
+		if params.Since != "" {
+			req.query.Set("since", params.Since)
+		}
+		if params.Until != "" {
+			req.query.Set("until", params.Until)
+		}
+		if params.Before != "" {
+			req.query.Set("before", params.Before)
+		}
+		if params.Limit != 0 {
+			req.query.Set("limit", strconv.Itoa(params.Limit))
+		}
+	}
+	var out GetMyActivityResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportMe calls POST /api/v1/me/export, which responds 200: Download a ZIP archive of everything stored about the user; needs a login
+func (c *Client) ExportMe(ctx context.Context) (io.ReadCloser, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/me/export",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.download(ctx, req)
+}
+
+// GetOpenAPI calls GET /api/v1/openapi.json, which responds 200: Get this document
+func (c *Client) GetOpenAPI(ctx context.Context) (map[string]interface{}, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/openapi.json",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out map[string]interface{}
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GetDigestPreferences calls GET /api/v1/preferences/digest, which responds 200: Get email digest preferences
+func (c *Client) GetDigestPreferences(ctx context.Context) (*GetDigestPreferencesResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/preferences/digest",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetDigestPreferencesResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateDigestPreferences calls PUT /api/v1/preferences/digest, which responds 200: Set email digest preferences
+func (c *Client) UpdateDigestPreferences(ctx context.Context, body *DigestPreferencesRequest) (*UpdateDigestPreferencesResponse, error) {
+	req := request{
+		method: "PUT",
+		path:   "/api/v1/preferences/digest",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out UpdateDigestPreferencesResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetCashflowReport calls GET /api/v1/reports/cashflow, which responds 200: Report income and expenses
+func (c *Client) GetCashflowReport(ctx context.Context, params *GetCashflowReportParams) (*GetCashflowReportResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/reports/cashflow",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if params != nil {
+		if params.Group != "" {
+			req.query.Set("group", params.Group)
+		}
+		if params.StartDate != "" {
+			req.query.Set("start_date", params.StartDate)
+		}
+		if params.EndDate != "" {
+			req.query.Set("end_date", params.EndDate)
+		}
+		if params.IfNoneMatch != "" {
+			req.header["If-None-Match"] = params.IfNoneMatch
+		}
+	}
+	var out GetCashflowReportResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetSpendingReport calls GET /api/v1/reports/spending, which responds 200: Report spending
+func (c *Client) GetSpendingReport(ctx context.Context, params *GetSpendingReportParams) (*GetSpendingReportResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/reports/spending",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if params != nil {
+		if params.Group != "" {
+			req.query.Set("group", params.Group)
+		}
+		if params.StartDate != "" {
+			req.query.Set("start_date", params.StartDate)
+		}
+		if params.EndDate != "" {
+			req.query.Set("end_date", params.EndDate)
+		}
+		if params.IfNoneMatch != "" {
+			req.header["If-None-Match"] = params.IfNoneMatch
+		}
+	}
+	var out GetSpendingReportResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetShares calls GET /api/v1/shares, which responds 200: List the shares made by and offered to the user; needs a login
+func (c *Client) GetShares(ctx context.Context) (*GetSharesResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/shares",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetSharesResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateShare calls POST /api/v1/shares, which responds 201: Invite someone by email to an account or item, read-only or to edit
+func (c *Client) CreateShare(ctx context.Context, body *ShareRequest) (*CreateShareResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/shares",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out CreateShareResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RevokeShare calls DELETE /api/v1/shares/{id}, which responds 204: Revoke a share, or decline or leave one offered to the user
+func (c *Client) RevokeShare(ctx context.Context, id string) error {
+	req := request{
+		method: "DELETE",
+		path:   "/api/v1/shares/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.do(ctx, req, nil)
+}
+
+// AcceptShare calls POST /api/v1/shares/{id}/accept, which responds 200: Accept a share offered to the user
+func (c *Client) AcceptShare(ctx context.Context, id string) (*AcceptShareResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/shares/" + url.PathEscape(id) + "/accept",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out AcceptShareResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetPersonalAccessTokens calls GET /api/v1/tokens, which responds 200: List personal access tokens; needs a login
+func (c *Client) GetPersonalAccessTokens(ctx context.Context) (*GetPersonalAccessTokensResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/tokens",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetPersonalAccessTokensResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreatePersonalAccessToken calls POST /api/v1/tokens, which responds 201: Create a personal access token; the token is only returned here
+func (c *Client) CreatePersonalAccessToken(ctx context.Context, body *PersonalAccessTokenRequest) (*CreatePersonalAccessTokenResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/tokens",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out CreatePersonalAccessTokenResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RevokePersonalAccessToken calls DELETE /api/v1/tokens/{id}, which responds 204: Revoke a personal access token
+func (c *Client) RevokePersonalAccessToken(ctx context.Context, id string) error {
+	req := request{
+		method: "DELETE",
+		path:   "/api/v1/tokens/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.do(ctx, req, nil)
+}
+
+// GetTransactions calls GET /api/v1/transactions, which responds 200: List transactions in a date range
+func (c *Client) GetTransactions(ctx context.Context, params *GetTransactionsParams) (*GetTransactionsResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/transactions",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if params != nil {
+		if params.StartDate != "" {
+			req.query.Set("start_date", params.StartDate)
+		}
+		if params.EndDate != "" {
+			req.query.Set("end_date", params.EndDate)
+		}
+		if params.AccountID != "" {
+			req.query.Set("account_id", params.AccountID)
+		}
+	}
+	var out GetTransactionsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTransaction calls GET /api/v1/transactions/{id}, which responds 200: Get a transaction with its tags, attachments and splits
+func (c *Client) GetTransaction(ctx context.Context, id string) (*GetTransactionResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/transactions/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetTransactionResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UpdateTransaction calls PATCH /api/v1/transactions/{id}, which responds 200: Set a transaction's note and tags
+func (c *Client) UpdateTransaction(ctx context.Context, id string, body *TransactionUpdateRequest) (*UpdateTransactionResponse, error) {
+	req := request{
+		method: "PATCH",
+		path:   "/api/v1/transactions/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out UpdateTransactionResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UploadAttachment calls POST /api/v1/transactions/{id}/attachments, which responds 200: Attach a file to a transaction
+func (c *Client) UploadAttachment(ctx context.Context, id string, form MultipartForm) (*UploadAttachmentResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/transactions/" + url.PathEscape(id) + "/attachments",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	req.multipart = &form
+	req.fileField = "file"
+	var out UploadAttachmentResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteAttachment calls DELETE /api/v1/transactions/{id}/attachments/{attachment_id}, which responds 200: Remove an attachment
+func (c *Client) DeleteAttachment(ctx context.Context, id string, attachmentID string) (*DeleteAttachmentResponse, error) {
+	req := request{
+		method: "DELETE",
+		path:   "/api/v1/transactions/" + url.PathEscape(id) + "/attachments/" + url.PathEscape(attachmentID),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out DeleteAttachmentResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DownloadAttachment calls GET /api/v1/transactions/{id}/attachments/{attachment_id}, which responds 200: Download an attachment
+func (c *Client) DownloadAttachment(ctx context.Context, id string, attachmentID string) (io.ReadCloser, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/transactions/" + url.PathEscape(id) + "/attachments/" + url.PathEscape(attachmentID),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.download(ctx, req)
+}
+
+// SplitTransaction calls PUT /api/v1/transactions/{id}/splits, which responds 200: Replace a transaction's splits
+func (c *Client) SplitTransaction(ctx context.Context, id string, body *SplitTransactionRequest) (*SplitTransactionResponse, error) {
+	req := request{
+		method: "PUT",
+		path:   "/api/v1/transactions/" + url.PathEscape(id) + "/splits",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out SplitTransactionResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTransfers calls GET /api/v1/transfers, which responds 200: List detected transfers
+func (c *Client) GetTransfers(ctx context.Context) (*GetTransfersResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/transfers",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetTransfersResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DetectTransfers calls POST /api/v1/transfers/detect, which responds 200: Pair up recent transactions that look like transfers
+func (c *Client) DetectTransfers(ctx context.Context) (*DetectTransfersResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/transfers/detect",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out DetectTransfersResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RejectTransfer calls DELETE /api/v1/transfers/{id}, which responds 204: Break a detected transfer apart
+func (c *Client) RejectTransfer(ctx context.Context, id string) error {
+	req := request{
+		method: "DELETE",
+		path:   "/api/v1/transfers/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.do(ctx, req, nil)
+}
+
+// ConfirmTransfer calls PUT /api/v1/transfers/{id}/confirm, which responds 204: Mark a detected transfer as correct
+func (c *Client) ConfirmTransfer(ctx context.Context, id string) error {
+	req := request{
+		method: "PUT",
+		path:   "/api/v1/transfers/" + url.PathEscape(id) + "/confirm",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.do(ctx, req, nil)
+}
+
+// GetWebhookEndpoints calls GET /api/v1/webhooks, which responds 200: List webhook endpoints
+func (c *Client) GetWebhookEndpoints(ctx context.Context) (*GetWebhookEndpointsResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/webhooks",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetWebhookEndpointsResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateWebhookEndpoint calls POST /api/v1/webhooks, which responds 201: Register a webhook endpoint; the signing secret is only returned here
+func (c *Client) CreateWebhookEndpoint(ctx context.Context, body *WebhookEndpointRequest) (*CreateWebhookEndpointResponse, error) {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/webhooks",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	if body != nil {
+		req.json = body
+	}
+	var out CreateWebhookEndpointResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteWebhookEndpoint calls DELETE /api/v1/webhooks/{id}, which responds 204: Remove a webhook endpoint
+func (c *Client) DeleteWebhookEndpoint(ctx context.Context, id string) error {
+	req := request{
+		method: "DELETE",
+		path:   "/api/v1/webhooks/" + url.PathEscape(id),
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.do(ctx, req, nil)
+}
+
+// GetWebhookDeliveries calls GET /api/v1/webhooks/{id}/deliveries, which responds 200: List an endpoint's recent deliveries
+func (c *Client) GetWebhookDeliveries(ctx context.Context, id string) (*GetWebhookDeliveriesResponse, error) {
+	req := request{
+		method: "GET",
+		path:   "/api/v1/webhooks/" + url.PathEscape(id) + "/deliveries",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	var out GetWebhookDeliveriesResponse
+	if err := c.do(ctx, req, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RedeliverWebhook calls POST /api/v1/webhooks/{id}/deliveries/{delivery_id}/redeliver, which responds 202: Send a past delivery again
+func (c *Client) RedeliverWebhook(ctx context.Context, id string, deliveryID string) error {
+	req := request{
+		method: "POST",
+		path:   "/api/v1/webhooks/" + url.PathEscape(id) + "/deliveries/" + url.PathEscape(deliveryID) + "/redeliver",
+		query:  url.Values{},
+		header: map[string]string{},
+	}
+	return c.do(ctx, req, nil)
+}
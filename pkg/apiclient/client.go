@@ -0,0 +1,194 @@
+//Package apiclient is a typed client for the plaid-ui API. The types and
+//methods in client.gen.go are generated from the OpenAPI document served
+//at /api/v1/openapi.json; run `go generate ./pkg/apiclient` after
+//changing a route or payload.
+package apiclient
+
+//go:generate go run ../../cmd/apiclient-gen -o client.gen.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//ErrNotModified is returned when a request with If-None-Match names a
+//copy that's still current
+var ErrNotModified = errors.New("not modified")
+
+//Error is a failure reported by the API
+type Error struct {
+	StatusCode int
+	Message    string `json:"error"`
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return http.StatusText(e.StatusCode)
+	}
+	return e.Message
+}
+
+//MultipartForm is the body of a file upload
+type MultipartForm struct {
+	Fields   map[string]string
+	FileName string
+	File     io.Reader
+}
+
+//Client calls the API with a user's JWT or personal access token
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+//NewClient creates a new Client for the service at baseURL, like
+//`https://plaid.example.com`. The token is sent as a bearer token.
+func NewClient(baseURL string, token string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+//request is built by the generated methods
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	header map[string]string
+
+	json      interface{}
+	form      url.Values
+	multipart *MultipartForm
+	fileField string
+}
+
+//do sends a request and decodes the JSON response into out, unless out
+//is nil
+func (c *Client) do(ctx context.Context, req request, out interface{}) error {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		_, err = io.Copy(ioutil.Discard, resp.Body)
+		return err
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return errors.Wrapf(err, "failed decoding response from %s %s", req.method, req.path)
+	}
+	return nil
+}
+
+//download sends a request and returns the response body, which the
+//caller must close
+func (c *Client) download(ctx context.Context, req request) (io.ReadCloser, error) {
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+//send sends a request and checks its status, closing the body of
+//failed responses
+func (c *Client) send(ctx context.Context, req request) (*http.Response, error) {
+	body, contentType, err := req.encode()
+	if err != nil {
+		return nil, err
+	}
+
+	u := c.baseURL + req.path
+	if len(req.query) > 0 {
+		u += "?" + req.query.Encode()
+	}
+
+	httpReq, err := http.NewRequest(req.method, u, body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed building request for %s %s", req.method, req.path)
+	}
+	httpReq = httpReq.WithContext(ctx)
+
+	if contentType != "" {
+		httpReq.Header.Set("Content-Type", contentType)
+	}
+	for name, value := range req.header {
+		httpReq.Header.Set(name, value)
+	}
+	if c.token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed calling %s %s", req.method, req.path)
+	}
+
+	if resp.StatusCode == http.StatusNotModified {
+		resp.Body.Close()
+		return nil, ErrNotModified
+	}
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+
+		apiErr := &Error{}
+		//the body isn't JSON when a proxy fails, so fall back on the status
+		_ = json.NewDecoder(resp.Body).Decode(apiErr)
+		apiErr.StatusCode = resp.StatusCode
+		return nil, apiErr
+	}
+	return resp, nil
+}
+
+func (req request) encode() (io.Reader, string, error) {
+	switch {
+	case req.json != nil:
+		data, err := json.Marshal(req.json)
+		if err != nil {
+			return nil, "", errors.Wrapf(err, "failed encoding request for %s %s", req.method, req.path)
+		}
+		return bytes.NewReader(data), "application/json", nil
+
+	case req.form != nil:
+		return strings.NewReader(req.form.Encode()), "application/x-www-form-urlencoded", nil
+
+	case req.multipart != nil:
+		var buf bytes.Buffer
+		w := multipart.NewWriter(&buf)
+		for name, value := range req.multipart.Fields {
+			if err := w.WriteField(name, value); err != nil {
+				return nil, "", errors.Wrap(err, "failed encoding form")
+			}
+		}
+		if req.multipart.File != nil {
+			part, err := w.CreateFormFile(req.fileField, req.multipart.FileName)
+			if err != nil {
+				return nil, "", errors.Wrap(err, "failed encoding form")
+			}
+			if _, err := io.Copy(part, req.multipart.File); err != nil {
+				return nil, "", errors.Wrap(err, "failed reading upload")
+			}
+		}
+		if err := w.Close(); err != nil {
+			return nil, "", errors.Wrap(err, "failed encoding form")
+		}
+		return &buf, w.FormDataContentType(), nil
+	}
+	return nil, "", nil
+}
@@ -0,0 +1,22 @@
+package blob
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+//ErrNotFound indicates that no blob is stored under a key
+var ErrNotFound = errors.New("blob not found")
+
+//ErrInvalidKey indicates that a key can't be used to address a blob
+var ErrInvalidKey = errors.New("invalid blob key")
+
+//Store is a minimal interface for storing opaque file content
+//go:generate counterfeiter . Store
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,95 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//LocalStore implements Store on the local filesystem
+type LocalStore struct {
+	root string
+}
+
+//NewLocalStore creates a new LocalStore rooted at the given directory
+func NewLocalStore(root string) LocalStore {
+	return LocalStore{root: root}
+}
+
+//path resolves a key to a file beneath the root, refusing any key
+//that could escape it
+func (s LocalStore) path(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return "", ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return "", ErrInvalidKey
+		}
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+//Put writes a blob atomically, replacing any existing content
+func (s LocalStore) Put(ctx context.Context, key string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return errors.Wrapf(err, "failed to create directory for blob `%s`", key)
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".upload-")
+	if err != nil {
+		return errors.Wrapf(err, "failed to create temporary file for blob `%s`", key)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "failed to write blob `%s`", key)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "failed to write blob `%s`", key)
+	}
+
+	return errors.Wrapf(os.Rename(tmp.Name(), path), "failed to write blob `%s`", key)
+}
+
+//Get opens a blob for reading
+func (s LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read blob `%s`", key)
+	}
+	return f, nil
+}
+
+//Delete removes a blob, succeeding if it doesn't exist
+func (s LocalStore) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to delete blob `%s`", key)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -37,6 +38,42 @@ CREATE TABLE IF NOT EXISTS "accounts"
 		return errors.Wrapf(err, "failed to ensure accounts table")
 	}
 
+	_, err = a.db.ExecContext(ctx, `ALTER TABLE "accounts" ADD COLUMN IF NOT EXISTS "manual" boolean NOT NULL DEFAULT false`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure manual column for accounts")
+	}
+
+	//each user who can see an account hides it from their own reports
+	_, err = a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "account_preferences"
+(	"user_uuid" UUID NOT NULL REFERENCES users(uuid),
+	"account_uuid" UUID NOT NULL REFERENCES accounts(uuid),
+	"hidden" boolean NOT NULL DEFAULT false,
+	PRIMARY KEY ("user_uuid", "account_uuid")
+)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure account_preferences table")
+	}
+
+	//accounts were once hidden for everyone they were shared with, which
+	//is kept as the owner's preference
+	_, err = a.db.ExecContext(ctx, `
+DO $$
+BEGIN
+	IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'accounts' AND column_name = 'hidden') THEN
+		INSERT INTO "account_preferences" ("user_uuid", "account_uuid", "hidden")
+		SELECT "user_uuid", "uuid", true FROM "accounts"
+		WHERE "hidden" AND "user_uuid" IS NOT NULL
+		ON CONFLICT DO NOTHING;
+
+		ALTER TABLE "accounts" DROP COLUMN "hidden";
+	END IF;
+END
+$$`)
+	if err != nil {
+		return errors.Wrap(err, "failed to move hidden column of accounts to account_preferences")
+	}
+
 	_, err = a.db.ExecContext(ctx, `CREATE INDEX ON accounts USING btree(plaid_item_id)`)
 	if err != nil {
 		return errors.Wrap(err, "failed to ensure plaid_item_id index for accounts")
@@ -63,11 +100,14 @@ INSERT INTO "accounts" (
 	"plaid_item_id",
 	"plaid_institution_name",
 	"plaid_institution_url",
-	"plaid_institution_logo"
+	"plaid_institution_logo",
+
+	"manual"
 ) VALUES (
 	$1, NOW(), NOW(),
 	$2, $3, $4, $5, $6,
-	$7, $8, $9, $10
+	$7, $8, $9, $10,
+	$11
 ) RETURNING "uuid"`,
 		userUUID,
 
@@ -81,6 +121,8 @@ INSERT INTO "accounts" (
 		acct.PlaidInstitutionName,
 		acct.PlaidInstitutionURL,
 		acct.PlaidInstitutionLogo,
+
+		acct.Manual,
 	)
 
 	var uuid string
@@ -122,16 +164,18 @@ WHERE
 	return accounts, errors.Wrapf(err, "failed to scan result of querying for all accounts")
 }
 
-//GetAccounts gets all the accounts
+//GetAccounts gets all the accounts the user owns or has been shared
 //TODO pagination
 //TODO write an encoded next token library
 func (a *DBAgent) GetAccounts(ctx context.Context, userUUID string) ([]Account, error) {
 	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
-SELECT %s FROM "accounts"
+SELECT %s, "level", COALESCE("preferences"."hidden", false) FROM "accounts"
+JOIN (SELECT "account_uuid", "level" FROM "account_access" WHERE "user_uuid" = $1) AS "access"
+	ON "access"."account_uuid" = "accounts"."uuid"
+LEFT JOIN (SELECT "account_uuid", "hidden" FROM "account_preferences" WHERE "user_uuid" = $1) AS "preferences"
+	ON "preferences"."account_uuid" = "accounts"."uuid"
 WHERE
 	"deleted_at" IS NULL
-	AND
-	"user_uuid" = $1
 `, StandardAccountFieldNameList),
 		userUUID,
 	)
@@ -142,7 +186,7 @@ WHERE
 	var accounts []Account
 	for rows.Next() {
 		var account Account
-		err = rows.Scan((&account).StandardFieldPointers()...)
+		err = rows.Scan(append((&account).StandardFieldPointers(), &account.Access, &account.Hidden)...)
 		if err != nil {
 			break
 		}
@@ -153,6 +197,61 @@ WHERE
 	return accounts, errors.Wrapf(err, "failed to scan result of querying for all accounts")
 }
 
+//GetAccount gets a single account the user owns or has been shared
+func (a *DBAgent) GetAccount(ctx context.Context, userUUID string, uuid string) (Account, error) {
+	var account Account
+	err := a.db.QueryRowContext(ctx, fmt.Sprintf(`
+SELECT %s, "level", COALESCE("preferences"."hidden", false) FROM "accounts"
+JOIN (SELECT "account_uuid", "level" FROM "account_access" WHERE "user_uuid" = $1) AS "access"
+	ON "access"."account_uuid" = "accounts"."uuid"
+LEFT JOIN (SELECT "account_uuid", "hidden" FROM "account_preferences" WHERE "user_uuid" = $1) AS "preferences"
+	ON "preferences"."account_uuid" = "accounts"."uuid"
+WHERE
+	"deleted_at" IS NULL
+	AND
+	"uuid" = $2
+`, StandardAccountFieldNameList),
+		userUUID,
+		uuid,
+	).Scan(append((&account).StandardFieldPointers(), &account.Access, &account.Hidden)...)
+	if err == sql.ErrNoRows {
+		return Account{}, ErrNoSuchAccount
+	}
+	if err != nil {
+		return Account{}, errors.Wrapf(err, "failed to get account `%s`", uuid)
+	}
+	return account, nil
+}
+
+//SetAccountHidden hides or unhides an account the user can see, for
+//that user only
+func (a *DBAgent) SetAccountHidden(ctx context.Context, userUUID string, uuid string, hidden bool) error {
+	res, err := a.db.ExecContext(ctx, `
+INSERT INTO "account_preferences" ("user_uuid", "account_uuid", "hidden")
+SELECT $2::uuid, "uuid", $1::boolean FROM "accounts"
+WHERE
+	"deleted_at" IS NULL
+	AND "uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $2)
+	AND "uuid" = $3
+ON CONFLICT ("user_uuid", "account_uuid") DO UPDATE SET "hidden" = EXCLUDED."hidden"`,
+		hidden,
+		userUUID,
+		uuid,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update hidden field for account `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to update hidden field for account `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchAccount
+	}
+	return nil
+}
+
 //ConfigureAccount mark an account as webhook-configured
 func (a *DBAgent) ConfigureAccount(ctx context.Context, userUUID string, uuid string) error {
 	return a.setAccountConfigured(ctx, userUUID, uuid, true)
@@ -163,17 +262,58 @@ func (a *DBAgent) DeconfigureAccount(ctx context.Context, userUUID string, uuid
 	return a.setAccountConfigured(ctx, userUUID, uuid, false)
 }
 
-//TODO make sure this can only access accounts owned by the user
 func (a *DBAgent) setAccountConfigured(ctx context.Context, userUUID string, uuid string, val bool) error {
-	_, err := a.db.ExecContext(ctx, userUUID, `
+	res, err := a.db.ExecContext(ctx, `
 UPDATE "accounts"
-SET "webhook_configured" = $1
-WHERE "arrivals"."identifier" = $2`,
+SET
+	"webhook_configured" = $1,
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $2
+	AND "uuid" = $3`,
 		val,
+		userUUID,
 		uuid,
 	)
 	if err != nil {
 		return errors.Wrapf(err, "failed to update webhook_configured field for account `%s`", uuid)
 	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to update webhook_configured field for account `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchAccount
+	}
+	return nil
+}
+
+//DeleteAccount removes one of the user's accounts
+func (a *DBAgent) DeleteAccount(ctx context.Context, userUUID string, uuid string) error {
+	res, err := a.db.ExecContext(ctx, `
+UPDATE "accounts"
+SET
+	"deleted_at" = NOW(),
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+	AND "uuid" = $2`,
+		userUUID,
+		uuid,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete account `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete account `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchAccount
+	}
 	return nil
 }
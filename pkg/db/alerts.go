@@ -0,0 +1,296 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+)
+
+//ErrNoSuchAlert indicates that an alert doesn't exist or isn't owned
+//by the user
+var ErrNoSuchAlert = errors.New("no such alert")
+
+//EnsureAlertsTables creates the alerts and alert_deliveries tables
+func (a *DBAgent) EnsureAlertsTables(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "alerts"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"user_uuid" UUID REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"kind" varchar NOT NULL,
+	"account_uuid" UUID REFERENCES accounts(uuid),
+	"category" varchar NOT NULL,
+	"threshold" varchar,
+	"channel" varchar NOT NULL,
+	"target" varchar NOT NULL,
+	PRIMARY KEY ("uuid")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure alerts table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "alerts_user_uuid_idx" ON alerts USING btree(user_uuid)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure user_uuid index for alerts")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "alert_deliveries"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"alert_uuid" UUID REFERENCES alerts(uuid),
+	"user_uuid" UUID REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"dedup_key" varchar NOT NULL,
+	"subject" varchar NOT NULL,
+	"body" varchar NOT NULL,
+	"status" varchar NOT NULL,
+	"error" varchar,
+	PRIMARY KEY ("uuid"),
+	UNIQUE ("alert_uuid", "dedup_key")
+)`)
+	return errors.Wrapf(err, "failed to ensure alert_deliveries table")
+}
+
+//CreateAlert inserts an alert into the table
+func (a *DBAgent) CreateAlert(ctx context.Context, alert Alert) (string, error) {
+	var accountUUID interface{}
+	if alert.AccountUUID != "" {
+		accountUUID = alert.AccountUUID
+	}
+
+	row := a.db.QueryRowContext(ctx, `
+INSERT INTO "alerts" (
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"kind",
+	"account_uuid",
+	"category",
+	"threshold",
+	"channel",
+	"target"
+) VALUES (
+	$1, NOW(), NOW(),
+	$2, $3, $4, $5, $6, $7
+) RETURNING "uuid"`,
+		alert.UserUUID,
+
+		alert.Kind,
+		accountUUID,
+		alert.Category,
+		formatAmount(alert.Threshold),
+		alert.Channel,
+		alert.Target,
+	)
+
+	var uuid string
+	err := row.Scan(&uuid)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to insert into alerts table")
+	}
+	return uuid, nil
+}
+
+//GetAlerts lists the user's alerts
+func (a *DBAgent) GetAlerts(ctx context.Context, userUUID string) ([]Alert, error) {
+	rows, err := a.db.QueryContext(ctx, `
+SELECT
+	"uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"kind",
+	"account_uuid",
+	"category",
+	"threshold",
+	"channel",
+	"target"
+FROM "alerts"
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+ORDER BY "created_at"`,
+		userUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get alerts from table")
+	}
+	defer rows.Close()
+
+	alerts := []Alert{}
+	for rows.Next() {
+		var alert Alert
+		var accountUUID, threshold sql.NullString
+		err := rows.Scan(
+			&alert.UUID,
+			&alert.UserUUID,
+			&alert.CreatedAt,
+			&alert.ModifiedAt,
+
+			&alert.Kind,
+			&accountUUID,
+			&alert.Category,
+			&threshold,
+			&alert.Channel,
+			&alert.Target,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan alerts for user `%s`", userUUID)
+		}
+
+		alert.AccountUUID = accountUUID.String
+		alert.Threshold = parseAmount(threshold)
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, errors.Wrapf(rows.Err(), "failed to scan alerts for user `%s`", userUUID)
+}
+
+//DeleteAlert soft-deletes one of the user's alerts
+func (a *DBAgent) DeleteAlert(ctx context.Context, userUUID string, uuid string) error {
+	res, err := a.db.ExecContext(ctx, `
+UPDATE "alerts"
+SET "deleted_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+	AND "uuid" = $2`,
+		userUUID,
+		uuid,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete alert `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete alert `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchAlert
+	}
+	return nil
+}
+
+//ClaimAlertDelivery records a pending delivery for an alert. It returns
+//an empty UUID if the same event has already been delivered, so that
+//concurrent syncs don't notify twice.
+func (a *DBAgent) ClaimAlertDelivery(ctx context.Context, delivery AlertDelivery) (string, error) {
+	row := a.db.QueryRowContext(ctx, `
+INSERT INTO "alert_deliveries" (
+	"alert_uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"dedup_key",
+	"subject",
+	"body",
+	"status"
+) VALUES (
+	$1, $2, NOW(), NOW(),
+	$3, $4, $5, $6
+) ON CONFLICT DO NOTHING
+RETURNING "uuid"`,
+		delivery.AlertUUID,
+		delivery.UserUUID,
+
+		delivery.DedupKey,
+		delivery.Subject,
+		delivery.Body,
+		AlertDeliveryStatusPending,
+	)
+
+	var uuid string
+	err := row.Scan(&uuid)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to insert into alert_deliveries table")
+	}
+	return uuid, nil
+}
+
+//SetAlertDeliveryStatus records the outcome of a delivery
+func (a *DBAgent) SetAlertDeliveryStatus(ctx context.Context, uuid string, status AlertDeliveryStatus, errMessage string) error {
+	_, err := a.db.ExecContext(ctx, `
+UPDATE "alert_deliveries"
+SET
+	"status" = $1,
+	"error" = $2,
+	"modified_at" = NOW()
+WHERE "uuid" = $3`,
+		status,
+		errMessage,
+		uuid,
+	)
+	return errors.Wrapf(err, "failed to update status of alert delivery `%s`", uuid)
+}
+
+//GetAlertDeliveries lists the delivery history of one of the user's
+//alerts, most recent first
+func (a *DBAgent) GetAlertDeliveries(ctx context.Context, userUUID string, alertUUID string) ([]AlertDelivery, error) {
+	rows, err := a.db.QueryContext(ctx, `
+SELECT
+	"uuid",
+	"alert_uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"dedup_key",
+	"subject",
+	"body",
+	"status",
+	"error"
+FROM "alert_deliveries"
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+	AND "alert_uuid" = $2
+ORDER BY "created_at" DESC`,
+		userUUID,
+		alertUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get deliveries for alert `%s`", alertUUID)
+	}
+	defer rows.Close()
+
+	deliveries := []AlertDelivery{}
+	for rows.Next() {
+		var delivery AlertDelivery
+		var errMessage sql.NullString
+		err := rows.Scan(
+			&delivery.UUID,
+			&delivery.AlertUUID,
+			&delivery.UserUUID,
+			&delivery.CreatedAt,
+			&delivery.ModifiedAt,
+
+			&delivery.DedupKey,
+			&delivery.Subject,
+			&delivery.Body,
+			&delivery.Status,
+			&errMessage,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan deliveries for alert `%s`", alertUUID)
+		}
+
+		delivery.Error = errMessage.String
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, errors.Wrapf(rows.Err(), "failed to scan deliveries for alert `%s`", alertUUID)
+}
@@ -0,0 +1,218 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+//ErrNoSuchAttachment indicates that an attachment doesn't exist or
+//isn't visible to the user
+var ErrNoSuchAttachment = errors.New("no such attachment")
+
+//EnsureAttachmentsTable EnsureAttachmentsTable
+func (a *DBAgent) EnsureAttachmentsTable(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "attachments"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"user_uuid" UUID REFERENCES users(uuid),
+	"transaction_uuid" UUID REFERENCES transactions(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"filename" varchar NOT NULL,
+	"content_type" varchar NOT NULL,
+	"size" bigint NOT NULL,
+	PRIMARY KEY ("uuid")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure attachments table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "attachments_transaction_uuid_idx" ON attachments USING btree(transaction_uuid)`)
+	return errors.Wrap(err, "failed to ensure transaction_uuid index for attachments")
+}
+
+//CreateAttachment records an attachment on a transaction the user can
+//edit
+func (a *DBAgent) CreateAttachment(ctx context.Context, attachment Attachment) (string, error) {
+	row := a.db.QueryRowContext(ctx, `
+INSERT INTO "attachments" (
+	"user_uuid",
+	"transaction_uuid",
+	"created_at",
+	"modified_at",
+
+	"filename",
+	"content_type",
+	"size"
+)
+SELECT $1, "uuid", NOW(), NOW(), $3::varchar, $4::varchar, $5::bigint
+FROM "transactions"
+WHERE
+	"deleted_at" IS NULL
+	AND "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1 AND "level" <> 'read')
+	AND "uuid" = $2
+RETURNING "uuid"`,
+		attachment.UserUUID,
+		attachment.TransactionUUID,
+
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.Size,
+	)
+
+	var uuid string
+	err := row.Scan(&uuid)
+	if err == sql.ErrNoRows {
+		return "", ErrNoSuchTransaction
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to insert into attachments table")
+	}
+	return uuid, nil
+}
+
+const attachmentFieldNameList = `
+	"uuid",
+	"user_uuid",
+	"transaction_uuid",
+	"created_at",
+	"modified_at",
+
+	"filename",
+	"content_type",
+	"size"
+`
+
+func (a *Attachment) fieldPointers() []interface{} {
+	return []interface{}{
+		&a.UUID,
+		&a.UserUUID,
+		&a.TransactionUUID,
+		&a.CreatedAt,
+		&a.ModifiedAt,
+
+		&a.Filename,
+		&a.ContentType,
+		&a.Size,
+	}
+}
+
+//GetAttachment gets a single attachment on a transaction the user can
+//see
+func (a *DBAgent) GetAttachment(ctx context.Context, userUUID string, uuid string) (Attachment, error) {
+	var attachment Attachment
+	err := a.db.QueryRowContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "attachments"
+WHERE
+	"deleted_at" IS NULL
+	AND "transaction_uuid" IN (
+		SELECT "uuid" FROM "transactions"
+		WHERE "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
+	)
+	AND "uuid" = $2`, attachmentFieldNameList),
+		userUUID,
+		uuid,
+	).Scan((&attachment).fieldPointers()...)
+	if err == sql.ErrNoRows {
+		return Attachment{}, ErrNoSuchAttachment
+	}
+	if err != nil {
+		return Attachment{}, errors.Wrapf(err, "failed to get attachment `%s`", uuid)
+	}
+	return attachment, nil
+}
+
+//GetAttachments lists the attachments on a transaction the user can see
+func (a *DBAgent) GetAttachments(ctx context.Context, userUUID string, transactionUUID string) ([]Attachment, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "attachments"
+WHERE
+	"deleted_at" IS NULL
+	AND "transaction_uuid" IN (
+		SELECT "uuid" FROM "transactions"
+		WHERE "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
+	)
+	AND "transaction_uuid" = $2
+ORDER BY "created_at"`, attachmentFieldNameList),
+		userUUID,
+		transactionUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get attachments for transaction `%s`", transactionUUID)
+	}
+	defer rows.Close()
+
+	attachments := []Attachment{}
+	for rows.Next() {
+		var attachment Attachment
+		if err := rows.Scan((&attachment).fieldPointers()...); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan attachments for transaction `%s`", transactionUUID)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, errors.Wrapf(rows.Err(), "failed to scan attachments for transaction `%s`", transactionUUID)
+}
+
+//DeleteAttachment removes an attachment from a transaction the user can
+//edit
+func (a *DBAgent) DeleteAttachment(ctx context.Context, userUUID string, uuid string) error {
+	res, err := a.db.ExecContext(ctx, `
+UPDATE "attachments"
+SET "deleted_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "transaction_uuid" IN (
+		SELECT "uuid" FROM "transactions"
+		WHERE "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1 AND "level" <> 'read')
+	)
+	AND "uuid" = $2`,
+		userUUID,
+		uuid,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete attachment `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete attachment `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchAttachment
+	}
+	return nil
+}
+
+//GetUserAttachments gets every attachment the user has uploaded,
+//whichever transactions they're on
+func (a *DBAgent) GetUserAttachments(ctx context.Context, userUUID string) ([]Attachment, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "attachments"
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+ORDER BY "created_at"`, attachmentFieldNameList),
+		userUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get attachments for user `%s`", userUUID)
+	}
+	defer rows.Close()
+
+	attachments := []Attachment{}
+	for rows.Next() {
+		var attachment Attachment
+		if err := rows.Scan((&attachment).fieldPointers()...); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan attachments for user `%s`", userUUID)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, errors.Wrapf(rows.Err(), "failed to scan attachments for user `%s`", userUUID)
+}
@@ -0,0 +1,233 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+//EnsureAuditEventsTable creates the audit_events table. Events outlive
+//the users they mention, so they don't reference the users table, and
+//a trigger rejects any change to events that have been recorded.
+func (a *DBAgent) EnsureAuditEventsTable(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "audit_events"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"created_at" timestamp NOT NULL,
+
+	"actor_uuid" UUID,
+	"action" varchar NOT NULL,
+	"subject_uuid" UUID,
+	PRIMARY KEY ("uuid")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure audit_events table")
+	}
+
+	//the only events recorded before events had subject types are user
+	//deletions, so the default fills those in
+	_, err = a.db.ExecContext(ctx, `
+ALTER TABLE "audit_events"
+	ADD COLUMN IF NOT EXISTS "actor_token_uuid" UUID,
+	ADD COLUMN IF NOT EXISTS "subject_type" varchar NOT NULL DEFAULT 'user',
+	ADD COLUMN IF NOT EXISTS "request_id" varchar NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS "ip" varchar NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS "before" jsonb,
+	ADD COLUMN IF NOT EXISTS "after" jsonb`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to add request details to audit_events table")
+	}
+	_, err = a.db.ExecContext(ctx, `ALTER TABLE "audit_events" ALTER COLUMN "subject_type" DROP DEFAULT`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to drop subject_type default for audit_events table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "audit_events_subject_uuid_idx" ON audit_events USING btree(subject_uuid)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure subject_uuid index for audit_events")
+	}
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "audit_events_actor_uuid_idx" ON audit_events USING btree(actor_uuid)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure actor_uuid index for audit_events")
+	}
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "audit_events_created_at_idx" ON audit_events USING btree(created_at, uuid)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure created_at index for audit_events")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+CREATE OR REPLACE FUNCTION "audit_events_append_only"() RETURNS trigger AS $$
+BEGIN
+	RAISE EXCEPTION 'audit_events is append-only';
+END
+$$ LANGUAGE plpgsql`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure append-only function for audit_events")
+	}
+	_, err = a.db.ExecContext(ctx, `
+DO $$
+BEGIN
+	IF NOT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = 'audit_events_append_only') THEN
+		CREATE TRIGGER "audit_events_append_only"
+		BEFORE UPDATE OR DELETE ON "audit_events"
+		FOR EACH ROW EXECUTE PROCEDURE "audit_events_append_only"();
+	END IF;
+END
+$$`)
+	return errors.Wrap(err, "failed to ensure append-only trigger for audit_events")
+}
+
+const auditEventFieldNameList = `
+	"uuid",
+	"created_at",
+
+	COALESCE("actor_uuid"::text, ''),
+	COALESCE("actor_token_uuid"::text, ''),
+	"action",
+	"subject_type",
+	COALESCE("subject_uuid"::text, ''),
+
+	"request_id",
+	"ip",
+	"before",
+	"after"
+`
+
+func scanAuditEvent(row scanner) (AuditEvent, error) {
+	var event AuditEvent
+	var before, after []byte
+	err := row.Scan(
+		&event.UUID,
+		&event.CreatedAt,
+
+		&event.ActorUUID,
+		&event.ActorTokenUUID,
+		&event.Action,
+		&event.SubjectType,
+		&event.SubjectUUID,
+
+		&event.RequestID,
+		&event.IP,
+		&before,
+		&after,
+	)
+	if before != nil {
+		event.Before = json.RawMessage(before)
+	}
+	if after != nil {
+		event.After = json.RawMessage(after)
+	}
+	return event, err
+}
+
+//nullJSON prepares a JSON document for storage in a nullable jsonb column
+func nullJSON(doc json.RawMessage) interface{} {
+	if doc == nil {
+		return nil
+	}
+	return string(doc)
+}
+
+//RecordAuditEvent appends an event to the audit log
+func (a *DBAgent) RecordAuditEvent(ctx context.Context, event AuditEvent) (string, error) {
+	row := a.db.QueryRowContext(ctx, `
+INSERT INTO "audit_events" (
+	"created_at",
+
+	"actor_uuid",
+	"actor_token_uuid",
+	"action",
+	"subject_type",
+	"subject_uuid",
+
+	"request_id",
+	"ip",
+	"before",
+	"after"
+) VALUES (
+	NOW(),
+	NULLIF($1, '')::uuid, NULLIF($2, '')::uuid, $3, $4, NULLIF($5, '')::uuid,
+	$6, $7, $8::jsonb, $9::jsonb
+) RETURNING "uuid"`,
+		event.ActorUUID,
+		event.ActorTokenUUID,
+		event.Action,
+		event.SubjectType,
+		event.SubjectUUID,
+
+		event.RequestID,
+		event.IP,
+		nullJSON(event.Before),
+		nullJSON(event.After),
+	)
+
+	var uuid string
+	if err := row.Scan(&uuid); err != nil {
+		return "", errors.Wrapf(err, "failed to record audit event `%s`", event.Action)
+	}
+	return uuid, nil
+}
+
+//GetAuditEvents gets a page of events from the audit log, newest first
+func (a *DBAgent) GetAuditEvents(ctx context.Context, filter AuditEventFilter) ([]AuditEvent, error) {
+	var args []interface{}
+	conditions := "TRUE"
+	if filter.ActorUUID != "" {
+		args = append(args, filter.ActorUUID)
+		conditions += fmt.Sprintf(` AND "actor_uuid" = $%d::uuid`, len(args))
+	}
+	if filter.Action != "" {
+		args = append(args, filter.Action)
+		conditions += fmt.Sprintf(` AND "action" = $%d`, len(args))
+	}
+	if filter.SubjectType != "" {
+		args = append(args, filter.SubjectType)
+		conditions += fmt.Sprintf(` AND "subject_type" = $%d`, len(args))
+	}
+	if filter.SubjectUUID != "" {
+		args = append(args, filter.SubjectUUID)
+		conditions += fmt.Sprintf(` AND "subject_uuid" = $%d::uuid`, len(args))
+	}
+	if filter.Involving != "" {
+		args = append(args, filter.Involving)
+		conditions += fmt.Sprintf(` AND ("actor_uuid" = $%d::uuid OR ("subject_type" = 'user' AND "subject_uuid" = $%d::uuid))`, len(args), len(args))
+	}
+	if filter.Since != nil {
+		args = append(args, *filter.Since)
+		conditions += fmt.Sprintf(` AND "created_at" >= $%d`, len(args))
+	}
+	if filter.Until != nil {
+		args = append(args, *filter.Until)
+		conditions += fmt.Sprintf(` AND "created_at" < $%d`, len(args))
+	}
+	if filter.Before != nil {
+		args = append(args, filter.Before.CreatedAt, filter.Before.UUID)
+		conditions += fmt.Sprintf(` AND ("created_at", "uuid") < ($%d, $%d::uuid)`, len(args)-1, len(args))
+	}
+	args = append(args, filter.Limit)
+
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "audit_events"
+WHERE %s
+ORDER BY "created_at" DESC, "uuid" DESC
+LIMIT $%d`, auditEventFieldNameList, conditions, len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get audit events from table")
+	}
+	defer rows.Close()
+
+	events := []AuditEvent{}
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan audit events")
+		}
+		events = append(events, event)
+	}
+
+	return events, errors.Wrapf(rows.Err(), "failed to scan audit events")
+}
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+)
+
+//EnsureBalancesTable EnsureBalancesTable
+func (a *DBAgent) EnsureBalancesTable(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "balances"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"account_uuid" UUID REFERENCES accounts(uuid),
+	"user_uuid" UUID REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"date" varchar NOT NULL,
+	"iso_currency_code" varchar,
+	"current" varchar,
+	"available" varchar,
+	"limit" varchar,
+	PRIMARY KEY ("uuid")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure balances table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "balances_account_uuid_date_idx" ON balances USING btree(account_uuid, date)`)
+	return errors.Wrap(err, "failed to ensure account_uuid index for balances")
+}
+
+//RecordBalance stores a balance snapshot for an account
+func (a *DBAgent) RecordBalance(ctx context.Context, balance Balance) (string, error) {
+	row := a.db.QueryRowContext(ctx, `
+INSERT INTO "balances" (
+	"account_uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"date",
+	"iso_currency_code",
+	"current",
+	"available",
+	"limit"
+) VALUES (
+	$1, $2, NOW(), NOW(),
+	$3, $4, $5, $6, $7
+) RETURNING "uuid"`,
+		balance.AccountUUID,
+		balance.UserUUID,
+
+		balance.Date,
+		balance.ISOCurrencyCode,
+		formatAmount(balance.Current),
+		formatAmount(balance.Available),
+		formatAmount(balance.Limit),
+	)
+
+	var uuid string
+	err := row.Scan(&uuid)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to insert into balances table")
+	}
+	return uuid, nil
+}
+
+//GetBalances gets the balance history for an account the user can see
+func (a *DBAgent) GetBalances(ctx context.Context, userUUID string, accountUUID string) ([]Balance, error) {
+	rows, err := a.db.QueryContext(ctx, `
+SELECT
+	"uuid",
+	"account_uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"date",
+	"iso_currency_code",
+	"current",
+	"available",
+	"limit"
+FROM "balances"
+WHERE
+	"deleted_at" IS NULL
+	AND "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
+	AND "account_uuid" = $2
+ORDER BY "date", "created_at"
+`,
+		userUUID,
+		accountUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get balances from table")
+	}
+	defer rows.Close()
+
+	var balances []Balance
+	for rows.Next() {
+		var balance Balance
+		var currency, current, available, limit sql.NullString
+		err = rows.Scan(
+			&balance.UUID,
+			&balance.AccountUUID,
+			&balance.UserUUID,
+			&balance.CreatedAt,
+			&balance.ModifiedAt,
+
+			&balance.Date,
+			&currency,
+			&current,
+			&available,
+			&limit,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan result of querying for balances for account %s", accountUUID)
+		}
+
+		balance.ISOCurrencyCode = currency.String
+		balance.Current = parseAmount(current)
+		balance.Available = parseAmount(available)
+		balance.Limit = parseAmount(limit)
+		balances = append(balances, balance)
+	}
+
+	return balances, errors.Wrapf(rows.Err(), "failed to scan result of querying for balances for account %s", accountUUID)
+}
@@ -0,0 +1,9114 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+type FakeDB struct {
+	AcceptShareStub        func(context.Context, string, string) error
+	acceptShareMutex       sync.RWMutex
+	acceptShareArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	acceptShareReturns struct {
+		result1 error
+	}
+	acceptShareReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CheckUserStub        func(context.Context, string) (bool, error)
+	checkUserMutex       sync.RWMutex
+	checkUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	checkUserReturns struct {
+		result1 bool
+		result2 error
+	}
+	checkUserReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ClaimAlertDeliveryStub        func(context.Context, db.AlertDelivery) (string, error)
+	claimAlertDeliveryMutex       sync.RWMutex
+	claimAlertDeliveryArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.AlertDelivery
+	}
+	claimAlertDeliveryReturns struct {
+		result1 string
+		result2 error
+	}
+	claimAlertDeliveryReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ClaimDueDigestsStub        func(context.Context, int) ([]db.DueDigest, error)
+	claimDueDigestsMutex       sync.RWMutex
+	claimDueDigestsArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	claimDueDigestsReturns struct {
+		result1 []db.DueDigest
+		result2 error
+	}
+	claimDueDigestsReturnsOnCall map[int]struct {
+		result1 []db.DueDigest
+		result2 error
+	}
+	ClaimDueWebhookDeliveriesStub        func(context.Context, int, time.Duration) ([]db.WebhookDelivery, error)
+	claimDueWebhookDeliveriesMutex       sync.RWMutex
+	claimDueWebhookDeliveriesArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+		arg3 time.Duration
+	}
+	claimDueWebhookDeliveriesReturns struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}
+	claimDueWebhookDeliveriesReturnsOnCall map[int]struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}
+	ClaimItemRefreshStub        func(context.Context, string, string, time.Duration) (bool, error)
+	claimItemRefreshMutex       sync.RWMutex
+	claimItemRefreshArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 time.Duration
+	}
+	claimItemRefreshReturns struct {
+		result1 bool
+		result2 error
+	}
+	claimItemRefreshReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	ConfigureAccountStub        func(context.Context, string, string) error
+	configureAccountMutex       sync.RWMutex
+	configureAccountArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	configureAccountReturns struct {
+		result1 error
+	}
+	configureAccountReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateAccountStub        func(context.Context, string, db.Account) (string, error)
+	createAccountMutex       sync.RWMutex
+	createAccountArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.Account
+	}
+	createAccountReturns struct {
+		result1 string
+		result2 error
+	}
+	createAccountReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	CreateAlertStub        func(context.Context, db.Alert) (string, error)
+	createAlertMutex       sync.RWMutex
+	createAlertArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.Alert
+	}
+	createAlertReturns struct {
+		result1 string
+		result2 error
+	}
+	createAlertReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	CreateAttachmentStub        func(context.Context, db.Attachment) (string, error)
+	createAttachmentMutex       sync.RWMutex
+	createAttachmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.Attachment
+	}
+	createAttachmentReturns struct {
+		result1 string
+		result2 error
+	}
+	createAttachmentReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	CreatePersonalAccessTokenStub        func(context.Context, db.PersonalAccessToken) (string, error)
+	createPersonalAccessTokenMutex       sync.RWMutex
+	createPersonalAccessTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.PersonalAccessToken
+	}
+	createPersonalAccessTokenReturns struct {
+		result1 string
+		result2 error
+	}
+	createPersonalAccessTokenReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	CreateShareStub        func(context.Context, db.Share) (string, error)
+	createShareMutex       sync.RWMutex
+	createShareArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.Share
+	}
+	createShareReturns struct {
+		result1 string
+		result2 error
+	}
+	createShareReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	CreateTransferStub        func(context.Context, db.Transfer) (string, error)
+	createTransferMutex       sync.RWMutex
+	createTransferArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.Transfer
+	}
+	createTransferReturns struct {
+		result1 string
+		result2 error
+	}
+	createTransferReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	CreateWebhookDeliveryStub        func(context.Context, db.WebhookDelivery) (string, error)
+	createWebhookDeliveryMutex       sync.RWMutex
+	createWebhookDeliveryArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.WebhookDelivery
+	}
+	createWebhookDeliveryReturns struct {
+		result1 string
+		result2 error
+	}
+	createWebhookDeliveryReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	CreateWebhookEndpointStub        func(context.Context, db.WebhookEndpoint) (string, error)
+	createWebhookEndpointMutex       sync.RWMutex
+	createWebhookEndpointArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.WebhookEndpoint
+	}
+	createWebhookEndpointReturns struct {
+		result1 string
+		result2 error
+	}
+	createWebhookEndpointReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	DeconfigureAccountStub        func(context.Context, string, string) error
+	deconfigureAccountMutex       sync.RWMutex
+	deconfigureAccountArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	deconfigureAccountReturns struct {
+		result1 error
+	}
+	deconfigureAccountReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteAccountStub        func(context.Context, string, string) error
+	deleteAccountMutex       sync.RWMutex
+	deleteAccountArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	deleteAccountReturns struct {
+		result1 error
+	}
+	deleteAccountReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteAlertStub        func(context.Context, string, string) error
+	deleteAlertMutex       sync.RWMutex
+	deleteAlertArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	deleteAlertReturns struct {
+		result1 error
+	}
+	deleteAlertReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteAttachmentStub        func(context.Context, string, string) error
+	deleteAttachmentMutex       sync.RWMutex
+	deleteAttachmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	deleteAttachmentReturns struct {
+		result1 error
+	}
+	deleteAttachmentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteItemStub        func(context.Context, string, string) error
+	deleteItemMutex       sync.RWMutex
+	deleteItemArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	deleteItemReturns struct {
+		result1 error
+	}
+	deleteItemReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteTransactionByPlaidIDStub        func(context.Context, string) (db.Transaction, error)
+	deleteTransactionByPlaidIDMutex       sync.RWMutex
+	deleteTransactionByPlaidIDArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteTransactionByPlaidIDReturns struct {
+		result1 db.Transaction
+		result2 error
+	}
+	deleteTransactionByPlaidIDReturnsOnCall map[int]struct {
+		result1 db.Transaction
+		result2 error
+	}
+	DeleteUserStub        func(context.Context, string) ([]db.Attachment, error)
+	deleteUserMutex       sync.RWMutex
+	deleteUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deleteUserReturns struct {
+		result1 []db.Attachment
+		result2 error
+	}
+	deleteUserReturnsOnCall map[int]struct {
+		result1 []db.Attachment
+		result2 error
+	}
+	DeleteWebhookEndpointStub        func(context.Context, string, string) error
+	deleteWebhookEndpointMutex       sync.RWMutex
+	deleteWebhookEndpointArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	deleteWebhookEndpointReturns struct {
+		result1 error
+	}
+	deleteWebhookEndpointReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureAccountsTableStub        func(context.Context) error
+	ensureAccountsTableMutex       sync.RWMutex
+	ensureAccountsTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureAccountsTableReturns struct {
+		result1 error
+	}
+	ensureAccountsTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureAlertsTablesStub        func(context.Context) error
+	ensureAlertsTablesMutex       sync.RWMutex
+	ensureAlertsTablesArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureAlertsTablesReturns struct {
+		result1 error
+	}
+	ensureAlertsTablesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureAttachmentsTableStub        func(context.Context) error
+	ensureAttachmentsTableMutex       sync.RWMutex
+	ensureAttachmentsTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureAttachmentsTableReturns struct {
+		result1 error
+	}
+	ensureAttachmentsTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureAuditEventsTableStub        func(context.Context) error
+	ensureAuditEventsTableMutex       sync.RWMutex
+	ensureAuditEventsTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureAuditEventsTableReturns struct {
+		result1 error
+	}
+	ensureAuditEventsTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureBalancesTableStub        func(context.Context) error
+	ensureBalancesTableMutex       sync.RWMutex
+	ensureBalancesTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureBalancesTableReturns struct {
+		result1 error
+	}
+	ensureBalancesTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureDigestPreferencesTableStub        func(context.Context) error
+	ensureDigestPreferencesTableMutex       sync.RWMutex
+	ensureDigestPreferencesTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureDigestPreferencesTableReturns struct {
+		result1 error
+	}
+	ensureDigestPreferencesTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureItemsTableStub        func(context.Context) error
+	ensureItemsTableMutex       sync.RWMutex
+	ensureItemsTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureItemsTableReturns struct {
+		result1 error
+	}
+	ensureItemsTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureJobRunsTableStub        func(context.Context) error
+	ensureJobRunsTableMutex       sync.RWMutex
+	ensureJobRunsTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureJobRunsTableReturns struct {
+		result1 error
+	}
+	ensureJobRunsTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsurePersonalAccessTokensTableStub        func(context.Context) error
+	ensurePersonalAccessTokensTableMutex       sync.RWMutex
+	ensurePersonalAccessTokensTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensurePersonalAccessTokensTableReturns struct {
+		result1 error
+	}
+	ensurePersonalAccessTokensTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureRolesTablesStub        func(context.Context) error
+	ensureRolesTablesMutex       sync.RWMutex
+	ensureRolesTablesArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureRolesTablesReturns struct {
+		result1 error
+	}
+	ensureRolesTablesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureSharesTablesStub        func(context.Context) error
+	ensureSharesTablesMutex       sync.RWMutex
+	ensureSharesTablesArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureSharesTablesReturns struct {
+		result1 error
+	}
+	ensureSharesTablesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureSplitsTableStub        func(context.Context) error
+	ensureSplitsTableMutex       sync.RWMutex
+	ensureSplitsTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureSplitsTableReturns struct {
+		result1 error
+	}
+	ensureSplitsTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureTagsTablesStub        func(context.Context) error
+	ensureTagsTablesMutex       sync.RWMutex
+	ensureTagsTablesArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureTagsTablesReturns struct {
+		result1 error
+	}
+	ensureTagsTablesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureTransactionsTableStub        func(context.Context) error
+	ensureTransactionsTableMutex       sync.RWMutex
+	ensureTransactionsTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureTransactionsTableReturns struct {
+		result1 error
+	}
+	ensureTransactionsTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureTransfersTableStub        func(context.Context) error
+	ensureTransfersTableMutex       sync.RWMutex
+	ensureTransfersTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureTransfersTableReturns struct {
+		result1 error
+	}
+	ensureTransfersTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureUserIdentitiesTableStub        func(context.Context) error
+	ensureUserIdentitiesTableMutex       sync.RWMutex
+	ensureUserIdentitiesTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureUserIdentitiesTableReturns struct {
+		result1 error
+	}
+	ensureUserIdentitiesTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureUsersTableStub        func(context.Context) error
+	ensureUsersTableMutex       sync.RWMutex
+	ensureUsersTableArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureUsersTableReturns struct {
+		result1 error
+	}
+	ensureUsersTableReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EnsureWebhooksTablesStub        func(context.Context) error
+	ensureWebhooksTablesMutex       sync.RWMutex
+	ensureWebhooksTablesArgsForCall []struct {
+		arg1 context.Context
+	}
+	ensureWebhooksTablesReturns struct {
+		result1 error
+	}
+	ensureWebhooksTablesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FinishJobRunStub        func(context.Context, string, db.JobRunStatus, string) error
+	finishJobRunMutex       sync.RWMutex
+	finishJobRunArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.JobRunStatus
+		arg4 string
+	}
+	finishJobRunReturns struct {
+		result1 error
+	}
+	finishJobRunReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetAccountStub        func(context.Context, string, string) (db.Account, error)
+	getAccountMutex       sync.RWMutex
+	getAccountArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getAccountReturns struct {
+		result1 db.Account
+		result2 error
+	}
+	getAccountReturnsOnCall map[int]struct {
+		result1 db.Account
+		result2 error
+	}
+	GetAccountsStub        func(context.Context, string) ([]db.Account, error)
+	getAccountsMutex       sync.RWMutex
+	getAccountsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getAccountsReturns struct {
+		result1 []db.Account
+		result2 error
+	}
+	getAccountsReturnsOnCall map[int]struct {
+		result1 []db.Account
+		result2 error
+	}
+	GetAccountsByPlaidItemIDStub        func(context.Context, string) ([]db.Account, error)
+	getAccountsByPlaidItemIDMutex       sync.RWMutex
+	getAccountsByPlaidItemIDArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getAccountsByPlaidItemIDReturns struct {
+		result1 []db.Account
+		result2 error
+	}
+	getAccountsByPlaidItemIDReturnsOnCall map[int]struct {
+		result1 []db.Account
+		result2 error
+	}
+	GetAccountsTransactionsPageStub        func(context.Context, string, []string, db.TransactionPage) (map[string][]db.Transaction, error)
+	getAccountsTransactionsPageMutex       sync.RWMutex
+	getAccountsTransactionsPageArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+		arg4 db.TransactionPage
+	}
+	getAccountsTransactionsPageReturns struct {
+		result1 map[string][]db.Transaction
+		result2 error
+	}
+	getAccountsTransactionsPageReturnsOnCall map[int]struct {
+		result1 map[string][]db.Transaction
+		result2 error
+	}
+	GetAlertDeliveriesStub        func(context.Context, string, string) ([]db.AlertDelivery, error)
+	getAlertDeliveriesMutex       sync.RWMutex
+	getAlertDeliveriesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getAlertDeliveriesReturns struct {
+		result1 []db.AlertDelivery
+		result2 error
+	}
+	getAlertDeliveriesReturnsOnCall map[int]struct {
+		result1 []db.AlertDelivery
+		result2 error
+	}
+	GetAlertsStub        func(context.Context, string) ([]db.Alert, error)
+	getAlertsMutex       sync.RWMutex
+	getAlertsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getAlertsReturns struct {
+		result1 []db.Alert
+		result2 error
+	}
+	getAlertsReturnsOnCall map[int]struct {
+		result1 []db.Alert
+		result2 error
+	}
+	GetAttachmentStub        func(context.Context, string, string) (db.Attachment, error)
+	getAttachmentMutex       sync.RWMutex
+	getAttachmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getAttachmentReturns struct {
+		result1 db.Attachment
+		result2 error
+	}
+	getAttachmentReturnsOnCall map[int]struct {
+		result1 db.Attachment
+		result2 error
+	}
+	GetAttachmentsStub        func(context.Context, string, string) ([]db.Attachment, error)
+	getAttachmentsMutex       sync.RWMutex
+	getAttachmentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getAttachmentsReturns struct {
+		result1 []db.Attachment
+		result2 error
+	}
+	getAttachmentsReturnsOnCall map[int]struct {
+		result1 []db.Attachment
+		result2 error
+	}
+	GetAuditEventsStub        func(context.Context, db.AuditEventFilter) ([]db.AuditEvent, error)
+	getAuditEventsMutex       sync.RWMutex
+	getAuditEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.AuditEventFilter
+	}
+	getAuditEventsReturns struct {
+		result1 []db.AuditEvent
+		result2 error
+	}
+	getAuditEventsReturnsOnCall map[int]struct {
+		result1 []db.AuditEvent
+		result2 error
+	}
+	GetBackfillingItemsStub        func(context.Context) ([]db.Item, error)
+	getBackfillingItemsMutex       sync.RWMutex
+	getBackfillingItemsArgsForCall []struct {
+		arg1 context.Context
+	}
+	getBackfillingItemsReturns struct {
+		result1 []db.Item
+		result2 error
+	}
+	getBackfillingItemsReturnsOnCall map[int]struct {
+		result1 []db.Item
+		result2 error
+	}
+	GetBalancesStub        func(context.Context, string, string) ([]db.Balance, error)
+	getBalancesMutex       sync.RWMutex
+	getBalancesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getBalancesReturns struct {
+		result1 []db.Balance
+		result2 error
+	}
+	getBalancesReturnsOnCall map[int]struct {
+		result1 []db.Balance
+		result2 error
+	}
+	GetCashflowReportStub        func(context.Context, string, db.ReportGroup, string, string) ([]db.ReportRow, error)
+	getCashflowReportMutex       sync.RWMutex
+	getCashflowReportArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.ReportGroup
+		arg4 string
+		arg5 string
+	}
+	getCashflowReportReturns struct {
+		result1 []db.ReportRow
+		result2 error
+	}
+	getCashflowReportReturnsOnCall map[int]struct {
+		result1 []db.ReportRow
+		result2 error
+	}
+	GetDigestPreferencesStub        func(context.Context, string) (db.DigestPreferences, error)
+	getDigestPreferencesMutex       sync.RWMutex
+	getDigestPreferencesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getDigestPreferencesReturns struct {
+		result1 db.DigestPreferences
+		result2 error
+	}
+	getDigestPreferencesReturnsOnCall map[int]struct {
+		result1 db.DigestPreferences
+		result2 error
+	}
+	GetItemStub        func(context.Context, string, string) (db.Item, error)
+	getItemMutex       sync.RWMutex
+	getItemArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getItemReturns struct {
+		result1 db.Item
+		result2 error
+	}
+	getItemReturnsOnCall map[int]struct {
+		result1 db.Item
+		result2 error
+	}
+	GetItemsStub        func(context.Context, string) ([]db.Item, error)
+	getItemsMutex       sync.RWMutex
+	getItemsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getItemsReturns struct {
+		result1 []db.Item
+		result2 error
+	}
+	getItemsReturnsOnCall map[int]struct {
+		result1 []db.Item
+		result2 error
+	}
+	GetItemsSyncedBeforeStub        func(context.Context, time.Time) ([]db.Item, error)
+	getItemsSyncedBeforeMutex       sync.RWMutex
+	getItemsSyncedBeforeArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Time
+	}
+	getItemsSyncedBeforeReturns struct {
+		result1 []db.Item
+		result2 error
+	}
+	getItemsSyncedBeforeReturnsOnCall map[int]struct {
+		result1 []db.Item
+		result2 error
+	}
+	GetJobRunStub        func(context.Context, string, string) (db.JobRun, error)
+	getJobRunMutex       sync.RWMutex
+	getJobRunArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getJobRunReturns struct {
+		result1 db.JobRun
+		result2 error
+	}
+	getJobRunReturnsOnCall map[int]struct {
+		result1 db.JobRun
+		result2 error
+	}
+	GetJobRunsStub        func(context.Context, string, int) ([]db.JobRun, error)
+	getJobRunsMutex       sync.RWMutex
+	getJobRunsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}
+	getJobRunsReturns struct {
+		result1 []db.JobRun
+		result2 error
+	}
+	getJobRunsReturnsOnCall map[int]struct {
+		result1 []db.JobRun
+		result2 error
+	}
+	GetPersonalAccessTokensStub        func(context.Context, string) ([]db.PersonalAccessToken, error)
+	getPersonalAccessTokensMutex       sync.RWMutex
+	getPersonalAccessTokensArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getPersonalAccessTokensReturns struct {
+		result1 []db.PersonalAccessToken
+		result2 error
+	}
+	getPersonalAccessTokensReturnsOnCall map[int]struct {
+		result1 []db.PersonalAccessToken
+		result2 error
+	}
+	GetShareStub        func(context.Context, string, string) (db.Share, error)
+	getShareMutex       sync.RWMutex
+	getShareArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getShareReturns struct {
+		result1 db.Share
+		result2 error
+	}
+	getShareReturnsOnCall map[int]struct {
+		result1 db.Share
+		result2 error
+	}
+	GetSharesStub        func(context.Context, string) ([]db.Share, error)
+	getSharesMutex       sync.RWMutex
+	getSharesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getSharesReturns struct {
+		result1 []db.Share
+		result2 error
+	}
+	getSharesReturnsOnCall map[int]struct {
+		result1 []db.Share
+		result2 error
+	}
+	GetSpendingReportStub        func(context.Context, string, db.ReportGroup, string, string) ([]db.ReportRow, error)
+	getSpendingReportMutex       sync.RWMutex
+	getSpendingReportArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.ReportGroup
+		arg4 string
+		arg5 string
+	}
+	getSpendingReportReturns struct {
+		result1 []db.ReportRow
+		result2 error
+	}
+	getSpendingReportReturnsOnCall map[int]struct {
+		result1 []db.ReportRow
+		result2 error
+	}
+	GetSplitsForTransactionsStub        func(context.Context, string, []string) (map[string][]db.Split, error)
+	getSplitsForTransactionsMutex       sync.RWMutex
+	getSplitsForTransactionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}
+	getSplitsForTransactionsReturns struct {
+		result1 map[string][]db.Split
+		result2 error
+	}
+	getSplitsForTransactionsReturnsOnCall map[int]struct {
+		result1 map[string][]db.Split
+		result2 error
+	}
+	GetTagsForTransactionsStub        func(context.Context, string, []string) (map[string][]string, error)
+	getTagsForTransactionsMutex       sync.RWMutex
+	getTagsForTransactionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}
+	getTagsForTransactionsReturns struct {
+		result1 map[string][]string
+		result2 error
+	}
+	getTagsForTransactionsReturnsOnCall map[int]struct {
+		result1 map[string][]string
+		result2 error
+	}
+	GetTransactionStub        func(context.Context, string, string) (db.Transaction, error)
+	getTransactionMutex       sync.RWMutex
+	getTransactionArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getTransactionReturns struct {
+		result1 db.Transaction
+		result2 error
+	}
+	getTransactionReturnsOnCall map[int]struct {
+		result1 db.Transaction
+		result2 error
+	}
+	GetTransactionSplitsStub        func(context.Context, string, string) ([]db.Split, error)
+	getTransactionSplitsMutex       sync.RWMutex
+	getTransactionSplitsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getTransactionSplitsReturns struct {
+		result1 []db.Split
+		result2 error
+	}
+	getTransactionSplitsReturnsOnCall map[int]struct {
+		result1 []db.Split
+		result2 error
+	}
+	GetTransactionTagsStub        func(context.Context, string, string) ([]string, error)
+	getTransactionTagsMutex       sync.RWMutex
+	getTransactionTagsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getTransactionTagsReturns struct {
+		result1 []string
+		result2 error
+	}
+	getTransactionTagsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	GetTransactionsStub        func(context.Context, string, string) ([]db.Transaction, error)
+	getTransactionsMutex       sync.RWMutex
+	getTransactionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getTransactionsReturns struct {
+		result1 []db.Transaction
+		result2 error
+	}
+	getTransactionsReturnsOnCall map[int]struct {
+		result1 []db.Transaction
+		result2 error
+	}
+	GetTransactionsByDateRangeStub        func(context.Context, string, string, string, string) ([]db.Transaction, error)
+	getTransactionsByDateRangeMutex       sync.RWMutex
+	getTransactionsByDateRangeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}
+	getTransactionsByDateRangeReturns struct {
+		result1 []db.Transaction
+		result2 error
+	}
+	getTransactionsByDateRangeReturnsOnCall map[int]struct {
+		result1 []db.Transaction
+		result2 error
+	}
+	GetTransfersStub        func(context.Context, string) ([]db.Transfer, error)
+	getTransfersMutex       sync.RWMutex
+	getTransfersArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getTransfersReturns struct {
+		result1 []db.Transfer
+		result2 error
+	}
+	getTransfersReturnsOnCall map[int]struct {
+		result1 []db.Transfer
+		result2 error
+	}
+	GetUserStub        func(context.Context, string) (db.User, error)
+	getUserMutex       sync.RWMutex
+	getUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getUserReturns struct {
+		result1 db.User
+		result2 error
+	}
+	getUserReturnsOnCall map[int]struct {
+		result1 db.User
+		result2 error
+	}
+	GetUserAttachmentsStub        func(context.Context, string) ([]db.Attachment, error)
+	getUserAttachmentsMutex       sync.RWMutex
+	getUserAttachmentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getUserAttachmentsReturns struct {
+		result1 []db.Attachment
+		result2 error
+	}
+	getUserAttachmentsReturnsOnCall map[int]struct {
+		result1 []db.Attachment
+		result2 error
+	}
+	GetUserForIdentityStub        func(context.Context, string, string) (string, error)
+	getUserForIdentityMutex       sync.RWMutex
+	getUserForIdentityArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	getUserForIdentityReturns struct {
+		result1 string
+		result2 error
+	}
+	getUserForIdentityReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetUserPermissionsStub        func(context.Context, string) (string, []string, error)
+	getUserPermissionsMutex       sync.RWMutex
+	getUserPermissionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getUserPermissionsReturns struct {
+		result1 string
+		result2 []string
+		result3 error
+	}
+	getUserPermissionsReturnsOnCall map[int]struct {
+		result1 string
+		result2 []string
+		result3 error
+	}
+	GetUserTransactionsByDateRangeStub        func(context.Context, string, string, string) ([]db.Transaction, error)
+	getUserTransactionsByDateRangeMutex       sync.RWMutex
+	getUserTransactionsByDateRangeArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	getUserTransactionsByDateRangeReturns struct {
+		result1 []db.Transaction
+		result2 error
+	}
+	getUserTransactionsByDateRangeReturnsOnCall map[int]struct {
+		result1 []db.Transaction
+		result2 error
+	}
+	GetUserWebhookDeliveriesStub        func(context.Context, string, int) ([]db.WebhookDelivery, error)
+	getUserWebhookDeliveriesMutex       sync.RWMutex
+	getUserWebhookDeliveriesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}
+	getUserWebhookDeliveriesReturns struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}
+	getUserWebhookDeliveriesReturnsOnCall map[int]struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}
+	GetUsersStub        func(context.Context, db.UserPage) ([]db.User, error)
+	getUsersMutex       sync.RWMutex
+	getUsersArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.UserPage
+	}
+	getUsersReturns struct {
+		result1 []db.User
+		result2 error
+	}
+	getUsersReturnsOnCall map[int]struct {
+		result1 []db.User
+		result2 error
+	}
+	GetWebhookDeliveriesStub        func(context.Context, string, string, int) ([]db.WebhookDelivery, error)
+	getWebhookDeliveriesMutex       sync.RWMutex
+	getWebhookDeliveriesArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+	}
+	getWebhookDeliveriesReturns struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}
+	getWebhookDeliveriesReturnsOnCall map[int]struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}
+	GetWebhookEndpointStub        func(context.Context, string) (db.WebhookEndpoint, error)
+	getWebhookEndpointMutex       sync.RWMutex
+	getWebhookEndpointArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getWebhookEndpointReturns struct {
+		result1 db.WebhookEndpoint
+		result2 error
+	}
+	getWebhookEndpointReturnsOnCall map[int]struct {
+		result1 db.WebhookEndpoint
+		result2 error
+	}
+	GetWebhookEndpointsStub        func(context.Context, string) ([]db.WebhookEndpoint, error)
+	getWebhookEndpointsMutex       sync.RWMutex
+	getWebhookEndpointsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	getWebhookEndpointsReturns struct {
+		result1 []db.WebhookEndpoint
+		result2 error
+	}
+	getWebhookEndpointsReturnsOnCall map[int]struct {
+		result1 []db.WebhookEndpoint
+		result2 error
+	}
+	LinkUserIdentityStub        func(context.Context, string, string, string) (string, error)
+	linkUserIdentityMutex       sync.RWMutex
+	linkUserIdentityArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	linkUserIdentityReturns struct {
+		result1 string
+		result2 error
+	}
+	linkUserIdentityReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	MarkItemSyncedStub        func(context.Context, string) error
+	markItemSyncedMutex       sync.RWMutex
+	markItemSyncedArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	markItemSyncedReturns struct {
+		result1 error
+	}
+	markItemSyncedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PruneJobRunsStub        func(context.Context, time.Time) (int64, error)
+	pruneJobRunsMutex       sync.RWMutex
+	pruneJobRunsArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Time
+	}
+	pruneJobRunsReturns struct {
+		result1 int64
+		result2 error
+	}
+	pruneJobRunsReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	PruneWebhookDeliveriesStub        func(context.Context, time.Time) (int64, error)
+	pruneWebhookDeliveriesMutex       sync.RWMutex
+	pruneWebhookDeliveriesArgsForCall []struct {
+		arg1 context.Context
+		arg2 time.Time
+	}
+	pruneWebhookDeliveriesReturns struct {
+		result1 int64
+		result2 error
+	}
+	pruneWebhookDeliveriesReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	RecordAuditEventStub        func(context.Context, db.AuditEvent) (string, error)
+	recordAuditEventMutex       sync.RWMutex
+	recordAuditEventArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.AuditEvent
+	}
+	recordAuditEventReturns struct {
+		result1 string
+		result2 error
+	}
+	recordAuditEventReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	RecordBalanceStub        func(context.Context, db.Balance) (string, error)
+	recordBalanceMutex       sync.RWMutex
+	recordBalanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.Balance
+	}
+	recordBalanceReturns struct {
+		result1 string
+		result2 error
+	}
+	recordBalanceReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	RecordWebhookAttemptStub        func(context.Context, string, db.WebhookDeliveryStatus, int, string, *time.Time) error
+	recordWebhookAttemptMutex       sync.RWMutex
+	recordWebhookAttemptArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.WebhookDeliveryStatus
+		arg4 int
+		arg5 string
+		arg6 *time.Time
+	}
+	recordWebhookAttemptReturns struct {
+		result1 error
+	}
+	recordWebhookAttemptReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RedeliverWebhookDeliveryStub        func(context.Context, string, string, string) error
+	redeliverWebhookDeliveryMutex       sync.RWMutex
+	redeliverWebhookDeliveryArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	redeliverWebhookDeliveryReturns struct {
+		result1 error
+	}
+	redeliverWebhookDeliveryReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RegisterUserStub        func(context.Context, string, string, string) error
+	registerUserMutex       sync.RWMutex
+	registerUserArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	registerUserReturns struct {
+		result1 error
+	}
+	registerUserReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReleaseDigestStub        func(context.Context, string, *time.Time) error
+	releaseDigestMutex       sync.RWMutex
+	releaseDigestArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *time.Time
+	}
+	releaseDigestReturns struct {
+		result1 error
+	}
+	releaseDigestReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevokePersonalAccessTokenStub        func(context.Context, string, string) error
+	revokePersonalAccessTokenMutex       sync.RWMutex
+	revokePersonalAccessTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	revokePersonalAccessTokenReturns struct {
+		result1 error
+	}
+	revokePersonalAccessTokenReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RevokeShareStub        func(context.Context, string, string) error
+	revokeShareMutex       sync.RWMutex
+	revokeShareArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	revokeShareReturns struct {
+		result1 error
+	}
+	revokeShareReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SeedRoleStub        func(context.Context, string, []string) error
+	seedRoleMutex       sync.RWMutex
+	seedRoleArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}
+	seedRoleReturns struct {
+		result1 error
+	}
+	seedRoleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetAccountHiddenStub        func(context.Context, string, string, bool) error
+	setAccountHiddenMutex       sync.RWMutex
+	setAccountHiddenArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 bool
+	}
+	setAccountHiddenReturns struct {
+		result1 error
+	}
+	setAccountHiddenReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetAlertDeliveryStatusStub        func(context.Context, string, db.AlertDeliveryStatus, string) error
+	setAlertDeliveryStatusMutex       sync.RWMutex
+	setAlertDeliveryStatusArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.AlertDeliveryStatus
+		arg4 string
+	}
+	setAlertDeliveryStatusReturns struct {
+		result1 error
+	}
+	setAlertDeliveryStatusReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetDigestPreferencesStub        func(context.Context, db.DigestPreferences) error
+	setDigestPreferencesMutex       sync.RWMutex
+	setDigestPreferencesArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.DigestPreferences
+	}
+	setDigestPreferencesReturns struct {
+		result1 error
+	}
+	setDigestPreferencesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetItemBackfillStub        func(context.Context, string, db.Backfill) error
+	setItemBackfillMutex       sync.RWMutex
+	setItemBackfillArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.Backfill
+	}
+	setItemBackfillReturns struct {
+		result1 error
+	}
+	setItemBackfillReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetItemStatusStub        func(context.Context, string, db.ItemStatus, string) error
+	setItemStatusMutex       sync.RWMutex
+	setItemStatusArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.ItemStatus
+		arg4 string
+	}
+	setItemStatusReturns struct {
+		result1 error
+	}
+	setItemStatusReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetTransactionSplitsStub        func(context.Context, string, string, []db.Split) error
+	setTransactionSplitsMutex       sync.RWMutex
+	setTransactionSplitsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 []db.Split
+	}
+	setTransactionSplitsReturns struct {
+		result1 error
+	}
+	setTransactionSplitsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetTransactionTagsStub        func(context.Context, string, string, []string) error
+	setTransactionTagsMutex       sync.RWMutex
+	setTransactionTagsArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 []string
+	}
+	setTransactionTagsReturns struct {
+		result1 error
+	}
+	setTransactionTagsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetTransferStatusStub        func(context.Context, string, string, db.TransferStatus) error
+	setTransferStatusMutex       sync.RWMutex
+	setTransferStatusArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 db.TransferStatus
+	}
+	setTransferStatusReturns struct {
+		result1 error
+	}
+	setTransferStatusReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetUserActiveStub        func(context.Context, string, bool) error
+	setUserActiveMutex       sync.RWMutex
+	setUserActiveArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}
+	setUserActiveReturns struct {
+		result1 error
+	}
+	setUserActiveReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetUserEmailStub        func(context.Context, string, string) error
+	setUserEmailMutex       sync.RWMutex
+	setUserEmailArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	setUserEmailReturns struct {
+		result1 error
+	}
+	setUserEmailReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetUserRoleStub        func(context.Context, string, string) error
+	setUserRoleMutex       sync.RWMutex
+	setUserRoleArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	setUserRoleReturns struct {
+		result1 error
+	}
+	setUserRoleReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StartItemBackfillStub        func(context.Context, string, string, string) error
+	startItemBackfillMutex       sync.RWMutex
+	startItemBackfillArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	startItemBackfillReturns struct {
+		result1 error
+	}
+	startItemBackfillReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StartJobRunStub        func(context.Context, db.JobRun) (string, error)
+	startJobRunMutex       sync.RWMutex
+	startJobRunArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.JobRun
+	}
+	startJobRunReturns struct {
+		result1 string
+		result2 error
+	}
+	startJobRunReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	TryAdvisoryLockStub        func(context.Context, int64) (db.Lock, error)
+	tryAdvisoryLockMutex       sync.RWMutex
+	tryAdvisoryLockArgsForCall []struct {
+		arg1 context.Context
+		arg2 int64
+	}
+	tryAdvisoryLockReturns struct {
+		result1 db.Lock
+		result2 error
+	}
+	tryAdvisoryLockReturnsOnCall map[int]struct {
+		result1 db.Lock
+		result2 error
+	}
+	UpdateTransactionNoteStub        func(context.Context, string, string, string) error
+	updateTransactionNoteMutex       sync.RWMutex
+	updateTransactionNoteArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}
+	updateTransactionNoteReturns struct {
+		result1 error
+	}
+	updateTransactionNoteReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpsertItemStub        func(context.Context, db.Item) (string, error)
+	upsertItemMutex       sync.RWMutex
+	upsertItemArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.Item
+	}
+	upsertItemReturns struct {
+		result1 string
+		result2 error
+	}
+	upsertItemReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	UpsertTransactionStub        func(context.Context, db.Transaction) (string, bool, error)
+	upsertTransactionMutex       sync.RWMutex
+	upsertTransactionArgsForCall []struct {
+		arg1 context.Context
+		arg2 db.Transaction
+	}
+	upsertTransactionReturns struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	upsertTransactionReturnsOnCall map[int]struct {
+		result1 string
+		result2 bool
+		result3 error
+	}
+	UsePersonalAccessTokenStub        func(context.Context, string) (db.PersonalAccessToken, error)
+	usePersonalAccessTokenMutex       sync.RWMutex
+	usePersonalAccessTokenArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	usePersonalAccessTokenReturns struct {
+		result1 db.PersonalAccessToken
+		result2 error
+	}
+	usePersonalAccessTokenReturnsOnCall map[int]struct {
+		result1 db.PersonalAccessToken
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDB) AcceptShare(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.acceptShareMutex.Lock()
+	ret, specificReturn := fake.acceptShareReturnsOnCall[len(fake.acceptShareArgsForCall)]
+	fake.acceptShareArgsForCall = append(fake.acceptShareArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.AcceptShareStub
+	fakeReturns := fake.acceptShareReturns
+	fake.recordInvocation("AcceptShare", []interface{}{arg1, arg2, arg3})
+	fake.acceptShareMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) AcceptShareCallCount() int {
+	fake.acceptShareMutex.RLock()
+	defer fake.acceptShareMutex.RUnlock()
+	return len(fake.acceptShareArgsForCall)
+}
+
+func (fake *FakeDB) AcceptShareCalls(stub func(context.Context, string, string) error) {
+	fake.acceptShareMutex.Lock()
+	defer fake.acceptShareMutex.Unlock()
+	fake.AcceptShareStub = stub
+}
+
+func (fake *FakeDB) AcceptShareArgsForCall(i int) (context.Context, string, string) {
+	fake.acceptShareMutex.RLock()
+	defer fake.acceptShareMutex.RUnlock()
+	argsForCall := fake.acceptShareArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) AcceptShareReturns(result1 error) {
+	fake.acceptShareMutex.Lock()
+	defer fake.acceptShareMutex.Unlock()
+	fake.AcceptShareStub = nil
+	fake.acceptShareReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) AcceptShareReturnsOnCall(i int, result1 error) {
+	fake.acceptShareMutex.Lock()
+	defer fake.acceptShareMutex.Unlock()
+	fake.AcceptShareStub = nil
+	if fake.acceptShareReturnsOnCall == nil {
+		fake.acceptShareReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.acceptShareReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) CheckUser(arg1 context.Context, arg2 string) (bool, error) {
+	fake.checkUserMutex.Lock()
+	ret, specificReturn := fake.checkUserReturnsOnCall[len(fake.checkUserArgsForCall)]
+	fake.checkUserArgsForCall = append(fake.checkUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.CheckUserStub
+	fakeReturns := fake.checkUserReturns
+	fake.recordInvocation("CheckUser", []interface{}{arg1, arg2})
+	fake.checkUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) CheckUserCallCount() int {
+	fake.checkUserMutex.RLock()
+	defer fake.checkUserMutex.RUnlock()
+	return len(fake.checkUserArgsForCall)
+}
+
+func (fake *FakeDB) CheckUserCalls(stub func(context.Context, string) (bool, error)) {
+	fake.checkUserMutex.Lock()
+	defer fake.checkUserMutex.Unlock()
+	fake.CheckUserStub = stub
+}
+
+func (fake *FakeDB) CheckUserArgsForCall(i int) (context.Context, string) {
+	fake.checkUserMutex.RLock()
+	defer fake.checkUserMutex.RUnlock()
+	argsForCall := fake.checkUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) CheckUserReturns(result1 bool, result2 error) {
+	fake.checkUserMutex.Lock()
+	defer fake.checkUserMutex.Unlock()
+	fake.CheckUserStub = nil
+	fake.checkUserReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CheckUserReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.checkUserMutex.Lock()
+	defer fake.checkUserMutex.Unlock()
+	fake.CheckUserStub = nil
+	if fake.checkUserReturnsOnCall == nil {
+		fake.checkUserReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.checkUserReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ClaimAlertDelivery(arg1 context.Context, arg2 db.AlertDelivery) (string, error) {
+	fake.claimAlertDeliveryMutex.Lock()
+	ret, specificReturn := fake.claimAlertDeliveryReturnsOnCall[len(fake.claimAlertDeliveryArgsForCall)]
+	fake.claimAlertDeliveryArgsForCall = append(fake.claimAlertDeliveryArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.AlertDelivery
+	}{arg1, arg2})
+	stub := fake.ClaimAlertDeliveryStub
+	fakeReturns := fake.claimAlertDeliveryReturns
+	fake.recordInvocation("ClaimAlertDelivery", []interface{}{arg1, arg2})
+	fake.claimAlertDeliveryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) ClaimAlertDeliveryCallCount() int {
+	fake.claimAlertDeliveryMutex.RLock()
+	defer fake.claimAlertDeliveryMutex.RUnlock()
+	return len(fake.claimAlertDeliveryArgsForCall)
+}
+
+func (fake *FakeDB) ClaimAlertDeliveryCalls(stub func(context.Context, db.AlertDelivery) (string, error)) {
+	fake.claimAlertDeliveryMutex.Lock()
+	defer fake.claimAlertDeliveryMutex.Unlock()
+	fake.ClaimAlertDeliveryStub = stub
+}
+
+func (fake *FakeDB) ClaimAlertDeliveryArgsForCall(i int) (context.Context, db.AlertDelivery) {
+	fake.claimAlertDeliveryMutex.RLock()
+	defer fake.claimAlertDeliveryMutex.RUnlock()
+	argsForCall := fake.claimAlertDeliveryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) ClaimAlertDeliveryReturns(result1 string, result2 error) {
+	fake.claimAlertDeliveryMutex.Lock()
+	defer fake.claimAlertDeliveryMutex.Unlock()
+	fake.ClaimAlertDeliveryStub = nil
+	fake.claimAlertDeliveryReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ClaimAlertDeliveryReturnsOnCall(i int, result1 string, result2 error) {
+	fake.claimAlertDeliveryMutex.Lock()
+	defer fake.claimAlertDeliveryMutex.Unlock()
+	fake.ClaimAlertDeliveryStub = nil
+	if fake.claimAlertDeliveryReturnsOnCall == nil {
+		fake.claimAlertDeliveryReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.claimAlertDeliveryReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ClaimDueDigests(arg1 context.Context, arg2 int) ([]db.DueDigest, error) {
+	fake.claimDueDigestsMutex.Lock()
+	ret, specificReturn := fake.claimDueDigestsReturnsOnCall[len(fake.claimDueDigestsArgsForCall)]
+	fake.claimDueDigestsArgsForCall = append(fake.claimDueDigestsArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	stub := fake.ClaimDueDigestsStub
+	fakeReturns := fake.claimDueDigestsReturns
+	fake.recordInvocation("ClaimDueDigests", []interface{}{arg1, arg2})
+	fake.claimDueDigestsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) ClaimDueDigestsCallCount() int {
+	fake.claimDueDigestsMutex.RLock()
+	defer fake.claimDueDigestsMutex.RUnlock()
+	return len(fake.claimDueDigestsArgsForCall)
+}
+
+func (fake *FakeDB) ClaimDueDigestsCalls(stub func(context.Context, int) ([]db.DueDigest, error)) {
+	fake.claimDueDigestsMutex.Lock()
+	defer fake.claimDueDigestsMutex.Unlock()
+	fake.ClaimDueDigestsStub = stub
+}
+
+func (fake *FakeDB) ClaimDueDigestsArgsForCall(i int) (context.Context, int) {
+	fake.claimDueDigestsMutex.RLock()
+	defer fake.claimDueDigestsMutex.RUnlock()
+	argsForCall := fake.claimDueDigestsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) ClaimDueDigestsReturns(result1 []db.DueDigest, result2 error) {
+	fake.claimDueDigestsMutex.Lock()
+	defer fake.claimDueDigestsMutex.Unlock()
+	fake.ClaimDueDigestsStub = nil
+	fake.claimDueDigestsReturns = struct {
+		result1 []db.DueDigest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ClaimDueDigestsReturnsOnCall(i int, result1 []db.DueDigest, result2 error) {
+	fake.claimDueDigestsMutex.Lock()
+	defer fake.claimDueDigestsMutex.Unlock()
+	fake.ClaimDueDigestsStub = nil
+	if fake.claimDueDigestsReturnsOnCall == nil {
+		fake.claimDueDigestsReturnsOnCall = make(map[int]struct {
+			result1 []db.DueDigest
+			result2 error
+		})
+	}
+	fake.claimDueDigestsReturnsOnCall[i] = struct {
+		result1 []db.DueDigest
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ClaimDueWebhookDeliveries(arg1 context.Context, arg2 int, arg3 time.Duration) ([]db.WebhookDelivery, error) {
+	fake.claimDueWebhookDeliveriesMutex.Lock()
+	ret, specificReturn := fake.claimDueWebhookDeliveriesReturnsOnCall[len(fake.claimDueWebhookDeliveriesArgsForCall)]
+	fake.claimDueWebhookDeliveriesArgsForCall = append(fake.claimDueWebhookDeliveriesArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+		arg3 time.Duration
+	}{arg1, arg2, arg3})
+	stub := fake.ClaimDueWebhookDeliveriesStub
+	fakeReturns := fake.claimDueWebhookDeliveriesReturns
+	fake.recordInvocation("ClaimDueWebhookDeliveries", []interface{}{arg1, arg2, arg3})
+	fake.claimDueWebhookDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) ClaimDueWebhookDeliveriesCallCount() int {
+	fake.claimDueWebhookDeliveriesMutex.RLock()
+	defer fake.claimDueWebhookDeliveriesMutex.RUnlock()
+	return len(fake.claimDueWebhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeDB) ClaimDueWebhookDeliveriesCalls(stub func(context.Context, int, time.Duration) ([]db.WebhookDelivery, error)) {
+	fake.claimDueWebhookDeliveriesMutex.Lock()
+	defer fake.claimDueWebhookDeliveriesMutex.Unlock()
+	fake.ClaimDueWebhookDeliveriesStub = stub
+}
+
+func (fake *FakeDB) ClaimDueWebhookDeliveriesArgsForCall(i int) (context.Context, int, time.Duration) {
+	fake.claimDueWebhookDeliveriesMutex.RLock()
+	defer fake.claimDueWebhookDeliveriesMutex.RUnlock()
+	argsForCall := fake.claimDueWebhookDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) ClaimDueWebhookDeliveriesReturns(result1 []db.WebhookDelivery, result2 error) {
+	fake.claimDueWebhookDeliveriesMutex.Lock()
+	defer fake.claimDueWebhookDeliveriesMutex.Unlock()
+	fake.ClaimDueWebhookDeliveriesStub = nil
+	fake.claimDueWebhookDeliveriesReturns = struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ClaimDueWebhookDeliveriesReturnsOnCall(i int, result1 []db.WebhookDelivery, result2 error) {
+	fake.claimDueWebhookDeliveriesMutex.Lock()
+	defer fake.claimDueWebhookDeliveriesMutex.Unlock()
+	fake.ClaimDueWebhookDeliveriesStub = nil
+	if fake.claimDueWebhookDeliveriesReturnsOnCall == nil {
+		fake.claimDueWebhookDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []db.WebhookDelivery
+			result2 error
+		})
+	}
+	fake.claimDueWebhookDeliveriesReturnsOnCall[i] = struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ClaimItemRefresh(arg1 context.Context, arg2 string, arg3 string, arg4 time.Duration) (bool, error) {
+	fake.claimItemRefreshMutex.Lock()
+	ret, specificReturn := fake.claimItemRefreshReturnsOnCall[len(fake.claimItemRefreshArgsForCall)]
+	fake.claimItemRefreshArgsForCall = append(fake.claimItemRefreshArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 time.Duration
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ClaimItemRefreshStub
+	fakeReturns := fake.claimItemRefreshReturns
+	fake.recordInvocation("ClaimItemRefresh", []interface{}{arg1, arg2, arg3, arg4})
+	fake.claimItemRefreshMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) ClaimItemRefreshCallCount() int {
+	fake.claimItemRefreshMutex.RLock()
+	defer fake.claimItemRefreshMutex.RUnlock()
+	return len(fake.claimItemRefreshArgsForCall)
+}
+
+func (fake *FakeDB) ClaimItemRefreshCalls(stub func(context.Context, string, string, time.Duration) (bool, error)) {
+	fake.claimItemRefreshMutex.Lock()
+	defer fake.claimItemRefreshMutex.Unlock()
+	fake.ClaimItemRefreshStub = stub
+}
+
+func (fake *FakeDB) ClaimItemRefreshArgsForCall(i int) (context.Context, string, string, time.Duration) {
+	fake.claimItemRefreshMutex.RLock()
+	defer fake.claimItemRefreshMutex.RUnlock()
+	argsForCall := fake.claimItemRefreshArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) ClaimItemRefreshReturns(result1 bool, result2 error) {
+	fake.claimItemRefreshMutex.Lock()
+	defer fake.claimItemRefreshMutex.Unlock()
+	fake.ClaimItemRefreshStub = nil
+	fake.claimItemRefreshReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ClaimItemRefreshReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.claimItemRefreshMutex.Lock()
+	defer fake.claimItemRefreshMutex.Unlock()
+	fake.ClaimItemRefreshStub = nil
+	if fake.claimItemRefreshReturnsOnCall == nil {
+		fake.claimItemRefreshReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.claimItemRefreshReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) ConfigureAccount(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.configureAccountMutex.Lock()
+	ret, specificReturn := fake.configureAccountReturnsOnCall[len(fake.configureAccountArgsForCall)]
+	fake.configureAccountArgsForCall = append(fake.configureAccountArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ConfigureAccountStub
+	fakeReturns := fake.configureAccountReturns
+	fake.recordInvocation("ConfigureAccount", []interface{}{arg1, arg2, arg3})
+	fake.configureAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) ConfigureAccountCallCount() int {
+	fake.configureAccountMutex.RLock()
+	defer fake.configureAccountMutex.RUnlock()
+	return len(fake.configureAccountArgsForCall)
+}
+
+func (fake *FakeDB) ConfigureAccountCalls(stub func(context.Context, string, string) error) {
+	fake.configureAccountMutex.Lock()
+	defer fake.configureAccountMutex.Unlock()
+	fake.ConfigureAccountStub = stub
+}
+
+func (fake *FakeDB) ConfigureAccountArgsForCall(i int) (context.Context, string, string) {
+	fake.configureAccountMutex.RLock()
+	defer fake.configureAccountMutex.RUnlock()
+	argsForCall := fake.configureAccountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) ConfigureAccountReturns(result1 error) {
+	fake.configureAccountMutex.Lock()
+	defer fake.configureAccountMutex.Unlock()
+	fake.ConfigureAccountStub = nil
+	fake.configureAccountReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) ConfigureAccountReturnsOnCall(i int, result1 error) {
+	fake.configureAccountMutex.Lock()
+	defer fake.configureAccountMutex.Unlock()
+	fake.ConfigureAccountStub = nil
+	if fake.configureAccountReturnsOnCall == nil {
+		fake.configureAccountReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.configureAccountReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) CreateAccount(arg1 context.Context, arg2 string, arg3 db.Account) (string, error) {
+	fake.createAccountMutex.Lock()
+	ret, specificReturn := fake.createAccountReturnsOnCall[len(fake.createAccountArgsForCall)]
+	fake.createAccountArgsForCall = append(fake.createAccountArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.Account
+	}{arg1, arg2, arg3})
+	stub := fake.CreateAccountStub
+	fakeReturns := fake.createAccountReturns
+	fake.recordInvocation("CreateAccount", []interface{}{arg1, arg2, arg3})
+	fake.createAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) CreateAccountCallCount() int {
+	fake.createAccountMutex.RLock()
+	defer fake.createAccountMutex.RUnlock()
+	return len(fake.createAccountArgsForCall)
+}
+
+func (fake *FakeDB) CreateAccountCalls(stub func(context.Context, string, db.Account) (string, error)) {
+	fake.createAccountMutex.Lock()
+	defer fake.createAccountMutex.Unlock()
+	fake.CreateAccountStub = stub
+}
+
+func (fake *FakeDB) CreateAccountArgsForCall(i int) (context.Context, string, db.Account) {
+	fake.createAccountMutex.RLock()
+	defer fake.createAccountMutex.RUnlock()
+	argsForCall := fake.createAccountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) CreateAccountReturns(result1 string, result2 error) {
+	fake.createAccountMutex.Lock()
+	defer fake.createAccountMutex.Unlock()
+	fake.CreateAccountStub = nil
+	fake.createAccountReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateAccountReturnsOnCall(i int, result1 string, result2 error) {
+	fake.createAccountMutex.Lock()
+	defer fake.createAccountMutex.Unlock()
+	fake.CreateAccountStub = nil
+	if fake.createAccountReturnsOnCall == nil {
+		fake.createAccountReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.createAccountReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateAlert(arg1 context.Context, arg2 db.Alert) (string, error) {
+	fake.createAlertMutex.Lock()
+	ret, specificReturn := fake.createAlertReturnsOnCall[len(fake.createAlertArgsForCall)]
+	fake.createAlertArgsForCall = append(fake.createAlertArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.Alert
+	}{arg1, arg2})
+	stub := fake.CreateAlertStub
+	fakeReturns := fake.createAlertReturns
+	fake.recordInvocation("CreateAlert", []interface{}{arg1, arg2})
+	fake.createAlertMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) CreateAlertCallCount() int {
+	fake.createAlertMutex.RLock()
+	defer fake.createAlertMutex.RUnlock()
+	return len(fake.createAlertArgsForCall)
+}
+
+func (fake *FakeDB) CreateAlertCalls(stub func(context.Context, db.Alert) (string, error)) {
+	fake.createAlertMutex.Lock()
+	defer fake.createAlertMutex.Unlock()
+	fake.CreateAlertStub = stub
+}
+
+func (fake *FakeDB) CreateAlertArgsForCall(i int) (context.Context, db.Alert) {
+	fake.createAlertMutex.RLock()
+	defer fake.createAlertMutex.RUnlock()
+	argsForCall := fake.createAlertArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) CreateAlertReturns(result1 string, result2 error) {
+	fake.createAlertMutex.Lock()
+	defer fake.createAlertMutex.Unlock()
+	fake.CreateAlertStub = nil
+	fake.createAlertReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateAlertReturnsOnCall(i int, result1 string, result2 error) {
+	fake.createAlertMutex.Lock()
+	defer fake.createAlertMutex.Unlock()
+	fake.CreateAlertStub = nil
+	if fake.createAlertReturnsOnCall == nil {
+		fake.createAlertReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.createAlertReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateAttachment(arg1 context.Context, arg2 db.Attachment) (string, error) {
+	fake.createAttachmentMutex.Lock()
+	ret, specificReturn := fake.createAttachmentReturnsOnCall[len(fake.createAttachmentArgsForCall)]
+	fake.createAttachmentArgsForCall = append(fake.createAttachmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.Attachment
+	}{arg1, arg2})
+	stub := fake.CreateAttachmentStub
+	fakeReturns := fake.createAttachmentReturns
+	fake.recordInvocation("CreateAttachment", []interface{}{arg1, arg2})
+	fake.createAttachmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) CreateAttachmentCallCount() int {
+	fake.createAttachmentMutex.RLock()
+	defer fake.createAttachmentMutex.RUnlock()
+	return len(fake.createAttachmentArgsForCall)
+}
+
+func (fake *FakeDB) CreateAttachmentCalls(stub func(context.Context, db.Attachment) (string, error)) {
+	fake.createAttachmentMutex.Lock()
+	defer fake.createAttachmentMutex.Unlock()
+	fake.CreateAttachmentStub = stub
+}
+
+func (fake *FakeDB) CreateAttachmentArgsForCall(i int) (context.Context, db.Attachment) {
+	fake.createAttachmentMutex.RLock()
+	defer fake.createAttachmentMutex.RUnlock()
+	argsForCall := fake.createAttachmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) CreateAttachmentReturns(result1 string, result2 error) {
+	fake.createAttachmentMutex.Lock()
+	defer fake.createAttachmentMutex.Unlock()
+	fake.CreateAttachmentStub = nil
+	fake.createAttachmentReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateAttachmentReturnsOnCall(i int, result1 string, result2 error) {
+	fake.createAttachmentMutex.Lock()
+	defer fake.createAttachmentMutex.Unlock()
+	fake.CreateAttachmentStub = nil
+	if fake.createAttachmentReturnsOnCall == nil {
+		fake.createAttachmentReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.createAttachmentReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreatePersonalAccessToken(arg1 context.Context, arg2 db.PersonalAccessToken) (string, error) {
+	fake.createPersonalAccessTokenMutex.Lock()
+	ret, specificReturn := fake.createPersonalAccessTokenReturnsOnCall[len(fake.createPersonalAccessTokenArgsForCall)]
+	fake.createPersonalAccessTokenArgsForCall = append(fake.createPersonalAccessTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.PersonalAccessToken
+	}{arg1, arg2})
+	stub := fake.CreatePersonalAccessTokenStub
+	fakeReturns := fake.createPersonalAccessTokenReturns
+	fake.recordInvocation("CreatePersonalAccessToken", []interface{}{arg1, arg2})
+	fake.createPersonalAccessTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) CreatePersonalAccessTokenCallCount() int {
+	fake.createPersonalAccessTokenMutex.RLock()
+	defer fake.createPersonalAccessTokenMutex.RUnlock()
+	return len(fake.createPersonalAccessTokenArgsForCall)
+}
+
+func (fake *FakeDB) CreatePersonalAccessTokenCalls(stub func(context.Context, db.PersonalAccessToken) (string, error)) {
+	fake.createPersonalAccessTokenMutex.Lock()
+	defer fake.createPersonalAccessTokenMutex.Unlock()
+	fake.CreatePersonalAccessTokenStub = stub
+}
+
+func (fake *FakeDB) CreatePersonalAccessTokenArgsForCall(i int) (context.Context, db.PersonalAccessToken) {
+	fake.createPersonalAccessTokenMutex.RLock()
+	defer fake.createPersonalAccessTokenMutex.RUnlock()
+	argsForCall := fake.createPersonalAccessTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) CreatePersonalAccessTokenReturns(result1 string, result2 error) {
+	fake.createPersonalAccessTokenMutex.Lock()
+	defer fake.createPersonalAccessTokenMutex.Unlock()
+	fake.CreatePersonalAccessTokenStub = nil
+	fake.createPersonalAccessTokenReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreatePersonalAccessTokenReturnsOnCall(i int, result1 string, result2 error) {
+	fake.createPersonalAccessTokenMutex.Lock()
+	defer fake.createPersonalAccessTokenMutex.Unlock()
+	fake.CreatePersonalAccessTokenStub = nil
+	if fake.createPersonalAccessTokenReturnsOnCall == nil {
+		fake.createPersonalAccessTokenReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.createPersonalAccessTokenReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateShare(arg1 context.Context, arg2 db.Share) (string, error) {
+	fake.createShareMutex.Lock()
+	ret, specificReturn := fake.createShareReturnsOnCall[len(fake.createShareArgsForCall)]
+	fake.createShareArgsForCall = append(fake.createShareArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.Share
+	}{arg1, arg2})
+	stub := fake.CreateShareStub
+	fakeReturns := fake.createShareReturns
+	fake.recordInvocation("CreateShare", []interface{}{arg1, arg2})
+	fake.createShareMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) CreateShareCallCount() int {
+	fake.createShareMutex.RLock()
+	defer fake.createShareMutex.RUnlock()
+	return len(fake.createShareArgsForCall)
+}
+
+func (fake *FakeDB) CreateShareCalls(stub func(context.Context, db.Share) (string, error)) {
+	fake.createShareMutex.Lock()
+	defer fake.createShareMutex.Unlock()
+	fake.CreateShareStub = stub
+}
+
+func (fake *FakeDB) CreateShareArgsForCall(i int) (context.Context, db.Share) {
+	fake.createShareMutex.RLock()
+	defer fake.createShareMutex.RUnlock()
+	argsForCall := fake.createShareArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) CreateShareReturns(result1 string, result2 error) {
+	fake.createShareMutex.Lock()
+	defer fake.createShareMutex.Unlock()
+	fake.CreateShareStub = nil
+	fake.createShareReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateShareReturnsOnCall(i int, result1 string, result2 error) {
+	fake.createShareMutex.Lock()
+	defer fake.createShareMutex.Unlock()
+	fake.CreateShareStub = nil
+	if fake.createShareReturnsOnCall == nil {
+		fake.createShareReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.createShareReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateTransfer(arg1 context.Context, arg2 db.Transfer) (string, error) {
+	fake.createTransferMutex.Lock()
+	ret, specificReturn := fake.createTransferReturnsOnCall[len(fake.createTransferArgsForCall)]
+	fake.createTransferArgsForCall = append(fake.createTransferArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.Transfer
+	}{arg1, arg2})
+	stub := fake.CreateTransferStub
+	fakeReturns := fake.createTransferReturns
+	fake.recordInvocation("CreateTransfer", []interface{}{arg1, arg2})
+	fake.createTransferMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) CreateTransferCallCount() int {
+	fake.createTransferMutex.RLock()
+	defer fake.createTransferMutex.RUnlock()
+	return len(fake.createTransferArgsForCall)
+}
+
+func (fake *FakeDB) CreateTransferCalls(stub func(context.Context, db.Transfer) (string, error)) {
+	fake.createTransferMutex.Lock()
+	defer fake.createTransferMutex.Unlock()
+	fake.CreateTransferStub = stub
+}
+
+func (fake *FakeDB) CreateTransferArgsForCall(i int) (context.Context, db.Transfer) {
+	fake.createTransferMutex.RLock()
+	defer fake.createTransferMutex.RUnlock()
+	argsForCall := fake.createTransferArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) CreateTransferReturns(result1 string, result2 error) {
+	fake.createTransferMutex.Lock()
+	defer fake.createTransferMutex.Unlock()
+	fake.CreateTransferStub = nil
+	fake.createTransferReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateTransferReturnsOnCall(i int, result1 string, result2 error) {
+	fake.createTransferMutex.Lock()
+	defer fake.createTransferMutex.Unlock()
+	fake.CreateTransferStub = nil
+	if fake.createTransferReturnsOnCall == nil {
+		fake.createTransferReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.createTransferReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateWebhookDelivery(arg1 context.Context, arg2 db.WebhookDelivery) (string, error) {
+	fake.createWebhookDeliveryMutex.Lock()
+	ret, specificReturn := fake.createWebhookDeliveryReturnsOnCall[len(fake.createWebhookDeliveryArgsForCall)]
+	fake.createWebhookDeliveryArgsForCall = append(fake.createWebhookDeliveryArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.WebhookDelivery
+	}{arg1, arg2})
+	stub := fake.CreateWebhookDeliveryStub
+	fakeReturns := fake.createWebhookDeliveryReturns
+	fake.recordInvocation("CreateWebhookDelivery", []interface{}{arg1, arg2})
+	fake.createWebhookDeliveryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) CreateWebhookDeliveryCallCount() int {
+	fake.createWebhookDeliveryMutex.RLock()
+	defer fake.createWebhookDeliveryMutex.RUnlock()
+	return len(fake.createWebhookDeliveryArgsForCall)
+}
+
+func (fake *FakeDB) CreateWebhookDeliveryCalls(stub func(context.Context, db.WebhookDelivery) (string, error)) {
+	fake.createWebhookDeliveryMutex.Lock()
+	defer fake.createWebhookDeliveryMutex.Unlock()
+	fake.CreateWebhookDeliveryStub = stub
+}
+
+func (fake *FakeDB) CreateWebhookDeliveryArgsForCall(i int) (context.Context, db.WebhookDelivery) {
+	fake.createWebhookDeliveryMutex.RLock()
+	defer fake.createWebhookDeliveryMutex.RUnlock()
+	argsForCall := fake.createWebhookDeliveryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) CreateWebhookDeliveryReturns(result1 string, result2 error) {
+	fake.createWebhookDeliveryMutex.Lock()
+	defer fake.createWebhookDeliveryMutex.Unlock()
+	fake.CreateWebhookDeliveryStub = nil
+	fake.createWebhookDeliveryReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateWebhookDeliveryReturnsOnCall(i int, result1 string, result2 error) {
+	fake.createWebhookDeliveryMutex.Lock()
+	defer fake.createWebhookDeliveryMutex.Unlock()
+	fake.CreateWebhookDeliveryStub = nil
+	if fake.createWebhookDeliveryReturnsOnCall == nil {
+		fake.createWebhookDeliveryReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.createWebhookDeliveryReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateWebhookEndpoint(arg1 context.Context, arg2 db.WebhookEndpoint) (string, error) {
+	fake.createWebhookEndpointMutex.Lock()
+	ret, specificReturn := fake.createWebhookEndpointReturnsOnCall[len(fake.createWebhookEndpointArgsForCall)]
+	fake.createWebhookEndpointArgsForCall = append(fake.createWebhookEndpointArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.WebhookEndpoint
+	}{arg1, arg2})
+	stub := fake.CreateWebhookEndpointStub
+	fakeReturns := fake.createWebhookEndpointReturns
+	fake.recordInvocation("CreateWebhookEndpoint", []interface{}{arg1, arg2})
+	fake.createWebhookEndpointMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) CreateWebhookEndpointCallCount() int {
+	fake.createWebhookEndpointMutex.RLock()
+	defer fake.createWebhookEndpointMutex.RUnlock()
+	return len(fake.createWebhookEndpointArgsForCall)
+}
+
+func (fake *FakeDB) CreateWebhookEndpointCalls(stub func(context.Context, db.WebhookEndpoint) (string, error)) {
+	fake.createWebhookEndpointMutex.Lock()
+	defer fake.createWebhookEndpointMutex.Unlock()
+	fake.CreateWebhookEndpointStub = stub
+}
+
+func (fake *FakeDB) CreateWebhookEndpointArgsForCall(i int) (context.Context, db.WebhookEndpoint) {
+	fake.createWebhookEndpointMutex.RLock()
+	defer fake.createWebhookEndpointMutex.RUnlock()
+	argsForCall := fake.createWebhookEndpointArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) CreateWebhookEndpointReturns(result1 string, result2 error) {
+	fake.createWebhookEndpointMutex.Lock()
+	defer fake.createWebhookEndpointMutex.Unlock()
+	fake.CreateWebhookEndpointStub = nil
+	fake.createWebhookEndpointReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) CreateWebhookEndpointReturnsOnCall(i int, result1 string, result2 error) {
+	fake.createWebhookEndpointMutex.Lock()
+	defer fake.createWebhookEndpointMutex.Unlock()
+	fake.CreateWebhookEndpointStub = nil
+	if fake.createWebhookEndpointReturnsOnCall == nil {
+		fake.createWebhookEndpointReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.createWebhookEndpointReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) DeconfigureAccount(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.deconfigureAccountMutex.Lock()
+	ret, specificReturn := fake.deconfigureAccountReturnsOnCall[len(fake.deconfigureAccountArgsForCall)]
+	fake.deconfigureAccountArgsForCall = append(fake.deconfigureAccountArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeconfigureAccountStub
+	fakeReturns := fake.deconfigureAccountReturns
+	fake.recordInvocation("DeconfigureAccount", []interface{}{arg1, arg2, arg3})
+	fake.deconfigureAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) DeconfigureAccountCallCount() int {
+	fake.deconfigureAccountMutex.RLock()
+	defer fake.deconfigureAccountMutex.RUnlock()
+	return len(fake.deconfigureAccountArgsForCall)
+}
+
+func (fake *FakeDB) DeconfigureAccountCalls(stub func(context.Context, string, string) error) {
+	fake.deconfigureAccountMutex.Lock()
+	defer fake.deconfigureAccountMutex.Unlock()
+	fake.DeconfigureAccountStub = stub
+}
+
+func (fake *FakeDB) DeconfigureAccountArgsForCall(i int) (context.Context, string, string) {
+	fake.deconfigureAccountMutex.RLock()
+	defer fake.deconfigureAccountMutex.RUnlock()
+	argsForCall := fake.deconfigureAccountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) DeconfigureAccountReturns(result1 error) {
+	fake.deconfigureAccountMutex.Lock()
+	defer fake.deconfigureAccountMutex.Unlock()
+	fake.DeconfigureAccountStub = nil
+	fake.deconfigureAccountReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeconfigureAccountReturnsOnCall(i int, result1 error) {
+	fake.deconfigureAccountMutex.Lock()
+	defer fake.deconfigureAccountMutex.Unlock()
+	fake.DeconfigureAccountStub = nil
+	if fake.deconfigureAccountReturnsOnCall == nil {
+		fake.deconfigureAccountReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deconfigureAccountReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteAccount(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.deleteAccountMutex.Lock()
+	ret, specificReturn := fake.deleteAccountReturnsOnCall[len(fake.deleteAccountArgsForCall)]
+	fake.deleteAccountArgsForCall = append(fake.deleteAccountArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteAccountStub
+	fakeReturns := fake.deleteAccountReturns
+	fake.recordInvocation("DeleteAccount", []interface{}{arg1, arg2, arg3})
+	fake.deleteAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) DeleteAccountCallCount() int {
+	fake.deleteAccountMutex.RLock()
+	defer fake.deleteAccountMutex.RUnlock()
+	return len(fake.deleteAccountArgsForCall)
+}
+
+func (fake *FakeDB) DeleteAccountCalls(stub func(context.Context, string, string) error) {
+	fake.deleteAccountMutex.Lock()
+	defer fake.deleteAccountMutex.Unlock()
+	fake.DeleteAccountStub = stub
+}
+
+func (fake *FakeDB) DeleteAccountArgsForCall(i int) (context.Context, string, string) {
+	fake.deleteAccountMutex.RLock()
+	defer fake.deleteAccountMutex.RUnlock()
+	argsForCall := fake.deleteAccountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) DeleteAccountReturns(result1 error) {
+	fake.deleteAccountMutex.Lock()
+	defer fake.deleteAccountMutex.Unlock()
+	fake.DeleteAccountStub = nil
+	fake.deleteAccountReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteAccountReturnsOnCall(i int, result1 error) {
+	fake.deleteAccountMutex.Lock()
+	defer fake.deleteAccountMutex.Unlock()
+	fake.DeleteAccountStub = nil
+	if fake.deleteAccountReturnsOnCall == nil {
+		fake.deleteAccountReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteAccountReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteAlert(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.deleteAlertMutex.Lock()
+	ret, specificReturn := fake.deleteAlertReturnsOnCall[len(fake.deleteAlertArgsForCall)]
+	fake.deleteAlertArgsForCall = append(fake.deleteAlertArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteAlertStub
+	fakeReturns := fake.deleteAlertReturns
+	fake.recordInvocation("DeleteAlert", []interface{}{arg1, arg2, arg3})
+	fake.deleteAlertMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) DeleteAlertCallCount() int {
+	fake.deleteAlertMutex.RLock()
+	defer fake.deleteAlertMutex.RUnlock()
+	return len(fake.deleteAlertArgsForCall)
+}
+
+func (fake *FakeDB) DeleteAlertCalls(stub func(context.Context, string, string) error) {
+	fake.deleteAlertMutex.Lock()
+	defer fake.deleteAlertMutex.Unlock()
+	fake.DeleteAlertStub = stub
+}
+
+func (fake *FakeDB) DeleteAlertArgsForCall(i int) (context.Context, string, string) {
+	fake.deleteAlertMutex.RLock()
+	defer fake.deleteAlertMutex.RUnlock()
+	argsForCall := fake.deleteAlertArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) DeleteAlertReturns(result1 error) {
+	fake.deleteAlertMutex.Lock()
+	defer fake.deleteAlertMutex.Unlock()
+	fake.DeleteAlertStub = nil
+	fake.deleteAlertReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteAlertReturnsOnCall(i int, result1 error) {
+	fake.deleteAlertMutex.Lock()
+	defer fake.deleteAlertMutex.Unlock()
+	fake.DeleteAlertStub = nil
+	if fake.deleteAlertReturnsOnCall == nil {
+		fake.deleteAlertReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteAlertReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteAttachment(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.deleteAttachmentMutex.Lock()
+	ret, specificReturn := fake.deleteAttachmentReturnsOnCall[len(fake.deleteAttachmentArgsForCall)]
+	fake.deleteAttachmentArgsForCall = append(fake.deleteAttachmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteAttachmentStub
+	fakeReturns := fake.deleteAttachmentReturns
+	fake.recordInvocation("DeleteAttachment", []interface{}{arg1, arg2, arg3})
+	fake.deleteAttachmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) DeleteAttachmentCallCount() int {
+	fake.deleteAttachmentMutex.RLock()
+	defer fake.deleteAttachmentMutex.RUnlock()
+	return len(fake.deleteAttachmentArgsForCall)
+}
+
+func (fake *FakeDB) DeleteAttachmentCalls(stub func(context.Context, string, string) error) {
+	fake.deleteAttachmentMutex.Lock()
+	defer fake.deleteAttachmentMutex.Unlock()
+	fake.DeleteAttachmentStub = stub
+}
+
+func (fake *FakeDB) DeleteAttachmentArgsForCall(i int) (context.Context, string, string) {
+	fake.deleteAttachmentMutex.RLock()
+	defer fake.deleteAttachmentMutex.RUnlock()
+	argsForCall := fake.deleteAttachmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) DeleteAttachmentReturns(result1 error) {
+	fake.deleteAttachmentMutex.Lock()
+	defer fake.deleteAttachmentMutex.Unlock()
+	fake.DeleteAttachmentStub = nil
+	fake.deleteAttachmentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteAttachmentReturnsOnCall(i int, result1 error) {
+	fake.deleteAttachmentMutex.Lock()
+	defer fake.deleteAttachmentMutex.Unlock()
+	fake.DeleteAttachmentStub = nil
+	if fake.deleteAttachmentReturnsOnCall == nil {
+		fake.deleteAttachmentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteAttachmentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteItem(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.deleteItemMutex.Lock()
+	ret, specificReturn := fake.deleteItemReturnsOnCall[len(fake.deleteItemArgsForCall)]
+	fake.deleteItemArgsForCall = append(fake.deleteItemArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteItemStub
+	fakeReturns := fake.deleteItemReturns
+	fake.recordInvocation("DeleteItem", []interface{}{arg1, arg2, arg3})
+	fake.deleteItemMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) DeleteItemCallCount() int {
+	fake.deleteItemMutex.RLock()
+	defer fake.deleteItemMutex.RUnlock()
+	return len(fake.deleteItemArgsForCall)
+}
+
+func (fake *FakeDB) DeleteItemCalls(stub func(context.Context, string, string) error) {
+	fake.deleteItemMutex.Lock()
+	defer fake.deleteItemMutex.Unlock()
+	fake.DeleteItemStub = stub
+}
+
+func (fake *FakeDB) DeleteItemArgsForCall(i int) (context.Context, string, string) {
+	fake.deleteItemMutex.RLock()
+	defer fake.deleteItemMutex.RUnlock()
+	argsForCall := fake.deleteItemArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) DeleteItemReturns(result1 error) {
+	fake.deleteItemMutex.Lock()
+	defer fake.deleteItemMutex.Unlock()
+	fake.DeleteItemStub = nil
+	fake.deleteItemReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteItemReturnsOnCall(i int, result1 error) {
+	fake.deleteItemMutex.Lock()
+	defer fake.deleteItemMutex.Unlock()
+	fake.DeleteItemStub = nil
+	if fake.deleteItemReturnsOnCall == nil {
+		fake.deleteItemReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteItemReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteTransactionByPlaidID(arg1 context.Context, arg2 string) (db.Transaction, error) {
+	fake.deleteTransactionByPlaidIDMutex.Lock()
+	ret, specificReturn := fake.deleteTransactionByPlaidIDReturnsOnCall[len(fake.deleteTransactionByPlaidIDArgsForCall)]
+	fake.deleteTransactionByPlaidIDArgsForCall = append(fake.deleteTransactionByPlaidIDArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteTransactionByPlaidIDStub
+	fakeReturns := fake.deleteTransactionByPlaidIDReturns
+	fake.recordInvocation("DeleteTransactionByPlaidID", []interface{}{arg1, arg2})
+	fake.deleteTransactionByPlaidIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) DeleteTransactionByPlaidIDCallCount() int {
+	fake.deleteTransactionByPlaidIDMutex.RLock()
+	defer fake.deleteTransactionByPlaidIDMutex.RUnlock()
+	return len(fake.deleteTransactionByPlaidIDArgsForCall)
+}
+
+func (fake *FakeDB) DeleteTransactionByPlaidIDCalls(stub func(context.Context, string) (db.Transaction, error)) {
+	fake.deleteTransactionByPlaidIDMutex.Lock()
+	defer fake.deleteTransactionByPlaidIDMutex.Unlock()
+	fake.DeleteTransactionByPlaidIDStub = stub
+}
+
+func (fake *FakeDB) DeleteTransactionByPlaidIDArgsForCall(i int) (context.Context, string) {
+	fake.deleteTransactionByPlaidIDMutex.RLock()
+	defer fake.deleteTransactionByPlaidIDMutex.RUnlock()
+	argsForCall := fake.deleteTransactionByPlaidIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) DeleteTransactionByPlaidIDReturns(result1 db.Transaction, result2 error) {
+	fake.deleteTransactionByPlaidIDMutex.Lock()
+	defer fake.deleteTransactionByPlaidIDMutex.Unlock()
+	fake.DeleteTransactionByPlaidIDStub = nil
+	fake.deleteTransactionByPlaidIDReturns = struct {
+		result1 db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) DeleteTransactionByPlaidIDReturnsOnCall(i int, result1 db.Transaction, result2 error) {
+	fake.deleteTransactionByPlaidIDMutex.Lock()
+	defer fake.deleteTransactionByPlaidIDMutex.Unlock()
+	fake.DeleteTransactionByPlaidIDStub = nil
+	if fake.deleteTransactionByPlaidIDReturnsOnCall == nil {
+		fake.deleteTransactionByPlaidIDReturnsOnCall = make(map[int]struct {
+			result1 db.Transaction
+			result2 error
+		})
+	}
+	fake.deleteTransactionByPlaidIDReturnsOnCall[i] = struct {
+		result1 db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) DeleteUser(arg1 context.Context, arg2 string) ([]db.Attachment, error) {
+	fake.deleteUserMutex.Lock()
+	ret, specificReturn := fake.deleteUserReturnsOnCall[len(fake.deleteUserArgsForCall)]
+	fake.deleteUserArgsForCall = append(fake.deleteUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeleteUserStub
+	fakeReturns := fake.deleteUserReturns
+	fake.recordInvocation("DeleteUser", []interface{}{arg1, arg2})
+	fake.deleteUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) DeleteUserCallCount() int {
+	fake.deleteUserMutex.RLock()
+	defer fake.deleteUserMutex.RUnlock()
+	return len(fake.deleteUserArgsForCall)
+}
+
+func (fake *FakeDB) DeleteUserCalls(stub func(context.Context, string) ([]db.Attachment, error)) {
+	fake.deleteUserMutex.Lock()
+	defer fake.deleteUserMutex.Unlock()
+	fake.DeleteUserStub = stub
+}
+
+func (fake *FakeDB) DeleteUserArgsForCall(i int) (context.Context, string) {
+	fake.deleteUserMutex.RLock()
+	defer fake.deleteUserMutex.RUnlock()
+	argsForCall := fake.deleteUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) DeleteUserReturns(result1 []db.Attachment, result2 error) {
+	fake.deleteUserMutex.Lock()
+	defer fake.deleteUserMutex.Unlock()
+	fake.DeleteUserStub = nil
+	fake.deleteUserReturns = struct {
+		result1 []db.Attachment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) DeleteUserReturnsOnCall(i int, result1 []db.Attachment, result2 error) {
+	fake.deleteUserMutex.Lock()
+	defer fake.deleteUserMutex.Unlock()
+	fake.DeleteUserStub = nil
+	if fake.deleteUserReturnsOnCall == nil {
+		fake.deleteUserReturnsOnCall = make(map[int]struct {
+			result1 []db.Attachment
+			result2 error
+		})
+	}
+	fake.deleteUserReturnsOnCall[i] = struct {
+		result1 []db.Attachment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) DeleteWebhookEndpoint(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.deleteWebhookEndpointMutex.Lock()
+	ret, specificReturn := fake.deleteWebhookEndpointReturnsOnCall[len(fake.deleteWebhookEndpointArgsForCall)]
+	fake.deleteWebhookEndpointArgsForCall = append(fake.deleteWebhookEndpointArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteWebhookEndpointStub
+	fakeReturns := fake.deleteWebhookEndpointReturns
+	fake.recordInvocation("DeleteWebhookEndpoint", []interface{}{arg1, arg2, arg3})
+	fake.deleteWebhookEndpointMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) DeleteWebhookEndpointCallCount() int {
+	fake.deleteWebhookEndpointMutex.RLock()
+	defer fake.deleteWebhookEndpointMutex.RUnlock()
+	return len(fake.deleteWebhookEndpointArgsForCall)
+}
+
+func (fake *FakeDB) DeleteWebhookEndpointCalls(stub func(context.Context, string, string) error) {
+	fake.deleteWebhookEndpointMutex.Lock()
+	defer fake.deleteWebhookEndpointMutex.Unlock()
+	fake.DeleteWebhookEndpointStub = stub
+}
+
+func (fake *FakeDB) DeleteWebhookEndpointArgsForCall(i int) (context.Context, string, string) {
+	fake.deleteWebhookEndpointMutex.RLock()
+	defer fake.deleteWebhookEndpointMutex.RUnlock()
+	argsForCall := fake.deleteWebhookEndpointArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) DeleteWebhookEndpointReturns(result1 error) {
+	fake.deleteWebhookEndpointMutex.Lock()
+	defer fake.deleteWebhookEndpointMutex.Unlock()
+	fake.DeleteWebhookEndpointStub = nil
+	fake.deleteWebhookEndpointReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) DeleteWebhookEndpointReturnsOnCall(i int, result1 error) {
+	fake.deleteWebhookEndpointMutex.Lock()
+	defer fake.deleteWebhookEndpointMutex.Unlock()
+	fake.DeleteWebhookEndpointStub = nil
+	if fake.deleteWebhookEndpointReturnsOnCall == nil {
+		fake.deleteWebhookEndpointReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteWebhookEndpointReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureAccountsTable(arg1 context.Context) error {
+	fake.ensureAccountsTableMutex.Lock()
+	ret, specificReturn := fake.ensureAccountsTableReturnsOnCall[len(fake.ensureAccountsTableArgsForCall)]
+	fake.ensureAccountsTableArgsForCall = append(fake.ensureAccountsTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureAccountsTableStub
+	fakeReturns := fake.ensureAccountsTableReturns
+	fake.recordInvocation("EnsureAccountsTable", []interface{}{arg1})
+	fake.ensureAccountsTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureAccountsTableCallCount() int {
+	fake.ensureAccountsTableMutex.RLock()
+	defer fake.ensureAccountsTableMutex.RUnlock()
+	return len(fake.ensureAccountsTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsureAccountsTableCalls(stub func(context.Context) error) {
+	fake.ensureAccountsTableMutex.Lock()
+	defer fake.ensureAccountsTableMutex.Unlock()
+	fake.EnsureAccountsTableStub = stub
+}
+
+func (fake *FakeDB) EnsureAccountsTableArgsForCall(i int) context.Context {
+	fake.ensureAccountsTableMutex.RLock()
+	defer fake.ensureAccountsTableMutex.RUnlock()
+	argsForCall := fake.ensureAccountsTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureAccountsTableReturns(result1 error) {
+	fake.ensureAccountsTableMutex.Lock()
+	defer fake.ensureAccountsTableMutex.Unlock()
+	fake.EnsureAccountsTableStub = nil
+	fake.ensureAccountsTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureAccountsTableReturnsOnCall(i int, result1 error) {
+	fake.ensureAccountsTableMutex.Lock()
+	defer fake.ensureAccountsTableMutex.Unlock()
+	fake.EnsureAccountsTableStub = nil
+	if fake.ensureAccountsTableReturnsOnCall == nil {
+		fake.ensureAccountsTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureAccountsTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureAlertsTables(arg1 context.Context) error {
+	fake.ensureAlertsTablesMutex.Lock()
+	ret, specificReturn := fake.ensureAlertsTablesReturnsOnCall[len(fake.ensureAlertsTablesArgsForCall)]
+	fake.ensureAlertsTablesArgsForCall = append(fake.ensureAlertsTablesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureAlertsTablesStub
+	fakeReturns := fake.ensureAlertsTablesReturns
+	fake.recordInvocation("EnsureAlertsTables", []interface{}{arg1})
+	fake.ensureAlertsTablesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureAlertsTablesCallCount() int {
+	fake.ensureAlertsTablesMutex.RLock()
+	defer fake.ensureAlertsTablesMutex.RUnlock()
+	return len(fake.ensureAlertsTablesArgsForCall)
+}
+
+func (fake *FakeDB) EnsureAlertsTablesCalls(stub func(context.Context) error) {
+	fake.ensureAlertsTablesMutex.Lock()
+	defer fake.ensureAlertsTablesMutex.Unlock()
+	fake.EnsureAlertsTablesStub = stub
+}
+
+func (fake *FakeDB) EnsureAlertsTablesArgsForCall(i int) context.Context {
+	fake.ensureAlertsTablesMutex.RLock()
+	defer fake.ensureAlertsTablesMutex.RUnlock()
+	argsForCall := fake.ensureAlertsTablesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureAlertsTablesReturns(result1 error) {
+	fake.ensureAlertsTablesMutex.Lock()
+	defer fake.ensureAlertsTablesMutex.Unlock()
+	fake.EnsureAlertsTablesStub = nil
+	fake.ensureAlertsTablesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureAlertsTablesReturnsOnCall(i int, result1 error) {
+	fake.ensureAlertsTablesMutex.Lock()
+	defer fake.ensureAlertsTablesMutex.Unlock()
+	fake.EnsureAlertsTablesStub = nil
+	if fake.ensureAlertsTablesReturnsOnCall == nil {
+		fake.ensureAlertsTablesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureAlertsTablesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureAttachmentsTable(arg1 context.Context) error {
+	fake.ensureAttachmentsTableMutex.Lock()
+	ret, specificReturn := fake.ensureAttachmentsTableReturnsOnCall[len(fake.ensureAttachmentsTableArgsForCall)]
+	fake.ensureAttachmentsTableArgsForCall = append(fake.ensureAttachmentsTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureAttachmentsTableStub
+	fakeReturns := fake.ensureAttachmentsTableReturns
+	fake.recordInvocation("EnsureAttachmentsTable", []interface{}{arg1})
+	fake.ensureAttachmentsTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureAttachmentsTableCallCount() int {
+	fake.ensureAttachmentsTableMutex.RLock()
+	defer fake.ensureAttachmentsTableMutex.RUnlock()
+	return len(fake.ensureAttachmentsTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsureAttachmentsTableCalls(stub func(context.Context) error) {
+	fake.ensureAttachmentsTableMutex.Lock()
+	defer fake.ensureAttachmentsTableMutex.Unlock()
+	fake.EnsureAttachmentsTableStub = stub
+}
+
+func (fake *FakeDB) EnsureAttachmentsTableArgsForCall(i int) context.Context {
+	fake.ensureAttachmentsTableMutex.RLock()
+	defer fake.ensureAttachmentsTableMutex.RUnlock()
+	argsForCall := fake.ensureAttachmentsTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureAttachmentsTableReturns(result1 error) {
+	fake.ensureAttachmentsTableMutex.Lock()
+	defer fake.ensureAttachmentsTableMutex.Unlock()
+	fake.EnsureAttachmentsTableStub = nil
+	fake.ensureAttachmentsTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureAttachmentsTableReturnsOnCall(i int, result1 error) {
+	fake.ensureAttachmentsTableMutex.Lock()
+	defer fake.ensureAttachmentsTableMutex.Unlock()
+	fake.EnsureAttachmentsTableStub = nil
+	if fake.ensureAttachmentsTableReturnsOnCall == nil {
+		fake.ensureAttachmentsTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureAttachmentsTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureAuditEventsTable(arg1 context.Context) error {
+	fake.ensureAuditEventsTableMutex.Lock()
+	ret, specificReturn := fake.ensureAuditEventsTableReturnsOnCall[len(fake.ensureAuditEventsTableArgsForCall)]
+	fake.ensureAuditEventsTableArgsForCall = append(fake.ensureAuditEventsTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureAuditEventsTableStub
+	fakeReturns := fake.ensureAuditEventsTableReturns
+	fake.recordInvocation("EnsureAuditEventsTable", []interface{}{arg1})
+	fake.ensureAuditEventsTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureAuditEventsTableCallCount() int {
+	fake.ensureAuditEventsTableMutex.RLock()
+	defer fake.ensureAuditEventsTableMutex.RUnlock()
+	return len(fake.ensureAuditEventsTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsureAuditEventsTableCalls(stub func(context.Context) error) {
+	fake.ensureAuditEventsTableMutex.Lock()
+	defer fake.ensureAuditEventsTableMutex.Unlock()
+	fake.EnsureAuditEventsTableStub = stub
+}
+
+func (fake *FakeDB) EnsureAuditEventsTableArgsForCall(i int) context.Context {
+	fake.ensureAuditEventsTableMutex.RLock()
+	defer fake.ensureAuditEventsTableMutex.RUnlock()
+	argsForCall := fake.ensureAuditEventsTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureAuditEventsTableReturns(result1 error) {
+	fake.ensureAuditEventsTableMutex.Lock()
+	defer fake.ensureAuditEventsTableMutex.Unlock()
+	fake.EnsureAuditEventsTableStub = nil
+	fake.ensureAuditEventsTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureAuditEventsTableReturnsOnCall(i int, result1 error) {
+	fake.ensureAuditEventsTableMutex.Lock()
+	defer fake.ensureAuditEventsTableMutex.Unlock()
+	fake.EnsureAuditEventsTableStub = nil
+	if fake.ensureAuditEventsTableReturnsOnCall == nil {
+		fake.ensureAuditEventsTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureAuditEventsTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureBalancesTable(arg1 context.Context) error {
+	fake.ensureBalancesTableMutex.Lock()
+	ret, specificReturn := fake.ensureBalancesTableReturnsOnCall[len(fake.ensureBalancesTableArgsForCall)]
+	fake.ensureBalancesTableArgsForCall = append(fake.ensureBalancesTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureBalancesTableStub
+	fakeReturns := fake.ensureBalancesTableReturns
+	fake.recordInvocation("EnsureBalancesTable", []interface{}{arg1})
+	fake.ensureBalancesTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureBalancesTableCallCount() int {
+	fake.ensureBalancesTableMutex.RLock()
+	defer fake.ensureBalancesTableMutex.RUnlock()
+	return len(fake.ensureBalancesTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsureBalancesTableCalls(stub func(context.Context) error) {
+	fake.ensureBalancesTableMutex.Lock()
+	defer fake.ensureBalancesTableMutex.Unlock()
+	fake.EnsureBalancesTableStub = stub
+}
+
+func (fake *FakeDB) EnsureBalancesTableArgsForCall(i int) context.Context {
+	fake.ensureBalancesTableMutex.RLock()
+	defer fake.ensureBalancesTableMutex.RUnlock()
+	argsForCall := fake.ensureBalancesTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureBalancesTableReturns(result1 error) {
+	fake.ensureBalancesTableMutex.Lock()
+	defer fake.ensureBalancesTableMutex.Unlock()
+	fake.EnsureBalancesTableStub = nil
+	fake.ensureBalancesTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureBalancesTableReturnsOnCall(i int, result1 error) {
+	fake.ensureBalancesTableMutex.Lock()
+	defer fake.ensureBalancesTableMutex.Unlock()
+	fake.EnsureBalancesTableStub = nil
+	if fake.ensureBalancesTableReturnsOnCall == nil {
+		fake.ensureBalancesTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureBalancesTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureDigestPreferencesTable(arg1 context.Context) error {
+	fake.ensureDigestPreferencesTableMutex.Lock()
+	ret, specificReturn := fake.ensureDigestPreferencesTableReturnsOnCall[len(fake.ensureDigestPreferencesTableArgsForCall)]
+	fake.ensureDigestPreferencesTableArgsForCall = append(fake.ensureDigestPreferencesTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureDigestPreferencesTableStub
+	fakeReturns := fake.ensureDigestPreferencesTableReturns
+	fake.recordInvocation("EnsureDigestPreferencesTable", []interface{}{arg1})
+	fake.ensureDigestPreferencesTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureDigestPreferencesTableCallCount() int {
+	fake.ensureDigestPreferencesTableMutex.RLock()
+	defer fake.ensureDigestPreferencesTableMutex.RUnlock()
+	return len(fake.ensureDigestPreferencesTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsureDigestPreferencesTableCalls(stub func(context.Context) error) {
+	fake.ensureDigestPreferencesTableMutex.Lock()
+	defer fake.ensureDigestPreferencesTableMutex.Unlock()
+	fake.EnsureDigestPreferencesTableStub = stub
+}
+
+func (fake *FakeDB) EnsureDigestPreferencesTableArgsForCall(i int) context.Context {
+	fake.ensureDigestPreferencesTableMutex.RLock()
+	defer fake.ensureDigestPreferencesTableMutex.RUnlock()
+	argsForCall := fake.ensureDigestPreferencesTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureDigestPreferencesTableReturns(result1 error) {
+	fake.ensureDigestPreferencesTableMutex.Lock()
+	defer fake.ensureDigestPreferencesTableMutex.Unlock()
+	fake.EnsureDigestPreferencesTableStub = nil
+	fake.ensureDigestPreferencesTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureDigestPreferencesTableReturnsOnCall(i int, result1 error) {
+	fake.ensureDigestPreferencesTableMutex.Lock()
+	defer fake.ensureDigestPreferencesTableMutex.Unlock()
+	fake.EnsureDigestPreferencesTableStub = nil
+	if fake.ensureDigestPreferencesTableReturnsOnCall == nil {
+		fake.ensureDigestPreferencesTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureDigestPreferencesTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureItemsTable(arg1 context.Context) error {
+	fake.ensureItemsTableMutex.Lock()
+	ret, specificReturn := fake.ensureItemsTableReturnsOnCall[len(fake.ensureItemsTableArgsForCall)]
+	fake.ensureItemsTableArgsForCall = append(fake.ensureItemsTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureItemsTableStub
+	fakeReturns := fake.ensureItemsTableReturns
+	fake.recordInvocation("EnsureItemsTable", []interface{}{arg1})
+	fake.ensureItemsTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureItemsTableCallCount() int {
+	fake.ensureItemsTableMutex.RLock()
+	defer fake.ensureItemsTableMutex.RUnlock()
+	return len(fake.ensureItemsTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsureItemsTableCalls(stub func(context.Context) error) {
+	fake.ensureItemsTableMutex.Lock()
+	defer fake.ensureItemsTableMutex.Unlock()
+	fake.EnsureItemsTableStub = stub
+}
+
+func (fake *FakeDB) EnsureItemsTableArgsForCall(i int) context.Context {
+	fake.ensureItemsTableMutex.RLock()
+	defer fake.ensureItemsTableMutex.RUnlock()
+	argsForCall := fake.ensureItemsTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureItemsTableReturns(result1 error) {
+	fake.ensureItemsTableMutex.Lock()
+	defer fake.ensureItemsTableMutex.Unlock()
+	fake.EnsureItemsTableStub = nil
+	fake.ensureItemsTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureItemsTableReturnsOnCall(i int, result1 error) {
+	fake.ensureItemsTableMutex.Lock()
+	defer fake.ensureItemsTableMutex.Unlock()
+	fake.EnsureItemsTableStub = nil
+	if fake.ensureItemsTableReturnsOnCall == nil {
+		fake.ensureItemsTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureItemsTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureJobRunsTable(arg1 context.Context) error {
+	fake.ensureJobRunsTableMutex.Lock()
+	ret, specificReturn := fake.ensureJobRunsTableReturnsOnCall[len(fake.ensureJobRunsTableArgsForCall)]
+	fake.ensureJobRunsTableArgsForCall = append(fake.ensureJobRunsTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureJobRunsTableStub
+	fakeReturns := fake.ensureJobRunsTableReturns
+	fake.recordInvocation("EnsureJobRunsTable", []interface{}{arg1})
+	fake.ensureJobRunsTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureJobRunsTableCallCount() int {
+	fake.ensureJobRunsTableMutex.RLock()
+	defer fake.ensureJobRunsTableMutex.RUnlock()
+	return len(fake.ensureJobRunsTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsureJobRunsTableCalls(stub func(context.Context) error) {
+	fake.ensureJobRunsTableMutex.Lock()
+	defer fake.ensureJobRunsTableMutex.Unlock()
+	fake.EnsureJobRunsTableStub = stub
+}
+
+func (fake *FakeDB) EnsureJobRunsTableArgsForCall(i int) context.Context {
+	fake.ensureJobRunsTableMutex.RLock()
+	defer fake.ensureJobRunsTableMutex.RUnlock()
+	argsForCall := fake.ensureJobRunsTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureJobRunsTableReturns(result1 error) {
+	fake.ensureJobRunsTableMutex.Lock()
+	defer fake.ensureJobRunsTableMutex.Unlock()
+	fake.EnsureJobRunsTableStub = nil
+	fake.ensureJobRunsTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureJobRunsTableReturnsOnCall(i int, result1 error) {
+	fake.ensureJobRunsTableMutex.Lock()
+	defer fake.ensureJobRunsTableMutex.Unlock()
+	fake.EnsureJobRunsTableStub = nil
+	if fake.ensureJobRunsTableReturnsOnCall == nil {
+		fake.ensureJobRunsTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureJobRunsTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsurePersonalAccessTokensTable(arg1 context.Context) error {
+	fake.ensurePersonalAccessTokensTableMutex.Lock()
+	ret, specificReturn := fake.ensurePersonalAccessTokensTableReturnsOnCall[len(fake.ensurePersonalAccessTokensTableArgsForCall)]
+	fake.ensurePersonalAccessTokensTableArgsForCall = append(fake.ensurePersonalAccessTokensTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsurePersonalAccessTokensTableStub
+	fakeReturns := fake.ensurePersonalAccessTokensTableReturns
+	fake.recordInvocation("EnsurePersonalAccessTokensTable", []interface{}{arg1})
+	fake.ensurePersonalAccessTokensTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsurePersonalAccessTokensTableCallCount() int {
+	fake.ensurePersonalAccessTokensTableMutex.RLock()
+	defer fake.ensurePersonalAccessTokensTableMutex.RUnlock()
+	return len(fake.ensurePersonalAccessTokensTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsurePersonalAccessTokensTableCalls(stub func(context.Context) error) {
+	fake.ensurePersonalAccessTokensTableMutex.Lock()
+	defer fake.ensurePersonalAccessTokensTableMutex.Unlock()
+	fake.EnsurePersonalAccessTokensTableStub = stub
+}
+
+func (fake *FakeDB) EnsurePersonalAccessTokensTableArgsForCall(i int) context.Context {
+	fake.ensurePersonalAccessTokensTableMutex.RLock()
+	defer fake.ensurePersonalAccessTokensTableMutex.RUnlock()
+	argsForCall := fake.ensurePersonalAccessTokensTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsurePersonalAccessTokensTableReturns(result1 error) {
+	fake.ensurePersonalAccessTokensTableMutex.Lock()
+	defer fake.ensurePersonalAccessTokensTableMutex.Unlock()
+	fake.EnsurePersonalAccessTokensTableStub = nil
+	fake.ensurePersonalAccessTokensTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsurePersonalAccessTokensTableReturnsOnCall(i int, result1 error) {
+	fake.ensurePersonalAccessTokensTableMutex.Lock()
+	defer fake.ensurePersonalAccessTokensTableMutex.Unlock()
+	fake.EnsurePersonalAccessTokensTableStub = nil
+	if fake.ensurePersonalAccessTokensTableReturnsOnCall == nil {
+		fake.ensurePersonalAccessTokensTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensurePersonalAccessTokensTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureRolesTables(arg1 context.Context) error {
+	fake.ensureRolesTablesMutex.Lock()
+	ret, specificReturn := fake.ensureRolesTablesReturnsOnCall[len(fake.ensureRolesTablesArgsForCall)]
+	fake.ensureRolesTablesArgsForCall = append(fake.ensureRolesTablesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureRolesTablesStub
+	fakeReturns := fake.ensureRolesTablesReturns
+	fake.recordInvocation("EnsureRolesTables", []interface{}{arg1})
+	fake.ensureRolesTablesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureRolesTablesCallCount() int {
+	fake.ensureRolesTablesMutex.RLock()
+	defer fake.ensureRolesTablesMutex.RUnlock()
+	return len(fake.ensureRolesTablesArgsForCall)
+}
+
+func (fake *FakeDB) EnsureRolesTablesCalls(stub func(context.Context) error) {
+	fake.ensureRolesTablesMutex.Lock()
+	defer fake.ensureRolesTablesMutex.Unlock()
+	fake.EnsureRolesTablesStub = stub
+}
+
+func (fake *FakeDB) EnsureRolesTablesArgsForCall(i int) context.Context {
+	fake.ensureRolesTablesMutex.RLock()
+	defer fake.ensureRolesTablesMutex.RUnlock()
+	argsForCall := fake.ensureRolesTablesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureRolesTablesReturns(result1 error) {
+	fake.ensureRolesTablesMutex.Lock()
+	defer fake.ensureRolesTablesMutex.Unlock()
+	fake.EnsureRolesTablesStub = nil
+	fake.ensureRolesTablesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureRolesTablesReturnsOnCall(i int, result1 error) {
+	fake.ensureRolesTablesMutex.Lock()
+	defer fake.ensureRolesTablesMutex.Unlock()
+	fake.EnsureRolesTablesStub = nil
+	if fake.ensureRolesTablesReturnsOnCall == nil {
+		fake.ensureRolesTablesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureRolesTablesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureSharesTables(arg1 context.Context) error {
+	fake.ensureSharesTablesMutex.Lock()
+	ret, specificReturn := fake.ensureSharesTablesReturnsOnCall[len(fake.ensureSharesTablesArgsForCall)]
+	fake.ensureSharesTablesArgsForCall = append(fake.ensureSharesTablesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureSharesTablesStub
+	fakeReturns := fake.ensureSharesTablesReturns
+	fake.recordInvocation("EnsureSharesTables", []interface{}{arg1})
+	fake.ensureSharesTablesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureSharesTablesCallCount() int {
+	fake.ensureSharesTablesMutex.RLock()
+	defer fake.ensureSharesTablesMutex.RUnlock()
+	return len(fake.ensureSharesTablesArgsForCall)
+}
+
+func (fake *FakeDB) EnsureSharesTablesCalls(stub func(context.Context) error) {
+	fake.ensureSharesTablesMutex.Lock()
+	defer fake.ensureSharesTablesMutex.Unlock()
+	fake.EnsureSharesTablesStub = stub
+}
+
+func (fake *FakeDB) EnsureSharesTablesArgsForCall(i int) context.Context {
+	fake.ensureSharesTablesMutex.RLock()
+	defer fake.ensureSharesTablesMutex.RUnlock()
+	argsForCall := fake.ensureSharesTablesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureSharesTablesReturns(result1 error) {
+	fake.ensureSharesTablesMutex.Lock()
+	defer fake.ensureSharesTablesMutex.Unlock()
+	fake.EnsureSharesTablesStub = nil
+	fake.ensureSharesTablesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureSharesTablesReturnsOnCall(i int, result1 error) {
+	fake.ensureSharesTablesMutex.Lock()
+	defer fake.ensureSharesTablesMutex.Unlock()
+	fake.EnsureSharesTablesStub = nil
+	if fake.ensureSharesTablesReturnsOnCall == nil {
+		fake.ensureSharesTablesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureSharesTablesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureSplitsTable(arg1 context.Context) error {
+	fake.ensureSplitsTableMutex.Lock()
+	ret, specificReturn := fake.ensureSplitsTableReturnsOnCall[len(fake.ensureSplitsTableArgsForCall)]
+	fake.ensureSplitsTableArgsForCall = append(fake.ensureSplitsTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureSplitsTableStub
+	fakeReturns := fake.ensureSplitsTableReturns
+	fake.recordInvocation("EnsureSplitsTable", []interface{}{arg1})
+	fake.ensureSplitsTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureSplitsTableCallCount() int {
+	fake.ensureSplitsTableMutex.RLock()
+	defer fake.ensureSplitsTableMutex.RUnlock()
+	return len(fake.ensureSplitsTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsureSplitsTableCalls(stub func(context.Context) error) {
+	fake.ensureSplitsTableMutex.Lock()
+	defer fake.ensureSplitsTableMutex.Unlock()
+	fake.EnsureSplitsTableStub = stub
+}
+
+func (fake *FakeDB) EnsureSplitsTableArgsForCall(i int) context.Context {
+	fake.ensureSplitsTableMutex.RLock()
+	defer fake.ensureSplitsTableMutex.RUnlock()
+	argsForCall := fake.ensureSplitsTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureSplitsTableReturns(result1 error) {
+	fake.ensureSplitsTableMutex.Lock()
+	defer fake.ensureSplitsTableMutex.Unlock()
+	fake.EnsureSplitsTableStub = nil
+	fake.ensureSplitsTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureSplitsTableReturnsOnCall(i int, result1 error) {
+	fake.ensureSplitsTableMutex.Lock()
+	defer fake.ensureSplitsTableMutex.Unlock()
+	fake.EnsureSplitsTableStub = nil
+	if fake.ensureSplitsTableReturnsOnCall == nil {
+		fake.ensureSplitsTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureSplitsTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureTagsTables(arg1 context.Context) error {
+	fake.ensureTagsTablesMutex.Lock()
+	ret, specificReturn := fake.ensureTagsTablesReturnsOnCall[len(fake.ensureTagsTablesArgsForCall)]
+	fake.ensureTagsTablesArgsForCall = append(fake.ensureTagsTablesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureTagsTablesStub
+	fakeReturns := fake.ensureTagsTablesReturns
+	fake.recordInvocation("EnsureTagsTables", []interface{}{arg1})
+	fake.ensureTagsTablesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureTagsTablesCallCount() int {
+	fake.ensureTagsTablesMutex.RLock()
+	defer fake.ensureTagsTablesMutex.RUnlock()
+	return len(fake.ensureTagsTablesArgsForCall)
+}
+
+func (fake *FakeDB) EnsureTagsTablesCalls(stub func(context.Context) error) {
+	fake.ensureTagsTablesMutex.Lock()
+	defer fake.ensureTagsTablesMutex.Unlock()
+	fake.EnsureTagsTablesStub = stub
+}
+
+func (fake *FakeDB) EnsureTagsTablesArgsForCall(i int) context.Context {
+	fake.ensureTagsTablesMutex.RLock()
+	defer fake.ensureTagsTablesMutex.RUnlock()
+	argsForCall := fake.ensureTagsTablesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureTagsTablesReturns(result1 error) {
+	fake.ensureTagsTablesMutex.Lock()
+	defer fake.ensureTagsTablesMutex.Unlock()
+	fake.EnsureTagsTablesStub = nil
+	fake.ensureTagsTablesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureTagsTablesReturnsOnCall(i int, result1 error) {
+	fake.ensureTagsTablesMutex.Lock()
+	defer fake.ensureTagsTablesMutex.Unlock()
+	fake.EnsureTagsTablesStub = nil
+	if fake.ensureTagsTablesReturnsOnCall == nil {
+		fake.ensureTagsTablesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureTagsTablesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureTransactionsTable(arg1 context.Context) error {
+	fake.ensureTransactionsTableMutex.Lock()
+	ret, specificReturn := fake.ensureTransactionsTableReturnsOnCall[len(fake.ensureTransactionsTableArgsForCall)]
+	fake.ensureTransactionsTableArgsForCall = append(fake.ensureTransactionsTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureTransactionsTableStub
+	fakeReturns := fake.ensureTransactionsTableReturns
+	fake.recordInvocation("EnsureTransactionsTable", []interface{}{arg1})
+	fake.ensureTransactionsTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureTransactionsTableCallCount() int {
+	fake.ensureTransactionsTableMutex.RLock()
+	defer fake.ensureTransactionsTableMutex.RUnlock()
+	return len(fake.ensureTransactionsTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsureTransactionsTableCalls(stub func(context.Context) error) {
+	fake.ensureTransactionsTableMutex.Lock()
+	defer fake.ensureTransactionsTableMutex.Unlock()
+	fake.EnsureTransactionsTableStub = stub
+}
+
+func (fake *FakeDB) EnsureTransactionsTableArgsForCall(i int) context.Context {
+	fake.ensureTransactionsTableMutex.RLock()
+	defer fake.ensureTransactionsTableMutex.RUnlock()
+	argsForCall := fake.ensureTransactionsTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureTransactionsTableReturns(result1 error) {
+	fake.ensureTransactionsTableMutex.Lock()
+	defer fake.ensureTransactionsTableMutex.Unlock()
+	fake.EnsureTransactionsTableStub = nil
+	fake.ensureTransactionsTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureTransactionsTableReturnsOnCall(i int, result1 error) {
+	fake.ensureTransactionsTableMutex.Lock()
+	defer fake.ensureTransactionsTableMutex.Unlock()
+	fake.EnsureTransactionsTableStub = nil
+	if fake.ensureTransactionsTableReturnsOnCall == nil {
+		fake.ensureTransactionsTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureTransactionsTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureTransfersTable(arg1 context.Context) error {
+	fake.ensureTransfersTableMutex.Lock()
+	ret, specificReturn := fake.ensureTransfersTableReturnsOnCall[len(fake.ensureTransfersTableArgsForCall)]
+	fake.ensureTransfersTableArgsForCall = append(fake.ensureTransfersTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureTransfersTableStub
+	fakeReturns := fake.ensureTransfersTableReturns
+	fake.recordInvocation("EnsureTransfersTable", []interface{}{arg1})
+	fake.ensureTransfersTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureTransfersTableCallCount() int {
+	fake.ensureTransfersTableMutex.RLock()
+	defer fake.ensureTransfersTableMutex.RUnlock()
+	return len(fake.ensureTransfersTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsureTransfersTableCalls(stub func(context.Context) error) {
+	fake.ensureTransfersTableMutex.Lock()
+	defer fake.ensureTransfersTableMutex.Unlock()
+	fake.EnsureTransfersTableStub = stub
+}
+
+func (fake *FakeDB) EnsureTransfersTableArgsForCall(i int) context.Context {
+	fake.ensureTransfersTableMutex.RLock()
+	defer fake.ensureTransfersTableMutex.RUnlock()
+	argsForCall := fake.ensureTransfersTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureTransfersTableReturns(result1 error) {
+	fake.ensureTransfersTableMutex.Lock()
+	defer fake.ensureTransfersTableMutex.Unlock()
+	fake.EnsureTransfersTableStub = nil
+	fake.ensureTransfersTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureTransfersTableReturnsOnCall(i int, result1 error) {
+	fake.ensureTransfersTableMutex.Lock()
+	defer fake.ensureTransfersTableMutex.Unlock()
+	fake.EnsureTransfersTableStub = nil
+	if fake.ensureTransfersTableReturnsOnCall == nil {
+		fake.ensureTransfersTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureTransfersTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureUserIdentitiesTable(arg1 context.Context) error {
+	fake.ensureUserIdentitiesTableMutex.Lock()
+	ret, specificReturn := fake.ensureUserIdentitiesTableReturnsOnCall[len(fake.ensureUserIdentitiesTableArgsForCall)]
+	fake.ensureUserIdentitiesTableArgsForCall = append(fake.ensureUserIdentitiesTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureUserIdentitiesTableStub
+	fakeReturns := fake.ensureUserIdentitiesTableReturns
+	fake.recordInvocation("EnsureUserIdentitiesTable", []interface{}{arg1})
+	fake.ensureUserIdentitiesTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureUserIdentitiesTableCallCount() int {
+	fake.ensureUserIdentitiesTableMutex.RLock()
+	defer fake.ensureUserIdentitiesTableMutex.RUnlock()
+	return len(fake.ensureUserIdentitiesTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsureUserIdentitiesTableCalls(stub func(context.Context) error) {
+	fake.ensureUserIdentitiesTableMutex.Lock()
+	defer fake.ensureUserIdentitiesTableMutex.Unlock()
+	fake.EnsureUserIdentitiesTableStub = stub
+}
+
+func (fake *FakeDB) EnsureUserIdentitiesTableArgsForCall(i int) context.Context {
+	fake.ensureUserIdentitiesTableMutex.RLock()
+	defer fake.ensureUserIdentitiesTableMutex.RUnlock()
+	argsForCall := fake.ensureUserIdentitiesTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureUserIdentitiesTableReturns(result1 error) {
+	fake.ensureUserIdentitiesTableMutex.Lock()
+	defer fake.ensureUserIdentitiesTableMutex.Unlock()
+	fake.EnsureUserIdentitiesTableStub = nil
+	fake.ensureUserIdentitiesTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureUserIdentitiesTableReturnsOnCall(i int, result1 error) {
+	fake.ensureUserIdentitiesTableMutex.Lock()
+	defer fake.ensureUserIdentitiesTableMutex.Unlock()
+	fake.EnsureUserIdentitiesTableStub = nil
+	if fake.ensureUserIdentitiesTableReturnsOnCall == nil {
+		fake.ensureUserIdentitiesTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureUserIdentitiesTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureUsersTable(arg1 context.Context) error {
+	fake.ensureUsersTableMutex.Lock()
+	ret, specificReturn := fake.ensureUsersTableReturnsOnCall[len(fake.ensureUsersTableArgsForCall)]
+	fake.ensureUsersTableArgsForCall = append(fake.ensureUsersTableArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureUsersTableStub
+	fakeReturns := fake.ensureUsersTableReturns
+	fake.recordInvocation("EnsureUsersTable", []interface{}{arg1})
+	fake.ensureUsersTableMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureUsersTableCallCount() int {
+	fake.ensureUsersTableMutex.RLock()
+	defer fake.ensureUsersTableMutex.RUnlock()
+	return len(fake.ensureUsersTableArgsForCall)
+}
+
+func (fake *FakeDB) EnsureUsersTableCalls(stub func(context.Context) error) {
+	fake.ensureUsersTableMutex.Lock()
+	defer fake.ensureUsersTableMutex.Unlock()
+	fake.EnsureUsersTableStub = stub
+}
+
+func (fake *FakeDB) EnsureUsersTableArgsForCall(i int) context.Context {
+	fake.ensureUsersTableMutex.RLock()
+	defer fake.ensureUsersTableMutex.RUnlock()
+	argsForCall := fake.ensureUsersTableArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureUsersTableReturns(result1 error) {
+	fake.ensureUsersTableMutex.Lock()
+	defer fake.ensureUsersTableMutex.Unlock()
+	fake.EnsureUsersTableStub = nil
+	fake.ensureUsersTableReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureUsersTableReturnsOnCall(i int, result1 error) {
+	fake.ensureUsersTableMutex.Lock()
+	defer fake.ensureUsersTableMutex.Unlock()
+	fake.EnsureUsersTableStub = nil
+	if fake.ensureUsersTableReturnsOnCall == nil {
+		fake.ensureUsersTableReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureUsersTableReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureWebhooksTables(arg1 context.Context) error {
+	fake.ensureWebhooksTablesMutex.Lock()
+	ret, specificReturn := fake.ensureWebhooksTablesReturnsOnCall[len(fake.ensureWebhooksTablesArgsForCall)]
+	fake.ensureWebhooksTablesArgsForCall = append(fake.ensureWebhooksTablesArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.EnsureWebhooksTablesStub
+	fakeReturns := fake.ensureWebhooksTablesReturns
+	fake.recordInvocation("EnsureWebhooksTables", []interface{}{arg1})
+	fake.ensureWebhooksTablesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) EnsureWebhooksTablesCallCount() int {
+	fake.ensureWebhooksTablesMutex.RLock()
+	defer fake.ensureWebhooksTablesMutex.RUnlock()
+	return len(fake.ensureWebhooksTablesArgsForCall)
+}
+
+func (fake *FakeDB) EnsureWebhooksTablesCalls(stub func(context.Context) error) {
+	fake.ensureWebhooksTablesMutex.Lock()
+	defer fake.ensureWebhooksTablesMutex.Unlock()
+	fake.EnsureWebhooksTablesStub = stub
+}
+
+func (fake *FakeDB) EnsureWebhooksTablesArgsForCall(i int) context.Context {
+	fake.ensureWebhooksTablesMutex.RLock()
+	defer fake.ensureWebhooksTablesMutex.RUnlock()
+	argsForCall := fake.ensureWebhooksTablesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) EnsureWebhooksTablesReturns(result1 error) {
+	fake.ensureWebhooksTablesMutex.Lock()
+	defer fake.ensureWebhooksTablesMutex.Unlock()
+	fake.EnsureWebhooksTablesStub = nil
+	fake.ensureWebhooksTablesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) EnsureWebhooksTablesReturnsOnCall(i int, result1 error) {
+	fake.ensureWebhooksTablesMutex.Lock()
+	defer fake.ensureWebhooksTablesMutex.Unlock()
+	fake.EnsureWebhooksTablesStub = nil
+	if fake.ensureWebhooksTablesReturnsOnCall == nil {
+		fake.ensureWebhooksTablesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.ensureWebhooksTablesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) FinishJobRun(arg1 context.Context, arg2 string, arg3 db.JobRunStatus, arg4 string) error {
+	fake.finishJobRunMutex.Lock()
+	ret, specificReturn := fake.finishJobRunReturnsOnCall[len(fake.finishJobRunArgsForCall)]
+	fake.finishJobRunArgsForCall = append(fake.finishJobRunArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.JobRunStatus
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.FinishJobRunStub
+	fakeReturns := fake.finishJobRunReturns
+	fake.recordInvocation("FinishJobRun", []interface{}{arg1, arg2, arg3, arg4})
+	fake.finishJobRunMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) FinishJobRunCallCount() int {
+	fake.finishJobRunMutex.RLock()
+	defer fake.finishJobRunMutex.RUnlock()
+	return len(fake.finishJobRunArgsForCall)
+}
+
+func (fake *FakeDB) FinishJobRunCalls(stub func(context.Context, string, db.JobRunStatus, string) error) {
+	fake.finishJobRunMutex.Lock()
+	defer fake.finishJobRunMutex.Unlock()
+	fake.FinishJobRunStub = stub
+}
+
+func (fake *FakeDB) FinishJobRunArgsForCall(i int) (context.Context, string, db.JobRunStatus, string) {
+	fake.finishJobRunMutex.RLock()
+	defer fake.finishJobRunMutex.RUnlock()
+	argsForCall := fake.finishJobRunArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) FinishJobRunReturns(result1 error) {
+	fake.finishJobRunMutex.Lock()
+	defer fake.finishJobRunMutex.Unlock()
+	fake.FinishJobRunStub = nil
+	fake.finishJobRunReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) FinishJobRunReturnsOnCall(i int, result1 error) {
+	fake.finishJobRunMutex.Lock()
+	defer fake.finishJobRunMutex.Unlock()
+	fake.FinishJobRunStub = nil
+	if fake.finishJobRunReturnsOnCall == nil {
+		fake.finishJobRunReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.finishJobRunReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) GetAccount(arg1 context.Context, arg2 string, arg3 string) (db.Account, error) {
+	fake.getAccountMutex.Lock()
+	ret, specificReturn := fake.getAccountReturnsOnCall[len(fake.getAccountArgsForCall)]
+	fake.getAccountArgsForCall = append(fake.getAccountArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetAccountStub
+	fakeReturns := fake.getAccountReturns
+	fake.recordInvocation("GetAccount", []interface{}{arg1, arg2, arg3})
+	fake.getAccountMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetAccountCallCount() int {
+	fake.getAccountMutex.RLock()
+	defer fake.getAccountMutex.RUnlock()
+	return len(fake.getAccountArgsForCall)
+}
+
+func (fake *FakeDB) GetAccountCalls(stub func(context.Context, string, string) (db.Account, error)) {
+	fake.getAccountMutex.Lock()
+	defer fake.getAccountMutex.Unlock()
+	fake.GetAccountStub = stub
+}
+
+func (fake *FakeDB) GetAccountArgsForCall(i int) (context.Context, string, string) {
+	fake.getAccountMutex.RLock()
+	defer fake.getAccountMutex.RUnlock()
+	argsForCall := fake.getAccountArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetAccountReturns(result1 db.Account, result2 error) {
+	fake.getAccountMutex.Lock()
+	defer fake.getAccountMutex.Unlock()
+	fake.GetAccountStub = nil
+	fake.getAccountReturns = struct {
+		result1 db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAccountReturnsOnCall(i int, result1 db.Account, result2 error) {
+	fake.getAccountMutex.Lock()
+	defer fake.getAccountMutex.Unlock()
+	fake.GetAccountStub = nil
+	if fake.getAccountReturnsOnCall == nil {
+		fake.getAccountReturnsOnCall = make(map[int]struct {
+			result1 db.Account
+			result2 error
+		})
+	}
+	fake.getAccountReturnsOnCall[i] = struct {
+		result1 db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAccounts(arg1 context.Context, arg2 string) ([]db.Account, error) {
+	fake.getAccountsMutex.Lock()
+	ret, specificReturn := fake.getAccountsReturnsOnCall[len(fake.getAccountsArgsForCall)]
+	fake.getAccountsArgsForCall = append(fake.getAccountsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetAccountsStub
+	fakeReturns := fake.getAccountsReturns
+	fake.recordInvocation("GetAccounts", []interface{}{arg1, arg2})
+	fake.getAccountsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetAccountsCallCount() int {
+	fake.getAccountsMutex.RLock()
+	defer fake.getAccountsMutex.RUnlock()
+	return len(fake.getAccountsArgsForCall)
+}
+
+func (fake *FakeDB) GetAccountsCalls(stub func(context.Context, string) ([]db.Account, error)) {
+	fake.getAccountsMutex.Lock()
+	defer fake.getAccountsMutex.Unlock()
+	fake.GetAccountsStub = stub
+}
+
+func (fake *FakeDB) GetAccountsArgsForCall(i int) (context.Context, string) {
+	fake.getAccountsMutex.RLock()
+	defer fake.getAccountsMutex.RUnlock()
+	argsForCall := fake.getAccountsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetAccountsReturns(result1 []db.Account, result2 error) {
+	fake.getAccountsMutex.Lock()
+	defer fake.getAccountsMutex.Unlock()
+	fake.GetAccountsStub = nil
+	fake.getAccountsReturns = struct {
+		result1 []db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAccountsReturnsOnCall(i int, result1 []db.Account, result2 error) {
+	fake.getAccountsMutex.Lock()
+	defer fake.getAccountsMutex.Unlock()
+	fake.GetAccountsStub = nil
+	if fake.getAccountsReturnsOnCall == nil {
+		fake.getAccountsReturnsOnCall = make(map[int]struct {
+			result1 []db.Account
+			result2 error
+		})
+	}
+	fake.getAccountsReturnsOnCall[i] = struct {
+		result1 []db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAccountsByPlaidItemID(arg1 context.Context, arg2 string) ([]db.Account, error) {
+	fake.getAccountsByPlaidItemIDMutex.Lock()
+	ret, specificReturn := fake.getAccountsByPlaidItemIDReturnsOnCall[len(fake.getAccountsByPlaidItemIDArgsForCall)]
+	fake.getAccountsByPlaidItemIDArgsForCall = append(fake.getAccountsByPlaidItemIDArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetAccountsByPlaidItemIDStub
+	fakeReturns := fake.getAccountsByPlaidItemIDReturns
+	fake.recordInvocation("GetAccountsByPlaidItemID", []interface{}{arg1, arg2})
+	fake.getAccountsByPlaidItemIDMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetAccountsByPlaidItemIDCallCount() int {
+	fake.getAccountsByPlaidItemIDMutex.RLock()
+	defer fake.getAccountsByPlaidItemIDMutex.RUnlock()
+	return len(fake.getAccountsByPlaidItemIDArgsForCall)
+}
+
+func (fake *FakeDB) GetAccountsByPlaidItemIDCalls(stub func(context.Context, string) ([]db.Account, error)) {
+	fake.getAccountsByPlaidItemIDMutex.Lock()
+	defer fake.getAccountsByPlaidItemIDMutex.Unlock()
+	fake.GetAccountsByPlaidItemIDStub = stub
+}
+
+func (fake *FakeDB) GetAccountsByPlaidItemIDArgsForCall(i int) (context.Context, string) {
+	fake.getAccountsByPlaidItemIDMutex.RLock()
+	defer fake.getAccountsByPlaidItemIDMutex.RUnlock()
+	argsForCall := fake.getAccountsByPlaidItemIDArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetAccountsByPlaidItemIDReturns(result1 []db.Account, result2 error) {
+	fake.getAccountsByPlaidItemIDMutex.Lock()
+	defer fake.getAccountsByPlaidItemIDMutex.Unlock()
+	fake.GetAccountsByPlaidItemIDStub = nil
+	fake.getAccountsByPlaidItemIDReturns = struct {
+		result1 []db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAccountsByPlaidItemIDReturnsOnCall(i int, result1 []db.Account, result2 error) {
+	fake.getAccountsByPlaidItemIDMutex.Lock()
+	defer fake.getAccountsByPlaidItemIDMutex.Unlock()
+	fake.GetAccountsByPlaidItemIDStub = nil
+	if fake.getAccountsByPlaidItemIDReturnsOnCall == nil {
+		fake.getAccountsByPlaidItemIDReturnsOnCall = make(map[int]struct {
+			result1 []db.Account
+			result2 error
+		})
+	}
+	fake.getAccountsByPlaidItemIDReturnsOnCall[i] = struct {
+		result1 []db.Account
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAccountsTransactionsPage(arg1 context.Context, arg2 string, arg3 []string, arg4 db.TransactionPage) (map[string][]db.Transaction, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getAccountsTransactionsPageMutex.Lock()
+	ret, specificReturn := fake.getAccountsTransactionsPageReturnsOnCall[len(fake.getAccountsTransactionsPageArgsForCall)]
+	fake.getAccountsTransactionsPageArgsForCall = append(fake.getAccountsTransactionsPageArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+		arg4 db.TransactionPage
+	}{arg1, arg2, arg3Copy, arg4})
+	stub := fake.GetAccountsTransactionsPageStub
+	fakeReturns := fake.getAccountsTransactionsPageReturns
+	fake.recordInvocation("GetAccountsTransactionsPage", []interface{}{arg1, arg2, arg3Copy, arg4})
+	fake.getAccountsTransactionsPageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetAccountsTransactionsPageCallCount() int {
+	fake.getAccountsTransactionsPageMutex.RLock()
+	defer fake.getAccountsTransactionsPageMutex.RUnlock()
+	return len(fake.getAccountsTransactionsPageArgsForCall)
+}
+
+func (fake *FakeDB) GetAccountsTransactionsPageCalls(stub func(context.Context, string, []string, db.TransactionPage) (map[string][]db.Transaction, error)) {
+	fake.getAccountsTransactionsPageMutex.Lock()
+	defer fake.getAccountsTransactionsPageMutex.Unlock()
+	fake.GetAccountsTransactionsPageStub = stub
+}
+
+func (fake *FakeDB) GetAccountsTransactionsPageArgsForCall(i int) (context.Context, string, []string, db.TransactionPage) {
+	fake.getAccountsTransactionsPageMutex.RLock()
+	defer fake.getAccountsTransactionsPageMutex.RUnlock()
+	argsForCall := fake.getAccountsTransactionsPageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) GetAccountsTransactionsPageReturns(result1 map[string][]db.Transaction, result2 error) {
+	fake.getAccountsTransactionsPageMutex.Lock()
+	defer fake.getAccountsTransactionsPageMutex.Unlock()
+	fake.GetAccountsTransactionsPageStub = nil
+	fake.getAccountsTransactionsPageReturns = struct {
+		result1 map[string][]db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAccountsTransactionsPageReturnsOnCall(i int, result1 map[string][]db.Transaction, result2 error) {
+	fake.getAccountsTransactionsPageMutex.Lock()
+	defer fake.getAccountsTransactionsPageMutex.Unlock()
+	fake.GetAccountsTransactionsPageStub = nil
+	if fake.getAccountsTransactionsPageReturnsOnCall == nil {
+		fake.getAccountsTransactionsPageReturnsOnCall = make(map[int]struct {
+			result1 map[string][]db.Transaction
+			result2 error
+		})
+	}
+	fake.getAccountsTransactionsPageReturnsOnCall[i] = struct {
+		result1 map[string][]db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAlertDeliveries(arg1 context.Context, arg2 string, arg3 string) ([]db.AlertDelivery, error) {
+	fake.getAlertDeliveriesMutex.Lock()
+	ret, specificReturn := fake.getAlertDeliveriesReturnsOnCall[len(fake.getAlertDeliveriesArgsForCall)]
+	fake.getAlertDeliveriesArgsForCall = append(fake.getAlertDeliveriesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetAlertDeliveriesStub
+	fakeReturns := fake.getAlertDeliveriesReturns
+	fake.recordInvocation("GetAlertDeliveries", []interface{}{arg1, arg2, arg3})
+	fake.getAlertDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetAlertDeliveriesCallCount() int {
+	fake.getAlertDeliveriesMutex.RLock()
+	defer fake.getAlertDeliveriesMutex.RUnlock()
+	return len(fake.getAlertDeliveriesArgsForCall)
+}
+
+func (fake *FakeDB) GetAlertDeliveriesCalls(stub func(context.Context, string, string) ([]db.AlertDelivery, error)) {
+	fake.getAlertDeliveriesMutex.Lock()
+	defer fake.getAlertDeliveriesMutex.Unlock()
+	fake.GetAlertDeliveriesStub = stub
+}
+
+func (fake *FakeDB) GetAlertDeliveriesArgsForCall(i int) (context.Context, string, string) {
+	fake.getAlertDeliveriesMutex.RLock()
+	defer fake.getAlertDeliveriesMutex.RUnlock()
+	argsForCall := fake.getAlertDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetAlertDeliveriesReturns(result1 []db.AlertDelivery, result2 error) {
+	fake.getAlertDeliveriesMutex.Lock()
+	defer fake.getAlertDeliveriesMutex.Unlock()
+	fake.GetAlertDeliveriesStub = nil
+	fake.getAlertDeliveriesReturns = struct {
+		result1 []db.AlertDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAlertDeliveriesReturnsOnCall(i int, result1 []db.AlertDelivery, result2 error) {
+	fake.getAlertDeliveriesMutex.Lock()
+	defer fake.getAlertDeliveriesMutex.Unlock()
+	fake.GetAlertDeliveriesStub = nil
+	if fake.getAlertDeliveriesReturnsOnCall == nil {
+		fake.getAlertDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []db.AlertDelivery
+			result2 error
+		})
+	}
+	fake.getAlertDeliveriesReturnsOnCall[i] = struct {
+		result1 []db.AlertDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAlerts(arg1 context.Context, arg2 string) ([]db.Alert, error) {
+	fake.getAlertsMutex.Lock()
+	ret, specificReturn := fake.getAlertsReturnsOnCall[len(fake.getAlertsArgsForCall)]
+	fake.getAlertsArgsForCall = append(fake.getAlertsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetAlertsStub
+	fakeReturns := fake.getAlertsReturns
+	fake.recordInvocation("GetAlerts", []interface{}{arg1, arg2})
+	fake.getAlertsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetAlertsCallCount() int {
+	fake.getAlertsMutex.RLock()
+	defer fake.getAlertsMutex.RUnlock()
+	return len(fake.getAlertsArgsForCall)
+}
+
+func (fake *FakeDB) GetAlertsCalls(stub func(context.Context, string) ([]db.Alert, error)) {
+	fake.getAlertsMutex.Lock()
+	defer fake.getAlertsMutex.Unlock()
+	fake.GetAlertsStub = stub
+}
+
+func (fake *FakeDB) GetAlertsArgsForCall(i int) (context.Context, string) {
+	fake.getAlertsMutex.RLock()
+	defer fake.getAlertsMutex.RUnlock()
+	argsForCall := fake.getAlertsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetAlertsReturns(result1 []db.Alert, result2 error) {
+	fake.getAlertsMutex.Lock()
+	defer fake.getAlertsMutex.Unlock()
+	fake.GetAlertsStub = nil
+	fake.getAlertsReturns = struct {
+		result1 []db.Alert
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAlertsReturnsOnCall(i int, result1 []db.Alert, result2 error) {
+	fake.getAlertsMutex.Lock()
+	defer fake.getAlertsMutex.Unlock()
+	fake.GetAlertsStub = nil
+	if fake.getAlertsReturnsOnCall == nil {
+		fake.getAlertsReturnsOnCall = make(map[int]struct {
+			result1 []db.Alert
+			result2 error
+		})
+	}
+	fake.getAlertsReturnsOnCall[i] = struct {
+		result1 []db.Alert
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAttachment(arg1 context.Context, arg2 string, arg3 string) (db.Attachment, error) {
+	fake.getAttachmentMutex.Lock()
+	ret, specificReturn := fake.getAttachmentReturnsOnCall[len(fake.getAttachmentArgsForCall)]
+	fake.getAttachmentArgsForCall = append(fake.getAttachmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetAttachmentStub
+	fakeReturns := fake.getAttachmentReturns
+	fake.recordInvocation("GetAttachment", []interface{}{arg1, arg2, arg3})
+	fake.getAttachmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetAttachmentCallCount() int {
+	fake.getAttachmentMutex.RLock()
+	defer fake.getAttachmentMutex.RUnlock()
+	return len(fake.getAttachmentArgsForCall)
+}
+
+func (fake *FakeDB) GetAttachmentCalls(stub func(context.Context, string, string) (db.Attachment, error)) {
+	fake.getAttachmentMutex.Lock()
+	defer fake.getAttachmentMutex.Unlock()
+	fake.GetAttachmentStub = stub
+}
+
+func (fake *FakeDB) GetAttachmentArgsForCall(i int) (context.Context, string, string) {
+	fake.getAttachmentMutex.RLock()
+	defer fake.getAttachmentMutex.RUnlock()
+	argsForCall := fake.getAttachmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetAttachmentReturns(result1 db.Attachment, result2 error) {
+	fake.getAttachmentMutex.Lock()
+	defer fake.getAttachmentMutex.Unlock()
+	fake.GetAttachmentStub = nil
+	fake.getAttachmentReturns = struct {
+		result1 db.Attachment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAttachmentReturnsOnCall(i int, result1 db.Attachment, result2 error) {
+	fake.getAttachmentMutex.Lock()
+	defer fake.getAttachmentMutex.Unlock()
+	fake.GetAttachmentStub = nil
+	if fake.getAttachmentReturnsOnCall == nil {
+		fake.getAttachmentReturnsOnCall = make(map[int]struct {
+			result1 db.Attachment
+			result2 error
+		})
+	}
+	fake.getAttachmentReturnsOnCall[i] = struct {
+		result1 db.Attachment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAttachments(arg1 context.Context, arg2 string, arg3 string) ([]db.Attachment, error) {
+	fake.getAttachmentsMutex.Lock()
+	ret, specificReturn := fake.getAttachmentsReturnsOnCall[len(fake.getAttachmentsArgsForCall)]
+	fake.getAttachmentsArgsForCall = append(fake.getAttachmentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetAttachmentsStub
+	fakeReturns := fake.getAttachmentsReturns
+	fake.recordInvocation("GetAttachments", []interface{}{arg1, arg2, arg3})
+	fake.getAttachmentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetAttachmentsCallCount() int {
+	fake.getAttachmentsMutex.RLock()
+	defer fake.getAttachmentsMutex.RUnlock()
+	return len(fake.getAttachmentsArgsForCall)
+}
+
+func (fake *FakeDB) GetAttachmentsCalls(stub func(context.Context, string, string) ([]db.Attachment, error)) {
+	fake.getAttachmentsMutex.Lock()
+	defer fake.getAttachmentsMutex.Unlock()
+	fake.GetAttachmentsStub = stub
+}
+
+func (fake *FakeDB) GetAttachmentsArgsForCall(i int) (context.Context, string, string) {
+	fake.getAttachmentsMutex.RLock()
+	defer fake.getAttachmentsMutex.RUnlock()
+	argsForCall := fake.getAttachmentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetAttachmentsReturns(result1 []db.Attachment, result2 error) {
+	fake.getAttachmentsMutex.Lock()
+	defer fake.getAttachmentsMutex.Unlock()
+	fake.GetAttachmentsStub = nil
+	fake.getAttachmentsReturns = struct {
+		result1 []db.Attachment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAttachmentsReturnsOnCall(i int, result1 []db.Attachment, result2 error) {
+	fake.getAttachmentsMutex.Lock()
+	defer fake.getAttachmentsMutex.Unlock()
+	fake.GetAttachmentsStub = nil
+	if fake.getAttachmentsReturnsOnCall == nil {
+		fake.getAttachmentsReturnsOnCall = make(map[int]struct {
+			result1 []db.Attachment
+			result2 error
+		})
+	}
+	fake.getAttachmentsReturnsOnCall[i] = struct {
+		result1 []db.Attachment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAuditEvents(arg1 context.Context, arg2 db.AuditEventFilter) ([]db.AuditEvent, error) {
+	fake.getAuditEventsMutex.Lock()
+	ret, specificReturn := fake.getAuditEventsReturnsOnCall[len(fake.getAuditEventsArgsForCall)]
+	fake.getAuditEventsArgsForCall = append(fake.getAuditEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.AuditEventFilter
+	}{arg1, arg2})
+	stub := fake.GetAuditEventsStub
+	fakeReturns := fake.getAuditEventsReturns
+	fake.recordInvocation("GetAuditEvents", []interface{}{arg1, arg2})
+	fake.getAuditEventsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetAuditEventsCallCount() int {
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	return len(fake.getAuditEventsArgsForCall)
+}
+
+func (fake *FakeDB) GetAuditEventsCalls(stub func(context.Context, db.AuditEventFilter) ([]db.AuditEvent, error)) {
+	fake.getAuditEventsMutex.Lock()
+	defer fake.getAuditEventsMutex.Unlock()
+	fake.GetAuditEventsStub = stub
+}
+
+func (fake *FakeDB) GetAuditEventsArgsForCall(i int) (context.Context, db.AuditEventFilter) {
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	argsForCall := fake.getAuditEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetAuditEventsReturns(result1 []db.AuditEvent, result2 error) {
+	fake.getAuditEventsMutex.Lock()
+	defer fake.getAuditEventsMutex.Unlock()
+	fake.GetAuditEventsStub = nil
+	fake.getAuditEventsReturns = struct {
+		result1 []db.AuditEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetAuditEventsReturnsOnCall(i int, result1 []db.AuditEvent, result2 error) {
+	fake.getAuditEventsMutex.Lock()
+	defer fake.getAuditEventsMutex.Unlock()
+	fake.GetAuditEventsStub = nil
+	if fake.getAuditEventsReturnsOnCall == nil {
+		fake.getAuditEventsReturnsOnCall = make(map[int]struct {
+			result1 []db.AuditEvent
+			result2 error
+		})
+	}
+	fake.getAuditEventsReturnsOnCall[i] = struct {
+		result1 []db.AuditEvent
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetBackfillingItems(arg1 context.Context) ([]db.Item, error) {
+	fake.getBackfillingItemsMutex.Lock()
+	ret, specificReturn := fake.getBackfillingItemsReturnsOnCall[len(fake.getBackfillingItemsArgsForCall)]
+	fake.getBackfillingItemsArgsForCall = append(fake.getBackfillingItemsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.GetBackfillingItemsStub
+	fakeReturns := fake.getBackfillingItemsReturns
+	fake.recordInvocation("GetBackfillingItems", []interface{}{arg1})
+	fake.getBackfillingItemsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetBackfillingItemsCallCount() int {
+	fake.getBackfillingItemsMutex.RLock()
+	defer fake.getBackfillingItemsMutex.RUnlock()
+	return len(fake.getBackfillingItemsArgsForCall)
+}
+
+func (fake *FakeDB) GetBackfillingItemsCalls(stub func(context.Context) ([]db.Item, error)) {
+	fake.getBackfillingItemsMutex.Lock()
+	defer fake.getBackfillingItemsMutex.Unlock()
+	fake.GetBackfillingItemsStub = stub
+}
+
+func (fake *FakeDB) GetBackfillingItemsArgsForCall(i int) context.Context {
+	fake.getBackfillingItemsMutex.RLock()
+	defer fake.getBackfillingItemsMutex.RUnlock()
+	argsForCall := fake.getBackfillingItemsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDB) GetBackfillingItemsReturns(result1 []db.Item, result2 error) {
+	fake.getBackfillingItemsMutex.Lock()
+	defer fake.getBackfillingItemsMutex.Unlock()
+	fake.GetBackfillingItemsStub = nil
+	fake.getBackfillingItemsReturns = struct {
+		result1 []db.Item
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetBackfillingItemsReturnsOnCall(i int, result1 []db.Item, result2 error) {
+	fake.getBackfillingItemsMutex.Lock()
+	defer fake.getBackfillingItemsMutex.Unlock()
+	fake.GetBackfillingItemsStub = nil
+	if fake.getBackfillingItemsReturnsOnCall == nil {
+		fake.getBackfillingItemsReturnsOnCall = make(map[int]struct {
+			result1 []db.Item
+			result2 error
+		})
+	}
+	fake.getBackfillingItemsReturnsOnCall[i] = struct {
+		result1 []db.Item
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetBalances(arg1 context.Context, arg2 string, arg3 string) ([]db.Balance, error) {
+	fake.getBalancesMutex.Lock()
+	ret, specificReturn := fake.getBalancesReturnsOnCall[len(fake.getBalancesArgsForCall)]
+	fake.getBalancesArgsForCall = append(fake.getBalancesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetBalancesStub
+	fakeReturns := fake.getBalancesReturns
+	fake.recordInvocation("GetBalances", []interface{}{arg1, arg2, arg3})
+	fake.getBalancesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetBalancesCallCount() int {
+	fake.getBalancesMutex.RLock()
+	defer fake.getBalancesMutex.RUnlock()
+	return len(fake.getBalancesArgsForCall)
+}
+
+func (fake *FakeDB) GetBalancesCalls(stub func(context.Context, string, string) ([]db.Balance, error)) {
+	fake.getBalancesMutex.Lock()
+	defer fake.getBalancesMutex.Unlock()
+	fake.GetBalancesStub = stub
+}
+
+func (fake *FakeDB) GetBalancesArgsForCall(i int) (context.Context, string, string) {
+	fake.getBalancesMutex.RLock()
+	defer fake.getBalancesMutex.RUnlock()
+	argsForCall := fake.getBalancesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetBalancesReturns(result1 []db.Balance, result2 error) {
+	fake.getBalancesMutex.Lock()
+	defer fake.getBalancesMutex.Unlock()
+	fake.GetBalancesStub = nil
+	fake.getBalancesReturns = struct {
+		result1 []db.Balance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetBalancesReturnsOnCall(i int, result1 []db.Balance, result2 error) {
+	fake.getBalancesMutex.Lock()
+	defer fake.getBalancesMutex.Unlock()
+	fake.GetBalancesStub = nil
+	if fake.getBalancesReturnsOnCall == nil {
+		fake.getBalancesReturnsOnCall = make(map[int]struct {
+			result1 []db.Balance
+			result2 error
+		})
+	}
+	fake.getBalancesReturnsOnCall[i] = struct {
+		result1 []db.Balance
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetCashflowReport(arg1 context.Context, arg2 string, arg3 db.ReportGroup, arg4 string, arg5 string) ([]db.ReportRow, error) {
+	fake.getCashflowReportMutex.Lock()
+	ret, specificReturn := fake.getCashflowReportReturnsOnCall[len(fake.getCashflowReportArgsForCall)]
+	fake.getCashflowReportArgsForCall = append(fake.getCashflowReportArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.ReportGroup
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.GetCashflowReportStub
+	fakeReturns := fake.getCashflowReportReturns
+	fake.recordInvocation("GetCashflowReport", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getCashflowReportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetCashflowReportCallCount() int {
+	fake.getCashflowReportMutex.RLock()
+	defer fake.getCashflowReportMutex.RUnlock()
+	return len(fake.getCashflowReportArgsForCall)
+}
+
+func (fake *FakeDB) GetCashflowReportCalls(stub func(context.Context, string, db.ReportGroup, string, string) ([]db.ReportRow, error)) {
+	fake.getCashflowReportMutex.Lock()
+	defer fake.getCashflowReportMutex.Unlock()
+	fake.GetCashflowReportStub = stub
+}
+
+func (fake *FakeDB) GetCashflowReportArgsForCall(i int) (context.Context, string, db.ReportGroup, string, string) {
+	fake.getCashflowReportMutex.RLock()
+	defer fake.getCashflowReportMutex.RUnlock()
+	argsForCall := fake.getCashflowReportArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeDB) GetCashflowReportReturns(result1 []db.ReportRow, result2 error) {
+	fake.getCashflowReportMutex.Lock()
+	defer fake.getCashflowReportMutex.Unlock()
+	fake.GetCashflowReportStub = nil
+	fake.getCashflowReportReturns = struct {
+		result1 []db.ReportRow
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetCashflowReportReturnsOnCall(i int, result1 []db.ReportRow, result2 error) {
+	fake.getCashflowReportMutex.Lock()
+	defer fake.getCashflowReportMutex.Unlock()
+	fake.GetCashflowReportStub = nil
+	if fake.getCashflowReportReturnsOnCall == nil {
+		fake.getCashflowReportReturnsOnCall = make(map[int]struct {
+			result1 []db.ReportRow
+			result2 error
+		})
+	}
+	fake.getCashflowReportReturnsOnCall[i] = struct {
+		result1 []db.ReportRow
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetDigestPreferences(arg1 context.Context, arg2 string) (db.DigestPreferences, error) {
+	fake.getDigestPreferencesMutex.Lock()
+	ret, specificReturn := fake.getDigestPreferencesReturnsOnCall[len(fake.getDigestPreferencesArgsForCall)]
+	fake.getDigestPreferencesArgsForCall = append(fake.getDigestPreferencesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetDigestPreferencesStub
+	fakeReturns := fake.getDigestPreferencesReturns
+	fake.recordInvocation("GetDigestPreferences", []interface{}{arg1, arg2})
+	fake.getDigestPreferencesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetDigestPreferencesCallCount() int {
+	fake.getDigestPreferencesMutex.RLock()
+	defer fake.getDigestPreferencesMutex.RUnlock()
+	return len(fake.getDigestPreferencesArgsForCall)
+}
+
+func (fake *FakeDB) GetDigestPreferencesCalls(stub func(context.Context, string) (db.DigestPreferences, error)) {
+	fake.getDigestPreferencesMutex.Lock()
+	defer fake.getDigestPreferencesMutex.Unlock()
+	fake.GetDigestPreferencesStub = stub
+}
+
+func (fake *FakeDB) GetDigestPreferencesArgsForCall(i int) (context.Context, string) {
+	fake.getDigestPreferencesMutex.RLock()
+	defer fake.getDigestPreferencesMutex.RUnlock()
+	argsForCall := fake.getDigestPreferencesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetDigestPreferencesReturns(result1 db.DigestPreferences, result2 error) {
+	fake.getDigestPreferencesMutex.Lock()
+	defer fake.getDigestPreferencesMutex.Unlock()
+	fake.GetDigestPreferencesStub = nil
+	fake.getDigestPreferencesReturns = struct {
+		result1 db.DigestPreferences
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetDigestPreferencesReturnsOnCall(i int, result1 db.DigestPreferences, result2 error) {
+	fake.getDigestPreferencesMutex.Lock()
+	defer fake.getDigestPreferencesMutex.Unlock()
+	fake.GetDigestPreferencesStub = nil
+	if fake.getDigestPreferencesReturnsOnCall == nil {
+		fake.getDigestPreferencesReturnsOnCall = make(map[int]struct {
+			result1 db.DigestPreferences
+			result2 error
+		})
+	}
+	fake.getDigestPreferencesReturnsOnCall[i] = struct {
+		result1 db.DigestPreferences
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetItem(arg1 context.Context, arg2 string, arg3 string) (db.Item, error) {
+	fake.getItemMutex.Lock()
+	ret, specificReturn := fake.getItemReturnsOnCall[len(fake.getItemArgsForCall)]
+	fake.getItemArgsForCall = append(fake.getItemArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetItemStub
+	fakeReturns := fake.getItemReturns
+	fake.recordInvocation("GetItem", []interface{}{arg1, arg2, arg3})
+	fake.getItemMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetItemCallCount() int {
+	fake.getItemMutex.RLock()
+	defer fake.getItemMutex.RUnlock()
+	return len(fake.getItemArgsForCall)
+}
+
+func (fake *FakeDB) GetItemCalls(stub func(context.Context, string, string) (db.Item, error)) {
+	fake.getItemMutex.Lock()
+	defer fake.getItemMutex.Unlock()
+	fake.GetItemStub = stub
+}
+
+func (fake *FakeDB) GetItemArgsForCall(i int) (context.Context, string, string) {
+	fake.getItemMutex.RLock()
+	defer fake.getItemMutex.RUnlock()
+	argsForCall := fake.getItemArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetItemReturns(result1 db.Item, result2 error) {
+	fake.getItemMutex.Lock()
+	defer fake.getItemMutex.Unlock()
+	fake.GetItemStub = nil
+	fake.getItemReturns = struct {
+		result1 db.Item
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetItemReturnsOnCall(i int, result1 db.Item, result2 error) {
+	fake.getItemMutex.Lock()
+	defer fake.getItemMutex.Unlock()
+	fake.GetItemStub = nil
+	if fake.getItemReturnsOnCall == nil {
+		fake.getItemReturnsOnCall = make(map[int]struct {
+			result1 db.Item
+			result2 error
+		})
+	}
+	fake.getItemReturnsOnCall[i] = struct {
+		result1 db.Item
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetItems(arg1 context.Context, arg2 string) ([]db.Item, error) {
+	fake.getItemsMutex.Lock()
+	ret, specificReturn := fake.getItemsReturnsOnCall[len(fake.getItemsArgsForCall)]
+	fake.getItemsArgsForCall = append(fake.getItemsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetItemsStub
+	fakeReturns := fake.getItemsReturns
+	fake.recordInvocation("GetItems", []interface{}{arg1, arg2})
+	fake.getItemsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetItemsCallCount() int {
+	fake.getItemsMutex.RLock()
+	defer fake.getItemsMutex.RUnlock()
+	return len(fake.getItemsArgsForCall)
+}
+
+func (fake *FakeDB) GetItemsCalls(stub func(context.Context, string) ([]db.Item, error)) {
+	fake.getItemsMutex.Lock()
+	defer fake.getItemsMutex.Unlock()
+	fake.GetItemsStub = stub
+}
+
+func (fake *FakeDB) GetItemsArgsForCall(i int) (context.Context, string) {
+	fake.getItemsMutex.RLock()
+	defer fake.getItemsMutex.RUnlock()
+	argsForCall := fake.getItemsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetItemsReturns(result1 []db.Item, result2 error) {
+	fake.getItemsMutex.Lock()
+	defer fake.getItemsMutex.Unlock()
+	fake.GetItemsStub = nil
+	fake.getItemsReturns = struct {
+		result1 []db.Item
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetItemsReturnsOnCall(i int, result1 []db.Item, result2 error) {
+	fake.getItemsMutex.Lock()
+	defer fake.getItemsMutex.Unlock()
+	fake.GetItemsStub = nil
+	if fake.getItemsReturnsOnCall == nil {
+		fake.getItemsReturnsOnCall = make(map[int]struct {
+			result1 []db.Item
+			result2 error
+		})
+	}
+	fake.getItemsReturnsOnCall[i] = struct {
+		result1 []db.Item
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetItemsSyncedBefore(arg1 context.Context, arg2 time.Time) ([]db.Item, error) {
+	fake.getItemsSyncedBeforeMutex.Lock()
+	ret, specificReturn := fake.getItemsSyncedBeforeReturnsOnCall[len(fake.getItemsSyncedBeforeArgsForCall)]
+	fake.getItemsSyncedBeforeArgsForCall = append(fake.getItemsSyncedBeforeArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.GetItemsSyncedBeforeStub
+	fakeReturns := fake.getItemsSyncedBeforeReturns
+	fake.recordInvocation("GetItemsSyncedBefore", []interface{}{arg1, arg2})
+	fake.getItemsSyncedBeforeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetItemsSyncedBeforeCallCount() int {
+	fake.getItemsSyncedBeforeMutex.RLock()
+	defer fake.getItemsSyncedBeforeMutex.RUnlock()
+	return len(fake.getItemsSyncedBeforeArgsForCall)
+}
+
+func (fake *FakeDB) GetItemsSyncedBeforeCalls(stub func(context.Context, time.Time) ([]db.Item, error)) {
+	fake.getItemsSyncedBeforeMutex.Lock()
+	defer fake.getItemsSyncedBeforeMutex.Unlock()
+	fake.GetItemsSyncedBeforeStub = stub
+}
+
+func (fake *FakeDB) GetItemsSyncedBeforeArgsForCall(i int) (context.Context, time.Time) {
+	fake.getItemsSyncedBeforeMutex.RLock()
+	defer fake.getItemsSyncedBeforeMutex.RUnlock()
+	argsForCall := fake.getItemsSyncedBeforeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetItemsSyncedBeforeReturns(result1 []db.Item, result2 error) {
+	fake.getItemsSyncedBeforeMutex.Lock()
+	defer fake.getItemsSyncedBeforeMutex.Unlock()
+	fake.GetItemsSyncedBeforeStub = nil
+	fake.getItemsSyncedBeforeReturns = struct {
+		result1 []db.Item
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetItemsSyncedBeforeReturnsOnCall(i int, result1 []db.Item, result2 error) {
+	fake.getItemsSyncedBeforeMutex.Lock()
+	defer fake.getItemsSyncedBeforeMutex.Unlock()
+	fake.GetItemsSyncedBeforeStub = nil
+	if fake.getItemsSyncedBeforeReturnsOnCall == nil {
+		fake.getItemsSyncedBeforeReturnsOnCall = make(map[int]struct {
+			result1 []db.Item
+			result2 error
+		})
+	}
+	fake.getItemsSyncedBeforeReturnsOnCall[i] = struct {
+		result1 []db.Item
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetJobRun(arg1 context.Context, arg2 string, arg3 string) (db.JobRun, error) {
+	fake.getJobRunMutex.Lock()
+	ret, specificReturn := fake.getJobRunReturnsOnCall[len(fake.getJobRunArgsForCall)]
+	fake.getJobRunArgsForCall = append(fake.getJobRunArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetJobRunStub
+	fakeReturns := fake.getJobRunReturns
+	fake.recordInvocation("GetJobRun", []interface{}{arg1, arg2, arg3})
+	fake.getJobRunMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetJobRunCallCount() int {
+	fake.getJobRunMutex.RLock()
+	defer fake.getJobRunMutex.RUnlock()
+	return len(fake.getJobRunArgsForCall)
+}
+
+func (fake *FakeDB) GetJobRunCalls(stub func(context.Context, string, string) (db.JobRun, error)) {
+	fake.getJobRunMutex.Lock()
+	defer fake.getJobRunMutex.Unlock()
+	fake.GetJobRunStub = stub
+}
+
+func (fake *FakeDB) GetJobRunArgsForCall(i int) (context.Context, string, string) {
+	fake.getJobRunMutex.RLock()
+	defer fake.getJobRunMutex.RUnlock()
+	argsForCall := fake.getJobRunArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetJobRunReturns(result1 db.JobRun, result2 error) {
+	fake.getJobRunMutex.Lock()
+	defer fake.getJobRunMutex.Unlock()
+	fake.GetJobRunStub = nil
+	fake.getJobRunReturns = struct {
+		result1 db.JobRun
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetJobRunReturnsOnCall(i int, result1 db.JobRun, result2 error) {
+	fake.getJobRunMutex.Lock()
+	defer fake.getJobRunMutex.Unlock()
+	fake.GetJobRunStub = nil
+	if fake.getJobRunReturnsOnCall == nil {
+		fake.getJobRunReturnsOnCall = make(map[int]struct {
+			result1 db.JobRun
+			result2 error
+		})
+	}
+	fake.getJobRunReturnsOnCall[i] = struct {
+		result1 db.JobRun
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetJobRuns(arg1 context.Context, arg2 string, arg3 int) ([]db.JobRun, error) {
+	fake.getJobRunsMutex.Lock()
+	ret, specificReturn := fake.getJobRunsReturnsOnCall[len(fake.getJobRunsArgsForCall)]
+	fake.getJobRunsArgsForCall = append(fake.getJobRunsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetJobRunsStub
+	fakeReturns := fake.getJobRunsReturns
+	fake.recordInvocation("GetJobRuns", []interface{}{arg1, arg2, arg3})
+	fake.getJobRunsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetJobRunsCallCount() int {
+	fake.getJobRunsMutex.RLock()
+	defer fake.getJobRunsMutex.RUnlock()
+	return len(fake.getJobRunsArgsForCall)
+}
+
+func (fake *FakeDB) GetJobRunsCalls(stub func(context.Context, string, int) ([]db.JobRun, error)) {
+	fake.getJobRunsMutex.Lock()
+	defer fake.getJobRunsMutex.Unlock()
+	fake.GetJobRunsStub = stub
+}
+
+func (fake *FakeDB) GetJobRunsArgsForCall(i int) (context.Context, string, int) {
+	fake.getJobRunsMutex.RLock()
+	defer fake.getJobRunsMutex.RUnlock()
+	argsForCall := fake.getJobRunsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetJobRunsReturns(result1 []db.JobRun, result2 error) {
+	fake.getJobRunsMutex.Lock()
+	defer fake.getJobRunsMutex.Unlock()
+	fake.GetJobRunsStub = nil
+	fake.getJobRunsReturns = struct {
+		result1 []db.JobRun
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetJobRunsReturnsOnCall(i int, result1 []db.JobRun, result2 error) {
+	fake.getJobRunsMutex.Lock()
+	defer fake.getJobRunsMutex.Unlock()
+	fake.GetJobRunsStub = nil
+	if fake.getJobRunsReturnsOnCall == nil {
+		fake.getJobRunsReturnsOnCall = make(map[int]struct {
+			result1 []db.JobRun
+			result2 error
+		})
+	}
+	fake.getJobRunsReturnsOnCall[i] = struct {
+		result1 []db.JobRun
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetPersonalAccessTokens(arg1 context.Context, arg2 string) ([]db.PersonalAccessToken, error) {
+	fake.getPersonalAccessTokensMutex.Lock()
+	ret, specificReturn := fake.getPersonalAccessTokensReturnsOnCall[len(fake.getPersonalAccessTokensArgsForCall)]
+	fake.getPersonalAccessTokensArgsForCall = append(fake.getPersonalAccessTokensArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetPersonalAccessTokensStub
+	fakeReturns := fake.getPersonalAccessTokensReturns
+	fake.recordInvocation("GetPersonalAccessTokens", []interface{}{arg1, arg2})
+	fake.getPersonalAccessTokensMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetPersonalAccessTokensCallCount() int {
+	fake.getPersonalAccessTokensMutex.RLock()
+	defer fake.getPersonalAccessTokensMutex.RUnlock()
+	return len(fake.getPersonalAccessTokensArgsForCall)
+}
+
+func (fake *FakeDB) GetPersonalAccessTokensCalls(stub func(context.Context, string) ([]db.PersonalAccessToken, error)) {
+	fake.getPersonalAccessTokensMutex.Lock()
+	defer fake.getPersonalAccessTokensMutex.Unlock()
+	fake.GetPersonalAccessTokensStub = stub
+}
+
+func (fake *FakeDB) GetPersonalAccessTokensArgsForCall(i int) (context.Context, string) {
+	fake.getPersonalAccessTokensMutex.RLock()
+	defer fake.getPersonalAccessTokensMutex.RUnlock()
+	argsForCall := fake.getPersonalAccessTokensArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetPersonalAccessTokensReturns(result1 []db.PersonalAccessToken, result2 error) {
+	fake.getPersonalAccessTokensMutex.Lock()
+	defer fake.getPersonalAccessTokensMutex.Unlock()
+	fake.GetPersonalAccessTokensStub = nil
+	fake.getPersonalAccessTokensReturns = struct {
+		result1 []db.PersonalAccessToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetPersonalAccessTokensReturnsOnCall(i int, result1 []db.PersonalAccessToken, result2 error) {
+	fake.getPersonalAccessTokensMutex.Lock()
+	defer fake.getPersonalAccessTokensMutex.Unlock()
+	fake.GetPersonalAccessTokensStub = nil
+	if fake.getPersonalAccessTokensReturnsOnCall == nil {
+		fake.getPersonalAccessTokensReturnsOnCall = make(map[int]struct {
+			result1 []db.PersonalAccessToken
+			result2 error
+		})
+	}
+	fake.getPersonalAccessTokensReturnsOnCall[i] = struct {
+		result1 []db.PersonalAccessToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetShare(arg1 context.Context, arg2 string, arg3 string) (db.Share, error) {
+	fake.getShareMutex.Lock()
+	ret, specificReturn := fake.getShareReturnsOnCall[len(fake.getShareArgsForCall)]
+	fake.getShareArgsForCall = append(fake.getShareArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetShareStub
+	fakeReturns := fake.getShareReturns
+	fake.recordInvocation("GetShare", []interface{}{arg1, arg2, arg3})
+	fake.getShareMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetShareCallCount() int {
+	fake.getShareMutex.RLock()
+	defer fake.getShareMutex.RUnlock()
+	return len(fake.getShareArgsForCall)
+}
+
+func (fake *FakeDB) GetShareCalls(stub func(context.Context, string, string) (db.Share, error)) {
+	fake.getShareMutex.Lock()
+	defer fake.getShareMutex.Unlock()
+	fake.GetShareStub = stub
+}
+
+func (fake *FakeDB) GetShareArgsForCall(i int) (context.Context, string, string) {
+	fake.getShareMutex.RLock()
+	defer fake.getShareMutex.RUnlock()
+	argsForCall := fake.getShareArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetShareReturns(result1 db.Share, result2 error) {
+	fake.getShareMutex.Lock()
+	defer fake.getShareMutex.Unlock()
+	fake.GetShareStub = nil
+	fake.getShareReturns = struct {
+		result1 db.Share
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetShareReturnsOnCall(i int, result1 db.Share, result2 error) {
+	fake.getShareMutex.Lock()
+	defer fake.getShareMutex.Unlock()
+	fake.GetShareStub = nil
+	if fake.getShareReturnsOnCall == nil {
+		fake.getShareReturnsOnCall = make(map[int]struct {
+			result1 db.Share
+			result2 error
+		})
+	}
+	fake.getShareReturnsOnCall[i] = struct {
+		result1 db.Share
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetShares(arg1 context.Context, arg2 string) ([]db.Share, error) {
+	fake.getSharesMutex.Lock()
+	ret, specificReturn := fake.getSharesReturnsOnCall[len(fake.getSharesArgsForCall)]
+	fake.getSharesArgsForCall = append(fake.getSharesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetSharesStub
+	fakeReturns := fake.getSharesReturns
+	fake.recordInvocation("GetShares", []interface{}{arg1, arg2})
+	fake.getSharesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetSharesCallCount() int {
+	fake.getSharesMutex.RLock()
+	defer fake.getSharesMutex.RUnlock()
+	return len(fake.getSharesArgsForCall)
+}
+
+func (fake *FakeDB) GetSharesCalls(stub func(context.Context, string) ([]db.Share, error)) {
+	fake.getSharesMutex.Lock()
+	defer fake.getSharesMutex.Unlock()
+	fake.GetSharesStub = stub
+}
+
+func (fake *FakeDB) GetSharesArgsForCall(i int) (context.Context, string) {
+	fake.getSharesMutex.RLock()
+	defer fake.getSharesMutex.RUnlock()
+	argsForCall := fake.getSharesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetSharesReturns(result1 []db.Share, result2 error) {
+	fake.getSharesMutex.Lock()
+	defer fake.getSharesMutex.Unlock()
+	fake.GetSharesStub = nil
+	fake.getSharesReturns = struct {
+		result1 []db.Share
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetSharesReturnsOnCall(i int, result1 []db.Share, result2 error) {
+	fake.getSharesMutex.Lock()
+	defer fake.getSharesMutex.Unlock()
+	fake.GetSharesStub = nil
+	if fake.getSharesReturnsOnCall == nil {
+		fake.getSharesReturnsOnCall = make(map[int]struct {
+			result1 []db.Share
+			result2 error
+		})
+	}
+	fake.getSharesReturnsOnCall[i] = struct {
+		result1 []db.Share
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetSpendingReport(arg1 context.Context, arg2 string, arg3 db.ReportGroup, arg4 string, arg5 string) ([]db.ReportRow, error) {
+	fake.getSpendingReportMutex.Lock()
+	ret, specificReturn := fake.getSpendingReportReturnsOnCall[len(fake.getSpendingReportArgsForCall)]
+	fake.getSpendingReportArgsForCall = append(fake.getSpendingReportArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.ReportGroup
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.GetSpendingReportStub
+	fakeReturns := fake.getSpendingReportReturns
+	fake.recordInvocation("GetSpendingReport", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getSpendingReportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetSpendingReportCallCount() int {
+	fake.getSpendingReportMutex.RLock()
+	defer fake.getSpendingReportMutex.RUnlock()
+	return len(fake.getSpendingReportArgsForCall)
+}
+
+func (fake *FakeDB) GetSpendingReportCalls(stub func(context.Context, string, db.ReportGroup, string, string) ([]db.ReportRow, error)) {
+	fake.getSpendingReportMutex.Lock()
+	defer fake.getSpendingReportMutex.Unlock()
+	fake.GetSpendingReportStub = stub
+}
+
+func (fake *FakeDB) GetSpendingReportArgsForCall(i int) (context.Context, string, db.ReportGroup, string, string) {
+	fake.getSpendingReportMutex.RLock()
+	defer fake.getSpendingReportMutex.RUnlock()
+	argsForCall := fake.getSpendingReportArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeDB) GetSpendingReportReturns(result1 []db.ReportRow, result2 error) {
+	fake.getSpendingReportMutex.Lock()
+	defer fake.getSpendingReportMutex.Unlock()
+	fake.GetSpendingReportStub = nil
+	fake.getSpendingReportReturns = struct {
+		result1 []db.ReportRow
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetSpendingReportReturnsOnCall(i int, result1 []db.ReportRow, result2 error) {
+	fake.getSpendingReportMutex.Lock()
+	defer fake.getSpendingReportMutex.Unlock()
+	fake.GetSpendingReportStub = nil
+	if fake.getSpendingReportReturnsOnCall == nil {
+		fake.getSpendingReportReturnsOnCall = make(map[int]struct {
+			result1 []db.ReportRow
+			result2 error
+		})
+	}
+	fake.getSpendingReportReturnsOnCall[i] = struct {
+		result1 []db.ReportRow
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetSplitsForTransactions(arg1 context.Context, arg2 string, arg3 []string) (map[string][]db.Split, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getSplitsForTransactionsMutex.Lock()
+	ret, specificReturn := fake.getSplitsForTransactionsReturnsOnCall[len(fake.getSplitsForTransactionsArgsForCall)]
+	fake.getSplitsForTransactionsArgsForCall = append(fake.getSplitsForTransactionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.GetSplitsForTransactionsStub
+	fakeReturns := fake.getSplitsForTransactionsReturns
+	fake.recordInvocation("GetSplitsForTransactions", []interface{}{arg1, arg2, arg3Copy})
+	fake.getSplitsForTransactionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetSplitsForTransactionsCallCount() int {
+	fake.getSplitsForTransactionsMutex.RLock()
+	defer fake.getSplitsForTransactionsMutex.RUnlock()
+	return len(fake.getSplitsForTransactionsArgsForCall)
+}
+
+func (fake *FakeDB) GetSplitsForTransactionsCalls(stub func(context.Context, string, []string) (map[string][]db.Split, error)) {
+	fake.getSplitsForTransactionsMutex.Lock()
+	defer fake.getSplitsForTransactionsMutex.Unlock()
+	fake.GetSplitsForTransactionsStub = stub
+}
+
+func (fake *FakeDB) GetSplitsForTransactionsArgsForCall(i int) (context.Context, string, []string) {
+	fake.getSplitsForTransactionsMutex.RLock()
+	defer fake.getSplitsForTransactionsMutex.RUnlock()
+	argsForCall := fake.getSplitsForTransactionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetSplitsForTransactionsReturns(result1 map[string][]db.Split, result2 error) {
+	fake.getSplitsForTransactionsMutex.Lock()
+	defer fake.getSplitsForTransactionsMutex.Unlock()
+	fake.GetSplitsForTransactionsStub = nil
+	fake.getSplitsForTransactionsReturns = struct {
+		result1 map[string][]db.Split
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetSplitsForTransactionsReturnsOnCall(i int, result1 map[string][]db.Split, result2 error) {
+	fake.getSplitsForTransactionsMutex.Lock()
+	defer fake.getSplitsForTransactionsMutex.Unlock()
+	fake.GetSplitsForTransactionsStub = nil
+	if fake.getSplitsForTransactionsReturnsOnCall == nil {
+		fake.getSplitsForTransactionsReturnsOnCall = make(map[int]struct {
+			result1 map[string][]db.Split
+			result2 error
+		})
+	}
+	fake.getSplitsForTransactionsReturnsOnCall[i] = struct {
+		result1 map[string][]db.Split
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTagsForTransactions(arg1 context.Context, arg2 string, arg3 []string) (map[string][]string, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.getTagsForTransactionsMutex.Lock()
+	ret, specificReturn := fake.getTagsForTransactionsReturnsOnCall[len(fake.getTagsForTransactionsArgsForCall)]
+	fake.getTagsForTransactionsArgsForCall = append(fake.getTagsForTransactionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.GetTagsForTransactionsStub
+	fakeReturns := fake.getTagsForTransactionsReturns
+	fake.recordInvocation("GetTagsForTransactions", []interface{}{arg1, arg2, arg3Copy})
+	fake.getTagsForTransactionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetTagsForTransactionsCallCount() int {
+	fake.getTagsForTransactionsMutex.RLock()
+	defer fake.getTagsForTransactionsMutex.RUnlock()
+	return len(fake.getTagsForTransactionsArgsForCall)
+}
+
+func (fake *FakeDB) GetTagsForTransactionsCalls(stub func(context.Context, string, []string) (map[string][]string, error)) {
+	fake.getTagsForTransactionsMutex.Lock()
+	defer fake.getTagsForTransactionsMutex.Unlock()
+	fake.GetTagsForTransactionsStub = stub
+}
+
+func (fake *FakeDB) GetTagsForTransactionsArgsForCall(i int) (context.Context, string, []string) {
+	fake.getTagsForTransactionsMutex.RLock()
+	defer fake.getTagsForTransactionsMutex.RUnlock()
+	argsForCall := fake.getTagsForTransactionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetTagsForTransactionsReturns(result1 map[string][]string, result2 error) {
+	fake.getTagsForTransactionsMutex.Lock()
+	defer fake.getTagsForTransactionsMutex.Unlock()
+	fake.GetTagsForTransactionsStub = nil
+	fake.getTagsForTransactionsReturns = struct {
+		result1 map[string][]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTagsForTransactionsReturnsOnCall(i int, result1 map[string][]string, result2 error) {
+	fake.getTagsForTransactionsMutex.Lock()
+	defer fake.getTagsForTransactionsMutex.Unlock()
+	fake.GetTagsForTransactionsStub = nil
+	if fake.getTagsForTransactionsReturnsOnCall == nil {
+		fake.getTagsForTransactionsReturnsOnCall = make(map[int]struct {
+			result1 map[string][]string
+			result2 error
+		})
+	}
+	fake.getTagsForTransactionsReturnsOnCall[i] = struct {
+		result1 map[string][]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTransaction(arg1 context.Context, arg2 string, arg3 string) (db.Transaction, error) {
+	fake.getTransactionMutex.Lock()
+	ret, specificReturn := fake.getTransactionReturnsOnCall[len(fake.getTransactionArgsForCall)]
+	fake.getTransactionArgsForCall = append(fake.getTransactionArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetTransactionStub
+	fakeReturns := fake.getTransactionReturns
+	fake.recordInvocation("GetTransaction", []interface{}{arg1, arg2, arg3})
+	fake.getTransactionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetTransactionCallCount() int {
+	fake.getTransactionMutex.RLock()
+	defer fake.getTransactionMutex.RUnlock()
+	return len(fake.getTransactionArgsForCall)
+}
+
+func (fake *FakeDB) GetTransactionCalls(stub func(context.Context, string, string) (db.Transaction, error)) {
+	fake.getTransactionMutex.Lock()
+	defer fake.getTransactionMutex.Unlock()
+	fake.GetTransactionStub = stub
+}
+
+func (fake *FakeDB) GetTransactionArgsForCall(i int) (context.Context, string, string) {
+	fake.getTransactionMutex.RLock()
+	defer fake.getTransactionMutex.RUnlock()
+	argsForCall := fake.getTransactionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetTransactionReturns(result1 db.Transaction, result2 error) {
+	fake.getTransactionMutex.Lock()
+	defer fake.getTransactionMutex.Unlock()
+	fake.GetTransactionStub = nil
+	fake.getTransactionReturns = struct {
+		result1 db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTransactionReturnsOnCall(i int, result1 db.Transaction, result2 error) {
+	fake.getTransactionMutex.Lock()
+	defer fake.getTransactionMutex.Unlock()
+	fake.GetTransactionStub = nil
+	if fake.getTransactionReturnsOnCall == nil {
+		fake.getTransactionReturnsOnCall = make(map[int]struct {
+			result1 db.Transaction
+			result2 error
+		})
+	}
+	fake.getTransactionReturnsOnCall[i] = struct {
+		result1 db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTransactionSplits(arg1 context.Context, arg2 string, arg3 string) ([]db.Split, error) {
+	fake.getTransactionSplitsMutex.Lock()
+	ret, specificReturn := fake.getTransactionSplitsReturnsOnCall[len(fake.getTransactionSplitsArgsForCall)]
+	fake.getTransactionSplitsArgsForCall = append(fake.getTransactionSplitsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetTransactionSplitsStub
+	fakeReturns := fake.getTransactionSplitsReturns
+	fake.recordInvocation("GetTransactionSplits", []interface{}{arg1, arg2, arg3})
+	fake.getTransactionSplitsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetTransactionSplitsCallCount() int {
+	fake.getTransactionSplitsMutex.RLock()
+	defer fake.getTransactionSplitsMutex.RUnlock()
+	return len(fake.getTransactionSplitsArgsForCall)
+}
+
+func (fake *FakeDB) GetTransactionSplitsCalls(stub func(context.Context, string, string) ([]db.Split, error)) {
+	fake.getTransactionSplitsMutex.Lock()
+	defer fake.getTransactionSplitsMutex.Unlock()
+	fake.GetTransactionSplitsStub = stub
+}
+
+func (fake *FakeDB) GetTransactionSplitsArgsForCall(i int) (context.Context, string, string) {
+	fake.getTransactionSplitsMutex.RLock()
+	defer fake.getTransactionSplitsMutex.RUnlock()
+	argsForCall := fake.getTransactionSplitsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetTransactionSplitsReturns(result1 []db.Split, result2 error) {
+	fake.getTransactionSplitsMutex.Lock()
+	defer fake.getTransactionSplitsMutex.Unlock()
+	fake.GetTransactionSplitsStub = nil
+	fake.getTransactionSplitsReturns = struct {
+		result1 []db.Split
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTransactionSplitsReturnsOnCall(i int, result1 []db.Split, result2 error) {
+	fake.getTransactionSplitsMutex.Lock()
+	defer fake.getTransactionSplitsMutex.Unlock()
+	fake.GetTransactionSplitsStub = nil
+	if fake.getTransactionSplitsReturnsOnCall == nil {
+		fake.getTransactionSplitsReturnsOnCall = make(map[int]struct {
+			result1 []db.Split
+			result2 error
+		})
+	}
+	fake.getTransactionSplitsReturnsOnCall[i] = struct {
+		result1 []db.Split
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTransactionTags(arg1 context.Context, arg2 string, arg3 string) ([]string, error) {
+	fake.getTransactionTagsMutex.Lock()
+	ret, specificReturn := fake.getTransactionTagsReturnsOnCall[len(fake.getTransactionTagsArgsForCall)]
+	fake.getTransactionTagsArgsForCall = append(fake.getTransactionTagsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetTransactionTagsStub
+	fakeReturns := fake.getTransactionTagsReturns
+	fake.recordInvocation("GetTransactionTags", []interface{}{arg1, arg2, arg3})
+	fake.getTransactionTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetTransactionTagsCallCount() int {
+	fake.getTransactionTagsMutex.RLock()
+	defer fake.getTransactionTagsMutex.RUnlock()
+	return len(fake.getTransactionTagsArgsForCall)
+}
+
+func (fake *FakeDB) GetTransactionTagsCalls(stub func(context.Context, string, string) ([]string, error)) {
+	fake.getTransactionTagsMutex.Lock()
+	defer fake.getTransactionTagsMutex.Unlock()
+	fake.GetTransactionTagsStub = stub
+}
+
+func (fake *FakeDB) GetTransactionTagsArgsForCall(i int) (context.Context, string, string) {
+	fake.getTransactionTagsMutex.RLock()
+	defer fake.getTransactionTagsMutex.RUnlock()
+	argsForCall := fake.getTransactionTagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetTransactionTagsReturns(result1 []string, result2 error) {
+	fake.getTransactionTagsMutex.Lock()
+	defer fake.getTransactionTagsMutex.Unlock()
+	fake.GetTransactionTagsStub = nil
+	fake.getTransactionTagsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTransactionTagsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.getTransactionTagsMutex.Lock()
+	defer fake.getTransactionTagsMutex.Unlock()
+	fake.GetTransactionTagsStub = nil
+	if fake.getTransactionTagsReturnsOnCall == nil {
+		fake.getTransactionTagsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.getTransactionTagsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTransactions(arg1 context.Context, arg2 string, arg3 string) ([]db.Transaction, error) {
+	fake.getTransactionsMutex.Lock()
+	ret, specificReturn := fake.getTransactionsReturnsOnCall[len(fake.getTransactionsArgsForCall)]
+	fake.getTransactionsArgsForCall = append(fake.getTransactionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetTransactionsStub
+	fakeReturns := fake.getTransactionsReturns
+	fake.recordInvocation("GetTransactions", []interface{}{arg1, arg2, arg3})
+	fake.getTransactionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetTransactionsCallCount() int {
+	fake.getTransactionsMutex.RLock()
+	defer fake.getTransactionsMutex.RUnlock()
+	return len(fake.getTransactionsArgsForCall)
+}
+
+func (fake *FakeDB) GetTransactionsCalls(stub func(context.Context, string, string) ([]db.Transaction, error)) {
+	fake.getTransactionsMutex.Lock()
+	defer fake.getTransactionsMutex.Unlock()
+	fake.GetTransactionsStub = stub
+}
+
+func (fake *FakeDB) GetTransactionsArgsForCall(i int) (context.Context, string, string) {
+	fake.getTransactionsMutex.RLock()
+	defer fake.getTransactionsMutex.RUnlock()
+	argsForCall := fake.getTransactionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetTransactionsReturns(result1 []db.Transaction, result2 error) {
+	fake.getTransactionsMutex.Lock()
+	defer fake.getTransactionsMutex.Unlock()
+	fake.GetTransactionsStub = nil
+	fake.getTransactionsReturns = struct {
+		result1 []db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTransactionsReturnsOnCall(i int, result1 []db.Transaction, result2 error) {
+	fake.getTransactionsMutex.Lock()
+	defer fake.getTransactionsMutex.Unlock()
+	fake.GetTransactionsStub = nil
+	if fake.getTransactionsReturnsOnCall == nil {
+		fake.getTransactionsReturnsOnCall = make(map[int]struct {
+			result1 []db.Transaction
+			result2 error
+		})
+	}
+	fake.getTransactionsReturnsOnCall[i] = struct {
+		result1 []db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTransactionsByDateRange(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string) ([]db.Transaction, error) {
+	fake.getTransactionsByDateRangeMutex.Lock()
+	ret, specificReturn := fake.getTransactionsByDateRangeReturnsOnCall[len(fake.getTransactionsByDateRangeArgsForCall)]
+	fake.getTransactionsByDateRangeArgsForCall = append(fake.getTransactionsByDateRangeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.GetTransactionsByDateRangeStub
+	fakeReturns := fake.getTransactionsByDateRangeReturns
+	fake.recordInvocation("GetTransactionsByDateRange", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.getTransactionsByDateRangeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetTransactionsByDateRangeCallCount() int {
+	fake.getTransactionsByDateRangeMutex.RLock()
+	defer fake.getTransactionsByDateRangeMutex.RUnlock()
+	return len(fake.getTransactionsByDateRangeArgsForCall)
+}
+
+func (fake *FakeDB) GetTransactionsByDateRangeCalls(stub func(context.Context, string, string, string, string) ([]db.Transaction, error)) {
+	fake.getTransactionsByDateRangeMutex.Lock()
+	defer fake.getTransactionsByDateRangeMutex.Unlock()
+	fake.GetTransactionsByDateRangeStub = stub
+}
+
+func (fake *FakeDB) GetTransactionsByDateRangeArgsForCall(i int) (context.Context, string, string, string, string) {
+	fake.getTransactionsByDateRangeMutex.RLock()
+	defer fake.getTransactionsByDateRangeMutex.RUnlock()
+	argsForCall := fake.getTransactionsByDateRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeDB) GetTransactionsByDateRangeReturns(result1 []db.Transaction, result2 error) {
+	fake.getTransactionsByDateRangeMutex.Lock()
+	defer fake.getTransactionsByDateRangeMutex.Unlock()
+	fake.GetTransactionsByDateRangeStub = nil
+	fake.getTransactionsByDateRangeReturns = struct {
+		result1 []db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTransactionsByDateRangeReturnsOnCall(i int, result1 []db.Transaction, result2 error) {
+	fake.getTransactionsByDateRangeMutex.Lock()
+	defer fake.getTransactionsByDateRangeMutex.Unlock()
+	fake.GetTransactionsByDateRangeStub = nil
+	if fake.getTransactionsByDateRangeReturnsOnCall == nil {
+		fake.getTransactionsByDateRangeReturnsOnCall = make(map[int]struct {
+			result1 []db.Transaction
+			result2 error
+		})
+	}
+	fake.getTransactionsByDateRangeReturnsOnCall[i] = struct {
+		result1 []db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTransfers(arg1 context.Context, arg2 string) ([]db.Transfer, error) {
+	fake.getTransfersMutex.Lock()
+	ret, specificReturn := fake.getTransfersReturnsOnCall[len(fake.getTransfersArgsForCall)]
+	fake.getTransfersArgsForCall = append(fake.getTransfersArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetTransfersStub
+	fakeReturns := fake.getTransfersReturns
+	fake.recordInvocation("GetTransfers", []interface{}{arg1, arg2})
+	fake.getTransfersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetTransfersCallCount() int {
+	fake.getTransfersMutex.RLock()
+	defer fake.getTransfersMutex.RUnlock()
+	return len(fake.getTransfersArgsForCall)
+}
+
+func (fake *FakeDB) GetTransfersCalls(stub func(context.Context, string) ([]db.Transfer, error)) {
+	fake.getTransfersMutex.Lock()
+	defer fake.getTransfersMutex.Unlock()
+	fake.GetTransfersStub = stub
+}
+
+func (fake *FakeDB) GetTransfersArgsForCall(i int) (context.Context, string) {
+	fake.getTransfersMutex.RLock()
+	defer fake.getTransfersMutex.RUnlock()
+	argsForCall := fake.getTransfersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetTransfersReturns(result1 []db.Transfer, result2 error) {
+	fake.getTransfersMutex.Lock()
+	defer fake.getTransfersMutex.Unlock()
+	fake.GetTransfersStub = nil
+	fake.getTransfersReturns = struct {
+		result1 []db.Transfer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetTransfersReturnsOnCall(i int, result1 []db.Transfer, result2 error) {
+	fake.getTransfersMutex.Lock()
+	defer fake.getTransfersMutex.Unlock()
+	fake.GetTransfersStub = nil
+	if fake.getTransfersReturnsOnCall == nil {
+		fake.getTransfersReturnsOnCall = make(map[int]struct {
+			result1 []db.Transfer
+			result2 error
+		})
+	}
+	fake.getTransfersReturnsOnCall[i] = struct {
+		result1 []db.Transfer
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetUser(arg1 context.Context, arg2 string) (db.User, error) {
+	fake.getUserMutex.Lock()
+	ret, specificReturn := fake.getUserReturnsOnCall[len(fake.getUserArgsForCall)]
+	fake.getUserArgsForCall = append(fake.getUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetUserStub
+	fakeReturns := fake.getUserReturns
+	fake.recordInvocation("GetUser", []interface{}{arg1, arg2})
+	fake.getUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetUserCallCount() int {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	return len(fake.getUserArgsForCall)
+}
+
+func (fake *FakeDB) GetUserCalls(stub func(context.Context, string) (db.User, error)) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = stub
+}
+
+func (fake *FakeDB) GetUserArgsForCall(i int) (context.Context, string) {
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	argsForCall := fake.getUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetUserReturns(result1 db.User, result2 error) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = nil
+	fake.getUserReturns = struct {
+		result1 db.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetUserReturnsOnCall(i int, result1 db.User, result2 error) {
+	fake.getUserMutex.Lock()
+	defer fake.getUserMutex.Unlock()
+	fake.GetUserStub = nil
+	if fake.getUserReturnsOnCall == nil {
+		fake.getUserReturnsOnCall = make(map[int]struct {
+			result1 db.User
+			result2 error
+		})
+	}
+	fake.getUserReturnsOnCall[i] = struct {
+		result1 db.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetUserAttachments(arg1 context.Context, arg2 string) ([]db.Attachment, error) {
+	fake.getUserAttachmentsMutex.Lock()
+	ret, specificReturn := fake.getUserAttachmentsReturnsOnCall[len(fake.getUserAttachmentsArgsForCall)]
+	fake.getUserAttachmentsArgsForCall = append(fake.getUserAttachmentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetUserAttachmentsStub
+	fakeReturns := fake.getUserAttachmentsReturns
+	fake.recordInvocation("GetUserAttachments", []interface{}{arg1, arg2})
+	fake.getUserAttachmentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetUserAttachmentsCallCount() int {
+	fake.getUserAttachmentsMutex.RLock()
+	defer fake.getUserAttachmentsMutex.RUnlock()
+	return len(fake.getUserAttachmentsArgsForCall)
+}
+
+func (fake *FakeDB) GetUserAttachmentsCalls(stub func(context.Context, string) ([]db.Attachment, error)) {
+	fake.getUserAttachmentsMutex.Lock()
+	defer fake.getUserAttachmentsMutex.Unlock()
+	fake.GetUserAttachmentsStub = stub
+}
+
+func (fake *FakeDB) GetUserAttachmentsArgsForCall(i int) (context.Context, string) {
+	fake.getUserAttachmentsMutex.RLock()
+	defer fake.getUserAttachmentsMutex.RUnlock()
+	argsForCall := fake.getUserAttachmentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetUserAttachmentsReturns(result1 []db.Attachment, result2 error) {
+	fake.getUserAttachmentsMutex.Lock()
+	defer fake.getUserAttachmentsMutex.Unlock()
+	fake.GetUserAttachmentsStub = nil
+	fake.getUserAttachmentsReturns = struct {
+		result1 []db.Attachment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetUserAttachmentsReturnsOnCall(i int, result1 []db.Attachment, result2 error) {
+	fake.getUserAttachmentsMutex.Lock()
+	defer fake.getUserAttachmentsMutex.Unlock()
+	fake.GetUserAttachmentsStub = nil
+	if fake.getUserAttachmentsReturnsOnCall == nil {
+		fake.getUserAttachmentsReturnsOnCall = make(map[int]struct {
+			result1 []db.Attachment
+			result2 error
+		})
+	}
+	fake.getUserAttachmentsReturnsOnCall[i] = struct {
+		result1 []db.Attachment
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetUserForIdentity(arg1 context.Context, arg2 string, arg3 string) (string, error) {
+	fake.getUserForIdentityMutex.Lock()
+	ret, specificReturn := fake.getUserForIdentityReturnsOnCall[len(fake.getUserForIdentityArgsForCall)]
+	fake.getUserForIdentityArgsForCall = append(fake.getUserForIdentityArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetUserForIdentityStub
+	fakeReturns := fake.getUserForIdentityReturns
+	fake.recordInvocation("GetUserForIdentity", []interface{}{arg1, arg2, arg3})
+	fake.getUserForIdentityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetUserForIdentityCallCount() int {
+	fake.getUserForIdentityMutex.RLock()
+	defer fake.getUserForIdentityMutex.RUnlock()
+	return len(fake.getUserForIdentityArgsForCall)
+}
+
+func (fake *FakeDB) GetUserForIdentityCalls(stub func(context.Context, string, string) (string, error)) {
+	fake.getUserForIdentityMutex.Lock()
+	defer fake.getUserForIdentityMutex.Unlock()
+	fake.GetUserForIdentityStub = stub
+}
+
+func (fake *FakeDB) GetUserForIdentityArgsForCall(i int) (context.Context, string, string) {
+	fake.getUserForIdentityMutex.RLock()
+	defer fake.getUserForIdentityMutex.RUnlock()
+	argsForCall := fake.getUserForIdentityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetUserForIdentityReturns(result1 string, result2 error) {
+	fake.getUserForIdentityMutex.Lock()
+	defer fake.getUserForIdentityMutex.Unlock()
+	fake.GetUserForIdentityStub = nil
+	fake.getUserForIdentityReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetUserForIdentityReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getUserForIdentityMutex.Lock()
+	defer fake.getUserForIdentityMutex.Unlock()
+	fake.GetUserForIdentityStub = nil
+	if fake.getUserForIdentityReturnsOnCall == nil {
+		fake.getUserForIdentityReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getUserForIdentityReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetUserPermissions(arg1 context.Context, arg2 string) (string, []string, error) {
+	fake.getUserPermissionsMutex.Lock()
+	ret, specificReturn := fake.getUserPermissionsReturnsOnCall[len(fake.getUserPermissionsArgsForCall)]
+	fake.getUserPermissionsArgsForCall = append(fake.getUserPermissionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetUserPermissionsStub
+	fakeReturns := fake.getUserPermissionsReturns
+	fake.recordInvocation("GetUserPermissions", []interface{}{arg1, arg2})
+	fake.getUserPermissionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeDB) GetUserPermissionsCallCount() int {
+	fake.getUserPermissionsMutex.RLock()
+	defer fake.getUserPermissionsMutex.RUnlock()
+	return len(fake.getUserPermissionsArgsForCall)
+}
+
+func (fake *FakeDB) GetUserPermissionsCalls(stub func(context.Context, string) (string, []string, error)) {
+	fake.getUserPermissionsMutex.Lock()
+	defer fake.getUserPermissionsMutex.Unlock()
+	fake.GetUserPermissionsStub = stub
+}
+
+func (fake *FakeDB) GetUserPermissionsArgsForCall(i int) (context.Context, string) {
+	fake.getUserPermissionsMutex.RLock()
+	defer fake.getUserPermissionsMutex.RUnlock()
+	argsForCall := fake.getUserPermissionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetUserPermissionsReturns(result1 string, result2 []string, result3 error) {
+	fake.getUserPermissionsMutex.Lock()
+	defer fake.getUserPermissionsMutex.Unlock()
+	fake.GetUserPermissionsStub = nil
+	fake.getUserPermissionsReturns = struct {
+		result1 string
+		result2 []string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) GetUserPermissionsReturnsOnCall(i int, result1 string, result2 []string, result3 error) {
+	fake.getUserPermissionsMutex.Lock()
+	defer fake.getUserPermissionsMutex.Unlock()
+	fake.GetUserPermissionsStub = nil
+	if fake.getUserPermissionsReturnsOnCall == nil {
+		fake.getUserPermissionsReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 []string
+			result3 error
+		})
+	}
+	fake.getUserPermissionsReturnsOnCall[i] = struct {
+		result1 string
+		result2 []string
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) GetUserTransactionsByDateRange(arg1 context.Context, arg2 string, arg3 string, arg4 string) ([]db.Transaction, error) {
+	fake.getUserTransactionsByDateRangeMutex.Lock()
+	ret, specificReturn := fake.getUserTransactionsByDateRangeReturnsOnCall[len(fake.getUserTransactionsByDateRangeArgsForCall)]
+	fake.getUserTransactionsByDateRangeArgsForCall = append(fake.getUserTransactionsByDateRangeArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetUserTransactionsByDateRangeStub
+	fakeReturns := fake.getUserTransactionsByDateRangeReturns
+	fake.recordInvocation("GetUserTransactionsByDateRange", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getUserTransactionsByDateRangeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetUserTransactionsByDateRangeCallCount() int {
+	fake.getUserTransactionsByDateRangeMutex.RLock()
+	defer fake.getUserTransactionsByDateRangeMutex.RUnlock()
+	return len(fake.getUserTransactionsByDateRangeArgsForCall)
+}
+
+func (fake *FakeDB) GetUserTransactionsByDateRangeCalls(stub func(context.Context, string, string, string) ([]db.Transaction, error)) {
+	fake.getUserTransactionsByDateRangeMutex.Lock()
+	defer fake.getUserTransactionsByDateRangeMutex.Unlock()
+	fake.GetUserTransactionsByDateRangeStub = stub
+}
+
+func (fake *FakeDB) GetUserTransactionsByDateRangeArgsForCall(i int) (context.Context, string, string, string) {
+	fake.getUserTransactionsByDateRangeMutex.RLock()
+	defer fake.getUserTransactionsByDateRangeMutex.RUnlock()
+	argsForCall := fake.getUserTransactionsByDateRangeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) GetUserTransactionsByDateRangeReturns(result1 []db.Transaction, result2 error) {
+	fake.getUserTransactionsByDateRangeMutex.Lock()
+	defer fake.getUserTransactionsByDateRangeMutex.Unlock()
+	fake.GetUserTransactionsByDateRangeStub = nil
+	fake.getUserTransactionsByDateRangeReturns = struct {
+		result1 []db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetUserTransactionsByDateRangeReturnsOnCall(i int, result1 []db.Transaction, result2 error) {
+	fake.getUserTransactionsByDateRangeMutex.Lock()
+	defer fake.getUserTransactionsByDateRangeMutex.Unlock()
+	fake.GetUserTransactionsByDateRangeStub = nil
+	if fake.getUserTransactionsByDateRangeReturnsOnCall == nil {
+		fake.getUserTransactionsByDateRangeReturnsOnCall = make(map[int]struct {
+			result1 []db.Transaction
+			result2 error
+		})
+	}
+	fake.getUserTransactionsByDateRangeReturnsOnCall[i] = struct {
+		result1 []db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetUserWebhookDeliveries(arg1 context.Context, arg2 string, arg3 int) ([]db.WebhookDelivery, error) {
+	fake.getUserWebhookDeliveriesMutex.Lock()
+	ret, specificReturn := fake.getUserWebhookDeliveriesReturnsOnCall[len(fake.getUserWebhookDeliveriesArgsForCall)]
+	fake.getUserWebhookDeliveriesArgsForCall = append(fake.getUserWebhookDeliveriesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	stub := fake.GetUserWebhookDeliveriesStub
+	fakeReturns := fake.getUserWebhookDeliveriesReturns
+	fake.recordInvocation("GetUserWebhookDeliveries", []interface{}{arg1, arg2, arg3})
+	fake.getUserWebhookDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetUserWebhookDeliveriesCallCount() int {
+	fake.getUserWebhookDeliveriesMutex.RLock()
+	defer fake.getUserWebhookDeliveriesMutex.RUnlock()
+	return len(fake.getUserWebhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeDB) GetUserWebhookDeliveriesCalls(stub func(context.Context, string, int) ([]db.WebhookDelivery, error)) {
+	fake.getUserWebhookDeliveriesMutex.Lock()
+	defer fake.getUserWebhookDeliveriesMutex.Unlock()
+	fake.GetUserWebhookDeliveriesStub = stub
+}
+
+func (fake *FakeDB) GetUserWebhookDeliveriesArgsForCall(i int) (context.Context, string, int) {
+	fake.getUserWebhookDeliveriesMutex.RLock()
+	defer fake.getUserWebhookDeliveriesMutex.RUnlock()
+	argsForCall := fake.getUserWebhookDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) GetUserWebhookDeliveriesReturns(result1 []db.WebhookDelivery, result2 error) {
+	fake.getUserWebhookDeliveriesMutex.Lock()
+	defer fake.getUserWebhookDeliveriesMutex.Unlock()
+	fake.GetUserWebhookDeliveriesStub = nil
+	fake.getUserWebhookDeliveriesReturns = struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetUserWebhookDeliveriesReturnsOnCall(i int, result1 []db.WebhookDelivery, result2 error) {
+	fake.getUserWebhookDeliveriesMutex.Lock()
+	defer fake.getUserWebhookDeliveriesMutex.Unlock()
+	fake.GetUserWebhookDeliveriesStub = nil
+	if fake.getUserWebhookDeliveriesReturnsOnCall == nil {
+		fake.getUserWebhookDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []db.WebhookDelivery
+			result2 error
+		})
+	}
+	fake.getUserWebhookDeliveriesReturnsOnCall[i] = struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetUsers(arg1 context.Context, arg2 db.UserPage) ([]db.User, error) {
+	fake.getUsersMutex.Lock()
+	ret, specificReturn := fake.getUsersReturnsOnCall[len(fake.getUsersArgsForCall)]
+	fake.getUsersArgsForCall = append(fake.getUsersArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.UserPage
+	}{arg1, arg2})
+	stub := fake.GetUsersStub
+	fakeReturns := fake.getUsersReturns
+	fake.recordInvocation("GetUsers", []interface{}{arg1, arg2})
+	fake.getUsersMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetUsersCallCount() int {
+	fake.getUsersMutex.RLock()
+	defer fake.getUsersMutex.RUnlock()
+	return len(fake.getUsersArgsForCall)
+}
+
+func (fake *FakeDB) GetUsersCalls(stub func(context.Context, db.UserPage) ([]db.User, error)) {
+	fake.getUsersMutex.Lock()
+	defer fake.getUsersMutex.Unlock()
+	fake.GetUsersStub = stub
+}
+
+func (fake *FakeDB) GetUsersArgsForCall(i int) (context.Context, db.UserPage) {
+	fake.getUsersMutex.RLock()
+	defer fake.getUsersMutex.RUnlock()
+	argsForCall := fake.getUsersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetUsersReturns(result1 []db.User, result2 error) {
+	fake.getUsersMutex.Lock()
+	defer fake.getUsersMutex.Unlock()
+	fake.GetUsersStub = nil
+	fake.getUsersReturns = struct {
+		result1 []db.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetUsersReturnsOnCall(i int, result1 []db.User, result2 error) {
+	fake.getUsersMutex.Lock()
+	defer fake.getUsersMutex.Unlock()
+	fake.GetUsersStub = nil
+	if fake.getUsersReturnsOnCall == nil {
+		fake.getUsersReturnsOnCall = make(map[int]struct {
+			result1 []db.User
+			result2 error
+		})
+	}
+	fake.getUsersReturnsOnCall[i] = struct {
+		result1 []db.User
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetWebhookDeliveries(arg1 context.Context, arg2 string, arg3 string, arg4 int) ([]db.WebhookDelivery, error) {
+	fake.getWebhookDeliveriesMutex.Lock()
+	ret, specificReturn := fake.getWebhookDeliveriesReturnsOnCall[len(fake.getWebhookDeliveriesArgsForCall)]
+	fake.getWebhookDeliveriesArgsForCall = append(fake.getWebhookDeliveriesArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 int
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetWebhookDeliveriesStub
+	fakeReturns := fake.getWebhookDeliveriesReturns
+	fake.recordInvocation("GetWebhookDeliveries", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getWebhookDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetWebhookDeliveriesCallCount() int {
+	fake.getWebhookDeliveriesMutex.RLock()
+	defer fake.getWebhookDeliveriesMutex.RUnlock()
+	return len(fake.getWebhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeDB) GetWebhookDeliveriesCalls(stub func(context.Context, string, string, int) ([]db.WebhookDelivery, error)) {
+	fake.getWebhookDeliveriesMutex.Lock()
+	defer fake.getWebhookDeliveriesMutex.Unlock()
+	fake.GetWebhookDeliveriesStub = stub
+}
+
+func (fake *FakeDB) GetWebhookDeliveriesArgsForCall(i int) (context.Context, string, string, int) {
+	fake.getWebhookDeliveriesMutex.RLock()
+	defer fake.getWebhookDeliveriesMutex.RUnlock()
+	argsForCall := fake.getWebhookDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) GetWebhookDeliveriesReturns(result1 []db.WebhookDelivery, result2 error) {
+	fake.getWebhookDeliveriesMutex.Lock()
+	defer fake.getWebhookDeliveriesMutex.Unlock()
+	fake.GetWebhookDeliveriesStub = nil
+	fake.getWebhookDeliveriesReturns = struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetWebhookDeliveriesReturnsOnCall(i int, result1 []db.WebhookDelivery, result2 error) {
+	fake.getWebhookDeliveriesMutex.Lock()
+	defer fake.getWebhookDeliveriesMutex.Unlock()
+	fake.GetWebhookDeliveriesStub = nil
+	if fake.getWebhookDeliveriesReturnsOnCall == nil {
+		fake.getWebhookDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []db.WebhookDelivery
+			result2 error
+		})
+	}
+	fake.getWebhookDeliveriesReturnsOnCall[i] = struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetWebhookEndpoint(arg1 context.Context, arg2 string) (db.WebhookEndpoint, error) {
+	fake.getWebhookEndpointMutex.Lock()
+	ret, specificReturn := fake.getWebhookEndpointReturnsOnCall[len(fake.getWebhookEndpointArgsForCall)]
+	fake.getWebhookEndpointArgsForCall = append(fake.getWebhookEndpointArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetWebhookEndpointStub
+	fakeReturns := fake.getWebhookEndpointReturns
+	fake.recordInvocation("GetWebhookEndpoint", []interface{}{arg1, arg2})
+	fake.getWebhookEndpointMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetWebhookEndpointCallCount() int {
+	fake.getWebhookEndpointMutex.RLock()
+	defer fake.getWebhookEndpointMutex.RUnlock()
+	return len(fake.getWebhookEndpointArgsForCall)
+}
+
+func (fake *FakeDB) GetWebhookEndpointCalls(stub func(context.Context, string) (db.WebhookEndpoint, error)) {
+	fake.getWebhookEndpointMutex.Lock()
+	defer fake.getWebhookEndpointMutex.Unlock()
+	fake.GetWebhookEndpointStub = stub
+}
+
+func (fake *FakeDB) GetWebhookEndpointArgsForCall(i int) (context.Context, string) {
+	fake.getWebhookEndpointMutex.RLock()
+	defer fake.getWebhookEndpointMutex.RUnlock()
+	argsForCall := fake.getWebhookEndpointArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetWebhookEndpointReturns(result1 db.WebhookEndpoint, result2 error) {
+	fake.getWebhookEndpointMutex.Lock()
+	defer fake.getWebhookEndpointMutex.Unlock()
+	fake.GetWebhookEndpointStub = nil
+	fake.getWebhookEndpointReturns = struct {
+		result1 db.WebhookEndpoint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetWebhookEndpointReturnsOnCall(i int, result1 db.WebhookEndpoint, result2 error) {
+	fake.getWebhookEndpointMutex.Lock()
+	defer fake.getWebhookEndpointMutex.Unlock()
+	fake.GetWebhookEndpointStub = nil
+	if fake.getWebhookEndpointReturnsOnCall == nil {
+		fake.getWebhookEndpointReturnsOnCall = make(map[int]struct {
+			result1 db.WebhookEndpoint
+			result2 error
+		})
+	}
+	fake.getWebhookEndpointReturnsOnCall[i] = struct {
+		result1 db.WebhookEndpoint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetWebhookEndpoints(arg1 context.Context, arg2 string) ([]db.WebhookEndpoint, error) {
+	fake.getWebhookEndpointsMutex.Lock()
+	ret, specificReturn := fake.getWebhookEndpointsReturnsOnCall[len(fake.getWebhookEndpointsArgsForCall)]
+	fake.getWebhookEndpointsArgsForCall = append(fake.getWebhookEndpointsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.GetWebhookEndpointsStub
+	fakeReturns := fake.getWebhookEndpointsReturns
+	fake.recordInvocation("GetWebhookEndpoints", []interface{}{arg1, arg2})
+	fake.getWebhookEndpointsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) GetWebhookEndpointsCallCount() int {
+	fake.getWebhookEndpointsMutex.RLock()
+	defer fake.getWebhookEndpointsMutex.RUnlock()
+	return len(fake.getWebhookEndpointsArgsForCall)
+}
+
+func (fake *FakeDB) GetWebhookEndpointsCalls(stub func(context.Context, string) ([]db.WebhookEndpoint, error)) {
+	fake.getWebhookEndpointsMutex.Lock()
+	defer fake.getWebhookEndpointsMutex.Unlock()
+	fake.GetWebhookEndpointsStub = stub
+}
+
+func (fake *FakeDB) GetWebhookEndpointsArgsForCall(i int) (context.Context, string) {
+	fake.getWebhookEndpointsMutex.RLock()
+	defer fake.getWebhookEndpointsMutex.RUnlock()
+	argsForCall := fake.getWebhookEndpointsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) GetWebhookEndpointsReturns(result1 []db.WebhookEndpoint, result2 error) {
+	fake.getWebhookEndpointsMutex.Lock()
+	defer fake.getWebhookEndpointsMutex.Unlock()
+	fake.GetWebhookEndpointsStub = nil
+	fake.getWebhookEndpointsReturns = struct {
+		result1 []db.WebhookEndpoint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) GetWebhookEndpointsReturnsOnCall(i int, result1 []db.WebhookEndpoint, result2 error) {
+	fake.getWebhookEndpointsMutex.Lock()
+	defer fake.getWebhookEndpointsMutex.Unlock()
+	fake.GetWebhookEndpointsStub = nil
+	if fake.getWebhookEndpointsReturnsOnCall == nil {
+		fake.getWebhookEndpointsReturnsOnCall = make(map[int]struct {
+			result1 []db.WebhookEndpoint
+			result2 error
+		})
+	}
+	fake.getWebhookEndpointsReturnsOnCall[i] = struct {
+		result1 []db.WebhookEndpoint
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) LinkUserIdentity(arg1 context.Context, arg2 string, arg3 string, arg4 string) (string, error) {
+	fake.linkUserIdentityMutex.Lock()
+	ret, specificReturn := fake.linkUserIdentityReturnsOnCall[len(fake.linkUserIdentityArgsForCall)]
+	fake.linkUserIdentityArgsForCall = append(fake.linkUserIdentityArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.LinkUserIdentityStub
+	fakeReturns := fake.linkUserIdentityReturns
+	fake.recordInvocation("LinkUserIdentity", []interface{}{arg1, arg2, arg3, arg4})
+	fake.linkUserIdentityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) LinkUserIdentityCallCount() int {
+	fake.linkUserIdentityMutex.RLock()
+	defer fake.linkUserIdentityMutex.RUnlock()
+	return len(fake.linkUserIdentityArgsForCall)
+}
+
+func (fake *FakeDB) LinkUserIdentityCalls(stub func(context.Context, string, string, string) (string, error)) {
+	fake.linkUserIdentityMutex.Lock()
+	defer fake.linkUserIdentityMutex.Unlock()
+	fake.LinkUserIdentityStub = stub
+}
+
+func (fake *FakeDB) LinkUserIdentityArgsForCall(i int) (context.Context, string, string, string) {
+	fake.linkUserIdentityMutex.RLock()
+	defer fake.linkUserIdentityMutex.RUnlock()
+	argsForCall := fake.linkUserIdentityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) LinkUserIdentityReturns(result1 string, result2 error) {
+	fake.linkUserIdentityMutex.Lock()
+	defer fake.linkUserIdentityMutex.Unlock()
+	fake.LinkUserIdentityStub = nil
+	fake.linkUserIdentityReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) LinkUserIdentityReturnsOnCall(i int, result1 string, result2 error) {
+	fake.linkUserIdentityMutex.Lock()
+	defer fake.linkUserIdentityMutex.Unlock()
+	fake.LinkUserIdentityStub = nil
+	if fake.linkUserIdentityReturnsOnCall == nil {
+		fake.linkUserIdentityReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.linkUserIdentityReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) MarkItemSynced(arg1 context.Context, arg2 string) error {
+	fake.markItemSyncedMutex.Lock()
+	ret, specificReturn := fake.markItemSyncedReturnsOnCall[len(fake.markItemSyncedArgsForCall)]
+	fake.markItemSyncedArgsForCall = append(fake.markItemSyncedArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.MarkItemSyncedStub
+	fakeReturns := fake.markItemSyncedReturns
+	fake.recordInvocation("MarkItemSynced", []interface{}{arg1, arg2})
+	fake.markItemSyncedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) MarkItemSyncedCallCount() int {
+	fake.markItemSyncedMutex.RLock()
+	defer fake.markItemSyncedMutex.RUnlock()
+	return len(fake.markItemSyncedArgsForCall)
+}
+
+func (fake *FakeDB) MarkItemSyncedCalls(stub func(context.Context, string) error) {
+	fake.markItemSyncedMutex.Lock()
+	defer fake.markItemSyncedMutex.Unlock()
+	fake.MarkItemSyncedStub = stub
+}
+
+func (fake *FakeDB) MarkItemSyncedArgsForCall(i int) (context.Context, string) {
+	fake.markItemSyncedMutex.RLock()
+	defer fake.markItemSyncedMutex.RUnlock()
+	argsForCall := fake.markItemSyncedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) MarkItemSyncedReturns(result1 error) {
+	fake.markItemSyncedMutex.Lock()
+	defer fake.markItemSyncedMutex.Unlock()
+	fake.MarkItemSyncedStub = nil
+	fake.markItemSyncedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) MarkItemSyncedReturnsOnCall(i int, result1 error) {
+	fake.markItemSyncedMutex.Lock()
+	defer fake.markItemSyncedMutex.Unlock()
+	fake.MarkItemSyncedStub = nil
+	if fake.markItemSyncedReturnsOnCall == nil {
+		fake.markItemSyncedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markItemSyncedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) PruneJobRuns(arg1 context.Context, arg2 time.Time) (int64, error) {
+	fake.pruneJobRunsMutex.Lock()
+	ret, specificReturn := fake.pruneJobRunsReturnsOnCall[len(fake.pruneJobRunsArgsForCall)]
+	fake.pruneJobRunsArgsForCall = append(fake.pruneJobRunsArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.PruneJobRunsStub
+	fakeReturns := fake.pruneJobRunsReturns
+	fake.recordInvocation("PruneJobRuns", []interface{}{arg1, arg2})
+	fake.pruneJobRunsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) PruneJobRunsCallCount() int {
+	fake.pruneJobRunsMutex.RLock()
+	defer fake.pruneJobRunsMutex.RUnlock()
+	return len(fake.pruneJobRunsArgsForCall)
+}
+
+func (fake *FakeDB) PruneJobRunsCalls(stub func(context.Context, time.Time) (int64, error)) {
+	fake.pruneJobRunsMutex.Lock()
+	defer fake.pruneJobRunsMutex.Unlock()
+	fake.PruneJobRunsStub = stub
+}
+
+func (fake *FakeDB) PruneJobRunsArgsForCall(i int) (context.Context, time.Time) {
+	fake.pruneJobRunsMutex.RLock()
+	defer fake.pruneJobRunsMutex.RUnlock()
+	argsForCall := fake.pruneJobRunsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) PruneJobRunsReturns(result1 int64, result2 error) {
+	fake.pruneJobRunsMutex.Lock()
+	defer fake.pruneJobRunsMutex.Unlock()
+	fake.PruneJobRunsStub = nil
+	fake.pruneJobRunsReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) PruneJobRunsReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.pruneJobRunsMutex.Lock()
+	defer fake.pruneJobRunsMutex.Unlock()
+	fake.PruneJobRunsStub = nil
+	if fake.pruneJobRunsReturnsOnCall == nil {
+		fake.pruneJobRunsReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.pruneJobRunsReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) PruneWebhookDeliveries(arg1 context.Context, arg2 time.Time) (int64, error) {
+	fake.pruneWebhookDeliveriesMutex.Lock()
+	ret, specificReturn := fake.pruneWebhookDeliveriesReturnsOnCall[len(fake.pruneWebhookDeliveriesArgsForCall)]
+	fake.pruneWebhookDeliveriesArgsForCall = append(fake.pruneWebhookDeliveriesArgsForCall, struct {
+		arg1 context.Context
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.PruneWebhookDeliveriesStub
+	fakeReturns := fake.pruneWebhookDeliveriesReturns
+	fake.recordInvocation("PruneWebhookDeliveries", []interface{}{arg1, arg2})
+	fake.pruneWebhookDeliveriesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) PruneWebhookDeliveriesCallCount() int {
+	fake.pruneWebhookDeliveriesMutex.RLock()
+	defer fake.pruneWebhookDeliveriesMutex.RUnlock()
+	return len(fake.pruneWebhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeDB) PruneWebhookDeliveriesCalls(stub func(context.Context, time.Time) (int64, error)) {
+	fake.pruneWebhookDeliveriesMutex.Lock()
+	defer fake.pruneWebhookDeliveriesMutex.Unlock()
+	fake.PruneWebhookDeliveriesStub = stub
+}
+
+func (fake *FakeDB) PruneWebhookDeliveriesArgsForCall(i int) (context.Context, time.Time) {
+	fake.pruneWebhookDeliveriesMutex.RLock()
+	defer fake.pruneWebhookDeliveriesMutex.RUnlock()
+	argsForCall := fake.pruneWebhookDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) PruneWebhookDeliveriesReturns(result1 int64, result2 error) {
+	fake.pruneWebhookDeliveriesMutex.Lock()
+	defer fake.pruneWebhookDeliveriesMutex.Unlock()
+	fake.PruneWebhookDeliveriesStub = nil
+	fake.pruneWebhookDeliveriesReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) PruneWebhookDeliveriesReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.pruneWebhookDeliveriesMutex.Lock()
+	defer fake.pruneWebhookDeliveriesMutex.Unlock()
+	fake.PruneWebhookDeliveriesStub = nil
+	if fake.pruneWebhookDeliveriesReturnsOnCall == nil {
+		fake.pruneWebhookDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.pruneWebhookDeliveriesReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) RecordAuditEvent(arg1 context.Context, arg2 db.AuditEvent) (string, error) {
+	fake.recordAuditEventMutex.Lock()
+	ret, specificReturn := fake.recordAuditEventReturnsOnCall[len(fake.recordAuditEventArgsForCall)]
+	fake.recordAuditEventArgsForCall = append(fake.recordAuditEventArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.AuditEvent
+	}{arg1, arg2})
+	stub := fake.RecordAuditEventStub
+	fakeReturns := fake.recordAuditEventReturns
+	fake.recordInvocation("RecordAuditEvent", []interface{}{arg1, arg2})
+	fake.recordAuditEventMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) RecordAuditEventCallCount() int {
+	fake.recordAuditEventMutex.RLock()
+	defer fake.recordAuditEventMutex.RUnlock()
+	return len(fake.recordAuditEventArgsForCall)
+}
+
+func (fake *FakeDB) RecordAuditEventCalls(stub func(context.Context, db.AuditEvent) (string, error)) {
+	fake.recordAuditEventMutex.Lock()
+	defer fake.recordAuditEventMutex.Unlock()
+	fake.RecordAuditEventStub = stub
+}
+
+func (fake *FakeDB) RecordAuditEventArgsForCall(i int) (context.Context, db.AuditEvent) {
+	fake.recordAuditEventMutex.RLock()
+	defer fake.recordAuditEventMutex.RUnlock()
+	argsForCall := fake.recordAuditEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) RecordAuditEventReturns(result1 string, result2 error) {
+	fake.recordAuditEventMutex.Lock()
+	defer fake.recordAuditEventMutex.Unlock()
+	fake.RecordAuditEventStub = nil
+	fake.recordAuditEventReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) RecordAuditEventReturnsOnCall(i int, result1 string, result2 error) {
+	fake.recordAuditEventMutex.Lock()
+	defer fake.recordAuditEventMutex.Unlock()
+	fake.RecordAuditEventStub = nil
+	if fake.recordAuditEventReturnsOnCall == nil {
+		fake.recordAuditEventReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.recordAuditEventReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) RecordBalance(arg1 context.Context, arg2 db.Balance) (string, error) {
+	fake.recordBalanceMutex.Lock()
+	ret, specificReturn := fake.recordBalanceReturnsOnCall[len(fake.recordBalanceArgsForCall)]
+	fake.recordBalanceArgsForCall = append(fake.recordBalanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.Balance
+	}{arg1, arg2})
+	stub := fake.RecordBalanceStub
+	fakeReturns := fake.recordBalanceReturns
+	fake.recordInvocation("RecordBalance", []interface{}{arg1, arg2})
+	fake.recordBalanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) RecordBalanceCallCount() int {
+	fake.recordBalanceMutex.RLock()
+	defer fake.recordBalanceMutex.RUnlock()
+	return len(fake.recordBalanceArgsForCall)
+}
+
+func (fake *FakeDB) RecordBalanceCalls(stub func(context.Context, db.Balance) (string, error)) {
+	fake.recordBalanceMutex.Lock()
+	defer fake.recordBalanceMutex.Unlock()
+	fake.RecordBalanceStub = stub
+}
+
+func (fake *FakeDB) RecordBalanceArgsForCall(i int) (context.Context, db.Balance) {
+	fake.recordBalanceMutex.RLock()
+	defer fake.recordBalanceMutex.RUnlock()
+	argsForCall := fake.recordBalanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) RecordBalanceReturns(result1 string, result2 error) {
+	fake.recordBalanceMutex.Lock()
+	defer fake.recordBalanceMutex.Unlock()
+	fake.RecordBalanceStub = nil
+	fake.recordBalanceReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) RecordBalanceReturnsOnCall(i int, result1 string, result2 error) {
+	fake.recordBalanceMutex.Lock()
+	defer fake.recordBalanceMutex.Unlock()
+	fake.RecordBalanceStub = nil
+	if fake.recordBalanceReturnsOnCall == nil {
+		fake.recordBalanceReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.recordBalanceReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) RecordWebhookAttempt(arg1 context.Context, arg2 string, arg3 db.WebhookDeliveryStatus, arg4 int, arg5 string, arg6 *time.Time) error {
+	fake.recordWebhookAttemptMutex.Lock()
+	ret, specificReturn := fake.recordWebhookAttemptReturnsOnCall[len(fake.recordWebhookAttemptArgsForCall)]
+	fake.recordWebhookAttemptArgsForCall = append(fake.recordWebhookAttemptArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.WebhookDeliveryStatus
+		arg4 int
+		arg5 string
+		arg6 *time.Time
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.RecordWebhookAttemptStub
+	fakeReturns := fake.recordWebhookAttemptReturns
+	fake.recordInvocation("RecordWebhookAttempt", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordWebhookAttemptMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) RecordWebhookAttemptCallCount() int {
+	fake.recordWebhookAttemptMutex.RLock()
+	defer fake.recordWebhookAttemptMutex.RUnlock()
+	return len(fake.recordWebhookAttemptArgsForCall)
+}
+
+func (fake *FakeDB) RecordWebhookAttemptCalls(stub func(context.Context, string, db.WebhookDeliveryStatus, int, string, *time.Time) error) {
+	fake.recordWebhookAttemptMutex.Lock()
+	defer fake.recordWebhookAttemptMutex.Unlock()
+	fake.RecordWebhookAttemptStub = stub
+}
+
+func (fake *FakeDB) RecordWebhookAttemptArgsForCall(i int) (context.Context, string, db.WebhookDeliveryStatus, int, string, *time.Time) {
+	fake.recordWebhookAttemptMutex.RLock()
+	defer fake.recordWebhookAttemptMutex.RUnlock()
+	argsForCall := fake.recordWebhookAttemptArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *FakeDB) RecordWebhookAttemptReturns(result1 error) {
+	fake.recordWebhookAttemptMutex.Lock()
+	defer fake.recordWebhookAttemptMutex.Unlock()
+	fake.RecordWebhookAttemptStub = nil
+	fake.recordWebhookAttemptReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) RecordWebhookAttemptReturnsOnCall(i int, result1 error) {
+	fake.recordWebhookAttemptMutex.Lock()
+	defer fake.recordWebhookAttemptMutex.Unlock()
+	fake.RecordWebhookAttemptStub = nil
+	if fake.recordWebhookAttemptReturnsOnCall == nil {
+		fake.recordWebhookAttemptReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordWebhookAttemptReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) RedeliverWebhookDelivery(arg1 context.Context, arg2 string, arg3 string, arg4 string) error {
+	fake.redeliverWebhookDeliveryMutex.Lock()
+	ret, specificReturn := fake.redeliverWebhookDeliveryReturnsOnCall[len(fake.redeliverWebhookDeliveryArgsForCall)]
+	fake.redeliverWebhookDeliveryArgsForCall = append(fake.redeliverWebhookDeliveryArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.RedeliverWebhookDeliveryStub
+	fakeReturns := fake.redeliverWebhookDeliveryReturns
+	fake.recordInvocation("RedeliverWebhookDelivery", []interface{}{arg1, arg2, arg3, arg4})
+	fake.redeliverWebhookDeliveryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) RedeliverWebhookDeliveryCallCount() int {
+	fake.redeliverWebhookDeliveryMutex.RLock()
+	defer fake.redeliverWebhookDeliveryMutex.RUnlock()
+	return len(fake.redeliverWebhookDeliveryArgsForCall)
+}
+
+func (fake *FakeDB) RedeliverWebhookDeliveryCalls(stub func(context.Context, string, string, string) error) {
+	fake.redeliverWebhookDeliveryMutex.Lock()
+	defer fake.redeliverWebhookDeliveryMutex.Unlock()
+	fake.RedeliverWebhookDeliveryStub = stub
+}
+
+func (fake *FakeDB) RedeliverWebhookDeliveryArgsForCall(i int) (context.Context, string, string, string) {
+	fake.redeliverWebhookDeliveryMutex.RLock()
+	defer fake.redeliverWebhookDeliveryMutex.RUnlock()
+	argsForCall := fake.redeliverWebhookDeliveryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) RedeliverWebhookDeliveryReturns(result1 error) {
+	fake.redeliverWebhookDeliveryMutex.Lock()
+	defer fake.redeliverWebhookDeliveryMutex.Unlock()
+	fake.RedeliverWebhookDeliveryStub = nil
+	fake.redeliverWebhookDeliveryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) RedeliverWebhookDeliveryReturnsOnCall(i int, result1 error) {
+	fake.redeliverWebhookDeliveryMutex.Lock()
+	defer fake.redeliverWebhookDeliveryMutex.Unlock()
+	fake.RedeliverWebhookDeliveryStub = nil
+	if fake.redeliverWebhookDeliveryReturnsOnCall == nil {
+		fake.redeliverWebhookDeliveryReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.redeliverWebhookDeliveryReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) RegisterUser(arg1 context.Context, arg2 string, arg3 string, arg4 string) error {
+	fake.registerUserMutex.Lock()
+	ret, specificReturn := fake.registerUserReturnsOnCall[len(fake.registerUserArgsForCall)]
+	fake.registerUserArgsForCall = append(fake.registerUserArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.RegisterUserStub
+	fakeReturns := fake.registerUserReturns
+	fake.recordInvocation("RegisterUser", []interface{}{arg1, arg2, arg3, arg4})
+	fake.registerUserMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) RegisterUserCallCount() int {
+	fake.registerUserMutex.RLock()
+	defer fake.registerUserMutex.RUnlock()
+	return len(fake.registerUserArgsForCall)
+}
+
+func (fake *FakeDB) RegisterUserCalls(stub func(context.Context, string, string, string) error) {
+	fake.registerUserMutex.Lock()
+	defer fake.registerUserMutex.Unlock()
+	fake.RegisterUserStub = stub
+}
+
+func (fake *FakeDB) RegisterUserArgsForCall(i int) (context.Context, string, string, string) {
+	fake.registerUserMutex.RLock()
+	defer fake.registerUserMutex.RUnlock()
+	argsForCall := fake.registerUserArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) RegisterUserReturns(result1 error) {
+	fake.registerUserMutex.Lock()
+	defer fake.registerUserMutex.Unlock()
+	fake.RegisterUserStub = nil
+	fake.registerUserReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) RegisterUserReturnsOnCall(i int, result1 error) {
+	fake.registerUserMutex.Lock()
+	defer fake.registerUserMutex.Unlock()
+	fake.RegisterUserStub = nil
+	if fake.registerUserReturnsOnCall == nil {
+		fake.registerUserReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.registerUserReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) ReleaseDigest(arg1 context.Context, arg2 string, arg3 *time.Time) error {
+	fake.releaseDigestMutex.Lock()
+	ret, specificReturn := fake.releaseDigestReturnsOnCall[len(fake.releaseDigestArgsForCall)]
+	fake.releaseDigestArgsForCall = append(fake.releaseDigestArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 *time.Time
+	}{arg1, arg2, arg3})
+	stub := fake.ReleaseDigestStub
+	fakeReturns := fake.releaseDigestReturns
+	fake.recordInvocation("ReleaseDigest", []interface{}{arg1, arg2, arg3})
+	fake.releaseDigestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) ReleaseDigestCallCount() int {
+	fake.releaseDigestMutex.RLock()
+	defer fake.releaseDigestMutex.RUnlock()
+	return len(fake.releaseDigestArgsForCall)
+}
+
+func (fake *FakeDB) ReleaseDigestCalls(stub func(context.Context, string, *time.Time) error) {
+	fake.releaseDigestMutex.Lock()
+	defer fake.releaseDigestMutex.Unlock()
+	fake.ReleaseDigestStub = stub
+}
+
+func (fake *FakeDB) ReleaseDigestArgsForCall(i int) (context.Context, string, *time.Time) {
+	fake.releaseDigestMutex.RLock()
+	defer fake.releaseDigestMutex.RUnlock()
+	argsForCall := fake.releaseDigestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) ReleaseDigestReturns(result1 error) {
+	fake.releaseDigestMutex.Lock()
+	defer fake.releaseDigestMutex.Unlock()
+	fake.ReleaseDigestStub = nil
+	fake.releaseDigestReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) ReleaseDigestReturnsOnCall(i int, result1 error) {
+	fake.releaseDigestMutex.Lock()
+	defer fake.releaseDigestMutex.Unlock()
+	fake.ReleaseDigestStub = nil
+	if fake.releaseDigestReturnsOnCall == nil {
+		fake.releaseDigestReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.releaseDigestReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) RevokePersonalAccessToken(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.revokePersonalAccessTokenMutex.Lock()
+	ret, specificReturn := fake.revokePersonalAccessTokenReturnsOnCall[len(fake.revokePersonalAccessTokenArgsForCall)]
+	fake.revokePersonalAccessTokenArgsForCall = append(fake.revokePersonalAccessTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RevokePersonalAccessTokenStub
+	fakeReturns := fake.revokePersonalAccessTokenReturns
+	fake.recordInvocation("RevokePersonalAccessToken", []interface{}{arg1, arg2, arg3})
+	fake.revokePersonalAccessTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) RevokePersonalAccessTokenCallCount() int {
+	fake.revokePersonalAccessTokenMutex.RLock()
+	defer fake.revokePersonalAccessTokenMutex.RUnlock()
+	return len(fake.revokePersonalAccessTokenArgsForCall)
+}
+
+func (fake *FakeDB) RevokePersonalAccessTokenCalls(stub func(context.Context, string, string) error) {
+	fake.revokePersonalAccessTokenMutex.Lock()
+	defer fake.revokePersonalAccessTokenMutex.Unlock()
+	fake.RevokePersonalAccessTokenStub = stub
+}
+
+func (fake *FakeDB) RevokePersonalAccessTokenArgsForCall(i int) (context.Context, string, string) {
+	fake.revokePersonalAccessTokenMutex.RLock()
+	defer fake.revokePersonalAccessTokenMutex.RUnlock()
+	argsForCall := fake.revokePersonalAccessTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) RevokePersonalAccessTokenReturns(result1 error) {
+	fake.revokePersonalAccessTokenMutex.Lock()
+	defer fake.revokePersonalAccessTokenMutex.Unlock()
+	fake.RevokePersonalAccessTokenStub = nil
+	fake.revokePersonalAccessTokenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) RevokePersonalAccessTokenReturnsOnCall(i int, result1 error) {
+	fake.revokePersonalAccessTokenMutex.Lock()
+	defer fake.revokePersonalAccessTokenMutex.Unlock()
+	fake.RevokePersonalAccessTokenStub = nil
+	if fake.revokePersonalAccessTokenReturnsOnCall == nil {
+		fake.revokePersonalAccessTokenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokePersonalAccessTokenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) RevokeShare(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.revokeShareMutex.Lock()
+	ret, specificReturn := fake.revokeShareReturnsOnCall[len(fake.revokeShareArgsForCall)]
+	fake.revokeShareArgsForCall = append(fake.revokeShareArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.RevokeShareStub
+	fakeReturns := fake.revokeShareReturns
+	fake.recordInvocation("RevokeShare", []interface{}{arg1, arg2, arg3})
+	fake.revokeShareMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) RevokeShareCallCount() int {
+	fake.revokeShareMutex.RLock()
+	defer fake.revokeShareMutex.RUnlock()
+	return len(fake.revokeShareArgsForCall)
+}
+
+func (fake *FakeDB) RevokeShareCalls(stub func(context.Context, string, string) error) {
+	fake.revokeShareMutex.Lock()
+	defer fake.revokeShareMutex.Unlock()
+	fake.RevokeShareStub = stub
+}
+
+func (fake *FakeDB) RevokeShareArgsForCall(i int) (context.Context, string, string) {
+	fake.revokeShareMutex.RLock()
+	defer fake.revokeShareMutex.RUnlock()
+	argsForCall := fake.revokeShareArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) RevokeShareReturns(result1 error) {
+	fake.revokeShareMutex.Lock()
+	defer fake.revokeShareMutex.Unlock()
+	fake.RevokeShareStub = nil
+	fake.revokeShareReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) RevokeShareReturnsOnCall(i int, result1 error) {
+	fake.revokeShareMutex.Lock()
+	defer fake.revokeShareMutex.Unlock()
+	fake.RevokeShareStub = nil
+	if fake.revokeShareReturnsOnCall == nil {
+		fake.revokeShareReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeShareReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SeedRole(arg1 context.Context, arg2 string, arg3 []string) error {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.seedRoleMutex.Lock()
+	ret, specificReturn := fake.seedRoleReturnsOnCall[len(fake.seedRoleArgsForCall)]
+	fake.seedRoleArgsForCall = append(fake.seedRoleArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	stub := fake.SeedRoleStub
+	fakeReturns := fake.seedRoleReturns
+	fake.recordInvocation("SeedRole", []interface{}{arg1, arg2, arg3Copy})
+	fake.seedRoleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) SeedRoleCallCount() int {
+	fake.seedRoleMutex.RLock()
+	defer fake.seedRoleMutex.RUnlock()
+	return len(fake.seedRoleArgsForCall)
+}
+
+func (fake *FakeDB) SeedRoleCalls(stub func(context.Context, string, []string) error) {
+	fake.seedRoleMutex.Lock()
+	defer fake.seedRoleMutex.Unlock()
+	fake.SeedRoleStub = stub
+}
+
+func (fake *FakeDB) SeedRoleArgsForCall(i int) (context.Context, string, []string) {
+	fake.seedRoleMutex.RLock()
+	defer fake.seedRoleMutex.RUnlock()
+	argsForCall := fake.seedRoleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) SeedRoleReturns(result1 error) {
+	fake.seedRoleMutex.Lock()
+	defer fake.seedRoleMutex.Unlock()
+	fake.SeedRoleStub = nil
+	fake.seedRoleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SeedRoleReturnsOnCall(i int, result1 error) {
+	fake.seedRoleMutex.Lock()
+	defer fake.seedRoleMutex.Unlock()
+	fake.SeedRoleStub = nil
+	if fake.seedRoleReturnsOnCall == nil {
+		fake.seedRoleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.seedRoleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetAccountHidden(arg1 context.Context, arg2 string, arg3 string, arg4 bool) error {
+	fake.setAccountHiddenMutex.Lock()
+	ret, specificReturn := fake.setAccountHiddenReturnsOnCall[len(fake.setAccountHiddenArgsForCall)]
+	fake.setAccountHiddenArgsForCall = append(fake.setAccountHiddenArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 bool
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SetAccountHiddenStub
+	fakeReturns := fake.setAccountHiddenReturns
+	fake.recordInvocation("SetAccountHidden", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setAccountHiddenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) SetAccountHiddenCallCount() int {
+	fake.setAccountHiddenMutex.RLock()
+	defer fake.setAccountHiddenMutex.RUnlock()
+	return len(fake.setAccountHiddenArgsForCall)
+}
+
+func (fake *FakeDB) SetAccountHiddenCalls(stub func(context.Context, string, string, bool) error) {
+	fake.setAccountHiddenMutex.Lock()
+	defer fake.setAccountHiddenMutex.Unlock()
+	fake.SetAccountHiddenStub = stub
+}
+
+func (fake *FakeDB) SetAccountHiddenArgsForCall(i int) (context.Context, string, string, bool) {
+	fake.setAccountHiddenMutex.RLock()
+	defer fake.setAccountHiddenMutex.RUnlock()
+	argsForCall := fake.setAccountHiddenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) SetAccountHiddenReturns(result1 error) {
+	fake.setAccountHiddenMutex.Lock()
+	defer fake.setAccountHiddenMutex.Unlock()
+	fake.SetAccountHiddenStub = nil
+	fake.setAccountHiddenReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetAccountHiddenReturnsOnCall(i int, result1 error) {
+	fake.setAccountHiddenMutex.Lock()
+	defer fake.setAccountHiddenMutex.Unlock()
+	fake.SetAccountHiddenStub = nil
+	if fake.setAccountHiddenReturnsOnCall == nil {
+		fake.setAccountHiddenReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setAccountHiddenReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetAlertDeliveryStatus(arg1 context.Context, arg2 string, arg3 db.AlertDeliveryStatus, arg4 string) error {
+	fake.setAlertDeliveryStatusMutex.Lock()
+	ret, specificReturn := fake.setAlertDeliveryStatusReturnsOnCall[len(fake.setAlertDeliveryStatusArgsForCall)]
+	fake.setAlertDeliveryStatusArgsForCall = append(fake.setAlertDeliveryStatusArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.AlertDeliveryStatus
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SetAlertDeliveryStatusStub
+	fakeReturns := fake.setAlertDeliveryStatusReturns
+	fake.recordInvocation("SetAlertDeliveryStatus", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setAlertDeliveryStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) SetAlertDeliveryStatusCallCount() int {
+	fake.setAlertDeliveryStatusMutex.RLock()
+	defer fake.setAlertDeliveryStatusMutex.RUnlock()
+	return len(fake.setAlertDeliveryStatusArgsForCall)
+}
+
+func (fake *FakeDB) SetAlertDeliveryStatusCalls(stub func(context.Context, string, db.AlertDeliveryStatus, string) error) {
+	fake.setAlertDeliveryStatusMutex.Lock()
+	defer fake.setAlertDeliveryStatusMutex.Unlock()
+	fake.SetAlertDeliveryStatusStub = stub
+}
+
+func (fake *FakeDB) SetAlertDeliveryStatusArgsForCall(i int) (context.Context, string, db.AlertDeliveryStatus, string) {
+	fake.setAlertDeliveryStatusMutex.RLock()
+	defer fake.setAlertDeliveryStatusMutex.RUnlock()
+	argsForCall := fake.setAlertDeliveryStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) SetAlertDeliveryStatusReturns(result1 error) {
+	fake.setAlertDeliveryStatusMutex.Lock()
+	defer fake.setAlertDeliveryStatusMutex.Unlock()
+	fake.SetAlertDeliveryStatusStub = nil
+	fake.setAlertDeliveryStatusReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetAlertDeliveryStatusReturnsOnCall(i int, result1 error) {
+	fake.setAlertDeliveryStatusMutex.Lock()
+	defer fake.setAlertDeliveryStatusMutex.Unlock()
+	fake.SetAlertDeliveryStatusStub = nil
+	if fake.setAlertDeliveryStatusReturnsOnCall == nil {
+		fake.setAlertDeliveryStatusReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setAlertDeliveryStatusReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetDigestPreferences(arg1 context.Context, arg2 db.DigestPreferences) error {
+	fake.setDigestPreferencesMutex.Lock()
+	ret, specificReturn := fake.setDigestPreferencesReturnsOnCall[len(fake.setDigestPreferencesArgsForCall)]
+	fake.setDigestPreferencesArgsForCall = append(fake.setDigestPreferencesArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.DigestPreferences
+	}{arg1, arg2})
+	stub := fake.SetDigestPreferencesStub
+	fakeReturns := fake.setDigestPreferencesReturns
+	fake.recordInvocation("SetDigestPreferences", []interface{}{arg1, arg2})
+	fake.setDigestPreferencesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) SetDigestPreferencesCallCount() int {
+	fake.setDigestPreferencesMutex.RLock()
+	defer fake.setDigestPreferencesMutex.RUnlock()
+	return len(fake.setDigestPreferencesArgsForCall)
+}
+
+func (fake *FakeDB) SetDigestPreferencesCalls(stub func(context.Context, db.DigestPreferences) error) {
+	fake.setDigestPreferencesMutex.Lock()
+	defer fake.setDigestPreferencesMutex.Unlock()
+	fake.SetDigestPreferencesStub = stub
+}
+
+func (fake *FakeDB) SetDigestPreferencesArgsForCall(i int) (context.Context, db.DigestPreferences) {
+	fake.setDigestPreferencesMutex.RLock()
+	defer fake.setDigestPreferencesMutex.RUnlock()
+	argsForCall := fake.setDigestPreferencesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) SetDigestPreferencesReturns(result1 error) {
+	fake.setDigestPreferencesMutex.Lock()
+	defer fake.setDigestPreferencesMutex.Unlock()
+	fake.SetDigestPreferencesStub = nil
+	fake.setDigestPreferencesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetDigestPreferencesReturnsOnCall(i int, result1 error) {
+	fake.setDigestPreferencesMutex.Lock()
+	defer fake.setDigestPreferencesMutex.Unlock()
+	fake.SetDigestPreferencesStub = nil
+	if fake.setDigestPreferencesReturnsOnCall == nil {
+		fake.setDigestPreferencesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setDigestPreferencesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetItemBackfill(arg1 context.Context, arg2 string, arg3 db.Backfill) error {
+	fake.setItemBackfillMutex.Lock()
+	ret, specificReturn := fake.setItemBackfillReturnsOnCall[len(fake.setItemBackfillArgsForCall)]
+	fake.setItemBackfillArgsForCall = append(fake.setItemBackfillArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.Backfill
+	}{arg1, arg2, arg3})
+	stub := fake.SetItemBackfillStub
+	fakeReturns := fake.setItemBackfillReturns
+	fake.recordInvocation("SetItemBackfill", []interface{}{arg1, arg2, arg3})
+	fake.setItemBackfillMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) SetItemBackfillCallCount() int {
+	fake.setItemBackfillMutex.RLock()
+	defer fake.setItemBackfillMutex.RUnlock()
+	return len(fake.setItemBackfillArgsForCall)
+}
+
+func (fake *FakeDB) SetItemBackfillCalls(stub func(context.Context, string, db.Backfill) error) {
+	fake.setItemBackfillMutex.Lock()
+	defer fake.setItemBackfillMutex.Unlock()
+	fake.SetItemBackfillStub = stub
+}
+
+func (fake *FakeDB) SetItemBackfillArgsForCall(i int) (context.Context, string, db.Backfill) {
+	fake.setItemBackfillMutex.RLock()
+	defer fake.setItemBackfillMutex.RUnlock()
+	argsForCall := fake.setItemBackfillArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) SetItemBackfillReturns(result1 error) {
+	fake.setItemBackfillMutex.Lock()
+	defer fake.setItemBackfillMutex.Unlock()
+	fake.SetItemBackfillStub = nil
+	fake.setItemBackfillReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetItemBackfillReturnsOnCall(i int, result1 error) {
+	fake.setItemBackfillMutex.Lock()
+	defer fake.setItemBackfillMutex.Unlock()
+	fake.SetItemBackfillStub = nil
+	if fake.setItemBackfillReturnsOnCall == nil {
+		fake.setItemBackfillReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setItemBackfillReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetItemStatus(arg1 context.Context, arg2 string, arg3 db.ItemStatus, arg4 string) error {
+	fake.setItemStatusMutex.Lock()
+	ret, specificReturn := fake.setItemStatusReturnsOnCall[len(fake.setItemStatusArgsForCall)]
+	fake.setItemStatusArgsForCall = append(fake.setItemStatusArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 db.ItemStatus
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SetItemStatusStub
+	fakeReturns := fake.setItemStatusReturns
+	fake.recordInvocation("SetItemStatus", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setItemStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) SetItemStatusCallCount() int {
+	fake.setItemStatusMutex.RLock()
+	defer fake.setItemStatusMutex.RUnlock()
+	return len(fake.setItemStatusArgsForCall)
+}
+
+func (fake *FakeDB) SetItemStatusCalls(stub func(context.Context, string, db.ItemStatus, string) error) {
+	fake.setItemStatusMutex.Lock()
+	defer fake.setItemStatusMutex.Unlock()
+	fake.SetItemStatusStub = stub
+}
+
+func (fake *FakeDB) SetItemStatusArgsForCall(i int) (context.Context, string, db.ItemStatus, string) {
+	fake.setItemStatusMutex.RLock()
+	defer fake.setItemStatusMutex.RUnlock()
+	argsForCall := fake.setItemStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) SetItemStatusReturns(result1 error) {
+	fake.setItemStatusMutex.Lock()
+	defer fake.setItemStatusMutex.Unlock()
+	fake.SetItemStatusStub = nil
+	fake.setItemStatusReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetItemStatusReturnsOnCall(i int, result1 error) {
+	fake.setItemStatusMutex.Lock()
+	defer fake.setItemStatusMutex.Unlock()
+	fake.SetItemStatusStub = nil
+	if fake.setItemStatusReturnsOnCall == nil {
+		fake.setItemStatusReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setItemStatusReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetTransactionSplits(arg1 context.Context, arg2 string, arg3 string, arg4 []db.Split) error {
+	var arg4Copy []db.Split
+	if arg4 != nil {
+		arg4Copy = make([]db.Split, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.setTransactionSplitsMutex.Lock()
+	ret, specificReturn := fake.setTransactionSplitsReturnsOnCall[len(fake.setTransactionSplitsArgsForCall)]
+	fake.setTransactionSplitsArgsForCall = append(fake.setTransactionSplitsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 []db.Split
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.SetTransactionSplitsStub
+	fakeReturns := fake.setTransactionSplitsReturns
+	fake.recordInvocation("SetTransactionSplits", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.setTransactionSplitsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) SetTransactionSplitsCallCount() int {
+	fake.setTransactionSplitsMutex.RLock()
+	defer fake.setTransactionSplitsMutex.RUnlock()
+	return len(fake.setTransactionSplitsArgsForCall)
+}
+
+func (fake *FakeDB) SetTransactionSplitsCalls(stub func(context.Context, string, string, []db.Split) error) {
+	fake.setTransactionSplitsMutex.Lock()
+	defer fake.setTransactionSplitsMutex.Unlock()
+	fake.SetTransactionSplitsStub = stub
+}
+
+func (fake *FakeDB) SetTransactionSplitsArgsForCall(i int) (context.Context, string, string, []db.Split) {
+	fake.setTransactionSplitsMutex.RLock()
+	defer fake.setTransactionSplitsMutex.RUnlock()
+	argsForCall := fake.setTransactionSplitsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) SetTransactionSplitsReturns(result1 error) {
+	fake.setTransactionSplitsMutex.Lock()
+	defer fake.setTransactionSplitsMutex.Unlock()
+	fake.SetTransactionSplitsStub = nil
+	fake.setTransactionSplitsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetTransactionSplitsReturnsOnCall(i int, result1 error) {
+	fake.setTransactionSplitsMutex.Lock()
+	defer fake.setTransactionSplitsMutex.Unlock()
+	fake.SetTransactionSplitsStub = nil
+	if fake.setTransactionSplitsReturnsOnCall == nil {
+		fake.setTransactionSplitsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setTransactionSplitsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetTransactionTags(arg1 context.Context, arg2 string, arg3 string, arg4 []string) error {
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.setTransactionTagsMutex.Lock()
+	ret, specificReturn := fake.setTransactionTagsReturnsOnCall[len(fake.setTransactionTagsArgsForCall)]
+	fake.setTransactionTagsArgsForCall = append(fake.setTransactionTagsArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 []string
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.SetTransactionTagsStub
+	fakeReturns := fake.setTransactionTagsReturns
+	fake.recordInvocation("SetTransactionTags", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.setTransactionTagsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) SetTransactionTagsCallCount() int {
+	fake.setTransactionTagsMutex.RLock()
+	defer fake.setTransactionTagsMutex.RUnlock()
+	return len(fake.setTransactionTagsArgsForCall)
+}
+
+func (fake *FakeDB) SetTransactionTagsCalls(stub func(context.Context, string, string, []string) error) {
+	fake.setTransactionTagsMutex.Lock()
+	defer fake.setTransactionTagsMutex.Unlock()
+	fake.SetTransactionTagsStub = stub
+}
+
+func (fake *FakeDB) SetTransactionTagsArgsForCall(i int) (context.Context, string, string, []string) {
+	fake.setTransactionTagsMutex.RLock()
+	defer fake.setTransactionTagsMutex.RUnlock()
+	argsForCall := fake.setTransactionTagsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) SetTransactionTagsReturns(result1 error) {
+	fake.setTransactionTagsMutex.Lock()
+	defer fake.setTransactionTagsMutex.Unlock()
+	fake.SetTransactionTagsStub = nil
+	fake.setTransactionTagsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetTransactionTagsReturnsOnCall(i int, result1 error) {
+	fake.setTransactionTagsMutex.Lock()
+	defer fake.setTransactionTagsMutex.Unlock()
+	fake.SetTransactionTagsStub = nil
+	if fake.setTransactionTagsReturnsOnCall == nil {
+		fake.setTransactionTagsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setTransactionTagsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetTransferStatus(arg1 context.Context, arg2 string, arg3 string, arg4 db.TransferStatus) error {
+	fake.setTransferStatusMutex.Lock()
+	ret, specificReturn := fake.setTransferStatusReturnsOnCall[len(fake.setTransferStatusArgsForCall)]
+	fake.setTransferStatusArgsForCall = append(fake.setTransferStatusArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 db.TransferStatus
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SetTransferStatusStub
+	fakeReturns := fake.setTransferStatusReturns
+	fake.recordInvocation("SetTransferStatus", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setTransferStatusMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) SetTransferStatusCallCount() int {
+	fake.setTransferStatusMutex.RLock()
+	defer fake.setTransferStatusMutex.RUnlock()
+	return len(fake.setTransferStatusArgsForCall)
+}
+
+func (fake *FakeDB) SetTransferStatusCalls(stub func(context.Context, string, string, db.TransferStatus) error) {
+	fake.setTransferStatusMutex.Lock()
+	defer fake.setTransferStatusMutex.Unlock()
+	fake.SetTransferStatusStub = stub
+}
+
+func (fake *FakeDB) SetTransferStatusArgsForCall(i int) (context.Context, string, string, db.TransferStatus) {
+	fake.setTransferStatusMutex.RLock()
+	defer fake.setTransferStatusMutex.RUnlock()
+	argsForCall := fake.setTransferStatusArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) SetTransferStatusReturns(result1 error) {
+	fake.setTransferStatusMutex.Lock()
+	defer fake.setTransferStatusMutex.Unlock()
+	fake.SetTransferStatusStub = nil
+	fake.setTransferStatusReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetTransferStatusReturnsOnCall(i int, result1 error) {
+	fake.setTransferStatusMutex.Lock()
+	defer fake.setTransferStatusMutex.Unlock()
+	fake.SetTransferStatusStub = nil
+	if fake.setTransferStatusReturnsOnCall == nil {
+		fake.setTransferStatusReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setTransferStatusReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetUserActive(arg1 context.Context, arg2 string, arg3 bool) error {
+	fake.setUserActiveMutex.Lock()
+	ret, specificReturn := fake.setUserActiveReturnsOnCall[len(fake.setUserActiveArgsForCall)]
+	fake.setUserActiveArgsForCall = append(fake.setUserActiveArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	stub := fake.SetUserActiveStub
+	fakeReturns := fake.setUserActiveReturns
+	fake.recordInvocation("SetUserActive", []interface{}{arg1, arg2, arg3})
+	fake.setUserActiveMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) SetUserActiveCallCount() int {
+	fake.setUserActiveMutex.RLock()
+	defer fake.setUserActiveMutex.RUnlock()
+	return len(fake.setUserActiveArgsForCall)
+}
+
+func (fake *FakeDB) SetUserActiveCalls(stub func(context.Context, string, bool) error) {
+	fake.setUserActiveMutex.Lock()
+	defer fake.setUserActiveMutex.Unlock()
+	fake.SetUserActiveStub = stub
+}
+
+func (fake *FakeDB) SetUserActiveArgsForCall(i int) (context.Context, string, bool) {
+	fake.setUserActiveMutex.RLock()
+	defer fake.setUserActiveMutex.RUnlock()
+	argsForCall := fake.setUserActiveArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) SetUserActiveReturns(result1 error) {
+	fake.setUserActiveMutex.Lock()
+	defer fake.setUserActiveMutex.Unlock()
+	fake.SetUserActiveStub = nil
+	fake.setUserActiveReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetUserActiveReturnsOnCall(i int, result1 error) {
+	fake.setUserActiveMutex.Lock()
+	defer fake.setUserActiveMutex.Unlock()
+	fake.SetUserActiveStub = nil
+	if fake.setUserActiveReturnsOnCall == nil {
+		fake.setUserActiveReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setUserActiveReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetUserEmail(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.setUserEmailMutex.Lock()
+	ret, specificReturn := fake.setUserEmailReturnsOnCall[len(fake.setUserEmailArgsForCall)]
+	fake.setUserEmailArgsForCall = append(fake.setUserEmailArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.SetUserEmailStub
+	fakeReturns := fake.setUserEmailReturns
+	fake.recordInvocation("SetUserEmail", []interface{}{arg1, arg2, arg3})
+	fake.setUserEmailMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) SetUserEmailCallCount() int {
+	fake.setUserEmailMutex.RLock()
+	defer fake.setUserEmailMutex.RUnlock()
+	return len(fake.setUserEmailArgsForCall)
+}
+
+func (fake *FakeDB) SetUserEmailCalls(stub func(context.Context, string, string) error) {
+	fake.setUserEmailMutex.Lock()
+	defer fake.setUserEmailMutex.Unlock()
+	fake.SetUserEmailStub = stub
+}
+
+func (fake *FakeDB) SetUserEmailArgsForCall(i int) (context.Context, string, string) {
+	fake.setUserEmailMutex.RLock()
+	defer fake.setUserEmailMutex.RUnlock()
+	argsForCall := fake.setUserEmailArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) SetUserEmailReturns(result1 error) {
+	fake.setUserEmailMutex.Lock()
+	defer fake.setUserEmailMutex.Unlock()
+	fake.SetUserEmailStub = nil
+	fake.setUserEmailReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetUserEmailReturnsOnCall(i int, result1 error) {
+	fake.setUserEmailMutex.Lock()
+	defer fake.setUserEmailMutex.Unlock()
+	fake.SetUserEmailStub = nil
+	if fake.setUserEmailReturnsOnCall == nil {
+		fake.setUserEmailReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setUserEmailReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetUserRole(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.setUserRoleMutex.Lock()
+	ret, specificReturn := fake.setUserRoleReturnsOnCall[len(fake.setUserRoleArgsForCall)]
+	fake.setUserRoleArgsForCall = append(fake.setUserRoleArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.SetUserRoleStub
+	fakeReturns := fake.setUserRoleReturns
+	fake.recordInvocation("SetUserRole", []interface{}{arg1, arg2, arg3})
+	fake.setUserRoleMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) SetUserRoleCallCount() int {
+	fake.setUserRoleMutex.RLock()
+	defer fake.setUserRoleMutex.RUnlock()
+	return len(fake.setUserRoleArgsForCall)
+}
+
+func (fake *FakeDB) SetUserRoleCalls(stub func(context.Context, string, string) error) {
+	fake.setUserRoleMutex.Lock()
+	defer fake.setUserRoleMutex.Unlock()
+	fake.SetUserRoleStub = stub
+}
+
+func (fake *FakeDB) SetUserRoleArgsForCall(i int) (context.Context, string, string) {
+	fake.setUserRoleMutex.RLock()
+	defer fake.setUserRoleMutex.RUnlock()
+	argsForCall := fake.setUserRoleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeDB) SetUserRoleReturns(result1 error) {
+	fake.setUserRoleMutex.Lock()
+	defer fake.setUserRoleMutex.Unlock()
+	fake.SetUserRoleStub = nil
+	fake.setUserRoleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) SetUserRoleReturnsOnCall(i int, result1 error) {
+	fake.setUserRoleMutex.Lock()
+	defer fake.setUserRoleMutex.Unlock()
+	fake.SetUserRoleStub = nil
+	if fake.setUserRoleReturnsOnCall == nil {
+		fake.setUserRoleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setUserRoleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) StartItemBackfill(arg1 context.Context, arg2 string, arg3 string, arg4 string) error {
+	fake.startItemBackfillMutex.Lock()
+	ret, specificReturn := fake.startItemBackfillReturnsOnCall[len(fake.startItemBackfillArgsForCall)]
+	fake.startItemBackfillArgsForCall = append(fake.startItemBackfillArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.StartItemBackfillStub
+	fakeReturns := fake.startItemBackfillReturns
+	fake.recordInvocation("StartItemBackfill", []interface{}{arg1, arg2, arg3, arg4})
+	fake.startItemBackfillMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) StartItemBackfillCallCount() int {
+	fake.startItemBackfillMutex.RLock()
+	defer fake.startItemBackfillMutex.RUnlock()
+	return len(fake.startItemBackfillArgsForCall)
+}
+
+func (fake *FakeDB) StartItemBackfillCalls(stub func(context.Context, string, string, string) error) {
+	fake.startItemBackfillMutex.Lock()
+	defer fake.startItemBackfillMutex.Unlock()
+	fake.StartItemBackfillStub = stub
+}
+
+func (fake *FakeDB) StartItemBackfillArgsForCall(i int) (context.Context, string, string, string) {
+	fake.startItemBackfillMutex.RLock()
+	defer fake.startItemBackfillMutex.RUnlock()
+	argsForCall := fake.startItemBackfillArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) StartItemBackfillReturns(result1 error) {
+	fake.startItemBackfillMutex.Lock()
+	defer fake.startItemBackfillMutex.Unlock()
+	fake.StartItemBackfillStub = nil
+	fake.startItemBackfillReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) StartItemBackfillReturnsOnCall(i int, result1 error) {
+	fake.startItemBackfillMutex.Lock()
+	defer fake.startItemBackfillMutex.Unlock()
+	fake.StartItemBackfillStub = nil
+	if fake.startItemBackfillReturnsOnCall == nil {
+		fake.startItemBackfillReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.startItemBackfillReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) StartJobRun(arg1 context.Context, arg2 db.JobRun) (string, error) {
+	fake.startJobRunMutex.Lock()
+	ret, specificReturn := fake.startJobRunReturnsOnCall[len(fake.startJobRunArgsForCall)]
+	fake.startJobRunArgsForCall = append(fake.startJobRunArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.JobRun
+	}{arg1, arg2})
+	stub := fake.StartJobRunStub
+	fakeReturns := fake.startJobRunReturns
+	fake.recordInvocation("StartJobRun", []interface{}{arg1, arg2})
+	fake.startJobRunMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) StartJobRunCallCount() int {
+	fake.startJobRunMutex.RLock()
+	defer fake.startJobRunMutex.RUnlock()
+	return len(fake.startJobRunArgsForCall)
+}
+
+func (fake *FakeDB) StartJobRunCalls(stub func(context.Context, db.JobRun) (string, error)) {
+	fake.startJobRunMutex.Lock()
+	defer fake.startJobRunMutex.Unlock()
+	fake.StartJobRunStub = stub
+}
+
+func (fake *FakeDB) StartJobRunArgsForCall(i int) (context.Context, db.JobRun) {
+	fake.startJobRunMutex.RLock()
+	defer fake.startJobRunMutex.RUnlock()
+	argsForCall := fake.startJobRunArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) StartJobRunReturns(result1 string, result2 error) {
+	fake.startJobRunMutex.Lock()
+	defer fake.startJobRunMutex.Unlock()
+	fake.StartJobRunStub = nil
+	fake.startJobRunReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) StartJobRunReturnsOnCall(i int, result1 string, result2 error) {
+	fake.startJobRunMutex.Lock()
+	defer fake.startJobRunMutex.Unlock()
+	fake.StartJobRunStub = nil
+	if fake.startJobRunReturnsOnCall == nil {
+		fake.startJobRunReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.startJobRunReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) TryAdvisoryLock(arg1 context.Context, arg2 int64) (db.Lock, error) {
+	fake.tryAdvisoryLockMutex.Lock()
+	ret, specificReturn := fake.tryAdvisoryLockReturnsOnCall[len(fake.tryAdvisoryLockArgsForCall)]
+	fake.tryAdvisoryLockArgsForCall = append(fake.tryAdvisoryLockArgsForCall, struct {
+		arg1 context.Context
+		arg2 int64
+	}{arg1, arg2})
+	stub := fake.TryAdvisoryLockStub
+	fakeReturns := fake.tryAdvisoryLockReturns
+	fake.recordInvocation("TryAdvisoryLock", []interface{}{arg1, arg2})
+	fake.tryAdvisoryLockMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) TryAdvisoryLockCallCount() int {
+	fake.tryAdvisoryLockMutex.RLock()
+	defer fake.tryAdvisoryLockMutex.RUnlock()
+	return len(fake.tryAdvisoryLockArgsForCall)
+}
+
+func (fake *FakeDB) TryAdvisoryLockCalls(stub func(context.Context, int64) (db.Lock, error)) {
+	fake.tryAdvisoryLockMutex.Lock()
+	defer fake.tryAdvisoryLockMutex.Unlock()
+	fake.TryAdvisoryLockStub = stub
+}
+
+func (fake *FakeDB) TryAdvisoryLockArgsForCall(i int) (context.Context, int64) {
+	fake.tryAdvisoryLockMutex.RLock()
+	defer fake.tryAdvisoryLockMutex.RUnlock()
+	argsForCall := fake.tryAdvisoryLockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) TryAdvisoryLockReturns(result1 db.Lock, result2 error) {
+	fake.tryAdvisoryLockMutex.Lock()
+	defer fake.tryAdvisoryLockMutex.Unlock()
+	fake.TryAdvisoryLockStub = nil
+	fake.tryAdvisoryLockReturns = struct {
+		result1 db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) TryAdvisoryLockReturnsOnCall(i int, result1 db.Lock, result2 error) {
+	fake.tryAdvisoryLockMutex.Lock()
+	defer fake.tryAdvisoryLockMutex.Unlock()
+	fake.TryAdvisoryLockStub = nil
+	if fake.tryAdvisoryLockReturnsOnCall == nil {
+		fake.tryAdvisoryLockReturnsOnCall = make(map[int]struct {
+			result1 db.Lock
+			result2 error
+		})
+	}
+	fake.tryAdvisoryLockReturnsOnCall[i] = struct {
+		result1 db.Lock
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) UpdateTransactionNote(arg1 context.Context, arg2 string, arg3 string, arg4 string) error {
+	fake.updateTransactionNoteMutex.Lock()
+	ret, specificReturn := fake.updateTransactionNoteReturnsOnCall[len(fake.updateTransactionNoteArgsForCall)]
+	fake.updateTransactionNoteArgsForCall = append(fake.updateTransactionNoteArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateTransactionNoteStub
+	fakeReturns := fake.updateTransactionNoteReturns
+	fake.recordInvocation("UpdateTransactionNote", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateTransactionNoteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDB) UpdateTransactionNoteCallCount() int {
+	fake.updateTransactionNoteMutex.RLock()
+	defer fake.updateTransactionNoteMutex.RUnlock()
+	return len(fake.updateTransactionNoteArgsForCall)
+}
+
+func (fake *FakeDB) UpdateTransactionNoteCalls(stub func(context.Context, string, string, string) error) {
+	fake.updateTransactionNoteMutex.Lock()
+	defer fake.updateTransactionNoteMutex.Unlock()
+	fake.UpdateTransactionNoteStub = stub
+}
+
+func (fake *FakeDB) UpdateTransactionNoteArgsForCall(i int) (context.Context, string, string, string) {
+	fake.updateTransactionNoteMutex.RLock()
+	defer fake.updateTransactionNoteMutex.RUnlock()
+	argsForCall := fake.updateTransactionNoteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDB) UpdateTransactionNoteReturns(result1 error) {
+	fake.updateTransactionNoteMutex.Lock()
+	defer fake.updateTransactionNoteMutex.Unlock()
+	fake.UpdateTransactionNoteStub = nil
+	fake.updateTransactionNoteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) UpdateTransactionNoteReturnsOnCall(i int, result1 error) {
+	fake.updateTransactionNoteMutex.Lock()
+	defer fake.updateTransactionNoteMutex.Unlock()
+	fake.UpdateTransactionNoteStub = nil
+	if fake.updateTransactionNoteReturnsOnCall == nil {
+		fake.updateTransactionNoteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateTransactionNoteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDB) UpsertItem(arg1 context.Context, arg2 db.Item) (string, error) {
+	fake.upsertItemMutex.Lock()
+	ret, specificReturn := fake.upsertItemReturnsOnCall[len(fake.upsertItemArgsForCall)]
+	fake.upsertItemArgsForCall = append(fake.upsertItemArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.Item
+	}{arg1, arg2})
+	stub := fake.UpsertItemStub
+	fakeReturns := fake.upsertItemReturns
+	fake.recordInvocation("UpsertItem", []interface{}{arg1, arg2})
+	fake.upsertItemMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) UpsertItemCallCount() int {
+	fake.upsertItemMutex.RLock()
+	defer fake.upsertItemMutex.RUnlock()
+	return len(fake.upsertItemArgsForCall)
+}
+
+func (fake *FakeDB) UpsertItemCalls(stub func(context.Context, db.Item) (string, error)) {
+	fake.upsertItemMutex.Lock()
+	defer fake.upsertItemMutex.Unlock()
+	fake.UpsertItemStub = stub
+}
+
+func (fake *FakeDB) UpsertItemArgsForCall(i int) (context.Context, db.Item) {
+	fake.upsertItemMutex.RLock()
+	defer fake.upsertItemMutex.RUnlock()
+	argsForCall := fake.upsertItemArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) UpsertItemReturns(result1 string, result2 error) {
+	fake.upsertItemMutex.Lock()
+	defer fake.upsertItemMutex.Unlock()
+	fake.UpsertItemStub = nil
+	fake.upsertItemReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) UpsertItemReturnsOnCall(i int, result1 string, result2 error) {
+	fake.upsertItemMutex.Lock()
+	defer fake.upsertItemMutex.Unlock()
+	fake.UpsertItemStub = nil
+	if fake.upsertItemReturnsOnCall == nil {
+		fake.upsertItemReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.upsertItemReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) UpsertTransaction(arg1 context.Context, arg2 db.Transaction) (string, bool, error) {
+	fake.upsertTransactionMutex.Lock()
+	ret, specificReturn := fake.upsertTransactionReturnsOnCall[len(fake.upsertTransactionArgsForCall)]
+	fake.upsertTransactionArgsForCall = append(fake.upsertTransactionArgsForCall, struct {
+		arg1 context.Context
+		arg2 db.Transaction
+	}{arg1, arg2})
+	stub := fake.UpsertTransactionStub
+	fakeReturns := fake.upsertTransactionReturns
+	fake.recordInvocation("UpsertTransaction", []interface{}{arg1, arg2})
+	fake.upsertTransactionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeDB) UpsertTransactionCallCount() int {
+	fake.upsertTransactionMutex.RLock()
+	defer fake.upsertTransactionMutex.RUnlock()
+	return len(fake.upsertTransactionArgsForCall)
+}
+
+func (fake *FakeDB) UpsertTransactionCalls(stub func(context.Context, db.Transaction) (string, bool, error)) {
+	fake.upsertTransactionMutex.Lock()
+	defer fake.upsertTransactionMutex.Unlock()
+	fake.UpsertTransactionStub = stub
+}
+
+func (fake *FakeDB) UpsertTransactionArgsForCall(i int) (context.Context, db.Transaction) {
+	fake.upsertTransactionMutex.RLock()
+	defer fake.upsertTransactionMutex.RUnlock()
+	argsForCall := fake.upsertTransactionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) UpsertTransactionReturns(result1 string, result2 bool, result3 error) {
+	fake.upsertTransactionMutex.Lock()
+	defer fake.upsertTransactionMutex.Unlock()
+	fake.UpsertTransactionStub = nil
+	fake.upsertTransactionReturns = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) UpsertTransactionReturnsOnCall(i int, result1 string, result2 bool, result3 error) {
+	fake.upsertTransactionMutex.Lock()
+	defer fake.upsertTransactionMutex.Unlock()
+	fake.UpsertTransactionStub = nil
+	if fake.upsertTransactionReturnsOnCall == nil {
+		fake.upsertTransactionReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 bool
+			result3 error
+		})
+	}
+	fake.upsertTransactionReturnsOnCall[i] = struct {
+		result1 string
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeDB) UsePersonalAccessToken(arg1 context.Context, arg2 string) (db.PersonalAccessToken, error) {
+	fake.usePersonalAccessTokenMutex.Lock()
+	ret, specificReturn := fake.usePersonalAccessTokenReturnsOnCall[len(fake.usePersonalAccessTokenArgsForCall)]
+	fake.usePersonalAccessTokenArgsForCall = append(fake.usePersonalAccessTokenArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UsePersonalAccessTokenStub
+	fakeReturns := fake.usePersonalAccessTokenReturns
+	fake.recordInvocation("UsePersonalAccessToken", []interface{}{arg1, arg2})
+	fake.usePersonalAccessTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeDB) UsePersonalAccessTokenCallCount() int {
+	fake.usePersonalAccessTokenMutex.RLock()
+	defer fake.usePersonalAccessTokenMutex.RUnlock()
+	return len(fake.usePersonalAccessTokenArgsForCall)
+}
+
+func (fake *FakeDB) UsePersonalAccessTokenCalls(stub func(context.Context, string) (db.PersonalAccessToken, error)) {
+	fake.usePersonalAccessTokenMutex.Lock()
+	defer fake.usePersonalAccessTokenMutex.Unlock()
+	fake.UsePersonalAccessTokenStub = stub
+}
+
+func (fake *FakeDB) UsePersonalAccessTokenArgsForCall(i int) (context.Context, string) {
+	fake.usePersonalAccessTokenMutex.RLock()
+	defer fake.usePersonalAccessTokenMutex.RUnlock()
+	argsForCall := fake.usePersonalAccessTokenArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDB) UsePersonalAccessTokenReturns(result1 db.PersonalAccessToken, result2 error) {
+	fake.usePersonalAccessTokenMutex.Lock()
+	defer fake.usePersonalAccessTokenMutex.Unlock()
+	fake.UsePersonalAccessTokenStub = nil
+	fake.usePersonalAccessTokenReturns = struct {
+		result1 db.PersonalAccessToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) UsePersonalAccessTokenReturnsOnCall(i int, result1 db.PersonalAccessToken, result2 error) {
+	fake.usePersonalAccessTokenMutex.Lock()
+	defer fake.usePersonalAccessTokenMutex.Unlock()
+	fake.UsePersonalAccessTokenStub = nil
+	if fake.usePersonalAccessTokenReturnsOnCall == nil {
+		fake.usePersonalAccessTokenReturnsOnCall = make(map[int]struct {
+			result1 db.PersonalAccessToken
+			result2 error
+		})
+	}
+	fake.usePersonalAccessTokenReturnsOnCall[i] = struct {
+		result1 db.PersonalAccessToken
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeDB) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.acceptShareMutex.RLock()
+	defer fake.acceptShareMutex.RUnlock()
+	fake.checkUserMutex.RLock()
+	defer fake.checkUserMutex.RUnlock()
+	fake.claimAlertDeliveryMutex.RLock()
+	defer fake.claimAlertDeliveryMutex.RUnlock()
+	fake.claimDueDigestsMutex.RLock()
+	defer fake.claimDueDigestsMutex.RUnlock()
+	fake.claimDueWebhookDeliveriesMutex.RLock()
+	defer fake.claimDueWebhookDeliveriesMutex.RUnlock()
+	fake.claimItemRefreshMutex.RLock()
+	defer fake.claimItemRefreshMutex.RUnlock()
+	fake.configureAccountMutex.RLock()
+	defer fake.configureAccountMutex.RUnlock()
+	fake.createAccountMutex.RLock()
+	defer fake.createAccountMutex.RUnlock()
+	fake.createAlertMutex.RLock()
+	defer fake.createAlertMutex.RUnlock()
+	fake.createAttachmentMutex.RLock()
+	defer fake.createAttachmentMutex.RUnlock()
+	fake.createPersonalAccessTokenMutex.RLock()
+	defer fake.createPersonalAccessTokenMutex.RUnlock()
+	fake.createShareMutex.RLock()
+	defer fake.createShareMutex.RUnlock()
+	fake.createTransferMutex.RLock()
+	defer fake.createTransferMutex.RUnlock()
+	fake.createWebhookDeliveryMutex.RLock()
+	defer fake.createWebhookDeliveryMutex.RUnlock()
+	fake.createWebhookEndpointMutex.RLock()
+	defer fake.createWebhookEndpointMutex.RUnlock()
+	fake.deconfigureAccountMutex.RLock()
+	defer fake.deconfigureAccountMutex.RUnlock()
+	fake.deleteAccountMutex.RLock()
+	defer fake.deleteAccountMutex.RUnlock()
+	fake.deleteAlertMutex.RLock()
+	defer fake.deleteAlertMutex.RUnlock()
+	fake.deleteAttachmentMutex.RLock()
+	defer fake.deleteAttachmentMutex.RUnlock()
+	fake.deleteItemMutex.RLock()
+	defer fake.deleteItemMutex.RUnlock()
+	fake.deleteTransactionByPlaidIDMutex.RLock()
+	defer fake.deleteTransactionByPlaidIDMutex.RUnlock()
+	fake.deleteUserMutex.RLock()
+	defer fake.deleteUserMutex.RUnlock()
+	fake.deleteWebhookEndpointMutex.RLock()
+	defer fake.deleteWebhookEndpointMutex.RUnlock()
+	fake.ensureAccountsTableMutex.RLock()
+	defer fake.ensureAccountsTableMutex.RUnlock()
+	fake.ensureAlertsTablesMutex.RLock()
+	defer fake.ensureAlertsTablesMutex.RUnlock()
+	fake.ensureAttachmentsTableMutex.RLock()
+	defer fake.ensureAttachmentsTableMutex.RUnlock()
+	fake.ensureAuditEventsTableMutex.RLock()
+	defer fake.ensureAuditEventsTableMutex.RUnlock()
+	fake.ensureBalancesTableMutex.RLock()
+	defer fake.ensureBalancesTableMutex.RUnlock()
+	fake.ensureDigestPreferencesTableMutex.RLock()
+	defer fake.ensureDigestPreferencesTableMutex.RUnlock()
+	fake.ensureItemsTableMutex.RLock()
+	defer fake.ensureItemsTableMutex.RUnlock()
+	fake.ensureJobRunsTableMutex.RLock()
+	defer fake.ensureJobRunsTableMutex.RUnlock()
+	fake.ensurePersonalAccessTokensTableMutex.RLock()
+	defer fake.ensurePersonalAccessTokensTableMutex.RUnlock()
+	fake.ensureRolesTablesMutex.RLock()
+	defer fake.ensureRolesTablesMutex.RUnlock()
+	fake.ensureSharesTablesMutex.RLock()
+	defer fake.ensureSharesTablesMutex.RUnlock()
+	fake.ensureSplitsTableMutex.RLock()
+	defer fake.ensureSplitsTableMutex.RUnlock()
+	fake.ensureTagsTablesMutex.RLock()
+	defer fake.ensureTagsTablesMutex.RUnlock()
+	fake.ensureTransactionsTableMutex.RLock()
+	defer fake.ensureTransactionsTableMutex.RUnlock()
+	fake.ensureTransfersTableMutex.RLock()
+	defer fake.ensureTransfersTableMutex.RUnlock()
+	fake.ensureUserIdentitiesTableMutex.RLock()
+	defer fake.ensureUserIdentitiesTableMutex.RUnlock()
+	fake.ensureUsersTableMutex.RLock()
+	defer fake.ensureUsersTableMutex.RUnlock()
+	fake.ensureWebhooksTablesMutex.RLock()
+	defer fake.ensureWebhooksTablesMutex.RUnlock()
+	fake.finishJobRunMutex.RLock()
+	defer fake.finishJobRunMutex.RUnlock()
+	fake.getAccountMutex.RLock()
+	defer fake.getAccountMutex.RUnlock()
+	fake.getAccountsMutex.RLock()
+	defer fake.getAccountsMutex.RUnlock()
+	fake.getAccountsByPlaidItemIDMutex.RLock()
+	defer fake.getAccountsByPlaidItemIDMutex.RUnlock()
+	fake.getAccountsTransactionsPageMutex.RLock()
+	defer fake.getAccountsTransactionsPageMutex.RUnlock()
+	fake.getAlertDeliveriesMutex.RLock()
+	defer fake.getAlertDeliveriesMutex.RUnlock()
+	fake.getAlertsMutex.RLock()
+	defer fake.getAlertsMutex.RUnlock()
+	fake.getAttachmentMutex.RLock()
+	defer fake.getAttachmentMutex.RUnlock()
+	fake.getAttachmentsMutex.RLock()
+	defer fake.getAttachmentsMutex.RUnlock()
+	fake.getAuditEventsMutex.RLock()
+	defer fake.getAuditEventsMutex.RUnlock()
+	fake.getBackfillingItemsMutex.RLock()
+	defer fake.getBackfillingItemsMutex.RUnlock()
+	fake.getBalancesMutex.RLock()
+	defer fake.getBalancesMutex.RUnlock()
+	fake.getCashflowReportMutex.RLock()
+	defer fake.getCashflowReportMutex.RUnlock()
+	fake.getDigestPreferencesMutex.RLock()
+	defer fake.getDigestPreferencesMutex.RUnlock()
+	fake.getItemMutex.RLock()
+	defer fake.getItemMutex.RUnlock()
+	fake.getItemsMutex.RLock()
+	defer fake.getItemsMutex.RUnlock()
+	fake.getItemsSyncedBeforeMutex.RLock()
+	defer fake.getItemsSyncedBeforeMutex.RUnlock()
+	fake.getJobRunMutex.RLock()
+	defer fake.getJobRunMutex.RUnlock()
+	fake.getJobRunsMutex.RLock()
+	defer fake.getJobRunsMutex.RUnlock()
+	fake.getPersonalAccessTokensMutex.RLock()
+	defer fake.getPersonalAccessTokensMutex.RUnlock()
+	fake.getShareMutex.RLock()
+	defer fake.getShareMutex.RUnlock()
+	fake.getSharesMutex.RLock()
+	defer fake.getSharesMutex.RUnlock()
+	fake.getSpendingReportMutex.RLock()
+	defer fake.getSpendingReportMutex.RUnlock()
+	fake.getSplitsForTransactionsMutex.RLock()
+	defer fake.getSplitsForTransactionsMutex.RUnlock()
+	fake.getTagsForTransactionsMutex.RLock()
+	defer fake.getTagsForTransactionsMutex.RUnlock()
+	fake.getTransactionMutex.RLock()
+	defer fake.getTransactionMutex.RUnlock()
+	fake.getTransactionSplitsMutex.RLock()
+	defer fake.getTransactionSplitsMutex.RUnlock()
+	fake.getTransactionTagsMutex.RLock()
+	defer fake.getTransactionTagsMutex.RUnlock()
+	fake.getTransactionsMutex.RLock()
+	defer fake.getTransactionsMutex.RUnlock()
+	fake.getTransactionsByDateRangeMutex.RLock()
+	defer fake.getTransactionsByDateRangeMutex.RUnlock()
+	fake.getTransfersMutex.RLock()
+	defer fake.getTransfersMutex.RUnlock()
+	fake.getUserMutex.RLock()
+	defer fake.getUserMutex.RUnlock()
+	fake.getUserAttachmentsMutex.RLock()
+	defer fake.getUserAttachmentsMutex.RUnlock()
+	fake.getUserForIdentityMutex.RLock()
+	defer fake.getUserForIdentityMutex.RUnlock()
+	fake.getUserPermissionsMutex.RLock()
+	defer fake.getUserPermissionsMutex.RUnlock()
+	fake.getUserTransactionsByDateRangeMutex.RLock()
+	defer fake.getUserTransactionsByDateRangeMutex.RUnlock()
+	fake.getUserWebhookDeliveriesMutex.RLock()
+	defer fake.getUserWebhookDeliveriesMutex.RUnlock()
+	fake.getUsersMutex.RLock()
+	defer fake.getUsersMutex.RUnlock()
+	fake.getWebhookDeliveriesMutex.RLock()
+	defer fake.getWebhookDeliveriesMutex.RUnlock()
+	fake.getWebhookEndpointMutex.RLock()
+	defer fake.getWebhookEndpointMutex.RUnlock()
+	fake.getWebhookEndpointsMutex.RLock()
+	defer fake.getWebhookEndpointsMutex.RUnlock()
+	fake.linkUserIdentityMutex.RLock()
+	defer fake.linkUserIdentityMutex.RUnlock()
+	fake.markItemSyncedMutex.RLock()
+	defer fake.markItemSyncedMutex.RUnlock()
+	fake.pruneJobRunsMutex.RLock()
+	defer fake.pruneJobRunsMutex.RUnlock()
+	fake.pruneWebhookDeliveriesMutex.RLock()
+	defer fake.pruneWebhookDeliveriesMutex.RUnlock()
+	fake.recordAuditEventMutex.RLock()
+	defer fake.recordAuditEventMutex.RUnlock()
+	fake.recordBalanceMutex.RLock()
+	defer fake.recordBalanceMutex.RUnlock()
+	fake.recordWebhookAttemptMutex.RLock()
+	defer fake.recordWebhookAttemptMutex.RUnlock()
+	fake.redeliverWebhookDeliveryMutex.RLock()
+	defer fake.redeliverWebhookDeliveryMutex.RUnlock()
+	fake.registerUserMutex.RLock()
+	defer fake.registerUserMutex.RUnlock()
+	fake.releaseDigestMutex.RLock()
+	defer fake.releaseDigestMutex.RUnlock()
+	fake.revokePersonalAccessTokenMutex.RLock()
+	defer fake.revokePersonalAccessTokenMutex.RUnlock()
+	fake.revokeShareMutex.RLock()
+	defer fake.revokeShareMutex.RUnlock()
+	fake.seedRoleMutex.RLock()
+	defer fake.seedRoleMutex.RUnlock()
+	fake.setAccountHiddenMutex.RLock()
+	defer fake.setAccountHiddenMutex.RUnlock()
+	fake.setAlertDeliveryStatusMutex.RLock()
+	defer fake.setAlertDeliveryStatusMutex.RUnlock()
+	fake.setDigestPreferencesMutex.RLock()
+	defer fake.setDigestPreferencesMutex.RUnlock()
+	fake.setItemBackfillMutex.RLock()
+	defer fake.setItemBackfillMutex.RUnlock()
+	fake.setItemStatusMutex.RLock()
+	defer fake.setItemStatusMutex.RUnlock()
+	fake.setTransactionSplitsMutex.RLock()
+	defer fake.setTransactionSplitsMutex.RUnlock()
+	fake.setTransactionTagsMutex.RLock()
+	defer fake.setTransactionTagsMutex.RUnlock()
+	fake.setTransferStatusMutex.RLock()
+	defer fake.setTransferStatusMutex.RUnlock()
+	fake.setUserActiveMutex.RLock()
+	defer fake.setUserActiveMutex.RUnlock()
+	fake.setUserEmailMutex.RLock()
+	defer fake.setUserEmailMutex.RUnlock()
+	fake.setUserRoleMutex.RLock()
+	defer fake.setUserRoleMutex.RUnlock()
+	fake.startItemBackfillMutex.RLock()
+	defer fake.startItemBackfillMutex.RUnlock()
+	fake.startJobRunMutex.RLock()
+	defer fake.startJobRunMutex.RUnlock()
+	fake.tryAdvisoryLockMutex.RLock()
+	defer fake.tryAdvisoryLockMutex.RUnlock()
+	fake.updateTransactionNoteMutex.RLock()
+	defer fake.updateTransactionNoteMutex.RUnlock()
+	fake.upsertItemMutex.RLock()
+	defer fake.upsertItemMutex.RUnlock()
+	fake.upsertTransactionMutex.RLock()
+	defer fake.upsertTransactionMutex.RUnlock()
+	fake.usePersonalAccessTokenMutex.RLock()
+	defer fake.usePersonalAccessTokenMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDB) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.DB = new(FakeDB)
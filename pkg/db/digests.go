@@ -0,0 +1,147 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//EnsureDigestPreferencesTable creates the digest_preferences table
+func (a *DBAgent) EnsureDigestPreferencesTable(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "digest_preferences"
+(	"user_uuid" UUID REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+
+	"enabled" boolean NOT NULL,
+	"cadence" varchar NOT NULL,
+	"last_sent_at" timestamp,
+	PRIMARY KEY ("user_uuid")
+)`)
+	return errors.Wrapf(err, "failed to ensure digest_preferences table")
+}
+
+//GetDigestPreferences gets the user's digest preferences. Users who
+//never chose are opted out of weekly digests.
+func (a *DBAgent) GetDigestPreferences(ctx context.Context, userUUID string) (DigestPreferences, error) {
+	prefs := DigestPreferences{UserUUID: userUUID}
+	err := a.db.QueryRowContext(ctx, `
+SELECT "enabled", "cadence", "last_sent_at"
+FROM "digest_preferences"
+WHERE "user_uuid" = $1`,
+		userUUID,
+	).Scan(
+		&prefs.Enabled,
+		&prefs.Cadence,
+		&prefs.LastSentAt,
+	)
+	if err == sql.ErrNoRows {
+		prefs.Cadence = DigestCadenceWeekly
+		return prefs, nil
+	}
+	if err != nil {
+		return DigestPreferences{}, errors.Wrapf(err, "failed to get digest preferences for user `%s`", userUUID)
+	}
+	return prefs, nil
+}
+
+//SetDigestPreferences saves the user's digest preferences. The first
+//digest goes out one full period after the user opts in.
+func (a *DBAgent) SetDigestPreferences(ctx context.Context, prefs DigestPreferences) error {
+	_, err := a.db.ExecContext(ctx, `
+INSERT INTO "digest_preferences" (
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"enabled",
+	"cadence",
+	"last_sent_at"
+) VALUES (
+	$1, NOW(), NOW(),
+	$2, $3, NOW()
+) ON CONFLICT ("user_uuid") DO UPDATE SET
+	"modified_at" = NOW(),
+	"enabled" = EXCLUDED."enabled",
+	"cadence" = EXCLUDED."cadence"`,
+		prefs.UserUUID,
+
+		prefs.Enabled,
+		prefs.Cadence,
+	)
+	return errors.Wrapf(err, "failed to set digest preferences for user `%s`", prefs.UserUUID)
+}
+
+//ClaimDueDigests marks up to limit digests as sent and returns them.
+//Concurrent callers never claim the same digest; a digest that then
+//fails to send should be handed back with ReleaseDigest.
+func (a *DBAgent) ClaimDueDigests(ctx context.Context, limit int) ([]DueDigest, error) {
+	rows, err := a.db.QueryContext(ctx, `
+UPDATE "digest_preferences" AS "dp"
+SET
+	"last_sent_at" = NOW(),
+	"modified_at" = NOW()
+FROM (
+	SELECT
+		"digest_preferences"."user_uuid",
+		"digest_preferences"."last_sent_at",
+		"users"."email"
+	FROM "digest_preferences"
+	JOIN "users" ON "users"."uuid" = "digest_preferences"."user_uuid"
+	WHERE
+		"users"."deleted_at" IS NULL
+		AND "digest_preferences"."enabled"
+		AND (
+			"digest_preferences"."last_sent_at" IS NULL
+			OR "digest_preferences"."last_sent_at" <= NOW() - CASE "digest_preferences"."cadence"
+				WHEN 'monthly' THEN INTERVAL '1 month'
+				ELSE INTERVAL '7 days'
+			END
+		)
+	LIMIT $1
+	FOR UPDATE OF "digest_preferences" SKIP LOCKED
+) AS "due"
+WHERE "dp"."user_uuid" = "due"."user_uuid"
+RETURNING "dp"."user_uuid", "due"."email", "dp"."cadence", "due"."last_sent_at"`,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to claim due digests")
+	}
+	defer rows.Close()
+
+	digests := []DueDigest{}
+	for rows.Next() {
+		var digest DueDigest
+		err := rows.Scan(
+			&digest.UserUUID,
+			&digest.Email,
+			&digest.Cadence,
+			&digest.PreviousSentAt,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan due digests")
+		}
+		digests = append(digests, digest)
+	}
+
+	return digests, errors.Wrapf(rows.Err(), "failed to scan due digests")
+}
+
+//ReleaseDigest hands back a claimed digest that couldn't be sent, so
+//that it's retried
+func (a *DBAgent) ReleaseDigest(ctx context.Context, userUUID string, previousSentAt *time.Time) error {
+	_, err := a.db.ExecContext(ctx, `
+UPDATE "digest_preferences"
+SET
+	"last_sent_at" = $1,
+	"modified_at" = NOW()
+WHERE "user_uuid" = $2`,
+		previousSentAt,
+		userUUID,
+	)
+	return errors.Wrapf(err, "failed to release digest for user `%s`", userUUID)
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+)
+
+//ErrNoSuchUser indicates that no registered user matches
+var ErrNoSuchUser = errors.New("no such user")
+
+//EnsureUserIdentitiesTable creates the user_identities table, which
+//links the subjects of an OpenID Connect provider to registered users
+func (a *DBAgent) EnsureUserIdentitiesTable(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "user_identities"
+(	"issuer" varchar NOT NULL,
+	"subject" varchar NOT NULL,
+	"user_uuid" UUID NOT NULL REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	PRIMARY KEY ("issuer", "subject")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure user_identities table")
+	}
+	return nil
+}
+
+//GetUserForIdentity finds the user linked to a provider's subject
+func (a *DBAgent) GetUserForIdentity(ctx context.Context, issuer string, subject string) (string, error) {
+	var userUUID string
+	err := a.db.QueryRowContext(ctx, `
+SELECT "user_identities"."user_uuid"
+FROM "user_identities"
+JOIN "users" ON "users"."uuid" = "user_identities"."user_uuid"
+WHERE
+	"user_identities"."issuer" = $1 AND
+	"user_identities"."subject" = $2 AND
+	"users"."deleted_at" IS NULL`,
+		issuer, subject,
+	).Scan(&userUUID)
+	if err == sql.ErrNoRows {
+		return "", ErrNoSuchUser
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to get user for identity `%s` from `%s`", subject, issuer)
+	}
+	return userUUID, nil
+}
+
+//LinkUserIdentity links a provider's subject to the registered user with
+//the given email, which the provider must have verified. If the subject
+//is already linked, the existing user is kept.
+func (a *DBAgent) LinkUserIdentity(ctx context.Context, issuer string, subject string, email string) (string, error) {
+	var userUUID string
+	err := a.db.QueryRowContext(ctx, `
+INSERT INTO "user_identities" (
+	"issuer",
+	"subject",
+	"user_uuid",
+	"created_at"
+)
+SELECT $1, $2, "uuid", NOW()
+FROM "users"
+WHERE
+	lower("email") = lower($3) AND
+	"deleted_at" IS NULL
+ORDER BY "created_at"
+LIMIT 1
+ON CONFLICT ("issuer", "subject") DO UPDATE SET "user_uuid" = "user_identities"."user_uuid"
+RETURNING "user_uuid"`,
+		issuer, subject, email,
+	).Scan(&userUUID)
+	if err == sql.ErrNoRows {
+		return "", ErrNoSuchUser
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to link identity `%s` from `%s`", subject, issuer)
+	}
+	return userUUID, nil
+}
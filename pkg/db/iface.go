@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -10,25 +11,142 @@ import (
 //ErrBadToken indicates that an invalid pagination token has been provided
 var ErrBadToken = errors.New("bad pagination token")
 
+//uniqueViolation is the Postgres error code for a unique constraint failure
+const uniqueViolation = "23505"
+
 //DB is the minimal database interface to back the app
 //go:generate counterfeiter . DB
 type DB interface {
 	EnsureUsersTable(ctx context.Context) error
 	EnsureAccountsTable(ctx context.Context) error
+	EnsureItemsTable(ctx context.Context) error
 	EnsureTransactionsTable(ctx context.Context) error
+	EnsureBalancesTable(ctx context.Context) error
+	EnsureTagsTables(ctx context.Context) error
+	EnsureAttachmentsTable(ctx context.Context) error
+	EnsureSplitsTable(ctx context.Context) error
+	EnsureTransfersTable(ctx context.Context) error
+	EnsureAlertsTables(ctx context.Context) error
+	EnsureWebhooksTables(ctx context.Context) error
+	EnsureDigestPreferencesTable(ctx context.Context) error
+	EnsureJobRunsTable(ctx context.Context) error
+	EnsurePersonalAccessTokensTable(ctx context.Context) error
+	EnsureUserIdentitiesTable(ctx context.Context) error
+	EnsureRolesTables(ctx context.Context) error
+	EnsureSharesTables(ctx context.Context) error
+	EnsureAuditEventsTable(ctx context.Context) error
 
-	RegisterUser(ctx context.Context, uuid string, email string) error
+	RegisterUser(ctx context.Context, uuid string, email string, role string) error
 	CheckUser(ctx context.Context, uuid string) (bool, error)
+	GetUsers(ctx context.Context, page UserPage) ([]User, error)
+	GetUser(ctx context.Context, uuid string) (User, error)
+	SetUserEmail(ctx context.Context, uuid string, email string) error
+	SetUserActive(ctx context.Context, uuid string, active bool) error
+	DeleteUser(ctx context.Context, uuid string) ([]Attachment, error)
+	SeedRole(ctx context.Context, role string, permissions []string) error
+	GetUserPermissions(ctx context.Context, uuid string) (string, []string, error)
+	SetUserRole(ctx context.Context, uuid string, role string) error
+	GetUserForIdentity(ctx context.Context, issuer string, subject string) (string, error)
+	LinkUserIdentity(ctx context.Context, issuer string, subject string, email string) (string, error)
+
+	CreatePersonalAccessToken(ctx context.Context, token PersonalAccessToken) (string, error)
+	GetPersonalAccessTokens(ctx context.Context, userUUID string) ([]PersonalAccessToken, error)
+	UsePersonalAccessToken(ctx context.Context, tokenHash string) (PersonalAccessToken, error)
+	RevokePersonalAccessToken(ctx context.Context, userUUID string, uuid string) error
+
+	CreateShare(ctx context.Context, share Share) (string, error)
+	GetShares(ctx context.Context, userUUID string) ([]Share, error)
+	GetShare(ctx context.Context, userUUID string, uuid string) (Share, error)
+	AcceptShare(ctx context.Context, userUUID string, uuid string) error
+	RevokeShare(ctx context.Context, userUUID string, uuid string) error
+
+	RecordAuditEvent(ctx context.Context, event AuditEvent) (string, error)
+	GetAuditEvents(ctx context.Context, filter AuditEventFilter) ([]AuditEvent, error)
+
+	UpsertItem(ctx context.Context, item Item) (string, error)
+	GetItems(ctx context.Context, userUUID string) ([]Item, error)
+	GetItem(ctx context.Context, userUUID string, uuid string) (Item, error)
+	SetItemStatus(ctx context.Context, plaidItemID string, status ItemStatus, errorCode string) error
+	MarkItemSynced(ctx context.Context, plaidItemID string) error
+	GetItemsSyncedBefore(ctx context.Context, before time.Time) ([]Item, error)
+	ClaimItemRefresh(ctx context.Context, userUUID string, uuid string, interval time.Duration) (bool, error)
+	StartItemBackfill(ctx context.Context, plaidItemID string, startDate string, endDate string) error
+	GetBackfillingItems(ctx context.Context) ([]Item, error)
+	SetItemBackfill(ctx context.Context, uuid string, backfill Backfill) error
+	DeleteItem(ctx context.Context, userUUID string, uuid string) error
 
 	CreateAccount(ctx context.Context, userUUID string, acct Account) (string, error)
 	GetAccountsByPlaidItemID(ctx context.Context, itemID string) ([]Account, error)
 	GetAccounts(ctx context.Context, userUUID string) ([]Account, error)
+	GetAccount(ctx context.Context, userUUID string, uuid string) (Account, error)
+	SetAccountHidden(ctx context.Context, userUUID string, uuid string, hidden bool) error
 	ConfigureAccount(ctx context.Context, userUUID string, uuid string) error
 	DeconfigureAccount(ctx context.Context, userUUID string, uuid string) error
+	DeleteAccount(ctx context.Context, userUUID string, uuid string) error
 
 	UpsertTransaction(ctx context.Context, transaction Transaction) (string, bool, error)
-	DeleteTransactionByPlaidID(ctx context.Context, plaidTransactionID string) error
-	GetTransactions(ctx context.Context, accountUUID string, token string) ([]Transaction, error)
+	DeleteTransactionByPlaidID(ctx context.Context, plaidTransactionID string) (Transaction, error)
+	GetTransactions(ctx context.Context, userUUID string, accountUUID string) ([]Transaction, error)
+	GetTransactionsByDateRange(ctx context.Context, userUUID string, accountUUID string, startDate string, endDate string) ([]Transaction, error)
+	GetUserTransactionsByDateRange(ctx context.Context, userUUID string, startDate string, endDate string) ([]Transaction, error)
+	GetAccountsTransactionsPage(ctx context.Context, userUUID string, accountUUIDs []string, page TransactionPage) (map[string][]Transaction, error)
+	GetTransaction(ctx context.Context, userUUID string, uuid string) (Transaction, error)
+	UpdateTransactionNote(ctx context.Context, userUUID string, uuid string, note string) error
+
+	SetTransactionTags(ctx context.Context, userUUID string, transactionUUID string, tags []string) error
+	GetTransactionTags(ctx context.Context, userUUID string, transactionUUID string) ([]string, error)
+	GetTagsForTransactions(ctx context.Context, userUUID string, transactionUUIDs []string) (map[string][]string, error)
+
+	SetTransactionSplits(ctx context.Context, userUUID string, transactionUUID string, splits []Split) error
+	GetTransactionSplits(ctx context.Context, userUUID string, transactionUUID string) ([]Split, error)
+	GetSplitsForTransactions(ctx context.Context, userUUID string, transactionUUIDs []string) (map[string][]Split, error)
+
+	CreateTransfer(ctx context.Context, transfer Transfer) (string, error)
+	GetTransfers(ctx context.Context, userUUID string) ([]Transfer, error)
+	SetTransferStatus(ctx context.Context, userUUID string, uuid string, status TransferStatus) error
+
+	CreateAttachment(ctx context.Context, attachment Attachment) (string, error)
+	GetAttachment(ctx context.Context, userUUID string, uuid string) (Attachment, error)
+	GetAttachments(ctx context.Context, userUUID string, transactionUUID string) ([]Attachment, error)
+	GetUserAttachments(ctx context.Context, userUUID string) ([]Attachment, error)
+	DeleteAttachment(ctx context.Context, userUUID string, uuid string) error
+
+	GetCashflowReport(ctx context.Context, userUUID string, group ReportGroup, startDate string, endDate string) ([]ReportRow, error)
+	GetSpendingReport(ctx context.Context, userUUID string, group ReportGroup, startDate string, endDate string) ([]ReportRow, error)
+
+	CreateAlert(ctx context.Context, alert Alert) (string, error)
+	GetAlerts(ctx context.Context, userUUID string) ([]Alert, error)
+	DeleteAlert(ctx context.Context, userUUID string, uuid string) error
+	ClaimAlertDelivery(ctx context.Context, delivery AlertDelivery) (string, error)
+	SetAlertDeliveryStatus(ctx context.Context, uuid string, status AlertDeliveryStatus, errMessage string) error
+	GetAlertDeliveries(ctx context.Context, userUUID string, alertUUID string) ([]AlertDelivery, error)
+
+	CreateWebhookEndpoint(ctx context.Context, endpoint WebhookEndpoint) (string, error)
+	GetWebhookEndpoints(ctx context.Context, userUUID string) ([]WebhookEndpoint, error)
+	GetWebhookEndpoint(ctx context.Context, uuid string) (WebhookEndpoint, error)
+	DeleteWebhookEndpoint(ctx context.Context, userUUID string, uuid string) error
+	CreateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) (string, error)
+	ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error)
+	RecordWebhookAttempt(ctx context.Context, uuid string, status WebhookDeliveryStatus, statusCode int, errMessage string, nextAttemptAt *time.Time) error
+	GetWebhookDeliveries(ctx context.Context, userUUID string, endpointUUID string, limit int) ([]WebhookDelivery, error)
+	GetUserWebhookDeliveries(ctx context.Context, userUUID string, limit int) ([]WebhookDelivery, error)
+	RedeliverWebhookDelivery(ctx context.Context, userUUID string, endpointUUID string, uuid string) error
+	PruneWebhookDeliveries(ctx context.Context, before time.Time) (int64, error)
+
+	GetDigestPreferences(ctx context.Context, userUUID string) (DigestPreferences, error)
+	SetDigestPreferences(ctx context.Context, prefs DigestPreferences) error
+	ClaimDueDigests(ctx context.Context, limit int) ([]DueDigest, error)
+	ReleaseDigest(ctx context.Context, userUUID string, previousSentAt *time.Time) error
+
+	StartJobRun(ctx context.Context, run JobRun) (string, error)
+	GetJobRun(ctx context.Context, userUUID string, uuid string) (JobRun, error)
+	FinishJobRun(ctx context.Context, uuid string, status JobRunStatus, errMessage string) error
+	GetJobRuns(ctx context.Context, name string, limit int) ([]JobRun, error)
+	PruneJobRuns(ctx context.Context, before time.Time) (int64, error)
+	TryAdvisoryLock(ctx context.Context, key int64) (Lock, error)
+
+	RecordBalance(ctx context.Context, balance Balance) (string, error)
+	GetBalances(ctx context.Context, userUUID string, accountUUID string) ([]Balance, error)
 }
 
 //DBAgent implements DB using a *sql.DB
@@ -55,9 +173,69 @@ func EnsureTables(ctx context.Context, db DB) error {
 	if err != nil {
 		return err
 	}
+	err = db.EnsureItemsTable(ctx)
+	if err != nil {
+		return err
+	}
 	err = db.EnsureTransactionsTable(ctx)
 	if err != nil {
 		return err
 	}
+	err = db.EnsureBalancesTable(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsureTagsTables(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsureAttachmentsTable(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsureSplitsTable(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsureTransfersTable(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsureAlertsTables(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsureWebhooksTables(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsureDigestPreferencesTable(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsureJobRunsTable(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsurePersonalAccessTokensTable(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsureUserIdentitiesTable(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsureRolesTables(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsureSharesTables(ctx)
+	if err != nil {
+		return err
+	}
+	err = db.EnsureAuditEventsTable(ctx)
+	if err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,441 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//ErrNoSuchItem indicates that an item doesn't exist or isn't owned by
+//the user
+var ErrNoSuchItem = errors.New("no such item")
+
+//EnsureItemsTable creates the items table, and adds an item for each
+//Plaid item that accounts were linked from before it existed
+func (a *DBAgent) EnsureItemsTable(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "items"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"user_uuid" UUID REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"plaid_item_id" varchar NOT NULL,
+	"institution_name" varchar NOT NULL,
+	"status" varchar NOT NULL,
+	"error_code" varchar NOT NULL DEFAULT '',
+	"last_synced_at" timestamp,
+	PRIMARY KEY ("uuid"),
+	UNIQUE ("plaid_item_id")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure items table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `ALTER TABLE "items" ADD COLUMN IF NOT EXISTS "refresh_requested_at" timestamp`)
+	if err != nil {
+		return errors.Wrap(err, "failed to add refresh_requested_at column to items")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+ALTER TABLE "items"
+	ADD COLUMN IF NOT EXISTS "backfill_status" varchar NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS "backfill_start_date" varchar NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS "backfill_end_date" varchar NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS "backfill_oldest_date" varchar NOT NULL DEFAULT '',
+	ADD COLUMN IF NOT EXISTS "backfill_imported" integer NOT NULL DEFAULT 0,
+	ADD COLUMN IF NOT EXISTS "backfill_error" varchar NOT NULL DEFAULT ''`)
+	if err != nil {
+		return errors.Wrap(err, "failed to add backfill columns to items")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "items_user_uuid_idx" ON items USING btree(user_uuid)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure user_uuid index for items")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+INSERT INTO "items" (
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"plaid_item_id",
+	"institution_name",
+	"status"
+)
+SELECT DISTINCT ON ("plaid_item_id")
+	"user_uuid", NOW(), NOW(),
+	"plaid_item_id", "plaid_institution_name", 'ok'
+FROM "accounts"
+WHERE
+	"deleted_at" IS NULL
+	AND NOT "manual"
+	AND "plaid_item_id" <> ''
+ON CONFLICT DO NOTHING`)
+	return errors.Wrap(err, "failed to add items for existing accounts")
+}
+
+//UpsertItem records a Plaid item that the user has linked, or relinked
+func (a *DBAgent) UpsertItem(ctx context.Context, item Item) (string, error) {
+	row := a.db.QueryRowContext(ctx, `
+INSERT INTO "items" (
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"plaid_item_id",
+	"institution_name",
+	"status"
+) VALUES (
+	$1, NOW(), NOW(),
+	$2, $3, $4
+) ON CONFLICT ("plaid_item_id") DO UPDATE SET
+	"modified_at" = NOW(),
+	"deleted_at" = NULL,
+	"institution_name" = EXCLUDED."institution_name",
+	"status" = EXCLUDED."status",
+	"error_code" = ''
+RETURNING "uuid"`,
+		item.UserUUID,
+
+		item.PlaidItemID,
+		item.InstitutionName,
+		ItemStatusOK,
+	)
+
+	var uuid string
+	err := row.Scan(&uuid)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to upsert into items table")
+	}
+	return uuid, nil
+}
+
+const itemFieldNameList = `
+	"uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"plaid_item_id",
+	"institution_name",
+	"status",
+	"error_code",
+	"last_synced_at",
+	"refresh_requested_at",
+
+	"backfill_status",
+	"backfill_start_date",
+	"backfill_end_date",
+	"backfill_oldest_date",
+	"backfill_imported",
+	"backfill_error"
+`
+
+//scanItem scans an item, followed by any extra columns into extra
+func scanItem(row scanner, extra ...interface{}) (Item, error) {
+	var item Item
+	err := row.Scan(append([]interface{}{
+		&item.UUID,
+		&item.UserUUID,
+		&item.CreatedAt,
+		&item.ModifiedAt,
+
+		&item.PlaidItemID,
+		&item.InstitutionName,
+		&item.Status,
+		&item.ErrorCode,
+		&item.LastSyncedAt,
+		&item.RefreshRequestedAt,
+
+		&item.Backfill.Status,
+		&item.Backfill.StartDate,
+		&item.Backfill.EndDate,
+		&item.Backfill.OldestDate,
+		&item.Backfill.Imported,
+		&item.Backfill.Error,
+	}, extra...)...)
+	item.Backfill.Percent = item.Backfill.percent()
+	return item, err
+}
+
+//GetItems lists the items the user owns or has been shared
+func (a *DBAgent) GetItems(ctx context.Context, userUUID string) ([]Item, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s, "level" FROM "items"
+JOIN (SELECT "item_uuid", "level" FROM "item_access" WHERE "user_uuid" = $1) AS "access"
+	ON "access"."item_uuid" = "items"."uuid"
+WHERE
+	"deleted_at" IS NULL
+ORDER BY "created_at"`, itemFieldNameList),
+		userUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get items from table")
+	}
+	defer rows.Close()
+
+	items := []Item{}
+	var access ShareLevel
+	for rows.Next() {
+		item, err := scanItem(rows, &access)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan items for user `%s`", userUUID)
+		}
+		item.Access = access
+		items = append(items, item)
+	}
+
+	return items, errors.Wrapf(rows.Err(), "failed to scan items for user `%s`", userUUID)
+}
+
+//GetItem gets an item the user owns or has been shared
+func (a *DBAgent) GetItem(ctx context.Context, userUUID string, uuid string) (Item, error) {
+	var access ShareLevel
+	item, err := scanItem(a.db.QueryRowContext(ctx, fmt.Sprintf(`
+SELECT %s, "level" FROM "items"
+JOIN (SELECT "item_uuid", "level" FROM "item_access" WHERE "user_uuid" = $1) AS "access"
+	ON "access"."item_uuid" = "items"."uuid"
+WHERE
+	"deleted_at" IS NULL
+	AND "uuid" = $2`, itemFieldNameList),
+		userUUID,
+		uuid,
+	), &access)
+	item.Access = access
+	if err == sql.ErrNoRows {
+		return Item{}, ErrNoSuchItem
+	}
+	if err != nil {
+		return Item{}, errors.Wrapf(err, "failed to get item `%s`", uuid)
+	}
+	return item, nil
+}
+
+//SetItemStatus records the health of an item's connection, as reported
+//by Plaid
+func (a *DBAgent) SetItemStatus(ctx context.Context, plaidItemID string, status ItemStatus, errorCode string) error {
+	_, err := a.db.ExecContext(ctx, `
+UPDATE "items"
+SET
+	"status" = $1,
+	"error_code" = $2,
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "plaid_item_id" = $3`,
+		status,
+		errorCode,
+		plaidItemID,
+	)
+	return errors.Wrapf(err, "failed to update status of plaid item `%s`", plaidItemID)
+}
+
+//MarkItemSynced records that transactions were just pulled for an item,
+//which also shows that its connection is healthy
+func (a *DBAgent) MarkItemSynced(ctx context.Context, plaidItemID string) error {
+	_, err := a.db.ExecContext(ctx, `
+UPDATE "items"
+SET
+	"status" = $1,
+	"error_code" = '',
+	"last_synced_at" = NOW(),
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "plaid_item_id" = $2`,
+		ItemStatusOK,
+		plaidItemID,
+	)
+	return errors.Wrapf(err, "failed to mark plaid item `%s` as synced", plaidItemID)
+}
+
+//GetItemsSyncedBefore lists every user's healthy items that haven't
+//synced since a time, including items that never synced
+func (a *DBAgent) GetItemsSyncedBefore(ctx context.Context, before time.Time) ([]Item, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "items"
+WHERE
+	"deleted_at" IS NULL
+	AND "status" = $1
+	AND ("last_synced_at" IS NULL OR "last_synced_at" < $2)
+ORDER BY "last_synced_at" NULLS FIRST`, itemFieldNameList),
+		ItemStatusOK,
+		before,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get items from table")
+	}
+	defer rows.Close()
+
+	items := []Item{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan items")
+		}
+		items = append(items, item)
+	}
+
+	return items, errors.Wrapf(rows.Err(), "failed to scan items")
+}
+
+//ClaimItemRefresh records that the user asked to refresh an item they
+//own or can edit, unless someone already did within the interval, in
+//which case it returns false
+func (a *DBAgent) ClaimItemRefresh(ctx context.Context, userUUID string, uuid string, interval time.Duration) (bool, error) {
+	res, err := a.db.ExecContext(ctx, `
+UPDATE "items"
+SET
+	"refresh_requested_at" = NOW(),
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "uuid" IN (SELECT "item_uuid" FROM "item_access" WHERE "user_uuid" = $1 AND "level" <> 'read')
+	AND "uuid" = $2
+	AND (
+		"refresh_requested_at" IS NULL
+		OR "refresh_requested_at" <= NOW() - make_interval(secs => $3)
+	)`,
+		userUUID,
+		uuid,
+		interval.Seconds(),
+	)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to claim refresh of item `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to claim refresh of item `%s`", uuid)
+	}
+	return n > 0, nil
+}
+
+//StartItemBackfill begins importing an item's history between two
+//dates, unless an import is already underway or done
+func (a *DBAgent) StartItemBackfill(ctx context.Context, plaidItemID string, startDate string, endDate string) error {
+	_, err := a.db.ExecContext(ctx, `
+UPDATE "items"
+SET
+	"backfill_status" = $1,
+	"backfill_start_date" = $2,
+	"backfill_end_date" = $3,
+	"backfill_oldest_date" = '',
+	"backfill_imported" = 0,
+	"backfill_error" = '',
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "plaid_item_id" = $4
+	AND "backfill_status" IN ($5, $6)`,
+		BackfillStatusInProgress,
+		startDate,
+		endDate,
+		plaidItemID,
+		BackfillStatusNone,
+		BackfillStatusFailed,
+	)
+	return errors.Wrapf(err, "failed to start backfill of plaid item `%s`", plaidItemID)
+}
+
+//GetBackfillingItems lists every user's items whose history import is
+//underway
+func (a *DBAgent) GetBackfillingItems(ctx context.Context) ([]Item, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "items"
+WHERE
+	"deleted_at" IS NULL
+	AND "backfill_status" = $1
+ORDER BY "modified_at"`, itemFieldNameList),
+		BackfillStatusInProgress,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get items from table")
+	}
+	defer rows.Close()
+
+	items := []Item{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan items")
+		}
+		items = append(items, item)
+	}
+
+	return items, errors.Wrapf(rows.Err(), "failed to scan items")
+}
+
+//SetItemBackfill records the progress of an item's history import
+func (a *DBAgent) SetItemBackfill(ctx context.Context, uuid string, backfill Backfill) error {
+	_, err := a.db.ExecContext(ctx, `
+UPDATE "items"
+SET
+	"backfill_status" = $1,
+	"backfill_oldest_date" = $2,
+	"backfill_imported" = $3,
+	"backfill_error" = $4,
+	"modified_at" = NOW()
+WHERE "uuid" = $5`,
+		backfill.Status,
+		backfill.OldestDate,
+		backfill.Imported,
+		backfill.Error,
+		uuid,
+	)
+	return errors.Wrapf(err, "failed to record backfill progress of item `%s`", uuid)
+}
+
+//DeleteItem removes one of the user's items along with its accounts
+func (a *DBAgent) DeleteItem(ctx context.Context, userUUID string, uuid string) error {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to start transaction")
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var plaidItemID string
+	err = tx.QueryRowContext(ctx, `
+UPDATE "items"
+SET
+	"deleted_at" = NOW(),
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+	AND "uuid" = $2
+RETURNING "plaid_item_id"`,
+		userUUID,
+		uuid,
+	).Scan(&plaidItemID)
+	if err == sql.ErrNoRows {
+		return ErrNoSuchItem
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete item `%s`", uuid)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+UPDATE "accounts"
+SET
+	"deleted_at" = NOW(),
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+	AND "plaid_item_id" = $2`,
+		userUUID,
+		plaidItemID,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete accounts of item `%s`", uuid)
+	}
+
+	return errors.Wrapf(tx.Commit(), "failed to delete item `%s`", uuid)
+}
@@ -0,0 +1,251 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//ErrNoSuchJobRun indicates that a job run doesn't exist or wasn't run
+//on the user's behalf
+var ErrNoSuchJobRun = errors.New("no such job run")
+
+//ErrLockHeld indicates that another session holds an advisory lock
+var ErrLockHeld = errors.New("lock is held by another session")
+
+//EnsureJobRunsTable creates the job_runs table
+func (a *DBAgent) EnsureJobRunsTable(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "job_runs"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"name" varchar NOT NULL,
+	"status" varchar NOT NULL,
+	"finished_at" timestamp,
+	"error" varchar NOT NULL DEFAULT '',
+	PRIMARY KEY ("uuid")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure job_runs table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+ALTER TABLE "job_runs"
+	ADD COLUMN IF NOT EXISTS "user_uuid" UUID REFERENCES users(uuid),
+	ADD COLUMN IF NOT EXISTS "item_uuid" UUID REFERENCES items(uuid)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to add user_uuid and item_uuid columns to job_runs")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "job_runs_name_created_at_idx" ON job_runs USING btree(name, created_at)`)
+	return errors.Wrap(err, "failed to ensure name index for job_runs")
+}
+
+//StartJobRun records that a job has started running
+func (a *DBAgent) StartJobRun(ctx context.Context, run JobRun) (string, error) {
+	row := a.db.QueryRowContext(ctx, `
+INSERT INTO "job_runs" (
+	"created_at",
+	"modified_at",
+
+	"user_uuid",
+	"item_uuid",
+	"name",
+	"status"
+) VALUES (
+	NOW(), NOW(),
+	NULLIF($1, '')::UUID, NULLIF($2, '')::UUID, $3, $4
+) RETURNING "uuid"`,
+		run.UserUUID,
+		run.ItemUUID,
+		run.Name,
+		JobRunStatusRunning,
+	)
+
+	var uuid string
+	err := row.Scan(&uuid)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to insert into job_runs table")
+	}
+	return uuid, nil
+}
+
+//FinishJobRun records the outcome of a job run
+func (a *DBAgent) FinishJobRun(ctx context.Context, uuid string, status JobRunStatus, errMessage string) error {
+	_, err := a.db.ExecContext(ctx, `
+UPDATE "job_runs"
+SET
+	"status" = $1,
+	"error" = $2,
+	"finished_at" = NOW(),
+	"modified_at" = NOW()
+WHERE "uuid" = $3`,
+		status,
+		errMessage,
+		uuid,
+	)
+	return errors.Wrapf(err, "failed to finish job run `%s`", uuid)
+}
+
+const jobRunFieldNameList = `
+	"uuid",
+	"created_at",
+	"modified_at",
+
+	COALESCE("user_uuid"::varchar, ''),
+	COALESCE("item_uuid"::varchar, ''),
+	"name",
+	"status",
+	"finished_at",
+	"error"
+`
+
+func scanJobRun(row scanner) (JobRun, error) {
+	var run JobRun
+	err := row.Scan(
+		&run.UUID,
+		&run.CreatedAt,
+		&run.ModifiedAt,
+
+		&run.UserUUID,
+		&run.ItemUUID,
+		&run.Name,
+		&run.Status,
+		&run.FinishedAt,
+		&run.Error,
+	)
+	return run, err
+}
+
+//GetJobRun gets a job run that was started on the user's behalf
+func (a *DBAgent) GetJobRun(ctx context.Context, userUUID string, uuid string) (JobRun, error) {
+	run, err := scanJobRun(a.db.QueryRowContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "job_runs"
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+	AND "uuid" = $2`, jobRunFieldNameList),
+		userUUID,
+		uuid,
+	))
+	if err == sql.ErrNoRows {
+		return JobRun{}, ErrNoSuchJobRun
+	}
+	if err != nil {
+		return JobRun{}, errors.Wrapf(err, "failed to get job run `%s`", uuid)
+	}
+	return run, nil
+}
+
+//GetJobRuns lists recent job runs, most recent first. An empty name
+//lists runs of every job.
+func (a *DBAgent) GetJobRuns(ctx context.Context, name string, limit int) ([]JobRun, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "job_runs"
+WHERE
+	"deleted_at" IS NULL
+	AND ($1 = '' OR "name" = $1)
+ORDER BY "created_at" DESC
+LIMIT $2`, jobRunFieldNameList),
+		name,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get job runs from table")
+	}
+	defer rows.Close()
+
+	runs := []JobRun{}
+	for rows.Next() {
+		run, err := scanJobRun(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan job runs")
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, errors.Wrapf(rows.Err(), "failed to scan job runs")
+}
+
+//PruneJobRuns deletes the history of runs that finished before a time
+func (a *DBAgent) PruneJobRuns(ctx context.Context, before time.Time) (int64, error) {
+	result, err := a.db.ExecContext(ctx, `
+DELETE FROM "job_runs"
+WHERE
+	"finished_at" IS NOT NULL
+	AND "finished_at" < $1`,
+		before,
+	)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to prune job runs")
+	}
+	return result.RowsAffected()
+}
+
+//Lock is a held Postgres advisory lock. It lasts until it's released or
+//its database session ends.
+type Lock interface {
+	//Check fails if the session holding the lock has been lost
+	Check(ctx context.Context) error
+	Release() error
+}
+
+type advisoryLock struct {
+	conn *sql.Conn
+	key  int64
+}
+
+//TryAdvisoryLock takes a session-level advisory lock on its own
+//connection, or fails with ErrLockHeld if another session has it
+func (a *DBAgent) TryAdvisoryLock(ctx context.Context, key int64) (Lock, error) {
+	conn, err := a.db.Conn(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get a connection for advisory lock %d", key)
+	}
+
+	var acquired bool
+	err = conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&acquired)
+	if err != nil {
+		//the lock may have been taken before the error
+		discardConn(conn)
+		return nil, errors.Wrapf(err, "failed to take advisory lock %d", key)
+	}
+	if !acquired {
+		conn.Close()
+		return nil, ErrLockHeld
+	}
+
+	return advisoryLock{conn: conn, key: key}, nil
+}
+
+func (l advisoryLock) Check(ctx context.Context) error {
+	_, err := l.conn.ExecContext(ctx, `SELECT 1`)
+	return errors.Wrapf(err, "lost the session holding advisory lock %d", l.key)
+}
+
+func (l advisoryLock) Release() error {
+	_, err := l.conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, l.key)
+	if err == nil {
+		//the session no longer holds the lock, so it can go back to the pool
+		return errors.Wrapf(l.conn.Close(), "failed to release advisory lock %d", l.key)
+	}
+
+	//ending the session frees the lock
+	discardConn(l.conn)
+	return errors.Wrapf(err, "failed to release advisory lock %d", l.key)
+}
+
+//discardConn ends a connection's session. Closing a *sql.Conn only
+//returns it to the pool, where the session, and any lock it holds, would
+//live on, but reporting it as bad makes database/sql close it instead.
+func discardConn(conn *sql.Conn) {
+	conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	conn.Close()
+}
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"database/sql"
+	"encoding/json"
 	"math/big"
 	"time"
 
@@ -23,7 +25,18 @@ type Account struct {
 
 	WebhookConfigured bool `json:"webhook_configured"`
 
-	PlaidAccessToken    string                  `json:"plaid_access_token"`
+	//Manual accounts aren't linked through Plaid, and are
+	//populated by importing statement files instead
+	Manual bool `json:"manual"`
+
+	//Hidden accounts are left out of reports. Each user who can see an
+	//account hides it for themselves, so this is the requesting user's
+	//preference, for accounts looked up on their behalf.
+	Hidden bool `json:"hidden"`
+
+	//PlaidAccessToken grants access to the item at Plaid, and is never
+	//sent to clients
+	PlaidAccessToken    string                  `json:"-"`
 	PlaidAccountID      string                  `json:"plaid_account_id"`
 	PlaidAccountName    string                  `json:"plaid_account_name"`
 	PlaidAccountType    plaidapi.AccountType    `json:"plaid_account_type"`
@@ -33,6 +46,10 @@ type Account struct {
 	PlaidInstitutionName string `json:"plaid_institution_name"`
 	PlaidInstitutionURL  string `json:"plaid_institution_url"`
 	PlaidInstitutionLogo string `json:"plaid_institution_logo"`
+
+	//Access is the requesting user's level, for accounts looked up on
+	//their behalf
+	Access ShareLevel `json:"access,omitempty"`
 }
 
 const StandardAccountFieldNameList = `
@@ -49,7 +66,9 @@ const StandardAccountFieldNameList = `
 	"plaid_item_id",
 	"plaid_institution_name",
 	"plaid_institution_url",
-	"plaid_institution_logo"
+	"plaid_institution_logo",
+
+	"manual"
 `
 
 func (a *Account) StandardFieldPointers() []interface{} {
@@ -68,6 +87,8 @@ func (a *Account) StandardFieldPointers() []interface{} {
 		&a.PlaidInstitutionName,
 		&a.PlaidInstitutionURL,
 		&a.PlaidInstitutionLogo,
+
+		&a.Manual,
 	}
 }
 
@@ -90,9 +111,649 @@ type Transaction struct {
 	PlaidAccountOwner         string `json:"plaid_account_owner"`
 	PlaidID                   string `json:"plaid_transaction_id"`
 	PlaidType                 string `json:"plaid_transaction_type"`
+
+	Note string `json:"note"`
+
+	//FITID is the institution's identifier for a transaction imported
+	//from a statement file, when the file carried one
+	FITID string `json:"fitid"`
+}
+
+const StandardTransactionFieldNameList = `
+	"uuid",
+	"account_uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"iso_currency_code",
+	"amount",
+	"date",
+
+	"plaid_account_id",
+	"plaid_name",
+	"plaid_category_id",
+	"plaid_pending",
+	"plaid_pending_transaction_id",
+	"plaid_account_owner",
+	"plaid_transaction_id",
+	"plaid_type",
+
+	"note",
+	"fitid"
+`
+
+//scanner is satisfied by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTransaction(row scanner) (Transaction, error) {
+	var transaction Transaction
+	var amount, note, fitID sql.NullString
+	err := row.Scan(
+		&transaction.UUID,
+		&transaction.AccountUUID,
+		&transaction.UserUUID,
+		&transaction.CreatedAt,
+		&transaction.ModifiedAt,
+
+		&transaction.ISOCurrencyCode,
+		&amount,
+		&transaction.Date,
+
+		&transaction.PlaidAccountID,
+		&transaction.PlaidName,
+		&transaction.PlaidCategoryID,
+		&transaction.PlaidPending,
+		&transaction.PlaidPendingTransactionID,
+		&transaction.PlaidAccountOwner,
+		&transaction.PlaidID,
+		&transaction.PlaidType,
+
+		&note,
+		&fitID,
+	)
+	transaction.Amount = parseAmount(amount)
+	transaction.Note = note.String
+	transaction.FITID = fitID.String
+	return transaction, err
+}
+
+//TransactionPage selects a page of transactions, newest first
+type TransactionPage struct {
+	//StartDate and EndDate optionally bound the dates, inclusive
+	StartDate string
+	EndDate   string
+
+	//After is the position of the last transaction of the previous page
+	After *TransactionCursor
+	Limit int
+}
+
+//TransactionCursor is a transaction's position in newest-first order
+type TransactionCursor struct {
+	Date string
+	UUID string
 }
 
 func (t Transaction) AmountFloat() float64 {
+	if t.Amount == nil {
+		return 0
+	}
 	fl, _ := t.Amount.Float64()
 	return fl
 }
+
+//Balance represents a point-in-time balance snapshot for a single account
+type Balance struct {
+	Model
+
+	AccountUUID string `json:"account_uuid"`
+	UserUUID    string `json:"user_uuid"`
+
+	Date            string     `json:"date"`
+	ISOCurrencyCode string     `json:"iso_currency_code"`
+	Current         *big.Float `json:"current"`
+	Available       *big.Float `json:"available"`
+	Limit           *big.Float `json:"limit"`
+}
+
+//formatAmount prepares an amount for storage in a varchar column
+func formatAmount(f *big.Float) interface{} {
+	if f == nil {
+		return nil
+	}
+	return f.Text('f', -1)
+}
+
+//parseAmount reads an amount back out of a varchar column
+func parseAmount(s sql.NullString) *big.Float {
+	if !s.Valid {
+		return nil
+	}
+	f, ok := new(big.Float).SetString(s.String)
+	if !ok {
+		return nil
+	}
+	return f
+}
+
+//Split allocates part of a transaction's amount to a category
+type Split struct {
+	Model
+
+	TransactionUUID string `json:"transaction_uuid"`
+	UserUUID        string `json:"user_uuid"`
+
+	Amount   *big.Float `json:"amount"`
+	Category string     `json:"category"`
+	Note     string     `json:"note"`
+}
+
+//TransferStatus tracks whether the user agrees with a detected transfer
+type TransferStatus string
+
+const (
+	TransferStatusSuggested TransferStatus = "suggested"
+	TransferStatusConfirmed TransferStatus = "confirmed"
+	TransferStatusRejected  TransferStatus = "rejected"
+)
+
+//Transfer pairs the two sides of a movement of money between two of
+//the user's own accounts
+type Transfer struct {
+	Model
+
+	UserUUID string `json:"user_uuid"`
+
+	//OutflowTransactionUUID has a positive amount, since Plaid
+	//reports money leaving an account as positive
+	OutflowTransactionUUID string `json:"outflow_transaction_uuid"`
+	InflowTransactionUUID  string `json:"inflow_transaction_uuid"`
+
+	Status TransferStatus `json:"status"`
+}
+
+//Attachment is a file, such as a receipt, attached to a transaction
+type Attachment struct {
+	Model
+
+	UserUUID        string `json:"user_uuid"`
+	TransactionUUID string `json:"transaction_uuid"`
+
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+//BlobKey is where the attachment's content lives in the blob store
+func (a Attachment) BlobKey() string {
+	return a.UserUUID + "/" + a.UUID
+}
+
+//ReportGroup is the dimension that a report is broken down by
+type ReportGroup string
+
+const (
+	ReportGroupMonth    ReportGroup = "month"
+	ReportGroupCategory ReportGroup = "category"
+	ReportGroupMerchant ReportGroup = "merchant"
+	ReportGroupAccount  ReportGroup = "account"
+)
+
+//ReportRow is one line of a cash-flow or spending report. Amounts are
+//positive, and rows are kept separate per currency.
+type ReportRow struct {
+	Key             string `json:"key"`
+	Label           string `json:"label"`
+	ISOCurrencyCode string `json:"iso_currency_code"`
+
+	Income   *big.Float `json:"income,omitempty"`
+	Expenses *big.Float `json:"expenses"`
+	Net      *big.Float `json:"net,omitempty"`
+	Count    int        `json:"count"`
+}
+
+//AlertKind is the condition that an alert watches for
+type AlertKind string
+
+const (
+	//AlertKindLargeTransaction fires for any outflow over the threshold
+	AlertKindLargeTransaction AlertKind = "large_transaction"
+	//AlertKindLowBalance fires when a depository account's current
+	//balance falls under the threshold
+	AlertKindLowBalance AlertKind = "low_balance"
+	//AlertKindNewRecurring fires when a new recurring charge is detected
+	AlertKindNewRecurring AlertKind = "new_recurring"
+	//AlertKindItemLoginRequired fires when a Plaid item needs the user
+	//to log in again
+	AlertKindItemLoginRequired AlertKind = "item_login_required"
+	//AlertKindBudgetExceeded fires when spending so far this month, in
+	//the alert's category or overall, goes over the threshold
+	AlertKindBudgetExceeded AlertKind = "budget_exceeded"
+)
+
+//AlertChannel is how an alert's notifications are delivered
+type AlertChannel string
+
+const (
+	AlertChannelEmail   AlertChannel = "email"
+	AlertChannelWebhook AlertChannel = "webhook"
+)
+
+//Alert is a user-configured notification rule
+type Alert struct {
+	Model
+
+	UserUUID string    `json:"user_uuid"`
+	Kind     AlertKind `json:"kind"`
+
+	//AccountUUID limits the alert to a single account when set
+	AccountUUID string     `json:"account_uuid"`
+	Category    string     `json:"category"`
+	Threshold   *big.Float `json:"threshold"`
+
+	Channel AlertChannel `json:"channel"`
+
+	//Target is an email address or a webhook URL, depending on the channel
+	Target string `json:"target"`
+}
+
+//AlertDeliveryStatus tracks whether a notification went out
+type AlertDeliveryStatus string
+
+const (
+	AlertDeliveryStatusPending AlertDeliveryStatus = "pending"
+	AlertDeliveryStatusSent    AlertDeliveryStatus = "sent"
+	AlertDeliveryStatusFailed  AlertDeliveryStatus = "failed"
+)
+
+//AlertDelivery records a single notification sent for an alert
+type AlertDelivery struct {
+	Model
+
+	AlertUUID string `json:"alert_uuid"`
+	UserUUID  string `json:"user_uuid"`
+
+	//DedupKey identifies the event that triggered the alert, so that
+	//the same event is never notified twice
+	DedupKey string `json:"dedup_key"`
+	Subject  string `json:"subject"`
+	Body     string `json:"body"`
+
+	Status AlertDeliveryStatus `json:"status"`
+	Error  string              `json:"error"`
+}
+
+//WebhookEndpoint is a user-registered URL that receives signed events
+type WebhookEndpoint struct {
+	Model
+
+	UserUUID string `json:"user_uuid"`
+	URL      string `json:"url"`
+
+	//Events lists the event types to send, or every type when empty
+	Events []string `json:"events"`
+
+	//Secret signs each delivery, and is only shown when the endpoint is created
+	Secret string `json:"-"`
+}
+
+//Subscribes reports whether the endpoint wants events of a given type
+func (e WebhookEndpoint) Subscribes(eventType string) bool {
+	if len(e.Events) == 0 {
+		return true
+	}
+	for _, t := range e.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+//WebhookDeliveryStatus tracks the progress of a webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+//WebhookDelivery is a single event queued for a webhook endpoint
+type WebhookDelivery struct {
+	Model
+
+	EndpointUUID string `json:"endpoint_uuid"`
+	UserUUID     string `json:"user_uuid"`
+
+	EventType string `json:"event_type"`
+	Payload   string `json:"payload"`
+
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at"`
+	LastStatusCode int                   `json:"last_status_code"`
+	LastError      string                `json:"last_error"`
+}
+
+//ItemStatus is the health of a Plaid item's connection
+type ItemStatus string
+
+const (
+	ItemStatusOK            ItemStatus = "ok"
+	ItemStatusLoginRequired ItemStatus = "login_required"
+	ItemStatusError         ItemStatus = "error"
+)
+
+//Item is a user's connection to one institution through Plaid, which
+//may hold several accounts
+type Item struct {
+	Model
+
+	UserUUID        string `json:"user_uuid"`
+	PlaidItemID     string `json:"plaid_item_id"`
+	InstitutionName string `json:"institution_name"`
+
+	Status ItemStatus `json:"status"`
+
+	//ErrorCode is Plaid's error code for the most recent item error
+	ErrorCode    string     `json:"error_code"`
+	LastSyncedAt *time.Time `json:"last_synced_at"`
+
+	//RefreshRequestedAt is when the user last asked for a refresh
+	RefreshRequestedAt *time.Time `json:"refresh_requested_at"`
+
+	Backfill Backfill `json:"backfill"`
+
+	//Access is the requesting user's level, for items looked up on their
+	//behalf
+	Access ShareLevel `json:"access,omitempty"`
+}
+
+//BackfillStatus is the state of an item's history import
+type BackfillStatus string
+
+const (
+	BackfillStatusNone       BackfillStatus = ""
+	BackfillStatusInProgress BackfillStatus = "in_progress"
+	BackfillStatusComplete   BackfillStatus = "complete"
+	BackfillStatusFailed     BackfillStatus = "failed"
+)
+
+//Backfill tracks the import of an item's transaction history, which
+//works backwards from EndDate to StartDate
+type Backfill struct {
+	Status    BackfillStatus `json:"status"`
+	StartDate string         `json:"start_date"`
+	EndDate   string         `json:"end_date"`
+
+	//OldestDate is the oldest date imported so far, and Imported counts
+	//the transactions that were new
+	OldestDate string `json:"oldest_date"`
+	Imported   int    `json:"imported"`
+	Error      string `json:"error"`
+
+	//Percent is how much of the date range has been imported
+	Percent int `json:"percent"`
+}
+
+func (b Backfill) percent() int {
+	if b.Status == BackfillStatusComplete {
+		return 100
+	}
+
+	start, err1 := time.Parse(plaidapi.DateFormat, b.StartDate)
+	end, err2 := time.Parse(plaidapi.DateFormat, b.EndDate)
+	oldest, err3 := time.Parse(plaidapi.DateFormat, b.OldestDate)
+	if err1 != nil || err2 != nil || err3 != nil || !end.After(start) {
+		return 0
+	}
+	return int(100 * end.Sub(oldest) / end.Sub(start))
+}
+
+//NeedsAttention reports whether the user has to act to fix the item
+func (i Item) NeedsAttention() bool {
+	return i.Status != ItemStatusOK
+}
+
+//DigestCadence is how often a user receives an email digest
+type DigestCadence string
+
+const (
+	DigestCadenceWeekly  DigestCadence = "weekly"
+	DigestCadenceMonthly DigestCadence = "monthly"
+)
+
+//Period is the span of time a digest with this cadence covers
+func (c DigestCadence) Period(end time.Time) time.Time {
+	if c == DigestCadenceMonthly {
+		return end.AddDate(0, -1, 0)
+	}
+	return end.AddDate(0, 0, -7)
+}
+
+//DigestPreferences holds a user's choice of email digest
+type DigestPreferences struct {
+	UserUUID string        `json:"-"`
+	Enabled  bool          `json:"enabled"`
+	Cadence  DigestCadence `json:"cadence"`
+
+	LastSentAt *time.Time `json:"last_sent_at"`
+}
+
+//DueDigest is a digest that has been claimed for sending
+type DueDigest struct {
+	UserUUID string
+	Email    string
+	Cadence  DigestCadence
+
+	//PreviousSentAt is when the last digest went out, which is where
+	//this one's period starts
+	PreviousSentAt *time.Time
+}
+
+//JobRunStatus is the outcome of a background job run
+type JobRunStatus string
+
+const (
+	JobRunStatusRunning   JobRunStatus = "running"
+	JobRunStatusSucceeded JobRunStatus = "succeeded"
+	JobRunStatusFailed    JobRunStatus = "failed"
+)
+
+//JobRun records one run of a background job. CreatedAt is when the run
+//started.
+type JobRun struct {
+	Model
+
+	//UserUUID and ItemUUID are set for jobs run on a user's behalf
+	UserUUID string `json:"user_uuid,omitempty"`
+	ItemUUID string `json:"item_uuid,omitempty"`
+
+	Name       string       `json:"name"`
+	Status     JobRunStatus `json:"status"`
+	FinishedAt *time.Time   `json:"finished_at"`
+	Error      string       `json:"error"`
+}
+
+//PersonalAccessToken lets a user's scripts call the API with a subset of
+//their permissions
+type PersonalAccessToken struct {
+	Model
+
+	UserUUID string   `json:"user_uuid"`
+	Name     string   `json:"name"`
+	Scopes   []string `json:"scopes"`
+
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+
+	//TokenHash identifies the token, which is only shown when it's created
+	TokenHash string `json:"-"`
+}
+
+//ShareLevel is how much a user may do with an account or item
+type ShareLevel string
+
+const (
+	//ShareLevelRead lets a user see an account and its transactions
+	ShareLevelRead ShareLevel = "read"
+	//ShareLevelEdit also lets them annotate transactions - notes, tags,
+	//splits and attachments
+	ShareLevelEdit ShareLevel = "edit"
+	//ShareLevelOwner is the level of the user who linked the account. It
+	//can't be granted.
+	ShareLevelOwner ShareLevel = "owner"
+)
+
+//CanEdit reports whether the level allows changes to shared data
+func (l ShareLevel) CanEdit() bool {
+	return l == ShareLevelEdit || l == ShareLevelOwner
+}
+
+//Share grants another registered user access to one of the owner's
+//accounts, or to every account of one of their items. It takes effect
+//once the grantee accepts it.
+type Share struct {
+	Model
+
+	OwnerUUID  string `json:"owner_uuid"`
+	OwnerEmail string `json:"owner_email"`
+
+	//invitations are addressed to GranteeEmail, and GranteeUUID is
+	//only set once they're accepted
+	GranteeUUID  string `json:"grantee_uuid"`
+	GranteeEmail string `json:"grantee_email"`
+
+	//exactly one of AccountUUID and ItemUUID is set
+	AccountUUID string `json:"account_uuid,omitempty"`
+	ItemUUID    string `json:"item_uuid,omitempty"`
+
+	Level      ShareLevel `json:"level"`
+	AcceptedAt *time.Time `json:"accepted_at"`
+}
+
+//User is a registered user. Deactivated users have a DeletedAt, and are
+//locked out until they're reactivated.
+type User struct {
+	Model
+
+	Email string `json:"email"`
+	Role  string `json:"role"`
+}
+
+//UserPage selects a page of registered users, in order of email
+type UserPage struct {
+	//Query matches any part of the email, ignoring case
+	Query              string
+	IncludeDeactivated bool
+
+	//After is the position of the last user of the previous page
+	After *UserCursor
+	Limit int
+}
+
+//UserCursor is a user's position in order of email
+type UserCursor struct {
+	Email string
+	UUID  string
+}
+
+//AuditAction is a kind of action recorded in the audit log
+type AuditAction string
+
+//the actions recorded in the audit log, each of which is taken on a
+//subject of the type in its prefix
+const (
+	AuditActionUserRegistered  AuditAction = "user.registered"
+	AuditActionUserUpdated     AuditAction = "user.updated"
+	AuditActionUserRoleChanged AuditAction = "user.role_changed"
+	AuditActionUserDeactivated AuditAction = "user.deactivated"
+	AuditActionUserReactivated AuditAction = "user.reactivated"
+	AuditActionUserExported    AuditAction = "user.exported"
+	//AuditActionUserDeleted records a user and their data being purged,
+	//by themselves or by an admin
+	AuditActionUserDeleted AuditAction = "user.deleted"
+
+	AuditActionItemLinked  AuditAction = "item.linked"
+	AuditActionItemRemoved AuditAction = "item.removed"
+
+	AuditActionAccountCreated  AuditAction = "account.created"
+	AuditActionAccountUpdated  AuditAction = "account.updated"
+	AuditActionAccountDeleted  AuditAction = "account.deleted"
+	AuditActionAccountImported AuditAction = "account.imported"
+	AuditActionAccountExported AuditAction = "account.exported"
+
+	AuditActionTransactionEdited AuditAction = "transaction.edited"
+
+	AuditActionAttachmentUploaded AuditAction = "attachment.uploaded"
+	AuditActionAttachmentDeleted  AuditAction = "attachment.deleted"
+
+	AuditActionTransferConfirmed AuditAction = "transfer.confirmed"
+	AuditActionTransferRejected  AuditAction = "transfer.rejected"
+
+	AuditActionTokenCreated AuditAction = "token.created"
+	AuditActionTokenRevoked AuditAction = "token.revoked"
+
+	AuditActionShareCreated  AuditAction = "share.created"
+	AuditActionShareAccepted AuditAction = "share.accepted"
+	AuditActionShareRevoked  AuditAction = "share.revoked"
+
+	AuditActionAlertCreated AuditAction = "alert.created"
+	AuditActionAlertDeleted AuditAction = "alert.deleted"
+
+	AuditActionWebhookEndpointCreated AuditAction = "webhook_endpoint.created"
+	AuditActionWebhookEndpointDeleted AuditAction = "webhook_endpoint.deleted"
+)
+
+//AuditEvent is an entry in the append-only audit log
+type AuditEvent struct {
+	UUID      string    `json:"uuid"`
+	CreatedAt time.Time `json:"created_at"`
+
+	//ActorUUID is the user who took the action, and ActorTokenUUID the
+	//personal access token they used, if any
+	ActorUUID      string      `json:"actor_uuid"`
+	ActorTokenUUID string      `json:"actor_token_uuid,omitempty"`
+	Action         AuditAction `json:"action"`
+
+	//SubjectType and SubjectUUID are whatever the action was taken on
+	SubjectType string `json:"subject_type"`
+	SubjectUUID string `json:"subject_uuid"`
+
+	RequestID string `json:"request_id"`
+	IP        string `json:"ip"`
+
+	//Before and After hold the fields of the subject that the action
+	//changed
+	Before json.RawMessage `json:"before,omitempty"`
+	After  json.RawMessage `json:"after,omitempty"`
+}
+
+//AuditEventFilter selects a page of the audit log, newest first. Fields
+//that are empty match every event.
+type AuditEventFilter struct {
+	ActorUUID   string
+	Action      AuditAction
+	SubjectType string
+	SubjectUUID string
+
+	//Involving matches the actions a user took, and those taken on them
+	Involving string
+
+	Since *time.Time
+	Until *time.Time
+
+	//Before is the position of the last event of the previous page
+	Before *AuditCursor
+	Limit  int
+}
+
+//AuditCursor is an event's position in the audit log
+type AuditCursor struct {
+	CreatedAt time.Time
+	UUID      string
+}
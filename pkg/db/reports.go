@@ -0,0 +1,123 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+//ErrUnknownReportGroup indicates that a report can't be grouped by the
+//requested dimension
+var ErrUnknownReportGroup = errors.New("unknown report group")
+
+//reportGroupColumns maps each ReportGroup to the key and label
+//expressions it groups by
+var reportGroupColumns = map[ReportGroup][2]string{
+	ReportGroupMonth:    {`LEFT("ra"."date", 7)`, `LEFT("ra"."date", 7)`},
+	ReportGroupCategory: {`COALESCE(NULLIF("ra"."category", ''), 'uncategorized')`, `COALESCE(NULLIF("ra"."category", ''), 'uncategorized')`},
+	ReportGroupMerchant: {`COALESCE(NULLIF("ra"."plaid_name", ''), 'unknown')`, `COALESCE(NULLIF("ra"."plaid_name", ''), 'unknown')`},
+	ReportGroupAccount:  {`"ra"."account_uuid"::varchar`, `"accounts"."plaid_account_name"`},
+}
+
+//reportQuery reads from reportable_allocations, so split transactions
+//count once per split and transfers are left out. It covers every
+//account the user can see, including shared ones, except those they've
+//hidden.
+const reportQuery = `
+SELECT
+	%[1]s AS "key",
+	%[2]s AS "label",
+	COALESCE("ra"."iso_currency_code", ''),
+	COALESCE(SUM(-"ra"."amount") FILTER (WHERE "ra"."amount" < 0), 0)::varchar,
+	COALESCE(SUM("ra"."amount") FILTER (WHERE "ra"."amount" > 0), 0)::varchar,
+	COALESCE(SUM(-"ra"."amount"), 0)::varchar,
+	COUNT(*)
+FROM "reportable_allocations" AS "ra"
+JOIN "accounts"
+	ON "accounts"."uuid" = "ra"."account_uuid"
+	AND "accounts"."deleted_at" IS NULL
+WHERE
+	"ra"."account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
+	AND "ra"."account_uuid" NOT IN (SELECT "account_uuid" FROM "account_preferences" WHERE "user_uuid" = $1 AND "hidden")
+	AND "ra"."date" >= $2
+	AND "ra"."date" <= $3
+	%[3]s
+GROUP BY 1, 2, 3
+ORDER BY %[4]s`
+
+//GetCashflowReport totals the user's income and expenses between two
+//dates, inclusive, broken down by the given group
+func (a *DBAgent) GetCashflowReport(ctx context.Context, userUUID string, group ReportGroup, startDate string, endDate string) ([]ReportRow, error) {
+	columns, ok := reportGroupColumns[group]
+	if !ok {
+		return nil, ErrUnknownReportGroup
+	}
+
+	rows, err := a.queryReport(ctx,
+		fmt.Sprintf(reportQuery, columns[0], columns[1], "", `1, 3`),
+		userUUID, startDate, endDate,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get cash-flow report for user `%s`", userUUID)
+	}
+	return rows, nil
+}
+
+//GetSpendingReport totals the user's outflows between two dates,
+//inclusive, broken down by the given group, largest first
+func (a *DBAgent) GetSpendingReport(ctx context.Context, userUUID string, group ReportGroup, startDate string, endDate string) ([]ReportRow, error) {
+	columns, ok := reportGroupColumns[group]
+	if !ok {
+		return nil, ErrUnknownReportGroup
+	}
+
+	rows, err := a.queryReport(ctx,
+		fmt.Sprintf(reportQuery, columns[0], columns[1], `AND "ra"."amount" > 0`, `SUM("ra"."amount") DESC, 1, 3`),
+		userUUID, startDate, endDate,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get spending report for user `%s`", userUUID)
+	}
+
+	for i := range rows {
+		rows[i].Income = nil
+		rows[i].Net = nil
+	}
+	return rows, nil
+}
+
+func (a *DBAgent) queryReport(ctx context.Context, query string, args ...interface{}) ([]ReportRow, error) {
+	rows, err := a.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	report := []ReportRow{}
+	for rows.Next() {
+		var row ReportRow
+		var label, income, expenses, net sql.NullString
+		err := rows.Scan(
+			&row.Key,
+			&label,
+			&row.ISOCurrencyCode,
+			&income,
+			&expenses,
+			&net,
+			&row.Count,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		row.Label = label.String
+		row.Income = parseAmount(income)
+		row.Expenses = parseAmount(expenses)
+		row.Net = parseAmount(net)
+		report = append(report, row)
+	}
+
+	return report, rows.Err()
+}
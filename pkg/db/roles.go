@@ -0,0 +1,184 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+//ErrNoSuchRole indicates that a role doesn't exist
+var ErrNoSuchRole = errors.New("no such role")
+
+//EnsureRolesTables creates the roles and role_permissions tables, and
+//gives every user a role, which is `member` unless set otherwise
+func (a *DBAgent) EnsureRolesTables(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "roles"
+(	"name" varchar NOT NULL,
+	"created_at" timestamp NOT NULL,
+	PRIMARY KEY ("name")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure roles table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "role_permissions"
+(	"role" varchar NOT NULL REFERENCES roles(name),
+	"permission" varchar NOT NULL,
+	PRIMARY KEY ("role", "permission")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure role_permissions table")
+	}
+
+	//role_default_permissions records the default permissions each role
+	//has been given, so that defaults added later are granted once, but
+	//permissions revoked in the database aren't granted again. Roles
+	//seeded before it existed were given what they have now.
+	_, err = a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "role_default_permissions"
+(	"role" varchar NOT NULL REFERENCES roles(name),
+	"permission" varchar NOT NULL,
+	PRIMARY KEY ("role", "permission")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure role_default_permissions table")
+	}
+	_, err = a.db.ExecContext(ctx, `
+INSERT INTO "role_default_permissions" ("role", "permission")
+SELECT "role", "permission" FROM "role_permissions"
+ON CONFLICT DO NOTHING`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to backfill role_default_permissions table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+ALTER TABLE "users"
+	ADD COLUMN IF NOT EXISTS "role" varchar NOT NULL DEFAULT 'member'`)
+	return errors.Wrap(err, "failed to ensure role column for users")
+}
+
+//SeedRole creates a role with its default permissions. A role that
+//already exists is only given the defaults it hasn't been given before,
+//so that its permissions can be changed in the database.
+func (a *DBAgent) SeedRole(ctx context.Context, role string, permissions []string) error {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to begin transaction")
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO "roles" ("name", "created_at")
+VALUES ($1, NOW())
+ON CONFLICT ("name") DO NOTHING`,
+		role,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to insert role `%s`", role)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO "role_permissions" ("role", "permission")
+SELECT $1, "defaults"."permission" FROM UNNEST($2::varchar[]) AS "defaults" ("permission")
+WHERE NOT EXISTS (
+	SELECT 1 FROM "role_default_permissions"
+	WHERE
+		"role_default_permissions"."role" = $1 AND
+		"role_default_permissions"."permission" = "defaults"."permission"
+)
+ON CONFLICT DO NOTHING`,
+		role, pq.Array(permissions),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to insert permissions for role `%s`", role)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO "role_default_permissions" ("role", "permission")
+SELECT $1, UNNEST($2::varchar[])
+ON CONFLICT DO NOTHING`,
+		role, pq.Array(permissions),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to record default permissions for role `%s`", role)
+	}
+
+	return errors.Wrapf(tx.Commit(), "failed to commit role `%s`", role)
+}
+
+//GetUserPermissions gets a registered user's role and the permissions it
+//grants. It returns ErrUserDeactivated for deactivated users.
+func (a *DBAgent) GetUserPermissions(ctx context.Context, uuid string) (string, []string, error) {
+	var role string
+	var permissions []string
+	var deactivated bool
+	err := a.db.QueryRowContext(ctx, `
+SELECT
+	"users"."role",
+	COALESCE(ARRAY_AGG("role_permissions"."permission") FILTER (WHERE "role_permissions"."permission" IS NOT NULL), '{}'),
+	"users"."deleted_at" IS NOT NULL
+FROM "users"
+LEFT JOIN "role_permissions" ON "role_permissions"."role" = "users"."role"
+WHERE
+	"users"."uuid" = $1
+GROUP BY "users"."uuid"`,
+		uuid,
+	).Scan(&role, pq.Array(&permissions), &deactivated)
+	if err == sql.ErrNoRows {
+		return "", nil, ErrNoSuchUser
+	}
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to get permissions for user `%s`", uuid)
+	}
+	if deactivated {
+		return "", nil, ErrUserDeactivated
+	}
+	return role, permissions, nil
+}
+
+//SetUserRole changes a registered user's role
+func (a *DBAgent) SetUserRole(ctx context.Context, uuid string, role string) error {
+	res, err := a.db.ExecContext(ctx, `
+UPDATE "users"
+SET
+	"role" = "roles"."name",
+	"modified_at" = NOW()
+FROM "roles"
+WHERE
+	"users"."uuid" = $1 AND
+	"users"."deleted_at" IS NULL AND
+	"roles"."name" = $2`,
+		uuid, role,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set role for user `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to set role for user `%s`", uuid)
+	}
+	if n > 0 {
+		return nil
+	}
+
+	//tell a missing role apart from a missing user
+	exists, err := a.roleExists(ctx, role)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrNoSuchRole
+	}
+	return ErrNoSuchUser
+}
+
+func (a *DBAgent) roleExists(ctx context.Context, role string) (bool, error) {
+	var exists bool
+	err := a.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM "roles" WHERE "name" = $1)`, role).Scan(&exists)
+	return exists, errors.Wrapf(err, "failed to check role `%s`", role)
+}
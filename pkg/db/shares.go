@@ -0,0 +1,387 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+//ErrNoSuchShare indicates that a share doesn't exist, has been revoked,
+//or doesn't involve the user
+var ErrNoSuchShare = errors.New("no such share")
+
+//ErrShareExists indicates that the account or item is already shared
+//with the user
+var ErrShareExists = errors.New("already shared with this user")
+
+//EnsureSharesTables creates the shares table, along with the
+//account_access and item_access views, which list every user who can
+//see an account or item, at their strongest level. Queries on the
+//user's behalf go through these views instead of checking user_uuid.
+func (a *DBAgent) EnsureSharesTables(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "shares"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"owner_uuid" UUID NOT NULL REFERENCES users(uuid),
+	"grantee_uuid" UUID NOT NULL REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"account_uuid" UUID REFERENCES accounts(uuid),
+	"item_uuid" UUID REFERENCES items(uuid),
+	"level" varchar NOT NULL CHECK ("level" IN ('read', 'edit')),
+	"accepted_at" timestamp,
+	PRIMARY KEY ("uuid"),
+	CHECK (("account_uuid" IS NULL) <> ("item_uuid" IS NULL))
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure shares table")
+	}
+
+	//invitations are addressed to an email, and only tied to a user when
+	//they accept, so that inviting someone doesn't reveal whether
+	//they've registered
+	_, err = a.db.ExecContext(ctx, `ALTER TABLE "shares" ADD COLUMN IF NOT EXISTS "grantee_email" varchar`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure grantee_email column for shares")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+UPDATE "shares"
+SET "grantee_email" = "users"."email"
+FROM "users"
+WHERE "users"."uuid" = "shares"."grantee_uuid" AND "shares"."grantee_email" IS NULL`)
+	if err != nil {
+		return errors.Wrap(err, "failed to fill in grantee_email for shares")
+	}
+
+	_, err = a.db.ExecContext(ctx, `ALTER TABLE "shares" ALTER COLUMN "grantee_uuid" DROP NOT NULL`)
+	if err != nil {
+		return errors.Wrap(err, "failed to make grantee_uuid optional for shares")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE UNIQUE INDEX IF NOT EXISTS "shares_account_uuid_grantee_email_idx" ON shares (account_uuid, LOWER(grantee_email)) WHERE deleted_at IS NULL`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure account_uuid, grantee_email index for shares")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE UNIQUE INDEX IF NOT EXISTS "shares_item_uuid_grantee_email_idx" ON shares (item_uuid, LOWER(grantee_email)) WHERE deleted_at IS NULL`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure item_uuid, grantee_email index for shares")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE UNIQUE INDEX IF NOT EXISTS "shares_account_uuid_grantee_uuid_idx" ON shares (account_uuid, grantee_uuid) WHERE deleted_at IS NULL`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure account_uuid index for shares")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE UNIQUE INDEX IF NOT EXISTS "shares_item_uuid_grantee_uuid_idx" ON shares (item_uuid, grantee_uuid) WHERE deleted_at IS NULL`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure item_uuid index for shares")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "shares_grantee_uuid_idx" ON shares USING btree(grantee_uuid)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure grantee_uuid index for shares")
+	}
+
+	//owners keep access to their deleted accounts' transactions, as they
+	//always have, while grantees lose it
+	_, err = a.db.ExecContext(ctx, `
+CREATE OR REPLACE VIEW "account_access" AS
+SELECT
+	"account_uuid",
+	"user_uuid",
+	(ARRAY['read', 'edit', 'owner'])[MAX("rank")]::varchar AS "level"
+FROM (
+	SELECT "uuid" AS "account_uuid", "user_uuid", 3 AS "rank"
+	FROM "accounts"
+
+	UNION ALL
+
+	SELECT "accounts"."uuid", "shares"."grantee_uuid", CASE "shares"."level" WHEN 'edit' THEN 2 ELSE 1 END
+	FROM "shares"
+	JOIN "accounts" ON "accounts"."uuid" = "shares"."account_uuid"
+	WHERE
+		"shares"."deleted_at" IS NULL
+		AND "shares"."accepted_at" IS NOT NULL
+		AND "accounts"."deleted_at" IS NULL
+
+	UNION ALL
+
+	SELECT "accounts"."uuid", "shares"."grantee_uuid", CASE "shares"."level" WHEN 'edit' THEN 2 ELSE 1 END
+	FROM "shares"
+	JOIN "items" ON "items"."uuid" = "shares"."item_uuid"
+	JOIN "accounts" ON
+		"accounts"."plaid_item_id" = "items"."plaid_item_id"
+		AND "accounts"."user_uuid" = "items"."user_uuid"
+	WHERE
+		"shares"."deleted_at" IS NULL
+		AND "shares"."accepted_at" IS NOT NULL
+		AND "items"."deleted_at" IS NULL
+		AND "accounts"."deleted_at" IS NULL
+) AS "grants"
+GROUP BY "account_uuid", "user_uuid"`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure account_access view")
+	}
+
+	//sharing a single account lets the grantee see the state of its item,
+	//but not refresh it
+	_, err = a.db.ExecContext(ctx, `
+CREATE OR REPLACE VIEW "item_access" AS
+SELECT
+	"item_uuid",
+	"user_uuid",
+	(ARRAY['read', 'edit', 'owner'])[MAX("rank")]::varchar AS "level"
+FROM (
+	SELECT "uuid" AS "item_uuid", "user_uuid", 3 AS "rank"
+	FROM "items"
+
+	UNION ALL
+
+	SELECT "item_uuid", "grantee_uuid", CASE "level" WHEN 'edit' THEN 2 ELSE 1 END
+	FROM "shares"
+	WHERE
+		"deleted_at" IS NULL
+		AND "accepted_at" IS NOT NULL
+		AND "item_uuid" IS NOT NULL
+
+	UNION ALL
+
+	SELECT "items"."uuid", "shares"."grantee_uuid", 1
+	FROM "shares"
+	JOIN "accounts" ON "accounts"."uuid" = "shares"."account_uuid"
+	JOIN "items" ON
+		"items"."plaid_item_id" = "accounts"."plaid_item_id"
+		AND "items"."user_uuid" = "accounts"."user_uuid"
+	WHERE
+		"shares"."deleted_at" IS NULL
+		AND "shares"."accepted_at" IS NOT NULL
+		AND "accounts"."deleted_at" IS NULL
+) AS "grants"
+GROUP BY "item_uuid", "user_uuid"`)
+	return errors.Wrap(err, "failed to ensure item_access view")
+}
+
+const shareFieldNameList = `
+	"shares"."uuid",
+	"shares"."created_at",
+	"shares"."modified_at",
+
+	"shares"."owner_uuid",
+	"owners"."email",
+	COALESCE("shares"."grantee_uuid"::varchar, ''),
+	"shares"."grantee_email",
+	COALESCE("shares"."account_uuid"::varchar, ''),
+	COALESCE("shares"."item_uuid"::varchar, ''),
+	"shares"."level",
+	"shares"."accepted_at"
+`
+
+const shareJoins = `
+JOIN "users" AS "owners" ON "owners"."uuid" = "shares"."owner_uuid"
+`
+
+//shareInvolves matches the shares that the user in $1 has made, has
+//accepted, or has been invited to by email
+const shareInvolves = `(
+	"shares"."owner_uuid" = $1
+	OR "shares"."grantee_uuid" = $1
+	OR ("shares"."grantee_uuid" IS NULL AND LOWER("shares"."grantee_email") = (SELECT LOWER("email") FROM "users" WHERE "uuid" = $1))
+)`
+
+func scanShare(row scanner) (Share, error) {
+	var share Share
+	err := row.Scan(
+		&share.UUID,
+		&share.CreatedAt,
+		&share.ModifiedAt,
+
+		&share.OwnerUUID,
+		&share.OwnerEmail,
+		&share.GranteeUUID,
+		&share.GranteeEmail,
+		&share.AccountUUID,
+		&share.ItemUUID,
+		&share.Level,
+		&share.AcceptedAt,
+	)
+	return share, err
+}
+
+//CreateShare invites whoever has the share's GranteeEmail to one of the
+//owner's accounts or items, whether or not they've registered yet. It
+//returns ErrNoSuchAccount or ErrNoSuchItem unless the owner owns it -
+//sharing a shared account isn't allowed - and ErrNoSuchUser if the email
+//is the owner's own.
+func (a *DBAgent) CreateShare(ctx context.Context, share Share) (string, error) {
+	var owned bool
+	err := a.db.QueryRowContext(ctx, `
+SELECT
+	EXISTS (SELECT 1 FROM "accounts" WHERE "deleted_at" IS NULL AND "user_uuid" = $1 AND "uuid" = NULLIF($2, '')::uuid)
+	OR EXISTS (SELECT 1 FROM "items" WHERE "deleted_at" IS NULL AND "user_uuid" = $1 AND "uuid" = NULLIF($3, '')::uuid)`,
+		share.OwnerUUID,
+		share.AccountUUID,
+		share.ItemUUID,
+	).Scan(&owned)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to check ownership of shared resource")
+	}
+	if !owned && share.AccountUUID != "" {
+		return "", ErrNoSuchAccount
+	}
+	if !owned {
+		return "", ErrNoSuchItem
+	}
+
+	var uuid string
+	err = a.db.QueryRowContext(ctx, `
+INSERT INTO "shares" (
+	"owner_uuid",
+	"grantee_email",
+	"created_at",
+	"modified_at",
+
+	"account_uuid",
+	"item_uuid",
+	"level"
+)
+SELECT
+	$1, $2::varchar, NOW(), NOW(),
+	NULLIF($3, '')::uuid, NULLIF($4, '')::uuid, $5
+WHERE NOT EXISTS (SELECT 1 FROM "users" WHERE "uuid" = $1 AND LOWER("email") = LOWER($2))
+RETURNING "uuid"`,
+		share.OwnerUUID,
+		share.GranteeEmail,
+
+		share.AccountUUID,
+		share.ItemUUID,
+		share.Level,
+	).Scan(&uuid)
+	if err == sql.ErrNoRows {
+		return "", ErrNoSuchUser
+	}
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+		return "", ErrShareExists
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to insert into shares table")
+	}
+	return uuid, nil
+}
+
+//GetShares lists the unrevoked shares the user has made or been offered,
+//including ones that haven't been accepted yet
+func (a *DBAgent) GetShares(ctx context.Context, userUUID string) ([]Share, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "shares"
+%s
+WHERE
+	"shares"."deleted_at" IS NULL
+	AND %s
+ORDER BY "shares"."created_at"`, shareFieldNameList, shareJoins, shareInvolves),
+		userUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get shares from table")
+	}
+	defer rows.Close()
+
+	shares := []Share{}
+	for rows.Next() {
+		share, err := scanShare(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan shares for user %s", userUUID)
+		}
+		shares = append(shares, share)
+	}
+
+	return shares, errors.Wrapf(rows.Err(), "failed to scan shares for user %s", userUUID)
+}
+
+//GetShare gets one of the unrevoked shares the user has made or been
+//offered
+func (a *DBAgent) GetShare(ctx context.Context, userUUID string, uuid string) (Share, error) {
+	share, err := scanShare(a.db.QueryRowContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "shares"
+%s
+WHERE
+	"shares"."deleted_at" IS NULL
+	AND %s
+	AND "shares"."uuid" = $2`, shareFieldNameList, shareJoins, shareInvolves),
+		userUUID,
+		uuid,
+	))
+	if err == sql.ErrNoRows {
+		return Share{}, ErrNoSuchShare
+	}
+	if err != nil {
+		return Share{}, errors.Wrapf(err, "failed to get share `%s`", uuid)
+	}
+	return share, nil
+}
+
+//AcceptShare accepts a share offered to the user, tying an invitation
+//to their email to them. Accepting it twice is harmless.
+func (a *DBAgent) AcceptShare(ctx context.Context, userUUID string, uuid string) error {
+	res, err := a.db.ExecContext(ctx, fmt.Sprintf(`
+UPDATE "shares"
+SET
+	"grantee_uuid" = $1,
+	"accepted_at" = COALESCE("accepted_at", NOW()),
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "owner_uuid" <> $1
+	AND %s
+	AND "uuid" = $2`, shareInvolves),
+		userUUID,
+		uuid,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to accept share `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to accept share `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchShare
+	}
+	return nil
+}
+
+//RevokeShare soft-deletes a share. Owners revoke their shares, and
+//grantees decline or leave them the same way.
+func (a *DBAgent) RevokeShare(ctx context.Context, userUUID string, uuid string) error {
+	res, err := a.db.ExecContext(ctx, fmt.Sprintf(`
+UPDATE "shares"
+SET
+	"deleted_at" = NOW(),
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND %s
+	AND "uuid" = $2`, shareInvolves),
+		userUUID,
+		uuid,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to revoke share `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to revoke share `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchShare
+	}
+	return nil
+}
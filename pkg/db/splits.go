@@ -0,0 +1,295 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+//ErrUnbalancedSplits indicates that a set of splits doesn't add up to
+//the amount of the transaction being split
+var ErrUnbalancedSplits = errors.New("splits must add up to the transaction amount")
+
+//EnsureSplitsTable creates the transaction_splits table along with the
+//transaction_allocations view, which reports and budgets should read
+//instead of the transactions table. The view yields one row for each
+//split of a split transaction, and one row for the whole amount of any
+//transaction that hasn't been split. Splits whose parent amount has
+//since changed are kept, but marked out_of_balance.
+func (a *DBAgent) EnsureSplitsTable(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "transaction_splits"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"transaction_uuid" UUID REFERENCES transactions(uuid),
+	"user_uuid" UUID REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"amount" varchar NOT NULL,
+	"category" varchar NOT NULL,
+	"note" varchar,
+	PRIMARY KEY ("uuid")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure transaction_splits table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "transaction_splits_transaction_uuid_idx" ON transaction_splits USING btree(transaction_uuid)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure transaction_uuid index for transaction_splits")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+CREATE OR REPLACE VIEW "transaction_allocations" AS
+SELECT
+	"transactions"."uuid" AS "transaction_uuid",
+	"transaction_splits"."uuid" AS "split_uuid",
+	"transactions"."user_uuid",
+	"transactions"."account_uuid",
+	"transactions"."date",
+	"transactions"."iso_currency_code",
+	"transactions"."plaid_name",
+	"transaction_splits"."amount"::numeric AS "amount",
+	"transaction_splits"."category",
+	"transaction_splits"."note",
+	ROUND(SUM("transaction_splits"."amount"::numeric) OVER (PARTITION BY "transactions"."uuid"), 2)
+		<> ROUND("transactions"."amount"::numeric, 2) AS "out_of_balance"
+FROM "transactions"
+JOIN "transaction_splits"
+	ON "transaction_splits"."transaction_uuid" = "transactions"."uuid"
+	AND "transaction_splits"."deleted_at" IS NULL
+WHERE "transactions"."deleted_at" IS NULL
+UNION ALL
+SELECT
+	"transactions"."uuid",
+	NULL,
+	"transactions"."user_uuid",
+	"transactions"."account_uuid",
+	"transactions"."date",
+	"transactions"."iso_currency_code",
+	"transactions"."plaid_name",
+	"transactions"."amount"::numeric,
+	"transactions"."plaid_category_id",
+	"transactions"."note",
+	false
+FROM "transactions"
+WHERE
+	"transactions"."deleted_at" IS NULL
+	AND NOT EXISTS (
+		SELECT 1 FROM "transaction_splits"
+		WHERE "transaction_splits"."transaction_uuid" = "transactions"."uuid"
+		AND "transaction_splits"."deleted_at" IS NULL
+	)`)
+	return errors.Wrap(err, "failed to ensure transaction_allocations view")
+}
+
+//SetTransactionSplits replaces the splits on a transaction the user can
+//edit. The splits must add up to the transaction's amount, and
+//an empty list un-splits the transaction.
+func (a *DBAgent) SetTransactionSplits(ctx context.Context, userUUID string, transactionUUID string, splits []Split) error {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	//lock the parent so that a concurrent Plaid update can't change
+	//the amount between validation and commit
+	var amount sql.NullString
+	err = tx.QueryRowContext(ctx, `
+SELECT "amount" FROM "transactions"
+WHERE
+	"deleted_at" IS NULL
+	AND "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1 AND "level" <> 'read')
+	AND "uuid" = $2
+FOR UPDATE`,
+		userUUID,
+		transactionUUID,
+	).Scan(&amount)
+	if err == sql.ErrNoRows {
+		return ErrNoSuchTransaction
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to get transaction `%s`", transactionUUID)
+	}
+
+	if len(splits) > 0 && SplitsOutOfBalance(parseAmount(amount), splits) {
+		return ErrUnbalancedSplits
+	}
+
+	_, err = tx.ExecContext(ctx, `
+UPDATE "transaction_splits"
+SET "deleted_at" = NOW()
+WHERE "deleted_at" IS NULL AND "transaction_uuid" = $1`,
+		transactionUUID,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to clear splits for transaction `%s`", transactionUUID)
+	}
+
+	for _, split := range splits {
+		_, err = tx.ExecContext(ctx, `
+INSERT INTO "transaction_splits" (
+	"transaction_uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"amount",
+	"category",
+	"note"
+) VALUES (
+	$1, $2, NOW(), NOW(),
+	$3, $4, $5
+)`,
+			transactionUUID,
+			userUUID,
+
+			formatAmount(split.Amount),
+			split.Category,
+			split.Note,
+		)
+		if err != nil {
+			return errors.Wrap(err, "failed to insert into transaction_splits table")
+		}
+	}
+
+	return errors.Wrap(tx.Commit(), "failed to commit transaction")
+}
+
+//GetTransactionSplits lists the splits on a transaction the user can see,
+//whoever split it
+func (a *DBAgent) GetTransactionSplits(ctx context.Context, userUUID string, transactionUUID string) ([]Split, error) {
+	rows, err := a.db.QueryContext(ctx, `
+SELECT
+	"uuid",
+	"transaction_uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"amount",
+	"category",
+	"note"
+FROM "transaction_splits"
+WHERE
+	"deleted_at" IS NULL
+	AND "transaction_uuid" IN (
+		SELECT "uuid" FROM "transactions"
+		WHERE "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
+	)
+	AND "transaction_uuid" = $2
+ORDER BY "created_at", "uuid"`,
+		userUUID,
+		transactionUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get splits for transaction `%s`", transactionUUID)
+	}
+	defer rows.Close()
+
+	splits := []Split{}
+	for rows.Next() {
+		split, err := scanSplit(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan splits for transaction `%s`", transactionUUID)
+		}
+		splits = append(splits, split)
+	}
+
+	return splits, errors.Wrapf(rows.Err(), "failed to scan splits for transaction `%s`", transactionUUID)
+}
+
+//GetSplitsForTransactions lists the splits on several transactions the
+//user can see, keyed by transaction UUID
+func (a *DBAgent) GetSplitsForTransactions(ctx context.Context, userUUID string, transactionUUIDs []string) (map[string][]Split, error) {
+	rows, err := a.db.QueryContext(ctx, `
+SELECT
+	"uuid",
+	"transaction_uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"amount",
+	"category",
+	"note"
+FROM "transaction_splits"
+WHERE
+	"deleted_at" IS NULL
+	AND "transaction_uuid" IN (
+		SELECT "uuid" FROM "transactions"
+		WHERE "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
+	)
+	AND "transaction_uuid" = ANY($2::uuid[])
+ORDER BY "created_at", "uuid"`,
+		userUUID,
+		pq.Array(transactionUUIDs),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get splits for %d transactions", len(transactionUUIDs))
+	}
+	defer rows.Close()
+
+	splits := map[string][]Split{}
+	for rows.Next() {
+		split, err := scanSplit(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan splits for %d transactions", len(transactionUUIDs))
+		}
+		splits[split.TransactionUUID] = append(splits[split.TransactionUUID], split)
+	}
+
+	return splits, errors.Wrapf(rows.Err(), "failed to scan splits for %d transactions", len(transactionUUIDs))
+}
+
+func scanSplit(row scanner) (Split, error) {
+	var split Split
+	var amount, note sql.NullString
+	err := row.Scan(
+		&split.UUID,
+		&split.TransactionUUID,
+		&split.UserUUID,
+		&split.CreatedAt,
+		&split.ModifiedAt,
+
+		&amount,
+		&split.Category,
+		&note,
+	)
+	split.Amount = parseAmount(amount)
+	split.Note = note.String
+	return split, err
+}
+
+//SplitsOutOfBalance reports whether a transaction's splits no longer add
+//up to its amount, which happens when Plaid revises the amount of a
+//transaction after it was split. Amounts are compared to the cent.
+func SplitsOutOfBalance(amount *big.Float, splits []Split) bool {
+	if len(splits) == 0 {
+		return false
+	}
+
+	sum := new(big.Float)
+	for _, split := range splits {
+		if split.Amount != nil {
+			sum.Add(sum, split.Amount)
+		}
+	}
+	if amount == nil {
+		amount = new(big.Float)
+	}
+
+	return formatCents(sum) != formatCents(amount)
+}
+
+func formatCents(f *big.Float) string {
+	if f.Sign() == 0 {
+		return "0.00"
+	}
+	return f.Text('f', 2)
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+//EnsureTagsTables EnsureTagsTables
+func (a *DBAgent) EnsureTagsTables(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "tags"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"user_uuid" UUID REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"name" varchar NOT NULL,
+	PRIMARY KEY ("uuid"),
+	UNIQUE ("user_uuid", "name")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure tags table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "transaction_tags"
+(	"transaction_uuid" UUID REFERENCES transactions(uuid) ON DELETE CASCADE,
+	"tag_uuid" UUID REFERENCES tags(uuid) ON DELETE CASCADE,
+	"created_at" timestamp NOT NULL,
+	PRIMARY KEY ("transaction_uuid", "tag_uuid")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure transaction_tags table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "transaction_tags_tag_uuid_idx" ON transaction_tags USING btree(tag_uuid)`)
+	return errors.Wrap(err, "failed to ensure tag_uuid index for transaction_tags")
+}
+
+//SetTransactionTags replaces the set of tags on a transaction the user
+//can edit, creating any of the user's tags that don't exist yet
+func (a *DBAgent) SetTransactionTags(ctx context.Context, userUUID string, transactionUUID string, tags []string) error {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var owned bool
+	err = tx.QueryRowContext(ctx, `
+SELECT EXISTS (
+	SELECT 1 FROM "transactions"
+	WHERE
+		"deleted_at" IS NULL
+		AND "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1 AND "level" <> 'read')
+		AND "uuid" = $2
+)`,
+		userUUID,
+		transactionUUID,
+	).Scan(&owned)
+	if err != nil {
+		return errors.Wrapf(err, "failed to check ownership of transaction `%s`", transactionUUID)
+	}
+	if !owned {
+		return ErrNoSuchTransaction
+	}
+
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO "tags" ("user_uuid", "created_at", "modified_at", "name")
+SELECT $1, NOW(), NOW(), UNNEST($2::varchar[])
+ON CONFLICT ("user_uuid", "name") DO NOTHING`,
+		userUUID,
+		pq.Array(tags),
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to insert into tags table")
+	}
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM "transaction_tags" WHERE "transaction_uuid" = $1`, transactionUUID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to clear tags for transaction `%s`", transactionUUID)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+INSERT INTO "transaction_tags" ("transaction_uuid", "tag_uuid", "created_at")
+SELECT $1, "uuid", NOW() FROM "tags"
+WHERE "user_uuid" = $2 AND "name" = ANY($3::varchar[])`,
+		transactionUUID,
+		userUUID,
+		pq.Array(tags),
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to tag transaction `%s`", transactionUUID)
+	}
+
+	return errors.Wrap(tx.Commit(), "failed to commit transaction")
+}
+
+//GetTransactionTags lists the names of the tags on a transaction the
+//user can see, whoever tagged it
+func (a *DBAgent) GetTransactionTags(ctx context.Context, userUUID string, transactionUUID string) ([]string, error) {
+	rows, err := a.db.QueryContext(ctx, `
+SELECT "tags"."name" FROM "tags"
+JOIN "transaction_tags" ON "transaction_tags"."tag_uuid" = "tags"."uuid"
+JOIN "transactions" ON "transactions"."uuid" = "transaction_tags"."transaction_uuid"
+WHERE
+	"transactions"."account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
+	AND "transaction_tags"."transaction_uuid" = $2
+ORDER BY "tags"."name"`,
+		userUUID,
+		transactionUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get tags for transaction `%s`", transactionUUID)
+	}
+	defer rows.Close()
+
+	tags := []string{}
+	for rows.Next() {
+		var tag string
+		if err := rows.Scan(&tag); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan tags for transaction `%s`", transactionUUID)
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, errors.Wrapf(rows.Err(), "failed to scan tags for transaction `%s`", transactionUUID)
+}
+
+//GetTagsForTransactions lists the names of the tags on several
+//transactions the user can see, keyed by transaction UUID
+func (a *DBAgent) GetTagsForTransactions(ctx context.Context, userUUID string, transactionUUIDs []string) (map[string][]string, error) {
+	rows, err := a.db.QueryContext(ctx, `
+SELECT "transaction_tags"."transaction_uuid", "tags"."name" FROM "tags"
+JOIN "transaction_tags" ON "transaction_tags"."tag_uuid" = "tags"."uuid"
+JOIN "transactions" ON "transactions"."uuid" = "transaction_tags"."transaction_uuid"
+WHERE
+	"transactions"."account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
+	AND "transaction_tags"."transaction_uuid" = ANY($2::uuid[])
+ORDER BY "tags"."name"`,
+		userUUID,
+		pq.Array(transactionUUIDs),
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get tags for %d transactions", len(transactionUUIDs))
+	}
+	defer rows.Close()
+
+	tags := map[string][]string{}
+	for rows.Next() {
+		var transactionUUID, tag string
+		if err := rows.Scan(&transactionUUID, &tag); err != nil {
+			return nil, errors.Wrapf(err, "failed to scan tags for %d transactions", len(transactionUUIDs))
+		}
+		tags[transactionUUID] = append(tags[transactionUUID], tag)
+	}
+
+	return tags, errors.Wrapf(rows.Err(), "failed to scan tags for %d transactions", len(transactionUUIDs))
+}
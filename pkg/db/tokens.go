@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+//ErrNoSuchPersonalAccessToken indicates that a personal access token
+//doesn't exist, isn't owned by the user, or has been revoked or expired
+var ErrNoSuchPersonalAccessToken = errors.New("no such personal access token")
+
+//EnsurePersonalAccessTokensTable creates the personal_access_tokens
+//table. Tokens are looked up by the hash of their value, which is never
+//stored.
+func (a *DBAgent) EnsurePersonalAccessTokensTable(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "personal_access_tokens"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"user_uuid" UUID REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"name" varchar NOT NULL,
+	"token_hash" varchar NOT NULL,
+	"scopes" varchar[] NOT NULL,
+	"expires_at" timestamp NOT NULL,
+	"last_used_at" timestamp,
+	PRIMARY KEY ("uuid"),
+	UNIQUE ("token_hash")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure personal_access_tokens table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "personal_access_tokens_user_uuid_idx" ON personal_access_tokens USING btree(user_uuid)`)
+	return errors.Wrap(err, "failed to ensure user_uuid index for personal_access_tokens")
+}
+
+const personalAccessTokenFieldNameList = `
+	"uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"name",
+	"scopes",
+	"expires_at",
+	"last_used_at"
+`
+
+func scanPersonalAccessToken(row scanner) (PersonalAccessToken, error) {
+	var token PersonalAccessToken
+	err := row.Scan(
+		&token.UUID,
+		&token.UserUUID,
+		&token.CreatedAt,
+		&token.ModifiedAt,
+
+		&token.Name,
+		pq.Array(&token.Scopes),
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+	)
+	return token, err
+}
+
+//CreatePersonalAccessToken inserts a personal access token into the table
+func (a *DBAgent) CreatePersonalAccessToken(ctx context.Context, token PersonalAccessToken) (string, error) {
+	row := a.db.QueryRowContext(ctx, `
+INSERT INTO "personal_access_tokens" (
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"name",
+	"token_hash",
+	"scopes",
+	"expires_at"
+) VALUES (
+	$1, NOW(), NOW(),
+	$2, $3, $4, $5
+) RETURNING "uuid"`,
+		token.UserUUID,
+
+		token.Name,
+		token.TokenHash,
+		pq.Array(token.Scopes),
+		token.ExpiresAt,
+	)
+
+	var uuid string
+	err := row.Scan(&uuid)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to insert into personal_access_tokens table")
+	}
+	return uuid, nil
+}
+
+//GetPersonalAccessTokens lists the user's unrevoked personal access
+//tokens, including expired ones
+func (a *DBAgent) GetPersonalAccessTokens(ctx context.Context, userUUID string) ([]PersonalAccessToken, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "personal_access_tokens"
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+ORDER BY "created_at"`, personalAccessTokenFieldNameList),
+		userUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get personal access tokens from table")
+	}
+	defer rows.Close()
+
+	tokens := []PersonalAccessToken{}
+	for rows.Next() {
+		token, err := scanPersonalAccessToken(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan personal access tokens for user %s", userUUID)
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, errors.Wrapf(rows.Err(), "failed to scan personal access tokens for user %s", userUUID)
+}
+
+//UsePersonalAccessToken gets the unrevoked, unexpired token with the
+//given hash, and records that it was used
+func (a *DBAgent) UsePersonalAccessToken(ctx context.Context, tokenHash string) (PersonalAccessToken, error) {
+	token, err := scanPersonalAccessToken(a.db.QueryRowContext(ctx, fmt.Sprintf(`
+UPDATE "personal_access_tokens"
+SET "last_used_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "token_hash" = $1
+	AND "expires_at" > NOW()
+RETURNING %s`, personalAccessTokenFieldNameList),
+		tokenHash,
+	))
+	if err == sql.ErrNoRows {
+		return PersonalAccessToken{}, ErrNoSuchPersonalAccessToken
+	}
+	if err != nil {
+		return PersonalAccessToken{}, errors.Wrap(err, "failed to use personal access token")
+	}
+	return token, nil
+}
+
+//RevokePersonalAccessToken soft-deletes one of the user's personal
+//access tokens
+func (a *DBAgent) RevokePersonalAccessToken(ctx context.Context, userUUID string, uuid string) error {
+	res, err := a.db.ExecContext(ctx, `
+UPDATE "personal_access_tokens"
+SET
+	"deleted_at" = NOW(),
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+	AND "uuid" = $2`,
+		userUUID,
+		uuid,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to revoke personal access token `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to revoke personal access token `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchPersonalAccessToken
+	}
+	return nil
+}
@@ -2,7 +2,10 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
@@ -34,6 +37,25 @@ CREATE TABLE IF NOT EXISTS "transactions"
 		return errors.Wrapf(err, "failed to ensure transactions table")
 	}
 
+	_, err = a.db.ExecContext(ctx, `ALTER TABLE "transactions" ADD COLUMN IF NOT EXISTS "note" varchar`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure note column for transactions")
+	}
+
+	_, err = a.db.ExecContext(ctx, `ALTER TABLE "transactions" ADD COLUMN IF NOT EXISTS "fitid" varchar`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure fitid column for transactions")
+	}
+
+	//statement imports look up an account's transactions by FITID
+	_, err = a.db.ExecContext(ctx, `
+CREATE INDEX IF NOT EXISTS "transactions_account_uuid_fitid_idx"
+ON transactions USING btree(account_uuid, fitid)
+WHERE "deleted_at" IS NULL AND "fitid" <> ''`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure account_uuid, fitid index for transactions")
+	}
+
 	_, err = a.db.ExecContext(ctx, `CREATE INDEX ON transactions USING btree(account_uuid)`)
 	if err != nil {
 		return errors.Wrap(err, "failed to ensure account_uuid index for transactions")
@@ -44,9 +66,175 @@ CREATE TABLE IF NOT EXISTS "transactions"
 		return errors.Wrap(err, "failed to ensure plaid_transaction_id index for transactions")
 	}
 
+	//Earlier versions never matched existing rows on upsert, so retire
+	//any duplicate copies of a Plaid transaction before enforcing
+	//uniqueness, keeping the oldest one. Whatever the user added to a
+	//copy is moved onto the one that's kept first: notes are combined,
+	//tags and attachments are moved over, splits are moved unless the
+	//kept copy has its own, and transfers are moved unless that would
+	//clash with another pairing, in which case they're retired too. The
+	//annotation tables are skipped when they don't exist yet.
+	_, err = a.db.ExecContext(ctx, `
+DO $$
+DECLARE
+	moved RECORD;
+BEGIN
+	CREATE TEMPORARY TABLE "duplicate_transactions" ON COMMIT DROP AS
+	SELECT "uuid", "survivor_uuid", "created_at", "n" FROM (
+		SELECT
+			"uuid",
+			"created_at",
+			FIRST_VALUE("uuid") OVER "plaid_copies" AS "survivor_uuid",
+			ROW_NUMBER() OVER "plaid_copies" AS "n",
+			COUNT(*) OVER (PARTITION BY "plaid_transaction_id") AS "count"
+		FROM "transactions"
+		WHERE "deleted_at" IS NULL AND "plaid_transaction_id" <> ''
+		WINDOW "plaid_copies" AS (PARTITION BY "plaid_transaction_id" ORDER BY "created_at", "uuid")
+	) AS "copies"
+	WHERE "count" > 1;
+
+	IF NOT EXISTS (SELECT 1 FROM "duplicate_transactions") THEN
+		RETURN;
+	END IF;
+
+	UPDATE "transactions"
+	SET "note" = "merged"."note", "modified_at" = NOW()
+	FROM (
+		SELECT "survivor_uuid", STRING_AGG("note", E'\n' ORDER BY "created_at") AS "note"
+		FROM (
+			SELECT "copies"."survivor_uuid", "transactions"."note", MIN("copies"."created_at") AS "created_at"
+			FROM "duplicate_transactions" AS "copies"
+			JOIN "transactions" ON "transactions"."uuid" = "copies"."uuid"
+			WHERE COALESCE("transactions"."note", '') <> ''
+			GROUP BY "copies"."survivor_uuid", "transactions"."note"
+		) AS "notes"
+		GROUP BY "survivor_uuid"
+	) AS "merged"
+	WHERE
+		"transactions"."uuid" = "merged"."survivor_uuid"
+		AND "transactions"."note" IS DISTINCT FROM "merged"."note";
+
+	IF to_regclass('transaction_tags') IS NOT NULL THEN
+		INSERT INTO "transaction_tags" ("transaction_uuid", "tag_uuid", "created_at")
+		SELECT "copies"."survivor_uuid", "transaction_tags"."tag_uuid", MIN("transaction_tags"."created_at")
+		FROM "transaction_tags"
+		JOIN "duplicate_transactions" AS "copies" ON "copies"."uuid" = "transaction_tags"."transaction_uuid"
+		WHERE "copies"."n" > 1
+		GROUP BY "copies"."survivor_uuid", "transaction_tags"."tag_uuid"
+		ON CONFLICT DO NOTHING;
+
+		DELETE FROM "transaction_tags"
+		USING "duplicate_transactions" AS "copies"
+		WHERE "copies"."uuid" = "transaction_tags"."transaction_uuid" AND "copies"."n" > 1;
+	END IF;
+
+	IF to_regclass('attachments') IS NOT NULL THEN
+		UPDATE "attachments"
+		SET "transaction_uuid" = "copies"."survivor_uuid", "modified_at" = NOW()
+		FROM "duplicate_transactions" AS "copies"
+		WHERE "copies"."uuid" = "attachments"."transaction_uuid" AND "copies"."n" > 1;
+	END IF;
+
+	--the splits of the earliest copy that has any are kept, so that a
+	--transaction is never split twice over
+	IF to_regclass('transaction_splits') IS NOT NULL THEN
+		UPDATE "transaction_splits"
+		SET "transaction_uuid" = "copies"."survivor_uuid", "modified_at" = NOW()
+		FROM "duplicate_transactions" AS "copies"
+		WHERE
+			"copies"."uuid" = "transaction_splits"."transaction_uuid"
+			AND "copies"."n" > 1
+			AND "transaction_splits"."deleted_at" IS NULL
+			AND "copies"."uuid" = (
+				SELECT "split_copies"."uuid"
+				FROM "duplicate_transactions" AS "split_copies"
+				WHERE
+					"split_copies"."survivor_uuid" = "copies"."survivor_uuid"
+					AND EXISTS (
+						SELECT 1 FROM "transaction_splits" AS "splits"
+						WHERE "splits"."transaction_uuid" = "split_copies"."uuid" AND "splits"."deleted_at" IS NULL
+					)
+				ORDER BY "split_copies"."n"
+				LIMIT 1
+			);
+	END IF;
+
+	IF to_regclass('transfers') IS NOT NULL THEN
+		FOR moved IN
+			SELECT
+				"transfers"."uuid",
+				"transfers"."status",
+				COALESCE("outflow"."survivor_uuid", "transfers"."outflow_transaction_uuid") AS "outflow_uuid",
+				COALESCE("inflow"."survivor_uuid", "transfers"."inflow_transaction_uuid") AS "inflow_uuid"
+			FROM "transfers"
+			LEFT JOIN "duplicate_transactions" AS "outflow"
+				ON "outflow"."uuid" = "transfers"."outflow_transaction_uuid" AND "outflow"."n" > 1
+			LEFT JOIN "duplicate_transactions" AS "inflow"
+				ON "inflow"."uuid" = "transfers"."inflow_transaction_uuid" AND "inflow"."n" > 1
+			WHERE
+				"transfers"."deleted_at" IS NULL
+				AND ("outflow"."uuid" IS NOT NULL OR "inflow"."uuid" IS NOT NULL)
+			ORDER BY "transfers"."created_at"
+		LOOP
+			IF EXISTS (
+				SELECT 1 FROM "transfers"
+				WHERE "uuid" <> moved."uuid" AND (
+					("outflow_transaction_uuid" = moved."outflow_uuid" AND "inflow_transaction_uuid" = moved."inflow_uuid")
+					OR (
+						moved."status" <> 'rejected' AND "status" <> 'rejected' AND "deleted_at" IS NULL
+						AND ("outflow_transaction_uuid" = moved."outflow_uuid" OR "inflow_transaction_uuid" = moved."inflow_uuid")
+					)
+				)
+			) THEN
+				UPDATE "transfers" SET "deleted_at" = NOW() WHERE "uuid" = moved."uuid";
+			ELSE
+				UPDATE "transfers"
+				SET
+					"outflow_transaction_uuid" = moved."outflow_uuid",
+					"inflow_transaction_uuid" = moved."inflow_uuid",
+					"modified_at" = NOW()
+				WHERE "uuid" = moved."uuid";
+			END IF;
+		END LOOP;
+	END IF;
+
+	UPDATE "transactions"
+	SET "deleted_at" = NOW()
+	FROM "duplicate_transactions" AS "copies"
+	WHERE "copies"."uuid" = "transactions"."uuid" AND "copies"."n" > 1;
+END
+$$`)
+	if err != nil {
+		return errors.Wrap(err, "failed to merge duplicate plaid transactions")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+CREATE UNIQUE INDEX IF NOT EXISTS "transactions_plaid_transaction_id_key"
+ON transactions USING btree(plaid_transaction_id)
+WHERE "deleted_at" IS NULL AND "plaid_transaction_id" <> ''`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure plaid_transaction_id unique index for transactions")
+	}
+
+	//reports scan a user's transactions over a date range
+	_, err = a.db.ExecContext(ctx, `
+CREATE INDEX IF NOT EXISTS "transactions_user_uuid_date_idx"
+ON transactions USING btree(user_uuid, date)
+WHERE "deleted_at" IS NULL`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure user_uuid, date index for transactions")
+	}
+
 	return nil
 }
 
+//ErrNoSuchTransaction indicates that a transaction doesn't exist or
+//isn't visible to the user
+var ErrNoSuchTransaction = errors.New("no such transaction")
+
+//UpsertTransaction inserts a transaction, or updates the existing copy
+//of the same Plaid transaction. The boolean result is true for inserts,
+//and the UUID is empty if the existing copy was already up to date.
 func (a *DBAgent) UpsertTransaction(ctx context.Context, transaction Transaction) (string, bool, error) {
 	row := a.db.QueryRowContext(ctx, `
 INSERT INTO "transactions" (
@@ -66,17 +254,35 @@ INSERT INTO "transactions" (
 	"plaid_pending_transaction_id",
 	"plaid_account_owner",
 	"plaid_transaction_id",
-	"plaid_type"
+	"plaid_type",
+
+	"fitid"
 ) VALUES (
 	$1, $2, NOW(), NOW(),
 	$3, $4, $5,
-	$6, $7, $8, $9, $10, $11, $12, $13
-) ON CONFLICT ("uuid")
+	$6, $7, $8, $9, $10, $11, $12, $13,
+	$14
+) ON CONFLICT ("plaid_transaction_id") WHERE "deleted_at" IS NULL AND "plaid_transaction_id" <> ''
 DO UPDATE SET
 	"modified_at" = NOW(),
-	"amount" = $5,
-	"plaid_pending" = $9,
-	"plaid_pending_transaction_id" = $10
+	"amount" = EXCLUDED."amount",
+	"date" = EXCLUDED."date",
+	"plaid_name" = EXCLUDED."plaid_name",
+	"plaid_pending" = EXCLUDED."plaid_pending",
+	"plaid_pending_transaction_id" = EXCLUDED."plaid_pending_transaction_id"
+WHERE (
+	"transactions"."amount",
+	"transactions"."date",
+	"transactions"."plaid_name",
+	"transactions"."plaid_pending",
+	"transactions"."plaid_pending_transaction_id"
+) IS DISTINCT FROM (
+	EXCLUDED."amount",
+	EXCLUDED."date",
+	EXCLUDED."plaid_name",
+	EXCLUDED."plaid_pending",
+	EXCLUDED."plaid_pending_transaction_id"
+)
 RETURNING "uuid", "created_at" = "modified_at"`,
 		transaction.AccountUUID,
 		transaction.UserUUID,
@@ -93,88 +299,243 @@ RETURNING "uuid", "created_at" = "modified_at"`,
 		transaction.PlaidAccountOwner,
 		transaction.PlaidID,
 		transaction.PlaidType,
+
+		transaction.FITID,
 	)
 
 	var isNew bool
 	var uuid string
 	err := row.Scan(&uuid, &isNew)
+	if err == sql.ErrNoRows {
+		return "", false, nil
+	}
 	return uuid, isNew, errors.Wrapf(err, "failed to upsert to transactions table for plaid transaction %s", transaction.PlaidID)
 }
 
-func (a *DBAgent) DeleteTransactionByPlaidID(ctx context.Context, plaidTransactionID string) error {
-	_, err := a.db.ExecContext(ctx, `
-UPDATE "accounts"
+//DeleteTransactionByPlaidID soft-deletes a transaction that Plaid has
+//removed, and returns it
+func (a *DBAgent) DeleteTransactionByPlaidID(ctx context.Context, plaidTransactionID string) (Transaction, error) {
+	transaction, err := scanTransaction(a.db.QueryRowContext(ctx, fmt.Sprintf(`
+UPDATE "transactions"
 SET "deleted_at" = NOW()
-WHERE "plaid_transaction_id" = $1`,
+WHERE
+	"deleted_at" IS NULL
+	AND "plaid_transaction_id" = $1
+RETURNING %s`, StandardTransactionFieldNameList),
 		plaidTransactionID,
-	)
-	return errors.Wrapf(err, "failed to insert into transactions table")
+	))
+	if err == sql.ErrNoRows {
+		return Transaction{}, ErrNoSuchTransaction
+	}
+	if err != nil {
+		return Transaction{}, errors.Wrapf(err, "failed to delete plaid transaction `%s`", plaidTransactionID)
+	}
+	return transaction, nil
 }
 
+//GetTransactions gets all the transactions for an account the user can see
 func (a *DBAgent) GetTransactions(ctx context.Context, userUUID string, accountUUID string) ([]Transaction, error) {
 	//TODO pagination
-	rows, err := a.db.QueryContext(ctx, `
-SELECT
-	"uuid",
-	"account_uuid",
-	"user_uuid",
-	"created_at",
-	"modified_at",
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "transactions"
+WHERE
+	"deleted_at" IS NULL
+	AND "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
+	AND "account_uuid" = $2
+ORDER BY "date", "plaid_transaction_id"
+`, StandardTransactionFieldNameList),
+		userUUID,
+		accountUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get transactions from table")
+	}
+	defer rows.Close()
 
-	"iso_currency_code",
-	"amount",
-	"date",
+	var transactions []Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan result of querying for all transactions for account %s", accountUUID)
+		}
 
-	"plaid_account_id",
-	"plaid_name",
-	"plaid_category_id",
-	"plaid_pending",
-	"plaid_pending_transaction_id",
-	"plaid_account_owner",
-	"plaid_transaction_id",
-	"plaid_type"
-FROM "accounts"
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, errors.Wrapf(rows.Err(), "failed to scan result of querying for all transactions for account %s", accountUUID)
+}
+
+//GetTransactionsByDateRange gets the transactions for an account the
+//user can see that were posted between two dates, inclusive
+func (a *DBAgent) GetTransactionsByDateRange(ctx context.Context, userUUID string, accountUUID string, startDate string, endDate string) ([]Transaction, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "transactions"
 WHERE
 	"deleted_at" IS NULL
-	AND "user_uuid" = $1
+	AND "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
 	AND "account_uuid" = $2
-`,
+	AND "date" >= $3
+	AND "date" <= $4
+ORDER BY "date", "plaid_transaction_id"
+`, StandardTransactionFieldNameList),
 		userUUID,
 		accountUUID,
+		startDate,
+		endDate,
 	)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get transactions from table")
 	}
+	defer rows.Close()
 
 	var transactions []Transaction
 	for rows.Next() {
-		var transaction Transaction
-		err = rows.Scan(
-			&transaction.UUID,
-			&transaction.AccountUUID,
-			&transaction.UserUUID,
-			&transaction.CreatedAt,
-			&transaction.ModifiedAt,
-
-			&transaction.ISOCurrencyCode,
-			&transaction.Amount,
-			&transaction.Date,
-
-			&transaction.PlaidAccountID,
-			&transaction.PlaidName,
-			&transaction.PlaidCategoryID,
-			&transaction.PlaidPending,
-			&transaction.PlaidPendingTransactionID,
-			&transaction.PlaidAccountOwner,
-			&transaction.PlaidID,
-			&transaction.PlaidType,
-		)
+		transaction, err := scanTransaction(rows)
 		if err != nil {
-			return nil, errors.Wrapf(err, "failed to scan result of querying for all transactions for account %s", accountUUID)
+			return nil, errors.Wrapf(err, "failed to scan result of querying for transactions for account %s", accountUUID)
 		}
 
 		transactions = append(transactions, transaction)
 	}
 
-	return transactions, nil
+	return transactions, errors.Wrapf(rows.Err(), "failed to scan result of querying for transactions for account %s", accountUUID)
+}
+
+//GetTransaction gets a single transaction the user can see
+func (a *DBAgent) GetTransaction(ctx context.Context, userUUID string, uuid string) (Transaction, error) {
+	row := a.db.QueryRowContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "transactions"
+WHERE
+	"deleted_at" IS NULL
+	AND "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
+	AND "uuid" = $2
+`, StandardTransactionFieldNameList),
+		userUUID,
+		uuid,
+	)
+
+	transaction, err := scanTransaction(row)
+	if err == sql.ErrNoRows {
+		return Transaction{}, ErrNoSuchTransaction
+	}
+	if err != nil {
+		return Transaction{}, errors.Wrapf(err, "failed to get transaction `%s`", uuid)
+	}
+	return transaction, nil
+}
+
+//UpdateTransactionNote sets the free-text note on a transaction the user
+//can edit
+func (a *DBAgent) UpdateTransactionNote(ctx context.Context, userUUID string, uuid string, note string) error {
+	res, err := a.db.ExecContext(ctx, `
+UPDATE "transactions"
+SET
+	"note" = $1,
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $2 AND "level" <> 'read')
+	AND "uuid" = $3`,
+		note,
+		userUUID,
+		uuid,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update note for transaction `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to update note for transaction `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchTransaction
+	}
+	return nil
+}
+
+//GetUserTransactionsByDateRange gets the transactions across all of the
+//accounts the user can see that were posted between two dates, inclusive
+func (a *DBAgent) GetUserTransactionsByDateRange(ctx context.Context, userUUID string, startDate string, endDate string) ([]Transaction, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "transactions"
+WHERE
+	"deleted_at" IS NULL
+	AND "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
+	AND "date" >= $2
+	AND "date" <= $3
+ORDER BY "date", "plaid_transaction_id"
+`, StandardTransactionFieldNameList),
+		userUUID,
+		startDate,
+		endDate,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get transactions from table")
+	}
+	defer rows.Close()
+
+	var transactions []Transaction
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan result of querying for transactions for user %s", userUUID)
+		}
+
+		transactions = append(transactions, transaction)
+	}
+
+	return transactions, errors.Wrapf(rows.Err(), "failed to scan result of querying for transactions for user %s", userUUID)
+}
+
+//GetAccountsTransactionsPage gets a page of transactions for each of
+//several of the accounts the user can see, newest first. Dashboards list recent
+//activity for every account at once, so this is done in one query.
+func (a *DBAgent) GetAccountsTransactionsPage(ctx context.Context, userUUID string, accountUUIDs []string, page TransactionPage) (map[string][]Transaction, error) {
+	args := []interface{}{userUUID, pq.Array(accountUUIDs)}
+	var conditions string
+	if page.StartDate != "" {
+		args = append(args, page.StartDate)
+		conditions += fmt.Sprintf(` AND "date" >= $%d`, len(args))
+	}
+	if page.EndDate != "" {
+		args = append(args, page.EndDate)
+		conditions += fmt.Sprintf(` AND "date" <= $%d`, len(args))
+	}
+	if page.After != nil {
+		args = append(args, page.After.Date, page.After.UUID)
+		conditions += fmt.Sprintf(` AND ("date", "uuid") < ($%d, $%d::uuid)`, len(args)-1, len(args))
+	}
+	args = append(args, page.Limit)
+
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %[1]s FROM (
+	SELECT *, ROW_NUMBER() OVER (PARTITION BY "account_uuid" ORDER BY "date" DESC, "uuid" DESC) AS "n"
+	FROM "transactions"
+	WHERE
+		"deleted_at" IS NULL
+		AND "account_uuid" IN (SELECT "account_uuid" FROM "account_access" WHERE "user_uuid" = $1)
+		AND "account_uuid" = ANY($2::uuid[])%[2]s
+) AS "page"
+WHERE "n" <= $%[3]d
+ORDER BY "account_uuid", "date" DESC, "uuid" DESC
+`, StandardTransactionFieldNameList, conditions, len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get transactions from table")
+	}
+	defer rows.Close()
+
+	transactions := map[string][]Transaction{}
+	for rows.Next() {
+		transaction, err := scanTransaction(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan result of querying for transactions for user %s", userUUID)
+		}
+
+		transactions[transaction.AccountUUID] = append(transactions[transaction.AccountUUID], transaction)
+	}
+
+	return transactions, errors.Wrapf(rows.Err(), "failed to scan result of querying for transactions for user %s", userUUID)
 }
@@ -0,0 +1,206 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+//ErrNoSuchTransfer indicates that a transfer doesn't exist or isn't
+//owned by the user
+var ErrNoSuchTransfer = errors.New("no such transfer")
+
+//ErrTransferConflict indicates that one side of a transfer already
+//belongs to a different transfer that hasn't been rejected
+var ErrTransferConflict = errors.New("transaction is already part of another transfer")
+
+//EnsureTransfersTable creates the transfers table along with the
+//reportable_allocations view
+func (a *DBAgent) EnsureTransfersTable(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "transfers"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"user_uuid" UUID REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"outflow_transaction_uuid" UUID REFERENCES transactions(uuid),
+	"inflow_transaction_uuid" UUID REFERENCES transactions(uuid),
+	"status" varchar NOT NULL,
+	PRIMARY KEY ("uuid"),
+	UNIQUE ("outflow_transaction_uuid", "inflow_transaction_uuid")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure transfers table")
+	}
+
+	//a transaction can be part of any number of rejected pairings,
+	//but only one that the user hasn't rejected
+	_, err = a.db.ExecContext(ctx, `
+CREATE UNIQUE INDEX IF NOT EXISTS "transfers_outflow_transaction_uuid_key"
+ON transfers USING btree(outflow_transaction_uuid)
+WHERE "deleted_at" IS NULL AND "status" <> 'rejected'`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure outflow_transaction_uuid index for transfers")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+CREATE UNIQUE INDEX IF NOT EXISTS "transfers_inflow_transaction_uuid_key"
+ON transfers USING btree(inflow_transaction_uuid)
+WHERE "deleted_at" IS NULL AND "status" <> 'rejected'`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure inflow_transaction_uuid index for transfers")
+	}
+
+	//transfers were once detected across accounts shared with the user,
+	//pairing transactions that belong to someone else
+	_, err = a.db.ExecContext(ctx, `
+UPDATE "transfers"
+SET "deleted_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND EXISTS (
+		SELECT 1 FROM "transactions"
+		WHERE
+			"transactions"."uuid" IN ("transfers"."outflow_transaction_uuid", "transfers"."inflow_transaction_uuid")
+			AND "transactions"."user_uuid" IS DISTINCT FROM "transfers"."user_uuid"
+	)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove transfers between other users' transactions")
+	}
+
+	//spend and income reports should read this view, which leaves out
+	//both sides of any transfer the owner hasn't broken apart
+	_, err = a.db.ExecContext(ctx, `
+CREATE OR REPLACE VIEW "reportable_allocations" AS
+SELECT "transaction_allocations".*
+FROM "transaction_allocations"
+WHERE NOT EXISTS (
+	SELECT 1 FROM "transfers"
+	WHERE
+		"transfers"."deleted_at" IS NULL
+		AND "transfers"."status" <> 'rejected'
+		AND "transfers"."user_uuid" = "transaction_allocations"."user_uuid"
+		AND "transaction_allocations"."transaction_uuid" IN (
+			"transfers"."outflow_transaction_uuid",
+			"transfers"."inflow_transaction_uuid"
+		)
+)`)
+	return errors.Wrap(err, "failed to ensure reportable_allocations view")
+}
+
+//CreateTransfer records a pairing between two of the user's transactions.
+//It returns an empty UUID if either transaction is already paired.
+func (a *DBAgent) CreateTransfer(ctx context.Context, transfer Transfer) (string, error) {
+	row := a.db.QueryRowContext(ctx, `
+INSERT INTO "transfers" (
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"outflow_transaction_uuid",
+	"inflow_transaction_uuid",
+	"status"
+) VALUES (
+	$1, NOW(), NOW(),
+	$2, $3, $4
+) ON CONFLICT DO NOTHING
+RETURNING "uuid"`,
+		transfer.UserUUID,
+
+		transfer.OutflowTransactionUUID,
+		transfer.InflowTransactionUUID,
+		transfer.Status,
+	)
+
+	var uuid string
+	err := row.Scan(&uuid)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to insert into transfers table")
+	}
+	return uuid, nil
+}
+
+//GetTransfers lists all of the user's transfers, including rejected ones
+func (a *DBAgent) GetTransfers(ctx context.Context, userUUID string) ([]Transfer, error) {
+	rows, err := a.db.QueryContext(ctx, `
+SELECT
+	"uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"outflow_transaction_uuid",
+	"inflow_transaction_uuid",
+	"status"
+FROM "transfers"
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+ORDER BY "created_at"`,
+		userUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get transfers from table")
+	}
+	defer rows.Close()
+
+	transfers := []Transfer{}
+	for rows.Next() {
+		var transfer Transfer
+		err := rows.Scan(
+			&transfer.UUID,
+			&transfer.UserUUID,
+			&transfer.CreatedAt,
+			&transfer.ModifiedAt,
+
+			&transfer.OutflowTransactionUUID,
+			&transfer.InflowTransactionUUID,
+			&transfer.Status,
+		)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan transfers for user `%s`", userUUID)
+		}
+		transfers = append(transfers, transfer)
+	}
+
+	return transfers, errors.Wrapf(rows.Err(), "failed to scan transfers for user `%s`", userUUID)
+}
+
+//SetTransferStatus confirms or rejects one of the user's transfers
+func (a *DBAgent) SetTransferStatus(ctx context.Context, userUUID string, uuid string, status TransferStatus) error {
+	res, err := a.db.ExecContext(ctx, `
+UPDATE "transfers"
+SET
+	"status" = $1,
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $2
+	AND "uuid" = $3`,
+		status,
+		userUUID,
+		uuid,
+	)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+		return ErrTransferConflict
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to update status of transfer `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to update status of transfer `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchTransfer
+	}
+	return nil
+}
@@ -5,9 +5,17 @@ import (
 	"database/sql"
 	"fmt"
 
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
+//ErrUserExists indicates that a user is already registered
+var ErrUserExists = errors.New("user is already registered")
+
+//ErrUserDeactivated indicates that a registered user has been
+//deactivated, and may not use the service
+var ErrUserDeactivated = errors.New("user is deactivated")
+
 //EnsureUsersTable EnsureUsersTable
 func (a *DBAgent) EnsureUsersTable(ctx context.Context) error {
 	_, err := a.db.ExecContext(ctx, `
@@ -25,34 +33,46 @@ CREATE TABLE IF NOT EXISTS "users"
 	return nil
 }
 
-//RegisterUser whitelists a user for this service
-func (a *DBAgent) RegisterUser(ctx context.Context, uuid string, email string) error {
-	_, err := a.db.ExecContext(ctx, `
+//RegisterUser whitelists a user for this service with a role
+func (a *DBAgent) RegisterUser(ctx context.Context, uuid string, email string, role string) error {
+	res, err := a.db.ExecContext(ctx, `
 INSERT INTO "users" (
 	"uuid",
 	"email",
+	"role",
 	"created_at",
 	"modified_at"
-) VALUES (
-	$1, $2, NOW(), NOW()
-)`,
-		uuid, email,
+)
+SELECT $1, $2, "name", NOW(), NOW()
+FROM "roles"
+WHERE "name" = $3`,
+		uuid, email, role,
 	)
+	if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == uniqueViolation {
+		return ErrUserExists
+	}
 	if err != nil {
 		return errors.Wrapf(err, "failed to insert into users table")
 	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to insert into users table")
+	}
+	if n == 0 {
+		return ErrNoSuchRole
+	}
 	return nil
 }
 
-//CheckUser checks if a user is whitelisted
+//CheckUser checks if a user is whitelisted and hasn't been deactivated
 func (a *DBAgent) CheckUser(ctx context.Context, uuid string) (bool, error) {
 	var tmp interface{}
 	err := a.db.QueryRowContext(ctx, `
 SELECT true FROM users
-WHERE "uuid" = $1`,
+WHERE "uuid" = $1 AND "deleted_at" IS NULL`,
 		uuid,
 	).Scan(&tmp)
-	fmt.Println("checking user", uuid, tmp)
 	if err == sql.ErrNoRows {
 		return false, nil
 	}
@@ -61,3 +81,223 @@ WHERE "uuid" = $1`,
 	}
 	return true, nil
 }
+
+const userFieldNameList = `
+	"uuid",
+	"created_at",
+	"modified_at",
+	"deleted_at",
+
+	COALESCE("email", ''),
+	"role"
+`
+
+func scanUser(row scanner) (User, error) {
+	var user User
+	err := row.Scan(
+		&user.UUID,
+		&user.CreatedAt,
+		&user.ModifiedAt,
+		&user.DeletedAt,
+
+		&user.Email,
+		&user.Role,
+	)
+	return user, err
+}
+
+//GetUsers lists registered users in order of email, including
+//deactivated ones if the page asks for them
+func (a *DBAgent) GetUsers(ctx context.Context, page UserPage) ([]User, error) {
+	var args []interface{}
+	conditions := "TRUE"
+	if !page.IncludeDeactivated {
+		conditions += ` AND "deleted_at" IS NULL`
+	}
+	if page.Query != "" {
+		args = append(args, page.Query)
+		conditions += fmt.Sprintf(` AND STRPOS(LOWER("email"), LOWER($%d)) > 0`, len(args))
+	}
+	if page.After != nil {
+		args = append(args, page.After.Email, page.After.UUID)
+		conditions += fmt.Sprintf(` AND (COALESCE("email", ''), "uuid") > ($%d, $%d::uuid)`, len(args)-1, len(args))
+	}
+	args = append(args, page.Limit)
+
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "users"
+WHERE %s
+ORDER BY COALESCE("email", ''), "uuid"
+LIMIT $%d`, userFieldNameList, conditions, len(args)),
+		args...,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get users from table")
+	}
+	defer rows.Close()
+
+	users := []User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan users")
+		}
+		users = append(users, user)
+	}
+
+	return users, errors.Wrapf(rows.Err(), "failed to scan users")
+}
+
+//GetUser gets a registered user, even if they've been deactivated
+func (a *DBAgent) GetUser(ctx context.Context, uuid string) (User, error) {
+	user, err := scanUser(a.db.QueryRowContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "users"
+WHERE "uuid" = $1`, userFieldNameList),
+		uuid,
+	))
+	if err == sql.ErrNoRows {
+		return User{}, ErrNoSuchUser
+	}
+	if err != nil {
+		return User{}, errors.Wrapf(err, "failed to get user `%s`", uuid)
+	}
+	return user, nil
+}
+
+//SetUserEmail changes a registered user's email
+func (a *DBAgent) SetUserEmail(ctx context.Context, uuid string, email string) error {
+	res, err := a.db.ExecContext(ctx, `
+UPDATE "users"
+SET
+	"email" = $1,
+	"modified_at" = NOW()
+WHERE "uuid" = $2`,
+		email,
+		uuid,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set email for user `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to set email for user `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchUser
+	}
+	return nil
+}
+
+//SetUserActive deactivates a registered user, which keeps their data but
+//locks them out, or reactivates them
+func (a *DBAgent) SetUserActive(ctx context.Context, uuid string, active bool) error {
+	res, err := a.db.ExecContext(ctx, `
+UPDATE "users"
+SET
+	"deleted_at" = CASE WHEN $1 THEN NULL ELSE COALESCE("deleted_at", NOW()) END,
+	"modified_at" = NOW()
+WHERE "uuid" = $2`,
+		active,
+		uuid,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to set active for user `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to set active for user `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchUser
+	}
+	return nil
+}
+
+//userDeletions remove everything that belongs to, or hangs off of, the
+//user in $1, in an order the foreign keys allow. Other users' alerts,
+//annotations, transfers and preferences involving the user's accounts go
+//too, as do the user's annotations on accounts shared with them.
+var userDeletions = []struct {
+	table string
+	where string
+}{
+	{"shares", `"owner_uuid" = $1 OR "grantee_uuid" = $1`},
+	{"alert_deliveries", `"user_uuid" = $1 OR "alert_uuid" IN (
+		SELECT "uuid" FROM "alerts"
+		WHERE "user_uuid" = $1 OR "account_uuid" IN (SELECT "uuid" FROM "accounts" WHERE "user_uuid" = $1)
+	)`},
+	{"alerts", `"user_uuid" = $1 OR "account_uuid" IN (SELECT "uuid" FROM "accounts" WHERE "user_uuid" = $1)`},
+	{"webhook_deliveries", `"user_uuid" = $1 OR "endpoint_uuid" IN (SELECT "uuid" FROM "webhook_endpoints" WHERE "user_uuid" = $1)`},
+	{"webhook_endpoints", `"user_uuid" = $1`},
+	{"transfers", `"user_uuid" = $1
+		OR "outflow_transaction_uuid" IN (SELECT "uuid" FROM "transactions" WHERE "user_uuid" = $1)
+		OR "inflow_transaction_uuid" IN (SELECT "uuid" FROM "transactions" WHERE "user_uuid" = $1)`},
+	{"transaction_splits", `"user_uuid" = $1 OR "transaction_uuid" IN (SELECT "uuid" FROM "transactions" WHERE "user_uuid" = $1)`},
+	{"tags", `"user_uuid" = $1`},
+	{"transactions", `"user_uuid" = $1`},
+	{"balances", `"user_uuid" = $1 OR "account_uuid" IN (SELECT "uuid" FROM "accounts" WHERE "user_uuid" = $1)`},
+	{"job_runs", `"user_uuid" = $1 OR "item_uuid" IN (SELECT "uuid" FROM "items" WHERE "user_uuid" = $1)`},
+	{"account_preferences", `"user_uuid" = $1 OR "account_uuid" IN (SELECT "uuid" FROM "accounts" WHERE "user_uuid" = $1)`},
+	{"accounts", `"user_uuid" = $1`},
+	{"items", `"user_uuid" = $1`},
+	{"digest_preferences", `"user_uuid" = $1`},
+	{"personal_access_tokens", `"user_uuid" = $1`},
+	{"user_identities", `"user_uuid" = $1`},
+}
+
+//DeleteUser permanently deletes a user and all of their data. It returns
+//the attachments that were deleted, whose contents are stored elsewhere.
+func (a *DBAgent) DeleteUser(ctx context.Context, uuid string) ([]Attachment, error) {
+	tx, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	//attachments are deleted first, so that their blobs can be removed
+	rows, err := tx.QueryContext(ctx, fmt.Sprintf(`
+DELETE FROM "attachments"
+WHERE "user_uuid" = $1 OR "transaction_uuid" IN (SELECT "uuid" FROM "transactions" WHERE "user_uuid" = $1)
+RETURNING %s`, attachmentFieldNameList),
+		uuid,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to delete attachments for user `%s`", uuid)
+	}
+	attachments := []Attachment{}
+	for rows.Next() {
+		var attachment Attachment
+		if err := rows.Scan((&attachment).fieldPointers()...); err != nil {
+			rows.Close()
+			return nil, errors.Wrapf(err, "failed to scan attachments for user `%s`", uuid)
+		}
+		attachments = append(attachments, attachment)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrapf(err, "failed to scan attachments for user `%s`", uuid)
+	}
+
+	for _, deletion := range userDeletions {
+		_, err := tx.ExecContext(ctx, fmt.Sprintf(`DELETE FROM %q WHERE %s`, deletion.table, deletion.where), uuid)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to delete %s for user `%s`", deletion.table, uuid)
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM "users" WHERE "uuid" = $1`, uuid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to delete user `%s`", uuid)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to delete user `%s`", uuid)
+	}
+	if n == 0 {
+		return nil, ErrNoSuchUser
+	}
+
+	return attachments, errors.Wrap(tx.Commit(), "failed to commit transaction")
+}
@@ -15,3 +15,9 @@ type UUIDGenerator struct{}
 func (UUIDGenerator) UUID() string {
 	return uuid.NewV4().String()
 }
+
+//ValidUUID reports whether s is a well-formed UUID
+func ValidUUID(s string) bool {
+	_, err := uuid.Parse(s)
+	return err == nil
+}
@@ -0,0 +1,474 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+//ErrNoSuchWebhookEndpoint indicates that a webhook endpoint doesn't
+//exist or isn't owned by the user
+var ErrNoSuchWebhookEndpoint = errors.New("no such webhook endpoint")
+
+//ErrNoSuchWebhookDelivery indicates that a webhook delivery doesn't
+//exist or isn't owned by the user
+var ErrNoSuchWebhookDelivery = errors.New("no such webhook delivery")
+
+//EnsureWebhooksTables creates the webhook_endpoints and
+//webhook_deliveries tables
+func (a *DBAgent) EnsureWebhooksTables(ctx context.Context) error {
+	_, err := a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "webhook_endpoints"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"user_uuid" UUID REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"url" varchar NOT NULL,
+	"events" varchar[] NOT NULL,
+	"secret" varchar NOT NULL,
+	PRIMARY KEY ("uuid")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure webhook_endpoints table")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "webhook_endpoints_user_uuid_idx" ON webhook_endpoints USING btree(user_uuid)`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure user_uuid index for webhook_endpoints")
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS "webhook_deliveries"
+(	"uuid" UUID DEFAULT gen_random_uuid(),
+	"endpoint_uuid" UUID REFERENCES webhook_endpoints(uuid),
+	"user_uuid" UUID REFERENCES users(uuid),
+	"created_at" timestamp NOT NULL,
+	"modified_at" timestamp NOT NULL,
+	"deleted_at" timestamp,
+
+	"event_type" varchar NOT NULL,
+	"payload" varchar NOT NULL,
+	"status" varchar NOT NULL,
+	"attempts" integer NOT NULL DEFAULT 0,
+	"next_attempt_at" timestamp,
+	"last_status_code" integer NOT NULL DEFAULT 0,
+	"last_error" varchar,
+	PRIMARY KEY ("uuid")
+)`)
+	if err != nil {
+		return errors.Wrapf(err, "failed to ensure webhook_deliveries table")
+	}
+
+	//the dispatcher polls for pending deliveries that are due
+	_, err = a.db.ExecContext(ctx, `
+CREATE INDEX IF NOT EXISTS "webhook_deliveries_next_attempt_at_idx"
+ON webhook_deliveries USING btree(next_attempt_at)
+WHERE "deleted_at" IS NULL AND "status" = 'pending'`)
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure next_attempt_at index for webhook_deliveries")
+	}
+
+	_, err = a.db.ExecContext(ctx, `CREATE INDEX IF NOT EXISTS "webhook_deliveries_endpoint_uuid_idx" ON webhook_deliveries USING btree(endpoint_uuid, created_at)`)
+	return errors.Wrap(err, "failed to ensure endpoint_uuid index for webhook_deliveries")
+}
+
+//CreateWebhookEndpoint inserts a webhook endpoint into the table
+func (a *DBAgent) CreateWebhookEndpoint(ctx context.Context, endpoint WebhookEndpoint) (string, error) {
+	events := endpoint.Events
+	if events == nil {
+		events = []string{}
+	}
+
+	row := a.db.QueryRowContext(ctx, `
+INSERT INTO "webhook_endpoints" (
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"url",
+	"events",
+	"secret"
+) VALUES (
+	$1, NOW(), NOW(),
+	$2, $3, $4
+) RETURNING "uuid"`,
+		endpoint.UserUUID,
+
+		endpoint.URL,
+		pq.Array(events),
+		endpoint.Secret,
+	)
+
+	var uuid string
+	err := row.Scan(&uuid)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to insert into webhook_endpoints table")
+	}
+	return uuid, nil
+}
+
+const webhookEndpointFieldNameList = `
+	"uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"url",
+	"events",
+	"secret"
+`
+
+func scanWebhookEndpoint(row scanner) (WebhookEndpoint, error) {
+	var endpoint WebhookEndpoint
+	err := row.Scan(
+		&endpoint.UUID,
+		&endpoint.UserUUID,
+		&endpoint.CreatedAt,
+		&endpoint.ModifiedAt,
+
+		&endpoint.URL,
+		pq.Array(&endpoint.Events),
+		&endpoint.Secret,
+	)
+	return endpoint, err
+}
+
+//GetWebhookEndpoints lists the user's webhook endpoints
+func (a *DBAgent) GetWebhookEndpoints(ctx context.Context, userUUID string) ([]WebhookEndpoint, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "webhook_endpoints"
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+ORDER BY "created_at"`, webhookEndpointFieldNameList),
+		userUUID,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get webhook endpoints from table")
+	}
+	defer rows.Close()
+
+	endpoints := []WebhookEndpoint{}
+	for rows.Next() {
+		endpoint, err := scanWebhookEndpoint(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan webhook endpoints for user `%s`", userUUID)
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return endpoints, errors.Wrapf(rows.Err(), "failed to scan webhook endpoints for user `%s`", userUUID)
+}
+
+//GetWebhookEndpoint gets a webhook endpoint by UUID, for delivery
+func (a *DBAgent) GetWebhookEndpoint(ctx context.Context, uuid string) (WebhookEndpoint, error) {
+	endpoint, err := scanWebhookEndpoint(a.db.QueryRowContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "webhook_endpoints"
+WHERE
+	"deleted_at" IS NULL
+	AND "uuid" = $1`, webhookEndpointFieldNameList),
+		uuid,
+	))
+	if err == sql.ErrNoRows {
+		return WebhookEndpoint{}, ErrNoSuchWebhookEndpoint
+	}
+	if err != nil {
+		return WebhookEndpoint{}, errors.Wrapf(err, "failed to get webhook endpoint `%s`", uuid)
+	}
+	return endpoint, nil
+}
+
+//DeleteWebhookEndpoint soft-deletes one of the user's webhook endpoints
+//and cancels its pending deliveries
+func (a *DBAgent) DeleteWebhookEndpoint(ctx context.Context, userUUID string, uuid string) error {
+	res, err := a.db.ExecContext(ctx, `
+UPDATE "webhook_endpoints"
+SET "deleted_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+	AND "uuid" = $2`,
+		userUUID,
+		uuid,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete webhook endpoint `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete webhook endpoint `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchWebhookEndpoint
+	}
+
+	_, err = a.db.ExecContext(ctx, `
+UPDATE "webhook_deliveries"
+SET
+	"status" = $1,
+	"last_error" = 'endpoint was deleted',
+	"next_attempt_at" = NULL,
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "status" = 'pending'
+	AND "endpoint_uuid" = $2`,
+		WebhookDeliveryStatusFailed,
+		uuid,
+	)
+	return errors.Wrapf(err, "failed to cancel deliveries for webhook endpoint `%s`", uuid)
+}
+
+//CreateWebhookDelivery queues an event for a webhook endpoint, due immediately
+func (a *DBAgent) CreateWebhookDelivery(ctx context.Context, delivery WebhookDelivery) (string, error) {
+	row := a.db.QueryRowContext(ctx, `
+INSERT INTO "webhook_deliveries" (
+	"endpoint_uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"event_type",
+	"payload",
+	"status",
+	"next_attempt_at"
+) VALUES (
+	$1, $2, NOW(), NOW(),
+	$3, $4, $5, NOW()
+) RETURNING "uuid"`,
+		delivery.EndpointUUID,
+		delivery.UserUUID,
+
+		delivery.EventType,
+		delivery.Payload,
+		WebhookDeliveryStatusPending,
+	)
+
+	var uuid string
+	err := row.Scan(&uuid)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to insert into webhook_deliveries table")
+	}
+	return uuid, nil
+}
+
+const webhookDeliveryFieldNameList = `
+	"uuid",
+	"endpoint_uuid",
+	"user_uuid",
+	"created_at",
+	"modified_at",
+
+	"event_type",
+	"payload",
+	"status",
+	"attempts",
+	"next_attempt_at",
+	"last_status_code",
+	"last_error"
+`
+
+func scanWebhookDelivery(row scanner) (WebhookDelivery, error) {
+	var delivery WebhookDelivery
+	var lastError sql.NullString
+	err := row.Scan(
+		&delivery.UUID,
+		&delivery.EndpointUUID,
+		&delivery.UserUUID,
+		&delivery.CreatedAt,
+		&delivery.ModifiedAt,
+
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastStatusCode,
+		&lastError,
+	)
+	delivery.LastError = lastError.String
+	return delivery, err
+}
+
+//ClaimDueWebhookDeliveries picks up to `limit` pending deliveries that
+//are due, and pushes their next attempt back by `lease` so that no other
+//replica picks them up while they're being sent
+func (a *DBAgent) ClaimDueWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+UPDATE "webhook_deliveries"
+SET "next_attempt_at" = NOW() + $2 * INTERVAL '1 second'
+WHERE "uuid" IN (
+	SELECT "uuid" FROM "webhook_deliveries"
+	WHERE
+		"deleted_at" IS NULL
+		AND "status" = 'pending'
+		AND "next_attempt_at" <= NOW()
+	ORDER BY "next_attempt_at"
+	LIMIT $1
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING %s`, webhookDeliveryFieldNameList),
+		limit,
+		int(lease.Seconds()),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to claim webhook deliveries")
+	}
+	defer rows.Close()
+
+	deliveries := []WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan claimed webhook deliveries")
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, errors.Wrap(rows.Err(), "failed to scan claimed webhook deliveries")
+}
+
+//RecordWebhookAttempt records the outcome of an attempt to send a
+//delivery. A nil nextAttemptAt means no more attempts will be made.
+func (a *DBAgent) RecordWebhookAttempt(ctx context.Context, uuid string, status WebhookDeliveryStatus, statusCode int, errMessage string, nextAttemptAt *time.Time) error {
+	_, err := a.db.ExecContext(ctx, `
+UPDATE "webhook_deliveries"
+SET
+	"status" = $1,
+	"attempts" = "attempts" + 1,
+	"last_status_code" = $2,
+	"last_error" = $3,
+	"next_attempt_at" = $4,
+	"modified_at" = NOW()
+WHERE "uuid" = $5`,
+		status,
+		statusCode,
+		errMessage,
+		nextAttemptAt,
+		uuid,
+	)
+	return errors.Wrapf(err, "failed to record attempt for webhook delivery `%s`", uuid)
+}
+
+//GetWebhookDeliveries lists the most recent deliveries to one of the
+//user's webhook endpoints
+func (a *DBAgent) GetWebhookDeliveries(ctx context.Context, userUUID string, endpointUUID string, limit int) ([]WebhookDelivery, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "webhook_deliveries"
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+	AND "endpoint_uuid" = $2
+ORDER BY "created_at" DESC
+LIMIT $3`, webhookDeliveryFieldNameList),
+		userUUID,
+		endpointUUID,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get deliveries for webhook endpoint `%s`", endpointUUID)
+	}
+	defer rows.Close()
+
+	deliveries := []WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan deliveries for webhook endpoint `%s`", endpointUUID)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, errors.Wrapf(rows.Err(), "failed to scan deliveries for webhook endpoint `%s`", endpointUUID)
+}
+
+//GetUserWebhookDeliveries lists the most recent deliveries to any of the
+//user's webhook endpoints
+func (a *DBAgent) GetUserWebhookDeliveries(ctx context.Context, userUUID string, limit int) ([]WebhookDelivery, error) {
+	rows, err := a.db.QueryContext(ctx, fmt.Sprintf(`
+SELECT %s FROM "webhook_deliveries"
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $1
+ORDER BY "created_at" DESC
+LIMIT $2`, webhookDeliveryFieldNameList),
+		userUUID,
+		limit,
+	)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get webhook deliveries for user `%s`", userUUID)
+	}
+	defer rows.Close()
+
+	deliveries := []WebhookDelivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to scan webhook deliveries for user `%s`", userUUID)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, errors.Wrapf(rows.Err(), "failed to scan webhook deliveries for user `%s`", userUUID)
+}
+
+//RedeliverWebhookDelivery requeues one of the user's deliveries to be
+//sent again right away, with a fresh set of retries
+func (a *DBAgent) RedeliverWebhookDelivery(ctx context.Context, userUUID string, endpointUUID string, uuid string) error {
+	res, err := a.db.ExecContext(ctx, `
+UPDATE "webhook_deliveries"
+SET
+	"status" = $1,
+	"attempts" = 0,
+	"next_attempt_at" = NOW(),
+	"modified_at" = NOW()
+WHERE
+	"deleted_at" IS NULL
+	AND "user_uuid" = $2
+	AND "endpoint_uuid" = $3
+	AND "uuid" = $4
+	AND "endpoint_uuid" IN (
+		SELECT "uuid" FROM "webhook_endpoints" WHERE "deleted_at" IS NULL
+	)`,
+		WebhookDeliveryStatusPending,
+		userUUID,
+		endpointUUID,
+		uuid,
+	)
+	if err != nil {
+		return errors.Wrapf(err, "failed to requeue webhook delivery `%s`", uuid)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return errors.Wrapf(err, "failed to requeue webhook delivery `%s`", uuid)
+	}
+	if n == 0 {
+		return ErrNoSuchWebhookDelivery
+	}
+	return nil
+}
+
+//PruneWebhookDeliveries deletes finished deliveries that were queued
+//before a time
+func (a *DBAgent) PruneWebhookDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	res, err := a.db.ExecContext(ctx, `
+DELETE FROM "webhook_deliveries"
+WHERE
+	"status" <> $1
+	AND "created_at" < $2`,
+		WebhookDeliveryStatusPending,
+		before,
+	)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to prune webhook deliveries")
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,393 @@
+package digest
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"math/big"
+	"path/filepath"
+	"sort"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/lib/tools"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/notify"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+const (
+	topCategories = 8
+	topMerchants  = 5
+
+	claimBatchSize = 20
+)
+
+//Digest is one user's summary of a period
+type Digest struct {
+	Email   string
+	Cadence db.DigestCadence
+	Start   time.Time
+	End     time.Time
+
+	//Categories and Merchants are the largest spending groups, in
+	//descending order
+	Categories []db.ReportRow
+	Merchants  []db.ReportRow
+
+	Budgets  []BudgetStatus
+	Balances []BalanceChange
+
+	//Items lists connections the user has to fix
+	Items []db.Item
+}
+
+//BudgetStatus compares this month's spending to a budget
+type BudgetStatus struct {
+	//Category is empty for a budget on all spending
+	Category        string
+	ISOCurrencyCode string
+	Limit           *big.Float
+	Spent           *big.Float
+}
+
+//Over reports whether spending has passed the budget's limit
+func (b BudgetStatus) Over() bool {
+	return b.Spent.Cmp(b.Limit) > 0
+}
+
+//BalanceChange is how an account's balance moved over the period
+type BalanceChange struct {
+	AccountName     string
+	InstitutionName string
+	ISOCurrencyCode string
+	Start           *big.Float
+	End             *big.Float
+}
+
+//Change is the difference between the closing and opening balances
+func (b BalanceChange) Change() *big.Float {
+	return new(big.Float).Sub(b.End, b.Start)
+}
+
+//Builder gathers the contents of a digest
+//go:generate counterfeiter . Builder
+type Builder interface {
+	Build(ctx context.Context, due db.DueDigest, now time.Time) (Digest, error)
+}
+
+//BuilderAgent implements Builder
+type BuilderAgent struct {
+	dbClient db.DB
+}
+
+//NewBuilder creates a new BuilderAgent
+func NewBuilder(dbClient db.DB) BuilderAgent {
+	return BuilderAgent{dbClient: dbClient}
+}
+
+//Build summarizes the user's activity since their last digest, or over
+//one period of their cadence, whichever is shorter
+func (b BuilderAgent) Build(ctx context.Context, due db.DueDigest, now time.Time) (Digest, error) {
+	start := due.Cadence.Period(now)
+	if due.PreviousSentAt != nil && due.PreviousSentAt.After(start) {
+		start = *due.PreviousSentAt
+	}
+
+	d := Digest{
+		Email:   due.Email,
+		Cadence: due.Cadence,
+		Start:   start,
+		End:     now,
+	}
+	startDate, endDate := start.Format(plaidapi.DateFormat), now.Format(plaidapi.DateFormat)
+
+	var err error
+	d.Categories, err = b.dbClient.GetSpendingReport(ctx, due.UserUUID, db.ReportGroupCategory, startDate, endDate)
+	if err != nil {
+		return Digest{}, err
+	}
+	if len(d.Categories) > topCategories {
+		d.Categories = d.Categories[:topCategories]
+	}
+
+	d.Merchants, err = b.dbClient.GetSpendingReport(ctx, due.UserUUID, db.ReportGroupMerchant, startDate, endDate)
+	if err != nil {
+		return Digest{}, err
+	}
+	if len(d.Merchants) > topMerchants {
+		d.Merchants = d.Merchants[:topMerchants]
+	}
+
+	d.Budgets, err = b.budgets(ctx, due.UserUUID, now)
+	if err != nil {
+		return Digest{}, err
+	}
+
+	d.Balances, err = b.balances(ctx, due.UserUUID, startDate, endDate)
+	if err != nil {
+		return Digest{}, err
+	}
+
+	items, err := b.dbClient.GetItems(ctx, due.UserUUID)
+	if err != nil {
+		return Digest{}, err
+	}
+	for _, item := range items {
+		if item.NeedsAttention() {
+			d.Items = append(d.Items, item)
+		}
+	}
+
+	return d, nil
+}
+
+//budgets compares month-to-date spending to each of the user's budgets,
+//which are their budget_exceeded alerts
+func (b BuilderAgent) budgets(ctx context.Context, userUUID string, now time.Time) ([]BudgetStatus, error) {
+	alerts, err := b.dbClient.GetAlerts(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var budgets []db.Alert
+	seen := map[string]bool{}
+	for _, alert := range alerts {
+		if alert.Kind != db.AlertKindBudgetExceeded || alert.Threshold == nil {
+			continue
+		}
+
+		//the same budget may be set up once per notification channel
+		key := alert.Category + ":" + alert.Threshold.Text('f', 2)
+		if !seen[key] {
+			seen[key] = true
+			budgets = append(budgets, alert)
+		}
+	}
+	if len(budgets) == 0 {
+		return nil, nil
+	}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	spending, err := b.dbClient.GetSpendingReport(ctx, userUUID, db.ReportGroupCategory,
+		monthStart.Format(plaidapi.DateFormat),
+		now.Format(plaidapi.DateFormat),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []BudgetStatus
+	for _, budget := range budgets {
+		spent := map[string]*big.Float{}
+		var currencies []string
+		for _, row := range spending {
+			if budget.Category != "" && row.Key != budget.Category {
+				continue
+			}
+			if _, ok := spent[row.ISOCurrencyCode]; !ok {
+				spent[row.ISOCurrencyCode] = new(big.Float)
+				currencies = append(currencies, row.ISOCurrencyCode)
+			}
+			spent[row.ISOCurrencyCode].Add(spent[row.ISOCurrencyCode], row.Expenses)
+		}
+		sort.Strings(currencies)
+
+		if len(currencies) == 0 {
+			statuses = append(statuses, BudgetStatus{
+				Category: budget.Category,
+				Limit:    budget.Threshold,
+				Spent:    new(big.Float),
+			})
+		}
+		for _, currency := range currencies {
+			statuses = append(statuses, BudgetStatus{
+				Category:        budget.Category,
+				ISOCurrencyCode: currency,
+				Limit:           budget.Threshold,
+				Spent:           spent[currency],
+			})
+		}
+	}
+	return statuses, nil
+}
+
+//balances finds the opening and closing balance of each visible account
+func (b BuilderAgent) balances(ctx context.Context, userUUID string, startDate string, endDate string) ([]BalanceChange, error) {
+	accounts, err := b.dbClient.GetAccounts(ctx, userUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []BalanceChange
+	for _, account := range accounts {
+		if account.Hidden {
+			continue
+		}
+
+		history, err := b.dbClient.GetBalances(ctx, userUUID, account.UUID)
+		if err != nil {
+			return nil, err
+		}
+
+		//history is in date order, so the opening balance is the last
+		//snapshot on or before the start, or failing that the first one
+		//taken during the period
+		var opening, closing *db.Balance
+		for i := range history {
+			if history[i].Date > endDate {
+				break
+			}
+			if opening == nil || history[i].Date <= startDate {
+				opening = &history[i]
+			}
+			closing = &history[i]
+		}
+		if opening == nil || opening.Current == nil || closing.Current == nil {
+			continue
+		}
+
+		changes = append(changes, BalanceChange{
+			AccountName:     account.PlaidAccountName,
+			InstitutionName: account.PlaidInstitutionName,
+			ISOCurrencyCode: closing.ISOCurrencyCode,
+			Start:           opening.Current,
+			End:             closing.Current,
+		})
+	}
+	return changes, nil
+}
+
+//Renderer turns a digest into an email
+//go:generate counterfeiter . Renderer
+type Renderer interface {
+	Render(d Digest) (notify.Message, error)
+}
+
+//RendererAgent implements Renderer using a pair of templates
+type RendererAgent struct {
+	html *htmltemplate.Template
+	text *texttemplate.Template
+}
+
+var templateFuncs = map[string]interface{}{
+	"amount": func(f *big.Float) string {
+		return f.Text('f', 2)
+	},
+	"signed": func(f *big.Float) string {
+		if f.Sign() > 0 {
+			return "+" + f.Text('f', 2)
+		}
+		return f.Text('f', 2)
+	},
+	"date": func(t time.Time) string {
+		return t.Format("Jan 2, 2006")
+	},
+}
+
+//NewRenderer creates a new RendererAgent from an HTML template and a
+//plain-text template, each executed with a Digest
+func NewRenderer(htmlPath string, textPath string) (RendererAgent, error) {
+	html, err := htmltemplate.New(filepath.Base(htmlPath)).Funcs(templateFuncs).ParseFiles(htmlPath)
+	if err != nil {
+		return RendererAgent{}, errors.Wrapf(err, "failed to parse digest template `%s`", htmlPath)
+	}
+
+	text, err := texttemplate.New(filepath.Base(textPath)).Funcs(templateFuncs).ParseFiles(textPath)
+	if err != nil {
+		return RendererAgent{}, errors.Wrapf(err, "failed to parse digest template `%s`", textPath)
+	}
+
+	return RendererAgent{html: html, text: text}, nil
+}
+
+//Render builds the email for a digest
+func (r RendererAgent) Render(d Digest) (notify.Message, error) {
+	var html, text bytes.Buffer
+	if err := r.html.Execute(&html, d); err != nil {
+		return notify.Message{}, errors.Wrap(err, "failed to render digest")
+	}
+	if err := r.text.Execute(&text, d); err != nil {
+		return notify.Message{}, errors.Wrap(err, "failed to render digest")
+	}
+
+	return notify.Message{
+		To:      d.Email,
+		Event:   "digest." + string(d.Cadence),
+		Subject: fmt.Sprintf("Your %s summary: %s - %s", d.Cadence, d.Start.Format("Jan 2"), d.End.Format("Jan 2")),
+		Body:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+//Job sends every digest that's due
+//go:generate counterfeiter . Job
+type Job interface {
+	Run(ctx context.Context) error
+}
+
+//JobAgent implements Job
+type JobAgent struct {
+	logger   tools.Logger
+	dbClient db.DB
+	builder  Builder
+	renderer Renderer
+	notifier notify.Notifier
+}
+
+//NewJob creates a new JobAgent
+func NewJob(logger tools.Logger, dbClient db.DB, builder Builder, renderer Renderer, notifier notify.Notifier) JobAgent {
+	return JobAgent{
+		logger:   logger,
+		dbClient: dbClient,
+		builder:  builder,
+		renderer: renderer,
+		notifier: notifier,
+	}
+}
+
+//Run claims and sends due digests until none are left. A digest that
+//fails is released, so the next run retries it.
+func (j JobAgent) Run(ctx context.Context) error {
+	for {
+		due, err := j.dbClient.ClaimDueDigests(ctx, claimBatchSize)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for _, digest := range due {
+			if err := j.send(ctx, digest); err != nil {
+				j.logger.Errorf("failed sending digest to user `%s`: %s", digest.UserUUID, err.Error())
+				failed++
+
+				if err := j.dbClient.ReleaseDigest(ctx, digest.UserUUID, digest.PreviousSentAt); err != nil {
+					j.logger.Errorf("failed releasing digest for user `%s`: %s", digest.UserUUID, err.Error())
+				}
+			}
+		}
+
+		//released digests are due again right away, so stop rather than
+		//retrying them in a tight loop
+		if len(due) < claimBatchSize || failed > 0 {
+			return nil
+		}
+	}
+}
+
+func (j JobAgent) send(ctx context.Context, due db.DueDigest) error {
+	d, err := j.builder.Build(ctx, due, time.Now())
+	if err != nil {
+		return err
+	}
+
+	msg, err := j.renderer.Render(d)
+	if err != nil {
+		return err
+	}
+
+	return j.notifier.Notify(ctx, msg)
+}
@@ -0,0 +1,141 @@
+package digest_test
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"mime"
+	"mime/multipart"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/lib/tools/toolsfakes"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/db/dbfakes"
+	"github.com/xanderflood/plaid-ui/pkg/digest"
+	"github.com/xanderflood/plaid-ui/pkg/digest/digestfakes"
+	"github.com/xanderflood/plaid-ui/pkg/notify"
+	"github.com/xanderflood/plaid-ui/pkg/notify/notifytest"
+)
+
+const (
+	htmlTemplate = "../../build/api/templates/digest.tmpl"
+	textTemplate = "../../build/api/templates/digest_text.tmpl"
+)
+
+func TestDigestEmail(t *testing.T) {
+	smtpServer, err := notifytest.NewSMTPServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer smtpServer.Close()
+
+	renderer, err := digest.NewRenderer(htmlTemplate, textTemplate)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dbClient := &dbfakes.FakeDB{}
+	dbClient.ClaimDueDigestsReturns([]db.DueDigest{{
+		UserUUID: "user-1",
+		Email:    "user@plaid-ui.test",
+		Cadence:  db.DigestCadenceWeekly,
+	}}, nil)
+
+	builder := &digestfakes.FakeBuilder{}
+	builder.BuildReturns(digest.Digest{
+		Email:   "user@plaid-ui.test",
+		Cadence: db.DigestCadenceWeekly,
+		Start:   time.Date(2020, 3, 2, 0, 0, 0, 0, time.UTC),
+		End:     time.Date(2020, 3, 9, 0, 0, 0, 0, time.UTC),
+		Categories: []db.ReportRow{
+			{Key: "Food and Drink", Label: "Food and Drink", ISOCurrencyCode: "USD", Expenses: big.NewFloat(84.2), Count: 6},
+		},
+		Budgets: []digest.BudgetStatus{
+			{Category: "Food and Drink", ISOCurrencyCode: "USD", Limit: big.NewFloat(60), Spent: big.NewFloat(84.2)},
+		},
+		Items: []db.Item{{InstitutionName: "Crédit Agricole", Status: db.ItemStatusLoginRequired}},
+	}, nil)
+
+	logger := &toolsfakes.FakeLogger{}
+	notifier := notify.NewSMTPNotifier(smtpServer.Host, smtpServer.Port, "", "", "digests@plaid-ui.test")
+	if err := digest.NewJob(logger, dbClient, builder, renderer, notifier).Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if logger.ErrorfCallCount() > 0 {
+		format, args := logger.ErrorfArgsForCall(0)
+		t.Fatalf(format, args...)
+	}
+
+	emails := smtpServer.Emails()
+	if len(emails) != 1 {
+		t.Fatalf("%d emails were delivered, want 1", len(emails))
+	}
+	if to := emails[0].To; len(to) != 1 || to[0] != "user@plaid-ui.test" {
+		t.Errorf("email was sent to %v", to)
+	}
+
+	msg, err := emails[0].Message()
+	if err != nil {
+		t.Fatal(err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Your weekly summary: Mar 2 - Mar 9"; subject != want {
+		t.Errorf("subject = %q, want %q", subject, want)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediaType != "multipart/alternative" {
+		t.Fatalf("content type = %q, want multipart/alternative", mediaType)
+	}
+
+	parts := map[string]string{}
+	reader := multipart.NewReader(msg.Body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		//multipart.Reader decodes the quoted-printable parts
+		body, err := ioutil.ReadAll(part)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts[part.Header.Get("Content-Type")] = string(body)
+	}
+
+	text, ok := parts["text/plain; charset=utf-8"]
+	if !ok {
+		t.Fatalf("no plain-text part in %v", parts)
+	}
+	for _, want := range []string{
+		"Crédit Agricole needs you to log in again",
+		"Food and Drink: 84.20 USD (6 transactions)",
+		"Food and Drink: 84.20 of 60.00 USD - over budget",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("plain-text part is missing %q:\n%s", want, text)
+		}
+	}
+
+	html, ok := parts["text/html; charset=utf-8"]
+	if !ok {
+		t.Fatalf("no HTML part in %v", parts)
+	}
+	for _, want := range []string{"Crédit Agricole", "84.20", "60.00"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML part is missing %q:\n%s", want, html)
+		}
+	}
+
+	if dbClient.ReleaseDigestCallCount() != 0 {
+		t.Error("the digest was released after it was sent")
+	}
+}
@@ -0,0 +1,206 @@
+package forecast
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+	"github.com/xanderflood/plaid-ui/pkg/recurring"
+)
+
+//Input is everything a forecast is projected from
+type Input struct {
+	Accounts []db.Account
+
+	//Balances maps account UUIDs to their latest balance snapshot
+	Balances map[string]db.Balance
+	Series   []recurring.Series
+
+	//Transactions that posted after an account's balance snapshot
+	//was taken are applied to bring the balance up to date
+	Transactions []db.Transaction
+
+	Start     time.Time
+	Days      int
+	Threshold *big.Float
+}
+
+//Forecast is the projected balance of each account over a period
+type Forecast struct {
+	StartDate string     `json:"start_date"`
+	EndDate   string     `json:"end_date"`
+	Threshold *big.Float `json:"threshold"`
+
+	Accounts             []AccountForecast     `json:"accounts"`
+	ExpectedTransactions []ExpectedTransaction `json:"expected_transactions"`
+}
+
+//AccountForecast is the projected balance of a single account
+type AccountForecast struct {
+	AccountUUID     string     `json:"account_uuid"`
+	AccountName     string     `json:"account_name"`
+	ISOCurrencyCode string     `json:"iso_currency_code"`
+	StartingBalance *big.Float `json:"starting_balance"`
+
+	//BalanceDate is when the balance the projection starts from was
+	//reported by the institution
+	BalanceDate string `json:"balance_date"`
+
+	Days []Day `json:"days"`
+
+	//LowDays lists the dates on which the balance ends below the threshold
+	LowDays []string `json:"low_days"`
+}
+
+//Day is an account's projected balance at the end of a single day
+type Day struct {
+	Date    string     `json:"date"`
+	Balance *big.Float `json:"balance"`
+}
+
+//ExpectedTransaction is a future occurrence of a recurring series that
+//contributes to the forecast
+type ExpectedTransaction struct {
+	AccountUUID string            `json:"account_uuid"`
+	Date        string            `json:"date"`
+	Name        string            `json:"name"`
+	Amount      *big.Float        `json:"amount"`
+	Cadence     recurring.Cadence `json:"cadence"`
+}
+
+//Forecaster projects account balances forward
+//go:generate counterfeiter . Forecaster
+type Forecaster interface {
+	Forecast(input Input) Forecast
+}
+
+//ForecasterAgent implements Forecaster
+type ForecasterAgent struct{}
+
+//NewForecaster creates a new ForecasterAgent
+func NewForecaster() ForecasterAgent {
+	return ForecasterAgent{}
+}
+
+//Forecast projects the daily balance of each depository account with a
+//known balance, starting from its current balance and applying every
+//expected occurrence of the account's recurring series. Amounts follow
+//Plaid's convention, so positive amounts reduce the balance.
+func (f ForecasterAgent) Forecast(input Input) Forecast {
+	start := input.Start.Truncate(24 * time.Hour)
+	end := start.AddDate(0, 0, input.Days-1)
+
+	threshold := input.Threshold
+	if threshold == nil {
+		threshold = new(big.Float)
+	}
+	thresholdCents := toCents(threshold)
+
+	result := Forecast{
+		StartDate: start.Format(plaidapi.DateFormat),
+		EndDate:   end.Format(plaidapi.DateFormat),
+		Threshold: threshold,
+
+		Accounts:             []AccountForecast{},
+		ExpectedTransactions: []ExpectedTransaction{},
+	}
+
+	for _, account := range input.Accounts {
+		if account.PlaidAccountType != plaidapi.AccountTypeDepository {
+			continue
+		}
+		balance, ok := input.Balances[account.UUID]
+		if !ok || balance.Current == nil {
+			continue
+		}
+
+		changes := map[string]int64{}
+		for _, series := range input.Series {
+			if series.AccountUUID != account.UUID || series.Amount == nil {
+				continue
+			}
+
+			for _, date := range occurrences(series, start, end) {
+				changes[date] -= toCents(series.Amount)
+				result.ExpectedTransactions = append(result.ExpectedTransactions, ExpectedTransaction{
+					AccountUUID: account.UUID,
+					Date:        date,
+					Name:        series.Name,
+					Amount:      series.Amount,
+					Cadence:     series.Cadence,
+				})
+			}
+		}
+
+		projection := AccountForecast{
+			AccountUUID:     account.UUID,
+			AccountName:     account.PlaidAccountName,
+			ISOCurrencyCode: balance.ISOCurrencyCode,
+			BalanceDate:     balance.Date,
+
+			Days:    []Day{},
+			LowDays: []string{},
+		}
+
+		running := toCents(balance.Current)
+		for _, t := range input.Transactions {
+			if t.AccountUUID == account.UUID && t.Amount != nil && !t.PlaidPending &&
+				t.Date > balance.Date && t.Date < result.StartDate {
+				running -= toCents(t.Amount)
+			}
+		}
+		projection.StartingBalance = fromCents(running)
+
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			date := day.Format(plaidapi.DateFormat)
+			running += changes[date]
+
+			projection.Days = append(projection.Days, Day{Date: date, Balance: fromCents(running)})
+			if running < thresholdCents {
+				projection.LowDays = append(projection.LowDays, date)
+			}
+		}
+
+		result.Accounts = append(result.Accounts, projection)
+	}
+
+	sort.SliceStable(result.ExpectedTransactions, func(i, j int) bool {
+		return result.ExpectedTransactions[i].Date < result.ExpectedTransactions[j].Date
+	})
+
+	return result
+}
+
+//occurrences lists the dates between start and end, inclusive, on which
+//a series is expected to recur
+func occurrences(series recurring.Series, start, end time.Time) []string {
+	next, err := time.Parse(plaidapi.DateFormat, series.NextDate)
+	if err != nil {
+		return nil
+	}
+
+	//an overdue occurrence is assumed to land on the first day
+	var dates []string
+	for ; !next.After(end); next = series.Cadence.Next(next) {
+		date := next
+		if date.Before(start) {
+			date = start
+		}
+		dates = append(dates, date.Format(plaidapi.DateFormat))
+	}
+	return dates
+}
+
+func toCents(f *big.Float) int64 {
+	v, _ := f.Float64()
+	if v < 0 {
+		return int64(v*100 - 0.5)
+	}
+	return int64(v*100 + 0.5)
+}
+
+func fromCents(cents int64) *big.Float {
+	return new(big.Float).Quo(new(big.Float).SetInt64(cents), big.NewFloat(100))
+}
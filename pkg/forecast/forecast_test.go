@@ -0,0 +1,147 @@
+package forecast_test
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/forecast"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+	"github.com/xanderflood/plaid-ui/pkg/recurring"
+)
+
+func amount(s string) *big.Float {
+	f, ok := new(big.Float).SetString(s)
+	if !ok {
+		panic(s)
+	}
+	return f
+}
+
+func depository(uuid string) db.Account {
+	return db.Account{Model: db.Model{UUID: uuid}, PlaidAccountName: "Checking " + uuid, PlaidAccountType: plaidapi.AccountTypeDepository}
+}
+
+func balances(f forecast.AccountForecast) map[string]string {
+	days := map[string]string{}
+	for _, day := range f.Days {
+		days[day.Date] = day.Balance.Text('f', 2)
+	}
+	return days
+}
+
+func TestForecast(t *testing.T) {
+	result := forecast.NewForecaster().Forecast(forecast.Input{
+		Accounts: []db.Account{depository("acct-1")},
+		Balances: map[string]db.Balance{
+			"acct-1": {Date: "2020-01-01", ISOCurrencyCode: "USD", Current: amount("1000")},
+		},
+		Series: []recurring.Series{
+			{AccountUUID: "acct-1", Name: "Rent", Amount: amount("800"), Cadence: recurring.CadenceMonthly, NextDate: "2020-01-05"},
+			{AccountUUID: "acct-1", Name: "Paycheck", Amount: amount("-500"), Cadence: recurring.CadenceWeekly, NextDate: "2020-01-06"},
+			//overdue, so expected on the first day
+			{AccountUUID: "acct-1", Name: "Gym", Amount: amount("10.01"), Cadence: recurring.CadenceWeekly, NextDate: "2020-01-01"},
+			//other accounts' series, and ones without a typical amount
+			{AccountUUID: "acct-2", Name: "Rent", Amount: amount("800"), Cadence: recurring.CadenceMonthly, NextDate: "2020-01-05"},
+			{AccountUUID: "acct-1", Name: "Unknown", Cadence: recurring.CadenceWeekly, NextDate: "2020-01-04"},
+		},
+		Transactions: []db.Transaction{
+			//posted since the balance was reported
+			{AccountUUID: "acct-1", Date: "2020-01-02", Amount: amount("50")},
+			//already counted in the balance, still pending, already
+			//forecast, or for another account
+			{AccountUUID: "acct-1", Date: "2020-01-01", Amount: amount("1")},
+			{AccountUUID: "acct-1", Date: "2020-01-02", Amount: amount("2"), PlaidPending: true},
+			{AccountUUID: "acct-1", Date: "2020-01-03", Amount: amount("4")},
+			{AccountUUID: "acct-2", Date: "2020-01-02", Amount: amount("8")},
+		},
+		Start:     time.Date(2020, 1, 3, 15, 30, 0, 0, time.UTC),
+		Days:      5,
+		Threshold: amount("200"),
+	})
+
+	if result.StartDate != "2020-01-03" || result.EndDate != "2020-01-07" {
+		t.Errorf("forecast from %s to %s", result.StartDate, result.EndDate)
+	}
+	if len(result.Accounts) != 1 {
+		t.Fatalf("forecast %d accounts, want 1", len(result.Accounts))
+	}
+
+	account := result.Accounts[0]
+	if account.AccountUUID != "acct-1" || account.AccountName != "Checking acct-1" || account.ISOCurrencyCode != "USD" || account.BalanceDate != "2020-01-01" {
+		t.Errorf("account = %+v", account)
+	}
+	if got := account.StartingBalance.Text('f', 2); got != "950.00" {
+		t.Errorf("starting balance = %s, want 950.00", got)
+	}
+	want := map[string]string{
+		"2020-01-03": "939.99",
+		"2020-01-04": "939.99",
+		"2020-01-05": "139.99",
+		"2020-01-06": "639.99",
+		"2020-01-07": "639.99",
+	}
+	if got := balances(account); !reflect.DeepEqual(got, want) {
+		t.Errorf("balances = %v, want %v", got, want)
+	}
+	if len(account.Days) != 5 || account.Days[0].Date != "2020-01-03" || account.Days[4].Date != "2020-01-07" {
+		t.Errorf("days = %v", account.Days)
+	}
+	if !reflect.DeepEqual(account.LowDays, []string{"2020-01-05"}) {
+		t.Errorf("low days = %v", account.LowDays)
+	}
+
+	var expected []string
+	for _, e := range result.ExpectedTransactions {
+		expected = append(expected, e.Date+" "+e.Name)
+	}
+	if want := []string{"2020-01-03 Gym", "2020-01-05 Rent", "2020-01-06 Paycheck"}; !reflect.DeepEqual(expected, want) {
+		t.Errorf("expected transactions = %v, want %v", expected, want)
+	}
+}
+
+func TestForecastSkipsAccounts(t *testing.T) {
+	credit := depository("acct-credit")
+	credit.PlaidAccountType = plaidapi.AccountTypeCredit
+
+	result := forecast.NewForecaster().Forecast(forecast.Input{
+		Accounts: []db.Account{credit, depository("acct-no-balance"), depository("acct-no-current"), depository("acct-1")},
+		Balances: map[string]db.Balance{
+			"acct-credit":     {Date: "2020-01-01", Current: amount("100")},
+			"acct-no-current": {Date: "2020-01-01"},
+			"acct-1":          {Date: "2020-01-01", Current: amount("-5")},
+		},
+		Start: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Days:  2,
+	})
+
+	if len(result.Accounts) != 1 || result.Accounts[0].AccountUUID != "acct-1" {
+		t.Fatalf("forecast %v", result.Accounts)
+	}
+	//with no threshold, days below zero are low
+	if result.Threshold == nil || result.Threshold.Sign() != 0 {
+		t.Errorf("threshold = %v, want 0", result.Threshold)
+	}
+	if low := result.Accounts[0].LowDays; !reflect.DeepEqual(low, []string{"2020-01-01", "2020-01-02"}) {
+		t.Errorf("low days = %v", low)
+	}
+	if result.ExpectedTransactions == nil {
+		t.Error("expected transactions are null")
+	}
+}
+
+func TestForecastNoAccounts(t *testing.T) {
+	result := forecast.NewForecaster().Forecast(forecast.Input{
+		Start: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		Days:  30,
+	})
+
+	if result.StartDate != "2020-01-01" || result.EndDate != "2020-01-30" {
+		t.Errorf("forecast from %s to %s", result.StartDate, result.EndDate)
+	}
+	if result.Accounts == nil || len(result.Accounts) != 0 {
+		t.Errorf("accounts = %v, want []", result.Accounts)
+	}
+}
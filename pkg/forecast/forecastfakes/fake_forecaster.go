@@ -0,0 +1,111 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package forecastfakes
+
+import (
+	"sync"
+
+	"github.com/xanderflood/plaid-ui/pkg/forecast"
+)
+
+type FakeForecaster struct {
+	ForecastStub        func(forecast.Input) forecast.Forecast
+	forecastMutex       sync.RWMutex
+	forecastArgsForCall []struct {
+		arg1 forecast.Input
+	}
+	forecastReturns struct {
+		result1 forecast.Forecast
+	}
+	forecastReturnsOnCall map[int]struct {
+		result1 forecast.Forecast
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeForecaster) Forecast(arg1 forecast.Input) forecast.Forecast {
+	fake.forecastMutex.Lock()
+	ret, specificReturn := fake.forecastReturnsOnCall[len(fake.forecastArgsForCall)]
+	fake.forecastArgsForCall = append(fake.forecastArgsForCall, struct {
+		arg1 forecast.Input
+	}{arg1})
+	stub := fake.ForecastStub
+	fakeReturns := fake.forecastReturns
+	fake.recordInvocation("Forecast", []interface{}{arg1})
+	fake.forecastMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeForecaster) ForecastCallCount() int {
+	fake.forecastMutex.RLock()
+	defer fake.forecastMutex.RUnlock()
+	return len(fake.forecastArgsForCall)
+}
+
+func (fake *FakeForecaster) ForecastCalls(stub func(forecast.Input) forecast.Forecast) {
+	fake.forecastMutex.Lock()
+	defer fake.forecastMutex.Unlock()
+	fake.ForecastStub = stub
+}
+
+func (fake *FakeForecaster) ForecastArgsForCall(i int) forecast.Input {
+	fake.forecastMutex.RLock()
+	defer fake.forecastMutex.RUnlock()
+	argsForCall := fake.forecastArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeForecaster) ForecastReturns(result1 forecast.Forecast) {
+	fake.forecastMutex.Lock()
+	defer fake.forecastMutex.Unlock()
+	fake.ForecastStub = nil
+	fake.forecastReturns = struct {
+		result1 forecast.Forecast
+	}{result1}
+}
+
+func (fake *FakeForecaster) ForecastReturnsOnCall(i int, result1 forecast.Forecast) {
+	fake.forecastMutex.Lock()
+	defer fake.forecastMutex.Unlock()
+	fake.ForecastStub = nil
+	if fake.forecastReturnsOnCall == nil {
+		fake.forecastReturnsOnCall = make(map[int]struct {
+			result1 forecast.Forecast
+		})
+	}
+	fake.forecastReturnsOnCall[i] = struct {
+		result1 forecast.Forecast
+	}{result1}
+}
+
+func (fake *FakeForecaster) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.forecastMutex.RLock()
+	defer fake.forecastMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeForecaster) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ forecast.Forecaster = new(FakeForecaster)
@@ -0,0 +1,110 @@
+package graphql
+
+//Location is a position in a query, counted from 1
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+//document is a parsed query document
+type document struct {
+	operations []*operation
+	fragments  map[string]*fragment
+}
+
+type operation struct {
+	kind       string
+	name       string
+	variables  []*variableDefinition
+	directives []*directive
+	selections []selection
+	loc        Location
+}
+
+type variableDefinition struct {
+	name         string
+	typ          typeRef
+	defaultValue value
+	loc          Location
+}
+
+//typeRef is a type as written in a variable definition
+type typeRef struct {
+	name    string
+	list    *typeRef
+	nonNull bool
+}
+
+type fragment struct {
+	name          string
+	typeCondition string
+	directives    []*directive
+	selections    []selection
+	loc           Location
+}
+
+//selection is a *field, *fragmentSpread or *inlineFragment
+type selection interface {
+	location() Location
+}
+
+type field struct {
+	alias      string
+	name       string
+	arguments  []*argument
+	directives []*directive
+	selections []selection
+	loc        Location
+}
+
+//responseKey is the name the field's value is returned under
+func (f *field) responseKey() string {
+	if f.alias != "" {
+		return f.alias
+	}
+	return f.name
+}
+
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+	loc        Location
+}
+
+type inlineFragment struct {
+	typeCondition string
+	directives    []*directive
+	selections    []selection
+	loc           Location
+}
+
+func (f *field) location() Location          { return f.loc }
+func (f *fragmentSpread) location() Location { return f.loc }
+func (f *inlineFragment) location() Location { return f.loc }
+
+type argument struct {
+	name  string
+	value value
+	loc   Location
+}
+
+type directive struct {
+	name      string
+	arguments []*argument
+	loc       Location
+}
+
+//value is a literal or variable in a query
+type value interface{}
+
+type (
+	variableValue string
+	intValue      string
+	floatValue    string
+	stringValue   string
+	booleanValue  bool
+	nullValue     struct{}
+	enumValue     string
+	listValue     []value
+	objectValue   map[string]value
+)
@@ -0,0 +1,548 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+//Request is a query and its variables
+type Request struct {
+	Query         string
+	OperationName string
+	Variables     map[string]interface{}
+}
+
+//Response is the result of a query. Data is omitted when the query
+//couldn't be run at all.
+type Response struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []*Error        `json:"errors,omitempty"`
+}
+
+//Error is a problem with a query or one of its fields
+type Error struct {
+	Message   string        `json:"message"`
+	Locations []Location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (e *Error) Error() string {
+	if len(e.Locations) == 0 {
+		return e.Message
+	}
+	return fmt.Sprintf("%s (line %d, column %d)", e.Message, e.Locations[0].Line, e.Locations[0].Column)
+}
+
+func syntaxError(loc Location, format string, args ...interface{}) error {
+	return &Error{
+		Message:   "syntax error: " + fmt.Sprintf(format, args...),
+		Locations: []Location{loc},
+	}
+}
+
+//Execute runs a query
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	doc, err := parse(req.Query)
+	if err != nil {
+		return &Response{Errors: []*Error{err.(*Error)}}
+	}
+
+	op, errs := s.validate(doc, req.OperationName)
+	if len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+
+	vars, errs := s.coerceVariables(op, req.Variables)
+	if len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+
+	e := &executor{
+		ctx:       ctx,
+		fragments: doc.fragments,
+		vars:      vars,
+	}
+	return e.run(s.Query, op)
+}
+
+//result is an object or list in the response. Results are built top
+//down, so each one points at its parent for null propagation.
+type result struct {
+	parent   *result
+	nullable bool
+	null     bool
+	list     bool
+	keys     []string
+	values   []interface{}
+}
+
+//fail nulls the nearest result that's allowed to be null, after a
+//non-null field or list item was null
+func (r *result) fail() {
+	for ; r != nil; r = r.parent {
+		if r.nullable {
+			r.null = true
+			return
+		}
+	}
+}
+
+func (r *result) writeJSON(buf *bytes.Buffer) error {
+	if r.null {
+		buf.WriteString("null")
+		return nil
+	}
+
+	open, close := byte('{'), byte('}')
+	if r.list {
+		open, close = '[', ']'
+	}
+	buf.WriteByte(open)
+	for i, v := range r.values {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if !r.list {
+			key, _ := json.Marshal(r.keys[i])
+			buf.Write(key)
+			buf.WriteByte(':')
+		}
+		if child, ok := v.(*result); ok {
+			if err := child.writeJSON(buf); err != nil {
+				return err
+			}
+			continue
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+	buf.WriteByte(close)
+	return nil
+}
+
+//pending is an object whose fields are resolved in the next level
+type pending struct {
+	typ        *Object
+	source     interface{}
+	selections []selection
+	out        *result
+	path       []interface{}
+}
+
+//resolved is a field value waiting to be completed
+type resolved struct {
+	parent *pending
+	index  int
+	def    *Field
+	fields []*field
+	value  interface{}
+	err    error
+}
+
+type executor struct {
+	ctx       context.Context
+	fragments map[string]*fragment
+	vars      map[string]interface{}
+	errors    []*Error
+}
+
+func (e *executor) run(query *Object, op *operation) *Response {
+	root := &result{nullable: true}
+	level := []*pending{{typ: query, selections: op.selections, out: root}}
+
+	for len(level) > 0 {
+		if err := e.ctx.Err(); err != nil {
+			e.errors = append(e.errors, &Error{Message: err.Error()})
+			root.null = true
+			break
+		}
+
+		var fields []*resolved
+		for _, p := range level {
+			fields = append(fields, e.resolveFields(p)...)
+		}
+
+		//every resolver at this level has been called, so forcing the
+		//thunks lets each loader fetch everything it was asked for at once
+		for _, r := range fields {
+			for r.err == nil {
+				thunk, ok := r.value.(Thunk)
+				if !ok {
+					break
+				}
+				r.value, r.err = thunk()
+			}
+		}
+
+		var next []*pending
+		for _, r := range fields {
+			var selections []selection
+			for _, f := range r.fields {
+				selections = append(selections, f.selections...)
+			}
+			path := appendPath(r.parent.path, r.parent.out.keys[r.index])
+			e.complete(r.parent.out, r.index, r.def.Type, selections, r.value, r.err, r.fields[0].loc, path, &next)
+		}
+		level = next
+	}
+
+	var buf bytes.Buffer
+	if err := root.writeJSON(&buf); err != nil {
+		e.errors = append(e.errors, &Error{Message: "failed encoding the result: " + err.Error()})
+		buf.Reset()
+		buf.WriteString("null")
+	}
+	return &Response{Data: buf.Bytes(), Errors: e.errors}
+}
+
+//resolveFields calls the resolvers for the fields selected on an object
+func (e *executor) resolveFields(p *pending) []*resolved {
+	keys, grouped := e.collectFields(p.typ, p.selections, nil, map[string][]*field{}, map[string]bool{})
+
+	fields := make([]*resolved, 0, len(keys))
+	for _, key := range keys {
+		p.out.keys = append(p.out.keys, key)
+		p.out.values = append(p.out.values, nil)
+		index := len(p.out.keys) - 1
+
+		f := grouped[key][0]
+		if f.name == "__typename" {
+			p.out.values[index] = p.typ.Name
+			continue
+		}
+
+		r := &resolved{
+			parent: p,
+			index:  index,
+			def:    p.typ.Field(f.name),
+			fields: grouped[key],
+		}
+		for _, other := range r.fields[1:] {
+			if other.name != f.name {
+				r.err = fmt.Errorf("`%s` selects both %s and %s", key, f.name, other.name)
+			}
+		}
+		if r.err == nil {
+			var args map[string]interface{}
+			if args, r.err = e.coerceArguments(r.def, f); r.err == nil {
+				r.value, r.err = r.def.Resolve(e.ctx, p.source, args)
+			}
+		}
+		fields = append(fields, r)
+	}
+	return fields
+}
+
+//collectFields groups the selected fields by response key, following
+//fragments and dropping fields skipped by directives
+func (e *executor) collectFields(t *Object, selections []selection, keys []string, grouped map[string][]*field, visited map[string]bool) ([]string, map[string][]*field) {
+	for _, sel := range selections {
+		switch sel := sel.(type) {
+		case *field:
+			if !e.included(sel.directives) {
+				continue
+			}
+			key := sel.responseKey()
+			if _, ok := grouped[key]; !ok {
+				keys = append(keys, key)
+			}
+			grouped[key] = append(grouped[key], sel)
+
+		case *fragmentSpread:
+			if visited[sel.name] || !e.included(sel.directives) {
+				continue
+			}
+			visited[sel.name] = true
+			frag := e.fragments[sel.name]
+			if frag.typeCondition == t.Name {
+				keys, grouped = e.collectFields(t, frag.selections, keys, grouped, visited)
+			}
+
+		case *inlineFragment:
+			if !e.included(sel.directives) {
+				continue
+			}
+			if sel.typeCondition == "" || sel.typeCondition == t.Name {
+				keys, grouped = e.collectFields(t, sel.selections, keys, grouped, visited)
+			}
+		}
+	}
+	return keys, grouped
+}
+
+//included evaluates @skip and @include
+func (e *executor) included(directives []*directive) bool {
+	for _, d := range directives {
+		cond, _, _ := coerceLiteral(NonNullOf(Boolean), d.arguments[0].value, e.vars)
+		if d.name == "skip" && cond == true {
+			return false
+		}
+		if d.name == "include" && cond == false {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *executor) coerceArguments(def *Field, f *field) (map[string]interface{}, error) {
+	args := map[string]interface{}{}
+	for _, arg := range def.Args {
+		if arg.Default != nil {
+			args[arg.Name] = arg.Default
+		}
+	}
+	for _, arg := range f.arguments {
+		v, present, err := coerceLiteral(def.argument(arg.name).Type, arg.value, e.vars)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %s", arg.name, err)
+		}
+		if present {
+			args[arg.name] = v
+		}
+	}
+	for _, arg := range def.Args {
+		if _, ok := arg.Type.(*NonNull); ok && args[arg.Name] == nil {
+			return nil, fmt.Errorf("argument %s is required", arg.Name)
+		}
+	}
+	return args, nil
+}
+
+//complete stores a resolved value in its parent, queueing objects to
+//have their own fields resolved
+func (e *executor) complete(parent *result, index int, t Type, selections []selection, value interface{}, err error, loc Location, path []interface{}, next *[]*pending) {
+	nonNull, isNonNull := t.(*NonNull)
+	if isNonNull {
+		t = nonNull.Of
+	}
+
+	if err == nil && !isNil(value) {
+		switch t := t.(type) {
+		case *Scalar:
+			parent.values[index], err = t.Serialize(value)
+			if err == nil {
+				return
+			}
+
+		case *Object:
+			out := &result{parent: parent, nullable: !isNonNull}
+			parent.values[index] = out
+			*next = append(*next, &pending{
+				typ:        t,
+				source:     value,
+				selections: selections,
+				out:        out,
+				path:       path,
+			})
+			return
+
+		case *List:
+			items := reflect.ValueOf(value)
+			if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+				err = fmt.Errorf("expected a list, got %T", value)
+				break
+			}
+			out := &result{
+				parent:   parent,
+				nullable: !isNonNull,
+				list:     true,
+				values:   make([]interface{}, items.Len()),
+			}
+			parent.values[index] = out
+			for i := 0; i < items.Len(); i++ {
+				e.complete(out, i, t.Of, selections, items.Index(i).Interface(), nil, loc, appendPath(path, i), next)
+			}
+			return
+		}
+	}
+
+	parent.values[index] = nil
+	if err != nil {
+		e.errors = append(e.errors, &Error{Message: err.Error(), Locations: []Location{loc}, Path: path})
+	} else if isNonNull {
+		e.errors = append(e.errors, &Error{Message: "cannot return null for a non-null field", Locations: []Location{loc}, Path: path})
+	}
+	if isNonNull {
+		parent.fail()
+	}
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func:
+		return rv.IsNil()
+	}
+	return false
+}
+
+func appendPath(path []interface{}, elem interface{}) []interface{} {
+	out := make([]interface{}, len(path), len(path)+1)
+	copy(out, path)
+	return append(out, elem)
+}
+
+//coerceVariables checks the supplied variables against their definitions
+func (s *Schema) coerceVariables(op *operation, supplied map[string]interface{}) (map[string]interface{}, []*Error) {
+	vars := map[string]interface{}{}
+
+	var errs []*Error
+	for _, def := range op.variables {
+		t := s.inputType(def.typ)
+
+		v, ok := supplied[def.name]
+		if !ok {
+			if def.defaultValue != nil {
+				v, _, err := coerceLiteral(t, def.defaultValue, nil)
+				if err != nil {
+					errs = append(errs, &Error{Message: fmt.Sprintf("variable $%s: %s", def.name, err), Locations: []Location{def.loc}})
+				}
+				vars[def.name] = v
+			} else if _, required := t.(*NonNull); required {
+				errs = append(errs, &Error{Message: fmt.Sprintf("variable $%s is required", def.name), Locations: []Location{def.loc}})
+			}
+			continue
+		}
+
+		v, err := coerceInput(t, v)
+		if err != nil {
+			errs = append(errs, &Error{Message: fmt.Sprintf("variable $%s: %s", def.name, err), Locations: []Location{def.loc}})
+			continue
+		}
+		vars[def.name] = v
+	}
+	return vars, errs
+}
+
+//inputType converts a validated variable type to a schema type
+func (s *Schema) inputType(ref typeRef) Type {
+	var t Type
+	if ref.list != nil {
+		t = ListOf(s.inputType(*ref.list))
+	} else {
+		t = s.types[ref.name]
+	}
+	if ref.nonNull {
+		t = NonNullOf(t)
+	}
+	return t
+}
+
+//coerceInput converts a variable's JSON value to the value of type t
+func coerceInput(t Type, v interface{}) (interface{}, error) {
+	if nonNull, ok := t.(*NonNull); ok {
+		if v == nil {
+			return nil, fmt.Errorf("expected %s, got null", t)
+		}
+		t = nonNull.Of
+	}
+	if v == nil {
+		return nil, nil
+	}
+
+	switch t := t.(type) {
+	case *List:
+		items, ok := v.([]interface{})
+		if !ok {
+			item, err := coerceInput(t.Of, v)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{item}, nil
+		}
+		out := make([]interface{}, len(items))
+		for i, item := range items {
+			var err error
+			if out[i], err = coerceInput(t.Of, item); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+
+	case *Scalar:
+		return t.Parse(v)
+	}
+	return nil, fmt.Errorf("%s isn't an input type", t)
+}
+
+//coerceLiteral converts a value written in the query to the value of type
+//t. It reports whether the value is present, since a reference to a
+//variable that wasn't supplied leaves the argument unset.
+func coerceLiteral(t Type, v value, vars map[string]interface{}) (interface{}, bool, error) {
+	if name, ok := v.(variableValue); ok {
+		val, present := vars[string(name)]
+		if _, required := t.(*NonNull); required && present && val == nil {
+			return nil, false, fmt.Errorf("expected %s, got null", t)
+		}
+		return val, present, nil
+	}
+
+	if nonNull, ok := t.(*NonNull); ok {
+		if _, null := v.(nullValue); null {
+			return nil, false, fmt.Errorf("expected %s, got null", t)
+		}
+		t = nonNull.Of
+	}
+
+	var raw interface{}
+	switch v := v.(type) {
+	case nullValue:
+		return nil, true, nil
+	case intValue:
+		n, err := strconv.Atoi(string(v))
+		if err != nil {
+			return nil, false, fmt.Errorf("%s is out of range", v)
+		}
+		raw = n
+	case floatValue:
+		f, err := strconv.ParseFloat(string(v), 64)
+		if err != nil {
+			return nil, false, fmt.Errorf("%s is out of range", v)
+		}
+		raw = f
+	case stringValue:
+		raw = string(v)
+	case booleanValue:
+		raw = bool(v)
+	case listValue:
+		list, ok := t.(*List)
+		if !ok {
+			return nil, false, fmt.Errorf("expected %s, got a list", t)
+		}
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			val, _, err := coerceLiteral(list.Of, item, vars)
+			if err != nil {
+				return nil, false, err
+			}
+			out[i] = val
+		}
+		return out, true, nil
+	default:
+		return nil, false, fmt.Errorf("expected %s", t)
+	}
+
+	if list, ok := t.(*List); ok {
+		item, _, err := coerceLiteral(list.Of, v, vars)
+		if err != nil {
+			return nil, false, err
+		}
+		return []interface{}{item}, true, nil
+	}
+	scalar, ok := t.(*Scalar)
+	if !ok {
+		return nil, false, fmt.Errorf("expected %s", t)
+	}
+	val, err := scalar.Parse(raw)
+	return val, true, err
+}
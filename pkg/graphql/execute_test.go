@@ -0,0 +1,237 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+type testPerson struct {
+	Name    string
+	Age     int
+	Friends []string
+}
+
+var testPeople = map[string]testPerson{
+	"ada":   {Name: "ada", Age: 36, Friends: []string{"bob", "cy"}},
+	"bob":   {Name: "bob", Age: 41, Friends: []string{"ada"}},
+	"cy":    {Name: "cy", Friends: []string{"ada", "bob"}},
+	"ghost": {Name: "ghost", Friends: []string{"nobody"}},
+}
+
+type testLoaderKey struct{}
+
+//newTestSchema builds a schema of people and their friends. Friends are
+//fetched through the Loader that newTestContext puts in the context.
+func newTestSchema(t *testing.T) *Schema {
+	t.Helper()
+
+	person := NewObject("Person", "Someone")
+	person.AddField(&Field{
+		Name: "name",
+		Type: NonNullOf(String),
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return source.(testPerson).Name, nil
+		},
+	}).AddField(&Field{
+		Name: "age",
+		Type: Int,
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			if age := source.(testPerson).Age; age != 0 {
+				return age, nil
+			}
+			return nil, nil
+		},
+	}).AddField(&Field{
+		Name: "friends",
+		Type: NonNullOf(ListOf(NonNullOf(person))),
+		Args: []*Argument{{Name: "first", Type: Int, Default: 10}},
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			names := source.(testPerson).Friends
+			if first := args["first"].(int); first < len(names) {
+				names = names[:first]
+			}
+
+			loader := ctx.Value(testLoaderKey{}).(*Loader)
+			thunks := make([]Thunk, len(names))
+			for i, name := range names {
+				thunks[i] = loader.Load(ctx, name)
+			}
+			return Thunk(func() (interface{}, error) {
+				friends := make([]interface{}, len(thunks))
+				for i, thunk := range thunks {
+					var err error
+					if friends[i], err = thunk(); err != nil {
+						return nil, err
+					}
+				}
+				return friends, nil
+			}), nil
+		},
+	}).AddField(&Field{
+		Name: "fail",
+		Type: NonNullOf(String),
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return nil, errors.New("failed")
+		},
+	})
+
+	query := NewObject("Query", "")
+	query.AddField(&Field{
+		Name: "person",
+		Type: person,
+		Args: []*Argument{{Name: "name", Type: NonNullOf(ID)}},
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			if p, ok := testPeople[args["name"].(string)]; ok {
+				return p, nil
+			}
+			return nil, nil
+		},
+	}).AddField(&Field{
+		Name: "everyone",
+		Type: NonNullOf(ListOf(NonNullOf(person))),
+		Resolve: func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+			return []testPerson{testPeople["ada"], testPeople["bob"], testPeople["cy"]}, nil
+		},
+	})
+
+	schema, err := NewSchema(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schema
+}
+
+//newTestContext makes a Loader over testPeople, returning the context
+//it's stored in and the batches it was asked for
+func newTestContext() (context.Context, *[][]string) {
+	var batches [][]string
+	loader := NewLoader(func(ctx context.Context, keys []string) ([]interface{}, error) {
+		batches = append(batches, keys)
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			p, ok := testPeople[key]
+			if !ok {
+				return nil, errors.Errorf("no such person `%s`", key)
+			}
+			values[i] = p
+		}
+		return values, nil
+	})
+	return context.WithValue(context.Background(), testLoaderKey{}, loader), &batches
+}
+
+func execute(t *testing.T, schema *Schema, query string, variables map[string]interface{}) *Response {
+	t.Helper()
+	ctx, _ := newTestContext()
+	return schema.Execute(ctx, Request{Query: query, Variables: variables})
+}
+
+func assertData(t *testing.T, resp *Response, want string) {
+	t.Helper()
+
+	var got, expected interface{}
+	if err := json.Unmarshal(resp.Data, &got); err != nil {
+		t.Fatalf("data isn't JSON: %s\n%s", err, resp.Data)
+	}
+	if err := json.Unmarshal([]byte(want), &expected); err != nil {
+		t.Fatal(err)
+	}
+	gotJSON, _ := json.Marshal(got)
+	expectedJSON, _ := json.Marshal(expected)
+	if string(gotJSON) != string(expectedJSON) {
+		t.Errorf("data = %s, want %s", gotJSON, expectedJSON)
+	}
+}
+
+func assertErrors(t *testing.T, resp *Response, want ...string) {
+	t.Helper()
+
+	if len(resp.Errors) != len(want) {
+		t.Fatalf("got errors %v, want %v", resp.Errors, want)
+	}
+	for i, err := range resp.Errors {
+		if err.Message != want[i] {
+			t.Errorf("error %d = %q, want %q", i, err.Message, want[i])
+		}
+	}
+}
+
+func TestExecute(t *testing.T) {
+	resp := execute(t, newTestSchema(t), `
+		query Person($name: ID!, $withAge: Boolean = false) {
+			person(name: $name) {
+				__typename
+				name
+				years: age @include(if: $withAge)
+				friends(first: 1) { name age }
+			}
+			missing: person(name: "nobody") { name }
+		}
+	`, map[string]interface{}{"name": "ada", "withAge": true})
+
+	assertErrors(t, resp)
+	assertData(t, resp, `{
+		"person": {
+			"__typename": "Person",
+			"name": "ada",
+			"years": 36,
+			"friends": [{"name": "bob", "age": 41}]
+		},
+		"missing": null
+	}`)
+}
+
+func TestExecuteMergesFields(t *testing.T) {
+	resp := execute(t, newTestSchema(t), `
+		{ person(name: "cy") { name ...F ... on Person { age friends { name } } } }
+		fragment F on Person { name friends { age } }
+	`, nil)
+
+	assertErrors(t, resp)
+	assertData(t, resp, `{"person": {"name": "cy", "age": null, "friends": [{"age": 36, "name": "ada"}, {"age": 41, "name": "bob"}]}}`)
+}
+
+func TestExecuteNullPropagation(t *testing.T) {
+	resp := execute(t, newTestSchema(t), `{ person(name: "bob") { name fail } everyone { name } }`, nil)
+
+	assertErrors(t, resp, "failed")
+	if path := resp.Errors[0].Path; len(path) != 2 || path[0] != "person" || path[1] != "fail" {
+		t.Errorf("error path = %v", path)
+	}
+	//fail is non-null, so the error nulls out the nearest nullable parent
+	assertData(t, resp, `{"person": null, "everyone": [{"name": "ada"}, {"name": "bob"}, {"name": "cy"}]}`)
+}
+
+func TestExecuteNullPropagationToRoot(t *testing.T) {
+	resp := execute(t, newTestSchema(t), `{ everyone { name fail } }`, nil)
+
+	assertErrors(t, resp, "failed", "failed", "failed")
+	assertData(t, resp, `null`)
+}
+
+func TestExecuteVariableErrors(t *testing.T) {
+	schema := newTestSchema(t)
+
+	resp := execute(t, schema, `query($name: ID!) { person(name: $name) { name } }`, nil)
+	if len(resp.Errors) != 1 || resp.Data != nil {
+		t.Errorf("a missing required variable gave %s and %v", resp.Data, resp.Errors)
+	}
+
+	resp = execute(t, schema, `query($name: ID!) { person(name: $name) { name } }`, map[string]interface{}{"name": true})
+	if len(resp.Errors) != 1 || resp.Data != nil {
+		t.Errorf("a variable of the wrong type gave %s and %v", resp.Data, resp.Errors)
+	}
+}
+
+func TestExecuteContextCancelled(t *testing.T) {
+	ctx, _ := newTestContext()
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+
+	resp := newTestSchema(t).Execute(ctx, Request{Query: `{ everyone { name } }`})
+	assertErrors(t, resp, context.Canceled.Error())
+	assertData(t, resp, `null`)
+}
@@ -0,0 +1,75 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+//BatchFunc loads the values for several keys, returning them in the same
+//order as the keys
+type BatchFunc func(ctx context.Context, keys []string) ([]interface{}, error)
+
+//Loader batches and caches lookups by key. Loads requested while one
+//level of a query is resolved are fetched with a single call to the
+//batch function when the first of them is forced.
+//
+//A Loader caches for its whole life and isn't safe for concurrent use,
+//so make a new one for each request.
+type Loader struct {
+	batch   BatchFunc
+	entries map[string]*loaderEntry
+	queue   []string
+}
+
+type loaderEntry struct {
+	done  bool
+	value interface{}
+	err   error
+}
+
+//NewLoader creates a new Loader
+func NewLoader(batch BatchFunc) *Loader {
+	return &Loader{
+		batch:   batch,
+		entries: map[string]*loaderEntry{},
+	}
+}
+
+//Load returns a Thunk for the value of key, which a resolver can return
+//directly
+func (l *Loader) Load(ctx context.Context, key string) Thunk {
+	entry, ok := l.entries[key]
+	if !ok {
+		entry = &loaderEntry{}
+		l.entries[key] = entry
+		l.queue = append(l.queue, key)
+	}
+
+	return func() (interface{}, error) {
+		if !entry.done {
+			l.dispatch(ctx)
+		}
+		return entry.value, entry.err
+	}
+}
+
+//dispatch loads every queued key
+func (l *Loader) dispatch(ctx context.Context) {
+	keys := l.queue
+	l.queue = nil
+
+	values, err := l.batch(ctx, keys)
+	if err == nil && len(values) != len(keys) {
+		err = errors.Errorf("batch returned %d values for %d keys", len(values), len(keys))
+	}
+	for i, key := range keys {
+		entry := l.entries[key]
+		entry.done = true
+		if err != nil {
+			entry.err = err
+		} else {
+			entry.value = values[i]
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package graphql
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestLoader(t *testing.T) {
+	var batches [][]string
+	loader := NewLoader(func(ctx context.Context, keys []string) ([]interface{}, error) {
+		batches = append(batches, keys)
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			values[i] = "value of " + key
+		}
+		return values, nil
+	})
+
+	ctx := context.Background()
+	a, b, a2 := loader.Load(ctx, "a"), loader.Load(ctx, "b"), loader.Load(ctx, "a")
+	for thunk, want := range map[*Thunk]string{&a: "value of a", &b: "value of b", &a2: "value of a"} {
+		if v, err := (*thunk)(); err != nil || v != want {
+			t.Errorf("loaded %v, %v, want %s", v, err, want)
+		}
+	}
+
+	//cached keys aren't fetched again
+	c, a3 := loader.Load(ctx, "c"), loader.Load(ctx, "a")
+	c()  //nolint:errcheck
+	a3() //nolint:errcheck
+
+	if want := [][]string{{"a", "b"}, {"c"}}; !reflect.DeepEqual(batches, want) {
+		t.Errorf("batches = %v, want %v", batches, want)
+	}
+}
+
+func TestLoaderErrors(t *testing.T) {
+	ctx := context.Background()
+
+	failing := NewLoader(func(ctx context.Context, keys []string) ([]interface{}, error) {
+		return nil, errors.New("failed")
+	})
+	a, b := failing.Load(ctx, "a"), failing.Load(ctx, "b")
+	for _, thunk := range []Thunk{a, b} {
+		if _, err := thunk(); err == nil || err.Error() != "failed" {
+			t.Errorf("got error %v", err)
+		}
+	}
+
+	short := NewLoader(func(ctx context.Context, keys []string) ([]interface{}, error) {
+		return []interface{}{"only one"}, nil
+	})
+	a, b = short.Load(ctx, "a"), short.Load(ctx, "b")
+	if _, err := a(); err == nil || err.Error() != "batch returned 1 values for 2 keys" {
+		t.Errorf("got error %v", err)
+	}
+}
+
+//TestLoaderBatchesQueryLevels checks that the friends of every person in
+//a list are fetched with one call per level of the query, not one per
+//person, and that people already fetched aren't fetched again
+func TestLoaderBatchesQueryLevels(t *testing.T) {
+	for _, tc := range []struct {
+		query string
+		want  [][]string
+	}{
+		{
+			query: `{ everyone { name friends { name friends { name } } } }`,
+			want:  [][]string{{"bob", "cy", "ada"}},
+		},
+		{
+			query: `{ person(name: "cy") { friends { name friends { name } } } }`,
+			want:  [][]string{{"ada", "bob"}, {"cy"}},
+		},
+	} {
+		ctx, batches := newTestContext()
+
+		resp := newTestSchema(t).Execute(ctx, Request{Query: tc.query})
+		assertErrors(t, resp)
+		if !reflect.DeepEqual(*batches, tc.want) {
+			t.Errorf("%s: batches = %v, want %v", tc.query, *batches, tc.want)
+		}
+	}
+}
+
+func TestLoaderErrorInQuery(t *testing.T) {
+	ctx, _ := newTestContext()
+
+	resp := newTestSchema(t).Execute(ctx, Request{Query: `{ person(name: "ghost") { name friends { name } } }`})
+	assertErrors(t, resp, "no such person `nobody`")
+	assertData(t, resp, `{"person": null}`)
+}
@@ -0,0 +1,653 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	loc   Location
+}
+
+//lexer splits a query into tokens, skipping whitespace, commas and
+//comments, which GraphQL treats as insignificant
+type lexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+
+	loc := Location{Line: l.line, Column: l.col}
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, loc: loc}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.IndexByte("!$():=@[]{}|&", c) >= 0:
+		l.advance(1)
+		return token{kind: tokenPunctuator, value: string(c), loc: loc}, nil
+
+	case c == '.':
+		if !strings.HasPrefix(l.src[l.pos:], "...") {
+			return token{}, syntaxError(loc, "unexpected `.`")
+		}
+		l.advance(3)
+		return token{kind: tokenPunctuator, value: "...", loc: loc}, nil
+
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], loc: loc}, nil
+
+	case c == '-' || isDigit(c):
+		return l.number(loc)
+
+	case c == '"':
+		if strings.HasPrefix(l.src[l.pos:], `"""`) {
+			return l.blockString(loc)
+		}
+		return l.string(loc)
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	return token{}, syntaxError(loc, "unexpected character %q", r)
+}
+
+func (l *lexer) advance(n int) {
+	for i := 0; i < n && l.pos < len(l.src); i++ {
+		if l.src[l.pos] == '\n' {
+			l.line++
+			l.col = 1
+		} else {
+			l.col++
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.pos:], "\ufeff"):
+			l.pos += len("\ufeff")
+		default:
+			return
+		}
+	}
+}
+
+func (l *lexer) number(loc Location) (token, error) {
+	start := l.pos
+	if l.src[l.pos] == '-' {
+		l.advance(1)
+	}
+	digits := func() int {
+		n := 0
+		for l.pos < len(l.src) && isDigit(l.src[l.pos]) {
+			l.advance(1)
+			n++
+		}
+		return n
+	}
+
+	if digits() == 0 {
+		return token{}, syntaxError(loc, "invalid number")
+	}
+	kind := tokenInt
+	if l.pos < len(l.src) && l.src[l.pos] == '.' {
+		kind = tokenFloat
+		l.advance(1)
+		if digits() == 0 {
+			return token{}, syntaxError(loc, "invalid number")
+		}
+	}
+	if l.pos < len(l.src) && (l.src[l.pos] == 'e' || l.src[l.pos] == 'E') {
+		kind = tokenFloat
+		l.advance(1)
+		if l.pos < len(l.src) && (l.src[l.pos] == '+' || l.src[l.pos] == '-') {
+			l.advance(1)
+		}
+		if digits() == 0 {
+			return token{}, syntaxError(loc, "invalid number")
+		}
+	}
+	return token{kind: kind, value: l.src[start:l.pos], loc: loc}, nil
+}
+
+func (l *lexer) string(loc Location) (token, error) {
+	l.advance(1)
+
+	var b strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.advance(1)
+			return token{kind: tokenString, value: b.String(), loc: loc}, nil
+		case c == '\n':
+			return token{}, syntaxError(loc, "unterminated string")
+		case c == '\\':
+			if l.pos+1 >= len(l.src) {
+				return token{}, syntaxError(loc, "unterminated string")
+			}
+			esc := l.src[l.pos+1]
+			switch esc {
+			case '"', '\\', '/':
+				b.WriteByte(esc)
+			case 'b':
+				b.WriteByte('\b')
+			case 'f':
+				b.WriteByte('\f')
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case 'u':
+				if l.pos+6 > len(l.src) {
+					return token{}, syntaxError(loc, "invalid unicode escape")
+				}
+				var r rune
+				if _, err := fmt.Sscanf(l.src[l.pos+2:l.pos+6], "%04x", &r); err != nil {
+					return token{}, syntaxError(loc, "invalid unicode escape")
+				}
+				b.WriteRune(r)
+				l.advance(4)
+			default:
+				return token{}, syntaxError(loc, "invalid escape `\\%c`", esc)
+			}
+			l.advance(2)
+		default:
+			b.WriteByte(c)
+			l.advance(1)
+		}
+	}
+	return token{}, syntaxError(loc, "unterminated string")
+}
+
+//blockString reads a `"""` string, removing the indentation common to
+//its lines as the spec describes
+func (l *lexer) blockString(loc Location) (token, error) {
+	l.advance(3)
+
+	end := strings.Index(l.src[l.pos:], `"""`)
+	for end > 0 && l.src[l.pos+end-1] == '\\' {
+		next := strings.Index(l.src[l.pos+end+3:], `"""`)
+		if next < 0 {
+			end = -1
+			break
+		}
+		end += 3 + next
+	}
+	if end < 0 {
+		return token{}, syntaxError(loc, "unterminated string")
+	}
+	raw := strings.Replace(l.src[l.pos:l.pos+end], `\"""`, `"""`, -1)
+	l.advance(end + 3)
+
+	lines := strings.Split(strings.Replace(raw, "\r\n", "\n", -1), "\n")
+	indent := -1
+	for _, line := range lines[1:] {
+		trimmed := strings.TrimLeft(line, " \t")
+		if trimmed != "" && (indent < 0 || len(line)-len(trimmed) < indent) {
+			indent = len(line) - len(trimmed)
+		}
+	}
+	for i := 1; i < len(lines) && indent > 0; i++ {
+		if len(lines[i]) >= indent {
+			lines[i] = lines[i][indent:]
+		} else {
+			lines[i] = ""
+		}
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return token{kind: tokenString, value: strings.Join(lines, "\n"), loc: loc}, nil
+}
+
+func isLetter(c byte) bool { return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') }
+func isDigit(c byte) bool  { return c >= '0' && c <= '9' }
+
+//parser builds a document from tokens with one token of lookahead
+type parser struct {
+	lexer *lexer
+	tok   token
+}
+
+//parse parses an executable document. Type system definitions aren't
+//accepted, since the schema is defined in Go.
+func parse(src string) (*document, error) {
+	p := &parser{lexer: &lexer{src: src, line: 1, col: 1}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: map[string]*fragment{}}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.peek(tokenPunctuator, "{"),
+			p.peek(tokenName, "query"),
+			p.peek(tokenName, "mutation"),
+			p.peek(tokenName, "subscription"):
+			op, err := p.operation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+
+		case p.peek(tokenName, "fragment"):
+			frag, err := p.fragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[frag.name]; ok {
+				return nil, syntaxError(frag.loc, "there can be only one fragment named `%s`", frag.name)
+			}
+			doc.fragments[frag.name] = frag
+
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.operations) == 0 {
+		return nil, syntaxError(p.tok.loc, "the document contains no operations")
+	}
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) peek(kind tokenKind, value string) bool {
+	return p.tok.kind == kind && (value == "" || p.tok.value == value)
+}
+
+//skip consumes the token if it matches
+func (p *parser) skip(kind tokenKind, value string) (bool, error) {
+	if !p.peek(kind, value) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+func (p *parser) expect(kind tokenKind, value string) (token, error) {
+	tok := p.tok
+	if !p.peek(kind, value) {
+		return token{}, p.unexpected()
+	}
+	return tok, p.advance()
+}
+
+func (p *parser) name() (string, error) {
+	tok, err := p.expect(tokenName, "")
+	return tok.value, err
+}
+
+func (p *parser) unexpected() error {
+	if p.tok.kind == tokenEOF {
+		return syntaxError(p.tok.loc, "unexpected end of document")
+	}
+	return syntaxError(p.tok.loc, "unexpected `%s`", p.tok.value)
+}
+
+func (p *parser) operation() (*operation, error) {
+	op := &operation{kind: "query", loc: p.tok.loc}
+	if p.peek(tokenPunctuator, "{") {
+		var err error
+		op.selections, err = p.selectionSet()
+		return op, err
+	}
+
+	op.kind = p.tok.value
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.peek(tokenName, "") {
+		op.name = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	if ok, err := p.skip(tokenPunctuator, "("); err != nil {
+		return nil, err
+	} else if ok {
+		for !p.peek(tokenPunctuator, ")") {
+			def, err := p.variableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.variables = append(op.variables, def)
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if op.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	op.selections, err = p.selectionSet()
+	return op, err
+}
+
+func (p *parser) variableDefinition() (*variableDefinition, error) {
+	def := &variableDefinition{loc: p.tok.loc}
+	if _, err := p.expect(tokenPunctuator, "$"); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if def.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if _, err := p.expect(tokenPunctuator, ":"); err != nil {
+		return nil, err
+	}
+	if def.typ, err = p.typeRef(); err != nil {
+		return nil, err
+	}
+
+	if ok, err := p.skip(tokenPunctuator, "="); err != nil {
+		return nil, err
+	} else if ok {
+		if def.defaultValue, err = p.value(true); err != nil {
+			return nil, err
+		}
+	}
+	return def, nil
+}
+
+func (p *parser) typeRef() (typeRef, error) {
+	var t typeRef
+	if ok, err := p.skip(tokenPunctuator, "["); err != nil {
+		return t, err
+	} else if ok {
+		of, err := p.typeRef()
+		if err != nil {
+			return t, err
+		}
+		t.list = &of
+		if _, err := p.expect(tokenPunctuator, "]"); err != nil {
+			return t, err
+		}
+	} else {
+		name, err := p.name()
+		if err != nil {
+			return t, err
+		}
+		t.name = name
+	}
+
+	ok, err := p.skip(tokenPunctuator, "!")
+	t.nonNull = ok
+	return t, err
+}
+
+func (p *parser) fragment() (*fragment, error) {
+	frag := &fragment{loc: p.tok.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if frag.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if frag.name == "on" {
+		return nil, syntaxError(frag.loc, "a fragment can't be named `on`")
+	}
+	if _, err := p.expect(tokenName, "on"); err != nil {
+		return nil, err
+	}
+	if frag.typeCondition, err = p.name(); err != nil {
+		return nil, err
+	}
+	if frag.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	frag.selections, err = p.selectionSet()
+	return frag, err
+}
+
+func (p *parser) selectionSet() ([]selection, error) {
+	if _, err := p.expect(tokenPunctuator, "{"); err != nil {
+		return nil, err
+	}
+
+	var selections []selection
+	for !p.peek(tokenPunctuator, "}") {
+		sel, err := p.selection()
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, sel)
+	}
+	if len(selections) == 0 {
+		return nil, syntaxError(p.tok.loc, "a selection set can't be empty")
+	}
+	return selections, p.advance()
+}
+
+func (p *parser) selection() (selection, error) {
+	loc := p.tok.loc
+	if ok, err := p.skip(tokenPunctuator, "..."); err != nil {
+		return nil, err
+	} else if !ok {
+		return p.field()
+	}
+
+	if p.peek(tokenName, "") && p.tok.value != "on" {
+		spread := &fragmentSpread{name: p.tok.value, loc: loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var err error
+		spread.directives, err = p.directives()
+		return spread, err
+	}
+
+	inline := &inlineFragment{loc: loc}
+	if ok, err := p.skip(tokenName, "on"); err != nil {
+		return nil, err
+	} else if ok {
+		if inline.typeCondition, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if inline.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	inline.selections, err = p.selectionSet()
+	return inline, err
+}
+
+func (p *parser) field() (*field, error) {
+	f := &field{loc: p.tok.loc}
+
+	var err error
+	if f.name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(tokenPunctuator, ":"); err != nil {
+		return nil, err
+	} else if ok {
+		f.alias = f.name
+		if f.name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.arguments, err = p.arguments(false); err != nil {
+		return nil, err
+	}
+	if f.directives, err = p.directives(); err != nil {
+		return nil, err
+	}
+	if p.peek(tokenPunctuator, "{") {
+		f.selections, err = p.selectionSet()
+	}
+	return f, err
+}
+
+func (p *parser) arguments(constant bool) ([]*argument, error) {
+	if ok, err := p.skip(tokenPunctuator, "("); err != nil || !ok {
+		return nil, err
+	}
+
+	var args []*argument
+	for !p.peek(tokenPunctuator, ")") {
+		arg := &argument{loc: p.tok.loc}
+
+		var err error
+		if arg.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(tokenPunctuator, ":"); err != nil {
+			return nil, err
+		}
+		if arg.value, err = p.value(constant); err != nil {
+			return nil, err
+		}
+		args = append(args, arg)
+	}
+	if len(args) == 0 {
+		return nil, syntaxError(p.tok.loc, "an argument list can't be empty")
+	}
+	return args, p.advance()
+}
+
+func (p *parser) directives() ([]*directive, error) {
+	var directives []*directive
+	for p.peek(tokenPunctuator, "@") {
+		d := &directive{loc: p.tok.loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if d.name, err = p.name(); err != nil {
+			return nil, err
+		}
+		if d.arguments, err = p.arguments(false); err != nil {
+			return nil, err
+		}
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+//value parses a value; constant values, like variable defaults, can't
+//refer to variables
+func (p *parser) value(constant bool) (value, error) {
+	tok := p.tok
+	switch tok.kind {
+	case tokenInt:
+		return intValue(tok.value), p.advance()
+	case tokenFloat:
+		return floatValue(tok.value), p.advance()
+	case tokenString:
+		return stringValue(tok.value), p.advance()
+	case tokenName:
+		var v value
+		switch tok.value {
+		case "true":
+			v = booleanValue(true)
+		case "false":
+			v = booleanValue(false)
+		case "null":
+			v = nullValue{}
+		default:
+			v = enumValue(tok.value)
+		}
+		return v, p.advance()
+	}
+
+	switch tok.value {
+	case "$":
+		if constant {
+			return nil, syntaxError(tok.loc, "variables aren't allowed here")
+		}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		return variableValue(name), err
+
+	case "[":
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		list := listValue{}
+		for !p.peek(tokenPunctuator, "]") {
+			item, err := p.value(constant)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, item)
+		}
+		return list, p.advance()
+
+	case "{":
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		obj := objectValue{}
+		for !p.peek(tokenPunctuator, "}") {
+			name, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			if _, err := p.expect(tokenPunctuator, ":"); err != nil {
+				return nil, err
+			}
+			if obj[name], err = p.value(constant); err != nil {
+				return nil, err
+			}
+		}
+		return obj, p.advance()
+	}
+
+	return nil, p.unexpected()
+}
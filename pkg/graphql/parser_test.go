@@ -0,0 +1,119 @@
+package graphql
+
+import (
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := parse(`
+		# a comment, which is ignored
+		query Accounts($first: Int = 20, $ids: [ID!]!) @include(if: true) {
+			viewer { id }
+			mine: accounts(first: $first, ids: $ids, filter: {name: "x", tags: ["a", "b"]}) {
+				...AccountFields
+				... on Account @skip(if: false) { name }
+			}
+		}
+
+		fragment AccountFields on Account {
+			id, amount(scale: -1.5e2), note(text: """a "block" string"""), kind(is: CHECKING), gone(v: null)
+		}
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(doc.operations) != 1 {
+		t.Fatalf("parsed %d operations, want 1", len(doc.operations))
+	}
+	op := doc.operations[0]
+	if op.kind != "query" || op.name != "Accounts" {
+		t.Errorf("parsed a %s named `%s`", op.kind, op.name)
+	}
+	if len(op.variables) != 2 {
+		t.Fatalf("parsed %d variables, want 2", len(op.variables))
+	}
+	if v := op.variables[0]; v.name != "first" || typeRefString(v.typ) != "Int" || v.defaultValue != intValue("20") {
+		t.Errorf("parsed $%s: %s = %v", v.name, typeRefString(v.typ), v.defaultValue)
+	}
+	if v := op.variables[1]; v.name != "ids" || typeRefString(v.typ) != "[ID!]!" {
+		t.Errorf("parsed $%s: %s", v.name, typeRefString(v.typ))
+	}
+	if len(op.directives) != 1 || op.directives[0].name != "include" {
+		t.Errorf("parsed directives %v", op.directives)
+	}
+
+	if len(op.selections) != 2 {
+		t.Fatalf("parsed %d selections, want 2", len(op.selections))
+	}
+	accounts := op.selections[1].(*field)
+	if accounts.alias != "mine" || accounts.name != "accounts" || accounts.responseKey() != "mine" {
+		t.Errorf("parsed `%s: %s`", accounts.alias, accounts.name)
+	}
+	if len(accounts.arguments) != 3 {
+		t.Fatalf("parsed %d arguments, want 3", len(accounts.arguments))
+	}
+	if arg := accounts.arguments[0]; arg.name != "first" || arg.value != variableValue("first") {
+		t.Errorf("parsed %s: %v", arg.name, arg.value)
+	}
+	filter := accounts.arguments[2].value.(objectValue)
+	if filter["name"] != stringValue("x") || len(filter["tags"].(listValue)) != 2 {
+		t.Errorf("parsed filter %v", filter)
+	}
+	if spread := accounts.selections[0].(*fragmentSpread); spread.name != "AccountFields" {
+		t.Errorf("parsed a spread of `%s`", spread.name)
+	}
+	if inline := accounts.selections[1].(*inlineFragment); inline.typeCondition != "Account" || len(inline.directives) != 1 {
+		t.Errorf("parsed an inline fragment on `%s`", inline.typeCondition)
+	}
+
+	frag := doc.fragments["AccountFields"]
+	if frag == nil || frag.typeCondition != "Account" || len(frag.selections) != 5 {
+		t.Fatalf("parsed fragment %+v", frag)
+	}
+	for i, want := range []value{floatValue("-1.5e2"), stringValue(`a "block" string`), enumValue("CHECKING"), nullValue{}} {
+		if got := frag.selections[i+1].(*field).arguments[0].value; got != want {
+			t.Errorf("parsed %#v, want %#v", got, want)
+		}
+	}
+}
+
+func TestParseShorthandQuery(t *testing.T) {
+	doc, err := parse(`{ a b { c } }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if op := doc.operations[0]; op.kind != "query" || op.name != "" || len(op.selections) != 2 {
+		t.Errorf("parsed %+v", op)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, tc := range []struct {
+		query   string
+		message string
+		line    int
+		column  int
+	}{
+		{query: ``, message: "syntax error: the document contains no operations", line: 1, column: 1},
+		{query: `{ a `, message: "syntax error: unexpected end of document", line: 1, column: 5},
+		{query: "{\n  a(x: ) }", message: "syntax error: unexpected `)`", line: 2, column: 8},
+		{query: `{ a(x: "unterminated) }`, message: "syntax error: unterminated string", line: 1, column: 8},
+		{query: `{ a.b }`, message: "syntax error: unexpected `.`", line: 1, column: 4},
+		{query: `{ a } fragment F on A { a } fragment F on A { b }`, message: "syntax error: there can be only one fragment named `F`", line: 1, column: 29},
+		{query: `type A { a: Int }`, message: "syntax error: unexpected `type`", line: 1, column: 1},
+	} {
+		_, err := parse(tc.query)
+		gqlErr, ok := err.(*Error)
+		if !ok {
+			t.Errorf("parsing %q: got %v, want an *Error", tc.query, err)
+			continue
+		}
+		if gqlErr.Message != tc.message {
+			t.Errorf("parsing %q: message = %q, want %q", tc.query, gqlErr.Message, tc.message)
+		}
+		if loc := gqlErr.Locations[0]; loc.Line != tc.line || loc.Column != tc.column {
+			t.Errorf("parsing %q: error at %d:%d, want %d:%d", tc.query, loc.Line, loc.Column, tc.line, tc.column)
+		}
+	}
+}
@@ -0,0 +1,413 @@
+//Package graphql executes GraphQL queries against a schema defined in Go.
+//
+//Only queries are supported. Fields are resolved one level at a time, so
+//resolvers that return a Thunk are all started before any of them is
+//forced; a Loader uses that to batch the lookups made by sibling objects.
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+//Type is a *Scalar, *Object, *List or *NonNull
+type Type interface {
+	String() string
+}
+
+//ResolveFunc returns the value of a field of source. It may return a
+//Thunk to defer its work until its siblings have been resolved.
+type ResolveFunc func(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error)
+
+//Thunk is a deferred field value
+type Thunk func() (interface{}, error)
+
+//Scalar is a leaf type
+type Scalar struct {
+	Name        string
+	Description string
+
+	//Serialize converts a resolved value to its JSON form
+	Serialize func(v interface{}) (interface{}, error)
+
+	//Parse converts an input value, which has been decoded from JSON or
+	//read from the query, to the value resolvers receive
+	Parse func(v interface{}) (interface{}, error)
+}
+
+func (s *Scalar) String() string { return s.Name }
+
+//Object is a type with fields
+type Object struct {
+	Name        string
+	Description string
+
+	fields []*Field
+	byName map[string]*Field
+}
+
+//NewObject creates an Object with no fields; add them with AddField,
+//which lets objects refer to each other
+func NewObject(name string, description string) *Object {
+	return &Object{
+		Name:        name,
+		Description: description,
+		byName:      map[string]*Field{},
+	}
+}
+
+func (o *Object) String() string { return o.Name }
+
+//AddField adds a field to the object
+func (o *Object) AddField(f *Field) *Object {
+	if _, ok := o.byName[f.Name]; ok {
+		panic(fmt.Sprintf("graphql: %s.%s is defined twice", o.Name, f.Name))
+	}
+	o.fields = append(o.fields, f)
+	o.byName[f.Name] = f
+	return o
+}
+
+//Field returns the named field, or nil
+func (o *Object) Field(name string) *Field {
+	return o.byName[name]
+}
+
+//Field is a field of an Object
+type Field struct {
+	Name              string
+	Description       string
+	DeprecationReason string
+	Type              Type
+	Args              []*Argument
+	Resolve           ResolveFunc
+}
+
+func (f *Field) argument(name string) *Argument {
+	for _, arg := range f.Args {
+		if arg.Name == name {
+			return arg
+		}
+	}
+	return nil
+}
+
+//Argument is an argument of a Field. Only scalars, and lists of them, can
+//be arguments.
+type Argument struct {
+	Name        string
+	Description string
+	Type        Type
+	Default     interface{}
+}
+
+//List is a list of another type
+type List struct {
+	Of Type
+}
+
+func (l *List) String() string { return "[" + l.Of.String() + "]" }
+
+//NonNull is a type whose values can't be null
+type NonNull struct {
+	Of Type
+}
+
+func (n *NonNull) String() string { return n.Of.String() + "!" }
+
+//ListOf returns the type of lists of t
+func ListOf(t Type) *List { return &List{Of: t} }
+
+//NonNullOf returns the non-null version of t
+func NonNullOf(t Type) *NonNull { return &NonNull{Of: t} }
+
+//Schema is the set of types a query can select from
+type Schema struct {
+	Query *Object
+
+	//MaxDepth, if set, rejects queries that nest fields more deeply
+	MaxDepth int
+
+	types map[string]Type
+}
+
+//NewSchema creates a Schema rooted at query, checking that every type it
+//refers to is well formed
+func NewSchema(query *Object) (*Schema, error) {
+	s := &Schema{
+		Query: query,
+		types: map[string]Type{},
+	}
+	for _, scalar := range []*Scalar{String, Int, Float, Boolean, ID} {
+		s.types[scalar.Name] = scalar
+	}
+	if err := s.collect(query); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Schema) collect(t Type) error {
+	switch t := t.(type) {
+	case *List:
+		return s.collect(t.Of)
+	case *NonNull:
+		if _, ok := t.Of.(*NonNull); ok {
+			return errors.Errorf("type %s is doubly non-null", t)
+		}
+		return s.collect(t.Of)
+	}
+
+	name := t.String()
+	if existing, ok := s.types[name]; ok {
+		if existing != t {
+			return errors.Errorf("there are two types named %s", name)
+		}
+		return nil
+	}
+	s.types[name] = t
+
+	obj, ok := t.(*Object)
+	if !ok {
+		return nil
+	}
+	if len(obj.fields) == 0 {
+		return errors.Errorf("type %s has no fields", obj.Name)
+	}
+	for _, f := range obj.fields {
+		if f.Type == nil || f.Resolve == nil {
+			return errors.Errorf("field %s.%s needs a type and a resolver", obj.Name, f.Name)
+		}
+		if err := s.collect(f.Type); err != nil {
+			return err
+		}
+		for _, arg := range f.Args {
+			if _, ok := namedType(arg.Type).(*Scalar); !ok {
+				return errors.Errorf("argument %s of %s.%s must be a scalar", arg.Name, obj.Name, f.Name)
+			}
+			if err := s.collect(arg.Type); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//SDL describes the schema in the GraphQL schema definition language
+func (s *Schema) SDL() string {
+	var names []string
+	for name, t := range s.types {
+		if _, ok := t.(*Object); ok && t != s.Query {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("schema {\n  query: " + s.Query.Name + "\n}\n")
+	for _, scalar := range s.scalars() {
+		b.WriteString("\n")
+		writeDescription(&b, "", scalar.Description)
+		b.WriteString("scalar " + scalar.Name + "\n")
+	}
+	for _, obj := range append([]*Object{s.Query}, s.objects(names)...) {
+		b.WriteString("\n")
+		writeDescription(&b, "", obj.Description)
+		b.WriteString("type " + obj.Name + " {\n")
+		for _, f := range obj.fields {
+			writeDescription(&b, "  ", f.Description)
+			b.WriteString("  " + f.Name)
+			if len(f.Args) > 0 {
+				var args []string
+				for _, arg := range f.Args {
+					def := arg.Name + ": " + arg.Type.String()
+					if arg.Default != nil {
+						def += " = " + literal(arg.Default)
+					}
+					args = append(args, def)
+				}
+				b.WriteString("(" + strings.Join(args, ", ") + ")")
+			}
+			b.WriteString(": " + f.Type.String())
+			if f.DeprecationReason != "" {
+				b.WriteString(" @deprecated(reason: " + strconv.Quote(f.DeprecationReason) + ")")
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+//scalars returns the custom scalars, which SDL has to declare
+func (s *Schema) scalars() []*Scalar {
+	var scalars []*Scalar
+	for _, t := range s.types {
+		scalar, ok := t.(*Scalar)
+		if ok && scalar != String && scalar != Int && scalar != Float && scalar != Boolean && scalar != ID {
+			scalars = append(scalars, scalar)
+		}
+	}
+	sort.Slice(scalars, func(i, j int) bool { return scalars[i].Name < scalars[j].Name })
+	return scalars
+}
+
+func (s *Schema) objects(names []string) []*Object {
+	objects := make([]*Object, len(names))
+	for i, name := range names {
+		objects[i] = s.types[name].(*Object)
+	}
+	return objects
+}
+
+func writeDescription(b *strings.Builder, indent string, description string) {
+	if description != "" {
+		b.WriteString(indent + strconv.Quote(description) + "\n")
+	}
+}
+
+func literal(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			items = append(items, literal(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	return fmt.Sprint(v)
+}
+
+//namedType strips lists and non-null wrappers from t
+func namedType(t Type) Type {
+	for {
+		switch wrapped := t.(type) {
+		case *List:
+			t = wrapped.Of
+		case *NonNull:
+			t = wrapped.Of
+		default:
+			return t
+		}
+	}
+}
+
+//the built-in scalars
+var (
+	String = &Scalar{
+		Name:      "String",
+		Serialize: serializeString,
+		Parse: func(v interface{}) (interface{}, error) {
+			if s, ok := v.(string); ok {
+				return s, nil
+			}
+			return nil, errors.Errorf("String cannot represent %v", v)
+		},
+	}
+
+	ID = &Scalar{
+		Name:      "ID",
+		Serialize: serializeString,
+		Parse: func(v interface{}) (interface{}, error) {
+			switch v := v.(type) {
+			case string:
+				return v, nil
+			case int:
+				return strconv.Itoa(v), nil
+			case float64:
+				if v == math.Trunc(v) {
+					return strconv.FormatFloat(v, 'f', 0, 64), nil
+				}
+			}
+			return nil, errors.Errorf("ID cannot represent %v", v)
+		},
+	}
+
+	Int = &Scalar{
+		Name: "Int",
+		Serialize: func(v interface{}) (interface{}, error) {
+			switch v := v.(type) {
+			case int:
+				return v, nil
+			case int32:
+				return int(v), nil
+			case int64:
+				if v >= math.MinInt32 && v <= math.MaxInt32 {
+					return int(v), nil
+				}
+			}
+			return nil, errors.Errorf("Int cannot represent %v", v)
+		},
+		Parse: func(v interface{}) (interface{}, error) {
+			switch v := v.(type) {
+			case int:
+				if v >= math.MinInt32 && v <= math.MaxInt32 {
+					return v, nil
+				}
+			case float64:
+				if v == math.Trunc(v) && v >= math.MinInt32 && v <= math.MaxInt32 {
+					return int(v), nil
+				}
+			}
+			return nil, errors.Errorf("Int cannot represent %v", v)
+		},
+	}
+
+	Float = &Scalar{
+		Name: "Float",
+		Serialize: func(v interface{}) (interface{}, error) {
+			switch v := v.(type) {
+			case float64:
+				return v, nil
+			case float32:
+				return float64(v), nil
+			case int:
+				return float64(v), nil
+			}
+			return nil, errors.Errorf("Float cannot represent %v", v)
+		},
+		Parse: func(v interface{}) (interface{}, error) {
+			switch v := v.(type) {
+			case float64:
+				return v, nil
+			case int:
+				return float64(v), nil
+			}
+			return nil, errors.Errorf("Float cannot represent %v", v)
+		},
+	}
+
+	Boolean = &Scalar{
+		Name: "Boolean",
+		Serialize: func(v interface{}) (interface{}, error) {
+			if b, ok := v.(bool); ok {
+				return b, nil
+			}
+			return nil, errors.Errorf("Boolean cannot represent %v", v)
+		},
+		Parse: func(v interface{}) (interface{}, error) {
+			if b, ok := v.(bool); ok {
+				return b, nil
+			}
+			return nil, errors.Errorf("Boolean cannot represent %v", v)
+		},
+	}
+)
+
+func serializeString(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	}
+	return nil, errors.Errorf("cannot represent %v as a string", v)
+}
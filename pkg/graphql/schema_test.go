@@ -0,0 +1,100 @@
+package graphql
+
+import (
+	"context"
+	"testing"
+)
+
+func resolveNothing(ctx context.Context, source interface{}, args map[string]interface{}) (interface{}, error) {
+	return nil, nil
+}
+
+func TestNewSchemaErrors(t *testing.T) {
+	for _, tc := range []struct {
+		query   func() *Object
+		message string
+	}{
+		{
+			query:   func() *Object { return NewObject("Query", "") },
+			message: "type Query has no fields",
+		},
+		{
+			query: func() *Object {
+				return NewObject("Query", "").AddField(&Field{Name: "a", Type: String})
+			},
+			message: "field Query.a needs a type and a resolver",
+		},
+		{
+			query: func() *Object {
+				return NewObject("Query", "").AddField(&Field{Name: "a", Type: NonNullOf(NonNullOf(String)), Resolve: resolveNothing})
+			},
+			message: "type String!! is doubly non-null",
+		},
+		{
+			query: func() *Object {
+				other := &Scalar{Name: "String"}
+				return NewObject("Query", "").
+					AddField(&Field{Name: "a", Type: String, Resolve: resolveNothing}).
+					AddField(&Field{Name: "b", Type: other, Resolve: resolveNothing})
+			},
+			message: "there are two types named String",
+		},
+		{
+			query: func() *Object {
+				query := NewObject("Query", "")
+				return query.AddField(&Field{Name: "a", Type: String, Resolve: resolveNothing, Args: []*Argument{{Name: "q", Type: query}}})
+			},
+			message: "argument q of Query.a must be a scalar",
+		},
+	} {
+		if _, err := NewSchema(tc.query()); err == nil || err.Error() != tc.message {
+			t.Errorf("got error %v, want %q", err, tc.message)
+		}
+	}
+}
+
+func TestSDL(t *testing.T) {
+	money := &Scalar{Name: "Money", Description: "An amount"}
+	thing := NewObject("Thing", "A thing")
+	thing.AddField(&Field{Name: "cost", Type: money, Resolve: resolveNothing}).
+		AddField(&Field{Name: "parts", Type: NonNullOf(ListOf(NonNullOf(thing))), Resolve: resolveNothing})
+
+	query := NewObject("Query", "").AddField(&Field{
+		Name:              "things",
+		Description:       "Every thing",
+		DeprecationReason: "use stuff",
+		Type:              ListOf(thing),
+		Args: []*Argument{
+			{Name: "first", Type: Int, Default: 10},
+			{Name: "kinds", Type: ListOf(String), Default: []interface{}{"a", "b"}},
+		},
+		Resolve: resolveNothing,
+	})
+
+	schema, err := NewSchema(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `schema {
+  query: Query
+}
+
+"An amount"
+scalar Money
+
+type Query {
+  "Every thing"
+  things(first: Int = 10, kinds: [String] = ["a", "b"]): [Thing] @deprecated(reason: "use stuff")
+}
+
+"A thing"
+type Thing {
+  cost: Money
+  parts: [Thing!]!
+}
+`
+	if got := schema.SDL(); got != want {
+		t.Errorf("SDL =\n%s\nwant\n%s", got, want)
+	}
+}
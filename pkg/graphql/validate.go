@@ -0,0 +1,294 @@
+package graphql
+
+import (
+	"fmt"
+)
+
+//validator checks a document against the schema before any resolver runs
+type validator struct {
+	schema *Schema
+	doc    *document
+	errors []*Error
+
+	//per operation
+	variables map[string]*variableDefinition
+	used      map[string]bool
+}
+
+func (v *validator) errorf(loc Location, format string, args ...interface{}) {
+	v.errors = append(v.errors, &Error{Message: fmt.Sprintf(format, args...), Locations: []Location{loc}})
+}
+
+//validate checks the document and picks the operation to run
+func (s *Schema) validate(doc *document, operationName string) (*operation, []*Error) {
+	v := &validator{schema: s, doc: doc}
+
+	var op *operation
+	names := map[string]bool{}
+	for _, candidate := range doc.operations {
+		if candidate.name == "" && len(doc.operations) > 1 {
+			v.errorf(candidate.loc, "an anonymous operation must be the only operation in the document")
+		}
+		if candidate.name != "" && names[candidate.name] {
+			v.errorf(candidate.loc, "there can be only one operation named `%s`", candidate.name)
+		}
+		names[candidate.name] = true
+
+		if candidate.name == operationName || (operationName == "" && len(doc.operations) == 1) {
+			op = candidate
+		}
+	}
+	if len(v.errors) > 0 {
+		return nil, v.errors
+	}
+	if op == nil {
+		if operationName == "" {
+			return nil, []*Error{{Message: "operationName is required when the document has several operations"}}
+		}
+		return nil, []*Error{{Message: fmt.Sprintf("there's no operation named `%s`", operationName)}}
+	}
+	if op.kind != "query" {
+		return nil, []*Error{{Message: fmt.Sprintf("%s operations aren't supported", op.kind), Locations: []Location{op.loc}}}
+	}
+
+	v.variables = map[string]*variableDefinition{}
+	v.used = map[string]bool{}
+	for _, def := range op.variables {
+		if _, ok := v.variables[def.name]; ok {
+			v.errorf(def.loc, "there can be only one variable named $%s", def.name)
+		}
+		v.variables[def.name] = def
+		if !v.checkTypeRef(def.typ) {
+			v.errorf(def.loc, "variable $%s can't be of type %s", def.name, typeRefString(def.typ))
+		}
+	}
+	if len(v.errors) > 0 {
+		return nil, v.errors
+	}
+
+	v.directives(op.directives)
+	spread := map[string]bool{}
+	v.selections(s.Query, op.selections, 1, spread, map[string]bool{})
+
+	for _, def := range op.variables {
+		if !v.used[def.name] {
+			v.errorf(def.loc, "variable $%s is never used", def.name)
+		}
+	}
+	for name, frag := range doc.fragments {
+		if !spread[name] {
+			v.errorf(frag.loc, "fragment `%s` is never used", name)
+		}
+	}
+	return op, v.errors
+}
+
+//checkTypeRef reports whether a variable type names an input type
+func (v *validator) checkTypeRef(ref typeRef) bool {
+	if ref.list != nil {
+		return v.checkTypeRef(*ref.list)
+	}
+	_, ok := v.schema.types[ref.name].(*Scalar)
+	return ok
+}
+
+func typeRefString(ref typeRef) string {
+	s := ref.name
+	if ref.list != nil {
+		s = "[" + typeRefString(*ref.list) + "]"
+	}
+	if ref.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+//selections checks a selection set on type t. spread records every
+//fragment used; active holds the fragments being expanded, to catch
+//cycles.
+func (v *validator) selections(t *Object, selections []selection, depth int, spread map[string]bool, active map[string]bool) {
+	if v.schema.MaxDepth > 0 && depth > v.schema.MaxDepth {
+		v.errorf(selections[0].location(), "the query is nested more than %d levels deep", v.schema.MaxDepth)
+		return
+	}
+
+	for _, sel := range selections {
+		switch sel := sel.(type) {
+		case *field:
+			v.directives(sel.directives)
+			v.field(t, sel, depth, spread, active)
+
+		case *fragmentSpread:
+			v.directives(sel.directives)
+			spread[sel.name] = true
+
+			frag, ok := v.doc.fragments[sel.name]
+			if !ok {
+				v.errorf(sel.loc, "there's no fragment named `%s`", sel.name)
+				continue
+			}
+			if active[sel.name] {
+				v.errorf(sel.loc, "fragment `%s` spreads itself", sel.name)
+				continue
+			}
+			if !v.typeCondition(t, frag.typeCondition, sel.loc) {
+				continue
+			}
+			v.directives(frag.directives)
+
+			active[sel.name] = true
+			v.selections(t, frag.selections, depth, spread, active)
+			delete(active, sel.name)
+
+		case *inlineFragment:
+			v.directives(sel.directives)
+			if sel.typeCondition != "" && !v.typeCondition(t, sel.typeCondition, sel.loc) {
+				continue
+			}
+			v.selections(t, sel.selections, depth, spread, active)
+		}
+	}
+}
+
+//typeCondition checks that a fragment can apply to t. Every type is an
+//object, so it has to be t itself.
+func (v *validator) typeCondition(t *Object, name string, loc Location) bool {
+	switch v.schema.types[name].(type) {
+	case *Object:
+	case nil:
+		v.errorf(loc, "there's no type named %s", name)
+		return false
+	default:
+		v.errorf(loc, "a fragment can't be on %s, which isn't an object type", name)
+		return false
+	}
+	if name != t.Name {
+		v.errorf(loc, "a fragment on %s can never apply to %s", name, t.Name)
+		return false
+	}
+	return true
+}
+
+func (v *validator) field(t *Object, f *field, depth int, spread map[string]bool, active map[string]bool) {
+	if f.name == "__typename" {
+		if len(f.arguments) > 0 || len(f.selections) > 0 {
+			v.errorf(f.loc, "__typename takes no arguments or selections")
+		}
+		return
+	}
+
+	def := t.Field(f.name)
+	if def == nil {
+		v.errorf(f.loc, "cannot query field `%s` on type %s", f.name, t.Name)
+		return
+	}
+
+	given := map[string]bool{}
+	for _, arg := range f.arguments {
+		if given[arg.name] {
+			v.errorf(arg.loc, "argument %s is given twice", arg.name)
+			continue
+		}
+		given[arg.name] = true
+
+		argDef := def.argument(arg.name)
+		if argDef == nil {
+			v.errorf(arg.loc, "%s.%s has no argument %s", t.Name, f.name, arg.name)
+			continue
+		}
+		v.value(argDef.Type, argDef.Default != nil, arg.value, arg.loc)
+	}
+	for _, argDef := range def.Args {
+		if _, required := argDef.Type.(*NonNull); required && argDef.Default == nil && !given[argDef.Name] {
+			v.errorf(f.loc, "%s.%s requires argument %s", t.Name, f.name, argDef.Name)
+		}
+	}
+
+	switch named := namedType(def.Type).(type) {
+	case *Object:
+		if len(f.selections) == 0 {
+			v.errorf(f.loc, "field `%s` of type %s must have a selection of subfields", f.name, def.Type)
+			return
+		}
+		v.selections(named, f.selections, depth+1, spread, active)
+	default:
+		if len(f.selections) > 0 {
+			v.errorf(f.loc, "field `%s` of type %s can't have a selection of subfields", f.name, def.Type)
+		}
+	}
+}
+
+//directives checks @skip and @include, the only directives supported
+func (v *validator) directives(directives []*directive) {
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			v.errorf(d.loc, "unknown directive @%s", d.name)
+			continue
+		}
+		if len(d.arguments) != 1 || d.arguments[0].name != "if" {
+			v.errorf(d.loc, "@%s takes a single argument, `if`", d.name)
+			continue
+		}
+		v.value(NonNullOf(Boolean), false, d.arguments[0].value, d.arguments[0].loc)
+	}
+}
+
+//value checks an argument value against its type
+func (v *validator) value(t Type, hasDefault bool, val value, loc Location) {
+	if name, ok := val.(variableValue); ok {
+		def, ok := v.variables[string(name)]
+		if !ok {
+			v.errorf(loc, "variable $%s isn't defined", name)
+			return
+		}
+		v.used[def.name] = true
+
+		varType := v.schema.inputType(def.typ)
+		if !compatible(varType, hasDefault || def.defaultValue != nil, t) {
+			v.errorf(loc, "variable $%s of type %s can't be used as %s", name, varType, t)
+		}
+		return
+	}
+
+	if list, ok := val.(listValue); ok {
+		if listType, ok := unwrapNonNull(t).(*List); ok {
+			for _, item := range list {
+				v.value(listType.Of, false, item, loc)
+			}
+			return
+		}
+	}
+
+	if _, _, err := coerceLiteral(t, val, nil); err != nil {
+		v.errorf(loc, "%s", err)
+	}
+}
+
+func unwrapNonNull(t Type) Type {
+	if nonNull, ok := t.(*NonNull); ok {
+		return nonNull.Of
+	}
+	return t
+}
+
+//compatible reports whether a variable of type varType can be used where
+//t is expected
+func compatible(varType Type, hasDefault bool, t Type) bool {
+	if nonNull, ok := t.(*NonNull); ok {
+		if varNonNull, ok := varType.(*NonNull); ok {
+			return compatible(varNonNull.Of, false, nonNull.Of)
+		}
+		return hasDefault && compatible(varType, false, nonNull.Of)
+	}
+	if varNonNull, ok := varType.(*NonNull); ok {
+		return compatible(varNonNull.Of, false, t)
+	}
+	if list, ok := t.(*List); ok {
+		varList, ok := varType.(*List)
+		return ok && compatible(varList.Of, false, list.Of)
+	}
+	if _, ok := varType.(*List); ok {
+		return false
+	}
+	return varType == t
+}
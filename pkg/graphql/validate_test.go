@@ -0,0 +1,122 @@
+package graphql
+
+import (
+	"testing"
+)
+
+func TestValidateFragmentCycles(t *testing.T) {
+	schema := newTestSchema(t)
+
+	for name, query := range map[string]string{
+		"direct": `
+			{ person(name: "ada") { ...A } }
+			fragment A on Person { name ...A }`,
+		"indirect": `
+			{ person(name: "ada") { ...A } }
+			fragment A on Person { name ...B }
+			fragment B on Person { age ...A }`,
+		"through a field": `
+			{ person(name: "ada") { ...A } }
+			fragment A on Person { friends { ...A } }`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := execute(t, schema, query, nil)
+			if resp.Data != nil {
+				t.Errorf("the query ran: %s", resp.Data)
+			}
+			if len(resp.Errors) == 0 {
+				t.Fatal("the cycle wasn't reported")
+			}
+			if msg := resp.Errors[0].Message; msg != "fragment `A` spreads itself" {
+				t.Errorf("error = %q", msg)
+			}
+		})
+	}
+}
+
+func TestValidateRepeatedFragment(t *testing.T) {
+	//spreading a fragment twice, or in sibling fields, isn't a cycle
+	resp := execute(t, newTestSchema(t), `
+		{ person(name: "ada") { ...A ...A friends { ...A } } }
+		fragment A on Person { name }
+	`, nil)
+
+	assertErrors(t, resp)
+	assertData(t, resp, `{"person": {"name": "ada", "friends": [{"name": "bob"}, {"name": "cy"}]}}`)
+}
+
+func TestValidateMaxDepth(t *testing.T) {
+	schema := newTestSchema(t)
+	schema.MaxDepth = 3
+
+	resp := execute(t, schema, `{ person(name: "ada") { friends { name } } }`, nil)
+	assertErrors(t, resp)
+
+	resp = execute(t, schema, `{ person(name: "ada") { friends { friends { name } } } }`, nil)
+	assertErrors(t, resp, "the query is nested more than 3 levels deep")
+	if resp.Data != nil {
+		t.Errorf("the query ran: %s", resp.Data)
+	}
+	if loc := resp.Errors[0].Locations[0]; loc.Line != 1 || loc.Column != 45 {
+		t.Errorf("error at %d:%d", loc.Line, loc.Column)
+	}
+
+	//fragments don't hide depth
+	resp = execute(t, schema, `
+		{ person(name: "ada") { ...F } }
+		fragment F on Person { friends { ... on Person { friends { name } } } }
+	`, nil)
+	assertErrors(t, resp, "the query is nested more than 3 levels deep")
+}
+
+func TestValidate(t *testing.T) {
+	schema := newTestSchema(t)
+
+	for _, tc := range []struct {
+		query   string
+		message string
+	}{
+		{`{ nobody }`, "cannot query field `nobody` on type Query"},
+		{`{ person { name } }`, "Query.person requires argument name"},
+		{`{ person(name: "ada", name: "bob") { name } }`, "argument name is given twice"},
+		{`{ person(name: "ada", age: 3) { name } }`, "Query.person has no argument age"},
+		{`{ person(name: "ada") }`, "field `person` of type Person must have a selection of subfields"},
+		{`{ person(name: "ada") { name { first } } }`, "field `name` of type String! can't have a selection of subfields"},
+		{`{ person(name: true) { name } }`, "ID cannot represent true"},
+		{`{ person(name: "ada") { ...Missing } }`, "there's no fragment named `Missing`"},
+		{`{ person(name: "ada") { name } } fragment F on Person { name }`, "fragment `F` is never used"},
+		{`{ person(name: "ada") { ... on Query { everyone { name } } } }`, "a fragment on Query can never apply to Person"},
+		{`{ person(name: "ada") { name @defer } }`, "unknown directive @defer"},
+		{`query($name: ID!) { everyone { name } }`, "variable $name is never used"},
+		{`{ person(name: $name) { name } }`, "variable $name isn't defined"},
+		{`query($name: String!) { person(name: $name) { name } }`, "variable $name of type String! can't be used as ID!"},
+		{`query($name: ID) { person(name: $name) { name } }`, "variable $name of type ID can't be used as ID!"},
+		{`query($p: Person) { everyone { name } }`, "variable $p can't be of type Person"},
+		{`{ everyone { name } } { everyone { age } }`, "an anonymous operation must be the only operation in the document"},
+		{`mutation { everyone { name } }`, "mutation operations aren't supported"},
+	} {
+		resp := execute(t, schema, tc.query, nil)
+		if resp.Data != nil {
+			t.Errorf("%s: the query ran: %s", tc.query, resp.Data)
+		}
+		if len(resp.Errors) == 0 || resp.Errors[0].Message != tc.message {
+			t.Errorf("%s: got errors %v, want %q", tc.query, resp.Errors, tc.message)
+		}
+	}
+}
+
+func TestValidateOperationName(t *testing.T) {
+	schema := newTestSchema(t)
+	query := `query A { person(name: "ada") { name } } query B { person(name: "bob") { name } }`
+
+	ctx, _ := newTestContext()
+	resp := schema.Execute(ctx, Request{Query: query, OperationName: "B"})
+	assertErrors(t, resp)
+	assertData(t, resp, `{"person": {"name": "bob"}}`)
+
+	resp = schema.Execute(ctx, Request{Query: query})
+	assertErrors(t, resp, "operationName is required when the document has several operations")
+
+	resp = schema.Execute(ctx, Request{Query: query, OperationName: "C"})
+	assertErrors(t, resp, "there's no operation named `C`")
+}
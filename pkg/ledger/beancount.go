@@ -0,0 +1,54 @@
+package ledger
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xanderflood/plaid-ui/pkg/textfmt"
+)
+
+//beancount renders the Beancount syntax
+type beancount struct{}
+
+func (beancount) open(w io.Writer, o opening) error {
+	_, err := fmt.Fprintf(w, "%s open %s %s\n  plaid_account_id: %s\n\n",
+		o.date, o.account, o.currency, beancountString(o.accountID))
+	return err
+}
+
+func (beancount) entry(w io.Writer, e entry) error {
+	flag := "*"
+	if e.pending {
+		flag = "!"
+	}
+
+	_, err := fmt.Fprintf(w, "%s %s %s\n  plaid_id: %s\n",
+		e.date, flag, beancountString(e.payee), beancountString(e.plaidID))
+	if err != nil {
+		return err
+	}
+
+	for _, p := range e.postings {
+		_, err = fmt.Fprintf(w, "  %s  %s %s\n", p.account, textfmt.Amount(p.amount), p.currency)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(w)
+	return err
+}
+
+//Beancount checks a balance at the start of the day, so a snapshot
+//taken on a given day is asserted on the following one.
+func (beancount) assert(w io.Writer, a assertion) error {
+	_, err := fmt.Fprintf(w, "%s balance %s  %s %s\n\n",
+		nextDay(a.date), a.account, textfmt.Amount(a.amount), a.currency)
+	return err
+}
+
+func beancountString(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(textfmt.SingleLine(s))
+	return `"` + s + `"`
+}
@@ -0,0 +1,288 @@
+package ledger
+
+import (
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//Format identifies a plain-text accounting syntax
+type Format string
+
+const (
+	FormatBeancount Format = "beancount"
+	FormatLedger    Format = "ledger"
+)
+
+//ErrUnknownFormat indicates that an unsupported Format was requested
+var ErrUnknownFormat = errors.New("unknown export format")
+
+//Journal is everything that goes into a single export
+type Journal struct {
+	Accounts     []db.Account
+	Transactions []db.Transaction
+	Balances     []db.Balance
+}
+
+//Exporter renders a Journal as a plain-text accounting file
+//go:generate counterfeiter . Exporter
+type Exporter interface {
+	Export(w io.Writer, format Format, journal Journal) error
+}
+
+//ExporterAgent implements Exporter
+type ExporterAgent struct {
+	mapping Mapping
+}
+
+//NewExporter creates a new ExporterAgent
+func NewExporter(mapping Mapping) ExporterAgent {
+	return ExporterAgent{mapping: mapping}
+}
+
+//Export writes the journal to w in the requested format
+func (e ExporterAgent) Export(w io.Writer, format Format, journal Journal) error {
+	var d dialect
+	switch format {
+	case FormatBeancount:
+		d = beancount{}
+	case FormatLedger:
+		d = ledgerCLI{}
+	default:
+		return ErrUnknownFormat
+	}
+
+	return e.build(journal).write(w, d)
+}
+
+//posting is a single leg of an entry
+type posting struct {
+	account  string
+	amount   *big.Float
+	currency string
+}
+
+//entry is a balanced transaction
+type entry struct {
+	date     string
+	pending  bool
+	payee    string
+	plaidID  string
+	postings []posting
+}
+
+//assertion is a balance check for one account
+type assertion struct {
+	date     string
+	account  string
+	amount   *big.Float
+	currency string
+}
+
+//opening declares an account before first use
+type opening struct {
+	date      string
+	account   string
+	currency  string
+	accountID string
+}
+
+//book is a journal after account names have been resolved and
+//everything has been put into a stable order
+type book struct {
+	openings   []opening
+	entries    []entry
+	assertions []assertion
+}
+
+func (e ExporterAgent) build(journal Journal) book {
+	var b book
+
+	accounts := map[string]db.Account{}
+	names := e.accountNames(journal.Accounts)
+	openDates := map[string]string{}
+	currencies := map[string]string{}
+	for _, acct := range journal.Accounts {
+		accounts[acct.UUID] = acct
+		openDates[acct.UUID] = acct.CreatedAt.Format(plaidapi.DateFormat)
+		currencies[acct.UUID] = e.mapping.DefaultCurrency
+	}
+
+	earliest := func(accountUUID, date string) {
+		if date != "" && date < openDates[accountUUID] {
+			openDates[accountUUID] = date
+		}
+	}
+
+	for _, t := range journal.Transactions {
+		if _, ok := accounts[t.AccountUUID]; !ok {
+			continue
+		}
+
+		currency := e.mapping.currency(t.ISOCurrencyCode)
+		currencies[t.AccountUUID] = currency
+		earliest(t.AccountUUID, t.Date)
+
+		amount := t.Amount
+		if amount == nil {
+			amount = new(big.Float)
+		}
+
+		//Plaid amounts are positive when money leaves the account, so
+		//the account leg is always the negation of the Plaid amount
+		b.entries = append(b.entries, entry{
+			date:    t.Date,
+			pending: t.PlaidPending,
+			payee:   t.PlaidName,
+			plaidID: t.PlaidID,
+			postings: []posting{
+				{account: names[t.AccountUUID], amount: new(big.Float).Neg(amount), currency: currency},
+				{account: e.mapping.CounterAccount(t), amount: amount, currency: currency},
+			},
+		})
+	}
+
+	for _, bal := range journal.Balances {
+		acct, ok := accounts[bal.AccountUUID]
+		if !ok || bal.Current == nil {
+			continue
+		}
+
+		//Plaid reports liabilities as a positive amount owed
+		amount := bal.Current
+		if isLiability(acct.PlaidAccountType) {
+			amount = new(big.Float).Neg(amount)
+		}
+
+		earliest(bal.AccountUUID, bal.Date)
+		b.assertions = append(b.assertions, assertion{
+			date:     bal.Date,
+			account:  names[bal.AccountUUID],
+			amount:   amount,
+			currency: e.mapping.currency(bal.ISOCurrencyCode),
+		})
+	}
+
+	//accounts that the mapping gives the same name are opened once
+	opened := map[string]int{}
+	for _, acct := range journal.Accounts {
+		o := opening{
+			date:      openDates[acct.UUID],
+			account:   names[acct.UUID],
+			currency:  currencies[acct.UUID],
+			accountID: acct.PlaidAccountID,
+		}
+		if i, ok := opened[o.account]; ok {
+			if o.date < b.openings[i].date {
+				b.openings[i].date = o.date
+			}
+			continue
+		}
+		opened[o.account] = len(b.openings)
+		b.openings = append(b.openings, o)
+	}
+
+	sort.Slice(b.openings, func(i, j int) bool {
+		return b.openings[i].account < b.openings[j].account
+	})
+	sort.SliceStable(b.entries, func(i, j int) bool {
+		if b.entries[i].date != b.entries[j].date {
+			return b.entries[i].date < b.entries[j].date
+		}
+		return b.entries[i].plaidID < b.entries[j].plaidID
+	})
+	sort.SliceStable(b.assertions, func(i, j int) bool {
+		if b.assertions[i].date != b.assertions[j].date {
+			return b.assertions[i].date < b.assertions[j].date
+		}
+		return b.assertions[i].account < b.assertions[j].account
+	})
+
+	return b
+}
+
+//dialect renders the individual pieces of a book
+type dialect interface {
+	open(w io.Writer, o opening) error
+	entry(w io.Writer, e entry) error
+	assert(w io.Writer, a assertion) error
+}
+
+func (b book) write(w io.Writer, d dialect) error {
+	for _, o := range b.openings {
+		if err := d.open(w, o); err != nil {
+			return err
+		}
+	}
+
+	//interleave entries and assertions by date so that each
+	//assertion follows every entry on the day it was taken
+	var i, j int
+	for i < len(b.entries) || j < len(b.assertions) {
+		if j >= len(b.assertions) || (i < len(b.entries) && b.entries[i].date <= b.assertions[j].date) {
+			if err := d.entry(w, b.entries[i]); err != nil {
+				return err
+			}
+			i++
+		} else {
+			if err := d.assert(w, b.assertions[j]); err != nil {
+				return err
+			}
+			j++
+		}
+	}
+
+	return nil
+}
+
+//accountNames names each account by UUID. Generated names can collide,
+//such as for two cards with the same name at one institution, so those
+//accounts get a short UUID suffix to keep their postings apart. Names
+//set in the mapping are used as they are, even if they're shared.
+func (e ExporterAgent) accountNames(accts []db.Account) map[string]string {
+	names := map[string]string{}
+	counts := map[string]int{}
+	for _, acct := range accts {
+		names[acct.UUID] = e.mapping.AccountName(acct)
+		counts[names[acct.UUID]]++
+	}
+
+	for _, acct := range accts {
+		if _, ok := e.mapping.override(acct); ok || counts[names[acct.UUID]] < 2 {
+			continue
+		}
+
+		suffix := strings.ToUpper(strings.Replace(acct.UUID, "-", "", -1))
+		if len(suffix) > 8 {
+			suffix = suffix[:8]
+		}
+		//a bare root like `Assets` can't be renamed, so the suffix
+		//becomes a component of its own
+		separator := "-"
+		if !strings.Contains(names[acct.UUID], ":") {
+			separator = ":"
+		}
+		names[acct.UUID] += separator + suffix
+	}
+	return names
+}
+
+func isLiability(t plaidapi.AccountType) bool {
+	return t == plaidapi.AccountTypeCredit || t == plaidapi.AccountTypeLoan
+}
+
+//nextDay returns the day after a date in plaidapi.DateFormat
+func nextDay(date string) string {
+	t, err := time.Parse(plaidapi.DateFormat, date)
+	if err != nil {
+		return date
+	}
+	return t.AddDate(0, 0, 1).Format(plaidapi.DateFormat)
+}
@@ -0,0 +1,80 @@
+package ledger_test
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/ledger"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+func card(uuid string, plaidID string) db.Account {
+	return db.Account{
+		Model:                db.Model{UUID: uuid, CreatedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)},
+		PlaidAccountID:       plaidID,
+		PlaidAccountName:     "Rewards Card",
+		PlaidAccountType:     plaidapi.AccountTypeCredit,
+		PlaidAccountSubtype:  plaidapi.AccountSubtypeCreditCard,
+		PlaidInstitutionName: "Chase",
+	}
+}
+
+func TestExportCollidingAccountNames(t *testing.T) {
+	journal := ledger.Journal{
+		Accounts: []db.Account{
+			card("1a2b3c4d-0000-0000-0000-000000000000", "acct-1"),
+			card("5e6f7a8b-0000-0000-0000-000000000000", "acct-2"),
+		},
+		Transactions: []db.Transaction{
+			{AccountUUID: "1a2b3c4d-0000-0000-0000-000000000000", PlaidID: "plaid-1", Date: "2020-01-03", Amount: big.NewFloat(12.5), PlaidName: "Coffee"},
+			{AccountUUID: "5e6f7a8b-0000-0000-0000-000000000000", PlaidID: "plaid-2", Date: "2020-01-04", Amount: big.NewFloat(30), PlaidName: "Books"},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ledger.NewExporter(ledger.DefaultMapping()).Export(&buf, ledger.FormatBeancount, journal); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"2020-01-01 open Liabilities:Credit-Card:Chase:Rewards-Card-1A2B3C4D USD",
+		"2020-01-01 open Liabilities:Credit-Card:Chase:Rewards-Card-5E6F7A8B USD",
+		"  Liabilities:Credit-Card:Chase:Rewards-Card-1A2B3C4D  -12.50 USD",
+		"  Liabilities:Credit-Card:Chase:Rewards-Card-5E6F7A8B  -30.00 USD",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("export is missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExportSharedMappedName(t *testing.T) {
+	mapping := ledger.DefaultMapping().Merge(ledger.Mapping{
+		Accounts: map[string]string{"acct-1": "Liabilities:Chase", "acct-2": "Liabilities:Chase"},
+	})
+	journal := ledger.Journal{
+		Accounts: []db.Account{
+			card("1a2b3c4d-0000-0000-0000-000000000000", "acct-1"),
+			card("5e6f7a8b-0000-0000-0000-000000000000", "acct-2"),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ledger.NewExporter(mapping).Export(&buf, ledger.FormatBeancount, journal); err != nil {
+		t.Fatal(err)
+	}
+
+	//names set in the mapping are merged on purpose, so they're kept,
+	//but only opened once
+	if strings.Contains(buf.String(), "Liabilities:Chase-") {
+		t.Errorf("a mapped name was changed:\n%s", buf.String())
+	}
+	if n := strings.Count(buf.String(), " open Liabilities:Chase "); n != 1 {
+		t.Errorf("the shared account was opened %d times:\n%s", n, buf.String())
+	}
+}
@@ -0,0 +1,48 @@
+package ledger
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xanderflood/plaid-ui/pkg/textfmt"
+)
+
+//ledgerCLI renders the ledger-cli syntax
+type ledgerCLI struct{}
+
+func (ledgerCLI) open(w io.Writer, o opening) error {
+	_, err := fmt.Fprintf(w, "account %s\n    ; plaid_account_id: %s\n\n",
+		o.account, textfmt.SingleLine(o.accountID))
+	return err
+}
+
+func (ledgerCLI) entry(w io.Writer, e entry) error {
+	flag := "*"
+	if e.pending {
+		flag = "!"
+	}
+
+	_, err := fmt.Fprintf(w, "%s %s %s\n    ; plaid_id: %s\n",
+		e.date, flag, textfmt.SingleLine(e.payee), textfmt.SingleLine(e.plaidID))
+	if err != nil {
+		return err
+	}
+
+	for _, p := range e.postings {
+		_, err = fmt.Fprintf(w, "    %s  %s %s\n", p.account, textfmt.Amount(p.amount), p.currency)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(w)
+	return err
+}
+
+//ledger-cli checks assertions in file order, and the book is already
+//ordered so that this follows every entry from the same day.
+func (ledgerCLI) assert(w io.Writer, a assertion) error {
+	_, err := fmt.Fprintf(w, "%s * Balance assertion\n    %s  0 %s = %s %s\n\n",
+		a.date, a.account, a.currency, textfmt.Amount(a.amount), a.currency)
+	return err
+}
@@ -0,0 +1,168 @@
+package ledger
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//Mapping controls how accounts and transactions are named in an export
+type Mapping struct {
+	//Roots maps each Plaid account type to a top-level account
+	Roots map[plaidapi.AccountType]string `json:"roots"`
+
+	//Accounts overrides the generated name of an account, keyed
+	//by either its UUID or its Plaid account ID
+	Accounts map[string]string `json:"accounts"`
+
+	//Categories maps Plaid category IDs to the account used for
+	//the other side of each transaction
+	Categories map[string]string `json:"categories"`
+
+	DefaultExpense  string `json:"default_expense"`
+	DefaultIncome   string `json:"default_income"`
+	DefaultCurrency string `json:"default_currency"`
+}
+
+//DefaultMapping puts depository and investment accounts under Assets and
+//credit and loan accounts under Liabilities
+func DefaultMapping() Mapping {
+	return Mapping{
+		Roots: map[plaidapi.AccountType]string{
+			plaidapi.AccountTypeDepository: "Assets",
+			plaidapi.AccountTypeInvestment: "Assets",
+			plaidapi.AccountTypeOther:      "Assets",
+			plaidapi.AccountTypeCredit:     "Liabilities",
+			plaidapi.AccountTypeLoan:       "Liabilities",
+		},
+		DefaultExpense:  "Expenses:Uncategorized",
+		DefaultIncome:   "Income:Uncategorized",
+		DefaultCurrency: "USD",
+	}
+}
+
+//Merge overlays any non-empty settings from other onto m
+func (m Mapping) Merge(other Mapping) Mapping {
+	m.Roots = mergeMap(m.Roots, other.Roots)
+	m.Accounts = mergeStrings(m.Accounts, other.Accounts)
+	m.Categories = mergeStrings(m.Categories, other.Categories)
+	if other.DefaultExpense != "" {
+		m.DefaultExpense = other.DefaultExpense
+	}
+	if other.DefaultIncome != "" {
+		m.DefaultIncome = other.DefaultIncome
+	}
+	if other.DefaultCurrency != "" {
+		m.DefaultCurrency = other.DefaultCurrency
+	}
+	return m
+}
+
+//AccountName builds the full hierarchical name for an account, such as
+//`Assets:Checking:Chase:Total-Checking`
+func (m Mapping) AccountName(acct db.Account) string {
+	if name, ok := m.override(acct); ok {
+		return name
+	}
+
+	root, ok := m.Roots[acct.PlaidAccountType]
+	if !ok {
+		root = "Assets"
+	}
+
+	parts := []string{root}
+	for _, part := range []string{
+		string(acct.PlaidAccountSubtype),
+		acct.PlaidInstitutionName,
+		acct.PlaidAccountName,
+	} {
+		if component := accountComponent(part); component != "" {
+			parts = append(parts, component)
+		}
+	}
+
+	return strings.Join(parts, ":")
+}
+
+//override gets the name set for an account in Accounts, if any
+func (m Mapping) override(acct db.Account) (string, bool) {
+	if name, ok := m.Accounts[acct.UUID]; ok {
+		return name, true
+	}
+	name, ok := m.Accounts[acct.PlaidAccountID]
+	return name, ok
+}
+
+//CounterAccount picks the account for the other side of a transaction
+func (m Mapping) CounterAccount(t db.Transaction) string {
+	if name, ok := m.Categories[t.PlaidCategoryID]; ok {
+		return name
+	}
+	if t.Amount != nil && t.Amount.Sign() < 0 {
+		return m.DefaultIncome
+	}
+	return m.DefaultExpense
+}
+
+func (m Mapping) currency(code string) string {
+	if code == "" {
+		return m.DefaultCurrency
+	}
+	return strings.ToUpper(code)
+}
+
+//accountComponent converts free text into a single account name
+//component that both Beancount and ledger-cli will accept: it must
+//start with an uppercase letter or digit and contain only letters,
+//digits and dashes. Each word is capitalized.
+func accountComponent(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if dash && b.Len() > 0 {
+				b.WriteRune('-')
+			}
+			if dash || b.Len() == 0 {
+				r = unicode.ToUpper(r)
+			}
+			dash = false
+			b.WriteRune(r)
+		} else {
+			dash = true
+		}
+	}
+
+	component := b.String()
+	if component == "" {
+		return ""
+	}
+	if first := []rune(component)[0]; !unicode.IsUpper(first) && !unicode.IsDigit(first) {
+		return "X" + component
+	}
+	return component
+}
+
+func mergeMap(a, b map[plaidapi.AccountType]string) map[plaidapi.AccountType]string {
+	out := map[plaidapi.AccountType]string{}
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		out[k] = v
+	}
+	return out
+}
+
+func mergeStrings(a, b map[string]string) map[string]string {
+	out := map[string]string{}
+	for k, v := range a {
+		out[k] = v
+	}
+	for k, v := range b {
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,33 @@
+package notify
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+//ErrNotConfigured indicates that a channel has no notifier set up
+var ErrNotConfigured = errors.New("notification channel is not configured")
+
+//Message is a single notification
+type Message struct {
+	//To is an email address or a URL, depending on the notifier
+	To string
+
+	Event   string
+	Subject string
+	Body    string
+
+	//HTML is an optional rich version of Body, for notifiers that can
+	//render it
+	HTML string
+
+	//Data is structured detail for machine consumers, such as webhooks
+	Data interface{}
+}
+
+//Notifier delivers notifications over a single channel
+//go:generate counterfeiter . Notifier
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,150 @@
+//Package notifytest provides an in-process SMTP server for testing
+//email notifications, in the spirit of net/http/httptest
+package notifytest
+
+import (
+	"bytes"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+//Email is a message that was delivered to an SMTPServer
+type Email struct {
+	From string
+	To   []string
+	Data []byte
+}
+
+//Message parses the delivered message
+func (e Email) Message() (*mail.Message, error) {
+	msg, err := mail.ReadMessage(bytes.NewReader(e.Data))
+	return msg, errors.Wrap(err, "failed to parse delivered email")
+}
+
+//SMTPServer accepts every message sent to it over a local listener,
+//and keeps them for inspection. It speaks just enough SMTP for
+//net/smtp.SendMail without authentication.
+type SMTPServer struct {
+	Host string
+	Port int
+
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu     sync.Mutex
+	emails []Email
+}
+
+//NewSMTPServer starts an SMTPServer on a random local port
+func NewSMTPServer() (*SMTPServer, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to listen for SMTP")
+	}
+
+	addr := listener.Addr().(*net.TCPAddr)
+	s := &SMTPServer{
+		Host:     addr.IP.String(),
+		Port:     addr.Port,
+		listener: listener,
+	}
+
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+//Emails lists the messages delivered so far, in order
+func (s *SMTPServer) Emails() []Email {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Email{}, s.emails...)
+}
+
+//Close stops the server and waits for open connections to finish
+func (s *SMTPServer) Close() error {
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *SMTPServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return //the listener was closed
+		}
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.handle(conn)
+		}()
+	}
+}
+
+func (s *SMTPServer) handle(conn net.Conn) {
+	text := textproto.NewConn(conn)
+	defer text.Close()
+
+	var email Email
+	if err := text.PrintfLine("220 notifytest ESMTP"); err != nil {
+		return
+	}
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		reply := "250 OK"
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch verb {
+		case "HELO", "EHLO", "NOOP":
+		case "RSET":
+			email = Email{}
+		case "MAIL":
+			email = Email{From: address(line)}
+		case "RCPT":
+			email.To = append(email.To, address(line))
+		case "DATA":
+			if err := text.PrintfLine("354 end data with <CR><LF>.<CR><LF>"); err != nil {
+				return
+			}
+			email.Data, err = text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+
+			s.mu.Lock()
+			s.emails = append(s.emails, email)
+			s.mu.Unlock()
+			email = Email{}
+		case "QUIT":
+			text.PrintfLine("221 bye") //nolint:errcheck
+			return
+		default:
+			reply = "502 command not implemented"
+		}
+
+		if err := text.PrintfLine("%s", reply); err != nil {
+			return
+		}
+	}
+}
+
+//address gets the address out of a `MAIL FROM:<...>` or `RCPT TO:<...>`
+//command
+func address(line string) string {
+	start, end := strings.Index(line, "<"), strings.LastIndex(line, ">")
+	if start < 0 || end < start {
+		return ""
+	}
+	return line[start+1 : end]
+}
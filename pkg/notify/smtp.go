@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//SMTPNotifier sends notifications as email, with an HTML alternative
+//when the message has one
+type SMTPNotifier struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+//NewSMTPNotifier creates a new SMTPNotifier. Authentication is skipped
+//when username is empty, which suits a local SMTP stand-in like MailHog.
+func NewSMTPNotifier(host string, port int, username string, password string, from string) SMTPNotifier {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return SMTPNotifier{
+		addr: net.JoinHostPort(host, fmt.Sprint(port)),
+		from: from,
+		auth: auth,
+	}
+}
+
+//Notify emails the message to msg.To
+func (n SMTPNotifier) Notify(ctx context.Context, msg Message) error {
+	if msg.HTML == "" {
+		return n.send(msg.To, msg.Subject, "text/plain; charset=utf-8", msg.Body)
+	}
+
+	var buf bytes.Buffer
+	parts := multipart.NewWriter(&buf)
+	for _, alternative := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.Body},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		part, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {alternative.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return errors.Wrap(err, "failed to build email")
+		}
+
+		qp := quotedprintable.NewWriter(part)
+		if _, err := qp.Write([]byte(alternative.body)); err != nil {
+			return errors.Wrap(err, "failed to build email")
+		}
+		if err := qp.Close(); err != nil {
+			return errors.Wrap(err, "failed to build email")
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return errors.Wrap(err, "failed to build email")
+	}
+
+	return n.send(msg.To, msg.Subject, "multipart/alternative; boundary="+parts.Boundary(), buf.String())
+}
+
+func (n SMTPNotifier) send(to string, subject string, contentType string, body string) error {
+	if strings.ContainsAny(to, "\r\n") {
+		return errors.New("invalid recipient address")
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", n.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: %s\r\n", contentType)
+	fmt.Fprintf(&buf, "\r\n")
+	//multipart bodies already end their lines with CRLF, so lines are
+	//normalized before converting them
+	buf.WriteString(strings.Replace(strings.Replace(body, "\r\n", "\n", -1), "\n", "\r\n", -1))
+
+	err := smtp.SendMail(n.addr, n.auth, n.from, []string{to}, buf.Bytes())
+	return errors.Wrapf(err, "failed to send email via `%s`", n.addr)
+}
@@ -0,0 +1,62 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//WebhookNotifier posts notifications as JSON to a URL
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+//NewWebhookNotifier creates a new WebhookNotifier
+func NewWebhookNotifier(client *http.Client) WebhookNotifier {
+	return WebhookNotifier{client: client}
+}
+
+type webhookPayload struct {
+	Event   string      `json:"event"`
+	Subject string      `json:"subject"`
+	Body    string      `json:"body"`
+	Data    interface{} `json:"data,omitempty"`
+	SentAt  time.Time   `json:"sent_at"`
+}
+
+//Notify posts the message to the URL in msg.To, and fails unless the
+//endpoint responds with a 2xx status
+func (n WebhookNotifier) Notify(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(webhookPayload{
+		Event:   msg.Event,
+		Subject: msg.Subject,
+		Body:    msg.Body,
+		Data:    msg.Data,
+		SentAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode webhook payload")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, msg.To, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrapf(err, "failed to build webhook request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to post webhook")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook endpoint responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,392 @@
+package openapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+//Version is the OpenAPI version documents are written in
+const Version = "3.0.3"
+
+//Document is an OpenAPI document, limited to the parts this service uses
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components Components            `json:"components"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+}
+
+//Info describes the API
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+//Server is a base URL the API is served under
+type Server struct {
+	URL string `json:"url"`
+}
+
+//PathItem holds the operations on a single path, keyed by lowercase
+//HTTP method
+type PathItem map[string]*Operation
+
+//Operation describes one route
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+
+	//Security overrides the document's requirements when set, and an
+	//empty list makes the operation public
+	Security *[]SecurityRequirement `json:"security,omitempty"`
+}
+
+//Parameter is a path or query parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+//RequestBody describes the body of a request, keyed by content type
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+//Response describes a response, keyed by content type. Responses with
+//no body have no content.
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+//MediaType holds the schema of a body
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+//Components holds the schemas that are shared between operations
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+
+	//names remembers which Go type each schema was generated from
+	names map[reflect.Type]string
+}
+
+//SecurityScheme describes how requests authenticate
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty"`
+	Name         string `json:"name,omitempty"`
+}
+
+//SecurityRequirement names the schemes an operation accepts
+type SecurityRequirement map[string][]string
+
+//Public is the security of operations that need no authorization
+func Public() *[]SecurityRequirement {
+	return &[]SecurityRequirement{}
+}
+
+//Schema is a JSON schema, limited to the parts this service uses
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+//FormatDecimal marks strings that hold an arbitrary-precision decimal,
+//which is how amounts are encoded
+const FormatDecimal = "decimal"
+
+//NewDocument creates an empty document
+func NewDocument(title string, version string) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Version: version},
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+			names:   map[reflect.Type]string{},
+		},
+	}
+}
+
+//Add documents a route. Gin-style `:param` path segments are converted
+//to OpenAPI `{param}` ones, and a required path parameter is added for
+//each unless the operation already declares it.
+func (d *Document) Add(method string, path string, op *Operation) {
+	var segments []string
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, ":") {
+			name := segment[1:]
+			segment = "{" + name + "}"
+			if !op.hasParameter(name, "path") {
+				op.Parameters = append(op.Parameters, Parameter{
+					Name:     name,
+					In:       "path",
+					Required: true,
+					Schema:   &Schema{Type: "string"},
+				})
+			}
+		}
+		segments = append(segments, segment)
+	}
+	path = strings.Join(segments, "/")
+
+	item, ok := d.Paths[path]
+	if !ok {
+		item = PathItem{}
+		d.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = op
+}
+
+//Lookup finds the operation for a method and gin-style path
+func (d *Document) Lookup(method string, path string) (*Operation, bool) {
+	item, ok := d.Paths[GinPathToOpenAPI(path)]
+	if !ok {
+		return nil, false
+	}
+	op, ok := item[strings.ToLower(method)]
+	return op, ok
+}
+
+//OperationRef identifies an operation in a document
+type OperationRef struct {
+	Method    string
+	Path      string
+	Operation *Operation
+}
+
+//Operations lists every operation, ordered by path and then method
+func (d *Document) Operations() []OperationRef {
+	var ops []OperationRef
+	for path, item := range d.Paths {
+		for method, op := range item {
+			ops = append(ops, OperationRef{Method: strings.ToUpper(method), Path: path, Operation: op})
+		}
+	}
+	sort.Slice(ops, func(i, j int) bool {
+		if ops[i].Path != ops[j].Path {
+			return ops[i].Path < ops[j].Path
+		}
+		return ops[i].Method < ops[j].Method
+	})
+	return ops
+}
+
+//GinPathToOpenAPI converts `:param` path segments to `{param}` ones
+func GinPathToOpenAPI(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func (op *Operation) hasParameter(name string, in string) bool {
+	for _, p := range op.Parameters {
+		if p.Name == name && p.In == in {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	bigFloatType      = reflect.TypeOf(big.Float{})
+	jsonNumberType    = reflect.TypeOf(json.Number(""))
+	rawMessageType    = reflect.TypeOf(json.RawMessage(nil))
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+//SchemaOf generates a schema for the JSON encoding of v's type. Named
+//struct types are added to the components and referenced, so that each
+//is described once.
+func (c *Components) SchemaOf(v interface{}) *Schema {
+	return c.schemaFor(reflect.TypeOf(v), false)
+}
+
+//RequestSchemaOf is like SchemaOf, but for a type that requests are
+//bound to, so only fields tagged `binding:"required"` are required
+func (c *Components) RequestSchemaOf(v interface{}) *Schema {
+	return c.schemaFor(reflect.TypeOf(v), true)
+}
+
+//Object builds an inline object schema with the given properties, each
+//described by an example value, like the `gin.H` envelope of a response.
+//Every property is required.
+func (c *Components) Object(properties map[string]interface{}) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for name, v := range properties {
+		if schema, ok := v.(*Schema); ok {
+			s.Properties[name] = schema
+		} else {
+			s.Properties[name] = c.SchemaOf(v)
+		}
+		s.Required = append(s.Required, name)
+	}
+	sort.Strings(s.Required)
+	return s
+}
+
+func (c *Components) schemaFor(t reflect.Type, request bool) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case bigFloatType:
+		return &Schema{Type: "string", Format: FormatDecimal}
+	case jsonNumberType:
+		return &Schema{Type: "number"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := c.schemaFor(t.Elem(), request)
+		if s.Ref == "" {
+			s.Nullable = true
+		}
+		return s
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: c.schemaFor(t.Elem(), request)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: c.schemaFor(t.Elem(), request)}
+	case reflect.Struct:
+		if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+			return &Schema{Type: "string"}
+		}
+		if t.Name() == "" {
+			return c.structSchema(t, request)
+		}
+		return &Schema{Ref: "#/components/schemas/" + c.componentName(t, request)}
+	}
+	return &Schema{}
+}
+
+//componentName registers a named struct type, generating its schema the
+//first time it's seen. Types from different packages that share a name
+//are told apart by prefixing the package name.
+func (c *Components) componentName(t reflect.Type, request bool) string {
+	if name, ok := c.names[t]; ok {
+		return name
+	}
+
+	name := t.Name()
+	if _, taken := c.Schemas[name]; taken {
+		pkg := t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:]
+		name = strings.Title(pkg) + name
+	}
+
+	//register the name before generating the schema, so that
+	//recursive types refer back to it
+	c.names[t] = name
+	c.Schemas[name] = &Schema{}
+	*c.Schemas[name] = *c.structSchema(t, request)
+	return name
+}
+
+func (c *Components) structSchema(t reflect.Type, request bool) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	c.addFields(s, t, request)
+	sort.Strings(s.Required)
+	return s
+}
+
+//addFields adds the JSON fields of a struct to s, flattening embedded
+//structs the way encoding/json does
+func (c *Components) addFields(s *Schema, t reflect.Type, request bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts := tag, ""
+		if i := strings.Index(tag, ","); i >= 0 {
+			name, opts = tag[:i], tag[i+1:]
+		}
+
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				c.addFields(s, ft, request)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue //unexported
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		field := c.schemaFor(f.Type, request)
+		if opts == "string" {
+			field = &Schema{Type: "string"}
+		}
+		s.Properties[name] = field
+
+		if request {
+			if strings.Contains(f.Tag.Get("binding"), "required") {
+				s.Required = append(s.Required, name)
+			}
+		} else if !strings.Contains(opts, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
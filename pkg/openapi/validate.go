@@ -0,0 +1,186 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+//ValidateResponse checks a response against the one the document
+//describes for the operation and status, falling back to the default
+//response. JSON bodies are checked against their schema; other content
+//types are only checked for being documented.
+func (d *Document) ValidateResponse(method string, path string, status int, contentType string, body []byte) error {
+	op, ok := d.Lookup(method, path)
+	if !ok {
+		return errors.Errorf("%s %s isn't documented", method, path)
+	}
+	resp, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		if resp, ok = op.Responses["default"]; !ok {
+			return errors.Errorf("%s %s doesn't document status %d", method, path, status)
+		}
+	}
+
+	if len(resp.Content) == 0 {
+		if len(body) > 0 {
+			return errors.Errorf("%s %s documents no body for status %d, but one was sent", method, path, status)
+		}
+		return nil
+	}
+
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+	contentType = strings.TrimSpace(contentType)
+	media, ok := resp.Content[contentType]
+	if !ok {
+		return errors.Errorf("%s %s doesn't document a %s body for status %d", method, path, contentType, status)
+	}
+	if contentType != "application/json" {
+		return nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return errors.Wrapf(err, "%s %s sent invalid JSON", method, path)
+	}
+	if err := d.validate(media.Schema, v, "$"); err != nil {
+		return errors.Wrapf(err, "%s %s with status %d doesn't match its schema", method, path, status)
+	}
+	return nil
+}
+
+//validate checks a decoded JSON value against a schema, naming where it
+//went wrong with a JSONPath-like location
+func (d *Document) validate(s *Schema, v interface{}, at string) error {
+	if s.Ref != "" {
+		//a reference can't be marked nullable in OpenAPI 3.0, so pointers
+		//to structs are bare references. Values of struct type are never
+		//encoded as null, so allowing it loses nothing.
+		if v == nil {
+			return nil
+		}
+		name := strings.TrimPrefix(s.Ref, "#/components/schemas/")
+		ref, ok := d.Components.Schemas[name]
+		if !ok {
+			return errors.Errorf("%s: unknown schema %s", at, s.Ref)
+		}
+		s = ref
+	}
+
+	if v == nil {
+		if s.Nullable || s.Type == "" {
+			return nil
+		}
+		return errors.Errorf("%s: null isn't a %s", at, s.Type)
+	}
+
+	switch s.Type {
+	case "":
+		return nil
+
+	case "object":
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return typeError(at, s.Type, v)
+		}
+		for _, name := range s.Required {
+			if _, ok := obj[name]; !ok {
+				return errors.Errorf("%s: missing required property `%s`", at, name)
+			}
+		}
+
+		names := make([]string, 0, len(obj))
+		for name := range obj {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop, ok := s.Properties[name]
+			switch {
+			case ok:
+			case s.AdditionalProperties != nil:
+				prop = s.AdditionalProperties
+			case len(s.Properties) > 0:
+				return errors.Errorf("%s: undocumented property `%s`", at, name)
+			default:
+				continue
+			}
+			if err := d.validate(prop, obj[name], at+"."+name); err != nil {
+				return err
+			}
+		}
+
+	case "array":
+		items, ok := v.([]interface{})
+		if !ok {
+			return typeError(at, s.Type, v)
+		}
+		if s.Items == nil {
+			return nil
+		}
+		for i, item := range items {
+			if err := d.validate(s.Items, item, fmt.Sprintf("%s[%d]", at, i)); err != nil {
+				return err
+			}
+		}
+
+	case "string":
+		str, ok := v.(string)
+		if !ok {
+			return typeError(at, s.Type, v)
+		}
+		if len(s.Enum) > 0 && !contains(s.Enum, str) {
+			return errors.Errorf("%s: `%s` isn't one of %s", at, str, strings.Join(s.Enum, ", "))
+		}
+		switch s.Format {
+		case FormatDecimal:
+			if _, ok := new(big.Float).SetString(str); !ok {
+				return errors.Errorf("%s: `%s` isn't a decimal", at, str)
+			}
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				return errors.Errorf("%s: `%s` isn't a date-time", at, str)
+			}
+		}
+
+	case "integer":
+		n, ok := v.(float64)
+		if !ok || n != math.Trunc(n) {
+			return typeError(at, s.Type, v)
+		}
+
+	case "number":
+		if _, ok := v.(float64); !ok {
+			return typeError(at, s.Type, v)
+		}
+
+	case "boolean":
+		if _, ok := v.(bool); !ok {
+			return typeError(at, s.Type, v)
+		}
+	}
+	return nil
+}
+
+func typeError(at string, want string, v interface{}) error {
+	got, _ := json.Marshal(v)
+	return errors.Errorf("%s: %s isn't a %s", at, got, want)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package openapi_test
+
+import (
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/pkg/openapi"
+)
+
+type testThing struct {
+	UUID    string     `json:"uuid"`
+	Amount  *big.Float `json:"amount"`
+	Count   int        `json:"count"`
+	Created time.Time  `json:"created_at"`
+	Note    string     `json:"note,omitempty"`
+	Parent  *testThing `json:"parent"`
+}
+
+func newTestDocument() *openapi.Document {
+	d := openapi.NewDocument("test", "1")
+	s := &d.Components
+
+	d.Add("GET", "/things/:id", &openapi.Operation{
+		OperationID: "getThing",
+		Responses: map[string]*openapi.Response{
+			"200": {Content: map[string]*openapi.MediaType{"application/json": {Schema: s.Object(map[string]interface{}{
+				"thing": testThing{},
+				"tags":  []string{},
+			})}}},
+			"204": {Description: "nothing"},
+		},
+	})
+	return d
+}
+
+func TestValidateResponse(t *testing.T) {
+	d := newTestDocument()
+	valid := `{"tags": ["a"], "thing": {"uuid": "t1", "amount": "1.50", "count": 2, "created_at": "2020-01-02T03:04:05Z", "parent": null}}`
+
+	for _, tc := range []struct {
+		name        string
+		method      string
+		status      int
+		contentType string
+		body        string
+		want        string
+	}{
+		{name: "valid", status: 200, body: valid},
+		{name: "no content", status: 204},
+		{name: "body without content", status: 204, body: `{}`, want: "documents no body for status 204"},
+		{name: "undocumented status", status: 500, body: `{}`, want: "doesn't document status 500"},
+		{name: "undocumented route", method: "POST", status: 200, body: `{}`, want: "POST /things/:id isn't documented"},
+		{name: "other content type", status: 200, contentType: "text/csv", body: "a,b", want: "doesn't document a text/csv body"},
+		{name: "missing property", status: 200, body: `{"tags": []}`, want: "$: missing required property `thing`"},
+		{name: "extra property", status: 200, body: strings.Replace(valid, `"tags"`, `"labels": [], "tags"`, 1), want: "$: undocumented property `labels`"},
+		{name: "null array", status: 200, body: strings.Replace(valid, `["a"]`, `null`, 1), want: "$.tags: null isn't a array"},
+		{name: "wrong item type", status: 200, body: strings.Replace(valid, `["a"]`, `[1]`, 1), want: "$.tags[0]: 1 isn't a string"},
+		{name: "bad decimal", status: 200, body: strings.Replace(valid, `"1.50"`, `1.5`, 1), want: "$.thing.amount: 1.5 isn't a string"},
+		{name: "fractional integer", status: 200, body: strings.Replace(valid, `"count": 2`, `"count": 2.5`, 1), want: "$.thing.count: 2.5 isn't a integer"},
+		{name: "bad date-time", status: 200, body: strings.Replace(valid, `2020-01-02T03:04:05Z`, `2020-01-02`, 1), want: "$.thing.created_at: `2020-01-02` isn't a date-time"},
+		{name: "nested reference", status: 200, body: strings.Replace(valid, `"parent": null`, `"parent": {"uuid": "t0"}`, 1), want: "$.thing.parent: missing required property `amount`"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			method, contentType := tc.method, tc.contentType
+			if method == "" {
+				method = "GET"
+			}
+			if contentType == "" {
+				contentType = "application/json; charset=utf-8"
+			}
+
+			err := d.ValidateResponse(method, "/things/:id", tc.status, contentType, []byte(tc.body))
+			switch {
+			case tc.want == "" && err != nil:
+				t.Errorf("got %s", err)
+			case tc.want != "" && (err == nil || !strings.Contains(err.Error(), tc.want)):
+				t.Errorf("got %v, want an error containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateResponseDefault(t *testing.T) {
+	d := newTestDocument()
+	op, _ := d.Lookup("GET", "/things/:id")
+	op.Responses["default"] = &openapi.Response{Content: map[string]*openapi.MediaType{
+		"application/json": {Schema: d.Components.Object(map[string]interface{}{"error": ""})},
+	}}
+
+	if err := d.ValidateResponse("GET", "/things/:id", http.StatusNotFound, "application/json", []byte(`{"error": "no such thing"}`)); err != nil {
+		t.Error(err)
+	}
+	if err := d.ValidateResponse("GET", "/things/:id", http.StatusNotFound, "application/json", []byte(`{"message": "no such thing"}`)); err == nil {
+		t.Error("an error without `error` was allowed")
+	}
+}
@@ -0,0 +1,55 @@
+package plaidapi
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/plaid/plaid-go/plaid"
+)
+
+//ClientAgent implements Client with a *plaid.Client, adding the calls
+//that the Plaid library doesn't wrap
+type ClientAgent struct {
+	*plaid.Client
+
+	clientID string
+	secret   string
+}
+
+//NewClient creates a new ClientAgent. The credentials must match the
+//ones the *plaid.Client was created with.
+func NewClient(client *plaid.Client, clientID string, secret string) ClientAgent {
+	return ClientAgent{
+		Client:   client,
+		clientID: clientID,
+		secret:   secret,
+	}
+}
+
+type refreshTransactionsRequest struct {
+	ClientID    string `json:"client_id"`
+	Secret      string `json:"secret"`
+	AccessToken string `json:"access_token"`
+}
+
+//RefreshTransactionsResponse is the response to /transactions/refresh
+type RefreshTransactionsResponse struct {
+	plaid.APIResponse
+}
+
+//RefreshTransactions asks Plaid to check the institution for new
+//transactions right away. Plaid reports anything it finds through the
+//usual transactions webhooks.
+func (c ClientAgent) RefreshTransactions(accessToken string) (resp RefreshTransactionsResponse, err error) {
+	body, err := json.Marshal(refreshTransactionsRequest{
+		ClientID:    c.clientID,
+		Secret:      c.secret,
+		AccessToken: accessToken,
+	})
+	if err != nil {
+		return resp, errors.Wrap(err, "/transactions/refresh - failed to encode request")
+	}
+
+	err = c.Call("/transactions/refresh", body, &resp)
+	return resp, err
+}
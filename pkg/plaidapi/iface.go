@@ -9,6 +9,10 @@ type Client interface {
 	GetItem(accessToken string) (resp plaid.GetItemResponse, err error)
 	GetInstitutionByIDWithOptions(id string, options plaid.GetInstitutionByIDOptions) (resp plaid.GetInstitutionByIDResponse, err error)
 	GetAccounts(accessToken string) (resp plaid.GetAccountsResponse, err error)
+	GetBalances(accessToken string) (resp plaid.GetBalancesResponse, err error)
 	GetTransactions(accessToken, startDate, endDate string) (resp plaid.GetTransactionsResponse, err error)
+	GetTransactionsWithOptions(accessToken string, options plaid.GetTransactionsOptions) (resp plaid.GetTransactionsResponse, err error)
+	RefreshTransactions(accessToken string) (resp RefreshTransactionsResponse, err error)
+	RemoveItem(accessToken string) (resp plaid.RemoveItemResponse, err error)
 	UpdateItemWebhook(accessToken, webhook string) (resp plaid.UpdateItemWebhookResponse, err error)
 }
@@ -0,0 +1,838 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package plaidapifakes
+
+import (
+	"sync"
+
+	"github.com/plaid/plaid-go/plaid"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+type FakeClient struct {
+	ExchangePublicTokenStub        func(string) (plaid.ExchangePublicTokenResponse, error)
+	exchangePublicTokenMutex       sync.RWMutex
+	exchangePublicTokenArgsForCall []struct {
+		arg1 string
+	}
+	exchangePublicTokenReturns struct {
+		result1 plaid.ExchangePublicTokenResponse
+		result2 error
+	}
+	exchangePublicTokenReturnsOnCall map[int]struct {
+		result1 plaid.ExchangePublicTokenResponse
+		result2 error
+	}
+	GetAccountsStub        func(string) (plaid.GetAccountsResponse, error)
+	getAccountsMutex       sync.RWMutex
+	getAccountsArgsForCall []struct {
+		arg1 string
+	}
+	getAccountsReturns struct {
+		result1 plaid.GetAccountsResponse
+		result2 error
+	}
+	getAccountsReturnsOnCall map[int]struct {
+		result1 plaid.GetAccountsResponse
+		result2 error
+	}
+	GetBalancesStub        func(string) (plaid.GetBalancesResponse, error)
+	getBalancesMutex       sync.RWMutex
+	getBalancesArgsForCall []struct {
+		arg1 string
+	}
+	getBalancesReturns struct {
+		result1 plaid.GetBalancesResponse
+		result2 error
+	}
+	getBalancesReturnsOnCall map[int]struct {
+		result1 plaid.GetBalancesResponse
+		result2 error
+	}
+	GetInstitutionByIDWithOptionsStub        func(string, plaid.GetInstitutionByIDOptions) (plaid.GetInstitutionByIDResponse, error)
+	getInstitutionByIDWithOptionsMutex       sync.RWMutex
+	getInstitutionByIDWithOptionsArgsForCall []struct {
+		arg1 string
+		arg2 plaid.GetInstitutionByIDOptions
+	}
+	getInstitutionByIDWithOptionsReturns struct {
+		result1 plaid.GetInstitutionByIDResponse
+		result2 error
+	}
+	getInstitutionByIDWithOptionsReturnsOnCall map[int]struct {
+		result1 plaid.GetInstitutionByIDResponse
+		result2 error
+	}
+	GetItemStub        func(string) (plaid.GetItemResponse, error)
+	getItemMutex       sync.RWMutex
+	getItemArgsForCall []struct {
+		arg1 string
+	}
+	getItemReturns struct {
+		result1 plaid.GetItemResponse
+		result2 error
+	}
+	getItemReturnsOnCall map[int]struct {
+		result1 plaid.GetItemResponse
+		result2 error
+	}
+	GetTransactionsStub        func(string, string, string) (plaid.GetTransactionsResponse, error)
+	getTransactionsMutex       sync.RWMutex
+	getTransactionsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	getTransactionsReturns struct {
+		result1 plaid.GetTransactionsResponse
+		result2 error
+	}
+	getTransactionsReturnsOnCall map[int]struct {
+		result1 plaid.GetTransactionsResponse
+		result2 error
+	}
+	GetTransactionsWithOptionsStub        func(string, plaid.GetTransactionsOptions) (plaid.GetTransactionsResponse, error)
+	getTransactionsWithOptionsMutex       sync.RWMutex
+	getTransactionsWithOptionsArgsForCall []struct {
+		arg1 string
+		arg2 plaid.GetTransactionsOptions
+	}
+	getTransactionsWithOptionsReturns struct {
+		result1 plaid.GetTransactionsResponse
+		result2 error
+	}
+	getTransactionsWithOptionsReturnsOnCall map[int]struct {
+		result1 plaid.GetTransactionsResponse
+		result2 error
+	}
+	RefreshTransactionsStub        func(string) (plaidapi.RefreshTransactionsResponse, error)
+	refreshTransactionsMutex       sync.RWMutex
+	refreshTransactionsArgsForCall []struct {
+		arg1 string
+	}
+	refreshTransactionsReturns struct {
+		result1 plaidapi.RefreshTransactionsResponse
+		result2 error
+	}
+	refreshTransactionsReturnsOnCall map[int]struct {
+		result1 plaidapi.RefreshTransactionsResponse
+		result2 error
+	}
+	RemoveItemStub        func(string) (plaid.RemoveItemResponse, error)
+	removeItemMutex       sync.RWMutex
+	removeItemArgsForCall []struct {
+		arg1 string
+	}
+	removeItemReturns struct {
+		result1 plaid.RemoveItemResponse
+		result2 error
+	}
+	removeItemReturnsOnCall map[int]struct {
+		result1 plaid.RemoveItemResponse
+		result2 error
+	}
+	UpdateItemWebhookStub        func(string, string) (plaid.UpdateItemWebhookResponse, error)
+	updateItemWebhookMutex       sync.RWMutex
+	updateItemWebhookArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	updateItemWebhookReturns struct {
+		result1 plaid.UpdateItemWebhookResponse
+		result2 error
+	}
+	updateItemWebhookReturnsOnCall map[int]struct {
+		result1 plaid.UpdateItemWebhookResponse
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeClient) ExchangePublicToken(arg1 string) (plaid.ExchangePublicTokenResponse, error) {
+	fake.exchangePublicTokenMutex.Lock()
+	ret, specificReturn := fake.exchangePublicTokenReturnsOnCall[len(fake.exchangePublicTokenArgsForCall)]
+	fake.exchangePublicTokenArgsForCall = append(fake.exchangePublicTokenArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.ExchangePublicTokenStub
+	fakeReturns := fake.exchangePublicTokenReturns
+	fake.recordInvocation("ExchangePublicToken", []interface{}{arg1})
+	fake.exchangePublicTokenMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ExchangePublicTokenCallCount() int {
+	fake.exchangePublicTokenMutex.RLock()
+	defer fake.exchangePublicTokenMutex.RUnlock()
+	return len(fake.exchangePublicTokenArgsForCall)
+}
+
+func (fake *FakeClient) ExchangePublicTokenCalls(stub func(string) (plaid.ExchangePublicTokenResponse, error)) {
+	fake.exchangePublicTokenMutex.Lock()
+	defer fake.exchangePublicTokenMutex.Unlock()
+	fake.ExchangePublicTokenStub = stub
+}
+
+func (fake *FakeClient) ExchangePublicTokenArgsForCall(i int) string {
+	fake.exchangePublicTokenMutex.RLock()
+	defer fake.exchangePublicTokenMutex.RUnlock()
+	argsForCall := fake.exchangePublicTokenArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ExchangePublicTokenReturns(result1 plaid.ExchangePublicTokenResponse, result2 error) {
+	fake.exchangePublicTokenMutex.Lock()
+	defer fake.exchangePublicTokenMutex.Unlock()
+	fake.ExchangePublicTokenStub = nil
+	fake.exchangePublicTokenReturns = struct {
+		result1 plaid.ExchangePublicTokenResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ExchangePublicTokenReturnsOnCall(i int, result1 plaid.ExchangePublicTokenResponse, result2 error) {
+	fake.exchangePublicTokenMutex.Lock()
+	defer fake.exchangePublicTokenMutex.Unlock()
+	fake.ExchangePublicTokenStub = nil
+	if fake.exchangePublicTokenReturnsOnCall == nil {
+		fake.exchangePublicTokenReturnsOnCall = make(map[int]struct {
+			result1 plaid.ExchangePublicTokenResponse
+			result2 error
+		})
+	}
+	fake.exchangePublicTokenReturnsOnCall[i] = struct {
+		result1 plaid.ExchangePublicTokenResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetAccounts(arg1 string) (plaid.GetAccountsResponse, error) {
+	fake.getAccountsMutex.Lock()
+	ret, specificReturn := fake.getAccountsReturnsOnCall[len(fake.getAccountsArgsForCall)]
+	fake.getAccountsArgsForCall = append(fake.getAccountsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetAccountsStub
+	fakeReturns := fake.getAccountsReturns
+	fake.recordInvocation("GetAccounts", []interface{}{arg1})
+	fake.getAccountsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetAccountsCallCount() int {
+	fake.getAccountsMutex.RLock()
+	defer fake.getAccountsMutex.RUnlock()
+	return len(fake.getAccountsArgsForCall)
+}
+
+func (fake *FakeClient) GetAccountsCalls(stub func(string) (plaid.GetAccountsResponse, error)) {
+	fake.getAccountsMutex.Lock()
+	defer fake.getAccountsMutex.Unlock()
+	fake.GetAccountsStub = stub
+}
+
+func (fake *FakeClient) GetAccountsArgsForCall(i int) string {
+	fake.getAccountsMutex.RLock()
+	defer fake.getAccountsMutex.RUnlock()
+	argsForCall := fake.getAccountsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) GetAccountsReturns(result1 plaid.GetAccountsResponse, result2 error) {
+	fake.getAccountsMutex.Lock()
+	defer fake.getAccountsMutex.Unlock()
+	fake.GetAccountsStub = nil
+	fake.getAccountsReturns = struct {
+		result1 plaid.GetAccountsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetAccountsReturnsOnCall(i int, result1 plaid.GetAccountsResponse, result2 error) {
+	fake.getAccountsMutex.Lock()
+	defer fake.getAccountsMutex.Unlock()
+	fake.GetAccountsStub = nil
+	if fake.getAccountsReturnsOnCall == nil {
+		fake.getAccountsReturnsOnCall = make(map[int]struct {
+			result1 plaid.GetAccountsResponse
+			result2 error
+		})
+	}
+	fake.getAccountsReturnsOnCall[i] = struct {
+		result1 plaid.GetAccountsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetBalances(arg1 string) (plaid.GetBalancesResponse, error) {
+	fake.getBalancesMutex.Lock()
+	ret, specificReturn := fake.getBalancesReturnsOnCall[len(fake.getBalancesArgsForCall)]
+	fake.getBalancesArgsForCall = append(fake.getBalancesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetBalancesStub
+	fakeReturns := fake.getBalancesReturns
+	fake.recordInvocation("GetBalances", []interface{}{arg1})
+	fake.getBalancesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetBalancesCallCount() int {
+	fake.getBalancesMutex.RLock()
+	defer fake.getBalancesMutex.RUnlock()
+	return len(fake.getBalancesArgsForCall)
+}
+
+func (fake *FakeClient) GetBalancesCalls(stub func(string) (plaid.GetBalancesResponse, error)) {
+	fake.getBalancesMutex.Lock()
+	defer fake.getBalancesMutex.Unlock()
+	fake.GetBalancesStub = stub
+}
+
+func (fake *FakeClient) GetBalancesArgsForCall(i int) string {
+	fake.getBalancesMutex.RLock()
+	defer fake.getBalancesMutex.RUnlock()
+	argsForCall := fake.getBalancesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) GetBalancesReturns(result1 plaid.GetBalancesResponse, result2 error) {
+	fake.getBalancesMutex.Lock()
+	defer fake.getBalancesMutex.Unlock()
+	fake.GetBalancesStub = nil
+	fake.getBalancesReturns = struct {
+		result1 plaid.GetBalancesResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetBalancesReturnsOnCall(i int, result1 plaid.GetBalancesResponse, result2 error) {
+	fake.getBalancesMutex.Lock()
+	defer fake.getBalancesMutex.Unlock()
+	fake.GetBalancesStub = nil
+	if fake.getBalancesReturnsOnCall == nil {
+		fake.getBalancesReturnsOnCall = make(map[int]struct {
+			result1 plaid.GetBalancesResponse
+			result2 error
+		})
+	}
+	fake.getBalancesReturnsOnCall[i] = struct {
+		result1 plaid.GetBalancesResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetInstitutionByIDWithOptions(arg1 string, arg2 plaid.GetInstitutionByIDOptions) (plaid.GetInstitutionByIDResponse, error) {
+	fake.getInstitutionByIDWithOptionsMutex.Lock()
+	ret, specificReturn := fake.getInstitutionByIDWithOptionsReturnsOnCall[len(fake.getInstitutionByIDWithOptionsArgsForCall)]
+	fake.getInstitutionByIDWithOptionsArgsForCall = append(fake.getInstitutionByIDWithOptionsArgsForCall, struct {
+		arg1 string
+		arg2 plaid.GetInstitutionByIDOptions
+	}{arg1, arg2})
+	stub := fake.GetInstitutionByIDWithOptionsStub
+	fakeReturns := fake.getInstitutionByIDWithOptionsReturns
+	fake.recordInvocation("GetInstitutionByIDWithOptions", []interface{}{arg1, arg2})
+	fake.getInstitutionByIDWithOptionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetInstitutionByIDWithOptionsCallCount() int {
+	fake.getInstitutionByIDWithOptionsMutex.RLock()
+	defer fake.getInstitutionByIDWithOptionsMutex.RUnlock()
+	return len(fake.getInstitutionByIDWithOptionsArgsForCall)
+}
+
+func (fake *FakeClient) GetInstitutionByIDWithOptionsCalls(stub func(string, plaid.GetInstitutionByIDOptions) (plaid.GetInstitutionByIDResponse, error)) {
+	fake.getInstitutionByIDWithOptionsMutex.Lock()
+	defer fake.getInstitutionByIDWithOptionsMutex.Unlock()
+	fake.GetInstitutionByIDWithOptionsStub = stub
+}
+
+func (fake *FakeClient) GetInstitutionByIDWithOptionsArgsForCall(i int) (string, plaid.GetInstitutionByIDOptions) {
+	fake.getInstitutionByIDWithOptionsMutex.RLock()
+	defer fake.getInstitutionByIDWithOptionsMutex.RUnlock()
+	argsForCall := fake.getInstitutionByIDWithOptionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) GetInstitutionByIDWithOptionsReturns(result1 plaid.GetInstitutionByIDResponse, result2 error) {
+	fake.getInstitutionByIDWithOptionsMutex.Lock()
+	defer fake.getInstitutionByIDWithOptionsMutex.Unlock()
+	fake.GetInstitutionByIDWithOptionsStub = nil
+	fake.getInstitutionByIDWithOptionsReturns = struct {
+		result1 plaid.GetInstitutionByIDResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetInstitutionByIDWithOptionsReturnsOnCall(i int, result1 plaid.GetInstitutionByIDResponse, result2 error) {
+	fake.getInstitutionByIDWithOptionsMutex.Lock()
+	defer fake.getInstitutionByIDWithOptionsMutex.Unlock()
+	fake.GetInstitutionByIDWithOptionsStub = nil
+	if fake.getInstitutionByIDWithOptionsReturnsOnCall == nil {
+		fake.getInstitutionByIDWithOptionsReturnsOnCall = make(map[int]struct {
+			result1 plaid.GetInstitutionByIDResponse
+			result2 error
+		})
+	}
+	fake.getInstitutionByIDWithOptionsReturnsOnCall[i] = struct {
+		result1 plaid.GetInstitutionByIDResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetItem(arg1 string) (plaid.GetItemResponse, error) {
+	fake.getItemMutex.Lock()
+	ret, specificReturn := fake.getItemReturnsOnCall[len(fake.getItemArgsForCall)]
+	fake.getItemArgsForCall = append(fake.getItemArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.GetItemStub
+	fakeReturns := fake.getItemReturns
+	fake.recordInvocation("GetItem", []interface{}{arg1})
+	fake.getItemMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetItemCallCount() int {
+	fake.getItemMutex.RLock()
+	defer fake.getItemMutex.RUnlock()
+	return len(fake.getItemArgsForCall)
+}
+
+func (fake *FakeClient) GetItemCalls(stub func(string) (plaid.GetItemResponse, error)) {
+	fake.getItemMutex.Lock()
+	defer fake.getItemMutex.Unlock()
+	fake.GetItemStub = stub
+}
+
+func (fake *FakeClient) GetItemArgsForCall(i int) string {
+	fake.getItemMutex.RLock()
+	defer fake.getItemMutex.RUnlock()
+	argsForCall := fake.getItemArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) GetItemReturns(result1 plaid.GetItemResponse, result2 error) {
+	fake.getItemMutex.Lock()
+	defer fake.getItemMutex.Unlock()
+	fake.GetItemStub = nil
+	fake.getItemReturns = struct {
+		result1 plaid.GetItemResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetItemReturnsOnCall(i int, result1 plaid.GetItemResponse, result2 error) {
+	fake.getItemMutex.Lock()
+	defer fake.getItemMutex.Unlock()
+	fake.GetItemStub = nil
+	if fake.getItemReturnsOnCall == nil {
+		fake.getItemReturnsOnCall = make(map[int]struct {
+			result1 plaid.GetItemResponse
+			result2 error
+		})
+	}
+	fake.getItemReturnsOnCall[i] = struct {
+		result1 plaid.GetItemResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetTransactions(arg1 string, arg2 string, arg3 string) (plaid.GetTransactionsResponse, error) {
+	fake.getTransactionsMutex.Lock()
+	ret, specificReturn := fake.getTransactionsReturnsOnCall[len(fake.getTransactionsArgsForCall)]
+	fake.getTransactionsArgsForCall = append(fake.getTransactionsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetTransactionsStub
+	fakeReturns := fake.getTransactionsReturns
+	fake.recordInvocation("GetTransactions", []interface{}{arg1, arg2, arg3})
+	fake.getTransactionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetTransactionsCallCount() int {
+	fake.getTransactionsMutex.RLock()
+	defer fake.getTransactionsMutex.RUnlock()
+	return len(fake.getTransactionsArgsForCall)
+}
+
+func (fake *FakeClient) GetTransactionsCalls(stub func(string, string, string) (plaid.GetTransactionsResponse, error)) {
+	fake.getTransactionsMutex.Lock()
+	defer fake.getTransactionsMutex.Unlock()
+	fake.GetTransactionsStub = stub
+}
+
+func (fake *FakeClient) GetTransactionsArgsForCall(i int) (string, string, string) {
+	fake.getTransactionsMutex.RLock()
+	defer fake.getTransactionsMutex.RUnlock()
+	argsForCall := fake.getTransactionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeClient) GetTransactionsReturns(result1 plaid.GetTransactionsResponse, result2 error) {
+	fake.getTransactionsMutex.Lock()
+	defer fake.getTransactionsMutex.Unlock()
+	fake.GetTransactionsStub = nil
+	fake.getTransactionsReturns = struct {
+		result1 plaid.GetTransactionsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetTransactionsReturnsOnCall(i int, result1 plaid.GetTransactionsResponse, result2 error) {
+	fake.getTransactionsMutex.Lock()
+	defer fake.getTransactionsMutex.Unlock()
+	fake.GetTransactionsStub = nil
+	if fake.getTransactionsReturnsOnCall == nil {
+		fake.getTransactionsReturnsOnCall = make(map[int]struct {
+			result1 plaid.GetTransactionsResponse
+			result2 error
+		})
+	}
+	fake.getTransactionsReturnsOnCall[i] = struct {
+		result1 plaid.GetTransactionsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetTransactionsWithOptions(arg1 string, arg2 plaid.GetTransactionsOptions) (plaid.GetTransactionsResponse, error) {
+	fake.getTransactionsWithOptionsMutex.Lock()
+	ret, specificReturn := fake.getTransactionsWithOptionsReturnsOnCall[len(fake.getTransactionsWithOptionsArgsForCall)]
+	fake.getTransactionsWithOptionsArgsForCall = append(fake.getTransactionsWithOptionsArgsForCall, struct {
+		arg1 string
+		arg2 plaid.GetTransactionsOptions
+	}{arg1, arg2})
+	stub := fake.GetTransactionsWithOptionsStub
+	fakeReturns := fake.getTransactionsWithOptionsReturns
+	fake.recordInvocation("GetTransactionsWithOptions", []interface{}{arg1, arg2})
+	fake.getTransactionsWithOptionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetTransactionsWithOptionsCallCount() int {
+	fake.getTransactionsWithOptionsMutex.RLock()
+	defer fake.getTransactionsWithOptionsMutex.RUnlock()
+	return len(fake.getTransactionsWithOptionsArgsForCall)
+}
+
+func (fake *FakeClient) GetTransactionsWithOptionsCalls(stub func(string, plaid.GetTransactionsOptions) (plaid.GetTransactionsResponse, error)) {
+	fake.getTransactionsWithOptionsMutex.Lock()
+	defer fake.getTransactionsWithOptionsMutex.Unlock()
+	fake.GetTransactionsWithOptionsStub = stub
+}
+
+func (fake *FakeClient) GetTransactionsWithOptionsArgsForCall(i int) (string, plaid.GetTransactionsOptions) {
+	fake.getTransactionsWithOptionsMutex.RLock()
+	defer fake.getTransactionsWithOptionsMutex.RUnlock()
+	argsForCall := fake.getTransactionsWithOptionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) GetTransactionsWithOptionsReturns(result1 plaid.GetTransactionsResponse, result2 error) {
+	fake.getTransactionsWithOptionsMutex.Lock()
+	defer fake.getTransactionsWithOptionsMutex.Unlock()
+	fake.GetTransactionsWithOptionsStub = nil
+	fake.getTransactionsWithOptionsReturns = struct {
+		result1 plaid.GetTransactionsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetTransactionsWithOptionsReturnsOnCall(i int, result1 plaid.GetTransactionsResponse, result2 error) {
+	fake.getTransactionsWithOptionsMutex.Lock()
+	defer fake.getTransactionsWithOptionsMutex.Unlock()
+	fake.GetTransactionsWithOptionsStub = nil
+	if fake.getTransactionsWithOptionsReturnsOnCall == nil {
+		fake.getTransactionsWithOptionsReturnsOnCall = make(map[int]struct {
+			result1 plaid.GetTransactionsResponse
+			result2 error
+		})
+	}
+	fake.getTransactionsWithOptionsReturnsOnCall[i] = struct {
+		result1 plaid.GetTransactionsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RefreshTransactions(arg1 string) (plaidapi.RefreshTransactionsResponse, error) {
+	fake.refreshTransactionsMutex.Lock()
+	ret, specificReturn := fake.refreshTransactionsReturnsOnCall[len(fake.refreshTransactionsArgsForCall)]
+	fake.refreshTransactionsArgsForCall = append(fake.refreshTransactionsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RefreshTransactionsStub
+	fakeReturns := fake.refreshTransactionsReturns
+	fake.recordInvocation("RefreshTransactions", []interface{}{arg1})
+	fake.refreshTransactionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RefreshTransactionsCallCount() int {
+	fake.refreshTransactionsMutex.RLock()
+	defer fake.refreshTransactionsMutex.RUnlock()
+	return len(fake.refreshTransactionsArgsForCall)
+}
+
+func (fake *FakeClient) RefreshTransactionsCalls(stub func(string) (plaidapi.RefreshTransactionsResponse, error)) {
+	fake.refreshTransactionsMutex.Lock()
+	defer fake.refreshTransactionsMutex.Unlock()
+	fake.RefreshTransactionsStub = stub
+}
+
+func (fake *FakeClient) RefreshTransactionsArgsForCall(i int) string {
+	fake.refreshTransactionsMutex.RLock()
+	defer fake.refreshTransactionsMutex.RUnlock()
+	argsForCall := fake.refreshTransactionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) RefreshTransactionsReturns(result1 plaidapi.RefreshTransactionsResponse, result2 error) {
+	fake.refreshTransactionsMutex.Lock()
+	defer fake.refreshTransactionsMutex.Unlock()
+	fake.RefreshTransactionsStub = nil
+	fake.refreshTransactionsReturns = struct {
+		result1 plaidapi.RefreshTransactionsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RefreshTransactionsReturnsOnCall(i int, result1 plaidapi.RefreshTransactionsResponse, result2 error) {
+	fake.refreshTransactionsMutex.Lock()
+	defer fake.refreshTransactionsMutex.Unlock()
+	fake.RefreshTransactionsStub = nil
+	if fake.refreshTransactionsReturnsOnCall == nil {
+		fake.refreshTransactionsReturnsOnCall = make(map[int]struct {
+			result1 plaidapi.RefreshTransactionsResponse
+			result2 error
+		})
+	}
+	fake.refreshTransactionsReturnsOnCall[i] = struct {
+		result1 plaidapi.RefreshTransactionsResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RemoveItem(arg1 string) (plaid.RemoveItemResponse, error) {
+	fake.removeItemMutex.Lock()
+	ret, specificReturn := fake.removeItemReturnsOnCall[len(fake.removeItemArgsForCall)]
+	fake.removeItemArgsForCall = append(fake.removeItemArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RemoveItemStub
+	fakeReturns := fake.removeItemReturns
+	fake.recordInvocation("RemoveItem", []interface{}{arg1})
+	fake.removeItemMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) RemoveItemCallCount() int {
+	fake.removeItemMutex.RLock()
+	defer fake.removeItemMutex.RUnlock()
+	return len(fake.removeItemArgsForCall)
+}
+
+func (fake *FakeClient) RemoveItemCalls(stub func(string) (plaid.RemoveItemResponse, error)) {
+	fake.removeItemMutex.Lock()
+	defer fake.removeItemMutex.Unlock()
+	fake.RemoveItemStub = stub
+}
+
+func (fake *FakeClient) RemoveItemArgsForCall(i int) string {
+	fake.removeItemMutex.RLock()
+	defer fake.removeItemMutex.RUnlock()
+	argsForCall := fake.removeItemArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) RemoveItemReturns(result1 plaid.RemoveItemResponse, result2 error) {
+	fake.removeItemMutex.Lock()
+	defer fake.removeItemMutex.Unlock()
+	fake.RemoveItemStub = nil
+	fake.removeItemReturns = struct {
+		result1 plaid.RemoveItemResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) RemoveItemReturnsOnCall(i int, result1 plaid.RemoveItemResponse, result2 error) {
+	fake.removeItemMutex.Lock()
+	defer fake.removeItemMutex.Unlock()
+	fake.RemoveItemStub = nil
+	if fake.removeItemReturnsOnCall == nil {
+		fake.removeItemReturnsOnCall = make(map[int]struct {
+			result1 plaid.RemoveItemResponse
+			result2 error
+		})
+	}
+	fake.removeItemReturnsOnCall[i] = struct {
+		result1 plaid.RemoveItemResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) UpdateItemWebhook(arg1 string, arg2 string) (plaid.UpdateItemWebhookResponse, error) {
+	fake.updateItemWebhookMutex.Lock()
+	ret, specificReturn := fake.updateItemWebhookReturnsOnCall[len(fake.updateItemWebhookArgsForCall)]
+	fake.updateItemWebhookArgsForCall = append(fake.updateItemWebhookArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.UpdateItemWebhookStub
+	fakeReturns := fake.updateItemWebhookReturns
+	fake.recordInvocation("UpdateItemWebhook", []interface{}{arg1, arg2})
+	fake.updateItemWebhookMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) UpdateItemWebhookCallCount() int {
+	fake.updateItemWebhookMutex.RLock()
+	defer fake.updateItemWebhookMutex.RUnlock()
+	return len(fake.updateItemWebhookArgsForCall)
+}
+
+func (fake *FakeClient) UpdateItemWebhookCalls(stub func(string, string) (plaid.UpdateItemWebhookResponse, error)) {
+	fake.updateItemWebhookMutex.Lock()
+	defer fake.updateItemWebhookMutex.Unlock()
+	fake.UpdateItemWebhookStub = stub
+}
+
+func (fake *FakeClient) UpdateItemWebhookArgsForCall(i int) (string, string) {
+	fake.updateItemWebhookMutex.RLock()
+	defer fake.updateItemWebhookMutex.RUnlock()
+	argsForCall := fake.updateItemWebhookArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) UpdateItemWebhookReturns(result1 plaid.UpdateItemWebhookResponse, result2 error) {
+	fake.updateItemWebhookMutex.Lock()
+	defer fake.updateItemWebhookMutex.Unlock()
+	fake.UpdateItemWebhookStub = nil
+	fake.updateItemWebhookReturns = struct {
+		result1 plaid.UpdateItemWebhookResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) UpdateItemWebhookReturnsOnCall(i int, result1 plaid.UpdateItemWebhookResponse, result2 error) {
+	fake.updateItemWebhookMutex.Lock()
+	defer fake.updateItemWebhookMutex.Unlock()
+	fake.UpdateItemWebhookStub = nil
+	if fake.updateItemWebhookReturnsOnCall == nil {
+		fake.updateItemWebhookReturnsOnCall = make(map[int]struct {
+			result1 plaid.UpdateItemWebhookResponse
+			result2 error
+		})
+	}
+	fake.updateItemWebhookReturnsOnCall[i] = struct {
+		result1 plaid.UpdateItemWebhookResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.exchangePublicTokenMutex.RLock()
+	defer fake.exchangePublicTokenMutex.RUnlock()
+	fake.getAccountsMutex.RLock()
+	defer fake.getAccountsMutex.RUnlock()
+	fake.getBalancesMutex.RLock()
+	defer fake.getBalancesMutex.RUnlock()
+	fake.getInstitutionByIDWithOptionsMutex.RLock()
+	defer fake.getInstitutionByIDWithOptionsMutex.RUnlock()
+	fake.getItemMutex.RLock()
+	defer fake.getItemMutex.RUnlock()
+	fake.getTransactionsMutex.RLock()
+	defer fake.getTransactionsMutex.RUnlock()
+	fake.getTransactionsWithOptionsMutex.RLock()
+	defer fake.getTransactionsWithOptionsMutex.RUnlock()
+	fake.refreshTransactionsMutex.RLock()
+	defer fake.refreshTransactionsMutex.RUnlock()
+	fake.removeItemMutex.RLock()
+	defer fake.removeItemMutex.RUnlock()
+	fake.updateItemWebhookMutex.RLock()
+	defer fake.updateItemWebhookMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeClient) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ plaidapi.Client = new(FakeClient)
@@ -0,0 +1,261 @@
+package recurring
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//Cadence is how often a recurring transaction happens
+type Cadence string
+
+const (
+	CadenceWeekly   Cadence = "weekly"
+	CadenceBiweekly Cadence = "biweekly"
+	CadenceMonthly  Cadence = "monthly"
+	CadenceYearly   Cadence = "yearly"
+)
+
+//cadences lists the typical number of days between occurrences of each
+//cadence, and how far off an interval may be and still count
+var cadences = []struct {
+	cadence   Cadence
+	days      int
+	tolerance int
+}{
+	{CadenceWeekly, 7, 1},
+	{CadenceBiweekly, 14, 2},
+	{CadenceMonthly, 30, 4},
+	{CadenceYearly, 365, 10},
+}
+
+//Next returns the date of the occurrence after t
+func (c Cadence) Next(t time.Time) time.Time {
+	switch c {
+	case CadenceWeekly:
+		return t.AddDate(0, 0, 7)
+	case CadenceBiweekly:
+		return t.AddDate(0, 0, 14)
+	case CadenceMonthly:
+		return t.AddDate(0, 1, 0)
+	case CadenceYearly:
+		return t.AddDate(1, 0, 0)
+	}
+	return t
+}
+
+//Series is a run of similar transactions at a regular interval, such as
+//a paycheck, a subscription or a bill
+type Series struct {
+	AccountUUID     string `json:"account_uuid"`
+	Name            string `json:"name"`
+	ISOCurrencyCode string `json:"iso_currency_code"`
+
+	//Amount is the typical amount, using Plaid's sign convention
+	Amount  *big.Float `json:"amount"`
+	Cadence Cadence    `json:"cadence"`
+
+	FirstDate string `json:"first_date"`
+	LastDate  string `json:"last_date"`
+	NextDate  string `json:"next_date"`
+
+	TransactionUUIDs []string `json:"transaction_uuids"`
+}
+
+//Detector finds recurring series in a user's transaction history
+//go:generate counterfeiter . Detector
+type Detector interface {
+	Detect(transactions []db.Transaction, asOf time.Time) []Series
+}
+
+//DetectorAgent implements Detector
+type DetectorAgent struct {
+	minOccurrences  int
+	amountTolerance float64
+}
+
+//NewDetector creates a new DetectorAgent that requires at least
+//minOccurrences similar transactions before calling them a series
+func NewDetector(minOccurrences int) DetectorAgent {
+	return DetectorAgent{
+		minOccurrences:  minOccurrences,
+		amountTolerance: 0.2,
+	}
+}
+
+//Detect groups transactions by account and merchant, and reports each
+//group whose dates fall at a regular cadence and whose amounts stay
+//within 20% of their median. Series that have missed two expected
+//occurrences as of `asOf` are considered cancelled and left out.
+func (d DetectorAgent) Detect(transactions []db.Transaction, asOf time.Time) []Series {
+	groups := map[string][]db.Transaction{}
+	var keys []string
+	for _, t := range transactions {
+		if t.PlaidPending || t.Amount == nil || t.Amount.Sign() == 0 {
+			continue
+		}
+		if _, err := time.Parse(plaidapi.DateFormat, t.Date); err != nil {
+			continue
+		}
+
+		name := NormalizeName(t.PlaidName)
+		if name == "" {
+			continue
+		}
+
+		//inflows and outflows from the same merchant, like refunds,
+		//are kept apart
+		key := t.AccountUUID + "|" + name + "|" + t.ISOCurrencyCode + "|" + sign(t)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], t)
+	}
+	sort.Strings(keys)
+
+	series := []Series{}
+	for _, key := range keys {
+		if s, ok := d.detectSeries(groups[key], asOf); ok {
+			series = append(series, s)
+		}
+	}
+	return series
+}
+
+func (d DetectorAgent) detectSeries(group []db.Transaction, asOf time.Time) (Series, bool) {
+	if len(group) < d.minOccurrences {
+		return Series{}, false
+	}
+
+	sort.SliceStable(group, func(i, j int) bool { return group[i].Date < group[j].Date })
+
+	var intervals []int
+	for i := 1; i < len(group); i++ {
+		intervals = append(intervals, daysBetween(group[i-1].Date, group[i].Date))
+	}
+
+	cadence, tolerance, ok := classify(intervals)
+	if !ok {
+		return Series{}, false
+	}
+
+	amounts := make([]float64, len(group))
+	for i, t := range group {
+		amounts[i], _ = t.Amount.Float64()
+	}
+	typical := median(amounts)
+	for _, amount := range amounts {
+		if abs(amount-typical) > abs(typical)*d.amountTolerance {
+			return Series{}, false
+		}
+	}
+
+	first, _ := time.Parse(plaidapi.DateFormat, group[0].Date)
+	last, _ := time.Parse(plaidapi.DateFormat, group[len(group)-1].Date)
+	next := cadence.Next(last)
+	for !next.After(asOf.AddDate(0, 0, -tolerance)) {
+		//allow for a single missed or late occurrence
+		if cadence.Next(next).Before(asOf.AddDate(0, 0, -tolerance)) {
+			return Series{}, false
+		}
+		next = cadence.Next(next)
+	}
+
+	uuids := make([]string, len(group))
+	for i, t := range group {
+		uuids[i] = t.UUID
+	}
+
+	return Series{
+		AccountUUID:     group[0].AccountUUID,
+		Name:            group[len(group)-1].PlaidName,
+		ISOCurrencyCode: group[0].ISOCurrencyCode,
+
+		Amount:  new(big.Float).SetFloat64(float64(int64(typical*100+copysign(0.5, typical))) / 100),
+		Cadence: cadence,
+
+		FirstDate: first.Format(plaidapi.DateFormat),
+		LastDate:  last.Format(plaidapi.DateFormat),
+		NextDate:  next.Format(plaidapi.DateFormat),
+
+		TransactionUUIDs: uuids,
+	}, true
+}
+
+//classify picks the cadence that at least two thirds of the intervals
+//agree with
+func classify(intervals []int) (Cadence, int, bool) {
+	for _, c := range cadences {
+		matches := 0
+		for _, interval := range intervals {
+			if interval >= c.days-c.tolerance && interval <= c.days+c.tolerance {
+				matches++
+			}
+		}
+		if matches*3 >= len(intervals)*2 {
+			return c.cadence, c.tolerance, true
+		}
+	}
+	return "", 0, false
+}
+
+//NormalizeName reduces a merchant name to lowercase letters and single
+//spaces, so that store numbers and reference codes don't split a series
+func NormalizeName(name string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) {
+			if space && b.Len() > 0 {
+				b.WriteRune(' ')
+			}
+			b.WriteRune(r)
+			space = false
+			continue
+		}
+		space = true
+	}
+	return b.String()
+}
+
+func sign(t db.Transaction) string {
+	if t.Amount.Sign() < 0 {
+		return "in"
+	}
+	return "out"
+}
+
+func daysBetween(a, b string) int {
+	ta, _ := time.Parse(plaidapi.DateFormat, a)
+	tb, _ := time.Parse(plaidapi.DateFormat, b)
+	return int(tb.Sub(ta).Hours()/24 + 0.5)
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func abs(f float64) float64 {
+	if f < 0 {
+		return -f
+	}
+	return f
+}
+
+func copysign(f float64, sign float64) float64 {
+	if sign < 0 {
+		return -f
+	}
+	return f
+}
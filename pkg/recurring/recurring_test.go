@@ -0,0 +1,164 @@
+package recurring_test
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/recurring"
+)
+
+func day(date string) time.Time {
+	t, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+//transactions makes one transaction per date, all alike
+func transactions(account string, name string, amount float64, dates ...string) []db.Transaction {
+	var ts []db.Transaction
+	for _, date := range dates {
+		ts = append(ts, db.Transaction{
+			Model:           db.Model{UUID: name + " " + date},
+			AccountUUID:     account,
+			ISOCurrencyCode: "USD",
+			Amount:          big.NewFloat(amount),
+			Date:            date,
+			PlaidName:       name,
+		})
+	}
+	return ts
+}
+
+func TestDetect(t *testing.T) {
+	var history []db.Transaction
+	history = append(history, transactions("acct-1", "NETFLIX.COM 4421", 15.99, "2020-01-15", "2020-02-15", "2020-03-16", "2020-04-15")...)
+	history = append(history, transactions("acct-1", "ACME PAYROLL", -1200, "2020-03-06", "2020-03-20", "2020-04-03", "2020-04-17")...)
+	//a refund from the same merchant isn't part of the series
+	history = append(history, transactions("acct-1", "NETFLIX.COM 9876", -15.99, "2020-03-20")...)
+
+	series := recurring.NewDetector(3).Detect(history, day("2020-04-20"))
+	if len(series) != 2 {
+		t.Fatalf("detected %d series, want 2: %+v", len(series), series)
+	}
+
+	payroll, netflix := series[0], series[1]
+	if netflix.Cadence != recurring.CadenceMonthly || netflix.NextDate != "2020-05-15" ||
+		netflix.FirstDate != "2020-01-15" || netflix.LastDate != "2020-04-15" {
+		t.Errorf("netflix = %+v", netflix)
+	}
+	if netflix.Name != "NETFLIX.COM 4421" || netflix.AccountUUID != "acct-1" || netflix.ISOCurrencyCode != "USD" {
+		t.Errorf("netflix = %+v", netflix)
+	}
+	if got := netflix.Amount.Text('f', 2); got != "15.99" {
+		t.Errorf("netflix amount = %s", got)
+	}
+	if len(netflix.TransactionUUIDs) != 4 {
+		t.Errorf("netflix transactions = %v", netflix.TransactionUUIDs)
+	}
+
+	if payroll.Cadence != recurring.CadenceBiweekly || payroll.NextDate != "2020-05-01" {
+		t.Errorf("payroll = %+v", payroll)
+	}
+	if got := payroll.Amount.Text('f', 2); got != "-1200.00" {
+		t.Errorf("payroll amount = %s", got)
+	}
+}
+
+func TestDetectCadences(t *testing.T) {
+	for _, tc := range []struct {
+		dates   []string
+		asOf    string
+		cadence recurring.Cadence
+		next    string
+	}{
+		{[]string{"2020-01-01", "2020-01-08", "2020-01-15", "2020-01-22"}, "2020-01-23", recurring.CadenceWeekly, "2020-01-29"},
+		//a day late is still weekly
+		{[]string{"2020-01-01", "2020-01-09", "2020-01-15", "2020-01-22"}, "2020-01-23", recurring.CadenceWeekly, "2020-01-29"},
+		{[]string{"2020-01-01", "2020-01-15", "2020-01-29"}, "2020-01-30", recurring.CadenceBiweekly, "2020-02-12"},
+		{[]string{"2017-03-01", "2018-03-01", "2019-03-02", "2020-03-01"}, "2020-06-01", recurring.CadenceYearly, "2021-03-01"},
+		//one missed occurrence is allowed for
+		{[]string{"2020-01-10", "2020-02-10", "2020-03-10"}, "2020-04-20", recurring.CadenceMonthly, "2020-05-10"},
+	} {
+		series := recurring.NewDetector(3).Detect(transactions("acct-1", "Merchant", 10, tc.dates...), day(tc.asOf))
+		if len(series) != 1 {
+			t.Errorf("%v: detected %+v", tc.dates, series)
+			continue
+		}
+		if series[0].Cadence != tc.cadence || series[0].NextDate != tc.next {
+			t.Errorf("%v: %s next on %s, want %s next on %s", tc.dates, series[0].Cadence, series[0].NextDate, tc.cadence, tc.next)
+		}
+	}
+}
+
+func TestDetectNotRecurring(t *testing.T) {
+	varying := transactions("acct-1", "Grocer", 50, "2020-01-01", "2020-01-08", "2020-01-15", "2020-01-22")
+	varying[2].Amount = big.NewFloat(80)
+
+	split := transactions("acct-1", "Gym", 30, "2020-01-01", "2020-02-01")
+	split = append(split, transactions("acct-2", "Gym", 30, "2020-03-01")...)
+
+	ignored := transactions("acct-1", "Coffee", 4, "2020-01-01", "2020-01-08", "2020-01-15")
+	ignored[0].PlaidPending = true
+	ignored[1].Amount = new(big.Float)
+	ignored[2].Date = "Jan 15"
+
+	for name, history := range map[string][]db.Transaction{
+		"too few":         transactions("acct-1", "Gym", 30, "2020-01-01", "2020-02-01"),
+		"irregular":       transactions("acct-1", "Hardware", 20, "2020-01-01", "2020-01-04", "2020-01-20", "2020-02-28"),
+		"varying amounts": varying,
+		"cancelled":       transactions("acct-1", "Magazine", 5, "2019-10-01", "2019-11-01", "2019-12-01"),
+		"split accounts":  split,
+		"ignored":         ignored,
+		"nameless":        transactions("acct-1", "#1234", 10, "2020-01-01", "2020-01-08", "2020-01-15"),
+	} {
+		if series := recurring.NewDetector(3).Detect(history, day("2020-03-05")); len(series) != 0 {
+			t.Errorf("%s: detected %+v", name, series)
+		}
+	}
+}
+
+func TestCadenceNext(t *testing.T) {
+	for _, tc := range []struct {
+		cadence recurring.Cadence
+		want    string
+	}{
+		{recurring.CadenceWeekly, "2020-01-22"},
+		{recurring.CadenceBiweekly, "2020-01-29"},
+		{recurring.CadenceMonthly, "2020-02-15"},
+		{recurring.CadenceYearly, "2021-01-15"},
+		{"daily", "2020-01-15"},
+	} {
+		if next := tc.cadence.Next(day("2020-01-15")); !next.Equal(day(tc.want)) {
+			t.Errorf("%s: next = %s, want %s", tc.cadence, next, tc.want)
+		}
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	got := map[string]string{}
+	for _, name := range []string{
+		"NETFLIX.COM 4421",
+		"Netflix.com",
+		"  SQ *BLUE BOTTLE #0042 ",
+		"Café-Crème",
+		"#1234",
+	} {
+		got[name] = recurring.NormalizeName(name)
+	}
+
+	want := map[string]string{
+		"NETFLIX.COM 4421":         "netflix com",
+		"Netflix.com":              "netflix com",
+		"  SQ *BLUE BOTTLE #0042 ": "sq blue bottle",
+		"Café-Crème":               "café crème",
+		"#1234":                    "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalized %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package recurringfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/recurring"
+)
+
+type FakeDetector struct {
+	DetectStub        func([]db.Transaction, time.Time) []recurring.Series
+	detectMutex       sync.RWMutex
+	detectArgsForCall []struct {
+		arg1 []db.Transaction
+		arg2 time.Time
+	}
+	detectReturns struct {
+		result1 []recurring.Series
+	}
+	detectReturnsOnCall map[int]struct {
+		result1 []recurring.Series
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDetector) Detect(arg1 []db.Transaction, arg2 time.Time) []recurring.Series {
+	var arg1Copy []db.Transaction
+	if arg1 != nil {
+		arg1Copy = make([]db.Transaction, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.detectMutex.Lock()
+	ret, specificReturn := fake.detectReturnsOnCall[len(fake.detectArgsForCall)]
+	fake.detectArgsForCall = append(fake.detectArgsForCall, struct {
+		arg1 []db.Transaction
+		arg2 time.Time
+	}{arg1Copy, arg2})
+	stub := fake.DetectStub
+	fakeReturns := fake.detectReturns
+	fake.recordInvocation("Detect", []interface{}{arg1Copy, arg2})
+	fake.detectMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDetector) DetectCallCount() int {
+	fake.detectMutex.RLock()
+	defer fake.detectMutex.RUnlock()
+	return len(fake.detectArgsForCall)
+}
+
+func (fake *FakeDetector) DetectCalls(stub func([]db.Transaction, time.Time) []recurring.Series) {
+	fake.detectMutex.Lock()
+	defer fake.detectMutex.Unlock()
+	fake.DetectStub = stub
+}
+
+func (fake *FakeDetector) DetectArgsForCall(i int) ([]db.Transaction, time.Time) {
+	fake.detectMutex.RLock()
+	defer fake.detectMutex.RUnlock()
+	argsForCall := fake.detectArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeDetector) DetectReturns(result1 []recurring.Series) {
+	fake.detectMutex.Lock()
+	defer fake.detectMutex.Unlock()
+	fake.DetectStub = nil
+	fake.detectReturns = struct {
+		result1 []recurring.Series
+	}{result1}
+}
+
+func (fake *FakeDetector) DetectReturnsOnCall(i int, result1 []recurring.Series) {
+	fake.detectMutex.Lock()
+	defer fake.detectMutex.Unlock()
+	fake.DetectStub = nil
+	if fake.detectReturnsOnCall == nil {
+		fake.detectReturnsOnCall = make(map[int]struct {
+			result1 []recurring.Series
+		})
+	}
+	fake.detectReturnsOnCall[i] = struct {
+		result1 []recurring.Series
+	}{result1}
+}
+
+func (fake *FakeDetector) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.detectMutex.RLock()
+	defer fake.detectMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDetector) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ recurring.Detector = new(FakeDetector)
@@ -0,0 +1,112 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/pkg/scheduler"
+)
+
+func at(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04:05", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestScheduleNext(t *testing.T) {
+	for _, tc := range []struct {
+		spec string
+		from string
+		want string
+	}{
+		{"* * * * *", "2020-01-01 00:00:30", "2020-01-01 00:01:00"},
+		{"30 2 * * *", "2020-01-01 02:30:00", "2020-01-02 02:30:00"},
+		{"0 0 1 1 *", "2020-06-15 12:34:56", "2021-01-01 00:00:00"},
+
+		//steps, from a wildcard, a start or a range
+		{"*/15 * * * *", "2020-01-01 00:00:00", "2020-01-01 00:15:00"},
+		{"*/15 * * * *", "2020-01-01 00:50:00", "2020-01-01 01:00:00"},
+		{"5/15 * * * *", "2020-01-01 00:00:00", "2020-01-01 00:05:00"},
+		{"5/15 * * * *", "2020-01-01 00:06:00", "2020-01-01 00:20:00"},
+		{"0 9-17/4 * * *", "2020-01-01 00:00:00", "2020-01-01 09:00:00"},
+		{"0 9-17/4 * * *", "2020-01-01 09:00:00", "2020-01-01 13:00:00"},
+		{"0 9-17/4 * * *", "2020-01-01 17:00:00", "2020-01-02 09:00:00"},
+		{"0,30 * * * *", "2020-01-01 00:10:00", "2020-01-01 00:30:00"},
+
+		//2020-01-01 is a Wednesday, and 0 and 7 are both Sunday
+		{"0 0 * * 0", "2020-01-01 00:00:00", "2020-01-05 00:00:00"},
+		{"0 0 * * 7", "2020-01-01 00:00:00", "2020-01-05 00:00:00"},
+		{"0 0 * * 1-5", "2020-01-03 00:00:00", "2020-01-06 00:00:00"},
+		{"@weekly", "2020-01-01 00:00:00", "2020-01-05 00:00:00"},
+
+		//with both day fields restricted, either one matching is enough
+		{"0 0 13 * 5", "2020-01-01 00:00:00", "2020-01-03 00:00:00"},
+		{"0 0 13 * 5", "2020-01-10 00:00:00", "2020-01-13 00:00:00"},
+		{"0 0 13 * 5", "2020-01-13 00:00:00", "2020-01-17 00:00:00"},
+		//but a wildcard in either one defers to the other
+		{"0 0 13 * *", "2020-01-01 00:00:00", "2020-01-13 00:00:00"},
+		{"0 0 * * 5", "2020-01-03 00:00:00", "2020-01-10 00:00:00"},
+		//even a stepped one, like Vixie cron: the 1st, 11th, 21st or 31st
+		//that's also a Friday
+		{"0 0 */10 * 5", "2020-01-01 00:00:00", "2020-01-31 00:00:00"},
+
+		{"0 0 31 * *", "2020-02-01 00:00:00", "2020-03-31 00:00:00"},
+		{"0 0 29 2 *", "2020-01-01 00:00:00", "2020-02-29 00:00:00"},
+		{"0 0 29 2 *", "2020-03-01 00:00:00", "2024-02-29 00:00:00"},
+	} {
+		t.Run(tc.spec+" from "+tc.from, func(t *testing.T) {
+			s, err := scheduler.ParseSchedule(tc.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if next := s.Next(at(tc.from)); !next.Equal(at(tc.want)) {
+				t.Errorf("next = %s, want %s", next, tc.want)
+			}
+		})
+	}
+}
+
+func TestScheduleNextLocation(t *testing.T) {
+	s, err := scheduler.ParseSchedule("@daily")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zone := time.FixedZone("UTC-5", -5*60*60)
+	next := s.Next(time.Date(2020, 1, 1, 12, 0, 0, 0, zone))
+	if want := time.Date(2020, 1, 2, 0, 0, 0, 0, zone); !next.Equal(want) {
+		t.Errorf("next = %s, want %s", next, want)
+	}
+}
+
+func TestParseScheduleErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"@fortnightly",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * 32 * *",
+		"* * * 0 *",
+		"* * * 13 *",
+		"* * * * 8",
+		"a * * * *",
+		"1-a * * * *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"*/x * * * *",
+		"-1 * * * *",
+
+		//never match
+		"0 0 30 2 *",
+		"0 0 31 4,6,9,11 *",
+	} {
+		if _, err := scheduler.ParseSchedule(spec); err == nil {
+			t.Errorf("`%s` parsed", spec)
+		}
+	}
+}
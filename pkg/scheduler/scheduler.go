@@ -0,0 +1,198 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/lib/tools"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+const (
+	//lockKey identifies the scheduler's advisory lock. Only the replica
+	//holding it runs jobs.
+	lockKey int64 = 0x706c616964756901
+
+	//electionInterval is how often a follower tries to become leader,
+	//and how often the leader checks that it still holds the lock
+	electionInterval = 30 * time.Second
+)
+
+//JobFunc does the work of a job
+type JobFunc func(ctx context.Context) error
+
+//JobInfo describes a registered job
+type JobInfo struct {
+	Name      string    `json:"name"`
+	Schedule  string    `json:"schedule"`
+	NextRunAt time.Time `json:"next_run_at"`
+}
+
+//Scheduler runs jobs on cron schedules, on one replica at a time
+//go:generate counterfeiter . Scheduler
+type Scheduler interface {
+	Add(name string, spec string, run JobFunc) error
+	Jobs() []JobInfo
+	Run(ctx context.Context)
+}
+
+type job struct {
+	name     string
+	spec     string
+	schedule Schedule
+	run      JobFunc
+}
+
+//SchedulerAgent implements Scheduler, electing a leader with a
+//Postgres advisory lock and recording each run in the job_runs table
+type SchedulerAgent struct {
+	logger   tools.Logger
+	dbClient db.DB
+
+	mu   sync.Mutex
+	jobs []job
+}
+
+//NewScheduler creates a new SchedulerAgent
+func NewScheduler(logger tools.Logger, dbClient db.DB) *SchedulerAgent {
+	return &SchedulerAgent{
+		logger:   logger,
+		dbClient: dbClient,
+	}
+}
+
+//Add registers a job to run whenever spec matches
+func (s *SchedulerAgent) Add(name string, spec string, run JobFunc) error {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = append(s.jobs, job{
+		name:     name,
+		spec:     spec,
+		schedule: schedule,
+		run:      run,
+	})
+	return nil
+}
+
+//Jobs lists the registered jobs and when each will next run
+func (s *SchedulerAgent) Jobs() []JobInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	infos := []JobInfo{}
+	for _, j := range s.jobs {
+		infos = append(infos, JobInfo{
+			Name:      j.name,
+			Schedule:  j.spec,
+			NextRunAt: j.schedule.Next(now),
+		})
+	}
+	sort.Slice(infos, func(i, k int) bool { return infos[i].Name < infos[k].Name })
+	return infos
+}
+
+//Run campaigns for leadership until the context is cancelled, running
+//jobs for as long as this replica is the leader
+func (s *SchedulerAgent) Run(ctx context.Context) {
+	for {
+		lock, err := s.dbClient.TryAdvisoryLock(ctx, lockKey)
+		switch err {
+		case nil:
+			s.logger.Infof("scheduler acquired leadership")
+			s.lead(ctx, lock)
+			if err := lock.Release(); err != nil {
+				s.logger.Errorf("failed releasing scheduler lock: %s", err.Error())
+			}
+			s.logger.Infof("scheduler gave up leadership")
+		case db.ErrLockHeld:
+		default:
+			s.logger.Errorf("failed campaigning for scheduler leadership: %s", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(electionInterval):
+		}
+	}
+}
+
+//lead runs due jobs one at a time until the context is cancelled or the
+//lock is lost. Runs missed while no replica was leader are skipped.
+func (s *SchedulerAgent) lead(ctx context.Context, lock db.Lock) {
+	s.mu.Lock()
+	jobs := append([]job{}, s.jobs...)
+	s.mu.Unlock()
+
+	now := time.Now()
+	next := make([]time.Time, len(jobs))
+	for i, j := range jobs {
+		next[i] = j.schedule.Next(now)
+	}
+
+	for {
+		wake := time.Now().Add(electionInterval)
+		for _, t := range next {
+			if t.Before(wake) {
+				wake = t
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(wake)):
+		}
+
+		if err := lock.Check(ctx); err != nil {
+			s.logger.Errorf("scheduler lost leadership: %s", err.Error())
+			return
+		}
+
+		for i, j := range jobs {
+			if time.Now().Before(next[i]) {
+				continue
+			}
+			s.runJob(ctx, j)
+			next[i] = j.schedule.Next(time.Now())
+		}
+	}
+}
+
+//runJob runs a job and records the outcome. A job that panics is
+//recorded as failed rather than taking down the process.
+func (s *SchedulerAgent) runJob(ctx context.Context, j job) {
+	uuid, err := s.dbClient.StartJobRun(ctx, db.JobRun{Name: j.name})
+	if err != nil {
+		s.logger.Errorf("failed recording start of job `%s`: %s", j.name, err.Error())
+		return
+	}
+
+	err = func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("job panicked: %v", r)
+			}
+		}()
+		return j.run(ctx)
+	}()
+
+	status, errMessage := db.JobRunStatusSucceeded, ""
+	if err != nil {
+		s.logger.Errorf("job `%s` failed: %s", j.name, err.Error())
+		status, errMessage = db.JobRunStatusFailed, err.Error()
+	}
+
+	if err := s.dbClient.FinishJobRun(ctx, uuid, status, errMessage); err != nil {
+		s.logger.Errorf("failed recording outcome of job `%s`: %s", j.name, err.Error())
+	}
+}
@@ -0,0 +1,220 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package schedulerfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/xanderflood/plaid-ui/pkg/scheduler"
+)
+
+type FakeScheduler struct {
+	AddStub        func(string, string, scheduler.JobFunc) error
+	addMutex       sync.RWMutex
+	addArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 scheduler.JobFunc
+	}
+	addReturns struct {
+		result1 error
+	}
+	addReturnsOnCall map[int]struct {
+		result1 error
+	}
+	JobsStub        func() []scheduler.JobInfo
+	jobsMutex       sync.RWMutex
+	jobsArgsForCall []struct {
+	}
+	jobsReturns struct {
+		result1 []scheduler.JobInfo
+	}
+	jobsReturnsOnCall map[int]struct {
+		result1 []scheduler.JobInfo
+	}
+	RunStub        func(context.Context)
+	runMutex       sync.RWMutex
+	runArgsForCall []struct {
+		arg1 context.Context
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeScheduler) Add(arg1 string, arg2 string, arg3 scheduler.JobFunc) error {
+	fake.addMutex.Lock()
+	ret, specificReturn := fake.addReturnsOnCall[len(fake.addArgsForCall)]
+	fake.addArgsForCall = append(fake.addArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 scheduler.JobFunc
+	}{arg1, arg2, arg3})
+	stub := fake.AddStub
+	fakeReturns := fake.addReturns
+	fake.recordInvocation("Add", []interface{}{arg1, arg2, arg3})
+	fake.addMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeScheduler) AddCallCount() int {
+	fake.addMutex.RLock()
+	defer fake.addMutex.RUnlock()
+	return len(fake.addArgsForCall)
+}
+
+func (fake *FakeScheduler) AddCalls(stub func(string, string, scheduler.JobFunc) error) {
+	fake.addMutex.Lock()
+	defer fake.addMutex.Unlock()
+	fake.AddStub = stub
+}
+
+func (fake *FakeScheduler) AddArgsForCall(i int) (string, string, scheduler.JobFunc) {
+	fake.addMutex.RLock()
+	defer fake.addMutex.RUnlock()
+	argsForCall := fake.addArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeScheduler) AddReturns(result1 error) {
+	fake.addMutex.Lock()
+	defer fake.addMutex.Unlock()
+	fake.AddStub = nil
+	fake.addReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScheduler) AddReturnsOnCall(i int, result1 error) {
+	fake.addMutex.Lock()
+	defer fake.addMutex.Unlock()
+	fake.AddStub = nil
+	if fake.addReturnsOnCall == nil {
+		fake.addReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.addReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeScheduler) Jobs() []scheduler.JobInfo {
+	fake.jobsMutex.Lock()
+	ret, specificReturn := fake.jobsReturnsOnCall[len(fake.jobsArgsForCall)]
+	fake.jobsArgsForCall = append(fake.jobsArgsForCall, struct {
+	}{})
+	stub := fake.JobsStub
+	fakeReturns := fake.jobsReturns
+	fake.recordInvocation("Jobs", []interface{}{})
+	fake.jobsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeScheduler) JobsCallCount() int {
+	fake.jobsMutex.RLock()
+	defer fake.jobsMutex.RUnlock()
+	return len(fake.jobsArgsForCall)
+}
+
+func (fake *FakeScheduler) JobsCalls(stub func() []scheduler.JobInfo) {
+	fake.jobsMutex.Lock()
+	defer fake.jobsMutex.Unlock()
+	fake.JobsStub = stub
+}
+
+func (fake *FakeScheduler) JobsReturns(result1 []scheduler.JobInfo) {
+	fake.jobsMutex.Lock()
+	defer fake.jobsMutex.Unlock()
+	fake.JobsStub = nil
+	fake.jobsReturns = struct {
+		result1 []scheduler.JobInfo
+	}{result1}
+}
+
+func (fake *FakeScheduler) JobsReturnsOnCall(i int, result1 []scheduler.JobInfo) {
+	fake.jobsMutex.Lock()
+	defer fake.jobsMutex.Unlock()
+	fake.JobsStub = nil
+	if fake.jobsReturnsOnCall == nil {
+		fake.jobsReturnsOnCall = make(map[int]struct {
+			result1 []scheduler.JobInfo
+		})
+	}
+	fake.jobsReturnsOnCall[i] = struct {
+		result1 []scheduler.JobInfo
+	}{result1}
+}
+
+func (fake *FakeScheduler) Run(arg1 context.Context) {
+	fake.runMutex.Lock()
+	fake.runArgsForCall = append(fake.runArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.RunStub
+	fake.recordInvocation("Run", []interface{}{arg1})
+	fake.runMutex.Unlock()
+	if stub != nil {
+		fake.RunStub(arg1)
+	}
+}
+
+func (fake *FakeScheduler) RunCallCount() int {
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	return len(fake.runArgsForCall)
+}
+
+func (fake *FakeScheduler) RunCalls(stub func(context.Context)) {
+	fake.runMutex.Lock()
+	defer fake.runMutex.Unlock()
+	fake.RunStub = stub
+}
+
+func (fake *FakeScheduler) RunArgsForCall(i int) context.Context {
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	argsForCall := fake.runArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeScheduler) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.addMutex.RLock()
+	defer fake.addMutex.RUnlock()
+	fake.jobsMutex.RLock()
+	defer fake.jobsMutex.RUnlock()
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeScheduler) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ scheduler.Scheduler = new(FakeScheduler)
@@ -0,0 +1,194 @@
+package statement
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+	"github.com/xanderflood/plaid-ui/pkg/textfmt"
+)
+
+//ofxHeader declares the file as UTF-8, which OFX 1.x calls UNICODE, since
+//names are written as they were stored
+const ofxHeader = `OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+SECURITY:NONE
+ENCODING:UNICODE
+CHARSET:NONE
+COMPRESSION:NONE
+OLDFILEUID:NONE
+NEWFILEUID:NONE
+
+`
+
+//OFX 1.x limits NAME to 32 characters
+const ofxNameLength = 32
+
+//writeOFX produces an OFX 1.0.2 (SGML) statement. When intuitBankID is
+//set the file also carries the INTU.BID tag that Quicken requires of
+//QFX files.
+func writeOFX(w io.Writer, stmt Statement, intuitBankID string) error {
+	bw := bufio.NewWriter(w)
+	o := ofxWriter{w: bw}
+
+	o.raw(ofxHeader)
+	o.open("OFX")
+
+	o.open("SIGNONMSGSRSV1")
+	o.open("SONRS")
+	o.status()
+	o.field("DTSERVER", stmt.GeneratedAt.UTC().Format("20060102150405"))
+	o.field("LANGUAGE", "ENG")
+	if intuitBankID != "" {
+		o.field("INTU.BID", intuitBankID)
+	}
+	o.close("SONRS")
+	o.close("SIGNONMSGSRSV1")
+
+	msgs, trnrs, rs, from := "BANKMSGSRSV1", "STMTTRNRS", "STMTRS", "BANKACCTFROM"
+	if isCreditCard(stmt.Account) {
+		msgs, trnrs, rs, from = "CREDITCARDMSGSRSV1", "CCSTMTTRNRS", "CCSTMTRS", "CCACCTFROM"
+	}
+
+	o.open(msgs)
+	o.open(trnrs)
+	o.field("TRNUID", "0")
+	o.status()
+	o.open(rs)
+	o.field("CURDEF", currency(stmt))
+
+	o.open(from)
+	if !isCreditCard(stmt.Account) {
+		//Plaid doesn't give us a routing number without the auth product
+		o.field("BANKID", "000000000")
+	}
+	o.field("ACCTID", stmt.Account.PlaidAccountID)
+	if !isCreditCard(stmt.Account) {
+		o.field("ACCTTYPE", ofxAccountType(stmt.Account.PlaidAccountSubtype))
+	}
+	o.close(from)
+
+	o.open("BANKTRANLIST")
+	o.field("DTSTART", ofxDate(stmt.StartDate))
+	o.field("DTEND", ofxDate(stmt.EndDate))
+	for _, t := range stmt.Transactions {
+		amount := accountAmount(t)
+		trnType := "CREDIT"
+		if amount.Sign() < 0 {
+			trnType = "DEBIT"
+		}
+
+		o.open("STMTTRN")
+		o.field("TRNTYPE", trnType)
+		o.field("DTPOSTED", ofxDate(t.Date))
+		o.field("TRNAMT", textfmt.Amount(amount))
+		o.field("FITID", fitID(t))
+		name := strings.TrimSpace(truncate(t.PlaidName, ofxNameLength))
+		o.field("NAME", name)
+		if name != t.PlaidName {
+			o.field("MEMO", t.PlaidName)
+		}
+		o.close("STMTTRN")
+	}
+	o.close("BANKTRANLIST")
+
+	if stmt.Balance != nil && stmt.Balance.Current != nil {
+		//Plaid reports credit balances as a positive amount owed
+		balance := stmt.Balance.Current
+		if isCreditCard(stmt.Account) {
+			balance = new(big.Float).Neg(balance)
+		}
+
+		o.open("LEDGERBAL")
+		o.field("BALAMT", textfmt.Amount(balance))
+		o.field("DTASOF", ofxDate(stmt.Balance.Date))
+		o.close("LEDGERBAL")
+	}
+
+	o.close(rs)
+	o.close(trnrs)
+	o.close(msgs)
+	o.close("OFX")
+
+	if o.err != nil {
+		return o.err
+	}
+	return bw.Flush()
+}
+
+//ofxWriter keeps the first write error so the statement can be
+//written without checking every line
+type ofxWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (o *ofxWriter) raw(s string) {
+	if o.err == nil {
+		_, o.err = io.WriteString(o.w, s)
+	}
+}
+
+func (o *ofxWriter) open(tag string) {
+	o.raw("<" + tag + ">\n")
+}
+
+func (o *ofxWriter) close(tag string) {
+	o.raw("</" + tag + ">\n")
+}
+
+func (o *ofxWriter) field(tag, value string) {
+	o.raw(fmt.Sprintf("<%s>%s\n", tag, ofxEscape(value)))
+}
+
+func (o *ofxWriter) status() {
+	o.open("STATUS")
+	o.field("CODE", "0")
+	o.field("SEVERITY", "INFO")
+	o.close("STATUS")
+}
+
+func ofxAccountType(subtype plaidapi.AccountSubtype) string {
+	switch subtype {
+	case plaidapi.AccountSubtypeSavings, plaidapi.AccountSubtypeCD:
+		return "SAVINGS"
+	case plaidapi.AccountSubtypeMoneyMarket:
+		return "MONEYMRKT"
+	default:
+		return "CHECKING"
+	}
+}
+
+func ofxDate(date string) string {
+	return strings.Replace(date, "-", "", -1)
+}
+
+//ofxEscape escapes markup, and replaces line breaks since every field
+//has to fit on one line
+func ofxEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r\n", " ", "\r", " ", "\n", " ").Replace(s)
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
+
+func currency(stmt Statement) string {
+	if stmt.Balance != nil && stmt.Balance.ISOCurrencyCode != "" {
+		return stmt.Balance.ISOCurrencyCode
+	}
+	for _, t := range stmt.Transactions {
+		if t.ISOCurrencyCode != "" {
+			return t.ISOCurrencyCode
+		}
+	}
+	return "USD"
+}
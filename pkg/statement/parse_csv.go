@@ -0,0 +1,160 @@
+package statement
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//CSVProfile describes how to read a particular bank's CSV export. Columns
+//are identified by their names in the header row.
+type CSVProfile struct {
+	DateColumn        string `json:"date_column"`
+	DescriptionColumn string `json:"description_column"`
+
+	//Either AmountColumn, or both DebitColumn and CreditColumn
+	AmountColumn string `json:"amount_column"`
+	DebitColumn  string `json:"debit_column"`
+	CreditColumn string `json:"credit_column"`
+
+	//DateLayout is a Go time layout, defaulting to plaidapi.DateFormat
+	DateLayout string `json:"date_layout"`
+
+	//OutflowPositive indicates that AmountColumn is positive when money
+	//leaves the account, as Plaid does. Most banks do the opposite.
+	OutflowPositive bool `json:"outflow_positive"`
+
+	ISOCurrencyCode string `json:"iso_currency_code"`
+}
+
+//ErrInvalidProfile indicates that a CSVProfile can't be applied to a file
+var ErrInvalidProfile = errors.New("invalid CSV profile")
+
+//ParseCSV reads records from a CSV file using the given profile
+func ParseCSV(r io.Reader, profile CSVProfile) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CSV header")
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	column := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		i, ok := columns[name]
+		if !ok {
+			return 0, errors.Wrapf(ErrInvalidProfile, "no column named `%s`", name)
+		}
+		return i, nil
+	}
+
+	dateCol, err := column(profile.DateColumn)
+	if err != nil {
+		return nil, err
+	}
+	descCol, err := column(profile.DescriptionColumn)
+	if err != nil {
+		return nil, err
+	}
+	amountCol, err := column(profile.AmountColumn)
+	if err != nil {
+		return nil, err
+	}
+	debitCol, err := column(profile.DebitColumn)
+	if err != nil {
+		return nil, err
+	}
+	creditCol, err := column(profile.CreditColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	if dateCol < 0 || descCol < 0 {
+		return nil, errors.Wrap(ErrInvalidProfile, "date and description columns are required")
+	}
+	if amountCol < 0 && (debitCol < 0 || creditCol < 0) {
+		return nil, errors.Wrap(ErrInvalidProfile, "either an amount column or both debit and credit columns are required")
+	}
+
+	layout := profile.DateLayout
+	if layout == "" {
+		layout = plaidapi.DateFormat
+	}
+
+	var records []Record
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read CSV line %d", line)
+		}
+
+		get := func(i int) string {
+			if i < 0 || i >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[i])
+		}
+
+		//skip blank lines and trailing summaries
+		if get(dateCol) == "" {
+			continue
+		}
+
+		date, err := time.Parse(layout, get(dateCol))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date `%s`", line, get(dateCol))
+		}
+
+		var record = Record{
+			Date:            date.Format(plaidapi.DateFormat),
+			Description:     get(descCol),
+			ISOCurrencyCode: profile.ISOCurrencyCode,
+		}
+
+		if amountCol >= 0 {
+			record.Amount, err = parseAmount(get(amountCol))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", line, err.Error())
+			}
+			if !profile.OutflowPositive {
+				record.Amount.Neg(record.Amount)
+			}
+		} else {
+			debit, err := parseAmount(get(debitCol))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", line, err.Error())
+			}
+			credit, err := parseAmount(get(creditCol))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", line, err.Error())
+			}
+
+			//some banks sign their debit column, some don't
+			debit.Abs(debit)
+			credit.Abs(credit)
+			record.Amount = debit.Sub(debit, credit)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
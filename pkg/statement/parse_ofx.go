@@ -0,0 +1,102 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//ofxElement matches one tag and any text that follows it. This works for
+//both SGML (OFX 1.x), where leaf elements are never closed, and XML
+//(OFX 2.x), where the closing tags simply have no text.
+var ofxElement = regexp.MustCompile(`<([^>]+)>([^<]*)`)
+
+//ParseOFX reads the transactions from an OFX or QFX statement
+func ParseOFX(r io.Reader) ([]Record, error) {
+	body, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read OFX file")
+	}
+
+	var (
+		records  []Record
+		current  *Record
+		currency string
+	)
+	for _, match := range ofxElement.FindAllStringSubmatch(string(body), -1) {
+		tag := strings.ToUpper(strings.TrimSpace(match[1]))
+		value := ofxUnescape(strings.TrimSpace(match[2]))
+
+		switch tag {
+		case "CURDEF":
+			currency = value
+		case "STMTTRN":
+			current = &Record{ISOCurrencyCode: currency}
+		case "/STMTTRN":
+			if current == nil {
+				continue
+			}
+			if current.Date == "" || current.Amount == nil {
+				return nil, fmt.Errorf("OFX transaction `%s` is missing a date or amount", current.FITID)
+			}
+			records = append(records, *current)
+			current = nil
+		}
+
+		if current == nil || value == "" {
+			continue
+		}
+
+		switch tag {
+		case "DTPOSTED":
+			date, err := parseOFXDate(value)
+			if err != nil {
+				return nil, err
+			}
+			current.Date = date
+		case "TRNAMT":
+			amount, err := parseAmount(value)
+			if err != nil {
+				return nil, err
+			}
+			//OFX amounts are positive when money enters the account
+			current.Amount = amount.Neg(amount)
+		case "FITID":
+			current.FITID = value
+		case "NAME":
+			current.Description = value
+		case "MEMO":
+			//NAME is limited to 32 characters, so writers often
+			//put the full description in MEMO
+			if strings.HasPrefix(value, current.Description) {
+				current.Description = value
+			}
+		}
+	}
+
+	return records, nil
+}
+
+//parseOFXDate reads the date portion of an OFX datetime such as
+//`20200105120000.000[-5:EST]`
+func parseOFXDate(value string) (string, error) {
+	if len(value) < 8 {
+		return "", fmt.Errorf("invalid OFX date `%s`", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return "", fmt.Errorf("invalid OFX date `%s`", value)
+	}
+	return date.Format(plaidapi.DateFormat), nil
+}
+
+func ofxUnescape(s string) string {
+	return strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&").Replace(s)
+}
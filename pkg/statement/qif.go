@@ -0,0 +1,37 @@
+package statement
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/xanderflood/plaid-ui/pkg/textfmt"
+)
+
+//writeQIF produces a QIF statement. QIF has no notion of a transaction
+//identifier, so the Plaid ID is carried in the memo field instead.
+func writeQIF(w io.Writer, stmt Statement) error {
+	bw := bufio.NewWriter(w)
+
+	header := "!Type:Bank"
+	if isCreditCard(stmt.Account) {
+		header = "!Type:CCard"
+	}
+	if _, err := fmt.Fprintln(bw, header); err != nil {
+		return err
+	}
+
+	for _, t := range stmt.Transactions {
+		_, err := fmt.Fprintf(bw, "D%s\nT%s\nP%s\nM%s\n^\n",
+			parseDate(t.Date).Format("01/02/2006"),
+			textfmt.Amount(accountAmount(t)),
+			textfmt.SingleLine(t.PlaidName),
+			fitID(t),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
@@ -0,0 +1,61 @@
+package statement
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/xanderflood/plaid-ui/pkg/textfmt"
+)
+
+//Record is a single transaction read from an imported statement file.
+//Amount follows the Plaid convention of being positive when money
+//leaves the account.
+type Record struct {
+	Date            string
+	Amount          *big.Float
+	Description     string
+	ISOCurrencyCode string
+
+	//FITID is the institution's transaction identifier, when the
+	//file format carries one
+	FITID string
+}
+
+//DedupKey identifies records that should be considered the same
+//transaction when they share a date, amount and description
+func DedupKey(date string, amount *big.Float, description string) string {
+	if amount == nil {
+		amount = new(big.Float)
+	}
+	return fmt.Sprintf("%s|%s|%s",
+		date,
+		textfmt.Amount(amount),
+		strings.ToLower(textfmt.SingleLine(description)),
+	)
+}
+
+//parseAmount reads a monetary amount as written in a statement file,
+//allowing for currency symbols, thousands separators and accounting
+//style parentheses for negative values
+func parseAmount(s string) (*big.Float, error) {
+	s = strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		negative = true
+		s = strings.TrimSuffix(strings.TrimPrefix(s, "("), ")")
+	}
+	s = strings.NewReplacer("$", "", ",", "", " ", "").Replace(s)
+	if s == "" {
+		return new(big.Float), nil
+	}
+
+	f, ok := new(big.Float).SetString(s)
+	if !ok {
+		return nil, fmt.Errorf("invalid amount `%s`", s)
+	}
+	if negative {
+		f.Neg(f)
+	}
+	return f, nil
+}
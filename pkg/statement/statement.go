@@ -0,0 +1,115 @@
+package statement
+
+import (
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//Format identifies a statement file format
+type Format string
+
+const (
+	FormatOFX Format = "ofx"
+	FormatQFX Format = "qfx"
+	FormatQIF Format = "qif"
+
+	//FormatCSV can only be imported, using a CSVProfile
+	FormatCSV Format = "csv"
+)
+
+//ErrUnknownFormat indicates that an unsupported Format was requested
+var ErrUnknownFormat = errors.New("unknown statement format")
+
+//Statement is a single account's activity over a date range
+type Statement struct {
+	Account      db.Account
+	StartDate    string
+	EndDate      string
+	Transactions []db.Transaction
+
+	//Balance is the most recent snapshot as of EndDate, if any
+	Balance *db.Balance
+
+	GeneratedAt time.Time
+}
+
+//Exporter renders statement files
+//go:generate counterfeiter . Exporter
+type Exporter interface {
+	Export(w io.Writer, format Format, stmt Statement) error
+	ContentType(format Format) string
+}
+
+//ExporterAgent implements Exporter
+type ExporterAgent struct {
+	intuitBankID string
+}
+
+//NewExporter creates a new ExporterAgent
+func NewExporter(intuitBankID string) ExporterAgent {
+	return ExporterAgent{intuitBankID: intuitBankID}
+}
+
+//Export writes the statement to w in the requested format
+func (e ExporterAgent) Export(w io.Writer, format Format, stmt Statement) error {
+	switch format {
+	case FormatOFX:
+		return writeOFX(w, stmt, "")
+	case FormatQFX:
+		return writeOFX(w, stmt, e.intuitBankID)
+	case FormatQIF:
+		return writeQIF(w, stmt)
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+//ContentType gives the MIME type for a format
+func (e ExporterAgent) ContentType(format Format) string {
+	switch format {
+	case FormatOFX:
+		return "application/x-ofx"
+	case FormatQFX:
+		return "application/vnd.intu.qfx"
+	case FormatQIF:
+		return "application/qif"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func isCreditCard(acct db.Account) bool {
+	return acct.PlaidAccountType == plaidapi.AccountTypeCredit
+}
+
+//accountAmount converts a Plaid amount, which is positive when money
+//leaves the account, into the usual statement convention where
+//debits are negative
+func accountAmount(t db.Transaction) *big.Float {
+	if t.Amount == nil {
+		return new(big.Float)
+	}
+	return new(big.Float).Neg(t.Amount)
+}
+
+//fitID gives a stable identifier for a transaction that survives re-export
+func fitID(t db.Transaction) string {
+	if t.PlaidID != "" {
+		return t.PlaidID
+	}
+	if t.FITID != "" {
+		return t.FITID
+	}
+	return t.UUID
+}
+
+func parseDate(date string) time.Time {
+	t, _ := time.Parse(plaidapi.DateFormat, date)
+	return t
+}
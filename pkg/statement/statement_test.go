@@ -0,0 +1,170 @@
+package statement_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+	"github.com/xanderflood/plaid-ui/pkg/statement"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+//assertGolden compares got with testdata/<name>.golden, or rewrites the
+//file when the tests are run with -update
+func assertGolden(t *testing.T, name string, got []byte) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := ioutil.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("failed to update %s: %s", path, err)
+		}
+		return
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %s", path, err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output doesn't match %s; run `go test ./pkg/statement -update` if the change is intended\n--- got:\n%s\n--- want:\n%s", path, got, want)
+	}
+}
+
+func amount(s string) *big.Float {
+	f, _ := new(big.Float).SetString(s)
+	return f
+}
+
+func checkingStatement() statement.Statement {
+	return statement.Statement{
+		Account: db.Account{
+			PlaidAccountID:      "acct-checking",
+			PlaidAccountType:    plaidapi.AccountTypeDepository,
+			PlaidAccountSubtype: plaidapi.AccountSubtypeChecking,
+		},
+		StartDate: "2020-01-01",
+		EndDate:   "2020-01-31",
+		Transactions: []db.Transaction{
+			{Model: db.Model{UUID: "txn-1"}, PlaidID: "plaid-1", Date: "2020-01-03", Amount: amount("12.5"), ISOCurrencyCode: "USD", PlaidName: "Coffee & <Bagels>"},
+			{Model: db.Model{UUID: "txn-2"}, PlaidID: "plaid-2", Date: "2020-01-15", Amount: amount("-2000"), ISOCurrencyCode: "USD", PlaidName: "Payroll\r\nDirect Deposit"},
+			{Model: db.Model{UUID: "txn-3"}, Date: "2020-01-20", Amount: amount("42.1"), ISOCurrencyCode: "USD", PlaidName: "Café des Artistes on the Corner of Main Street"},
+			{Model: db.Model{UUID: "txn-4"}, PlaidID: "plaid-4", Date: "2020-01-31", ISOCurrencyCode: "USD", PlaidName: "Adjustment"},
+		},
+		Balance: &db.Balance{
+			Date:            "2020-01-31",
+			ISOCurrencyCode: "USD",
+			Current:         amount("1945.4"),
+		},
+		GeneratedAt: time.Date(2020, 2, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func creditCardStatement() statement.Statement {
+	return statement.Statement{
+		Account: db.Account{
+			PlaidAccountID:      "acct-credit",
+			PlaidAccountType:    plaidapi.AccountTypeCredit,
+			PlaidAccountSubtype: plaidapi.AccountSubtypeCreditCard,
+		},
+		StartDate: "2020-01-01",
+		EndDate:   "2020-01-31",
+		Transactions: []db.Transaction{
+			{Model: db.Model{UUID: "txn-5"}, PlaidID: "plaid-5", Date: "2020-01-10", Amount: amount("99.99"), ISOCurrencyCode: "EUR", PlaidName: "Bookshop"},
+			{Model: db.Model{UUID: "txn-6"}, PlaidID: "plaid-6", Date: "2020-01-25", Amount: amount("-50"), ISOCurrencyCode: "EUR", PlaidName: "Payment, thank you"},
+		},
+		Balance: &db.Balance{
+			Date:            "2020-01-31",
+			ISOCurrencyCode: "EUR",
+			Current:         amount("49.99"),
+		},
+		GeneratedAt: time.Date(2020, 2, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestExport(t *testing.T) {
+	exporter := statement.NewExporter("12345")
+
+	for _, tc := range []struct {
+		name   string
+		format statement.Format
+		stmt   statement.Statement
+	}{
+		{"checking.ofx", statement.FormatOFX, checkingStatement()},
+		{"checking.qfx", statement.FormatQFX, checkingStatement()},
+		{"checking.qif", statement.FormatQIF, checkingStatement()},
+		{"credit.ofx", statement.FormatOFX, creditCardStatement()},
+		{"credit.qif", statement.FormatQIF, creditCardStatement()},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := exporter.Export(&buf, tc.format, tc.stmt); err != nil {
+				t.Fatalf("export failed: %s", err)
+			}
+			assertGolden(t, tc.name, buf.Bytes())
+		})
+	}
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	err := statement.NewExporter("").Export(&buf, statement.FormatCSV, checkingStatement())
+	if err != statement.ErrUnknownFormat {
+		t.Fatalf("expected ErrUnknownFormat, got %v", err)
+	}
+}
+
+//TestParseOFX reads back a statement written by the exporter
+func TestParseOFX(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "checking.ofx.golden"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := statement.ParseOFX(f)
+	if err != nil {
+		t.Fatalf("parse failed: %s", err)
+	}
+	assertGolden(t, "checking.ofx.records.json", recordsJSON(t, records))
+}
+
+func TestParseCSV(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "bank.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	records, err := statement.ParseCSV(f, statement.CSVProfile{
+		DateColumn:        "Posted",
+		DescriptionColumn: "Description",
+		DebitColumn:       "Debit",
+		CreditColumn:      "Credit",
+		DateLayout:        "01/02/2006",
+		ISOCurrencyCode:   "USD",
+	})
+	if err != nil {
+		t.Fatalf("parse failed: %s", err)
+	}
+	assertGolden(t, "bank.csv.records.json", recordsJSON(t, records))
+}
+
+func recordsJSON(t *testing.T, records []statement.Record) []byte {
+	t.Helper()
+
+	doc, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(doc, '\n')
+}
@@ -0,0 +1,190 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package statementfakes
+
+import (
+	"io"
+	"sync"
+
+	"github.com/xanderflood/plaid-ui/pkg/statement"
+)
+
+type FakeExporter struct {
+	ContentTypeStub        func(statement.Format) string
+	contentTypeMutex       sync.RWMutex
+	contentTypeArgsForCall []struct {
+		arg1 statement.Format
+	}
+	contentTypeReturns struct {
+		result1 string
+	}
+	contentTypeReturnsOnCall map[int]struct {
+		result1 string
+	}
+	ExportStub        func(io.Writer, statement.Format, statement.Statement) error
+	exportMutex       sync.RWMutex
+	exportArgsForCall []struct {
+		arg1 io.Writer
+		arg2 statement.Format
+		arg3 statement.Statement
+	}
+	exportReturns struct {
+		result1 error
+	}
+	exportReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeExporter) ContentType(arg1 statement.Format) string {
+	fake.contentTypeMutex.Lock()
+	ret, specificReturn := fake.contentTypeReturnsOnCall[len(fake.contentTypeArgsForCall)]
+	fake.contentTypeArgsForCall = append(fake.contentTypeArgsForCall, struct {
+		arg1 statement.Format
+	}{arg1})
+	stub := fake.ContentTypeStub
+	fakeReturns := fake.contentTypeReturns
+	fake.recordInvocation("ContentType", []interface{}{arg1})
+	fake.contentTypeMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExporter) ContentTypeCallCount() int {
+	fake.contentTypeMutex.RLock()
+	defer fake.contentTypeMutex.RUnlock()
+	return len(fake.contentTypeArgsForCall)
+}
+
+func (fake *FakeExporter) ContentTypeCalls(stub func(statement.Format) string) {
+	fake.contentTypeMutex.Lock()
+	defer fake.contentTypeMutex.Unlock()
+	fake.ContentTypeStub = stub
+}
+
+func (fake *FakeExporter) ContentTypeArgsForCall(i int) statement.Format {
+	fake.contentTypeMutex.RLock()
+	defer fake.contentTypeMutex.RUnlock()
+	argsForCall := fake.contentTypeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeExporter) ContentTypeReturns(result1 string) {
+	fake.contentTypeMutex.Lock()
+	defer fake.contentTypeMutex.Unlock()
+	fake.ContentTypeStub = nil
+	fake.contentTypeReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeExporter) ContentTypeReturnsOnCall(i int, result1 string) {
+	fake.contentTypeMutex.Lock()
+	defer fake.contentTypeMutex.Unlock()
+	fake.ContentTypeStub = nil
+	if fake.contentTypeReturnsOnCall == nil {
+		fake.contentTypeReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.contentTypeReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeExporter) Export(arg1 io.Writer, arg2 statement.Format, arg3 statement.Statement) error {
+	fake.exportMutex.Lock()
+	ret, specificReturn := fake.exportReturnsOnCall[len(fake.exportArgsForCall)]
+	fake.exportArgsForCall = append(fake.exportArgsForCall, struct {
+		arg1 io.Writer
+		arg2 statement.Format
+		arg3 statement.Statement
+	}{arg1, arg2, arg3})
+	stub := fake.ExportStub
+	fakeReturns := fake.exportReturns
+	fake.recordInvocation("Export", []interface{}{arg1, arg2, arg3})
+	fake.exportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeExporter) ExportCallCount() int {
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	return len(fake.exportArgsForCall)
+}
+
+func (fake *FakeExporter) ExportCalls(stub func(io.Writer, statement.Format, statement.Statement) error) {
+	fake.exportMutex.Lock()
+	defer fake.exportMutex.Unlock()
+	fake.ExportStub = stub
+}
+
+func (fake *FakeExporter) ExportArgsForCall(i int) (io.Writer, statement.Format, statement.Statement) {
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	argsForCall := fake.exportArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeExporter) ExportReturns(result1 error) {
+	fake.exportMutex.Lock()
+	defer fake.exportMutex.Unlock()
+	fake.ExportStub = nil
+	fake.exportReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExporter) ExportReturnsOnCall(i int, result1 error) {
+	fake.exportMutex.Lock()
+	defer fake.exportMutex.Unlock()
+	fake.ExportStub = nil
+	if fake.exportReturnsOnCall == nil {
+		fake.exportReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.exportReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeExporter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.contentTypeMutex.RLock()
+	defer fake.contentTypeMutex.RUnlock()
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeExporter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ statement.Exporter = new(FakeExporter)
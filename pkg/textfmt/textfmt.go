@@ -0,0 +1,23 @@
+//Package textfmt formats values for the plain-text files that plaid-ui
+//exports, such as statements and ledgers
+package textfmt
+
+import (
+	"math/big"
+	"strings"
+)
+
+//Amount formats an amount rounded to two decimal places, never as
+//`-0.00`
+func Amount(f *big.Float) string {
+	s := f.Text('f', 2)
+	if strings.Trim(s, "-0.") == "" {
+		return "0.00"
+	}
+	return s
+}
+
+//SingleLine strips characters that would break a one-line field
+func SingleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
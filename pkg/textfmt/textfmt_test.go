@@ -0,0 +1,37 @@
+package textfmt_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/xanderflood/plaid-ui/pkg/textfmt"
+)
+
+func TestAmount(t *testing.T) {
+	for _, tc := range []struct {
+		amount float64
+		want   string
+	}{
+		{0, "0.00"},
+		{12.5, "12.50"},
+		{-1200, "-1200.00"},
+		{0.1 + 0.2, "0.30"},
+		{-0.015, "-0.01"},
+		{-0.001, "0.00"},
+		{0.004, "0.00"},
+	} {
+		if got := textfmt.Amount(big.NewFloat(tc.amount)); got != tc.want {
+			t.Errorf("Amount(%v) = %s, want %s", tc.amount, got, tc.want)
+		}
+	}
+
+	if got := textfmt.Amount(new(big.Float).Neg(new(big.Float))); got != "0.00" {
+		t.Errorf("Amount(-0) = %s, want 0.00", got)
+	}
+}
+
+func TestSingleLine(t *testing.T) {
+	if got := textfmt.SingleLine("  ACME\r\nPAYROLL\t 42 "); got != "ACME PAYROLL 42" {
+		t.Errorf("SingleLine = %q", got)
+	}
+}
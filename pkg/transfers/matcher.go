@@ -0,0 +1,154 @@
+package transfers
+
+import (
+	"sort"
+	"time"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+)
+
+//transferable lists which kinds of accounts money commonly moves between.
+//Checking to credit card covers card payments, checking to loan covers
+//loan payments, and so on.
+var transferable = map[plaidapi.AccountType]map[plaidapi.AccountType]bool{
+	plaidapi.AccountTypeDepository: {
+		plaidapi.AccountTypeDepository: true,
+		plaidapi.AccountTypeCredit:     true,
+		plaidapi.AccountTypeLoan:       true,
+		plaidapi.AccountTypeInvestment: true,
+	},
+	plaidapi.AccountTypeInvestment: {
+		plaidapi.AccountTypeDepository: true,
+		plaidapi.AccountTypeInvestment: true,
+	},
+	plaidapi.AccountTypeCredit: {
+		plaidapi.AccountTypeDepository: true,
+	},
+	plaidapi.AccountTypeLoan: {
+		plaidapi.AccountTypeDepository: true,
+	},
+}
+
+//Pair is a proposed transfer between two transactions
+type Pair struct {
+	Outflow db.Transaction
+	Inflow  db.Transaction
+}
+
+//Matcher finds transfers between a user's own accounts
+//go:generate counterfeiter . Matcher
+type Matcher interface {
+	Match(accounts []db.Account, transactions []db.Transaction, existing []db.Transfer) []Pair
+}
+
+//MatcherAgent implements Matcher
+type MatcherAgent struct {
+	window int
+}
+
+//NewMatcher creates a new MatcherAgent that pairs transactions posted
+//up to `window` days apart
+func NewMatcher(window int) MatcherAgent {
+	return MatcherAgent{window: window}
+}
+
+//Match pairs each outflow with an inflow of the same amount and
+//currency in a different, compatible account, preferring the inflow
+//closest in date. Transactions that already belong to a transfer are
+//skipped, as are pairings that the user previously rejected.
+func (m MatcherAgent) Match(accounts []db.Account, transactions []db.Transaction, existing []db.Transfer) []Pair {
+	types := map[string]plaidapi.AccountType{}
+	for _, acct := range accounts {
+		types[acct.UUID] = acct.PlaidAccountType
+	}
+
+	used := map[string]bool{}
+	rejected := map[[2]string]bool{}
+	for _, transfer := range existing {
+		if transfer.Status == db.TransferStatusRejected {
+			rejected[[2]string{transfer.OutflowTransactionUUID, transfer.InflowTransactionUUID}] = true
+			continue
+		}
+		used[transfer.OutflowTransactionUUID] = true
+		used[transfer.InflowTransactionUUID] = true
+	}
+
+	var outflows, inflows []db.Transaction
+	for _, t := range transactions {
+		if used[t.UUID] || t.Amount == nil || t.PlaidPending {
+			continue
+		}
+		switch t.Amount.Sign() {
+		case 1:
+			outflows = append(outflows, t)
+		case -1:
+			inflows = append(inflows, t)
+		}
+	}
+
+	sort.SliceStable(outflows, func(i, j int) bool {
+		if outflows[i].Date != outflows[j].Date {
+			return outflows[i].Date < outflows[j].Date
+		}
+		return outflows[i].UUID < outflows[j].UUID
+	})
+
+	var pairs []Pair
+	for _, out := range outflows {
+		best := -1
+		bestDistance := m.window + 1
+		for i, in := range inflows {
+			if used[in.UUID] ||
+				in.AccountUUID == out.AccountUUID ||
+				in.ISOCurrencyCode != out.ISOCurrencyCode ||
+				rejected[[2]string{out.UUID, in.UUID}] ||
+				!transferable[types[out.AccountUUID]][types[in.AccountUUID]] ||
+				cents(in) != -cents(out) {
+				continue
+			}
+
+			distance, ok := daysApart(out.Date, in.Date)
+			if !ok || distance > m.window {
+				continue
+			}
+			if distance < bestDistance || (distance == bestDistance && in.UUID < inflows[best].UUID) {
+				best, bestDistance = i, distance
+			}
+		}
+
+		if best >= 0 {
+			used[out.UUID] = true
+			used[inflows[best].UUID] = true
+			pairs = append(pairs, Pair{Outflow: out, Inflow: inflows[best]})
+		}
+	}
+
+	return pairs
+}
+
+//cents converts an amount to a whole number of cents for exact comparison
+func cents(t db.Transaction) int64 {
+	f, _ := t.Amount.Float64()
+	if f < 0 {
+		return int64(f*100 - 0.5)
+	}
+	return int64(f*100 + 0.5)
+}
+
+func daysApart(a, b string) (int, bool) {
+	ta, err := time.Parse(plaidapi.DateFormat, a)
+	if err != nil {
+		return 0, false
+	}
+	tb, err := time.Parse(plaidapi.DateFormat, b)
+	if err != nil {
+		return 0, false
+	}
+
+	days := int(ta.Sub(tb).Hours() / 24)
+	if days < 0 {
+		days = -days
+	}
+	return days, true
+}
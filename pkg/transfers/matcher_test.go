@@ -0,0 +1,176 @@
+package transfers_test
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/plaidapi"
+	"github.com/xanderflood/plaid-ui/pkg/transfers"
+)
+
+var testAccounts = []db.Account{
+	{Model: db.Model{UUID: "checking"}, PlaidAccountType: plaidapi.AccountTypeDepository},
+	{Model: db.Model{UUID: "savings"}, PlaidAccountType: plaidapi.AccountTypeDepository},
+	{Model: db.Model{UUID: "card"}, PlaidAccountType: plaidapi.AccountTypeCredit},
+	{Model: db.Model{UUID: "other-card"}, PlaidAccountType: plaidapi.AccountTypeCredit},
+	{Model: db.Model{UUID: "mortgage"}, PlaidAccountType: plaidapi.AccountTypeLoan},
+	{Model: db.Model{UUID: "brokerage"}, PlaidAccountType: plaidapi.AccountTypeInvestment},
+}
+
+func transaction(uuid string, account string, date string, amount float64) db.Transaction {
+	return db.Transaction{
+		Model:           db.Model{UUID: uuid},
+		AccountUUID:     account,
+		ISOCurrencyCode: "USD",
+		Amount:          big.NewFloat(amount),
+		Date:            date,
+	}
+}
+
+//pairs lists the UUIDs of each pair's outflow and inflow
+func pairs(matched []transfers.Pair) [][2]string {
+	uuids := [][2]string{}
+	for _, pair := range matched {
+		uuids = append(uuids, [2]string{pair.Outflow.UUID, pair.Inflow.UUID})
+	}
+	return uuids
+}
+
+func TestMatch(t *testing.T) {
+	pending := transaction("in-pending", "savings", "2020-01-05", -40)
+	pending.PlaidPending = true
+	euros := transaction("in-euros", "savings", "2020-01-05", -60)
+	euros.ISOCurrencyCode = "EUR"
+	noAmount := transaction("in-no-amount", "savings", "2020-01-05", 0)
+	noAmount.Amount = nil
+
+	for _, tc := range []struct {
+		name         string
+		transactions []db.Transaction
+		existing     []db.Transfer
+		want         [][2]string
+	}{
+		{
+			name: "savings deposit",
+			transactions: []db.Transaction{
+				transaction("out", "checking", "2020-01-05", 100),
+				transaction("in", "savings", "2020-01-06", -100),
+			},
+			want: [][2]string{{"out", "in"}},
+		},
+		{
+			name: "card and loan payments",
+			transactions: []db.Transaction{
+				transaction("out-card", "checking", "2020-01-05", 250.5),
+				transaction("in-card", "card", "2020-01-07", -250.5),
+				transaction("out-mortgage", "checking", "2020-01-06", 1200),
+				transaction("in-mortgage", "mortgage", "2020-01-06", -1200),
+			},
+			want: [][2]string{{"out-card", "in-card"}, {"out-mortgage", "in-mortgage"}},
+		},
+		{
+			name: "closest date wins",
+			transactions: []db.Transaction{
+				transaction("out", "checking", "2020-01-05", 100),
+				transaction("in-far", "savings", "2020-01-08", -100),
+				transaction("in-near", "brokerage", "2020-01-04", -100),
+			},
+			want: [][2]string{{"out", "in-near"}},
+		},
+		{
+			name: "ties go to the lowest UUID",
+			transactions: []db.Transaction{
+				transaction("out", "checking", "2020-01-05", 100),
+				transaction("in-b", "savings", "2020-01-06", -100),
+				transaction("in-a", "brokerage", "2020-01-04", -100),
+			},
+			want: [][2]string{{"out", "in-a"}},
+		},
+		{
+			name: "each inflow pairs once, with the earliest outflow",
+			transactions: []db.Transaction{
+				transaction("out-later", "card", "2020-01-06", 100),
+				transaction("out-earlier", "savings", "2020-01-05", 100),
+				transaction("in", "checking", "2020-01-06", -100),
+			},
+			want: [][2]string{{"out-earlier", "in"}},
+		},
+		{
+			name: "amounts match to the cent",
+			transactions: []db.Transaction{
+				transaction("out-sum", "checking", "2020-01-05", 0.1+0.2),
+				transaction("in-sum", "savings", "2020-01-05", -0.3),
+				transaction("out-off", "checking", "2020-01-05", 100),
+				transaction("in-off", "savings", "2020-01-05", -100.01),
+			},
+			want: [][2]string{{"out-sum", "in-sum"}},
+		},
+		{
+			name: "outside the window",
+			transactions: []db.Transaction{
+				transaction("out", "checking", "2020-01-05", 100),
+				transaction("in", "savings", "2020-01-09", -100),
+			},
+			want: [][2]string{},
+		},
+		{
+			name: "not transfers",
+			transactions: []db.Transaction{
+				transaction("out", "checking", "2020-01-05", 20),
+				//the same account
+				transaction("in-same", "checking", "2020-01-05", -20),
+				//accounts money doesn't move between
+				transaction("out-card", "card", "2020-01-05", 30),
+				transaction("in-card", "other-card", "2020-01-05", -30),
+				transaction("out-mortgage", "mortgage", "2020-01-05", 50),
+				transaction("in-brokerage", "brokerage", "2020-01-05", -50),
+				//an account that isn't the user's
+				transaction("in-unknown", "unknown", "2020-01-05", -20),
+				//unusable transactions
+				transaction("out-pending", "checking", "2020-01-05", 40),
+				pending,
+				transaction("out-euros", "checking", "2020-01-05", 60),
+				euros,
+				transaction("out-no-amount", "checking", "2020-01-05", 70),
+				noAmount,
+				transaction("out-bad-date", "checking", "January 5th", 80),
+				transaction("in-bad-date", "savings", "2020-01-05", -80),
+			},
+			want: [][2]string{},
+		},
+		{
+			name: "already paired",
+			transactions: []db.Transaction{
+				transaction("out", "checking", "2020-01-05", 100),
+				transaction("in", "savings", "2020-01-05", -100),
+				transaction("in-2", "brokerage", "2020-01-05", -100),
+			},
+			existing: []db.Transfer{
+				{OutflowTransactionUUID: "out-0", InflowTransactionUUID: "in", Status: db.TransferStatusConfirmed},
+				{OutflowTransactionUUID: "out-1", InflowTransactionUUID: "in-2", Status: db.TransferStatusSuggested},
+			},
+			want: [][2]string{},
+		},
+		{
+			name: "rejected before",
+			transactions: []db.Transaction{
+				transaction("out", "checking", "2020-01-05", 100),
+				transaction("in", "savings", "2020-01-05", -100),
+				transaction("in-2", "brokerage", "2020-01-07", -100),
+			},
+			existing: []db.Transfer{
+				{OutflowTransactionUUID: "out", InflowTransactionUUID: "in", Status: db.TransferStatusRejected},
+			},
+			want: [][2]string{{"out", "in-2"}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			matched := transfers.NewMatcher(3).Match(testAccounts, tc.transactions, tc.existing)
+			if got := pairs(matched); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("matched %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
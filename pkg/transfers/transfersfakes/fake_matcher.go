@@ -0,0 +1,131 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package transfersfakes
+
+import (
+	"sync"
+
+	"github.com/xanderflood/plaid-ui/pkg/db"
+	"github.com/xanderflood/plaid-ui/pkg/transfers"
+)
+
+type FakeMatcher struct {
+	MatchStub        func([]db.Account, []db.Transaction, []db.Transfer) []transfers.Pair
+	matchMutex       sync.RWMutex
+	matchArgsForCall []struct {
+		arg1 []db.Account
+		arg2 []db.Transaction
+		arg3 []db.Transfer
+	}
+	matchReturns struct {
+		result1 []transfers.Pair
+	}
+	matchReturnsOnCall map[int]struct {
+		result1 []transfers.Pair
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeMatcher) Match(arg1 []db.Account, arg2 []db.Transaction, arg3 []db.Transfer) []transfers.Pair {
+	var arg1Copy []db.Account
+	if arg1 != nil {
+		arg1Copy = make([]db.Account, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	var arg2Copy []db.Transaction
+	if arg2 != nil {
+		arg2Copy = make([]db.Transaction, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	var arg3Copy []db.Transfer
+	if arg3 != nil {
+		arg3Copy = make([]db.Transfer, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.matchMutex.Lock()
+	ret, specificReturn := fake.matchReturnsOnCall[len(fake.matchArgsForCall)]
+	fake.matchArgsForCall = append(fake.matchArgsForCall, struct {
+		arg1 []db.Account
+		arg2 []db.Transaction
+		arg3 []db.Transfer
+	}{arg1Copy, arg2Copy, arg3Copy})
+	stub := fake.MatchStub
+	fakeReturns := fake.matchReturns
+	fake.recordInvocation("Match", []interface{}{arg1Copy, arg2Copy, arg3Copy})
+	fake.matchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeMatcher) MatchCallCount() int {
+	fake.matchMutex.RLock()
+	defer fake.matchMutex.RUnlock()
+	return len(fake.matchArgsForCall)
+}
+
+func (fake *FakeMatcher) MatchCalls(stub func([]db.Account, []db.Transaction, []db.Transfer) []transfers.Pair) {
+	fake.matchMutex.Lock()
+	defer fake.matchMutex.Unlock()
+	fake.MatchStub = stub
+}
+
+func (fake *FakeMatcher) MatchArgsForCall(i int) ([]db.Account, []db.Transaction, []db.Transfer) {
+	fake.matchMutex.RLock()
+	defer fake.matchMutex.RUnlock()
+	argsForCall := fake.matchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeMatcher) MatchReturns(result1 []transfers.Pair) {
+	fake.matchMutex.Lock()
+	defer fake.matchMutex.Unlock()
+	fake.MatchStub = nil
+	fake.matchReturns = struct {
+		result1 []transfers.Pair
+	}{result1}
+}
+
+func (fake *FakeMatcher) MatchReturnsOnCall(i int, result1 []transfers.Pair) {
+	fake.matchMutex.Lock()
+	defer fake.matchMutex.Unlock()
+	fake.MatchStub = nil
+	if fake.matchReturnsOnCall == nil {
+		fake.matchReturnsOnCall = make(map[int]struct {
+			result1 []transfers.Pair
+		})
+	}
+	fake.matchReturnsOnCall[i] = struct {
+		result1 []transfers.Pair
+	}{result1}
+}
+
+func (fake *FakeMatcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.matchMutex.RLock()
+	defer fake.matchMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeMatcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ transfers.Matcher = new(FakeMatcher)
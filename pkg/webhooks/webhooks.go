@@ -0,0 +1,270 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/xanderflood/plaid-ui/lib/tools"
+	"github.com/xanderflood/plaid-ui/pkg/db"
+)
+
+//Event types sent to user webhook endpoints
+const (
+	EventTransactionCreated = "transaction.created"
+	EventTransactionUpdated = "transaction.updated"
+	EventTransactionRemoved = "transaction.removed"
+	EventItemError          = "item.error"
+	EventBalanceUpdated     = "balance.updated"
+)
+
+//EventTypes lists every event type, in the order they're documented
+var EventTypes = []string{
+	EventTransactionCreated,
+	EventTransactionUpdated,
+	EventTransactionRemoved,
+	EventItemError,
+	EventBalanceUpdated,
+}
+
+//Headers set on each delivery. The signature header holds the Unix
+//timestamp and the hex HMAC-SHA256 of "<timestamp>.<body>", keyed with
+//the endpoint's secret, as `t=<timestamp>,v1=<signature>`.
+const (
+	SignatureHeader = "X-Plaid-UI-Signature"
+	EventHeader     = "X-Plaid-UI-Event"
+	DeliveryHeader  = "X-Plaid-UI-Delivery"
+)
+
+const (
+	//MaxAttempts is how many times a delivery is tried before giving up
+	MaxAttempts = 8
+
+	baseBackoff = 30 * time.Second
+	maxBackoff  = 6 * time.Hour
+
+	claimBatchSize = 50
+	claimLease     = 2 * time.Minute
+	pollInterval   = 30 * time.Second
+)
+
+//Event is the body of every delivery
+type Event struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+//Dispatcher queues events for a user's webhook endpoints and delivers
+//them in the background, retrying failures with exponential backoff
+//go:generate counterfeiter . Dispatcher
+type Dispatcher interface {
+	Emit(ctx context.Context, userUUID string, eventType string, data interface{}) error
+	Wake()
+	Run(ctx context.Context)
+}
+
+//DispatcherAgent implements Dispatcher
+type DispatcherAgent struct {
+	logger   tools.Logger
+	dbClient db.DB
+	client   *http.Client
+	wake     chan struct{}
+}
+
+//NewDispatcher creates a new DispatcherAgent
+func NewDispatcher(logger tools.Logger, dbClient db.DB, client *http.Client) DispatcherAgent {
+	return DispatcherAgent{
+		logger:   logger,
+		dbClient: dbClient,
+		client:   client,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+//Emit queues an event for each of the user's endpoints that subscribe
+//to its type. Queued deliveries are durable, so they survive restarts.
+func (d DispatcherAgent) Emit(ctx context.Context, userUUID string, eventType string, data interface{}) error {
+	endpoints, err := d.dbClient.GetWebhookEndpoints(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+
+	queued := false
+	for _, endpoint := range endpoints {
+		if !endpoint.Subscribes(eventType) {
+			continue
+		}
+
+		id, err := NewSecret()
+		if err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(Event{
+			ID:        "evt_" + id[:24],
+			Type:      eventType,
+			CreatedAt: time.Now().UTC(),
+			Data:      data,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode %s event", eventType)
+		}
+
+		_, err = d.dbClient.CreateWebhookDelivery(ctx, db.WebhookDelivery{
+			EndpointUUID: endpoint.UUID,
+			UserUUID:     userUUID,
+			EventType:    eventType,
+			Payload:      string(payload),
+		})
+		if err != nil {
+			return err
+		}
+		queued = true
+	}
+
+	if queued {
+		d.Wake()
+	}
+	return nil
+}
+
+//Wake makes Run check for due deliveries right away
+func (d DispatcherAgent) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+//Run delivers due events until the context is cancelled. It's safe to
+//run on several replicas at once, since each claims its own deliveries.
+func (d DispatcherAgent) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		d.deliverDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+func (d DispatcherAgent) deliverDue(ctx context.Context) {
+	for {
+		deliveries, err := d.dbClient.ClaimDueWebhookDeliveries(ctx, claimBatchSize, claimLease)
+		if err != nil {
+			d.logger.Errorf("failed claiming webhook deliveries: %s", err.Error())
+			return
+		}
+
+		for _, delivery := range deliveries {
+			if err := d.deliver(ctx, delivery); err != nil {
+				d.logger.Errorf("failed recording webhook delivery `%s`: %s", delivery.UUID, err.Error())
+			}
+		}
+
+		if len(deliveries) < claimBatchSize {
+			return
+		}
+	}
+}
+
+func (d DispatcherAgent) deliver(ctx context.Context, delivery db.WebhookDelivery) error {
+	endpoint, err := d.dbClient.GetWebhookEndpoint(ctx, delivery.EndpointUUID)
+	if err == db.ErrNoSuchWebhookEndpoint {
+		return d.dbClient.RecordWebhookAttempt(ctx, delivery.UUID, db.WebhookDeliveryStatusFailed, 0, "endpoint was deleted", nil)
+	}
+	if err != nil {
+		return err
+	}
+
+	statusCode, err := d.send(ctx, endpoint, delivery)
+	if err == nil {
+		return d.dbClient.RecordWebhookAttempt(ctx, delivery.UUID, db.WebhookDeliveryStatusSucceeded, statusCode, "", nil)
+	}
+
+	attempts := delivery.Attempts + 1
+	if attempts >= MaxAttempts {
+		return d.dbClient.RecordWebhookAttempt(ctx, delivery.UUID, db.WebhookDeliveryStatusFailed, statusCode, err.Error(), nil)
+	}
+
+	next := time.Now().Add(Backoff(attempts))
+	return d.dbClient.RecordWebhookAttempt(ctx, delivery.UUID, db.WebhookDeliveryStatusPending, statusCode, err.Error(), &next)
+}
+
+func (d DispatcherAgent) send(ctx context.Context, endpoint db.WebhookEndpoint, delivery db.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	req, err := http.NewRequest(http.MethodPost, endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to build request")
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(DeliveryHeader, delivery.UUID)
+	req.Header.Set(SignatureHeader, fmt.Sprintf("t=%d,v1=%s", timestamp, Sign(endpoint.Secret, timestamp, body)))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, io.LimitReader(resp.Body, 64<<10)) //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, errors.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+//Sign computes the signature of a delivery body sent at a given time
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10))) //nolint:errcheck
+	mac.Write([]byte("."))                              //nolint:errcheck
+	mac.Write(body)                                     //nolint:errcheck
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+//Backoff is how long to wait before retrying after a given number of
+//failed attempts
+func Backoff(attempts int) time.Duration {
+	backoff := baseBackoff
+	for i := 1; i < attempts && backoff < maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	return backoff
+}
+
+//NewSecret generates a random hex string for signing deliveries
+func NewSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "failed to generate secret")
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,187 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package webhooksfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/xanderflood/plaid-ui/pkg/webhooks"
+)
+
+type FakeDispatcher struct {
+	EmitStub        func(context.Context, string, string, interface{}) error
+	emitMutex       sync.RWMutex
+	emitArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 interface{}
+	}
+	emitReturns struct {
+		result1 error
+	}
+	emitReturnsOnCall map[int]struct {
+		result1 error
+	}
+	RunStub        func(context.Context)
+	runMutex       sync.RWMutex
+	runArgsForCall []struct {
+		arg1 context.Context
+	}
+	WakeStub        func()
+	wakeMutex       sync.RWMutex
+	wakeArgsForCall []struct {
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDispatcher) Emit(arg1 context.Context, arg2 string, arg3 string, arg4 interface{}) error {
+	fake.emitMutex.Lock()
+	ret, specificReturn := fake.emitReturnsOnCall[len(fake.emitArgsForCall)]
+	fake.emitArgsForCall = append(fake.emitArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 interface{}
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.EmitStub
+	fakeReturns := fake.emitReturns
+	fake.recordInvocation("Emit", []interface{}{arg1, arg2, arg3, arg4})
+	fake.emitMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeDispatcher) EmitCallCount() int {
+	fake.emitMutex.RLock()
+	defer fake.emitMutex.RUnlock()
+	return len(fake.emitArgsForCall)
+}
+
+func (fake *FakeDispatcher) EmitCalls(stub func(context.Context, string, string, interface{}) error) {
+	fake.emitMutex.Lock()
+	defer fake.emitMutex.Unlock()
+	fake.EmitStub = stub
+}
+
+func (fake *FakeDispatcher) EmitArgsForCall(i int) (context.Context, string, string, interface{}) {
+	fake.emitMutex.RLock()
+	defer fake.emitMutex.RUnlock()
+	argsForCall := fake.emitArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeDispatcher) EmitReturns(result1 error) {
+	fake.emitMutex.Lock()
+	defer fake.emitMutex.Unlock()
+	fake.EmitStub = nil
+	fake.emitReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDispatcher) EmitReturnsOnCall(i int, result1 error) {
+	fake.emitMutex.Lock()
+	defer fake.emitMutex.Unlock()
+	fake.EmitStub = nil
+	if fake.emitReturnsOnCall == nil {
+		fake.emitReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.emitReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDispatcher) Run(arg1 context.Context) {
+	fake.runMutex.Lock()
+	fake.runArgsForCall = append(fake.runArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.RunStub
+	fake.recordInvocation("Run", []interface{}{arg1})
+	fake.runMutex.Unlock()
+	if stub != nil {
+		fake.RunStub(arg1)
+	}
+}
+
+func (fake *FakeDispatcher) RunCallCount() int {
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	return len(fake.runArgsForCall)
+}
+
+func (fake *FakeDispatcher) RunCalls(stub func(context.Context)) {
+	fake.runMutex.Lock()
+	defer fake.runMutex.Unlock()
+	fake.RunStub = stub
+}
+
+func (fake *FakeDispatcher) RunArgsForCall(i int) context.Context {
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	argsForCall := fake.runArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeDispatcher) Wake() {
+	fake.wakeMutex.Lock()
+	fake.wakeArgsForCall = append(fake.wakeArgsForCall, struct {
+	}{})
+	stub := fake.WakeStub
+	fake.recordInvocation("Wake", []interface{}{})
+	fake.wakeMutex.Unlock()
+	if stub != nil {
+		fake.WakeStub()
+	}
+}
+
+func (fake *FakeDispatcher) WakeCallCount() int {
+	fake.wakeMutex.RLock()
+	defer fake.wakeMutex.RUnlock()
+	return len(fake.wakeArgsForCall)
+}
+
+func (fake *FakeDispatcher) WakeCalls(stub func()) {
+	fake.wakeMutex.Lock()
+	defer fake.wakeMutex.Unlock()
+	fake.WakeStub = stub
+}
+
+func (fake *FakeDispatcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.emitMutex.RLock()
+	defer fake.emitMutex.RUnlock()
+	fake.runMutex.RLock()
+	defer fake.runMutex.RUnlock()
+	fake.wakeMutex.RLock()
+	defer fake.wakeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeDispatcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ webhooks.Dispatcher = new(FakeDispatcher)